@@ -8,15 +8,26 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/pipekit/mcp-for-argo-workflows/internal/config"
+	"github.com/pipekit/mcp-for-argo-workflows/internal/metrics"
 	"github.com/pipekit/mcp-for-argo-workflows/internal/server"
+	"github.com/pipekit/mcp-for-argo-workflows/internal/tracing"
 	"github.com/pipekit/mcp-for-argo-workflows/internal/version"
 	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/bundle"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/snapshot"
 )
 
 const serverName = "mcp-for-argo-workflows"
 
+// startupCheckTimeout bounds the connectivity and RBAC probe run at startup.
+const startupCheckTimeout = 15 * time.Second
+
+// tracingShutdownTimeout bounds flushing pending spans on exit.
+const tracingShutdownTimeout = 5 * time.Second
+
 func main() {
 	// Configure structured logging to stderr
 	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
@@ -47,16 +58,39 @@ func run(ctx context.Context) error {
 	}
 
 	// Create the Argo Workflows client with the root context
-	argoClient, err := argo.NewClient(ctx, cfg.ToArgoConfig())
+	rawClient, err := newArgoClient(ctx, cfg)
 	if err != nil {
-		return fmt.Errorf("failed to create Argo client: %w", err)
+		return err
 	}
 
+	// Record the latency of every Argo API call made by tools and resources
+	serverMetrics := metrics.New()
+	var argoClient argo.ClientInterface = argo.NewObservedClient(rawClient, serverMetrics)
+
+	// Trace MCP requests and the Argo API calls they make when an OTLP endpoint is configured
+	tracer, stopTracing, err := setupTracing(ctx, cfg.OTLPEndpoint)
+	if err != nil {
+		return err
+	}
+	defer stopTracing()
+	if tracer != nil {
+		argoClient = argo.NewObservedClient(argoClient, tracer)
+	}
+
+	// Serve workflows loaded with import_workflow_bundle beside the cluster, in bundle:<namespace> namespaces
+	argoClient = bundle.NewClient(argoClient, cfg.ExportDir)
+
 	// Use the client's context which contains K8s auth metadata for all subsequent operations.
 	// The Argo SDK embeds the K8s client in this context, which is required for authorization checks.
 	//nolint:contextcheck // Intentionally replacing context with Argo SDK's context containing K8s client
 	ctx = argoClient.Context()
 
+	// Verify the Argo API is reachable and the credentials grant the key verbs,
+	// so a wrong context or expired token surfaces now rather than on first use
+	if checkErr := runStartupCheck(ctx, argoClient, cfg.StartupCheck); checkErr != nil {
+		return checkErr
+	}
+
 	// Create the MCP server with name and version
 	srv := server.NewServer(serverName, version.Version)
 
@@ -72,6 +106,17 @@ func run(ctx context.Context) error {
 	// Register MCP prompts
 	srv.RegisterPrompts(argoClient)
 
+	// Report Argo connectivity on the HTTP readiness endpoint
+	srv.RegisterHealthChecks(argoClient)
+
+	// Record tool call metrics, served at /metrics in HTTP mode or on --metrics-addr
+	srv.RegisterMetrics(serverMetrics, cfg.MetricsAddr)
+
+	// Trace every MCP request; registered after metrics so its span covers them
+	if tracer != nil {
+		srv.RegisterTracing(tracer)
+	}
+
 	slog.Info("MCP server created",
 		"name", serverName,
 		"version", version.Version,
@@ -89,3 +134,80 @@ func run(ctx context.Context) error {
 	// Default to stdio transport
 	return srv.RunStdio(ctx) //nolint:contextcheck // ctx is Argo SDK context with K8s client
 }
+
+// newArgoClient connects to the configured cluster, or loads the configured
+// snapshot directory to serve it offline.
+func newArgoClient(ctx context.Context, cfg *config.Config) (argo.ClientInterface, error) {
+	if cfg.IsSnapshotMode() {
+		snap, err := snapshot.Load(cfg.SnapshotDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load snapshot: %w", err)
+		}
+		slog.Info("serving snapshot",
+			"dir", cfg.SnapshotDir,
+			"workflows", len(snap.Workflows),
+			"workflowTemplates", len(snap.WorkflowTemplates),
+			"clusterWorkflowTemplates", len(snap.ClusterWorkflowTemplates),
+			"cronWorkflows", len(snap.CronWorkflows),
+		)
+		return snapshot.NewClient(ctx, snap, cfg.Namespace), nil
+	}
+
+	client, err := argo.NewClient(ctx, cfg.ToArgoConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Argo client: %w", err)
+	}
+	return client, nil
+}
+
+// setupTracing creates the OTLP trace exporter when endpoint is set and returns
+// the tracer together with a function that flushes pending spans. The tracer is
+// nil when tracing is disabled.
+func setupTracing(ctx context.Context, endpoint string) (*tracing.Tracer, func(), error) {
+	if endpoint == "" {
+		return nil, func() {}, nil
+	}
+
+	provider, err := tracing.NewProvider(ctx, endpoint, serverName, version.Version)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to set up tracing: %w", err)
+	}
+	slog.Info("exporting traces", "endpoint", endpoint)
+
+	stop := func() {
+		//nolint:contextcheck // Use fresh context to flush spans after cancellation
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+		defer cancel()
+		if shutdownErr := provider.Shutdown(shutdownCtx); shutdownErr != nil {
+			slog.Warn("failed to flush traces", "error", shutdownErr)
+		}
+	}
+	return tracing.New(provider), stop, nil
+}
+
+// runStartupCheck probes Argo connectivity and RBAC according to the configured
+// mode. Failed checks are logged; in fail mode they also abort startup.
+func runStartupCheck(ctx context.Context, client argo.ClientInterface, mode string) error {
+	if mode == config.StartupCheckOff {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, startupCheckTimeout)
+	defer cancel()
+
+	report := argo.ProbeConnection(ctx, client)
+	for _, check := range report.Failed() {
+		slog.Warn("startup check failed", "check", check.Name, "namespace", report.Namespace, "error", check.Error)
+	}
+
+	if err := report.Err(); err != nil {
+		if mode == config.StartupCheckFail {
+			return err
+		}
+		slog.Warn("continuing despite failed startup checks (use --startup-check=fail to exit instead)")
+		return nil
+	}
+
+	slog.Info("startup checks passed", "mode", report.Mode, "namespace", report.Namespace, "checks", len(report.Checks))
+	return nil
+}
@@ -19,17 +19,48 @@ const (
 	TransportHTTP  = "http"
 )
 
+// Startup check modes.
+const (
+	StartupCheckWarn = "warn" // Log failed checks and continue
+	StartupCheckFail = "fail" // Exit if any check fails
+	StartupCheckOff  = "off"  // Skip the startup check
+)
+
 // Config holds the combined configuration for the MCP server.
 type Config struct {
 	// Server settings
 	Transport string // "stdio" or "http"
 	HTTPAddr  string // HTTP listen address (e.g., ":8080")
 
+	// MetricsAddr is a separate listen address for /metrics. In HTTP mode
+	// metrics are served on HTTPAddr when empty; in stdio mode they are
+	// disabled when empty.
+	MetricsAddr string
+
+	// OTLPEndpoint is the OTLP/HTTP collector URL traces are exported to,
+	// e.g. "http://otel-collector:4318". Tracing is disabled when empty.
+	OTLPEndpoint string
+
+	// ExportDir is the directory export_workflow writes bundles to and
+	// import_workflow_bundle reads them from. Bundles are returned inline when empty.
+	ExportDir string
+
+	// SnapshotDir is a directory of exported manifests, logs and bundles that
+	// is served instead of a cluster. The Argo connection settings are ignored when set.
+	SnapshotDir string
+
+	// StartupCheck controls the connectivity and RBAC probe run at startup:
+	// "warn", "fail", or "off"
+	StartupCheck string
+
 	// Argo connection settings
 	ArgoServer string // Argo Server host:port (empty = direct K8s)
 	ArgoToken  string // Bearer token for Argo Server auth
 	Namespace  string // Default namespace for operations
 
+	// ControllerNamespace is where the workflow controller runs (direct K8s mode)
+	ControllerNamespace string
+
 	// Kubernetes settings (when not using Argo Server)
 	Kubeconfig string // Path to kubeconfig file
 	Context    string // Kubernetes context to use
@@ -45,10 +76,12 @@ type Config struct {
 // DefaultConfig returns a Config with default values.
 func DefaultConfig() *Config {
 	return &Config{
-		Transport: TransportStdio,
-		HTTPAddr:  ":8080",
-		Namespace: "default",
-		Secure:    true,
+		Transport:           TransportStdio,
+		HTTPAddr:            ":8080",
+		StartupCheck:        StartupCheckWarn,
+		Namespace:           "default",
+		ControllerNamespace: "argo",
+		Secure:              true,
 	}
 }
 
@@ -63,6 +96,13 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("http-addr is required when using HTTP transport")
 	}
 
+	switch c.StartupCheck {
+	case StartupCheckWarn, StartupCheckFail, StartupCheckOff:
+	default:
+		return fmt.Errorf("invalid startup-check %q, must be %q, %q or %q",
+			c.StartupCheck, StartupCheckWarn, StartupCheckFail, StartupCheckOff)
+	}
+
 	return nil
 }
 
@@ -74,9 +114,15 @@ func NewFromFlags() (*Config, error) {
 	// Define CLI flags
 	pflag.StringVar(&cfg.Transport, "transport", cfg.Transport, "MCP transport mode: stdio or http")
 	pflag.StringVar(&cfg.HTTPAddr, "http-addr", cfg.HTTPAddr, "HTTP listen address")
+	pflag.StringVar(&cfg.MetricsAddr, "metrics-addr", cfg.MetricsAddr, "Separate listen address for Prometheus /metrics (required for metrics in stdio mode)")
+	pflag.StringVar(&cfg.OTLPEndpoint, "otlp-endpoint", cfg.OTLPEndpoint, "OTLP/HTTP collector URL for OpenTelemetry traces (empty = tracing disabled)")
+	pflag.StringVar(&cfg.ExportDir, "export-dir", cfg.ExportDir, "Directory workflow export bundles are written to and imported from (empty = return bundles inline)")
+	pflag.StringVar(&cfg.SnapshotDir, "snapshot-dir", cfg.SnapshotDir, "Directory of exported workflows, templates, cron workflows and logs to serve offline instead of a cluster")
+	pflag.StringVar(&cfg.StartupCheck, "startup-check", cfg.StartupCheck, "Startup connectivity and RBAC check: warn, fail, or off")
 	pflag.StringVar(&cfg.ArgoServer, "argo-server", cfg.ArgoServer, "Argo Server host:port (empty = direct K8s)")
 	pflag.StringVar(&cfg.ArgoToken, "argo-token", cfg.ArgoToken, "Bearer token for Argo Server auth")
 	pflag.StringVar(&cfg.Namespace, "namespace", cfg.Namespace, "Default namespace for operations")
+	pflag.StringVar(&cfg.ControllerNamespace, "controller-namespace", cfg.ControllerNamespace, "Namespace of the workflow controller (direct K8s mode)")
 	pflag.BoolVar(&cfg.Secure, "argo-secure", cfg.Secure, "Use TLS when connecting to Argo Server")
 	pflag.BoolVar(&cfg.InsecureSkipVerify, "argo-insecure-skip-verify", cfg.InsecureSkipVerify, "Skip TLS certificate verification")
 	pflag.BoolVar(&cfg.HTTP1, "argo-http1", cfg.HTTP1, "Use HTTP/1.1 (REST) instead of gRPC for Argo Server")
@@ -140,9 +186,15 @@ func applyEnvOverridesWithFlagSet(fs *pflag.FlagSet, cfg *Config) {
 	}
 
 	cfg.HTTPAddr = getEnvIfNotSet(fs, "http-addr", "MCP_HTTP_ADDR", cfg.HTTPAddr)
+	cfg.MetricsAddr = getEnvIfNotSet(fs, "metrics-addr", "MCP_METRICS_ADDR", cfg.MetricsAddr)
+	cfg.OTLPEndpoint = getEnvIfNotSet(fs, "otlp-endpoint", "MCP_OTLP_ENDPOINT", cfg.OTLPEndpoint)
+	cfg.ExportDir = getEnvIfNotSet(fs, "export-dir", "MCP_EXPORT_DIR", cfg.ExportDir)
+	cfg.SnapshotDir = getEnvIfNotSet(fs, "snapshot-dir", "MCP_SNAPSHOT_DIR", cfg.SnapshotDir)
+	cfg.StartupCheck = getEnvIfNotSet(fs, "startup-check", "MCP_STARTUP_CHECK", cfg.StartupCheck)
 	cfg.ArgoServer = getEnvIfNotSet(fs, "argo-server", "ARGO_SERVER", cfg.ArgoServer)
 	cfg.ArgoToken = getEnvIfNotSet(fs, "argo-token", "ARGO_TOKEN", cfg.ArgoToken)
 	cfg.Namespace = getEnvIfNotSet(fs, "namespace", "ARGO_NAMESPACE", cfg.Namespace)
+	cfg.ControllerNamespace = getEnvIfNotSet(fs, "controller-namespace", "ARGO_CONTROLLER_NAMESPACE", cfg.ControllerNamespace)
 	cfg.Kubeconfig = getEnvIfNotSet(fs, "kubeconfig", "KUBECONFIG", cfg.Kubeconfig)
 
 	cfg.Secure = getEnvBoolIfNotSet(fs, "argo-secure", "ARGO_SECURE", cfg.Secure)
@@ -156,17 +208,23 @@ func applyEnvOverridesWithFlagSet(fs *pflag.FlagSet, cfg *Config) {
 // ToArgoConfig converts the Config to an argo.Config for creating the Argo client.
 func (c *Config) ToArgoConfig() *argo.Config {
 	return &argo.Config{
-		ArgoServer:         c.ArgoServer,
-		ArgoToken:          c.ArgoToken,
-		Namespace:          c.Namespace,
-		Kubeconfig:         c.Kubeconfig,
-		Context:            c.Context,
-		Secure:             c.Secure,
-		InsecureSkipVerify: c.InsecureSkipVerify,
-		HTTP1:              c.HTTP1,
+		ArgoServer:          c.ArgoServer,
+		ArgoToken:           c.ArgoToken,
+		Namespace:           c.Namespace,
+		ControllerNamespace: c.ControllerNamespace,
+		Kubeconfig:          c.Kubeconfig,
+		Context:             c.Context,
+		Secure:              c.Secure,
+		InsecureSkipVerify:  c.InsecureSkipVerify,
+		HTTP1:               c.HTTP1,
 	}
 }
 
+// IsSnapshotMode returns true if a snapshot directory is served instead of a cluster.
+func (c *Config) IsSnapshotMode() bool {
+	return c.SnapshotDir != ""
+}
+
 // IsHTTPTransport returns true if the HTTP transport mode is configured.
 func (c *Config) IsHTTPTransport() bool {
 	return c.Transport == TransportHTTP
@@ -0,0 +1,212 @@
+// Package metrics provides Prometheus instrumentation for the MCP server.
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
+)
+
+// namespace prefixes every metric exposed by the server.
+const namespace = "mcp"
+
+// Error classes used for the class label of mcp_tool_errors_total.
+const (
+	ClassNotFound        = "not_found"
+	ClassForbidden       = "forbidden"
+	ClassConflict        = "conflict"
+	ClassInvalid         = "invalid"
+	ClassUnavailable     = "unavailable"
+	ClassUnsupportedMode = "unsupported_mode"
+	ClassProtocol        = "protocol"
+	ClassInternal        = "internal"
+)
+
+// Metrics holds the Prometheus collectors for tool calls, Argo API calls,
+// sessions, watches and log streaming.
+type Metrics struct {
+	registry      *prometheus.Registry
+	toolCalls     *prometheus.CounterVec
+	toolErrors    *prometheus.CounterVec
+	toolDuration  *prometheus.HistogramVec
+	argoDuration  *prometheus.HistogramVec
+	argoStreams   *prometheus.GaugeVec
+	argoLogBytes  prometheus.Counter
+	toolsInFlight prometheus.Gauge
+}
+
+// Ensure Metrics can observe Argo API calls.
+var _ argo.CallObserver = (*Metrics)(nil)
+
+// New creates the metrics collectors on a dedicated registry, together with
+// the standard Go runtime and process collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		toolCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tool_calls_total",
+			Help:      "Total number of MCP tool calls by tool.",
+		}, []string{"tool"}),
+		toolErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tool_errors_total",
+			Help:      "Total number of failed MCP tool calls by tool and error class.",
+		}, []string{"tool", "class"}),
+		toolDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "tool_call_duration_seconds",
+			Help:      "Duration of MCP tool calls by tool.",
+			Buckets:   []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300},
+		}, []string{"tool"}),
+		toolsInFlight: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "tool_calls_in_flight",
+			Help:      "Number of MCP tool calls currently executing.",
+		}),
+		argoDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "argo_api_request_duration_seconds",
+			Help:      "Duration of Argo API calls by service, method and result.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"service", "method", "result"}),
+		argoStreams: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "argo_watches_in_flight",
+			Help:      "Number of open Argo watch and log streams by method.",
+		}, []string{"method"}),
+		argoLogBytes: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "argo_log_bytes_total",
+			Help:      "Total bytes of workflow and pod logs streamed from Argo.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		m.toolCalls,
+		m.toolErrors,
+		m.toolDuration,
+		m.toolsInFlight,
+		m.argoDuration,
+		m.argoStreams,
+		m.argoLogBytes,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	return m
+}
+
+// Handler returns the HTTP handler serving the metrics in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+// RegisterSessions exposes the number of connected MCP sessions of s, which
+// in HTTP mode is the number of active SSE sessions.
+func (m *Metrics) RegisterSessions(s *mcp.Server) {
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "sessions_active",
+		Help:      "Number of connected MCP sessions (SSE sessions in HTTP mode).",
+	}, func() float64 {
+		count := 0
+		for range s.Sessions() {
+			count++
+		}
+		return float64(count)
+	}))
+}
+
+// Middleware returns MCP receiving middleware that records tool call counts,
+// errors and latencies.
+func (m *Metrics) Middleware() mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			callReq, ok := req.(*mcp.CallToolRequest)
+			if !ok || callReq.Params == nil {
+				return next(ctx, method, req)
+			}
+
+			tool := callReq.Params.Name
+			m.toolsInFlight.Inc()
+			start := time.Now()
+
+			result, err := next(ctx, method, req)
+
+			m.toolsInFlight.Dec()
+			m.toolDuration.WithLabelValues(tool).Observe(time.Since(start).Seconds())
+			m.toolCalls.WithLabelValues(tool).Inc()
+			if class := toolErrorClass(result, err); class != "" {
+				m.toolErrors.WithLabelValues(tool, class).Inc()
+			}
+
+			return result, err
+		}
+	}
+}
+
+// StartCall implements argo.CallObserver by timing the call.
+func (m *Metrics) StartCall(ctx context.Context, service, method string) (context.Context, func(error)) {
+	start := time.Now()
+	return ctx, func(err error) {
+		result := "success"
+		if err != nil {
+			result = "error"
+		}
+		m.argoDuration.WithLabelValues(service, method, result).Observe(time.Since(start).Seconds())
+	}
+}
+
+// StartStream implements argo.CallObserver by tracking open streams.
+func (m *Metrics) StartStream(_, method string) func() {
+	gauge := m.argoStreams.WithLabelValues(method)
+	gauge.Inc()
+	return gauge.Dec
+}
+
+// LogBytes implements argo.CallObserver by counting streamed log bytes.
+func (m *Metrics) LogBytes(n int) {
+	m.argoLogBytes.Add(float64(n))
+}
+
+// toolErrorClass returns the error class of a tool call, or "" if it succeeded.
+// Protocol errors come back as err; tool failures come back as a result with
+// IsError set, classified by the toolerror middleware when it ran, otherwise
+// carrying the error message as its text content.
+func toolErrorClass(result mcp.Result, err error) string {
+	if err != nil {
+		return ClassProtocol
+	}
+
+	callResult, ok := result.(*mcp.CallToolResult)
+	if !ok || !callResult.IsError {
+		return ""
+	}
+	if detail, classified := toolerror.FromResult(callResult); classified {
+		return string(detail.Category)
+	}
+
+	var message strings.Builder
+	for _, content := range callResult.Content {
+		if text, isText := content.(*mcp.TextContent); isText {
+			message.WriteString(text.Text)
+		}
+	}
+	return ClassifyError(message.String())
+}
+
+// ClassifyError maps an error message from the Argo Server or Kubernetes API to
+// an error class, based on the gRPC status codes and Kubernetes reasons they carry.
+func ClassifyError(message string) string {
+	return string(toolerror.ClassifyMessage(message))
+}
@@ -0,0 +1,146 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
+)
+
+type echoInput struct {
+	Fail string `json:"fail,omitempty"`
+}
+
+type echoOutput struct {
+	OK bool `json:"ok"`
+}
+
+// connectTestServer serves a single "echo" tool with the metrics middleware
+// installed and returns a connected client session.
+func connectTestServer(t *testing.T, m *Metrics) *mcp.ClientSession {
+	t.Helper()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, nil)
+	server.AddReceivingMiddleware(m.Middleware())
+	m.RegisterSessions(server)
+	mcp.AddTool(server, &mcp.Tool{Name: "echo"}, func(_ context.Context, _ *mcp.CallToolRequest, input echoInput) (*mcp.CallToolResult, *echoOutput, error) {
+		if input.Fail != "" {
+			return nil, nil, errors.New(input.Fail)
+		}
+		return nil, &echoOutput{OK: true}, nil
+	})
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	_, err := server.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+
+	return session
+}
+
+func TestMiddleware_RecordsToolCalls(t *testing.T) {
+	m := New()
+	session := connectTestServer(t, m)
+
+	_, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "echo", Arguments: map[string]any{}})
+	require.NoError(t, err)
+
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "echo",
+		Arguments: map[string]any{"fail": "rpc error: code = NotFound desc = workflow not found"},
+	})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+
+	assert.InDelta(t, 2, testutil.ToFloat64(m.toolCalls.WithLabelValues("echo")), 0)
+	assert.InDelta(t, 1, testutil.ToFloat64(m.toolErrors.WithLabelValues("echo", ClassNotFound)), 0)
+	assert.Equal(t, 1, testutil.CollectAndCount(m.toolDuration))
+	assert.InDelta(t, 0, testutil.ToFloat64(m.toolsInFlight), 0)
+}
+
+func TestMetrics_ArgoObserver(t *testing.T) {
+	m := New()
+
+	_, done := m.StartCall(t.Context(), "WorkflowService", "GetWorkflow")
+	done(nil)
+	_, done = m.StartCall(t.Context(), "WorkflowService", "GetWorkflow")
+	done(errors.New("boom"))
+
+	assert.Equal(t, 2, testutil.CollectAndCount(m.argoDuration))
+
+	end := m.StartStream("WorkflowService", "WatchWorkflows")
+	assert.InDelta(t, 1, testutil.ToFloat64(m.argoStreams.WithLabelValues("WatchWorkflows")), 0)
+	end()
+	assert.InDelta(t, 0, testutil.ToFloat64(m.argoStreams.WithLabelValues("WatchWorkflows")), 0)
+
+	m.LogBytes(10)
+	m.LogBytes(5)
+	assert.InDelta(t, 15, testutil.ToFloat64(m.argoLogBytes), 0)
+}
+
+func TestHandler_ExposesMetrics(t *testing.T) {
+	m := New()
+	connectTestServer(t, m)
+	m.LogBytes(42)
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body := rec.Body.String()
+	assert.Contains(t, body, "mcp_argo_log_bytes_total 42")
+	assert.Contains(t, body, "mcp_sessions_active 1")
+	assert.True(t, strings.Contains(body, "go_goroutines"), "Go runtime metrics should be included")
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{"rpc error: code = NotFound desc = workflows.argoproj.io \"x\" not found", ClassNotFound},
+		{"rpc error: code = PermissionDenied desc = permission denied", ClassForbidden},
+		{"workflows.argoproj.io is forbidden: User cannot list resource", ClassForbidden},
+		{"rpc error: code = AlreadyExists desc = already exists", ClassConflict},
+		{"Operation cannot be fulfilled: the object has been modified", ClassConflict},
+		{"rpc error: code = Unavailable desc = connection refused", ClassUnavailable},
+		{"context deadline exceeded", ClassUnavailable},
+		{"workflow name cannot be empty", ClassInvalid},
+		{"failed to parse manifest: yaml: line 1", ClassInvalid},
+		{"something unexpected", ClassInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want+"/"+tt.message, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyError(tt.message))
+		})
+	}
+}
+
+func TestToolErrorClass_PrefersStructuredError(t *testing.T) {
+	result := &mcp.CallToolResult{
+		IsError: true,
+		Content: []mcp.Content{&mcp.TextContent{Text: "workflow not found"}},
+		StructuredContent: &toolerror.Payload{Error: toolerror.Detail{
+			Category: toolerror.CategoryUnsupportedMode,
+			Code:     toolerror.CodeArchiveRequiresArgoServer,
+		}},
+	}
+	assert.Equal(t, ClassUnsupportedMode, toolErrorClass(result, nil))
+
+	result.StructuredContent = nil
+	assert.Equal(t, ClassNotFound, toolErrorClass(result, nil))
+}
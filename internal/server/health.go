@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// readinessTimeout bounds the Argo connectivity check behind /readyz.
+const readinessTimeout = 5 * time.Second
+
+// healthResponse is the JSON body returned by /healthz and /readyz.
+type healthResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// RegisterHealthChecks makes the /readyz endpoint of the HTTP transport report
+// live connectivity to Argo through the given client.
+func (s *Server) RegisterHealthChecks(client argo.ClientInterface) {
+	s.readiness = func() error {
+		// The client context carries the Argo SDK authentication metadata,
+		// which the probe request context does not.
+		ctx, cancel := context.WithTimeout(client.Context(), readinessTimeout)
+		defer cancel()
+		return argo.CheckReachable(ctx, client)
+	}
+}
+
+// handleHealthz reports that the server process is up and serving requests.
+func (s *Server) handleHealthz(w http.ResponseWriter, _ *http.Request) {
+	writeHealth(w, http.StatusOK, healthResponse{Status: "ok"})
+}
+
+// handleReadyz reports whether the Argo API is currently reachable.
+func (s *Server) handleReadyz(w http.ResponseWriter, _ *http.Request) {
+	if s.readiness != nil {
+		if err := s.readiness(); err != nil {
+			slog.Warn("readiness check failed", "error", err)
+			writeHealth(w, http.StatusServiceUnavailable, healthResponse{Status: "unavailable", Error: err.Error()})
+			return
+		}
+	}
+	writeHealth(w, http.StatusOK, healthResponse{Status: "ok"})
+}
+
+// writeHealth writes a health response as JSON with the given status code.
+func writeHealth(w http.ResponseWriter, code int, resp healthResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		slog.Warn("failed to write health response", "error", err)
+	}
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+)
+
+func TestHandleHealthz(t *testing.T) {
+	srv := NewServer("test-server", "1.0.0")
+	srv.readiness = func() error { return errors.New("argo down") }
+
+	rec := httptest.NewRecorder()
+	srv.handleHealthz(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	// Liveness does not depend on Argo connectivity
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, rec.Body.String())
+}
+
+func TestHandleReadyz(t *testing.T) {
+	tests := []struct {
+		listErr  error
+		name     string
+		wantBody string
+		wantCode int
+	}{
+		{
+			name:     "argo reachable",
+			wantCode: http.StatusOK,
+			wantBody: `{"status":"ok"}`,
+		},
+		{
+			name:     "argo unreachable",
+			listErr:  errors.New("connection refused"),
+			wantCode: http.StatusServiceUnavailable,
+			wantBody: `{"status":"unavailable","error":"failed to list workflows: connection refused"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wfService := &mocks.MockWorkflowServiceClient{}
+			if tt.listErr != nil {
+				wfService.On("ListWorkflows", mock.Anything, mock.Anything).Return(nil, tt.listErr)
+			} else {
+				wfService.On("ListWorkflows", mock.Anything, mock.Anything).Return(&wfv1.WorkflowList{}, nil)
+			}
+
+			client := mocks.NewMockClient("default", false)
+			client.SetWorkflowService(wfService)
+
+			srv := NewServer("test-server", "1.0.0")
+			srv.RegisterHealthChecks(client)
+
+			rec := httptest.NewRecorder()
+			srv.handleReadyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+			assert.JSONEq(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func TestRunHTTP_HealthEndpoints(t *testing.T) {
+	srv := NewServer("test-server", "1.0.0")
+	addr := getAvailableAddr(t)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- srv.RunHTTP(ctx, addr)
+	}()
+	waitForServer(t, addr, 500*time.Millisecond)
+
+	client := &http.Client{Timeout: time.Second}
+	for _, path := range []string{"/healthz", "/readyz"} {
+		resp, err := client.Get(fmt.Sprintf("http://%s%s", addr, path))
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+
+		assert.Equal(t, http.StatusOK, resp.StatusCode, path)
+		assert.JSONEq(t, `{"status":"ok"}`, string(body), path)
+	}
+
+	cancel()
+	require.NoError(t, <-errChan)
+}
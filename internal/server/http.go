@@ -19,6 +19,16 @@ func (s *Server) RunHTTP(ctx context.Context, addr string) error {
 	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	// Stop any workflow watches backing resource subscriptions on exit
+	defer s.subscriptions.Close()
+
+	// Serve metrics on a separate listener when one is configured
+	stopMetrics, metricsErr := s.startMetricsServer()
+	if metricsErr != nil {
+		return metricsErr
+	}
+	defer stopMetrics()
+
 	slog.Info("starting MCP server", "transport", "http", "addr", addr)
 
 	// Create an SSE handler that returns our MCP server for each new session
@@ -26,10 +36,24 @@ func (s *Server) RunHTTP(ctx context.Context, addr string) error {
 		return s.mcp
 	}, nil)
 
+	// Serve health probes alongside the MCP endpoint
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", s.handleHealthz)
+	mux.HandleFunc("GET /readyz", s.handleReadyz)
+	if s.metrics != nil && s.metricsAddr == "" {
+		mux.Handle("GET /metrics", s.metrics.Handler())
+	}
+	if s.tracer != nil {
+		// Continue the caller's trace in the spans of the session
+		mux.Handle("/", s.tracer.HTTPHandler(handler))
+	} else {
+		mux.Handle("/", handler)
+	}
+
 	// Create HTTP server with timeouts to prevent Slowloris attacks
 	httpServer := &http.Server{
 		Addr:              addr,
-		Handler:           handler,
+		Handler:           mux,
 		ReadHeaderTimeout: 10 * time.Second,
 	}
 
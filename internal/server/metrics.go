@@ -0,0 +1,61 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/pipekit/mcp-for-argo-workflows/internal/metrics"
+)
+
+// metricsShutdownTimeout bounds the graceful shutdown of the metrics listener.
+const metricsShutdownTimeout = 5 * time.Second
+
+// RegisterMetrics records tool calls and connected sessions in m. When addr is
+// set, metrics are served on a separate listener at addr; otherwise the HTTP
+// transport serves them at /metrics and stdio mode does not expose them.
+func (s *Server) RegisterMetrics(m *metrics.Metrics, addr string) {
+	s.mcp.AddReceivingMiddleware(m.Middleware())
+	m.RegisterSessions(s.mcp)
+	s.metrics = m
+	s.metricsAddr = addr
+}
+
+// startMetricsServer starts the separate metrics listener, if configured, and
+// returns a function that shuts it down.
+func (s *Server) startMetricsServer() (func(), error) {
+	if s.metrics == nil || s.metricsAddr == "" {
+		return func() {}, nil
+	}
+
+	listener, err := net.Listen("tcp", s.metricsAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for metrics on %s: %w", s.metricsAddr, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", s.metrics.Handler())
+	metricsServer := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		if serveErr := metricsServer.Serve(listener); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+			slog.Error("metrics server failed", "error", serveErr)
+		}
+	}()
+	slog.Info("serving metrics", "addr", listener.Addr().String(), "path", "/metrics")
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), metricsShutdownTimeout)
+		defer cancel()
+		if shutdownErr := metricsServer.Shutdown(ctx); shutdownErr != nil {
+			slog.Warn("failed to shut down metrics server", "error", shutdownErr)
+		}
+	}, nil
+}
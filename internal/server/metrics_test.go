@@ -0,0 +1,85 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pipekit/mcp-for-argo-workflows/internal/metrics"
+)
+
+// getMetrics fetches the metrics page at addr and returns its status and body.
+func getMetrics(t *testing.T, addr string) (int, string) {
+	t.Helper()
+	client := &http.Client{Timeout: time.Second}
+	resp, err := client.Get(fmt.Sprintf("http://%s/metrics", addr))
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func TestRunHTTP_ServesMetrics(t *testing.T) {
+	srv := NewServer("test-server", "1.0.0")
+	srv.RegisterMetrics(metrics.New(), "")
+	addr := getAvailableAddr(t)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- srv.RunHTTP(ctx, addr)
+	}()
+	waitForServer(t, addr, 500*time.Millisecond)
+
+	code, body := getMetrics(t, addr)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, body, "mcp_sessions_active")
+
+	cancel()
+	require.NoError(t, <-errChan)
+}
+
+func TestStartMetricsServer(t *testing.T) {
+	t.Run("separate listener", func(t *testing.T) {
+		srv := NewServer("test-server", "1.0.0")
+		addr := getAvailableAddr(t)
+		srv.RegisterMetrics(metrics.New(), addr)
+
+		stop, err := srv.startMetricsServer()
+		require.NoError(t, err)
+
+		code, body := getMetrics(t, addr)
+		assert.Equal(t, http.StatusOK, code)
+		assert.Contains(t, body, "mcp_argo_log_bytes_total")
+
+		stop()
+		_, err = http.Get(fmt.Sprintf("http://%s/metrics", addr)) //nolint:noctx // Plain probe that the listener is closed
+		require.Error(t, err, "listener should be closed after stop")
+	})
+
+	t.Run("disabled without address", func(t *testing.T) {
+		srv := NewServer("test-server", "1.0.0")
+		srv.RegisterMetrics(metrics.New(), "")
+
+		stop, err := srv.startMetricsServer()
+		require.NoError(t, err)
+		stop()
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		srv := NewServer("test-server", "1.0.0")
+		srv.RegisterMetrics(metrics.New(), "127.0.0.1:99999")
+
+		_, err := srv.startMetricsServer()
+		require.Error(t, err)
+	})
+}
@@ -4,15 +4,27 @@ package server
 import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 
+	"github.com/pipekit/mcp-for-argo-workflows/internal/metrics"
+	"github.com/pipekit/mcp-for-argo-workflows/internal/tracing"
 	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
 	"github.com/pipekit/mcp-for-argo-workflows/pkg/prompts"
 	"github.com/pipekit/mcp-for-argo-workflows/pkg/resources"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
 	"github.com/pipekit/mcp-for-argo-workflows/pkg/tools"
 )
 
 // Server wraps the MCP server and provides methods for managing tools and resources.
 type Server struct {
-	mcp *mcp.Server
+	mcp           *mcp.Server
+	subscriptions *resources.WorkflowSubscriptions
+	// metrics is nil unless RegisterMetrics was called.
+	metrics *metrics.Metrics
+	// tracer is nil unless RegisterTracing was called.
+	tracer *tracing.Tracer
+	// readiness checks Argo connectivity for /readyz; nil means always ready.
+	readiness func() error
+	// metricsAddr is the separate metrics listen address, if any.
+	metricsAddr string
 }
 
 // NewServer creates and initializes a new MCP server instance.
@@ -23,12 +35,24 @@ func NewServer(name, version string) *Server {
 		Version: version,
 	}
 
+	// Workflow resource subscriptions are served by shared watches that are
+	// bound to the Argo client when cluster resources are registered.
+	subscriptions := resources.NewWorkflowSubscriptions()
+
 	// Create the MCP server with basic options
 	// Tools capability is enabled by default when tools are added
-	mcpServer := mcp.NewServer(implementation, nil)
+	mcpServer := mcp.NewServer(implementation, &mcp.ServerOptions{
+		SubscribeHandler:   subscriptions.Subscribe,
+		UnsubscribeHandler: subscriptions.Unsubscribe,
+	})
+
+	// Failed tool calls get a structured error. Added first so it runs
+	// innermost, and metrics and tracing see the classified result.
+	mcpServer.AddReceivingMiddleware(toolerror.Middleware())
 
 	return &Server{
-		mcp: mcpServer,
+		mcp:           mcpServer,
+		subscriptions: subscriptions,
 	}
 }
 
@@ -43,9 +67,11 @@ func (s *Server) RegisterResources() {
 }
 
 // RegisterClusterResources registers all dynamic cluster resources with the server.
-// These resources query the Argo cluster at runtime.
+// These resources query the Argo cluster at runtime, and workflow resources
+// can be subscribed to for live status updates.
 func (s *Server) RegisterClusterResources(client argo.ClientInterface) {
 	resources.RegisterClusterResources(s.mcp, client)
+	s.subscriptions.Bind(s.mcp, client)
 }
 
 // RegisterPrompts registers all Argo Workflows MCP prompts with the server.
@@ -18,6 +18,16 @@ func (s *Server) RunStdio(ctx context.Context) error {
 	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	// Stop any workflow watches backing resource subscriptions on exit
+	defer s.subscriptions.Close()
+
+	// Serve metrics on their own listener, since stdio has no HTTP endpoint
+	stopMetrics, metricsErr := s.startMetricsServer()
+	if metricsErr != nil {
+		return metricsErr
+	}
+	defer stopMetrics()
+
 	slog.Info("starting MCP server", "transport", "stdio")
 
 	// Run the server with stdio transport
@@ -0,0 +1,14 @@
+package server
+
+import (
+	"github.com/pipekit/mcp-for-argo-workflows/internal/tracing"
+)
+
+// RegisterTracing wraps every MCP request in a span recorded by t. In HTTP
+// mode, W3C trace context in the headers of incoming requests becomes the
+// parent of the session's spans. Middleware added later runs first, so
+// registering tracing after metrics makes the request span cover the whole call.
+func (s *Server) RegisterTracing(t *tracing.Tracer) {
+	s.mcp.AddReceivingMiddleware(t.Middleware())
+	s.tracer = t
+}
@@ -0,0 +1,74 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/pipekit/mcp-for-argo-workflows/internal/tracing"
+)
+
+// traceparentTransport adds a W3C traceparent header to every request.
+type traceparentTransport struct {
+	next        http.RoundTripper
+	traceparent string
+}
+
+func (t *traceparentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("traceparent", t.traceparent)
+	return t.next.RoundTrip(req)
+}
+
+func TestRunHTTP_PropagatesTraceContext(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	srv := NewServer("test-server", "1.0.0")
+	srv.RegisterTracing(tracing.New(provider))
+	addr := getAvailableAddr(t)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- srv.RunHTTP(ctx, addr)
+	}()
+	waitForServer(t, addr, 500*time.Millisecond)
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(ctx, &mcp.SSEClientTransport{
+		Endpoint: "http://" + addr,
+		HTTPClient: &http.Client{Transport: &traceparentTransport{
+			next:        http.DefaultTransport,
+			traceparent: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		}},
+	}, nil)
+	require.NoError(t, err)
+
+	_, err = session.ListTools(ctx, nil)
+	require.NoError(t, err)
+	require.NoError(t, session.Close())
+
+	var found bool
+	for _, span := range recorder.Ended() {
+		if span.Name() == "tools/list" {
+			found = true
+			assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", span.SpanContext().TraceID().String())
+			assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+		}
+	}
+	assert.True(t, found, "expected a span for tools/list")
+
+	cancel()
+	require.NoError(t, <-errChan)
+}
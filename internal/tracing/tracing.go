@@ -0,0 +1,274 @@
+// Package tracing provides OpenTelemetry tracing for MCP requests and the
+// Argo API calls made while handling them.
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// instrumentationName identifies the tracer that creates the server's spans.
+const instrumentationName = "github.com/pipekit/mcp-for-argo-workflows"
+
+// defaultTracesPath is the OTLP/HTTP path used when the endpoint has none.
+const defaultTracesPath = "/v1/traces"
+
+// Span attribute keys.
+const (
+	AttrMCPMethod        = attribute.Key("mcp.method.name")
+	AttrToolName         = attribute.Key("mcp.tool.name")
+	AttrResultSize       = attribute.Key("mcp.result.size")
+	AttrNamespace        = attribute.Key("argo.namespace")
+	AttrWorkflowName     = attribute.Key("argo.workflow.name")
+	AttrTemplateName     = attribute.Key("argo.template.name")
+	AttrCronWorkflowName = attribute.Key("argo.cron_workflow.name")
+)
+
+// Tracer creates spans for MCP requests and Argo API calls and propagates
+// W3C trace context from incoming HTTP requests and to outgoing gRPC calls.
+type Tracer struct {
+	tracer     trace.Tracer
+	propagator propagation.TextMapPropagator
+}
+
+// Ensure Tracer can observe Argo API calls.
+var _ argo.CallObserver = (*Tracer)(nil)
+
+// New creates a Tracer that records spans with provider.
+func New(provider trace.TracerProvider) *Tracer {
+	return &Tracer{
+		tracer:     provider.Tracer(instrumentationName),
+		propagator: propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+	}
+}
+
+// NewProvider creates a TracerProvider that batches spans and exports them
+// over OTLP/HTTP to endpoint, e.g. "http://otel-collector:4318". When the
+// endpoint has no path, the standard /v1/traces path is used. Exporter
+// headers, timeouts and TLS can be configured with the standard
+// OTEL_EXPORTER_OTLP_* environment variables. The caller must shut the
+// provider down to flush pending spans.
+func NewProvider(ctx context.Context, endpoint, serviceName, serviceVersion string) (*sdktrace.TracerProvider, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil || endpointURL.Scheme == "" || endpointURL.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q, expected a URL such as http://localhost:4318", endpoint)
+	}
+	if endpointURL.Path == "" || endpointURL.Path == "/" {
+		endpointURL.Path = defaultTracesPath
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpointURL.String()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+		semconv.ServiceVersion(serviceVersion),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace resource: %w", err)
+	}
+
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	), nil
+}
+
+// HTTPHandler extracts W3C trace context from incoming HTTP requests. With the
+// SSE transport, requests of a session inherit the context of the request that
+// opened it, so the session's spans continue the caller's trace.
+func (t *Tracer) HTTPHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := t.propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Middleware returns MCP receiving middleware that wraps every request in a
+// server span. Trace context in the request's HTTP headers or in the
+// traceparent field of a tool call's _meta takes precedence over the context
+// of the session.
+func (t *Tracer) Middleware() mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			ctx = t.extractRequest(ctx, req)
+
+			name := method
+			attrs := []attribute.KeyValue{AttrMCPMethod.String(method)}
+			if callReq, ok := req.(*mcp.CallToolRequest); ok && callReq.Params != nil {
+				name = method + " " + callReq.Params.Name
+				attrs = append(attrs, toolAttributes(callReq.Params.Name, callReq.Params.Arguments)...)
+			}
+
+			ctx, span := t.tracer.Start(ctx, name,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(attrs...),
+			)
+			defer span.End()
+
+			result, err := next(ctx, method, req)
+			recordResult(span, result, err)
+			return result, err
+		}
+	}
+}
+
+// extractRequest returns ctx with any trace context carried by the request itself.
+func (t *Tracer) extractRequest(ctx context.Context, req mcp.Request) context.Context {
+	if extra := req.GetExtra(); extra != nil && extra.Header != nil {
+		ctx = t.propagator.Extract(ctx, propagation.HeaderCarrier(extra.Header))
+	}
+	if callReq, ok := req.(*mcp.CallToolRequest); ok && callReq.Params != nil {
+		ctx = t.propagator.Extract(ctx, metaCarrier(callReq.Params.GetMeta()))
+	}
+	return ctx
+}
+
+// StartCall starts a client span for an Argo API call and injects its trace
+// context into the outgoing gRPC metadata, so that the Argo Server can
+// continue the trace.
+func (t *Tracer) StartCall(ctx context.Context, service, method string) (context.Context, func(error)) {
+	name := service + "/" + method
+	ctx, span := t.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(semconv.RPCMethod(name)),
+	)
+
+	carrier := propagation.MapCarrier{}
+	t.propagator.Inject(ctx, carrier)
+	for key, value := range carrier {
+		ctx = metadata.AppendToOutgoingContext(ctx, key, value)
+	}
+
+	return ctx, func(err error) {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}
+}
+
+// StartStream implements argo.CallObserver. Establishing a stream is traced
+// by StartCall; its lifetime is not.
+func (t *Tracer) StartStream(_, _ string) func() {
+	return func() {}
+}
+
+// LogBytes implements argo.CallObserver. Log volume is not traced.
+func (t *Tracer) LogBytes(_ int) {}
+
+// toolAttributes returns span attributes for the namespace and resource name
+// arguments of a tool call.
+func toolAttributes(tool string, arguments json.RawMessage) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{AttrToolName.String(tool)}
+	if len(arguments) == 0 {
+		return attrs
+	}
+
+	var args struct {
+		Namespace    string `json:"namespace"`
+		Name         string `json:"name"`
+		WorkflowName string `json:"workflowName"`
+	}
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return attrs
+	}
+
+	if args.Namespace != "" {
+		attrs = append(attrs, AttrNamespace.String(args.Namespace))
+	}
+	if args.WorkflowName != "" {
+		attrs = append(attrs, AttrWorkflowName.String(args.WorkflowName))
+	}
+	if args.Name != "" {
+		attrs = append(attrs, nameAttribute(tool).String(args.Name))
+	}
+	return attrs
+}
+
+// nameAttribute returns the attribute key for the name argument of a tool,
+// which names a template or cron workflow for the tools that manage them.
+func nameAttribute(tool string) attribute.Key {
+	switch {
+	case strings.Contains(tool, "template"):
+		return AttrTemplateName
+	case strings.Contains(tool, "cron"):
+		return AttrCronWorkflowName
+	default:
+		return AttrWorkflowName
+	}
+}
+
+// recordResult records the size of a result and marks the span as failed for
+// protocol errors and tool results that report an error.
+func recordResult(span trace.Span, result mcp.Result, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+	if result == nil || !span.IsRecording() {
+		return
+	}
+
+	if data, marshalErr := json.Marshal(result); marshalErr == nil {
+		span.SetAttributes(AttrResultSize.Int(len(data)))
+	}
+
+	if toolResult, ok := result.(*mcp.CallToolResult); ok && toolResult.IsError {
+		span.SetStatus(codes.Error, toolResultMessage(toolResult))
+	}
+}
+
+// toolResultMessage returns the text of an error tool result.
+func toolResultMessage(result *mcp.CallToolResult) string {
+	for _, content := range result.Content {
+		if text, ok := content.(*mcp.TextContent); ok {
+			return text.Text
+		}
+	}
+	return "tool call failed"
+}
+
+// metaCarrier reads trace context from the _meta field of an MCP request.
+type metaCarrier map[string]any
+
+// Get implements propagation.TextMapCarrier.
+func (c metaCarrier) Get(key string) string {
+	value, _ := c[key].(string)
+	return value
+}
+
+// Set implements propagation.TextMapCarrier.
+func (c metaCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+// Keys implements propagation.TextMapCarrier.
+func (c metaCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,261 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/metadata"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+)
+
+// remoteParent is the W3C traceparent of an upstream caller.
+const remoteParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+type getInput struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Fail      bool   `json:"fail,omitempty"`
+}
+
+type getOutput struct {
+	Phase string `json:"phase"`
+}
+
+// newTestTracer returns a Tracer that records finished spans in memory.
+func newTestTracer(t *testing.T) (*Tracer, *tracetest.SpanRecorder) {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	return New(provider), recorder
+}
+
+// connectTestServer serves a "get_workflow" tool that calls the Argo client
+// through the tracer, and returns a connected client session.
+func connectTestServer(t *testing.T, tracer *Tracer, client argo.ClientInterface) *mcp.ClientSession {
+	t.Helper()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, nil)
+	server.AddReceivingMiddleware(tracer.Middleware())
+	observed := argo.NewObservedClient(client, tracer)
+	mcp.AddTool(server, &mcp.Tool{Name: "get_workflow"}, func(ctx context.Context, _ *mcp.CallToolRequest, input getInput) (*mcp.CallToolResult, *getOutput, error) {
+		wf, err := observed.WorkflowService().GetWorkflow(ctx, &workflow.WorkflowGetRequest{Namespace: input.Namespace, Name: input.Name})
+		if err != nil {
+			return nil, nil, err
+		}
+		if input.Fail {
+			return nil, nil, errors.New("workflow is not running")
+		}
+		return nil, &getOutput{Phase: string(wf.Status.Phase)}, nil
+	})
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	_, err := server.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+
+	mcpClient := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := mcpClient.Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+
+	return session
+}
+
+// findSpan returns the recorded span with the given name.
+func findSpan(t *testing.T, recorder *tracetest.SpanRecorder, name string) sdktrace.ReadOnlySpan {
+	t.Helper()
+	for _, span := range recorder.Ended() {
+		if span.Name() == name {
+			return span
+		}
+	}
+	require.Failf(t, "span not found", "no span named %q", name)
+	return nil
+}
+
+// spanAttributes returns the attributes of span as a map.
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestMiddleware_ToolCallSpans(t *testing.T) {
+	tracer, recorder := newTestTracer(t)
+
+	wfService := &mocks.MockWorkflowServiceClient{}
+	wfService.On("GetWorkflow", mock.Anything, mock.Anything).Return(&wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "hello"},
+		Status:     wfv1.WorkflowStatus{Phase: wfv1.WorkflowRunning},
+	}, nil)
+	client := mocks.NewMockClient("default", true)
+	client.SetWorkflowService(wfService)
+
+	session := connectTestServer(t, tracer, client)
+
+	_, err := session.CallTool(t.Context(), &mcp.CallToolParams{
+		Meta:      mcp.Meta{"traceparent": remoteParent},
+		Name:      "get_workflow",
+		Arguments: map[string]any{"namespace": "argo", "name": "hello"},
+	})
+	require.NoError(t, err)
+
+	request := findSpan(t, recorder, "tools/call get_workflow")
+	assert.Equal(t, trace.SpanKindServer, request.SpanKind())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", request.SpanContext().TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", request.Parent().SpanID().String())
+
+	attrs := spanAttributes(request)
+	assert.Equal(t, "get_workflow", attrs[AttrToolName].AsString())
+	assert.Equal(t, "argo", attrs[AttrNamespace].AsString())
+	assert.Equal(t, "hello", attrs[AttrWorkflowName].AsString())
+	assert.Positive(t, attrs[AttrResultSize].AsInt64())
+	assert.Equal(t, codes.Unset, request.Status().Code)
+
+	call := findSpan(t, recorder, "WorkflowService/GetWorkflow")
+	assert.Equal(t, trace.SpanKindClient, call.SpanKind())
+	assert.Equal(t, request.SpanContext().SpanID(), call.Parent().SpanID())
+	assert.Equal(t, request.SpanContext().TraceID(), call.SpanContext().TraceID())
+}
+
+func TestMiddleware_ToolErrors(t *testing.T) {
+	tracer, recorder := newTestTracer(t)
+
+	wfService := &mocks.MockWorkflowServiceClient{}
+	wfService.On("GetWorkflow", mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+	client := mocks.NewMockClient("default", true)
+	client.SetWorkflowService(wfService)
+
+	session := connectTestServer(t, tracer, client)
+
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{
+		Name:      "get_workflow",
+		Arguments: map[string]any{"namespace": "argo", "name": "missing"},
+	})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+
+	request := findSpan(t, recorder, "tools/call get_workflow")
+	assert.Equal(t, codes.Error, request.Status().Code)
+	assert.Contains(t, request.Status().Description, "not found")
+
+	call := findSpan(t, recorder, "WorkflowService/GetWorkflow")
+	assert.Equal(t, codes.Error, call.Status().Code)
+	assert.Len(t, call.Events(), 1, "the error should be recorded on the call span")
+}
+
+func TestTracer_StartCallInjectsMetadata(t *testing.T) {
+	tracer, recorder := newTestTracer(t)
+
+	ctx, done := tracer.StartCall(t.Context(), "WorkflowService", "ListWorkflows")
+	md, ok := metadata.FromOutgoingContext(ctx)
+	require.True(t, ok)
+	done(nil)
+
+	span := findSpan(t, recorder, "WorkflowService/ListWorkflows")
+	require.Len(t, md.Get("traceparent"), 1)
+	assert.Contains(t, md.Get("traceparent")[0], span.SpanContext().TraceID().String())
+	assert.Contains(t, md.Get("traceparent")[0], span.SpanContext().SpanID().String())
+}
+
+func TestTracer_HTTPHandler(t *testing.T) {
+	tracer, _ := newTestTracer(t)
+
+	var got trace.SpanContext
+	handler := tracer.HTTPHandler(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		got = trace.SpanContextFromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("traceparent", remoteParent)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.True(t, got.IsRemote())
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", got.TraceID().String())
+}
+
+func TestToolAttributes(t *testing.T) {
+	tests := []struct {
+		want map[attribute.Key]string
+		name string
+		tool string
+		args string
+	}{
+		{
+			name: "workflow tool",
+			tool: "get_workflow",
+			args: `{"namespace":"argo","name":"hello"}`,
+			want: map[attribute.Key]string{AttrNamespace: "argo", AttrWorkflowName: "hello"},
+		},
+		{
+			name: "template tool",
+			tool: "get_workflow_template",
+			args: `{"name":"build"}`,
+			want: map[attribute.Key]string{AttrTemplateName: "build"},
+		},
+		{
+			name: "cron tool",
+			tool: "suspend_cron_workflow",
+			args: `{"name":"nightly"}`,
+			want: map[attribute.Key]string{AttrCronWorkflowName: "nightly"},
+		},
+		{
+			name: "explicit workflow name",
+			tool: "get_pod_logs",
+			args: `{"workflowName":"hello"}`,
+			want: map[attribute.Key]string{AttrWorkflowName: "hello"},
+		},
+		{
+			name: "invalid arguments",
+			tool: "list_workflows",
+			args: `[]`,
+			want: map[attribute.Key]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attrs := toolAttributes(tt.tool, []byte(tt.args))
+
+			got := make(map[attribute.Key]string)
+			for _, kv := range attrs {
+				if kv.Key != AttrToolName {
+					got[kv.Key] = kv.Value.AsString()
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	t.Run("valid endpoint", func(t *testing.T) {
+		provider, err := NewProvider(t.Context(), "http://localhost:4318", "test", "1.0.0")
+		require.NoError(t, err)
+		require.NoError(t, provider.Shutdown(t.Context()))
+	})
+
+	t.Run("invalid endpoint", func(t *testing.T) {
+		_, err := NewProvider(t.Context(), "localhost:4318", "test", "1.0.0")
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,66 @@
+package argo
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// artifactTimeout bounds downloading a single artifact from the Argo Server.
+const artifactTimeout = 2 * time.Minute
+
+// ArtifactRequest identifies an output artifact of a workflow node.
+type ArtifactRequest struct {
+	// Namespace is the namespace of the workflow.
+	Namespace string
+
+	// Workflow is the workflow name.
+	Workflow string
+
+	// NodeID is the ID of the node that produced the artifact.
+	NodeID string
+
+	// Name is the artifact name.
+	Name string
+}
+
+// OpenArtifact opens an output artifact through the /artifacts endpoint of
+// the Argo Server, which reads it from the artifact repository of the
+// workflow. Returns ErrArtifactsNotSupported if not in Argo Server mode.
+func (c *Client) OpenArtifact(ctx context.Context, req *ArtifactRequest) (io.ReadCloser, error) {
+	if !c.IsArgoServerMode() {
+		return nil, ErrArtifactsNotSupported
+	}
+	return openArtifact(ctx, newArgoServerHTTPClient(c.config, artifactTimeout), argoServerURL(c.config), c.config.ArgoToken, req)
+}
+
+// openArtifact gets /artifacts/{namespace}/{workflow}/{nodeId}/{name}. Error
+// responses are returned as gRPC status errors.
+func openArtifact(ctx context.Context, httpClient *http.Client, baseURL, authorization string, in *ArtifactRequest) (io.ReadCloser, error) {
+	path := "/artifacts/" + url.PathEscape(in.Namespace) + "/" + url.PathEscape(in.Workflow) + "/" +
+		url.PathEscape(in.NodeID) + "/" + url.PathEscape(in.Name)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+path, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	if resp.StatusCode != http.StatusOK {
+		_ = resp.Body.Close()
+		return nil, status.Error(codeFromHTTPStatus(resp.StatusCode), fmt.Sprintf("GET %s: %s", path, resp.Status))
+	}
+	return resp.Body, nil
+}
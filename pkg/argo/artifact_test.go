@@ -0,0 +1,60 @@
+package argo
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newTestArtifactClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &Client{
+		config: &Config{ArgoServer: strings.TrimPrefix(server.URL, "http://"), ArgoToken: "Bearer secret"},
+		ctx:    t.Context(),
+	}
+}
+
+func TestClient_OpenArtifact(t *testing.T) {
+	req := &ArtifactRequest{Namespace: "argo", Workflow: "wf", NodeID: "wf-123", Name: "main-logs"}
+
+	t.Run("streams the artifact", func(t *testing.T) {
+		client := newTestArtifactClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/artifacts/argo/wf/wf-123/main-logs", r.URL.Path)
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte("hello"))
+		})
+
+		reader, err := client.OpenArtifact(t.Context(), req)
+		require.NoError(t, err)
+		defer func() { _ = reader.Close() }()
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", string(data))
+	})
+
+	t.Run("maps error statuses", func(t *testing.T) {
+		client := newTestArtifactClient(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+
+		_, err := client.OpenArtifact(t.Context(), req)
+		require.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("requires argo server mode", func(t *testing.T) {
+		client := &Client{config: &Config{}, ctx: t.Context()}
+
+		_, err := client.OpenArtifact(t.Context(), req)
+		require.ErrorIs(t, err, ErrArtifactsNotSupported)
+	})
+}
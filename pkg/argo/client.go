@@ -4,16 +4,22 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"path/filepath"
 
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient"
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/clusterworkflowtemplate"
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/cronworkflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/event"
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/info"
+	syncpkg "github.com/argoproj/argo-workflows/v4/pkg/apiclient/sync"
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowarchive"
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	"github.com/argoproj/argo-workflows/v4/pkg/client/clientset/versioned"
+	"github.com/argoproj/argo-workflows/v4/server/auth"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
@@ -21,6 +27,18 @@ var (
 	// ErrArchivedWorkflowsNotSupported is returned when trying to access archived workflows
 	// in direct Kubernetes API mode.
 	ErrArchivedWorkflowsNotSupported = errors.New("archived workflows are only supported with Argo Server connection")
+
+	// ErrKubernetesClientNotAvailable is returned when trying to access the Kubernetes API
+	// directly while connected via Argo Server.
+	ErrKubernetesClientNotAvailable = errors.New("direct Kubernetes API access is only available without an Argo Server connection")
+
+	// ErrEventsNotSupported is returned when trying to send workflow events
+	// in direct Kubernetes API mode.
+	ErrEventsNotSupported = errors.New("workflow events are only supported with Argo Server connection")
+
+	// ErrArtifactsNotSupported is returned when trying to download artifacts
+	// in direct Kubernetes API mode.
+	ErrArtifactsNotSupported = errors.New("downloading artifacts is only supported with Argo Server connection")
 )
 
 // ClientInterface defines the interface for interacting with Argo Workflows.
@@ -44,6 +62,25 @@ type ClientInterface interface {
 	// InfoService returns the info service client.
 	InfoService() (info.InfoServiceClient, error)
 
+	// SyncService returns the sync limit service client.
+	SyncService() (syncpkg.SyncServiceClient, error)
+
+	// OpenArtifact opens an output artifact of a workflow node.
+	// Returns ErrArtifactsNotSupported if not in Argo Server mode.
+	OpenArtifact(ctx context.Context, req *ArtifactRequest) (io.ReadCloser, error)
+
+	// EventService returns the event service client of the Argo Server.
+	EventService() (event.EventServiceClient, error)
+
+	// KubernetesClient returns the Kubernetes clientset used in direct K8s mode.
+	KubernetesClient() (kubernetes.Interface, error)
+
+	// WorkflowClientset returns the Argo Workflows clientset used in direct K8s mode.
+	WorkflowClientset() (versioned.Interface, error)
+
+	// ControllerNamespace returns the namespace where the workflow controller runs.
+	ControllerNamespace() string
+
 	// IsArgoServerMode returns true if connected via Argo Server.
 	IsArgoServerMode() bool
 
@@ -182,6 +219,63 @@ func (c *Client) InfoService() (info.InfoServiceClient, error) {
 	return client, nil
 }
 
+// KubernetesClient returns the Kubernetes clientset used in direct K8s mode.
+// The Argo SDK stores the clientset in the client context.
+// Returns ErrKubernetesClientNotAvailable if in Argo Server mode.
+func (c *Client) KubernetesClient() (kubernetes.Interface, error) {
+	if c.IsArgoServerMode() {
+		return nil, ErrKubernetesClientNotAvailable
+	}
+
+	client, ok := c.ctx.Value(auth.KubeKey).(kubernetes.Interface)
+	if !ok || client == nil {
+		return nil, ErrKubernetesClientNotAvailable
+	}
+	return client, nil
+}
+
+// SyncService returns the sync limit service client.
+func (c *Client) SyncService() (syncpkg.SyncServiceClient, error) {
+	client, err := c.apiClient.NewSyncServiceClient(c.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sync service client: %w", err)
+	}
+	return client, nil
+}
+
+// EventService returns the event service client.
+// The Argo SDK has no event client, so events are sent to the REST API of the
+// Argo Server. Returns ErrEventsNotSupported if not in Argo Server mode.
+func (c *Client) EventService() (event.EventServiceClient, error) {
+	if !c.IsArgoServerMode() {
+		return nil, ErrEventsNotSupported
+	}
+	return newHTTPEventService(c.config), nil
+}
+
+// WorkflowClientset returns the Argo Workflows clientset used in direct K8s mode.
+// The Argo SDK stores the clientset in the client context.
+// Returns ErrKubernetesClientNotAvailable if in Argo Server mode.
+func (c *Client) WorkflowClientset() (versioned.Interface, error) {
+	if c.IsArgoServerMode() {
+		return nil, ErrKubernetesClientNotAvailable
+	}
+
+	client, ok := c.ctx.Value(auth.WfKey).(versioned.Interface)
+	if !ok || client == nil {
+		return nil, ErrKubernetesClientNotAvailable
+	}
+	return client, nil
+}
+
+// ControllerNamespace returns the namespace where the workflow controller runs.
+func (c *Client) ControllerNamespace() string {
+	if c.config.ControllerNamespace == "" {
+		return defaultControllerNamespace
+	}
+	return c.config.ControllerNamespace
+}
+
 // IsArgoServerMode returns true if the client is connected via Argo Server,
 // false if using direct Kubernetes API access.
 func (c *Client) IsArgoServerMode() bool {
@@ -5,8 +5,11 @@ import (
 	"path/filepath"
 	"testing"
 
+	wffake "github.com/argoproj/argo-workflows/v4/pkg/client/clientset/versioned/fake"
+	"github.com/argoproj/argo-workflows/v4/server/auth"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes/fake"
 )
 
 func TestNewClient_NilConfig(t *testing.T) {
@@ -121,6 +124,84 @@ func TestClient_ArchivedWorkflowService_NotArgoServerMode(t *testing.T) {
 	assert.ErrorIs(t, err, ErrArchivedWorkflowsNotSupported)
 }
 
+func TestClient_KubernetesClient(t *testing.T) {
+	t.Run("argo server mode", func(t *testing.T) {
+		client := &Client{
+			config: &Config{ArgoServer: "localhost:2746"},
+			ctx:    t.Context(),
+		}
+
+		kubeClient, err := client.KubernetesClient()
+		require.ErrorIs(t, err, ErrKubernetesClientNotAvailable)
+		assert.Nil(t, kubeClient)
+	})
+
+	t.Run("direct mode reads clientset from context", func(t *testing.T) {
+		clientset := fake.NewClientset()
+		client := &Client{
+			config: &Config{},
+			ctx:    context.WithValue(t.Context(), auth.KubeKey, clientset),
+		}
+
+		kubeClient, err := client.KubernetesClient()
+		require.NoError(t, err)
+		assert.Same(t, clientset, kubeClient)
+	})
+
+	t.Run("direct mode without clientset", func(t *testing.T) {
+		client := &Client{
+			config: &Config{},
+			ctx:    t.Context(),
+		}
+
+		_, err := client.KubernetesClient()
+		require.ErrorIs(t, err, ErrKubernetesClientNotAvailable)
+	})
+}
+
+func TestClient_WorkflowClientset(t *testing.T) {
+	t.Run("argo server mode", func(t *testing.T) {
+		client := &Client{
+			config: &Config{ArgoServer: "localhost:2746"},
+			ctx:    t.Context(),
+		}
+
+		_, err := client.WorkflowClientset()
+		require.ErrorIs(t, err, ErrKubernetesClientNotAvailable)
+	})
+
+	t.Run("direct mode reads clientset from context", func(t *testing.T) {
+		clientset := wffake.NewSimpleClientset()
+		client := &Client{
+			config: &Config{},
+			ctx:    context.WithValue(t.Context(), auth.WfKey, clientset),
+		}
+
+		wfClient, err := client.WorkflowClientset()
+		require.NoError(t, err)
+		assert.Same(t, clientset, wfClient)
+	})
+}
+
+func TestClient_EventService(t *testing.T) {
+	client := &Client{config: &Config{}, ctx: t.Context()}
+	_, err := client.EventService()
+	require.ErrorIs(t, err, ErrEventsNotSupported)
+
+	client = &Client{config: &Config{ArgoServer: "localhost:2746"}, ctx: t.Context()}
+	svc, err := client.EventService()
+	require.NoError(t, err)
+	assert.NotNil(t, svc)
+}
+
+func TestClient_ControllerNamespace(t *testing.T) {
+	client := &Client{config: &Config{}}
+	assert.Equal(t, "argo", client.ControllerNamespace())
+
+	client = &Client{config: &Config{ControllerNamespace: "workflows"}}
+	assert.Equal(t, "workflows", client.ControllerNamespace())
+}
+
 func TestErrArchivedWorkflowsNotSupported(t *testing.T) {
 	// Test that the error message is as expected
 	assert.Contains(t, ErrArchivedWorkflowsNotSupported.Error(), "archived workflows are only supported")
@@ -6,8 +6,14 @@ import (
 	"strconv"
 )
 
-// defaultNamespace is the namespace assumed when none is configured.
-const defaultNamespace = "default"
+const (
+	// defaultNamespace is the namespace assumed when none is configured.
+	defaultNamespace = "default"
+
+	// defaultControllerNamespace is the namespace assumed for the workflow
+	// controller when none is configured, matching the Argo install manifests.
+	defaultControllerNamespace = "argo"
+)
 
 // Config holds the configuration for connecting to Argo Workflows.
 type Config struct {
@@ -21,6 +27,11 @@ type Config struct {
 	// Namespace is the default namespace for operations.
 	Namespace string
 
+	// ControllerNamespace is the namespace where the workflow controller and its
+	// configmap are installed. Defaults to "argo". Only used in direct
+	// Kubernetes API mode.
+	ControllerNamespace string
+
 	// Kubeconfig is the path to the kubeconfig file, or a list of paths
 	// joined by os.PathListSeparator (':' on Unix, ';' on Windows), matching
 	// the kubectl convention for the KUBECONFIG environment variable.
@@ -49,11 +60,12 @@ type Config struct {
 // NewConfigFromEnv creates a Config from environment variables.
 func NewConfigFromEnv() *Config {
 	config := &Config{
-		ArgoServer: os.Getenv("ARGO_SERVER"),
-		ArgoToken:  os.Getenv("ARGO_TOKEN"),
-		Namespace:  os.Getenv("ARGO_NAMESPACE"),
-		Kubeconfig: os.Getenv("KUBECONFIG"),
-		Secure:     true, // Default to secure
+		ArgoServer:          os.Getenv("ARGO_SERVER"),
+		ArgoToken:           os.Getenv("ARGO_TOKEN"),
+		Namespace:           os.Getenv("ARGO_NAMESPACE"),
+		Kubeconfig:          os.Getenv("KUBECONFIG"),
+		Secure:              true, // Default to secure
+		ControllerNamespace: os.Getenv("ARGO_CONTROLLER_NAMESPACE"),
 	}
 
 	// Parse ARGO_SECURE if set
@@ -0,0 +1,160 @@
+package argo
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/event"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// eventServiceTimeout bounds a single call to the Argo Server event API.
+const eventServiceTimeout = 30 * time.Second
+
+// httpEventService implements event.EventServiceClient against the REST
+// endpoints of the Argo Server. The Argo SDK has no event client, and the
+// endpoints are served over HTTP/1 in both gRPC and HTTP/1 modes.
+type httpEventService struct {
+	httpClient    *http.Client
+	baseURL       string
+	authorization string
+}
+
+// Ensure httpEventService implements event.EventServiceClient.
+var _ event.EventServiceClient = (*httpEventService)(nil)
+
+// newHTTPEventService creates an event service client for the Argo Server in config.
+func newHTTPEventService(config *Config) *httpEventService {
+	return &httpEventService{
+		httpClient:    newArgoServerHTTPClient(config, eventServiceTimeout),
+		baseURL:       argoServerURL(config),
+		authorization: config.ArgoToken,
+	}
+}
+
+// newArgoServerHTTPClient creates an HTTP client for the REST endpoints of
+// the Argo Server in config.
+func newArgoServerHTTPClient(config *Config, timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: config.InsecureSkipVerify}, //nolint:gosec // Opt-in via --argo-insecure-skip-verify
+		},
+	}
+}
+
+// argoServerURL returns the base URL of the Argo Server in config.
+func argoServerURL(config *Config) string {
+	if config.Secure {
+		return "https://" + config.ArgoServer
+	}
+	return "http://" + config.ArgoServer
+}
+
+// ReceiveEvent posts the event payload to /api/v1/events/{namespace}/{discriminator}.
+func (s *httpEventService) ReceiveEvent(ctx context.Context, in *event.EventRequest, _ ...grpc.CallOption) (*event.EventResponse, error) {
+	path := "/api/v1/events/" + url.PathEscape(in.Namespace) + "/"
+	if in.Discriminator != "" {
+		path += url.PathEscape(in.Discriminator)
+	}
+
+	payload := in.Payload
+	if payload == nil {
+		payload = &wfv1.Item{}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode event payload: %w", err)
+	}
+
+	if err = s.do(ctx, http.MethodPost, path, body, nil); err != nil {
+		return nil, err
+	}
+	return &event.EventResponse{}, nil
+}
+
+// ListWorkflowEventBindings gets /api/v1/workflow-event-bindings/{namespace}.
+func (s *httpEventService) ListWorkflowEventBindings(ctx context.Context, in *event.ListWorkflowEventBindingsRequest, _ ...grpc.CallOption) (*wfv1.WorkflowEventBindingList, error) {
+	path := "/api/v1/workflow-event-bindings/" + url.PathEscape(in.Namespace)
+	if in.ListOptions != nil && in.ListOptions.LabelSelector != "" {
+		path += "?" + url.Values{"listOptions.labelSelector": {in.ListOptions.LabelSelector}}.Encode()
+	}
+
+	list := &wfv1.WorkflowEventBindingList{}
+	if err := s.do(ctx, http.MethodGet, path, nil, list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// do sends a request to the Argo Server and decodes the response into out.
+// Error responses are returned as gRPC status errors, like the Argo SDK's
+// HTTP/1 clients do.
+func (s *httpEventService) do(ctx context.Context, method, path string, body []byte, out any) error {
+	req, err := http.NewRequestWithContext(ctx, method, s.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.authorization != "" {
+		req.Header.Set("Authorization", s.authorization)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := struct {
+			Message string     `json:"message"`
+			Code    codes.Code `json:"code"`
+		}{}
+		data, _ := io.ReadAll(resp.Body)
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Message != "" {
+			return status.Error(apiErr.Code, apiErr.Message)
+		}
+		return status.Error(codeFromHTTPStatus(resp.StatusCode), fmt.Sprintf("%s %s: %s", method, path, resp.Status))
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// codeFromHTTPStatus maps an HTTP status without a gRPC error body to a gRPC code.
+func codeFromHTTPStatus(statusCode int) codes.Code {
+	switch statusCode {
+	case http.StatusBadRequest:
+		return codes.InvalidArgument
+	case http.StatusUnauthorized:
+		return codes.Unauthenticated
+	case http.StatusForbidden:
+		return codes.PermissionDenied
+	case http.StatusNotFound:
+		return codes.NotFound
+	case http.StatusConflict:
+		return codes.AlreadyExists
+	case http.StatusNotImplemented:
+		return codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		return codes.Unavailable
+	default:
+		return codes.Unknown
+	}
+}
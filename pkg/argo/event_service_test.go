@@ -0,0 +1,86 @@
+package argo
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/event"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestEventService(t *testing.T, handler http.HandlerFunc) *httpEventService {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return newHTTPEventService(&Config{
+		ArgoServer: strings.TrimPrefix(server.URL, "http://"),
+		ArgoToken:  "Bearer secret",
+	})
+}
+
+func TestHTTPEventService_ReceiveEvent(t *testing.T) {
+	var gotPath, gotAuth, gotBody string
+	svc := newTestEventService(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		gotAuth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		_, _ = w.Write([]byte("{}"))
+	})
+
+	payload := wfv1.Item{}
+	require.NoError(t, json.Unmarshal([]byte(`{"repo":"argo"}`), &payload))
+	_, err := svc.ReceiveEvent(t.Context(), &event.EventRequest{Namespace: "argo", Discriminator: "git hub", Payload: &payload})
+	require.NoError(t, err)
+
+	assert.Equal(t, "/api/v1/events/argo/git%20hub", gotPath)
+	assert.Equal(t, "Bearer secret", gotAuth)
+	assert.JSONEq(t, `{"repo":"argo"}`, gotBody)
+}
+
+func TestHTTPEventService_ListWorkflowEventBindings(t *testing.T) {
+	svc := newTestEventService(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/workflow-event-bindings/argo", r.URL.Path)
+		_ = json.NewEncoder(w).Encode(wfv1.WorkflowEventBindingList{
+			Items: []wfv1.WorkflowEventBinding{{ObjectMeta: metav1.ObjectMeta{Name: "on-push"}}},
+		})
+	})
+
+	list, err := svc.ListWorkflowEventBindings(t.Context(), &event.ListWorkflowEventBindingsRequest{Namespace: "argo"})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "on-push", list.Items[0].Name)
+}
+
+func TestHTTPEventService_Errors(t *testing.T) {
+	t.Run("gRPC error body", func(t *testing.T) {
+		svc := newTestEventService(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"code":7,"message":"not allowed to create events"}`))
+		})
+
+		_, err := svc.ReceiveEvent(t.Context(), &event.EventRequest{Namespace: "argo"})
+		require.Error(t, err)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Contains(t, err.Error(), "not allowed to create events")
+	})
+
+	t.Run("plain status", func(t *testing.T) {
+		svc := newTestEventService(t, func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+
+		_, err := svc.ListWorkflowEventBindings(t.Context(), &event.ListWorkflowEventBindingsRequest{Namespace: "argo"})
+		require.Error(t, err)
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
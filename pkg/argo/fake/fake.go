@@ -0,0 +1,410 @@
+// Package fake provides an in-memory Argo Workflows backend implementing
+// argo.ClientInterface. It stores workflows, templates and cron workflows per
+// namespace, moves workflows through phases on a scriptable timeline, and
+// serves watch and log streams, so handler tests can exercise multi-step
+// flows such as submit, watch, fail, retry and resubmit without a cluster.
+package fake
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/clusterworkflowtemplate"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/cronworkflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/event"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/info"
+	syncpkg "github.com/argoproj/argo-workflows/v4/pkg/apiclient/sync"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowarchive"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/pkg/client/clientset/versioned"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/snapshot"
+)
+
+// Version is the Argo version reported by the fake backend.
+const Version = "v0.0.0-fake"
+
+// defaultNamespace is used when a client is created without namespaces.
+const defaultNamespace = "default"
+
+// Client is an in-memory Argo backend. It behaves like an Argo Server
+// connection: archived workflows and events are available, the Kubernetes API
+// is not. All methods are safe for concurrent use.
+type Client struct {
+	ctx   context.Context //nolint:containedctx // Returned by Context like the Argo SDK context
+	clock func() time.Time
+
+	namespaces               map[string]bool
+	workflows                map[string]*wfv1.Workflow
+	archived                 map[types.UID]*wfv1.Workflow
+	workflowTemplates        map[string]*wfv1.WorkflowTemplate
+	clusterWorkflowTemplates map[string]*wfv1.ClusterWorkflowTemplate
+	cronWorkflows            map[string]*wfv1.CronWorkflow
+	eventBindings            map[string]*wfv1.WorkflowEventBinding
+	syncLimits               map[string]int32
+	logs                     map[string]map[string][]string
+	artifacts                map[string][]byte
+	scripts                  map[string][]Step
+	watchers                 map[*watcher]struct{}
+
+	defaultNamespace string
+	defaultScript    []Step
+	events           []*event.EventRequest
+
+	mu              sync.Mutex
+	resourceVersion int64
+	names           int64
+}
+
+// Ensure Client implements ClientInterface.
+var _ argo.ClientInterface = (*Client)(nil)
+
+// NewClient creates an empty fake backend with the given namespaces. The first
+// namespace is the default namespace; "default" is used when none are given.
+// Workflows follow DefaultScript until SetDefaultScript or Script is called.
+func NewClient(ctx context.Context, namespaces ...string) *Client {
+	if len(namespaces) == 0 {
+		namespaces = []string{defaultNamespace}
+	}
+	c := &Client{
+		ctx:                      ctx,
+		clock:                    time.Now,
+		namespaces:               make(map[string]bool),
+		workflows:                make(map[string]*wfv1.Workflow),
+		archived:                 make(map[types.UID]*wfv1.Workflow),
+		workflowTemplates:        make(map[string]*wfv1.WorkflowTemplate),
+		clusterWorkflowTemplates: make(map[string]*wfv1.ClusterWorkflowTemplate),
+		cronWorkflows:            make(map[string]*wfv1.CronWorkflow),
+		eventBindings:            make(map[string]*wfv1.WorkflowEventBinding),
+		syncLimits:               make(map[string]int32),
+		logs:                     make(map[string]map[string][]string),
+		artifacts:                make(map[string][]byte),
+		scripts:                  make(map[string][]Step),
+		watchers:                 make(map[*watcher]struct{}),
+		defaultNamespace:         namespaces[0],
+		defaultScript:            DefaultScript(),
+	}
+	for _, ns := range namespaces {
+		c.namespaces[ns] = true
+	}
+	return c
+}
+
+// objectKey returns the store key of a namespaced object.
+func objectKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// AddNamespace makes a namespace available.
+func (c *Client) AddNamespace(namespace string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.namespaces[namespace] = true
+}
+
+// SetClock replaces the clock used for timestamps, for deterministic tests.
+func (c *Client) SetClock(clock func() time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clock = clock
+}
+
+// now returns the current time of the client's clock. Callers hold c.mu.
+func (c *Client) now() metav1.Time {
+	return metav1.NewTime(c.clock().UTC().Truncate(time.Second))
+}
+
+// checkNamespace returns the error the Argo Server returns for a namespace
+// that does not exist. Callers hold c.mu.
+func (c *Client) checkNamespace(namespace string) error {
+	if namespace == "" {
+		return status.Error(codes.InvalidArgument, "namespace is required")
+	}
+	if !c.namespaces[namespace] {
+		return status.Errorf(codes.NotFound, "namespaces %q not found", namespace)
+	}
+	return nil
+}
+
+// admit checks the namespace of an object created in namespace and fills in
+// the metadata the API server sets. Callers hold c.mu.
+func (c *Client) admit(meta *metav1.ObjectMeta, namespace string) error {
+	if namespace != "" {
+		if err := c.checkNamespace(namespace); err != nil {
+			return err
+		}
+		if meta.Namespace == "" {
+			meta.Namespace = namespace
+		}
+		if meta.Namespace != namespace {
+			return status.Errorf(codes.InvalidArgument,
+				"the namespace of the provided object (%s) does not match the namespace sent on the request (%s)", meta.Namespace, namespace)
+		}
+	}
+	if meta.Name == "" {
+		if meta.GenerateName == "" {
+			return status.Error(codes.InvalidArgument, "name or generateName is required")
+		}
+		c.names++
+		meta.Name = meta.GenerateName + strconv.FormatInt(c.names, 36)
+	}
+	if meta.UID == "" {
+		meta.UID = uuid.NewUUID()
+	}
+	if meta.CreationTimestamp.IsZero() {
+		meta.CreationTimestamp = c.now()
+	}
+	meta.ResourceVersion = c.nextResourceVersion()
+	meta.Generation = 1
+	return nil
+}
+
+// nextResourceVersion returns a new resource version. Callers hold c.mu.
+func (c *Client) nextResourceVersion() string {
+	c.resourceVersion++
+	return strconv.FormatInt(c.resourceVersion, 10)
+}
+
+// checkResourceVersion rejects an update based on a stale resource version.
+func checkResourceVersion(kind, name, expected, current string) error {
+	if expected != "" && expected != current {
+		return status.Errorf(codes.Aborted,
+			"Operation cannot be fulfilled on %s %q: the object has been modified; please apply your changes to the latest version and try again", kind, name)
+	}
+	return nil
+}
+
+// notFound returns the error the Argo Server returns for a missing resource.
+func notFound(resource, name string) error {
+	return status.Errorf(codes.NotFound, "%s.argoproj.io %q not found", resource, name)
+}
+
+// alreadyExists returns the error the Argo Server returns for a duplicate resource.
+func alreadyExists(resource, name string) error {
+	return status.Errorf(codes.AlreadyExists, "%s.argoproj.io %q already exists", resource, name)
+}
+
+// AddWorkflow stores a workflow as is, including its status. Workflows that
+// have not completed follow the default script.
+func (c *Client) AddWorkflow(wf *wfv1.Workflow) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := c.createWorkflow(wf.DeepCopy(), wf.Namespace)
+	return err
+}
+
+// AddWorkflowTemplate stores a WorkflowTemplate.
+func (c *Client) AddWorkflowTemplate(wft *wfv1.WorkflowTemplate) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := c.createWorkflowTemplate(wft.DeepCopy(), wft.Namespace)
+	return err
+}
+
+// AddClusterWorkflowTemplate stores a ClusterWorkflowTemplate.
+func (c *Client) AddClusterWorkflowTemplate(cwft *wfv1.ClusterWorkflowTemplate) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := c.createClusterWorkflowTemplate(cwft.DeepCopy())
+	return err
+}
+
+// AddCronWorkflow stores a CronWorkflow.
+func (c *Client) AddCronWorkflow(cwf *wfv1.CronWorkflow) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, err := c.createCronWorkflow(cwf.DeepCopy(), cwf.Namespace)
+	return err
+}
+
+// AddWorkflowEventBinding stores a WorkflowEventBinding.
+func (c *Client) AddWorkflowEventBinding(binding *wfv1.WorkflowEventBinding) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stored := binding.DeepCopy()
+	if err := c.admit(&stored.ObjectMeta, stored.Namespace); err != nil {
+		return err
+	}
+	key := objectKey(stored.Namespace, stored.Name)
+	if _, ok := c.eventBindings[key]; ok {
+		return alreadyExists("workfloweventbindings", stored.Name)
+	}
+	c.eventBindings[key] = stored
+	return nil
+}
+
+// LoadSnapshot stores the resources and logs of a snapshot, creating the
+// namespaces it uses.
+func (c *Client) LoadSnapshot(s *snapshot.Snapshot) error {
+	c.mu.Lock()
+	for i := range s.Workflows {
+		c.namespaces[s.Workflows[i].Namespace] = true
+	}
+	for i := range s.WorkflowTemplates {
+		c.namespaces[s.WorkflowTemplates[i].Namespace] = true
+	}
+	for i := range s.CronWorkflows {
+		c.namespaces[s.CronWorkflows[i].Namespace] = true
+	}
+	c.mu.Unlock()
+
+	for i := range s.Workflows {
+		if err := c.AddWorkflow(&s.Workflows[i]); err != nil {
+			return err
+		}
+		wf := &s.Workflows[i]
+		for pod, data := range s.PodLogs(wf.Namespace, wf.Name) {
+			c.SetPodLogs(wf.Namespace, wf.Name, pod, splitLines(data)...)
+		}
+	}
+	for i := range s.WorkflowTemplates {
+		if err := c.AddWorkflowTemplate(&s.WorkflowTemplates[i]); err != nil {
+			return err
+		}
+	}
+	for i := range s.ClusterWorkflowTemplates {
+		if err := c.AddClusterWorkflowTemplate(&s.ClusterWorkflowTemplates[i]); err != nil {
+			return err
+		}
+	}
+	for i := range s.CronWorkflows {
+		if err := c.AddCronWorkflow(&s.CronWorkflows[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitLines splits a log into lines without their line endings.
+func splitLines(data []byte) []string {
+	var lines []string
+	for line := range bytes.Lines(data) {
+		lines = append(lines, string(bytes.TrimRight(line, "\r\n")))
+	}
+	return lines
+}
+
+// SetPodLogs replaces the log lines of a pod of a workflow.
+func (c *Client) SetPodLogs(namespace, workflow, pod string, lines ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := objectKey(namespace, workflow)
+	if c.logs[key] == nil {
+		c.logs[key] = make(map[string][]string)
+	}
+	c.logs[key][pod] = lines
+}
+
+// SetArtifact stores the content of an output artifact of a workflow node.
+func (c *Client) SetArtifact(namespace, workflow, nodeID, name string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.artifacts[artifactKey(namespace, workflow, nodeID, name)] = data
+}
+
+// artifactKey returns the store key of an artifact.
+func artifactKey(namespace, workflow, nodeID, name string) string {
+	return objectKey(namespace, workflow) + "/" + nodeID + "/" + name
+}
+
+// Events returns the events received through the event service, oldest first.
+func (c *Client) Events() []*event.EventRequest {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]*event.EventRequest(nil), c.events...)
+}
+
+// WorkflowService returns the workflow service client.
+func (c *Client) WorkflowService() workflow.WorkflowServiceClient {
+	return &workflowService{client: c}
+}
+
+// CronWorkflowService returns the cron workflow service client.
+func (c *Client) CronWorkflowService() (cronworkflow.CronWorkflowServiceClient, error) {
+	return &cronWorkflowService{client: c}, nil
+}
+
+// WorkflowTemplateService returns the workflow template service client.
+func (c *Client) WorkflowTemplateService() (workflowtemplate.WorkflowTemplateServiceClient, error) {
+	return &workflowTemplateService{client: c}, nil
+}
+
+// ClusterWorkflowTemplateService returns the cluster workflow template service client.
+func (c *Client) ClusterWorkflowTemplateService() (clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient, error) {
+	return &clusterWorkflowTemplateService{client: c}, nil
+}
+
+// ArchivedWorkflowService returns the archived workflow service client.
+// Workflows are archived when they complete.
+func (c *Client) ArchivedWorkflowService() (workflowarchive.ArchivedWorkflowServiceClient, error) {
+	return &archivedWorkflowService{client: c}, nil
+}
+
+// InfoService returns the info service client.
+func (c *Client) InfoService() (info.InfoServiceClient, error) {
+	return &infoService{client: c}, nil
+}
+
+// SyncService returns the sync limit service client.
+func (c *Client) SyncService() (syncpkg.SyncServiceClient, error) {
+	return &syncService{client: c}, nil
+}
+
+// EventService returns the event service client.
+func (c *Client) EventService() (event.EventServiceClient, error) {
+	return &eventService{client: c}, nil
+}
+
+// OpenArtifact opens an artifact stored with SetArtifact.
+func (c *Client) OpenArtifact(_ context.Context, req *argo.ArtifactRequest) (io.ReadCloser, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, ok := c.artifacts[artifactKey(req.Namespace, req.Workflow, req.NodeID, req.Name)]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "artifact %q of node %q not found", req.Name, req.NodeID)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// KubernetesClient returns argo.ErrKubernetesClientNotAvailable.
+func (c *Client) KubernetesClient() (kubernetes.Interface, error) {
+	return nil, argo.ErrKubernetesClientNotAvailable
+}
+
+// WorkflowClientset returns argo.ErrKubernetesClientNotAvailable.
+func (c *Client) WorkflowClientset() (versioned.Interface, error) {
+	return nil, argo.ErrKubernetesClientNotAvailable
+}
+
+// ControllerNamespace returns the conventional workflow controller namespace.
+func (c *Client) ControllerNamespace() string {
+	return "argo"
+}
+
+// IsArgoServerMode returns true, as the fake behaves like an Argo Server.
+func (c *Client) IsArgoServerMode() bool {
+	return true
+}
+
+// DefaultNamespace returns the first namespace the client was created with.
+func (c *Client) DefaultNamespace() string {
+	return c.defaultNamespace
+}
+
+// Context returns the context associated with this client.
+func (c *Client) Context() context.Context {
+	return c.ctx
+}
@@ -0,0 +1,496 @@
+package fake
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/cronworkflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/event"
+	syncpkg "github.com/argoproj/argo-workflows/v4/pkg/apiclient/sync"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowarchive"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/workflow/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// newTestWorkflow returns a single-template workflow with generateName hello-.
+func newTestWorkflow() *wfv1.Workflow {
+	return &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{GenerateName: "hello-"},
+		Spec: wfv1.WorkflowSpec{
+			Entrypoint: "main",
+			Templates:  []wfv1.Template{{Name: "main", Container: &corev1.Container{Image: "alpine"}}},
+			Arguments:  wfv1.Arguments{Parameters: []wfv1.Parameter{{Name: "message", Value: wfv1.AnyStringPtr("hi")}}},
+		},
+	}
+}
+
+// create creates newTestWorkflow in the argo namespace.
+func create(t *testing.T, client *Client) *wfv1.Workflow {
+	t.Helper()
+	wf, err := client.WorkflowService().CreateWorkflow(t.Context(), &workflow.WorkflowCreateRequest{Namespace: "argo", Workflow: newTestWorkflow()})
+	require.NoError(t, err)
+	return wf
+}
+
+// get returns the current state of a workflow in the argo namespace.
+func get(t *testing.T, client *Client, name string) *wfv1.Workflow {
+	t.Helper()
+	wf, err := client.WorkflowService().GetWorkflow(t.Context(), &workflow.WorkflowGetRequest{Namespace: "argo", Name: name})
+	require.NoError(t, err)
+	return wf
+}
+
+// readLogs drains a log stream into "pod: line" strings.
+func readLogs(t *testing.T, stream workflow.WorkflowService_WorkflowLogsClient) []string {
+	t.Helper()
+	var lines []string
+	for {
+		entry, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return lines
+		}
+		require.NoError(t, err)
+		lines = append(lines, entry.PodName+": "+entry.Content)
+	}
+}
+
+// failedScript is a timeline that fails with one failed and one succeeded node.
+func failedScript(name string) []Step {
+	return []Step{
+		{Phase: wfv1.WorkflowRunning},
+		{
+			Phase:   wfv1.WorkflowFailed,
+			Message: "child failed",
+			Nodes: wfv1.Nodes{
+				name:         {ID: name, Name: name, DisplayName: name, Type: wfv1.NodeTypeSteps, Phase: wfv1.NodeFailed},
+				name + "-ok": {ID: name + "-ok", Name: name + ".ok", DisplayName: "ok", Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded},
+				name + "-ko": {ID: name + "-ko", Name: name + ".ko", DisplayName: "ko", Type: wfv1.NodeTypePod, Phase: wfv1.NodeFailed},
+			},
+			Logs: map[string][]string{name + "-ko": {"starting", "boom"}},
+		},
+	}
+}
+
+func TestClient_Mode(t *testing.T) {
+	client := NewClient(t.Context())
+
+	assert.True(t, client.IsArgoServerMode())
+	assert.Equal(t, "default", client.DefaultNamespace())
+	_, err := client.KubernetesClient()
+	require.ErrorIs(t, err, argo.ErrKubernetesClientNotAvailable)
+	_, err = client.WorkflowClientset()
+	require.ErrorIs(t, err, argo.ErrKubernetesClientNotAvailable)
+}
+
+func TestClient_Namespaces(t *testing.T) {
+	client := NewClient(t.Context(), "argo")
+
+	t.Run("unknown namespace", func(t *testing.T) {
+		_, err := client.WorkflowService().CreateWorkflow(t.Context(), &workflow.WorkflowCreateRequest{Namespace: "dev", Workflow: newTestWorkflow()})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+
+	t.Run("object namespace differs from request", func(t *testing.T) {
+		wf := newTestWorkflow()
+		wf.Namespace = "dev"
+		_, err := client.WorkflowService().CreateWorkflow(t.Context(), &workflow.WorkflowCreateRequest{Namespace: "argo", Workflow: wf})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("workflows are not visible from other namespaces", func(t *testing.T) {
+		wf := create(t, client)
+		client.AddNamespace("dev")
+		_, err := client.WorkflowService().GetWorkflow(t.Context(), &workflow.WorkflowGetRequest{Namespace: "dev", Name: wf.Name})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		list, err := client.WorkflowService().ListWorkflows(t.Context(), &workflow.WorkflowListRequest{Namespace: "dev"})
+		require.NoError(t, err)
+		assert.Empty(t, list.Items)
+	})
+}
+
+func TestClient_CreateWorkflow(t *testing.T) {
+	client := NewClient(t.Context(), "argo")
+	client.SetClock(func() time.Time { return time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC) })
+
+	wf := create(t, client)
+	assert.Equal(t, "hello-1", wf.Name)
+	assert.Equal(t, "argo", wf.Namespace)
+	assert.NotEmpty(t, wf.UID)
+	assert.NotEmpty(t, wf.ResourceVersion)
+	assert.Equal(t, "2025-01-15T10:00:00Z", wf.CreationTimestamp.UTC().Format(time.RFC3339))
+	assert.Equal(t, wfv1.WorkflowPending, wf.Status.Phase)
+	assert.Equal(t, "Pending", wf.Labels[common.LabelKeyPhase])
+
+	again := create(t, client)
+	assert.Equal(t, "hello-2", again.Name)
+
+	duplicate := newTestWorkflow()
+	duplicate.Name = wf.Name
+	_, err := client.WorkflowService().CreateWorkflow(t.Context(), &workflow.WorkflowCreateRequest{Namespace: "argo", Workflow: duplicate})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	dryRun, err := client.WorkflowService().CreateWorkflow(t.Context(), &workflow.WorkflowCreateRequest{Namespace: "argo", Workflow: newTestWorkflow(), ServerDryRun: true})
+	require.NoError(t, err)
+	assert.Empty(t, dryRun.Name)
+	list, err := client.WorkflowService().ListWorkflows(t.Context(), &workflow.WorkflowListRequest{Namespace: "argo"})
+	require.NoError(t, err)
+	assert.Len(t, list.Items, 2)
+}
+
+func TestClient_Timeline(t *testing.T) {
+	client := NewClient(t.Context(), "argo")
+	wf := create(t, client)
+
+	assert.Equal(t, 1, client.Advance())
+	running := get(t, client, wf.Name)
+	assert.Equal(t, wfv1.WorkflowRunning, running.Status.Phase)
+	assert.False(t, running.Status.StartedAt.IsZero())
+	assert.Equal(t, wfv1.NodeRunning, running.Status.Nodes[wf.Name].Phase)
+
+	assert.Equal(t, 1, client.Settle())
+	succeeded := get(t, client, wf.Name)
+	assert.Equal(t, wfv1.WorkflowSucceeded, succeeded.Status.Phase)
+	assert.Equal(t, "true", succeeded.Labels[common.LabelKeyCompleted])
+	assert.Equal(t, 0, client.Advance())
+
+	archive, err := client.ArchivedWorkflowService()
+	require.NoError(t, err)
+	archived, err := archive.GetArchivedWorkflow(t.Context(), &workflowarchive.GetArchivedWorkflowRequest{Uid: string(wf.UID)})
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.WorkflowSucceeded, archived.Status.Phase)
+}
+
+func TestClient_WatchWorkflows(t *testing.T) {
+	client := NewClient(t.Context(), "argo")
+	wf := create(t, client)
+	other := create(t, client)
+
+	stream, err := client.WorkflowService().WatchWorkflows(t.Context(), &workflow.WatchWorkflowsRequest{
+		Namespace:   "argo",
+		ListOptions: &metav1.ListOptions{FieldSelector: "metadata.name=" + wf.Name},
+	})
+	require.NoError(t, err)
+
+	client.Settle()
+	var phases []string
+	for range 3 {
+		event, recvErr := stream.Recv()
+		require.NoError(t, recvErr)
+		assert.Equal(t, wf.Name, event.Object.Name, "events of %s are filtered out", other.Name)
+		phases = append(phases, event.Type+" "+string(event.Object.Status.Phase))
+	}
+	assert.Equal(t, []string{"ADDED Pending", "MODIFIED Running", "MODIFIED Succeeded"}, phases)
+}
+
+func TestClient_RetryWorkflow(t *testing.T) {
+	client := NewClient(t.Context(), "argo")
+	wf := create(t, client)
+	require.NoError(t, client.Script("argo", wf.Name, failedScript(wf.Name)...))
+
+	_, err := client.WorkflowService().RetryWorkflow(t.Context(), &workflow.WorkflowRetryRequest{Namespace: "argo", Name: wf.Name})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "running workflows cannot be retried")
+
+	client.Settle()
+	failed := get(t, client, wf.Name)
+	assert.Equal(t, wfv1.WorkflowFailed, failed.Status.Phase)
+	assert.Equal(t, "child failed", failed.Status.Message)
+
+	retried, err := client.WorkflowService().RetryWorkflow(t.Context(), &workflow.WorkflowRetryRequest{
+		Namespace:  "argo",
+		Name:       wf.Name,
+		Parameters: []string{"message=again"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.WorkflowRunning, retried.Status.Phase)
+	assert.Equal(t, []string{wf.Name + "-ok"}, nodeIDs(retried))
+	assert.Equal(t, "again", retried.Spec.Arguments.Parameters[0].Value.String())
+
+	client.Settle()
+	assert.Equal(t, wfv1.WorkflowSucceeded, get(t, client, wf.Name).Status.Phase)
+}
+
+// nodeIDs returns the IDs of the nodes of a workflow.
+func nodeIDs(wf *wfv1.Workflow) []string {
+	var ids []string
+	for id := range wf.Status.Nodes {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func TestClient_ResubmitWorkflow(t *testing.T) {
+	client := NewClient(t.Context(), "argo")
+	wf := create(t, client)
+	client.Settle()
+
+	resubmitted, err := client.WorkflowService().ResubmitWorkflow(t.Context(), &workflow.WorkflowResubmitRequest{Namespace: "argo", Name: wf.Name})
+	require.NoError(t, err)
+	assert.NotEqual(t, wf.Name, resubmitted.Name)
+	assert.Equal(t, wf.Name, resubmitted.Labels[common.LabelKeyPreviousWorkflowName])
+	assert.Equal(t, wfv1.WorkflowPending, resubmitted.Status.Phase)
+	assert.Equal(t, wf.Spec.Entrypoint, resubmitted.Spec.Entrypoint)
+
+	_, err = client.WorkflowService().ResubmitWorkflow(t.Context(), &workflow.WorkflowResubmitRequest{Namespace: "argo", Name: wf.Name, Memoized: true})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "memoized resubmission requires a failed workflow")
+}
+
+func TestClient_SuspendResume(t *testing.T) {
+	client := NewClient(t.Context(), "argo")
+	wf := create(t, client)
+	client.Advance()
+
+	_, err := client.WorkflowService().SuspendWorkflow(t.Context(), &workflow.WorkflowSuspendRequest{Namespace: "argo", Name: wf.Name})
+	require.NoError(t, err)
+	assert.Zero(t, client.Settle())
+	assert.Equal(t, wfv1.WorkflowRunning, get(t, client, wf.Name).Status.Phase)
+
+	_, err = client.WorkflowService().ResumeWorkflow(t.Context(), &workflow.WorkflowResumeRequest{Namespace: "argo", Name: wf.Name})
+	require.NoError(t, err)
+	client.Settle()
+	assert.Equal(t, wfv1.WorkflowSucceeded, get(t, client, wf.Name).Status.Phase)
+
+	_, err = client.WorkflowService().ResumeWorkflow(t.Context(), &workflow.WorkflowResumeRequest{Namespace: "argo", Name: wf.Name})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestClient_StopWorkflow(t *testing.T) {
+	client := NewClient(t.Context(), "argo")
+	wf := create(t, client)
+	client.Advance()
+
+	stopped, err := client.WorkflowService().StopWorkflow(t.Context(), &workflow.WorkflowStopRequest{Namespace: "argo", Name: wf.Name})
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.WorkflowFailed, stopped.Status.Phase)
+	assert.Equal(t, wfv1.ShutdownStrategyStop, stopped.Spec.Shutdown)
+	assert.Equal(t, wfv1.NodeFailed, stopped.Status.Nodes[wf.Name].Phase)
+	assert.Zero(t, client.Settle())
+
+	_, err = client.WorkflowService().TerminateWorkflow(t.Context(), &workflow.WorkflowTerminateRequest{Namespace: "argo", Name: wf.Name})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+}
+
+func TestClient_SetWorkflow(t *testing.T) {
+	client := NewClient(t.Context(), "argo")
+	wf := create(t, client)
+	client.Advance()
+
+	updated, err := client.WorkflowService().SetWorkflow(t.Context(), &workflow.WorkflowSetRequest{
+		Namespace:         "argo",
+		Name:              wf.Name,
+		NodeFieldSelector: "displayName=" + wf.Name,
+		Message:           "approved",
+		OutputParameters:  `{"approve":"yes"}`,
+	})
+	require.NoError(t, err)
+	node := updated.Status.Nodes[wf.Name]
+	assert.Equal(t, "approved", node.Message)
+	assert.Equal(t, "yes", node.Outputs.Parameters[0].Value.String())
+
+	_, err = client.WorkflowService().SetWorkflow(t.Context(), &workflow.WorkflowSetRequest{Namespace: "argo", Name: wf.Name, NodeFieldSelector: "displayName=missing"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestClient_WorkflowLogs(t *testing.T) {
+	client := NewClient(t.Context(), "argo")
+	wf := create(t, client)
+	require.NoError(t, client.Script("argo", wf.Name, failedScript(wf.Name)...))
+	client.Settle()
+	client.SetPodLogs("argo", wf.Name, wf.Name+"-ok", "done")
+
+	tests := []struct {
+		request *workflow.WorkflowLogRequest
+		name    string
+		want    []string
+	}{
+		{
+			name:    "all pods",
+			request: &workflow.WorkflowLogRequest{},
+			want:    []string{wf.Name + "-ko: starting", wf.Name + "-ko: boom", wf.Name + "-ok: done"},
+		},
+		{
+			name:    "one pod",
+			request: &workflow.WorkflowLogRequest{PodName: wf.Name + "-ok"},
+			want:    []string{wf.Name + "-ok: done"},
+		},
+		{
+			name:    "grep and tail",
+			request: &workflow.WorkflowLogRequest{Grep: "o", LogOptions: &corev1.PodLogOptions{TailLines: ptr.To[int64](1)}},
+			want:    []string{wf.Name + "-ko: boom", wf.Name + "-ok: done"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.request.Namespace = "argo"
+			tt.request.Name = wf.Name
+			stream, err := client.WorkflowService().WorkflowLogs(t.Context(), tt.request)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, readLogs(t, stream))
+		})
+	}
+}
+
+func TestClient_ArchivedWorkflows(t *testing.T) {
+	client := NewClient(t.Context(), "argo")
+	wf := create(t, client)
+	require.NoError(t, client.Script("argo", wf.Name, failedScript(wf.Name)...))
+	client.Settle()
+	archive, err := client.ArchivedWorkflowService()
+	require.NoError(t, err)
+
+	_, err = archive.RetryArchivedWorkflow(t.Context(), &workflowarchive.RetryArchivedWorkflowRequest{Uid: string(wf.UID)})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err), "the live workflow still exists")
+
+	_, err = client.WorkflowService().DeleteWorkflow(t.Context(), &workflow.WorkflowDeleteRequest{Namespace: "argo", Name: wf.Name})
+	require.NoError(t, err)
+	list, err := archive.ListArchivedWorkflows(t.Context(), &workflowarchive.ListArchivedWorkflowsRequest{Namespace: "argo"})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+
+	retried, err := archive.RetryArchivedWorkflow(t.Context(), &workflowarchive.RetryArchivedWorkflowRequest{Uid: string(wf.UID)})
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.WorkflowRunning, retried.Status.Phase)
+	client.Settle()
+	assert.Equal(t, wfv1.WorkflowSucceeded, get(t, client, wf.Name).Status.Phase)
+}
+
+func TestClient_SubmitWorkflow(t *testing.T) {
+	client := NewClient(t.Context(), "argo")
+	require.NoError(t, client.AddWorkflowTemplate(&wfv1.WorkflowTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "lib", Namespace: "argo"},
+		Spec:       newTestWorkflow().Spec,
+	}))
+	require.NoError(t, client.AddCronWorkflow(&wfv1.CronWorkflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "argo"},
+		Spec:       wfv1.CronWorkflowSpec{Schedules: []string{"0 0 * * *"}, WorkflowSpec: newTestWorkflow().Spec},
+	}))
+
+	tests := []struct {
+		name      string
+		kind      string
+		resource  string
+		wantLabel string
+		wantCode  codes.Code
+	}{
+		{name: "workflow template", kind: "WorkflowTemplate", resource: "lib", wantLabel: common.LabelKeyWorkflowTemplate},
+		{name: "cron workflow", kind: "cronwf", resource: "nightly", wantLabel: common.LabelKeyCronWorkflow},
+		{name: "missing template", kind: "WorkflowTemplate", resource: "missing", wantCode: codes.NotFound},
+		{name: "unknown kind", kind: "Pod", resource: "lib", wantCode: codes.InvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wf, err := client.WorkflowService().SubmitWorkflow(t.Context(), &workflow.WorkflowSubmitRequest{
+				Namespace:     "argo",
+				ResourceKind:  tt.kind,
+				ResourceName:  tt.resource,
+				SubmitOptions: &wfv1.SubmitOpts{Parameters: []string{"message=submitted"}, Labels: "team=data"},
+			})
+			if tt.wantCode != codes.OK {
+				assert.Equal(t, tt.wantCode, status.Code(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.resource, wf.Labels[tt.wantLabel])
+			assert.Equal(t, "data", wf.Labels["team"])
+			assert.Equal(t, "submitted", wf.Spec.Arguments.Parameters[0].Value.String())
+		})
+	}
+}
+
+func TestClient_UpdateConflict(t *testing.T) {
+	client := NewClient(t.Context(), "argo")
+	templates, err := client.WorkflowTemplateService()
+	require.NoError(t, err)
+	created, err := templates.CreateWorkflowTemplate(t.Context(), &workflowtemplate.WorkflowTemplateCreateRequest{
+		Namespace: "argo",
+		Template:  &wfv1.WorkflowTemplate{ObjectMeta: metav1.ObjectMeta{Name: "lib"}, Spec: newTestWorkflow().Spec},
+	})
+	require.NoError(t, err)
+
+	updated, err := templates.UpdateWorkflowTemplate(t.Context(), &workflowtemplate.WorkflowTemplateUpdateRequest{Namespace: "argo", Template: created})
+	require.NoError(t, err)
+	assert.NotEqual(t, created.ResourceVersion, updated.ResourceVersion)
+
+	_, err = templates.UpdateWorkflowTemplate(t.Context(), &workflowtemplate.WorkflowTemplateUpdateRequest{Namespace: "argo", Template: created})
+	assert.Equal(t, codes.Aborted, status.Code(err), "the resource version is stale")
+
+	invalid := updated.DeepCopy()
+	invalid.Spec.Entrypoint = "missing"
+	_, err = templates.UpdateWorkflowTemplate(t.Context(), &workflowtemplate.WorkflowTemplateUpdateRequest{Namespace: "argo", Template: invalid})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestClient_CronWorkflows(t *testing.T) {
+	client := NewClient(t.Context(), "argo")
+	crons, err := client.CronWorkflowService()
+	require.NoError(t, err)
+
+	_, err = crons.CreateCronWorkflow(t.Context(), &cronworkflow.CreateCronWorkflowRequest{
+		Namespace:    "argo",
+		CronWorkflow: &wfv1.CronWorkflow{ObjectMeta: metav1.ObjectMeta{Name: "nightly"}, Spec: wfv1.CronWorkflowSpec{WorkflowSpec: newTestWorkflow().Spec}},
+	})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "a schedule is required")
+
+	_, err = crons.CreateCronWorkflow(t.Context(), &cronworkflow.CreateCronWorkflowRequest{
+		Namespace: "argo",
+		CronWorkflow: &wfv1.CronWorkflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly"},
+			Spec:       wfv1.CronWorkflowSpec{Schedules: []string{"0 0 * * *"}, WorkflowSpec: newTestWorkflow().Spec},
+		},
+	})
+	require.NoError(t, err)
+
+	suspended, err := crons.SuspendCronWorkflow(t.Context(), &cronworkflow.CronWorkflowSuspendRequest{Namespace: "argo", Name: "nightly"})
+	require.NoError(t, err)
+	assert.True(t, suspended.Spec.Suspend)
+
+	_, err = crons.DeleteCronWorkflow(t.Context(), &cronworkflow.DeleteCronWorkflowRequest{Namespace: "argo", Name: "nightly"})
+	require.NoError(t, err)
+	_, err = crons.GetCronWorkflow(t.Context(), &cronworkflow.GetCronWorkflowRequest{Namespace: "argo", Name: "nightly"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestClient_SyncLimits(t *testing.T) {
+	client := NewClient(t.Context(), "argo")
+	sync, err := client.SyncService()
+	require.NoError(t, err)
+
+	_, err = sync.CreateSyncLimit(t.Context(), &syncpkg.CreateSyncLimitRequest{Namespace: "argo", CmName: "limits", Key: "gpu", Limit: 2})
+	require.NoError(t, err)
+	_, err = sync.CreateSyncLimit(t.Context(), &syncpkg.CreateSyncLimitRequest{Namespace: "argo", CmName: "limits", Key: "gpu", Limit: 2})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	_, err = sync.UpdateSyncLimit(t.Context(), &syncpkg.UpdateSyncLimitRequest{Namespace: "argo", CmName: "limits", Key: "gpu", Limit: 4})
+	require.NoError(t, err)
+	limit, err := sync.GetSyncLimit(t.Context(), &syncpkg.GetSyncLimitRequest{Namespace: "argo", CmName: "limits", Key: "gpu"})
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), limit.Limit)
+}
+
+func TestClient_Events(t *testing.T) {
+	client := NewClient(t.Context(), "argo")
+	events, err := client.EventService()
+	require.NoError(t, err)
+
+	_, err = events.ReceiveEvent(t.Context(), &event.EventRequest{Namespace: "argo", Discriminator: "push"})
+	require.NoError(t, err)
+	_, err = events.ReceiveEvent(t.Context(), &event.EventRequest{Namespace: "dev", Discriminator: "push"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	received := client.Events()
+	require.Len(t, received, 1)
+	assert.Equal(t, "push", received[0].Discriminator)
+}
@@ -0,0 +1,712 @@
+package fake
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/clusterworkflowtemplate"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/cronworkflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/event"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/info"
+	syncpkg "github.com/argoproj/argo-workflows/v4/pkg/apiclient/sync"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowarchive"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// archivedWorkflowService serves the workflow archive of the fake.
+type archivedWorkflowService struct {
+	client *Client
+}
+
+// archivedWorkflow returns an archived workflow by UID, or by namespace and
+// name when no UID is given. Callers hold c.mu.
+func (c *Client) archivedWorkflow(uid, namespace, name string) (*wfv1.Workflow, error) {
+	if uid != "" {
+		if wf, ok := c.archived[types.UID(uid)]; ok {
+			return wf, nil
+		}
+		return nil, status.Errorf(codes.NotFound, "archived workflow %q not found", uid)
+	}
+	var found *wfv1.Workflow
+	for _, wf := range c.archived {
+		if wf.Namespace == namespace && wf.Name == name && (found == nil || newestFirst(wf, found) < 0) {
+			found = wf
+		}
+	}
+	if found == nil {
+		return nil, status.Errorf(codes.NotFound, "archived workflow %s/%s not found", namespace, name)
+	}
+	return found, nil
+}
+
+// ListArchivedWorkflows implements workflowarchive.ArchivedWorkflowServiceClient.
+func (s *archivedWorkflowService) ListArchivedWorkflows(_ context.Context, in *workflowarchive.ListArchivedWorkflowsRequest, _ ...grpc.CallOption) (*wfv1.WorkflowList, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	filter, err := newListFilter(in.Namespace, in.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &wfv1.WorkflowList{}
+	for _, wf := range slices.SortedFunc(maps.Values(c.archived), newestFirst) {
+		if filter.full(len(list.Items)) {
+			break
+		}
+		if filter.matches(&wf.ObjectMeta) && strings.HasPrefix(wf.Name, in.NamePrefix) {
+			list.Items = append(list.Items, *wf.DeepCopy())
+		}
+	}
+	return list, nil
+}
+
+// GetArchivedWorkflow implements workflowarchive.ArchivedWorkflowServiceClient.
+func (s *archivedWorkflowService) GetArchivedWorkflow(_ context.Context, in *workflowarchive.GetArchivedWorkflowRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	wf, err := c.archivedWorkflow(in.Uid, in.Namespace, in.Name)
+	if err != nil {
+		return nil, err
+	}
+	return wf.DeepCopy(), nil
+}
+
+// DeleteArchivedWorkflow implements workflowarchive.ArchivedWorkflowServiceClient.
+func (s *archivedWorkflowService) DeleteArchivedWorkflow(_ context.Context, in *workflowarchive.DeleteArchivedWorkflowRequest, _ ...grpc.CallOption) (*workflowarchive.ArchivedWorkflowDeletedResponse, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.archivedWorkflow(in.Uid, "", ""); err != nil {
+		return nil, err
+	}
+	delete(c.archived, types.UID(in.Uid))
+	return &workflowarchive.ArchivedWorkflowDeletedResponse{}, nil
+}
+
+// ListArchivedWorkflowLabelKeys implements workflowarchive.ArchivedWorkflowServiceClient.
+func (s *archivedWorkflowService) ListArchivedWorkflowLabelKeys(_ context.Context, in *workflowarchive.ListArchivedWorkflowLabelKeysRequest, _ ...grpc.CallOption) (*wfv1.LabelKeys, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make(map[string]bool)
+	for _, wf := range c.archived {
+		if in.Namespace == "" || wf.Namespace == in.Namespace {
+			for key := range wf.Labels {
+				keys[key] = true
+			}
+		}
+	}
+	return &wfv1.LabelKeys{Items: slices.Sorted(maps.Keys(keys))}, nil
+}
+
+// ListArchivedWorkflowLabelValues implements workflowarchive.ArchivedWorkflowServiceClient.
+// Like the Argo Server, the label key is read from the label selector.
+func (s *archivedWorkflowService) ListArchivedWorkflowLabelValues(_ context.Context, in *workflowarchive.ListArchivedWorkflowLabelValuesRequest, _ ...grpc.CallOption) (*wfv1.LabelValues, error) {
+	var key string
+	if in.ListOptions != nil {
+		key = in.ListOptions.LabelSelector
+	}
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	values := make(map[string]bool)
+	for _, wf := range c.archived {
+		if value, ok := wf.Labels[key]; ok && (in.Namespace == "" || wf.Namespace == in.Namespace) {
+			values[value] = true
+		}
+	}
+	return &wfv1.LabelValues{Items: slices.Sorted(maps.Keys(values))}, nil
+}
+
+// RetryArchivedWorkflow implements workflowarchive.ArchivedWorkflowServiceClient.
+// The archived workflow is restored and retried; this fails with AlreadyExists
+// while a workflow with the same name exists.
+func (s *archivedWorkflowService) RetryArchivedWorkflow(_ context.Context, in *workflowarchive.RetryArchivedWorkflowRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	archived, err := c.archivedWorkflow(in.Uid, in.Namespace, in.Name)
+	if err != nil {
+		return nil, err
+	}
+	key := objectKey(archived.Namespace, archived.Name)
+	if _, ok := c.workflows[key]; ok {
+		return nil, alreadyExists("workflows", archived.Name)
+	}
+
+	wf := archived.DeepCopy()
+	c.workflows[key] = wf
+	if err := c.retry(wf, in.RestartSuccessful, in.NodeFieldSelector, in.Parameters); err != nil {
+		delete(c.workflows, key)
+		return nil, err
+	}
+	return wf.DeepCopy(), nil
+}
+
+// ResubmitArchivedWorkflow implements workflowarchive.ArchivedWorkflowServiceClient.
+func (s *archivedWorkflowService) ResubmitArchivedWorkflow(_ context.Context, in *workflowarchive.ResubmitArchivedWorkflowRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	archived, err := c.archivedWorkflow(in.Uid, in.Namespace, in.Name)
+	if err != nil {
+		return nil, err
+	}
+	return c.resubmit(archived, in.Memoized, in.Parameters)
+}
+
+// createCronWorkflow stores a new CronWorkflow without linting it. Callers hold c.mu.
+func (c *Client) createCronWorkflow(cwf *wfv1.CronWorkflow, namespace string) (*wfv1.CronWorkflow, error) {
+	if err := c.admit(&cwf.ObjectMeta, namespace); err != nil {
+		return nil, err
+	}
+	key := objectKey(cwf.Namespace, cwf.Name)
+	if _, ok := c.cronWorkflows[key]; ok {
+		return nil, alreadyExists("cronworkflows", cwf.Name)
+	}
+	c.cronWorkflows[key] = cwf
+	return cwf.DeepCopy(), nil
+}
+
+// cronWorkflow returns a stored CronWorkflow. Callers hold c.mu.
+func (c *Client) cronWorkflow(namespace, name string) (*wfv1.CronWorkflow, error) {
+	if err := c.checkNamespace(namespace); err != nil {
+		return nil, err
+	}
+	cwf, ok := c.cronWorkflows[objectKey(namespace, name)]
+	if !ok {
+		return nil, notFound("cronworkflows", name)
+	}
+	return cwf, nil
+}
+
+// lintCronWorkflow checks that a CronWorkflow has a schedule and a valid workflow spec.
+func lintCronWorkflow(cwf *wfv1.CronWorkflow) error {
+	if len(cwf.Spec.GetSchedules()) == 0 {
+		return status.Error(codes.InvalidArgument, "cron workflow must have at least one schedule")
+	}
+	return lintSpec(&cwf.Spec.WorkflowSpec)
+}
+
+// cronWorkflowService serves the CronWorkflows of the fake. CronWorkflows are
+// stored but never scheduled; use SubmitWorkflow to run one.
+type cronWorkflowService struct {
+	client *Client
+}
+
+// LintCronWorkflow implements cronworkflow.CronWorkflowServiceClient.
+func (s *cronWorkflowService) LintCronWorkflow(_ context.Context, in *cronworkflow.LintCronWorkflowRequest, _ ...grpc.CallOption) (*wfv1.CronWorkflow, error) {
+	if in.CronWorkflow == nil {
+		return nil, status.Error(codes.InvalidArgument, "cron workflow body not specified")
+	}
+	if err := lintCronWorkflow(in.CronWorkflow); err != nil {
+		return nil, err
+	}
+	return in.CronWorkflow.DeepCopy(), nil
+}
+
+// CreateCronWorkflow implements cronworkflow.CronWorkflowServiceClient.
+func (s *cronWorkflowService) CreateCronWorkflow(_ context.Context, in *cronworkflow.CreateCronWorkflowRequest, _ ...grpc.CallOption) (*wfv1.CronWorkflow, error) {
+	if in.CronWorkflow == nil {
+		return nil, status.Error(codes.InvalidArgument, "cron workflow body not specified")
+	}
+	if err := lintCronWorkflow(in.CronWorkflow); err != nil {
+		return nil, err
+	}
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.createCronWorkflow(in.CronWorkflow.DeepCopy(), in.Namespace)
+}
+
+// ListCronWorkflows implements cronworkflow.CronWorkflowServiceClient.
+func (s *cronWorkflowService) ListCronWorkflows(_ context.Context, in *cronworkflow.ListCronWorkflowsRequest, _ ...grpc.CallOption) (*wfv1.CronWorkflowList, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	filter, err := c.listFilter(in.Namespace, in.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &wfv1.CronWorkflowList{}
+	for _, cwf := range slices.SortedFunc(maps.Values(c.cronWorkflows), byName) {
+		if filter.full(len(list.Items)) {
+			break
+		}
+		if filter.matches(&cwf.ObjectMeta) {
+			list.Items = append(list.Items, *cwf.DeepCopy())
+		}
+	}
+	return list, nil
+}
+
+// GetCronWorkflow implements cronworkflow.CronWorkflowServiceClient.
+func (s *cronWorkflowService) GetCronWorkflow(_ context.Context, in *cronworkflow.GetCronWorkflowRequest, _ ...grpc.CallOption) (*wfv1.CronWorkflow, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cwf, err := c.cronWorkflow(in.Namespace, in.Name)
+	if err != nil {
+		return nil, err
+	}
+	return cwf.DeepCopy(), nil
+}
+
+// UpdateCronWorkflow implements cronworkflow.CronWorkflowServiceClient. A
+// resource version that does not match the stored one is a conflict.
+func (s *cronWorkflowService) UpdateCronWorkflow(_ context.Context, in *cronworkflow.UpdateCronWorkflowRequest, _ ...grpc.CallOption) (*wfv1.CronWorkflow, error) {
+	if in.CronWorkflow == nil {
+		return nil, status.Error(codes.InvalidArgument, "cron workflow body not specified")
+	}
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	current, err := c.cronWorkflow(in.Namespace, in.CronWorkflow.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResourceVersion("cronworkflows.argoproj.io", current.Name, in.CronWorkflow.ResourceVersion, current.ResourceVersion); err != nil {
+		return nil, err
+	}
+	if err := lintCronWorkflow(in.CronWorkflow); err != nil {
+		return nil, err
+	}
+
+	updated := in.CronWorkflow.DeepCopy()
+	updated.ObjectMeta = *current.ObjectMeta.DeepCopy()
+	updated.Labels = in.CronWorkflow.Labels
+	updated.Annotations = in.CronWorkflow.Annotations
+	updated.Generation++
+	updated.ResourceVersion = c.nextResourceVersion()
+	c.cronWorkflows[objectKey(current.Namespace, current.Name)] = updated
+	return updated.DeepCopy(), nil
+}
+
+// DeleteCronWorkflow implements cronworkflow.CronWorkflowServiceClient.
+func (s *cronWorkflowService) DeleteCronWorkflow(_ context.Context, in *cronworkflow.DeleteCronWorkflowRequest, _ ...grpc.CallOption) (*cronworkflow.CronWorkflowDeletedResponse, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.cronWorkflow(in.Namespace, in.Name); err != nil {
+		return nil, err
+	}
+	delete(c.cronWorkflows, objectKey(in.Namespace, in.Name))
+	return &cronworkflow.CronWorkflowDeletedResponse{}, nil
+}
+
+// ResumeCronWorkflow implements cronworkflow.CronWorkflowServiceClient.
+func (s *cronWorkflowService) ResumeCronWorkflow(_ context.Context, in *cronworkflow.CronWorkflowResumeRequest, _ ...grpc.CallOption) (*wfv1.CronWorkflow, error) {
+	return s.client.setCronSuspend(in.Namespace, in.Name, false)
+}
+
+// SuspendCronWorkflow implements cronworkflow.CronWorkflowServiceClient.
+func (s *cronWorkflowService) SuspendCronWorkflow(_ context.Context, in *cronworkflow.CronWorkflowSuspendRequest, _ ...grpc.CallOption) (*wfv1.CronWorkflow, error) {
+	return s.client.setCronSuspend(in.Namespace, in.Name, true)
+}
+
+// setCronSuspend suspends or resumes a CronWorkflow.
+func (c *Client) setCronSuspend(namespace, name string, suspend bool) (*wfv1.CronWorkflow, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cwf, err := c.cronWorkflow(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	cwf.Spec.Suspend = suspend
+	cwf.ResourceVersion = c.nextResourceVersion()
+	return cwf.DeepCopy(), nil
+}
+
+// createWorkflowTemplate stores a new WorkflowTemplate without linting it. Callers hold c.mu.
+func (c *Client) createWorkflowTemplate(wft *wfv1.WorkflowTemplate, namespace string) (*wfv1.WorkflowTemplate, error) {
+	if err := c.admit(&wft.ObjectMeta, namespace); err != nil {
+		return nil, err
+	}
+	key := objectKey(wft.Namespace, wft.Name)
+	if _, ok := c.workflowTemplates[key]; ok {
+		return nil, alreadyExists("workflowtemplates", wft.Name)
+	}
+	c.workflowTemplates[key] = wft
+	return wft.DeepCopy(), nil
+}
+
+// workflowTemplate returns a stored WorkflowTemplate. Callers hold c.mu.
+func (c *Client) workflowTemplate(namespace, name string) (*wfv1.WorkflowTemplate, error) {
+	if err := c.checkNamespace(namespace); err != nil {
+		return nil, err
+	}
+	wft, ok := c.workflowTemplates[objectKey(namespace, name)]
+	if !ok {
+		return nil, notFound("workflowtemplates", name)
+	}
+	return wft, nil
+}
+
+// workflowTemplateService serves the WorkflowTemplates of the fake.
+type workflowTemplateService struct {
+	client *Client
+}
+
+// CreateWorkflowTemplate implements workflowtemplate.WorkflowTemplateServiceClient.
+func (s *workflowTemplateService) CreateWorkflowTemplate(_ context.Context, in *workflowtemplate.WorkflowTemplateCreateRequest, _ ...grpc.CallOption) (*wfv1.WorkflowTemplate, error) {
+	if in.Template == nil {
+		return nil, status.Error(codes.InvalidArgument, "workflow template body not specified")
+	}
+	if err := lintSpec(&in.Template.Spec); err != nil {
+		return nil, err
+	}
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.createWorkflowTemplate(in.Template.DeepCopy(), in.Namespace)
+}
+
+// GetWorkflowTemplate implements workflowtemplate.WorkflowTemplateServiceClient.
+func (s *workflowTemplateService) GetWorkflowTemplate(_ context.Context, in *workflowtemplate.WorkflowTemplateGetRequest, _ ...grpc.CallOption) (*wfv1.WorkflowTemplate, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	wft, err := c.workflowTemplate(in.Namespace, in.Name)
+	if err != nil {
+		return nil, err
+	}
+	return wft.DeepCopy(), nil
+}
+
+// ListWorkflowTemplates implements workflowtemplate.WorkflowTemplateServiceClient.
+func (s *workflowTemplateService) ListWorkflowTemplates(_ context.Context, in *workflowtemplate.WorkflowTemplateListRequest, _ ...grpc.CallOption) (*wfv1.WorkflowTemplateList, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	filter, err := c.listFilter(in.Namespace, in.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &wfv1.WorkflowTemplateList{}
+	for _, wft := range slices.SortedFunc(maps.Values(c.workflowTemplates), byName) {
+		if filter.full(len(list.Items)) {
+			break
+		}
+		if filter.matches(&wft.ObjectMeta) && strings.Contains(wft.Name, in.NamePattern) {
+			list.Items = append(list.Items, *wft.DeepCopy())
+		}
+	}
+	return list, nil
+}
+
+// UpdateWorkflowTemplate implements workflowtemplate.WorkflowTemplateServiceClient.
+// A resource version that does not match the stored one is a conflict.
+func (s *workflowTemplateService) UpdateWorkflowTemplate(_ context.Context, in *workflowtemplate.WorkflowTemplateUpdateRequest, _ ...grpc.CallOption) (*wfv1.WorkflowTemplate, error) {
+	if in.Template == nil {
+		return nil, status.Error(codes.InvalidArgument, "workflow template body not specified")
+	}
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	current, err := c.workflowTemplate(in.Namespace, in.Template.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResourceVersion("workflowtemplates.argoproj.io", current.Name, in.Template.ResourceVersion, current.ResourceVersion); err != nil {
+		return nil, err
+	}
+	if err := lintSpec(&in.Template.Spec); err != nil {
+		return nil, err
+	}
+
+	updated := in.Template.DeepCopy()
+	updated.ObjectMeta = *current.ObjectMeta.DeepCopy()
+	updated.Labels = in.Template.Labels
+	updated.Annotations = in.Template.Annotations
+	updated.Generation++
+	updated.ResourceVersion = c.nextResourceVersion()
+	c.workflowTemplates[objectKey(current.Namespace, current.Name)] = updated
+	return updated.DeepCopy(), nil
+}
+
+// DeleteWorkflowTemplate implements workflowtemplate.WorkflowTemplateServiceClient.
+func (s *workflowTemplateService) DeleteWorkflowTemplate(_ context.Context, in *workflowtemplate.WorkflowTemplateDeleteRequest, _ ...grpc.CallOption) (*workflowtemplate.WorkflowTemplateDeleteResponse, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.workflowTemplate(in.Namespace, in.Name); err != nil {
+		return nil, err
+	}
+	delete(c.workflowTemplates, objectKey(in.Namespace, in.Name))
+	return &workflowtemplate.WorkflowTemplateDeleteResponse{}, nil
+}
+
+// LintWorkflowTemplate implements workflowtemplate.WorkflowTemplateServiceClient.
+func (s *workflowTemplateService) LintWorkflowTemplate(_ context.Context, in *workflowtemplate.WorkflowTemplateLintRequest, _ ...grpc.CallOption) (*wfv1.WorkflowTemplate, error) {
+	if in.Template == nil {
+		return nil, status.Error(codes.InvalidArgument, "workflow template body not specified")
+	}
+	if err := lintSpec(&in.Template.Spec); err != nil {
+		return nil, err
+	}
+	return in.Template.DeepCopy(), nil
+}
+
+// createClusterWorkflowTemplate stores a new ClusterWorkflowTemplate without linting it. Callers hold c.mu.
+func (c *Client) createClusterWorkflowTemplate(cwft *wfv1.ClusterWorkflowTemplate) (*wfv1.ClusterWorkflowTemplate, error) {
+	cwft.Namespace = ""
+	if err := c.admit(&cwft.ObjectMeta, ""); err != nil {
+		return nil, err
+	}
+	if _, ok := c.clusterWorkflowTemplates[cwft.Name]; ok {
+		return nil, alreadyExists("clusterworkflowtemplates", cwft.Name)
+	}
+	c.clusterWorkflowTemplates[cwft.Name] = cwft
+	return cwft.DeepCopy(), nil
+}
+
+// clusterWorkflowTemplate returns a stored ClusterWorkflowTemplate. Callers hold c.mu.
+func (c *Client) clusterWorkflowTemplate(name string) (*wfv1.ClusterWorkflowTemplate, error) {
+	cwft, ok := c.clusterWorkflowTemplates[name]
+	if !ok {
+		return nil, notFound("clusterworkflowtemplates", name)
+	}
+	return cwft, nil
+}
+
+// clusterWorkflowTemplateService serves the ClusterWorkflowTemplates of the fake.
+type clusterWorkflowTemplateService struct {
+	client *Client
+}
+
+// CreateClusterWorkflowTemplate implements clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient.
+func (s *clusterWorkflowTemplateService) CreateClusterWorkflowTemplate(_ context.Context, in *clusterworkflowtemplate.ClusterWorkflowTemplateCreateRequest, _ ...grpc.CallOption) (*wfv1.ClusterWorkflowTemplate, error) {
+	if in.Template == nil {
+		return nil, status.Error(codes.InvalidArgument, "cluster workflow template body not specified")
+	}
+	if err := lintSpec(&in.Template.Spec); err != nil {
+		return nil, err
+	}
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.createClusterWorkflowTemplate(in.Template.DeepCopy())
+}
+
+// GetClusterWorkflowTemplate implements clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient.
+func (s *clusterWorkflowTemplateService) GetClusterWorkflowTemplate(_ context.Context, in *clusterworkflowtemplate.ClusterWorkflowTemplateGetRequest, _ ...grpc.CallOption) (*wfv1.ClusterWorkflowTemplate, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cwft, err := c.clusterWorkflowTemplate(in.Name)
+	if err != nil {
+		return nil, err
+	}
+	return cwft.DeepCopy(), nil
+}
+
+// ListClusterWorkflowTemplates implements clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient.
+func (s *clusterWorkflowTemplateService) ListClusterWorkflowTemplates(_ context.Context, in *clusterworkflowtemplate.ClusterWorkflowTemplateListRequest, _ ...grpc.CallOption) (*wfv1.ClusterWorkflowTemplateList, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	filter, err := newListFilter("", in.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &wfv1.ClusterWorkflowTemplateList{}
+	for _, cwft := range slices.SortedFunc(maps.Values(c.clusterWorkflowTemplates), byName) {
+		if filter.full(len(list.Items)) {
+			break
+		}
+		if filter.matches(&cwft.ObjectMeta) {
+			list.Items = append(list.Items, *cwft.DeepCopy())
+		}
+	}
+	return list, nil
+}
+
+// UpdateClusterWorkflowTemplate implements clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient.
+// A resource version that does not match the stored one is a conflict.
+func (s *clusterWorkflowTemplateService) UpdateClusterWorkflowTemplate(_ context.Context, in *clusterworkflowtemplate.ClusterWorkflowTemplateUpdateRequest, _ ...grpc.CallOption) (*wfv1.ClusterWorkflowTemplate, error) {
+	if in.Template == nil {
+		return nil, status.Error(codes.InvalidArgument, "cluster workflow template body not specified")
+	}
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	current, err := c.clusterWorkflowTemplate(in.Template.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkResourceVersion("clusterworkflowtemplates.argoproj.io", current.Name, in.Template.ResourceVersion, current.ResourceVersion); err != nil {
+		return nil, err
+	}
+	if err := lintSpec(&in.Template.Spec); err != nil {
+		return nil, err
+	}
+
+	updated := in.Template.DeepCopy()
+	updated.ObjectMeta = *current.ObjectMeta.DeepCopy()
+	updated.Labels = in.Template.Labels
+	updated.Annotations = in.Template.Annotations
+	updated.Generation++
+	updated.ResourceVersion = c.nextResourceVersion()
+	c.clusterWorkflowTemplates[current.Name] = updated
+	return updated.DeepCopy(), nil
+}
+
+// DeleteClusterWorkflowTemplate implements clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient.
+func (s *clusterWorkflowTemplateService) DeleteClusterWorkflowTemplate(_ context.Context, in *clusterworkflowtemplate.ClusterWorkflowTemplateDeleteRequest, _ ...grpc.CallOption) (*clusterworkflowtemplate.ClusterWorkflowTemplateDeleteResponse, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.clusterWorkflowTemplate(in.Name); err != nil {
+		return nil, err
+	}
+	delete(c.clusterWorkflowTemplates, in.Name)
+	return &clusterworkflowtemplate.ClusterWorkflowTemplateDeleteResponse{}, nil
+}
+
+// LintClusterWorkflowTemplate implements clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient.
+func (s *clusterWorkflowTemplateService) LintClusterWorkflowTemplate(_ context.Context, in *clusterworkflowtemplate.ClusterWorkflowTemplateLintRequest, _ ...grpc.CallOption) (*wfv1.ClusterWorkflowTemplate, error) {
+	if in.Template == nil {
+		return nil, status.Error(codes.InvalidArgument, "cluster workflow template body not specified")
+	}
+	if err := lintSpec(&in.Template.Spec); err != nil {
+		return nil, err
+	}
+	return in.Template.DeepCopy(), nil
+}
+
+// infoService reports the fake's version.
+type infoService struct {
+	client *Client
+}
+
+// GetInfo implements info.InfoServiceClient.
+func (s *infoService) GetInfo(context.Context, *info.GetInfoRequest, ...grpc.CallOption) (*info.InfoResponse, error) {
+	return &info.InfoResponse{ManagedNamespace: s.client.defaultNamespace}, nil
+}
+
+// GetVersion implements info.InfoServiceClient.
+func (s *infoService) GetVersion(context.Context, *info.GetVersionRequest, ...grpc.CallOption) (*wfv1.Version, error) {
+	return &wfv1.Version{Version: Version}, nil
+}
+
+// GetUserInfo implements info.InfoServiceClient.
+func (s *infoService) GetUserInfo(context.Context, *info.GetUserInfoRequest, ...grpc.CallOption) (*info.GetUserInfoResponse, error) {
+	return &info.GetUserInfoResponse{}, nil
+}
+
+// CollectEvent implements info.InfoServiceClient.
+func (s *infoService) CollectEvent(context.Context, *info.CollectEventRequest, ...grpc.CallOption) (*info.CollectEventResponse, error) {
+	return &info.CollectEventResponse{}, nil
+}
+
+// syncLimitKey returns the store key of a sync limit.
+func syncLimitKey(configType syncpkg.SyncConfigType, namespace, cmName, key string) string {
+	return configType.String() + "/" + namespace + "/" + cmName + "/" + key
+}
+
+// syncService stores semaphore limits.
+type syncService struct {
+	client *Client
+}
+
+// CreateSyncLimit implements syncpkg.SyncServiceClient.
+func (s *syncService) CreateSyncLimit(_ context.Context, in *syncpkg.CreateSyncLimitRequest, _ ...grpc.CallOption) (*syncpkg.SyncLimitResponse, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.checkNamespace(in.Namespace); err != nil {
+		return nil, err
+	}
+	key := syncLimitKey(in.Type, in.Namespace, in.CmName, in.Key)
+	if _, ok := c.syncLimits[key]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "sync limit %s/%s already exists", in.Namespace, in.Key)
+	}
+	c.syncLimits[key] = in.Limit
+	return &syncpkg.SyncLimitResponse{Type: in.Type, Namespace: in.Namespace, CmName: in.CmName, Key: in.Key, Limit: in.Limit}, nil
+}
+
+// GetSyncLimit implements syncpkg.SyncServiceClient.
+func (s *syncService) GetSyncLimit(_ context.Context, in *syncpkg.GetSyncLimitRequest, _ ...grpc.CallOption) (*syncpkg.SyncLimitResponse, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	limit, ok := c.syncLimits[syncLimitKey(in.Type, in.Namespace, in.CmName, in.Key)]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "sync limit %s/%s not found", in.Namespace, in.Key)
+	}
+	return &syncpkg.SyncLimitResponse{Type: in.Type, Namespace: in.Namespace, CmName: in.CmName, Key: in.Key, Limit: limit}, nil
+}
+
+// UpdateSyncLimit implements syncpkg.SyncServiceClient.
+func (s *syncService) UpdateSyncLimit(_ context.Context, in *syncpkg.UpdateSyncLimitRequest, _ ...grpc.CallOption) (*syncpkg.SyncLimitResponse, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	key := syncLimitKey(in.Type, in.Namespace, in.CmName, in.Key)
+	if _, ok := c.syncLimits[key]; !ok {
+		return nil, status.Errorf(codes.NotFound, "sync limit %s/%s not found", in.Namespace, in.Key)
+	}
+	c.syncLimits[key] = in.Limit
+	return &syncpkg.SyncLimitResponse{Type: in.Type, Namespace: in.Namespace, CmName: in.CmName, Key: in.Key, Limit: in.Limit}, nil
+}
+
+// DeleteSyncLimit implements syncpkg.SyncServiceClient.
+func (s *syncService) DeleteSyncLimit(_ context.Context, in *syncpkg.DeleteSyncLimitRequest, _ ...grpc.CallOption) (*syncpkg.DeleteSyncLimitResponse, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.syncLimits, syncLimitKey(in.Type, in.Namespace, in.CmName, in.Key))
+	return &syncpkg.DeleteSyncLimitResponse{}, nil
+}
+
+// eventService records received events and serves WorkflowEventBindings.
+// Events are not matched against bindings; tests inspect them with Events.
+type eventService struct {
+	client *Client
+}
+
+// ReceiveEvent implements event.EventServiceClient.
+func (s *eventService) ReceiveEvent(_ context.Context, in *event.EventRequest, _ ...grpc.CallOption) (*event.EventResponse, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.checkNamespace(in.Namespace); err != nil {
+		return nil, err
+	}
+	c.events = append(c.events, in)
+	return &event.EventResponse{}, nil
+}
+
+// ListWorkflowEventBindings implements event.EventServiceClient.
+func (s *eventService) ListWorkflowEventBindings(_ context.Context, in *event.ListWorkflowEventBindingsRequest, _ ...grpc.CallOption) (*wfv1.WorkflowEventBindingList, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	filter, err := c.listFilter(in.Namespace, in.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &wfv1.WorkflowEventBindingList{}
+	for _, binding := range slices.SortedFunc(maps.Values(c.eventBindings), byName) {
+		if filter.full(len(list.Items)) {
+			break
+		}
+		if filter.matches(&binding.ObjectMeta) {
+			list.Items = append(list.Items, *binding.DeepCopy())
+		}
+	}
+	return list, nil
+}
@@ -0,0 +1,171 @@
+package fake
+
+import (
+	"cmp"
+	"context"
+	"io"
+	"sync"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// stream is a gRPC client stream fed by the fake. Recv blocks until an item
+// is pushed, the stream is closed or its context is done.
+type stream[T any] struct {
+	ctx    context.Context //nolint:containedctx // Required for grpc.ClientStream interface
+	ready  chan struct{}
+	items  []T
+	mu     sync.Mutex
+	closed bool
+}
+
+// newStream creates an open stream.
+func newStream[T any](ctx context.Context) *stream[T] {
+	return &stream[T]{ctx: ctx, ready: make(chan struct{}, 1)}
+}
+
+// newClosedStream creates a stream that returns items and then io.EOF.
+func newClosedStream[T any](ctx context.Context, items []T) *stream[T] {
+	s := newStream[T](ctx)
+	s.items = items
+	s.closed = true
+	return s
+}
+
+// push queues an item for Recv.
+func (s *stream[T]) push(item T) {
+	s.mu.Lock()
+	s.items = append(s.items, item)
+	s.mu.Unlock()
+	s.signal()
+}
+
+// close makes Recv return io.EOF once the queued items were returned.
+func (s *stream[T]) close() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	s.signal()
+}
+
+// signal wakes a waiting Recv.
+func (s *stream[T]) signal() {
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+// Recv returns the next item, or io.EOF once the stream was closed and all
+// items were returned.
+func (s *stream[T]) Recv() (T, error) {
+	var zero T
+	for {
+		if err := s.ctx.Err(); err != nil {
+			return zero, err
+		}
+
+		s.mu.Lock()
+		if len(s.items) > 0 {
+			item := s.items[0]
+			s.items = s.items[1:]
+			s.mu.Unlock()
+			return item, nil
+		}
+		closed := s.closed
+		s.mu.Unlock()
+		if closed {
+			return zero, io.EOF
+		}
+
+		select {
+		case <-s.ready:
+		case <-s.ctx.Done():
+		}
+	}
+}
+
+// Header implements grpc.ClientStream.
+func (s *stream[T]) Header() (metadata.MD, error) { return nil, nil }
+
+// Trailer implements grpc.ClientStream.
+func (s *stream[T]) Trailer() metadata.MD { return nil }
+
+// CloseSend implements grpc.ClientStream.
+func (s *stream[T]) CloseSend() error { return nil }
+
+// Context implements grpc.ClientStream.
+func (s *stream[T]) Context() context.Context { return s.ctx }
+
+// SendMsg implements grpc.ClientStream.
+func (s *stream[T]) SendMsg(any) error { return nil }
+
+// RecvMsg implements grpc.ClientStream.
+func (s *stream[T]) RecvMsg(any) error { return nil }
+
+// listFilter matches objects against the namespace and selectors of a list request.
+type listFilter struct {
+	labels    labels.Selector
+	fields    fields.Selector
+	namespace string
+	limit     int64
+}
+
+// newListFilter parses the selectors of a list request. An empty namespace matches all namespaces.
+func newListFilter(namespace string, opts *metav1.ListOptions) (*listFilter, error) {
+	filter := &listFilter{namespace: namespace, labels: labels.Everything(), fields: fields.Everything()}
+	if opts == nil {
+		return filter, nil
+	}
+	if opts.LabelSelector != "" {
+		selector, err := labels.Parse(opts.LabelSelector)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid label selector: %v", err)
+		}
+		filter.labels = selector
+	}
+	if opts.FieldSelector != "" {
+		selector, err := fields.ParseSelector(opts.FieldSelector)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid field selector: %v", err)
+		}
+		filter.fields = selector
+	}
+	filter.limit = opts.Limit
+	return filter, nil
+}
+
+// matches reports whether an object passes the filter.
+func (f *listFilter) matches(meta *metav1.ObjectMeta) bool {
+	if f.namespace != "" && meta.Namespace != f.namespace {
+		return false
+	}
+	if !f.labels.Matches(labels.Set(meta.Labels)) {
+		return false
+	}
+	return f.fields.Matches(fields.Set{"metadata.name": meta.Name, "metadata.namespace": meta.Namespace})
+}
+
+// full reports whether a list of n items reached the limit of the request.
+func (f *listFilter) full(n int) bool {
+	return f.limit > 0 && int64(n) >= f.limit
+}
+
+// newestFirst orders workflows by creation time, newest first, then by name.
+func newestFirst(a, b *wfv1.Workflow) int {
+	if c := b.CreationTimestamp.Compare(a.CreationTimestamp.Time); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Name, b.Name)
+}
+
+// byName orders objects by namespace and name.
+func byName[T metav1.Object](a, b T) int {
+	return cmp.Or(cmp.Compare(a.GetNamespace(), b.GetNamespace()), cmp.Compare(a.GetName(), b.GetName()))
+}
@@ -0,0 +1,171 @@
+package fake
+
+import (
+	"maps"
+	"slices"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/workflow/common"
+)
+
+// Step is one transition on the timeline of a workflow.
+type Step struct {
+	// Nodes replace the node statuses of the workflow when set. Otherwise a
+	// single pod node named after the workflow mirrors the workflow phase.
+	Nodes wfv1.Nodes
+
+	// Logs are appended to the logs of the workflow's pods, keyed by pod name.
+	Logs map[string][]string
+
+	// Phase is the phase the workflow moves to.
+	Phase wfv1.WorkflowPhase
+
+	// Message is the status message of the workflow after the step.
+	Message string
+}
+
+// DefaultScript returns the timeline workflows follow unless scripted
+// otherwise: Running, then Succeeded.
+func DefaultScript() []Step {
+	return []Step{{Phase: wfv1.WorkflowRunning}, {Phase: wfv1.WorkflowSucceeded}}
+}
+
+// SetDefaultScript sets the timeline of workflows created from now on, and of
+// retried workflows whose timeline has ended.
+func (c *Client) SetDefaultScript(steps ...Step) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.defaultScript = slices.Clone(steps)
+}
+
+// Script replaces the remaining timeline of a workflow. Steps left after the
+// workflow completes are kept for a retry.
+func (c *Client) Script(namespace, name string, steps ...Step) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.workflow(namespace, name); err != nil {
+		return err
+	}
+	c.scripts[objectKey(namespace, name)] = slices.Clone(steps)
+	return nil
+}
+
+// Advance applies the next step of every workflow that is neither completed
+// nor suspended, in namespace and name order. It returns the number of
+// workflows that changed.
+func (c *Client) Advance() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	advanced := 0
+	for _, key := range slices.Sorted(maps.Keys(c.workflows)) {
+		wf := c.workflows[key]
+		steps := c.scripts[key]
+		if len(steps) == 0 || wf.Status.Fulfilled() || isSuspended(wf) {
+			continue
+		}
+		c.scripts[key] = steps[1:]
+		c.applyStep(wf, steps[0])
+		advanced++
+	}
+	return advanced
+}
+
+// Settle advances the timeline until no workflow changes and returns the
+// number of steps applied. Suspended workflows stay where they are.
+func (c *Client) Settle() int {
+	total := 0
+	for {
+		advanced := c.Advance()
+		if advanced == 0 {
+			return total
+		}
+		total += advanced
+	}
+}
+
+// isSuspended reports whether a workflow is suspended.
+func isSuspended(wf *wfv1.Workflow) bool {
+	return wf.Spec.Suspend != nil && *wf.Spec.Suspend
+}
+
+// applyStep moves a workflow to the state described by step. Callers hold c.mu.
+func (c *Client) applyStep(wf *wfv1.Workflow, step Step) {
+	now := c.now()
+	wf.Status.Phase = step.Phase
+	wf.Status.Message = step.Message
+	if wf.Status.StartedAt.IsZero() && step.Phase != wfv1.WorkflowPending {
+		wf.Status.StartedAt = now
+	}
+
+	if step.Nodes != nil {
+		wf.Status.Nodes = step.Nodes.DeepCopy()
+	} else {
+		root := wf.Status.Nodes[wf.Name]
+		if root.ID == "" {
+			root = wfv1.NodeStatus{
+				ID:           wf.Name,
+				Name:         wf.Name,
+				DisplayName:  wf.Name,
+				Type:         wfv1.NodeTypePod,
+				TemplateName: wf.Spec.Entrypoint,
+				StartedAt:    now,
+			}
+		}
+		root.Phase = wfv1.NodePhase(step.Phase)
+		root.Message = step.Message
+		if root.Phase.Fulfilled(nil) {
+			root.FinishedAt = now
+		}
+		if wf.Status.Nodes == nil {
+			wf.Status.Nodes = make(wfv1.Nodes)
+		}
+		wf.Status.Nodes[wf.Name] = root
+	}
+
+	key := objectKey(wf.Namespace, wf.Name)
+	for _, pod := range slices.Sorted(maps.Keys(step.Logs)) {
+		if c.logs[key] == nil {
+			c.logs[key] = make(map[string][]string)
+		}
+		c.logs[key][pod] = append(c.logs[key][pod], step.Logs[pod]...)
+	}
+
+	if wf.Status.Fulfilled() {
+		c.complete(wf)
+	}
+	c.updateWorkflow(wf)
+}
+
+// complete records the completion of a workflow and archives it. Callers hold c.mu.
+func (c *Client) complete(wf *wfv1.Workflow) {
+	if wf.Status.FinishedAt.IsZero() {
+		wf.Status.FinishedAt = c.now()
+	}
+	if wf.Labels == nil {
+		wf.Labels = make(map[string]string)
+	}
+	wf.Labels[common.LabelKeyCompleted] = "true"
+	archived := wf.DeepCopy()
+	archived.ResourceVersion = ""
+	c.archived[wf.UID] = archived
+}
+
+// updateWorkflow bumps the resource version of a stored workflow, refreshes
+// its phase label and notifies watchers. Callers hold c.mu.
+func (c *Client) updateWorkflow(wf *wfv1.Workflow) {
+	setPhaseLabel(wf)
+	wf.ResourceVersion = c.nextResourceVersion()
+	c.notify(eventModified, wf)
+}
+
+// setPhaseLabel sets the labels the workflow controller maintains for the phase.
+func setPhaseLabel(wf *wfv1.Workflow) {
+	if wf.Labels == nil {
+		wf.Labels = make(map[string]string)
+	}
+	wf.Labels[common.LabelKeyPhase] = string(wf.Status.Phase)
+	if !wf.Status.Fulfilled() {
+		wf.Labels[common.LabelKeyCompleted] = "false"
+	}
+}
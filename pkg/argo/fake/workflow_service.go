@@ -0,0 +1,655 @@
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/workflow/common"
+	wfutil "github.com/argoproj/argo-workflows/v4/workflow/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/utils/ptr"
+)
+
+// Watch event types.
+const (
+	eventAdded    = "ADDED"
+	eventModified = "MODIFIED"
+	eventDeleted  = "DELETED"
+)
+
+// watcher is an open workflow watch.
+type watcher struct {
+	filter *listFilter
+	stream *stream[*workflow.WorkflowWatchEvent]
+}
+
+// notify sends a watch event for a workflow to the watchers it matches. Callers hold c.mu.
+func (c *Client) notify(eventType string, wf *wfv1.Workflow) {
+	for w := range c.watchers {
+		if w.filter.matches(&wf.ObjectMeta) {
+			w.stream.push(&workflow.WorkflowWatchEvent{Type: eventType, Object: wf.DeepCopy()})
+		}
+	}
+}
+
+// workflow returns a stored workflow. Callers hold c.mu.
+func (c *Client) workflow(namespace, name string) (*wfv1.Workflow, error) {
+	if err := c.checkNamespace(namespace); err != nil {
+		return nil, err
+	}
+	wf, ok := c.workflows[objectKey(namespace, name)]
+	if !ok {
+		return nil, notFound("workflows", name)
+	}
+	return wf, nil
+}
+
+// createWorkflow stores a new workflow created in namespace. Workflows that
+// have not completed start Pending and follow the default script; completed
+// workflows are archived. Callers hold c.mu.
+func (c *Client) createWorkflow(wf *wfv1.Workflow, namespace string) (*wfv1.Workflow, error) {
+	if err := c.admit(&wf.ObjectMeta, namespace); err != nil {
+		return nil, err
+	}
+	key := objectKey(wf.Namespace, wf.Name)
+	if _, ok := c.workflows[key]; ok {
+		return nil, alreadyExists("workflows", wf.Name)
+	}
+
+	if wf.Status.Phase == "" {
+		wf.Status.Phase = wfv1.WorkflowPending
+	}
+	setPhaseLabel(wf)
+	if wf.Status.Fulfilled() {
+		c.complete(wf)
+	} else {
+		c.scripts[key] = slices.Clone(c.defaultScript)
+	}
+	c.workflows[key] = wf
+	c.notify(eventAdded, wf)
+	return wf.DeepCopy(), nil
+}
+
+// setParameters overrides or adds workflow arguments from "name=value" strings.
+func setParameters(spec *wfv1.WorkflowSpec, parameters []string) error {
+	for _, param := range parameters {
+		name, value, ok := strings.Cut(param, "=")
+		if !ok {
+			return status.Errorf(codes.InvalidArgument, "expected parameter of the form: NAME=VALUE. Received: %s", param)
+		}
+		i := slices.IndexFunc(spec.Arguments.Parameters, func(p wfv1.Parameter) bool { return p.Name == name })
+		if i < 0 {
+			spec.Arguments.Parameters = append(spec.Arguments.Parameters, wfv1.Parameter{Name: name})
+			i = len(spec.Arguments.Parameters) - 1
+		}
+		spec.Arguments.Parameters[i].Value = wfv1.AnyStringPtr(value)
+	}
+	return nil
+}
+
+// workflowService serves the workflows of the fake.
+type workflowService struct {
+	client *Client
+}
+
+// CreateWorkflow implements workflow.WorkflowServiceClient.
+func (s *workflowService) CreateWorkflow(_ context.Context, in *workflow.WorkflowCreateRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	if in.Workflow == nil {
+		return nil, status.Error(codes.InvalidArgument, "workflow body not specified")
+	}
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	wf := in.Workflow.DeepCopy()
+	if in.ServerDryRun || (in.CreateOptions != nil && len(in.CreateOptions.DryRun) > 0) {
+		if err := c.checkNamespace(in.Namespace); err != nil {
+			return nil, err
+		}
+		return wf, nil
+	}
+	return c.createWorkflow(wf, in.Namespace)
+}
+
+// GetWorkflow implements workflow.WorkflowServiceClient.
+func (s *workflowService) GetWorkflow(_ context.Context, in *workflow.WorkflowGetRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	wf, err := c.workflow(in.Namespace, in.Name)
+	if err != nil {
+		return nil, err
+	}
+	return wf.DeepCopy(), nil
+}
+
+// ListWorkflows implements workflow.WorkflowServiceClient. Workflows are listed newest first.
+func (s *workflowService) ListWorkflows(_ context.Context, in *workflow.WorkflowListRequest, _ ...grpc.CallOption) (*wfv1.WorkflowList, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	filter, err := c.listFilter(in.Namespace, in.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &wfv1.WorkflowList{}
+	for _, wf := range slices.SortedFunc(maps.Values(c.workflows), newestFirst) {
+		if filter.full(len(list.Items)) {
+			break
+		}
+		if filter.matches(&wf.ObjectMeta) {
+			list.Items = append(list.Items, *wf.DeepCopy())
+		}
+	}
+	return list, nil
+}
+
+// listFilter creates the filter of a list request, checking the namespace
+// unless all namespaces are listed. Callers hold c.mu.
+func (c *Client) listFilter(namespace string, opts *metav1.ListOptions) (*listFilter, error) {
+	if namespace != "" {
+		if err := c.checkNamespace(namespace); err != nil {
+			return nil, err
+		}
+	}
+	return newListFilter(namespace, opts)
+}
+
+// WatchWorkflows implements workflow.WorkflowServiceClient. The stream starts
+// with an ADDED event per matching workflow and then follows changes until
+// ctx is done.
+func (s *workflowService) WatchWorkflows(ctx context.Context, in *workflow.WatchWorkflowsRequest, _ ...grpc.CallOption) (workflow.WorkflowService_WatchWorkflowsClient, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	filter, err := c.listFilter(in.Namespace, in.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &watcher{filter: filter, stream: newStream[*workflow.WorkflowWatchEvent](ctx)}
+	for _, wf := range slices.SortedFunc(maps.Values(c.workflows), newestFirst) {
+		if filter.matches(&wf.ObjectMeta) {
+			w.stream.push(&workflow.WorkflowWatchEvent{Type: eventAdded, Object: wf.DeepCopy()})
+		}
+	}
+	c.watchers[w] = struct{}{}
+	context.AfterFunc(ctx, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.watchers, w)
+	})
+	return w.stream, nil
+}
+
+// WatchEvents implements workflow.WorkflowServiceClient. The fake holds no
+// Kubernetes events, so the stream stays open without events until ctx is done.
+func (s *workflowService) WatchEvents(ctx context.Context, in *workflow.WatchEventsRequest, _ ...grpc.CallOption) (workflow.WorkflowService_WatchEventsClient, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.listFilter(in.Namespace, in.ListOptions); err != nil {
+		return nil, err
+	}
+	return newStream[*corev1.Event](ctx), nil
+}
+
+// DeleteWorkflow implements workflow.WorkflowServiceClient. Archived copies are kept.
+func (s *workflowService) DeleteWorkflow(_ context.Context, in *workflow.WorkflowDeleteRequest, _ ...grpc.CallOption) (*workflow.WorkflowDeleteResponse, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	wf, err := c.workflow(in.Namespace, in.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	key := objectKey(wf.Namespace, wf.Name)
+	delete(c.workflows, key)
+	delete(c.scripts, key)
+	delete(c.logs, key)
+	c.notify(eventDeleted, wf)
+	return &workflow.WorkflowDeleteResponse{}, nil
+}
+
+// RetryWorkflow implements workflow.WorkflowServiceClient. Failed nodes, and
+// successful nodes matching the selector when RestartSuccessful is set, are
+// removed and the workflow continues its timeline from Running.
+func (s *workflowService) RetryWorkflow(_ context.Context, in *workflow.WorkflowRetryRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	wf, err := c.workflow(in.Namespace, in.Name)
+	if err != nil {
+		return nil, err
+	}
+	if err := c.retry(wf, in.RestartSuccessful, in.NodeFieldSelector, in.Parameters); err != nil {
+		return nil, err
+	}
+	return wf.DeepCopy(), nil
+}
+
+// retry resets a completed workflow to Running. Callers hold c.mu.
+func (c *Client) retry(wf *wfv1.Workflow, restartSuccessful bool, nodeFieldSelector string, parameters []string) error {
+	switch wf.Status.Phase {
+	case wfv1.WorkflowFailed, wfv1.WorkflowError:
+	case wfv1.WorkflowSucceeded:
+		if !restartSuccessful || nodeFieldSelector == "" {
+			return status.Error(codes.InvalidArgument, "To retry a succeeded workflow, set the options restartSuccessful and nodeFieldSelector")
+		}
+	default:
+		return status.Errorf(codes.InvalidArgument, "Cannot retry a workflow in phase %s", wf.Status.Phase)
+	}
+
+	restart := fields.Nothing()
+	if restartSuccessful && nodeFieldSelector != "" {
+		selector, err := fields.ParseSelector(nodeFieldSelector)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid node field selector: %v", err)
+		}
+		restart = selector
+	}
+	if err := setParameters(&wf.Spec, parameters); err != nil {
+		return err
+	}
+
+	for id, node := range wf.Status.Nodes {
+		failed := node.Phase == wfv1.NodeFailed || node.Phase == wfv1.NodeError
+		if failed || (node.Phase == wfv1.NodeSucceeded && wfutil.SelectorMatchesNode(restart, node)) {
+			delete(wf.Status.Nodes, id)
+		}
+	}
+	wf.Spec.Shutdown = ""
+	wf.Status.Phase = wfv1.WorkflowRunning
+	wf.Status.Message = ""
+	wf.Status.FinishedAt = metav1.Time{}
+
+	key := objectKey(wf.Namespace, wf.Name)
+	if len(c.scripts[key]) == 0 {
+		c.scripts[key] = slices.Clone(c.defaultScript)
+	}
+	c.updateWorkflow(wf)
+	return nil
+}
+
+// ResubmitWorkflow implements workflow.WorkflowServiceClient.
+func (s *workflowService) ResubmitWorkflow(_ context.Context, in *workflow.WorkflowResubmitRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	wf, err := c.workflow(in.Namespace, in.Name)
+	if err != nil {
+		return nil, err
+	}
+	return c.resubmit(wf, in.Memoized, in.Parameters)
+}
+
+// resubmit creates a new workflow from the spec, labels and annotations of
+// wf, like the Argo Server does. Callers hold c.mu.
+func (c *Client) resubmit(wf *wfv1.Workflow, memoized bool, parameters []string) (*wfv1.Workflow, error) {
+	if memoized && wf.Status.Phase != wfv1.WorkflowFailed && wf.Status.Phase != wfv1.WorkflowError {
+		return nil, status.Error(codes.InvalidArgument, "workflow must be Failed/Error to resubmit in memoized mode")
+	}
+
+	resubmitted := &wfv1.Workflow{TypeMeta: wf.TypeMeta}
+	resubmitted.GenerateName = wf.GenerateName
+	if resubmitted.GenerateName == "" {
+		resubmitted.GenerateName = wf.Name + "-"
+	}
+	resubmitted.Namespace = wf.Namespace
+	resubmitted.Spec = *wf.Spec.DeepCopy()
+	resubmitted.Spec.Shutdown = ""
+	resubmitted.Labels = make(map[string]string)
+	for key, value := range wf.Labels {
+		if key != common.LabelKeyPhase && key != common.LabelKeyCompleted && key != common.LabelKeyWorkflowArchivingStatus {
+			resubmitted.Labels[key] = value
+		}
+	}
+	resubmitted.Labels[common.LabelKeyPreviousWorkflowName] = wf.Name
+	resubmitted.Annotations = maps.Clone(wf.Annotations)
+	if err := setParameters(&resubmitted.Spec, parameters); err != nil {
+		return nil, err
+	}
+	return c.createWorkflow(resubmitted, wf.Namespace)
+}
+
+// ResumeWorkflow implements workflow.WorkflowServiceClient. Suspend nodes
+// matching the selector are marked Succeeded.
+func (s *workflowService) ResumeWorkflow(_ context.Context, in *workflow.WorkflowResumeRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	wf, err := c.workflow(in.Namespace, in.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	selector := fields.Everything()
+	if in.NodeFieldSelector != "" {
+		if selector, err = fields.ParseSelector(in.NodeFieldSelector); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid node field selector: %v", err)
+		}
+	}
+
+	resumed := isSuspended(wf)
+	wf.Spec.Suspend = nil
+	for id, node := range wf.Status.Nodes {
+		if node.Type == wfv1.NodeTypeSuspend && node.Phase == wfv1.NodeRunning && wfutil.SelectorMatchesNode(selector, node) {
+			node.Phase = wfv1.NodeSucceeded
+			node.FinishedAt = c.now()
+			wf.Status.Nodes[id] = node
+			resumed = true
+		}
+	}
+	if !resumed {
+		return nil, status.Errorf(codes.FailedPrecondition, "workflow %q is not suspended", wf.Name)
+	}
+	c.updateWorkflow(wf)
+	return wf.DeepCopy(), nil
+}
+
+// SuspendWorkflow implements workflow.WorkflowServiceClient. Suspended
+// workflows do not advance on their timeline until resumed.
+func (s *workflowService) SuspendWorkflow(_ context.Context, in *workflow.WorkflowSuspendRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	wf, err := c.workflow(in.Namespace, in.Name)
+	if err != nil {
+		return nil, err
+	}
+	if wf.Status.Fulfilled() {
+		return nil, status.Error(codes.FailedPrecondition, "cannot suspend completed workflows")
+	}
+	wf.Spec.Suspend = ptr.To(true)
+	c.updateWorkflow(wf)
+	return wf.DeepCopy(), nil
+}
+
+// TerminateWorkflow implements workflow.WorkflowServiceClient.
+func (s *workflowService) TerminateWorkflow(_ context.Context, in *workflow.WorkflowTerminateRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return s.client.shutdown(in.Namespace, in.Name, wfv1.ShutdownStrategyTerminate, "")
+}
+
+// StopWorkflow implements workflow.WorkflowServiceClient.
+func (s *workflowService) StopWorkflow(_ context.Context, in *workflow.WorkflowStopRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return s.client.shutdown(in.Namespace, in.Name, wfv1.ShutdownStrategyStop, in.Message)
+}
+
+// shutdown fails a running workflow and its running nodes, ending its timeline.
+func (c *Client) shutdown(namespace, name string, strategy wfv1.ShutdownStrategy, message string) (*wfv1.Workflow, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	wf, err := c.workflow(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	if wf.Status.Fulfilled() {
+		return nil, status.Error(codes.FailedPrecondition, "cannot shutdown a completed workflow")
+	}
+
+	if message == "" {
+		message = "Stopped with strategy '" + string(strategy) + "'"
+	}
+	now := c.now()
+	for id, node := range wf.Status.Nodes {
+		if !node.Fulfilled() {
+			node.Phase = wfv1.NodeFailed
+			node.Message = message
+			node.FinishedAt = now
+			wf.Status.Nodes[id] = node
+		}
+	}
+	wf.Spec.Shutdown = strategy
+	wf.Spec.Suspend = nil
+	wf.Status.Phase = wfv1.WorkflowFailed
+	wf.Status.Message = message
+	delete(c.scripts, objectKey(namespace, name))
+	c.complete(wf)
+	c.updateWorkflow(wf)
+	return wf.DeepCopy(), nil
+}
+
+// SetWorkflow implements workflow.WorkflowServiceClient by updating the
+// phase, message and output parameters of the nodes matching the selector.
+func (s *workflowService) SetWorkflow(_ context.Context, in *workflow.WorkflowSetRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	wf, err := c.workflow(in.Namespace, in.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	selector, err := fields.ParseSelector(in.NodeFieldSelector)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid node field selector: %v", err)
+	}
+	var outputs map[string]string
+	if in.OutputParameters != "" {
+		if err := json.Unmarshal([]byte(in.OutputParameters), &outputs); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "unable to parse output parameter set request: %v", err)
+		}
+	}
+
+	matched := false
+	for id, node := range wf.Status.Nodes {
+		if !wfutil.SelectorMatchesNode(selector, node) {
+			continue
+		}
+		matched = true
+		if in.Phase != "" {
+			node.Phase = wfv1.NodePhase(in.Phase)
+			if node.Fulfilled() {
+				node.FinishedAt = c.now()
+			}
+		}
+		if in.Message != "" {
+			node.Message = in.Message
+		}
+		for _, name := range slices.Sorted(maps.Keys(outputs)) {
+			if node.Outputs == nil {
+				node.Outputs = &wfv1.Outputs{}
+			}
+			i := slices.IndexFunc(node.Outputs.Parameters, func(p wfv1.Parameter) bool { return p.Name == name })
+			if i < 0 {
+				node.Outputs.Parameters = append(node.Outputs.Parameters, wfv1.Parameter{Name: name})
+				i = len(node.Outputs.Parameters) - 1
+			}
+			node.Outputs.Parameters[i].Value = wfv1.AnyStringPtr(outputs[name])
+		}
+		wf.Status.Nodes[id] = node
+	}
+	if !matched {
+		return nil, status.Errorf(codes.InvalidArgument, "no nodes of workflow %q match %q", wf.Name, in.NodeFieldSelector)
+	}
+	c.updateWorkflow(wf)
+	return wf.DeepCopy(), nil
+}
+
+// LintWorkflow implements workflow.WorkflowServiceClient. Only the checks
+// needed to tell valid from invalid manifests in tests are performed.
+func (s *workflowService) LintWorkflow(_ context.Context, in *workflow.WorkflowLintRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.checkNamespace(in.Namespace); err != nil {
+		return nil, err
+	}
+	if in.Workflow == nil {
+		return nil, status.Error(codes.InvalidArgument, "workflow body not specified")
+	}
+	if err := lintSpec(&in.Workflow.Spec); err != nil {
+		return nil, err
+	}
+	return in.Workflow.DeepCopy(), nil
+}
+
+// lintSpec checks that a workflow spec has templates and a valid entrypoint.
+func lintSpec(spec *wfv1.WorkflowSpec) error {
+	if spec.WorkflowTemplateRef != nil {
+		return nil
+	}
+	if len(spec.Templates) == 0 {
+		return status.Error(codes.InvalidArgument, "spec.templates is required")
+	}
+	if spec.Entrypoint != "" && !slices.ContainsFunc(spec.Templates, func(t wfv1.Template) bool { return t.Name == spec.Entrypoint }) {
+		return status.Errorf(codes.InvalidArgument, "spec.entrypoint template '%s' not found", spec.Entrypoint)
+	}
+	return nil
+}
+
+// PodLogs implements workflow.WorkflowServiceClient.
+func (s *workflowService) PodLogs(ctx context.Context, in *workflow.WorkflowLogRequest, _ ...grpc.CallOption) (workflow.WorkflowService_PodLogsClient, error) {
+	entries, err := s.client.logEntries(in)
+	if err != nil {
+		return nil, err
+	}
+	return newClosedStream(ctx, entries), nil
+}
+
+// WorkflowLogs implements workflow.WorkflowServiceClient by replaying the
+// logs stored so far, honouring the pod, grep and tail options.
+func (s *workflowService) WorkflowLogs(ctx context.Context, in *workflow.WorkflowLogRequest, _ ...grpc.CallOption) (workflow.WorkflowService_WorkflowLogsClient, error) {
+	entries, err := s.client.logEntries(in)
+	if err != nil {
+		return nil, err
+	}
+	return newClosedStream(ctx, entries), nil
+}
+
+// logEntries returns the log lines matching a log request, ordered by pod name.
+func (c *Client) logEntries(in *workflow.WorkflowLogRequest) ([]*workflow.LogEntry, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, err := c.workflow(in.Namespace, in.Name); err != nil {
+		return nil, err
+	}
+
+	var grep *regexp.Regexp
+	if in.Grep != "" {
+		var err error
+		if grep, err = regexp.Compile(in.Grep); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to compile %q: %v", in.Grep, err)
+		}
+	}
+	var tail int64
+	if in.LogOptions != nil && in.LogOptions.TailLines != nil {
+		tail = *in.LogOptions.TailLines
+	}
+
+	logs := c.logs[objectKey(in.Namespace, in.Name)]
+	var entries []*workflow.LogEntry
+	for _, pod := range slices.Sorted(maps.Keys(logs)) {
+		if in.PodName != "" && pod != in.PodName {
+			continue
+		}
+		var podEntries []*workflow.LogEntry
+		for _, line := range logs[pod] {
+			if grep == nil || grep.MatchString(line) {
+				podEntries = append(podEntries, &workflow.LogEntry{PodName: pod, Content: line})
+			}
+		}
+		if tail > 0 && int64(len(podEntries)) > tail {
+			podEntries = podEntries[int64(len(podEntries))-tail:]
+		}
+		entries = append(entries, podEntries...)
+	}
+	return entries, nil
+}
+
+// SubmitWorkflow implements workflow.WorkflowServiceClient for
+// WorkflowTemplates, ClusterWorkflowTemplates and CronWorkflows.
+func (s *workflowService) SubmitWorkflow(_ context.Context, in *workflow.WorkflowSubmitRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	c := s.client
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.checkNamespace(in.Namespace); err != nil {
+		return nil, err
+	}
+
+	wf := &wfv1.Workflow{}
+	wf.Namespace = in.Namespace
+	wf.GenerateName = in.ResourceName + "-"
+	wf.Labels = make(map[string]string)
+	switch strings.ToLower(in.ResourceKind) {
+	case "workflowtemplate", "wftmpl":
+		if _, ok := c.workflowTemplates[objectKey(in.Namespace, in.ResourceName)]; !ok {
+			return nil, notFound("workflowtemplates", in.ResourceName)
+		}
+		wf.Spec.WorkflowTemplateRef = &wfv1.WorkflowTemplateRef{Name: in.ResourceName}
+		wf.Labels[common.LabelKeyWorkflowTemplate] = in.ResourceName
+	case "clusterworkflowtemplate", "cwftmpl", "cwft":
+		if _, ok := c.clusterWorkflowTemplates[in.ResourceName]; !ok {
+			return nil, notFound("clusterworkflowtemplates", in.ResourceName)
+		}
+		wf.Spec.WorkflowTemplateRef = &wfv1.WorkflowTemplateRef{Name: in.ResourceName, ClusterScope: true}
+		wf.Labels[common.LabelKeyClusterWorkflowTemplate] = in.ResourceName
+	case "cronworkflow", "cronwf":
+		cwf, ok := c.cronWorkflows[objectKey(in.Namespace, in.ResourceName)]
+		if !ok {
+			return nil, notFound("cronworkflows", in.ResourceName)
+		}
+		wf.Spec = *cwf.Spec.WorkflowSpec.DeepCopy()
+		if cwf.Spec.WorkflowMetadata != nil {
+			maps.Copy(wf.Labels, cwf.Spec.WorkflowMetadata.Labels)
+			wf.Annotations = maps.Clone(cwf.Spec.WorkflowMetadata.Annotations)
+		}
+		wf.Labels[common.LabelKeyCronWorkflow] = in.ResourceName
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "resourceKind[%s] is not supported", in.ResourceKind)
+	}
+
+	dryRun := false
+	if opts := in.SubmitOptions; opts != nil {
+		if err := applySubmitOptions(wf, opts); err != nil {
+			return nil, err
+		}
+		dryRun = opts.DryRun || opts.ServerDryRun
+	}
+	if dryRun {
+		return wf, nil
+	}
+	return c.createWorkflow(wf, in.Namespace)
+}
+
+// applySubmitOptions applies the name, entrypoint, parameter, label and
+// scheduling options of a submission.
+func applySubmitOptions(wf *wfv1.Workflow, opts *wfv1.SubmitOpts) error {
+	if opts.Name != "" {
+		wf.Name = opts.Name
+	}
+	if opts.GenerateName != "" {
+		wf.GenerateName = opts.GenerateName
+	}
+	if opts.Entrypoint != "" {
+		wf.Spec.Entrypoint = opts.Entrypoint
+	}
+	if opts.ServiceAccount != "" {
+		wf.Spec.ServiceAccountName = opts.ServiceAccount
+	}
+	if opts.Priority != nil {
+		wf.Spec.Priority = opts.Priority
+	}
+	if opts.Labels != "" {
+		set, err := labels.ConvertSelectorToLabelsMap(opts.Labels)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "expected labels of the form: NAME1=VALUE2,NAME2=VALUE2. Received: %s", opts.Labels)
+		}
+		maps.Copy(wf.Labels, set)
+	}
+	return setParameters(&wf.Spec, opts.Parameters)
+}
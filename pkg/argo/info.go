@@ -0,0 +1,346 @@
+package argo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	argoconfig "github.com/argoproj/argo-workflows/v4/config"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/info"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowarchive"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Connection modes reported in ClusterInfo.Mode.
+const (
+	ModeArgoServer    = "argo-server"
+	ModeKubernetesAPI = "kubernetes-api"
+)
+
+const (
+	// controllerDeploymentName is the name of the controller deployment in the
+	// Argo install manifests.
+	controllerDeploymentName = "workflow-controller"
+
+	// controllerLabelSelector matches the controller deployment when it has been renamed.
+	controllerLabelSelector = "app=workflow-controller"
+
+	// defaultControllerConfigMap is the configmap the controller reads unless
+	// overridden with --configmap.
+	defaultControllerConfigMap = "workflow-controller-configmap"
+
+	// serviceAccountIssuer is the issuer reported for Kubernetes service account tokens.
+	serviceAccountIssuer = "kubernetes/serviceaccount"
+
+	// archiveProbeUID is a UID that never matches an archived workflow. It is
+	// used to detect whether the Argo Server has a workflow archive configured.
+	archiveProbeUID = "00000000-0000-0000-0000-000000000000"
+)
+
+// ClusterInfo describes the Argo Workflows installation the client is connected to.
+type ClusterInfo struct {
+	Modals               map[string]bool `json:"modals,omitempty"`
+	User                 *UserInfo       `json:"user,omitempty"`
+	Links                []*wfv1.Link    `json:"links,omitempty"`
+	Columns              []*wfv1.Column  `json:"columns,omitempty"`
+	Warnings             []string        `json:"warnings,omitempty"`
+	Mode                 string          `json:"mode"`
+	Version              string          `json:"version,omitempty"`
+	GitCommit            string          `json:"gitCommit,omitempty"`
+	BuildDate            string          `json:"buildDate,omitempty"`
+	Platform             string          `json:"platform,omitempty"`
+	ManagedNamespace     string          `json:"managedNamespace,omitempty"`
+	NavColor             string          `json:"navColor,omitempty"`
+	ControllerNamespace  string          `json:"controllerNamespace,omitempty"`
+	ControllerImage      string          `json:"controllerImage,omitempty"`
+	ControllerReplicas   int32           `json:"controllerReplicas,omitempty"`
+	Parallelism          int             `json:"parallelism,omitempty"`
+	NamespaceParallelism int             `json:"namespaceParallelism,omitempty"`
+	// InstanceID is the controller's instance ID. It is only read in direct
+	// Kubernetes API mode, as the Argo Server InfoService does not report it.
+	InstanceID string `json:"instanceID,omitempty"`
+	// ArchiveEnabled reports whether completed workflows are persisted to the workflow archive.
+	ArchiveEnabled bool `json:"archiveEnabled"`
+	// ArchiveToolsAvailable reports whether the archived workflow tools can be used,
+	// which requires both an archive and an Argo Server connection.
+	ArchiveToolsAvailable bool `json:"archiveToolsAvailable"`
+	NodeStatusOffload     bool `json:"nodeStatusOffload"`
+	SSOEnabled            bool `json:"ssoEnabled"`
+}
+
+// UserInfo is the identity the Argo Server resolved for the current credentials.
+type UserInfo struct {
+	Groups                  []string `json:"groups,omitempty"`
+	Issuer                  string   `json:"issuer,omitempty"`
+	Subject                 string   `json:"subject,omitempty"`
+	Name                    string   `json:"name,omitempty"`
+	Email                   string   `json:"email,omitempty"`
+	ServiceAccountName      string   `json:"serviceAccountName,omitempty"`
+	ServiceAccountNamespace string   `json:"serviceAccountNamespace,omitempty"`
+}
+
+// GetClusterInfo gathers version, configuration and feature information about
+// the connected Argo Workflows installation. In Argo Server mode it uses the
+// InfoService; in direct Kubernetes API mode it inspects the workflow controller
+// deployment and configmap. Optional details that cannot be read are reported
+// as warnings rather than errors.
+func GetClusterInfo(ctx context.Context, client ClientInterface) (*ClusterInfo, error) {
+	if client.IsArgoServerMode() {
+		return getServerClusterInfo(ctx, client)
+	}
+	return getKubernetesClusterInfo(ctx, client)
+}
+
+// getServerClusterInfo collects cluster information from the Argo Server.
+func getServerClusterInfo(ctx context.Context, client ClientInterface) (*ClusterInfo, error) {
+	infoService, err := client.InfoService()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := infoService.GetInfo(ctx, &info.GetInfoRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server info: %w", err)
+	}
+
+	result := &ClusterInfo{
+		Mode:             ModeArgoServer,
+		ManagedNamespace: resp.ManagedNamespace,
+		Links:            resp.Links,
+		Columns:          resp.Columns,
+		Modals:           resp.Modals,
+		NavColor:         resp.NavColor,
+	}
+
+	if version, versionErr := infoService.GetVersion(ctx, &info.GetVersionRequest{}); versionErr != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to get server version: %v", versionErr))
+	} else {
+		result.Version = version.Version
+		result.GitCommit = version.GitCommit
+		result.BuildDate = version.BuildDate
+		result.Platform = version.Platform
+	}
+
+	if user, userErr := infoService.GetUserInfo(ctx, &info.GetUserInfoRequest{}); userErr != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to get user info: %v", userErr))
+	} else {
+		result.User = &UserInfo{
+			Groups:                  user.Groups,
+			Issuer:                  user.Issuer,
+			Subject:                 user.Subject,
+			Name:                    user.Name,
+			Email:                   user.Email,
+			ServiceAccountName:      user.ServiceAccountName,
+			ServiceAccountNamespace: user.ServiceAccountNamespace,
+		}
+		// SSO logins carry the OIDC issuer; service account tokens carry the
+		// Kubernetes issuer and server auth mode carries none.
+		result.SSOEnabled = user.Issuer != "" && user.Issuer != serviceAccountIssuer
+	}
+
+	enabled, archiveErr := probeArchive(ctx, client)
+	if archiveErr != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("could not determine whether the workflow archive is enabled: %v", archiveErr))
+	}
+	result.ArchiveEnabled = enabled
+	result.ArchiveToolsAvailable = enabled
+
+	return result, nil
+}
+
+// probeArchive detects whether the Argo Server has a workflow archive configured.
+// With an archive, looking up an unknown UID returns NotFound; without one the
+// server reports that archived workflows are not supported.
+func probeArchive(ctx context.Context, client ClientInterface) (bool, error) {
+	archiveService, err := client.ArchivedWorkflowService()
+	if err != nil {
+		return false, err
+	}
+
+	_, err = archiveService.GetArchivedWorkflow(ctx, &workflowarchive.GetArchivedWorkflowRequest{Uid: archiveProbeUID})
+	switch {
+	case err == nil, status.Code(err) == codes.NotFound:
+		return true, nil
+	case strings.Contains(err.Error(), "not supported"):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// getKubernetesClusterInfo collects cluster information by reading the workflow
+// controller deployment and configmap directly from Kubernetes.
+func getKubernetesClusterInfo(ctx context.Context, client ClientInterface) (*ClusterInfo, error) {
+	kubeClient, err := client.KubernetesClient()
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := client.ControllerNamespace()
+	result := &ClusterInfo{
+		Mode:                ModeKubernetesAPI,
+		ControllerNamespace: namespace,
+	}
+
+	configMapName := defaultControllerConfigMap
+	deployment, err := findControllerDeployment(ctx, kubeClient, namespace)
+	if err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to inspect workflow controller deployment: %v", err))
+	} else {
+		args := applyControllerDeployment(result, deployment)
+		if name := controllerArg(args, "--configmap"); name != "" {
+			configMapName = name
+		}
+	}
+
+	cfg, err := argoconfig.NewController(namespace, configMapName, kubeClient).Get(ctx)
+	if err != nil {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("failed to read controller configmap %s/%s: %v", namespace, configMapName, err))
+		return result, nil
+	}
+
+	result.InstanceID = cfg.InstanceID
+	result.Parallelism = cfg.Parallelism
+	result.NamespaceParallelism = cfg.NamespaceParallelism
+	result.Links = cfg.Links
+	result.Columns = cfg.Columns
+	result.SSOEnabled = cfg.SSO.Issuer != ""
+	if cfg.Persistence != nil {
+		result.ArchiveEnabled = cfg.Persistence.Archive
+		result.NodeStatusOffload = cfg.Persistence.NodeStatusOffload
+	}
+	if result.ArchiveEnabled {
+		result.Warnings = append(result.Warnings, "the workflow archive is enabled but archived workflow tools require an Argo Server connection")
+	}
+
+	return result, nil
+}
+
+// findControllerDeployment looks up the workflow controller deployment by its
+// default name, falling back to its app label.
+func findControllerDeployment(ctx context.Context, kubeClient kubernetes.Interface, namespace string) (*appsv1.Deployment, error) {
+	deployments := kubeClient.AppsV1().Deployments(namespace)
+
+	deployment, err := deployments.Get(ctx, controllerDeploymentName, metav1.GetOptions{})
+	if err == nil {
+		return deployment, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+
+	list, err := deployments.List(ctx, metav1.ListOptions{LabelSelector: controllerLabelSelector})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Items) == 0 {
+		return nil, fmt.Errorf("no workflow controller deployment found in namespace %s", namespace)
+	}
+	return &list.Items[0], nil
+}
+
+// applyControllerDeployment records the controller image, version and managed
+// namespace from its deployment, returning the controller container arguments.
+func applyControllerDeployment(result *ClusterInfo, deployment *appsv1.Deployment) []string {
+	if deployment.Spec.Replicas != nil {
+		result.ControllerReplicas = *deployment.Spec.Replicas
+	}
+
+	container := controllerContainer(deployment)
+	if container == nil {
+		return nil
+	}
+
+	result.ControllerImage = container.Image
+	result.Version = imageTag(container.Image)
+
+	args := append(append([]string{}, container.Command...), container.Args...)
+	if namespaced, _ := strconv.ParseBool(controllerArg(args, "--namespaced")); namespaced {
+		result.ManagedNamespace = controllerArg(args, "--managed-namespace")
+		if result.ManagedNamespace == "" {
+			result.ManagedNamespace = deployment.Namespace
+		}
+	}
+
+	return args
+}
+
+// controllerContainer returns the workflow controller container of its
+// deployment, or nil when the deployment has no containers.
+func controllerContainer(deployment *appsv1.Deployment) *corev1.Container {
+	containers := deployment.Spec.Template.Spec.Containers
+	if len(containers) == 0 {
+		return nil
+	}
+	for i := range containers {
+		if containers[i].Name == controllerDeploymentName {
+			return &containers[i]
+		}
+	}
+	return &containers[0]
+}
+
+// GetControllerConfig reads the workflow controller configuration from its
+// configmap, honouring a --configmap flag on the controller deployment.
+// It requires direct Kubernetes API access.
+func GetControllerConfig(ctx context.Context, client ClientInterface) (*argoconfig.Config, error) {
+	kubeClient, err := client.KubernetesClient()
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := client.ControllerNamespace()
+	configMapName := defaultControllerConfigMap
+	if deployment, deployErr := findControllerDeployment(ctx, kubeClient, namespace); deployErr == nil {
+		if container := controllerContainer(deployment); container != nil {
+			args := append(append([]string{}, container.Command...), container.Args...)
+			if name := controllerArg(args, "--configmap"); name != "" {
+				configMapName = name
+			}
+		}
+	}
+
+	cfg, err := argoconfig.NewController(namespace, configMapName, kubeClient).Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read controller configmap %s/%s: %w", namespace, configMapName, err)
+	}
+	return cfg, nil
+}
+
+// controllerArg returns the value of a controller command line flag, supporting
+// both "--flag value" and "--flag=value" forms. Boolean flags given without a
+// value return "true".
+func controllerArg(args []string, flag string) string {
+	for i, arg := range args {
+		if value, ok := strings.CutPrefix(arg, flag+"="); ok {
+			return value
+		}
+		if arg != flag {
+			continue
+		}
+		if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			return args[i+1]
+		}
+		return "true"
+	}
+	return ""
+}
+
+// imageTag returns the tag of a container image reference, ignoring any digest.
+func imageTag(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	slash := strings.LastIndex(image, "/")
+	colon := strings.LastIndex(image, ":")
+	if colon <= slash {
+		return ""
+	}
+	return image[colon+1:]
+}
@@ -0,0 +1,227 @@
+package argo_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/info"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+)
+
+func TestGetClusterInfo_ArgoServerMode(t *testing.T) {
+	tests := []struct {
+		archiveErr   error
+		setupMock    func(*mocks.MockInfoServiceClient)
+		name         string
+		wantWarnings int
+		wantArchive  bool
+		wantSSO      bool
+		wantUser     bool
+		wantVersion  bool
+		wantErr      bool
+	}{
+		{
+			name:       "full info with archive and SSO",
+			archiveErr: status.Error(codes.NotFound, "not found"),
+			setupMock: func(m *mocks.MockInfoServiceClient) {
+				m.On("GetInfo", mock.Anything, mock.Anything).Return(&info.InfoResponse{
+					ManagedNamespace: "argo",
+					Links:            []*wfv1.Link{{Name: "Logs", Scope: "workflow", URL: "https://logs"}},
+				}, nil)
+				m.On("GetVersion", mock.Anything, mock.Anything).Return(&wfv1.Version{Version: "v4.0.5", GitCommit: "abc123"}, nil)
+				m.On("GetUserInfo", mock.Anything, mock.Anything).Return(&info.GetUserInfoResponse{
+					Issuer: "https://dex.example.com",
+					Email:  "dev@example.com",
+					Groups: []string{"admins"},
+				}, nil)
+			},
+			wantArchive: true,
+			wantSSO:     true,
+			wantUser:    true,
+			wantVersion: true,
+		},
+		{
+			name:       "service account token without archive",
+			archiveErr: status.Error(codes.Internal, "getting archived workflows not supported"),
+			setupMock: func(m *mocks.MockInfoServiceClient) {
+				m.On("GetInfo", mock.Anything, mock.Anything).Return(&info.InfoResponse{}, nil)
+				m.On("GetVersion", mock.Anything, mock.Anything).Return(&wfv1.Version{Version: "v4.0.5"}, nil)
+				m.On("GetUserInfo", mock.Anything, mock.Anything).Return(&info.GetUserInfoResponse{
+					Issuer:             "kubernetes/serviceaccount",
+					ServiceAccountName: "argo-server",
+				}, nil)
+			},
+			wantUser:    true,
+			wantVersion: true,
+		},
+		{
+			name:       "optional calls fail with warnings",
+			archiveErr: status.Error(codes.Unavailable, "connection refused"),
+			setupMock: func(m *mocks.MockInfoServiceClient) {
+				m.On("GetInfo", mock.Anything, mock.Anything).Return(&info.InfoResponse{}, nil)
+				m.On("GetVersion", mock.Anything, mock.Anything).Return(nil, errors.New("boom"))
+				m.On("GetUserInfo", mock.Anything, mock.Anything).Return(nil, errors.New("boom"))
+			},
+			wantWarnings: 3,
+		},
+		{
+			name: "GetInfo fails",
+			setupMock: func(m *mocks.MockInfoServiceClient) {
+				m.On("GetInfo", mock.Anything, mock.Anything).Return(nil, errors.New("unauthorized"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			infoService := &mocks.MockInfoServiceClient{}
+			tt.setupMock(infoService)
+
+			archiveService := &mocks.MockArchivedWorkflowServiceClient{}
+			archiveService.On("GetArchivedWorkflow", mock.Anything, mock.Anything).Return(nil, tt.archiveErr)
+
+			client := mocks.NewMockClient("default", true)
+			client.SetInfoService(infoService)
+			client.SetArchivedWorkflowService(archiveService)
+
+			result, err := argo.GetClusterInfo(t.Context(), client)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, argo.ModeArgoServer, result.Mode)
+			assert.Equal(t, tt.wantArchive, result.ArchiveEnabled)
+			assert.Equal(t, tt.wantArchive, result.ArchiveToolsAvailable)
+			assert.Equal(t, tt.wantSSO, result.SSOEnabled)
+			assert.Equal(t, tt.wantUser, result.User != nil)
+			assert.Equal(t, tt.wantVersion, result.Version != "")
+			assert.Len(t, result.Warnings, tt.wantWarnings)
+		})
+	}
+}
+
+func TestGetClusterInfo_KubernetesMode(t *testing.T) {
+	replicas := int32(2)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "workflow-controller", Namespace: "argo"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name:    "workflow-controller",
+						Image:   "quay.io/argoproj/workflow-controller:v4.0.5",
+						Command: []string{"workflow-controller"},
+						Args:    []string{"--configmap", "custom-config", "--namespaced", "--managed-namespace=team-a"},
+					}},
+				},
+			},
+		},
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "custom-config", Namespace: "argo"},
+		Data: map[string]string{
+			"instanceID":  "prod",
+			"parallelism": "20",
+			"persistence": "archive: true\nnodeStatusOffLoad: true\n",
+		},
+	}
+
+	t.Run("reads deployment and configmap", func(t *testing.T) {
+		client := mocks.NewMockClient("default", false)
+		client.SetKubernetesClient(fake.NewClientset(deployment, configMap))
+
+		result, err := argo.GetClusterInfo(t.Context(), client)
+		require.NoError(t, err)
+
+		assert.Equal(t, argo.ModeKubernetesAPI, result.Mode)
+		assert.Equal(t, "v4.0.5", result.Version)
+		assert.Equal(t, "quay.io/argoproj/workflow-controller:v4.0.5", result.ControllerImage)
+		assert.Equal(t, int32(2), result.ControllerReplicas)
+		assert.Equal(t, "team-a", result.ManagedNamespace)
+		assert.Equal(t, "argo", result.ControllerNamespace)
+		assert.Equal(t, "prod", result.InstanceID)
+		assert.Equal(t, 20, result.Parallelism)
+		assert.True(t, result.ArchiveEnabled)
+		assert.True(t, result.NodeStatusOffload)
+		assert.False(t, result.ArchiveToolsAvailable)
+		assert.Len(t, result.Warnings, 1)
+	})
+
+	t.Run("missing controller reports warnings", func(t *testing.T) {
+		client := mocks.NewMockClient("default", false)
+		client.SetKubernetesClient(fake.NewClientset())
+
+		result, err := argo.GetClusterInfo(t.Context(), client)
+		require.NoError(t, err)
+
+		assert.Empty(t, result.Version)
+		assert.Len(t, result.Warnings, 2)
+	})
+
+	t.Run("no kubernetes client", func(t *testing.T) {
+		client := mocks.NewMockClient("default", false)
+		client.On("KubernetesClient").Return(nil, argo.ErrKubernetesClientNotAvailable)
+
+		_, err := argo.GetClusterInfo(t.Context(), client)
+		require.ErrorIs(t, err, argo.ErrKubernetesClientNotAvailable)
+	})
+}
+
+func TestGetControllerConfig(t *testing.T) {
+	t.Run("follows the --configmap flag of the controller", func(t *testing.T) {
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "workflow-controller", Namespace: "argo"},
+			Spec: appsv1.DeploymentSpec{Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "workflow-controller", Args: []string{"--configmap=custom-config"}}},
+			}}},
+		}
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "custom-config", Namespace: "argo"},
+			Data:       map[string]string{"parallelism": "10", "namespaceParallelism": "4"},
+		}
+		client := mocks.NewMockClient("default", false)
+		client.SetKubernetesClient(fake.NewClientset(deployment, configMap))
+
+		cfg, err := argo.GetControllerConfig(t.Context(), client)
+		require.NoError(t, err)
+		assert.Equal(t, 10, cfg.Parallelism)
+		assert.Equal(t, 4, cfg.NamespaceParallelism)
+	})
+
+	t.Run("defaults to workflow-controller-configmap", func(t *testing.T) {
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "workflow-controller-configmap", Namespace: "argo"},
+			Data:       map[string]string{"parallelism": "7"},
+		}
+		client := mocks.NewMockClient("default", false)
+		client.SetKubernetesClient(fake.NewClientset(configMap))
+
+		cfg, err := argo.GetControllerConfig(t.Context(), client)
+		require.NoError(t, err)
+		assert.Equal(t, 7, cfg.Parallelism)
+	})
+
+	t.Run("no kubernetes client", func(t *testing.T) {
+		client := mocks.NewMockClient("default", false)
+		client.On("KubernetesClient").Return(nil, argo.ErrKubernetesClientNotAvailable)
+
+		_, err := argo.GetControllerConfig(t.Context(), client)
+		require.ErrorIs(t, err, argo.ErrKubernetesClientNotAvailable)
+	})
+}
@@ -3,14 +3,19 @@ package mocks
 
 import (
 	"context"
+	"io"
 
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/clusterworkflowtemplate"
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/cronworkflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/event"
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/info"
+	syncpkg "github.com/argoproj/argo-workflows/v4/pkg/apiclient/sync"
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowarchive"
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	"github.com/argoproj/argo-workflows/v4/pkg/client/clientset/versioned"
 	"github.com/stretchr/testify/mock"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
 )
@@ -23,10 +28,16 @@ type MockClient struct {
 	clusterWorkflowTemplateService clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient
 	cronWorkflowService            cronworkflow.CronWorkflowServiceClient
 	archivedWorkflowService        workflowarchive.ArchivedWorkflowServiceClient
+	infoService                    info.InfoServiceClient
+	syncService                    syncpkg.SyncServiceClient
+	eventService                   event.EventServiceClient
+	kubernetesClient               kubernetes.Interface
+	workflowClientset              versioned.Interface
 	// ctx mirrors the real Client's context field for testing.
-	ctx            context.Context //nolint:containedctx // Mirrors real Client's Argo SDK pattern
-	namespace      string
-	argoServerMode bool
+	ctx                 context.Context //nolint:containedctx // Mirrors real Client's Argo SDK pattern
+	namespace           string
+	controllerNamespace string
+	argoServerMode      bool
 }
 
 // Ensure MockClient implements argo.ClientInterface.
@@ -65,6 +76,36 @@ func (m *MockClient) SetArchivedWorkflowService(service workflowarchive.Archived
 	m.archivedWorkflowService = service
 }
 
+// SetInfoService sets the info service client for this mock.
+func (m *MockClient) SetInfoService(service info.InfoServiceClient) {
+	m.infoService = service
+}
+
+// SetSyncService sets the sync service client for this mock.
+func (m *MockClient) SetSyncService(service syncpkg.SyncServiceClient) {
+	m.syncService = service
+}
+
+// SetEventService sets the event service client for this mock.
+func (m *MockClient) SetEventService(service event.EventServiceClient) {
+	m.eventService = service
+}
+
+// SetKubernetesClient sets the Kubernetes clientset for this mock.
+func (m *MockClient) SetKubernetesClient(client kubernetes.Interface) {
+	m.kubernetesClient = client
+}
+
+// SetWorkflowClientset sets the Argo Workflows clientset for this mock.
+func (m *MockClient) SetWorkflowClientset(client versioned.Interface) {
+	m.workflowClientset = client
+}
+
+// SetControllerNamespace sets the workflow controller namespace for this mock.
+func (m *MockClient) SetControllerNamespace(namespace string) {
+	m.controllerNamespace = namespace
+}
+
 // SetContext sets the context for this mock client.
 func (m *MockClient) SetContext(ctx context.Context) {
 	m.ctx = ctx
@@ -139,8 +180,11 @@ func (m *MockClient) ArchivedWorkflowService() (workflowarchive.ArchivedWorkflow
 	return svc, args.Error(1)
 }
 
-// InfoService returns the info service client (stub).
+// InfoService returns the info service client.
 func (m *MockClient) InfoService() (info.InfoServiceClient, error) {
+	if m.infoService != nil {
+		return m.infoService, nil
+	}
 	args := m.Called()
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -152,6 +196,91 @@ func (m *MockClient) InfoService() (info.InfoServiceClient, error) {
 	return svc, args.Error(1)
 }
 
+// KubernetesClient returns the Kubernetes clientset.
+func (m *MockClient) KubernetesClient() (kubernetes.Interface, error) {
+	if m.kubernetesClient != nil {
+		return m.kubernetesClient, nil
+	}
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	client, ok := args.Get(0).(kubernetes.Interface)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return client, args.Error(1)
+}
+
+// SyncService returns the sync service client.
+func (m *MockClient) SyncService() (syncpkg.SyncServiceClient, error) {
+	if m.syncService != nil {
+		return m.syncService, nil
+	}
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	svc, ok := args.Get(0).(syncpkg.SyncServiceClient)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return svc, args.Error(1)
+}
+
+// OpenArtifact opens an artifact.
+func (m *MockClient) OpenArtifact(ctx context.Context, req *argo.ArtifactRequest) (io.ReadCloser, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	reader, ok := args.Get(0).(io.ReadCloser)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return reader, args.Error(1)
+}
+
+// EventService returns the event service client.
+func (m *MockClient) EventService() (event.EventServiceClient, error) {
+	if m.eventService != nil {
+		return m.eventService, nil
+	}
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	svc, ok := args.Get(0).(event.EventServiceClient)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return svc, args.Error(1)
+}
+
+// WorkflowClientset returns the Argo Workflows clientset.
+func (m *MockClient) WorkflowClientset() (versioned.Interface, error) {
+	if m.workflowClientset != nil {
+		return m.workflowClientset, nil
+	}
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	client, ok := args.Get(0).(versioned.Interface)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return client, args.Error(1)
+}
+
+// ControllerNamespace returns the workflow controller namespace, defaulting to "argo".
+func (m *MockClient) ControllerNamespace() string {
+	if m.controllerNamespace != "" {
+		return m.controllerNamespace
+	}
+	return "argo"
+}
+
 // IsArgoServerMode returns whether this client is in Argo Server mode.
 func (m *MockClient) IsArgoServerMode() bool {
 	return m.argoServerMode
@@ -0,0 +1,45 @@
+// Package mocks provides mock implementations for testing.
+package mocks
+
+import (
+	"context"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/event"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+)
+
+// MockEventServiceClient is a mock implementation of event.EventServiceClient.
+type MockEventServiceClient struct {
+	mock.Mock
+}
+
+// Ensure MockEventServiceClient implements the interface.
+var _ event.EventServiceClient = (*MockEventServiceClient)(nil)
+
+// ReceiveEvent mocks the ReceiveEvent method.
+func (m *MockEventServiceClient) ReceiveEvent(ctx context.Context, req *event.EventRequest, opts ...grpc.CallOption) (*event.EventResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	resp, ok := args.Get(0).(*event.EventResponse)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return resp, args.Error(1)
+}
+
+// ListWorkflowEventBindings mocks the ListWorkflowEventBindings method.
+func (m *MockEventServiceClient) ListWorkflowEventBindings(ctx context.Context, req *event.ListWorkflowEventBindingsRequest, opts ...grpc.CallOption) (*wfv1.WorkflowEventBindingList, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	list, ok := args.Get(0).(*wfv1.WorkflowEventBindingList)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return list, args.Error(1)
+}
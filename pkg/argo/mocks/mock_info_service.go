@@ -0,0 +1,71 @@
+// Package mocks provides mock implementations for testing.
+package mocks
+
+import (
+	"context"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/info"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+)
+
+// MockInfoServiceClient is a mock implementation of info.InfoServiceClient.
+type MockInfoServiceClient struct {
+	mock.Mock
+}
+
+// Ensure MockInfoServiceClient implements the interface.
+var _ info.InfoServiceClient = (*MockInfoServiceClient)(nil)
+
+// GetInfo mocks the GetInfo method.
+func (m *MockInfoServiceClient) GetInfo(ctx context.Context, req *info.GetInfoRequest, opts ...grpc.CallOption) (*info.InfoResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	resp, ok := args.Get(0).(*info.InfoResponse)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return resp, args.Error(1)
+}
+
+// GetVersion mocks the GetVersion method.
+func (m *MockInfoServiceClient) GetVersion(ctx context.Context, req *info.GetVersionRequest, opts ...grpc.CallOption) (*wfv1.Version, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	version, ok := args.Get(0).(*wfv1.Version)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return version, args.Error(1)
+}
+
+// GetUserInfo mocks the GetUserInfo method.
+func (m *MockInfoServiceClient) GetUserInfo(ctx context.Context, req *info.GetUserInfoRequest, opts ...grpc.CallOption) (*info.GetUserInfoResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	resp, ok := args.Get(0).(*info.GetUserInfoResponse)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return resp, args.Error(1)
+}
+
+// CollectEvent mocks the CollectEvent method.
+func (m *MockInfoServiceClient) CollectEvent(ctx context.Context, req *info.CollectEventRequest, opts ...grpc.CallOption) (*info.CollectEventResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	resp, ok := args.Get(0).(*info.CollectEventResponse)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return resp, args.Error(1)
+}
@@ -0,0 +1,70 @@
+// Package mocks provides mock implementations for testing.
+package mocks
+
+import (
+	"context"
+
+	syncpkg "github.com/argoproj/argo-workflows/v4/pkg/apiclient/sync"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+)
+
+// MockSyncServiceClient is a mock implementation of syncpkg.SyncServiceClient.
+type MockSyncServiceClient struct {
+	mock.Mock
+}
+
+// Ensure MockSyncServiceClient implements the interface.
+var _ syncpkg.SyncServiceClient = (*MockSyncServiceClient)(nil)
+
+// CreateSyncLimit mocks the CreateSyncLimit method.
+func (m *MockSyncServiceClient) CreateSyncLimit(ctx context.Context, req *syncpkg.CreateSyncLimitRequest, opts ...grpc.CallOption) (*syncpkg.SyncLimitResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	resp, ok := args.Get(0).(*syncpkg.SyncLimitResponse)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return resp, args.Error(1)
+}
+
+// GetSyncLimit mocks the GetSyncLimit method.
+func (m *MockSyncServiceClient) GetSyncLimit(ctx context.Context, req *syncpkg.GetSyncLimitRequest, opts ...grpc.CallOption) (*syncpkg.SyncLimitResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	resp, ok := args.Get(0).(*syncpkg.SyncLimitResponse)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return resp, args.Error(1)
+}
+
+// UpdateSyncLimit mocks the UpdateSyncLimit method.
+func (m *MockSyncServiceClient) UpdateSyncLimit(ctx context.Context, req *syncpkg.UpdateSyncLimitRequest, opts ...grpc.CallOption) (*syncpkg.SyncLimitResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	resp, ok := args.Get(0).(*syncpkg.SyncLimitResponse)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return resp, args.Error(1)
+}
+
+// DeleteSyncLimit mocks the DeleteSyncLimit method.
+func (m *MockSyncServiceClient) DeleteSyncLimit(ctx context.Context, req *syncpkg.DeleteSyncLimitRequest, opts ...grpc.CallOption) (*syncpkg.DeleteSyncLimitResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	resp, ok := args.Get(0).(*syncpkg.DeleteSyncLimitResponse)
+	if !ok {
+		return nil, args.Error(1)
+	}
+	return resp, args.Error(1)
+}
@@ -0,0 +1,675 @@
+package argo
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/clusterworkflowtemplate"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/cronworkflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/event"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/info"
+	syncpkg "github.com/argoproj/argo-workflows/v4/pkg/apiclient/sync"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowarchive"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// CallObserver is notified about the Argo API calls made through an ObservedClient.
+// It is used to record metrics and traces without changing the tools.
+type CallObserver interface {
+	// StartCall is invoked before an API call with the service and method
+	// names, e.g. "WorkflowService" and "GetWorkflow". The call is made with
+	// the returned context, and the returned function is invoked with the
+	// call's error once it completes.
+	StartCall(ctx context.Context, service, method string) (context.Context, func(error))
+
+	// StartStream is invoked once a streaming call such as a watch or log
+	// stream is established. The returned function is invoked exactly once
+	// when the stream ends or its context is cancelled.
+	StartStream(service, method string) func()
+
+	// LogBytes is invoked with the size of each log line received from a log stream.
+	LogBytes(n int)
+}
+
+// ObservedClient wraps a ClientInterface and reports every Argo API call made
+// through its service clients to a CallObserver.
+type ObservedClient struct {
+	ClientInterface
+	observer CallObserver
+}
+
+// Ensure ObservedClient implements ClientInterface.
+var _ ClientInterface = (*ObservedClient)(nil)
+
+// NewObservedClient wraps client so that its API calls are reported to observer.
+func NewObservedClient(client ClientInterface, observer CallObserver) *ObservedClient {
+	return &ObservedClient{ClientInterface: client, observer: observer}
+}
+
+// WorkflowService returns the observed workflow service client.
+func (c *ObservedClient) WorkflowService() workflow.WorkflowServiceClient {
+	return &observedWorkflowService{next: c.ClientInterface.WorkflowService(), observer: c.observer}
+}
+
+// CronWorkflowService returns the observed cron workflow service client.
+func (c *ObservedClient) CronWorkflowService() (cronworkflow.CronWorkflowServiceClient, error) {
+	next, err := c.ClientInterface.CronWorkflowService()
+	if err != nil {
+		return nil, err
+	}
+	return &observedCronWorkflowService{next: next, observer: c.observer}, nil
+}
+
+// WorkflowTemplateService returns the observed workflow template service client.
+func (c *ObservedClient) WorkflowTemplateService() (workflowtemplate.WorkflowTemplateServiceClient, error) {
+	next, err := c.ClientInterface.WorkflowTemplateService()
+	if err != nil {
+		return nil, err
+	}
+	return &observedWorkflowTemplateService{next: next, observer: c.observer}, nil
+}
+
+// ClusterWorkflowTemplateService returns the observed cluster workflow template service client.
+func (c *ObservedClient) ClusterWorkflowTemplateService() (clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient, error) {
+	next, err := c.ClientInterface.ClusterWorkflowTemplateService()
+	if err != nil {
+		return nil, err
+	}
+	return &observedClusterWorkflowTemplateService{next: next, observer: c.observer}, nil
+}
+
+// ArchivedWorkflowService returns the observed archived workflow service client.
+func (c *ObservedClient) ArchivedWorkflowService() (workflowarchive.ArchivedWorkflowServiceClient, error) {
+	next, err := c.ClientInterface.ArchivedWorkflowService()
+	if err != nil {
+		return nil, err
+	}
+	return &observedArchivedWorkflowService{next: next, observer: c.observer}, nil
+}
+
+// InfoService returns the observed info service client.
+func (c *ObservedClient) InfoService() (info.InfoServiceClient, error) {
+	next, err := c.ClientInterface.InfoService()
+	if err != nil {
+		return nil, err
+	}
+	return &observedInfoService{next: next, observer: c.observer}, nil
+}
+
+// SyncService returns the observed sync service client.
+func (c *ObservedClient) SyncService() (syncpkg.SyncServiceClient, error) {
+	next, err := c.ClientInterface.SyncService()
+	if err != nil {
+		return nil, err
+	}
+	return &observedSyncService{next: next, observer: c.observer}, nil
+}
+
+// OpenArtifact times opening an artifact.
+func (c *ObservedClient) OpenArtifact(ctx context.Context, req *ArtifactRequest) (io.ReadCloser, error) {
+	return observe(ctx, c.observer, "ArtifactService", "OpenArtifact", func(ctx context.Context) (io.ReadCloser, error) {
+		return c.ClientInterface.OpenArtifact(ctx, req)
+	})
+}
+
+// EventService returns the observed event service client.
+func (c *ObservedClient) EventService() (event.EventServiceClient, error) {
+	next, err := c.ClientInterface.EventService()
+	if err != nil {
+		return nil, err
+	}
+	return &observedEventService{next: next, observer: c.observer}, nil
+}
+
+// observe runs call with the observer notified before and after.
+func observe[T any](ctx context.Context, observer CallObserver, service, method string, call func(context.Context) (T, error)) (T, error) {
+	ctx, done := observer.StartCall(ctx, service, method)
+	resp, err := call(ctx)
+	done(err)
+	return resp, err
+}
+
+// streamEnd reports the end of a stream to the observer exactly once, either
+// when Recv fails (including io.EOF) or when the stream context is cancelled.
+type streamEnd struct {
+	observer CallObserver
+	stop     func() bool
+	end      func()
+	once     sync.Once
+}
+
+// newStreamEnd notifies the observer that a stream has started and returns the
+// tracker that reports its end.
+func newStreamEnd(ctx context.Context, observer CallObserver, service, method string) *streamEnd {
+	s := &streamEnd{
+		observer: observer,
+		end:      observer.StartStream(service, method),
+	}
+	s.stop = context.AfterFunc(ctx, s.finish)
+	return s
+}
+
+// finish reports the end of the stream if it has not been reported yet.
+func (s *streamEnd) finish() {
+	s.once.Do(func() {
+		s.stop()
+		s.end()
+	})
+}
+
+// observedWorkflowService times calls to a workflow.WorkflowServiceClient.
+type observedWorkflowService struct {
+	next     workflow.WorkflowServiceClient
+	observer CallObserver
+}
+
+// CreateWorkflow implements workflow.WorkflowServiceClient.
+func (s *observedWorkflowService) CreateWorkflow(ctx context.Context, in *workflow.WorkflowCreateRequest, opts ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return observe(ctx, s.observer, "WorkflowService", "CreateWorkflow", func(ctx context.Context) (*wfv1.Workflow, error) {
+		return s.next.CreateWorkflow(ctx, in, opts...)
+	})
+}
+
+// GetWorkflow implements workflow.WorkflowServiceClient.
+func (s *observedWorkflowService) GetWorkflow(ctx context.Context, in *workflow.WorkflowGetRequest, opts ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return observe(ctx, s.observer, "WorkflowService", "GetWorkflow", func(ctx context.Context) (*wfv1.Workflow, error) {
+		return s.next.GetWorkflow(ctx, in, opts...)
+	})
+}
+
+// ListWorkflows implements workflow.WorkflowServiceClient.
+func (s *observedWorkflowService) ListWorkflows(ctx context.Context, in *workflow.WorkflowListRequest, opts ...grpc.CallOption) (*wfv1.WorkflowList, error) {
+	return observe(ctx, s.observer, "WorkflowService", "ListWorkflows", func(ctx context.Context) (*wfv1.WorkflowList, error) {
+		return s.next.ListWorkflows(ctx, in, opts...)
+	})
+}
+
+// WatchWorkflows implements workflow.WorkflowServiceClient.
+func (s *observedWorkflowService) WatchWorkflows(ctx context.Context, in *workflow.WatchWorkflowsRequest, opts ...grpc.CallOption) (workflow.WorkflowService_WatchWorkflowsClient, error) {
+	stream, err := observe(ctx, s.observer, "WorkflowService", "WatchWorkflows", func(ctx context.Context) (workflow.WorkflowService_WatchWorkflowsClient, error) {
+		return s.next.WatchWorkflows(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &observedWatchWorkflowsStream{WorkflowService_WatchWorkflowsClient: stream, streamEnd: newStreamEnd(ctx, s.observer, "WorkflowService", "WatchWorkflows")}, nil
+}
+
+// WatchEvents implements workflow.WorkflowServiceClient.
+func (s *observedWorkflowService) WatchEvents(ctx context.Context, in *workflow.WatchEventsRequest, opts ...grpc.CallOption) (workflow.WorkflowService_WatchEventsClient, error) {
+	stream, err := observe(ctx, s.observer, "WorkflowService", "WatchEvents", func(ctx context.Context) (workflow.WorkflowService_WatchEventsClient, error) {
+		return s.next.WatchEvents(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &observedWatchEventsStream{WorkflowService_WatchEventsClient: stream, streamEnd: newStreamEnd(ctx, s.observer, "WorkflowService", "WatchEvents")}, nil
+}
+
+// DeleteWorkflow implements workflow.WorkflowServiceClient.
+func (s *observedWorkflowService) DeleteWorkflow(ctx context.Context, in *workflow.WorkflowDeleteRequest, opts ...grpc.CallOption) (*workflow.WorkflowDeleteResponse, error) {
+	return observe(ctx, s.observer, "WorkflowService", "DeleteWorkflow", func(ctx context.Context) (*workflow.WorkflowDeleteResponse, error) {
+		return s.next.DeleteWorkflow(ctx, in, opts...)
+	})
+}
+
+// RetryWorkflow implements workflow.WorkflowServiceClient.
+func (s *observedWorkflowService) RetryWorkflow(ctx context.Context, in *workflow.WorkflowRetryRequest, opts ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return observe(ctx, s.observer, "WorkflowService", "RetryWorkflow", func(ctx context.Context) (*wfv1.Workflow, error) {
+		return s.next.RetryWorkflow(ctx, in, opts...)
+	})
+}
+
+// ResubmitWorkflow implements workflow.WorkflowServiceClient.
+func (s *observedWorkflowService) ResubmitWorkflow(ctx context.Context, in *workflow.WorkflowResubmitRequest, opts ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return observe(ctx, s.observer, "WorkflowService", "ResubmitWorkflow", func(ctx context.Context) (*wfv1.Workflow, error) {
+		return s.next.ResubmitWorkflow(ctx, in, opts...)
+	})
+}
+
+// ResumeWorkflow implements workflow.WorkflowServiceClient.
+func (s *observedWorkflowService) ResumeWorkflow(ctx context.Context, in *workflow.WorkflowResumeRequest, opts ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return observe(ctx, s.observer, "WorkflowService", "ResumeWorkflow", func(ctx context.Context) (*wfv1.Workflow, error) {
+		return s.next.ResumeWorkflow(ctx, in, opts...)
+	})
+}
+
+// SuspendWorkflow implements workflow.WorkflowServiceClient.
+func (s *observedWorkflowService) SuspendWorkflow(ctx context.Context, in *workflow.WorkflowSuspendRequest, opts ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return observe(ctx, s.observer, "WorkflowService", "SuspendWorkflow", func(ctx context.Context) (*wfv1.Workflow, error) {
+		return s.next.SuspendWorkflow(ctx, in, opts...)
+	})
+}
+
+// TerminateWorkflow implements workflow.WorkflowServiceClient.
+func (s *observedWorkflowService) TerminateWorkflow(ctx context.Context, in *workflow.WorkflowTerminateRequest, opts ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return observe(ctx, s.observer, "WorkflowService", "TerminateWorkflow", func(ctx context.Context) (*wfv1.Workflow, error) {
+		return s.next.TerminateWorkflow(ctx, in, opts...)
+	})
+}
+
+// StopWorkflow implements workflow.WorkflowServiceClient.
+func (s *observedWorkflowService) StopWorkflow(ctx context.Context, in *workflow.WorkflowStopRequest, opts ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return observe(ctx, s.observer, "WorkflowService", "StopWorkflow", func(ctx context.Context) (*wfv1.Workflow, error) {
+		return s.next.StopWorkflow(ctx, in, opts...)
+	})
+}
+
+// SetWorkflow implements workflow.WorkflowServiceClient.
+func (s *observedWorkflowService) SetWorkflow(ctx context.Context, in *workflow.WorkflowSetRequest, opts ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return observe(ctx, s.observer, "WorkflowService", "SetWorkflow", func(ctx context.Context) (*wfv1.Workflow, error) {
+		return s.next.SetWorkflow(ctx, in, opts...)
+	})
+}
+
+// LintWorkflow implements workflow.WorkflowServiceClient.
+func (s *observedWorkflowService) LintWorkflow(ctx context.Context, in *workflow.WorkflowLintRequest, opts ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return observe(ctx, s.observer, "WorkflowService", "LintWorkflow", func(ctx context.Context) (*wfv1.Workflow, error) {
+		return s.next.LintWorkflow(ctx, in, opts...)
+	})
+}
+
+// PodLogs implements workflow.WorkflowServiceClient.
+func (s *observedWorkflowService) PodLogs(ctx context.Context, in *workflow.WorkflowLogRequest, opts ...grpc.CallOption) (workflow.WorkflowService_PodLogsClient, error) {
+	stream, err := observe(ctx, s.observer, "WorkflowService", "PodLogs", func(ctx context.Context) (workflow.WorkflowService_PodLogsClient, error) {
+		return s.next.PodLogs(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &observedPodLogsStream{WorkflowService_PodLogsClient: stream, streamEnd: newStreamEnd(ctx, s.observer, "WorkflowService", "PodLogs")}, nil
+}
+
+// WorkflowLogs implements workflow.WorkflowServiceClient.
+func (s *observedWorkflowService) WorkflowLogs(ctx context.Context, in *workflow.WorkflowLogRequest, opts ...grpc.CallOption) (workflow.WorkflowService_WorkflowLogsClient, error) {
+	stream, err := observe(ctx, s.observer, "WorkflowService", "WorkflowLogs", func(ctx context.Context) (workflow.WorkflowService_WorkflowLogsClient, error) {
+		return s.next.WorkflowLogs(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &observedWorkflowLogsStream{WorkflowService_WorkflowLogsClient: stream, streamEnd: newStreamEnd(ctx, s.observer, "WorkflowService", "WorkflowLogs")}, nil
+}
+
+// SubmitWorkflow implements workflow.WorkflowServiceClient.
+func (s *observedWorkflowService) SubmitWorkflow(ctx context.Context, in *workflow.WorkflowSubmitRequest, opts ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return observe(ctx, s.observer, "WorkflowService", "SubmitWorkflow", func(ctx context.Context) (*wfv1.Workflow, error) {
+		return s.next.SubmitWorkflow(ctx, in, opts...)
+	})
+}
+
+// observedCronWorkflowService times calls to a cronworkflow.CronWorkflowServiceClient.
+type observedCronWorkflowService struct {
+	next     cronworkflow.CronWorkflowServiceClient
+	observer CallObserver
+}
+
+// LintCronWorkflow implements cronworkflow.CronWorkflowServiceClient.
+func (s *observedCronWorkflowService) LintCronWorkflow(ctx context.Context, in *cronworkflow.LintCronWorkflowRequest, opts ...grpc.CallOption) (*wfv1.CronWorkflow, error) {
+	return observe(ctx, s.observer, "CronWorkflowService", "LintCronWorkflow", func(ctx context.Context) (*wfv1.CronWorkflow, error) {
+		return s.next.LintCronWorkflow(ctx, in, opts...)
+	})
+}
+
+// CreateCronWorkflow implements cronworkflow.CronWorkflowServiceClient.
+func (s *observedCronWorkflowService) CreateCronWorkflow(ctx context.Context, in *cronworkflow.CreateCronWorkflowRequest, opts ...grpc.CallOption) (*wfv1.CronWorkflow, error) {
+	return observe(ctx, s.observer, "CronWorkflowService", "CreateCronWorkflow", func(ctx context.Context) (*wfv1.CronWorkflow, error) {
+		return s.next.CreateCronWorkflow(ctx, in, opts...)
+	})
+}
+
+// ListCronWorkflows implements cronworkflow.CronWorkflowServiceClient.
+func (s *observedCronWorkflowService) ListCronWorkflows(ctx context.Context, in *cronworkflow.ListCronWorkflowsRequest, opts ...grpc.CallOption) (*wfv1.CronWorkflowList, error) {
+	return observe(ctx, s.observer, "CronWorkflowService", "ListCronWorkflows", func(ctx context.Context) (*wfv1.CronWorkflowList, error) {
+		return s.next.ListCronWorkflows(ctx, in, opts...)
+	})
+}
+
+// GetCronWorkflow implements cronworkflow.CronWorkflowServiceClient.
+func (s *observedCronWorkflowService) GetCronWorkflow(ctx context.Context, in *cronworkflow.GetCronWorkflowRequest, opts ...grpc.CallOption) (*wfv1.CronWorkflow, error) {
+	return observe(ctx, s.observer, "CronWorkflowService", "GetCronWorkflow", func(ctx context.Context) (*wfv1.CronWorkflow, error) {
+		return s.next.GetCronWorkflow(ctx, in, opts...)
+	})
+}
+
+// UpdateCronWorkflow implements cronworkflow.CronWorkflowServiceClient.
+func (s *observedCronWorkflowService) UpdateCronWorkflow(ctx context.Context, in *cronworkflow.UpdateCronWorkflowRequest, opts ...grpc.CallOption) (*wfv1.CronWorkflow, error) {
+	return observe(ctx, s.observer, "CronWorkflowService", "UpdateCronWorkflow", func(ctx context.Context) (*wfv1.CronWorkflow, error) {
+		return s.next.UpdateCronWorkflow(ctx, in, opts...)
+	})
+}
+
+// DeleteCronWorkflow implements cronworkflow.CronWorkflowServiceClient.
+func (s *observedCronWorkflowService) DeleteCronWorkflow(ctx context.Context, in *cronworkflow.DeleteCronWorkflowRequest, opts ...grpc.CallOption) (*cronworkflow.CronWorkflowDeletedResponse, error) {
+	return observe(ctx, s.observer, "CronWorkflowService", "DeleteCronWorkflow", func(ctx context.Context) (*cronworkflow.CronWorkflowDeletedResponse, error) {
+		return s.next.DeleteCronWorkflow(ctx, in, opts...)
+	})
+}
+
+// ResumeCronWorkflow implements cronworkflow.CronWorkflowServiceClient.
+func (s *observedCronWorkflowService) ResumeCronWorkflow(ctx context.Context, in *cronworkflow.CronWorkflowResumeRequest, opts ...grpc.CallOption) (*wfv1.CronWorkflow, error) {
+	return observe(ctx, s.observer, "CronWorkflowService", "ResumeCronWorkflow", func(ctx context.Context) (*wfv1.CronWorkflow, error) {
+		return s.next.ResumeCronWorkflow(ctx, in, opts...)
+	})
+}
+
+// SuspendCronWorkflow implements cronworkflow.CronWorkflowServiceClient.
+func (s *observedCronWorkflowService) SuspendCronWorkflow(ctx context.Context, in *cronworkflow.CronWorkflowSuspendRequest, opts ...grpc.CallOption) (*wfv1.CronWorkflow, error) {
+	return observe(ctx, s.observer, "CronWorkflowService", "SuspendCronWorkflow", func(ctx context.Context) (*wfv1.CronWorkflow, error) {
+		return s.next.SuspendCronWorkflow(ctx, in, opts...)
+	})
+}
+
+// observedWorkflowTemplateService times calls to a workflowtemplate.WorkflowTemplateServiceClient.
+type observedWorkflowTemplateService struct {
+	next     workflowtemplate.WorkflowTemplateServiceClient
+	observer CallObserver
+}
+
+// CreateWorkflowTemplate implements workflowtemplate.WorkflowTemplateServiceClient.
+func (s *observedWorkflowTemplateService) CreateWorkflowTemplate(ctx context.Context, in *workflowtemplate.WorkflowTemplateCreateRequest, opts ...grpc.CallOption) (*wfv1.WorkflowTemplate, error) {
+	return observe(ctx, s.observer, "WorkflowTemplateService", "CreateWorkflowTemplate", func(ctx context.Context) (*wfv1.WorkflowTemplate, error) {
+		return s.next.CreateWorkflowTemplate(ctx, in, opts...)
+	})
+}
+
+// GetWorkflowTemplate implements workflowtemplate.WorkflowTemplateServiceClient.
+func (s *observedWorkflowTemplateService) GetWorkflowTemplate(ctx context.Context, in *workflowtemplate.WorkflowTemplateGetRequest, opts ...grpc.CallOption) (*wfv1.WorkflowTemplate, error) {
+	return observe(ctx, s.observer, "WorkflowTemplateService", "GetWorkflowTemplate", func(ctx context.Context) (*wfv1.WorkflowTemplate, error) {
+		return s.next.GetWorkflowTemplate(ctx, in, opts...)
+	})
+}
+
+// ListWorkflowTemplates implements workflowtemplate.WorkflowTemplateServiceClient.
+func (s *observedWorkflowTemplateService) ListWorkflowTemplates(ctx context.Context, in *workflowtemplate.WorkflowTemplateListRequest, opts ...grpc.CallOption) (*wfv1.WorkflowTemplateList, error) {
+	return observe(ctx, s.observer, "WorkflowTemplateService", "ListWorkflowTemplates", func(ctx context.Context) (*wfv1.WorkflowTemplateList, error) {
+		return s.next.ListWorkflowTemplates(ctx, in, opts...)
+	})
+}
+
+// UpdateWorkflowTemplate implements workflowtemplate.WorkflowTemplateServiceClient.
+func (s *observedWorkflowTemplateService) UpdateWorkflowTemplate(ctx context.Context, in *workflowtemplate.WorkflowTemplateUpdateRequest, opts ...grpc.CallOption) (*wfv1.WorkflowTemplate, error) {
+	return observe(ctx, s.observer, "WorkflowTemplateService", "UpdateWorkflowTemplate", func(ctx context.Context) (*wfv1.WorkflowTemplate, error) {
+		return s.next.UpdateWorkflowTemplate(ctx, in, opts...)
+	})
+}
+
+// DeleteWorkflowTemplate implements workflowtemplate.WorkflowTemplateServiceClient.
+func (s *observedWorkflowTemplateService) DeleteWorkflowTemplate(ctx context.Context, in *workflowtemplate.WorkflowTemplateDeleteRequest, opts ...grpc.CallOption) (*workflowtemplate.WorkflowTemplateDeleteResponse, error) {
+	return observe(ctx, s.observer, "WorkflowTemplateService", "DeleteWorkflowTemplate", func(ctx context.Context) (*workflowtemplate.WorkflowTemplateDeleteResponse, error) {
+		return s.next.DeleteWorkflowTemplate(ctx, in, opts...)
+	})
+}
+
+// LintWorkflowTemplate implements workflowtemplate.WorkflowTemplateServiceClient.
+func (s *observedWorkflowTemplateService) LintWorkflowTemplate(ctx context.Context, in *workflowtemplate.WorkflowTemplateLintRequest, opts ...grpc.CallOption) (*wfv1.WorkflowTemplate, error) {
+	return observe(ctx, s.observer, "WorkflowTemplateService", "LintWorkflowTemplate", func(ctx context.Context) (*wfv1.WorkflowTemplate, error) {
+		return s.next.LintWorkflowTemplate(ctx, in, opts...)
+	})
+}
+
+// observedClusterWorkflowTemplateService times calls to a clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient.
+type observedClusterWorkflowTemplateService struct {
+	next     clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient
+	observer CallObserver
+}
+
+// CreateClusterWorkflowTemplate implements clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient.
+func (s *observedClusterWorkflowTemplateService) CreateClusterWorkflowTemplate(ctx context.Context, in *clusterworkflowtemplate.ClusterWorkflowTemplateCreateRequest, opts ...grpc.CallOption) (*wfv1.ClusterWorkflowTemplate, error) {
+	return observe(ctx, s.observer, "ClusterWorkflowTemplateService", "CreateClusterWorkflowTemplate", func(ctx context.Context) (*wfv1.ClusterWorkflowTemplate, error) {
+		return s.next.CreateClusterWorkflowTemplate(ctx, in, opts...)
+	})
+}
+
+// GetClusterWorkflowTemplate implements clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient.
+func (s *observedClusterWorkflowTemplateService) GetClusterWorkflowTemplate(ctx context.Context, in *clusterworkflowtemplate.ClusterWorkflowTemplateGetRequest, opts ...grpc.CallOption) (*wfv1.ClusterWorkflowTemplate, error) {
+	return observe(ctx, s.observer, "ClusterWorkflowTemplateService", "GetClusterWorkflowTemplate", func(ctx context.Context) (*wfv1.ClusterWorkflowTemplate, error) {
+		return s.next.GetClusterWorkflowTemplate(ctx, in, opts...)
+	})
+}
+
+// ListClusterWorkflowTemplates implements clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient.
+func (s *observedClusterWorkflowTemplateService) ListClusterWorkflowTemplates(ctx context.Context, in *clusterworkflowtemplate.ClusterWorkflowTemplateListRequest, opts ...grpc.CallOption) (*wfv1.ClusterWorkflowTemplateList, error) {
+	return observe(ctx, s.observer, "ClusterWorkflowTemplateService", "ListClusterWorkflowTemplates", func(ctx context.Context) (*wfv1.ClusterWorkflowTemplateList, error) {
+		return s.next.ListClusterWorkflowTemplates(ctx, in, opts...)
+	})
+}
+
+// UpdateClusterWorkflowTemplate implements clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient.
+func (s *observedClusterWorkflowTemplateService) UpdateClusterWorkflowTemplate(ctx context.Context, in *clusterworkflowtemplate.ClusterWorkflowTemplateUpdateRequest, opts ...grpc.CallOption) (*wfv1.ClusterWorkflowTemplate, error) {
+	return observe(ctx, s.observer, "ClusterWorkflowTemplateService", "UpdateClusterWorkflowTemplate", func(ctx context.Context) (*wfv1.ClusterWorkflowTemplate, error) {
+		return s.next.UpdateClusterWorkflowTemplate(ctx, in, opts...)
+	})
+}
+
+// DeleteClusterWorkflowTemplate implements clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient.
+func (s *observedClusterWorkflowTemplateService) DeleteClusterWorkflowTemplate(ctx context.Context, in *clusterworkflowtemplate.ClusterWorkflowTemplateDeleteRequest, opts ...grpc.CallOption) (*clusterworkflowtemplate.ClusterWorkflowTemplateDeleteResponse, error) {
+	return observe(ctx, s.observer, "ClusterWorkflowTemplateService", "DeleteClusterWorkflowTemplate", func(ctx context.Context) (*clusterworkflowtemplate.ClusterWorkflowTemplateDeleteResponse, error) {
+		return s.next.DeleteClusterWorkflowTemplate(ctx, in, opts...)
+	})
+}
+
+// LintClusterWorkflowTemplate implements clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient.
+func (s *observedClusterWorkflowTemplateService) LintClusterWorkflowTemplate(ctx context.Context, in *clusterworkflowtemplate.ClusterWorkflowTemplateLintRequest, opts ...grpc.CallOption) (*wfv1.ClusterWorkflowTemplate, error) {
+	return observe(ctx, s.observer, "ClusterWorkflowTemplateService", "LintClusterWorkflowTemplate", func(ctx context.Context) (*wfv1.ClusterWorkflowTemplate, error) {
+		return s.next.LintClusterWorkflowTemplate(ctx, in, opts...)
+	})
+}
+
+// observedArchivedWorkflowService times calls to a workflowarchive.ArchivedWorkflowServiceClient.
+type observedArchivedWorkflowService struct {
+	next     workflowarchive.ArchivedWorkflowServiceClient
+	observer CallObserver
+}
+
+// ListArchivedWorkflows implements workflowarchive.ArchivedWorkflowServiceClient.
+func (s *observedArchivedWorkflowService) ListArchivedWorkflows(ctx context.Context, in *workflowarchive.ListArchivedWorkflowsRequest, opts ...grpc.CallOption) (*wfv1.WorkflowList, error) {
+	return observe(ctx, s.observer, "ArchivedWorkflowService", "ListArchivedWorkflows", func(ctx context.Context) (*wfv1.WorkflowList, error) {
+		return s.next.ListArchivedWorkflows(ctx, in, opts...)
+	})
+}
+
+// GetArchivedWorkflow implements workflowarchive.ArchivedWorkflowServiceClient.
+func (s *observedArchivedWorkflowService) GetArchivedWorkflow(ctx context.Context, in *workflowarchive.GetArchivedWorkflowRequest, opts ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return observe(ctx, s.observer, "ArchivedWorkflowService", "GetArchivedWorkflow", func(ctx context.Context) (*wfv1.Workflow, error) {
+		return s.next.GetArchivedWorkflow(ctx, in, opts...)
+	})
+}
+
+// DeleteArchivedWorkflow implements workflowarchive.ArchivedWorkflowServiceClient.
+func (s *observedArchivedWorkflowService) DeleteArchivedWorkflow(ctx context.Context, in *workflowarchive.DeleteArchivedWorkflowRequest, opts ...grpc.CallOption) (*workflowarchive.ArchivedWorkflowDeletedResponse, error) {
+	return observe(ctx, s.observer, "ArchivedWorkflowService", "DeleteArchivedWorkflow", func(ctx context.Context) (*workflowarchive.ArchivedWorkflowDeletedResponse, error) {
+		return s.next.DeleteArchivedWorkflow(ctx, in, opts...)
+	})
+}
+
+// ListArchivedWorkflowLabelKeys implements workflowarchive.ArchivedWorkflowServiceClient.
+func (s *observedArchivedWorkflowService) ListArchivedWorkflowLabelKeys(ctx context.Context, in *workflowarchive.ListArchivedWorkflowLabelKeysRequest, opts ...grpc.CallOption) (*wfv1.LabelKeys, error) {
+	return observe(ctx, s.observer, "ArchivedWorkflowService", "ListArchivedWorkflowLabelKeys", func(ctx context.Context) (*wfv1.LabelKeys, error) {
+		return s.next.ListArchivedWorkflowLabelKeys(ctx, in, opts...)
+	})
+}
+
+// ListArchivedWorkflowLabelValues implements workflowarchive.ArchivedWorkflowServiceClient.
+func (s *observedArchivedWorkflowService) ListArchivedWorkflowLabelValues(ctx context.Context, in *workflowarchive.ListArchivedWorkflowLabelValuesRequest, opts ...grpc.CallOption) (*wfv1.LabelValues, error) {
+	return observe(ctx, s.observer, "ArchivedWorkflowService", "ListArchivedWorkflowLabelValues", func(ctx context.Context) (*wfv1.LabelValues, error) {
+		return s.next.ListArchivedWorkflowLabelValues(ctx, in, opts...)
+	})
+}
+
+// RetryArchivedWorkflow implements workflowarchive.ArchivedWorkflowServiceClient.
+func (s *observedArchivedWorkflowService) RetryArchivedWorkflow(ctx context.Context, in *workflowarchive.RetryArchivedWorkflowRequest, opts ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return observe(ctx, s.observer, "ArchivedWorkflowService", "RetryArchivedWorkflow", func(ctx context.Context) (*wfv1.Workflow, error) {
+		return s.next.RetryArchivedWorkflow(ctx, in, opts...)
+	})
+}
+
+// ResubmitArchivedWorkflow implements workflowarchive.ArchivedWorkflowServiceClient.
+func (s *observedArchivedWorkflowService) ResubmitArchivedWorkflow(ctx context.Context, in *workflowarchive.ResubmitArchivedWorkflowRequest, opts ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return observe(ctx, s.observer, "ArchivedWorkflowService", "ResubmitArchivedWorkflow", func(ctx context.Context) (*wfv1.Workflow, error) {
+		return s.next.ResubmitArchivedWorkflow(ctx, in, opts...)
+	})
+}
+
+// observedInfoService times calls to a info.InfoServiceClient.
+type observedInfoService struct {
+	next     info.InfoServiceClient
+	observer CallObserver
+}
+
+// GetInfo implements info.InfoServiceClient.
+func (s *observedInfoService) GetInfo(ctx context.Context, in *info.GetInfoRequest, opts ...grpc.CallOption) (*info.InfoResponse, error) {
+	return observe(ctx, s.observer, "InfoService", "GetInfo", func(ctx context.Context) (*info.InfoResponse, error) {
+		return s.next.GetInfo(ctx, in, opts...)
+	})
+}
+
+// GetVersion implements info.InfoServiceClient.
+func (s *observedInfoService) GetVersion(ctx context.Context, in *info.GetVersionRequest, opts ...grpc.CallOption) (*wfv1.Version, error) {
+	return observe(ctx, s.observer, "InfoService", "GetVersion", func(ctx context.Context) (*wfv1.Version, error) {
+		return s.next.GetVersion(ctx, in, opts...)
+	})
+}
+
+// GetUserInfo implements info.InfoServiceClient.
+func (s *observedInfoService) GetUserInfo(ctx context.Context, in *info.GetUserInfoRequest, opts ...grpc.CallOption) (*info.GetUserInfoResponse, error) {
+	return observe(ctx, s.observer, "InfoService", "GetUserInfo", func(ctx context.Context) (*info.GetUserInfoResponse, error) {
+		return s.next.GetUserInfo(ctx, in, opts...)
+	})
+}
+
+// CollectEvent implements info.InfoServiceClient.
+func (s *observedInfoService) CollectEvent(ctx context.Context, in *info.CollectEventRequest, opts ...grpc.CallOption) (*info.CollectEventResponse, error) {
+	return observe(ctx, s.observer, "InfoService", "CollectEvent", func(ctx context.Context) (*info.CollectEventResponse, error) {
+		return s.next.CollectEvent(ctx, in, opts...)
+	})
+}
+
+// observedSyncService times calls to a syncpkg.SyncServiceClient.
+type observedSyncService struct {
+	next     syncpkg.SyncServiceClient
+	observer CallObserver
+}
+
+// CreateSyncLimit implements syncpkg.SyncServiceClient.
+func (s *observedSyncService) CreateSyncLimit(ctx context.Context, in *syncpkg.CreateSyncLimitRequest, opts ...grpc.CallOption) (*syncpkg.SyncLimitResponse, error) {
+	return observe(ctx, s.observer, "SyncService", "CreateSyncLimit", func(ctx context.Context) (*syncpkg.SyncLimitResponse, error) {
+		return s.next.CreateSyncLimit(ctx, in, opts...)
+	})
+}
+
+// GetSyncLimit implements syncpkg.SyncServiceClient.
+func (s *observedSyncService) GetSyncLimit(ctx context.Context, in *syncpkg.GetSyncLimitRequest, opts ...grpc.CallOption) (*syncpkg.SyncLimitResponse, error) {
+	return observe(ctx, s.observer, "SyncService", "GetSyncLimit", func(ctx context.Context) (*syncpkg.SyncLimitResponse, error) {
+		return s.next.GetSyncLimit(ctx, in, opts...)
+	})
+}
+
+// UpdateSyncLimit implements syncpkg.SyncServiceClient.
+func (s *observedSyncService) UpdateSyncLimit(ctx context.Context, in *syncpkg.UpdateSyncLimitRequest, opts ...grpc.CallOption) (*syncpkg.SyncLimitResponse, error) {
+	return observe(ctx, s.observer, "SyncService", "UpdateSyncLimit", func(ctx context.Context) (*syncpkg.SyncLimitResponse, error) {
+		return s.next.UpdateSyncLimit(ctx, in, opts...)
+	})
+}
+
+// DeleteSyncLimit implements syncpkg.SyncServiceClient.
+func (s *observedSyncService) DeleteSyncLimit(ctx context.Context, in *syncpkg.DeleteSyncLimitRequest, opts ...grpc.CallOption) (*syncpkg.DeleteSyncLimitResponse, error) {
+	return observe(ctx, s.observer, "SyncService", "DeleteSyncLimit", func(ctx context.Context) (*syncpkg.DeleteSyncLimitResponse, error) {
+		return s.next.DeleteSyncLimit(ctx, in, opts...)
+	})
+}
+
+// observedEventService times calls to an event.EventServiceClient.
+type observedEventService struct {
+	next     event.EventServiceClient
+	observer CallObserver
+}
+
+// ReceiveEvent implements event.EventServiceClient.
+func (s *observedEventService) ReceiveEvent(ctx context.Context, in *event.EventRequest, opts ...grpc.CallOption) (*event.EventResponse, error) {
+	return observe(ctx, s.observer, "EventService", "ReceiveEvent", func(ctx context.Context) (*event.EventResponse, error) {
+		return s.next.ReceiveEvent(ctx, in, opts...)
+	})
+}
+
+// ListWorkflowEventBindings implements event.EventServiceClient.
+func (s *observedEventService) ListWorkflowEventBindings(ctx context.Context, in *event.ListWorkflowEventBindingsRequest, opts ...grpc.CallOption) (*wfv1.WorkflowEventBindingList, error) {
+	return observe(ctx, s.observer, "EventService", "ListWorkflowEventBindings", func(ctx context.Context) (*wfv1.WorkflowEventBindingList, error) {
+		return s.next.ListWorkflowEventBindings(ctx, in, opts...)
+	})
+}
+
+// observedWatchWorkflowsStream reports when a workflow.WorkflowService_WatchWorkflowsClient ends.
+type observedWatchWorkflowsStream struct {
+	workflow.WorkflowService_WatchWorkflowsClient
+	*streamEnd
+}
+
+// Recv implements workflow.WorkflowService_WatchWorkflowsClient.
+func (s *observedWatchWorkflowsStream) Recv() (*workflow.WorkflowWatchEvent, error) {
+	msg, err := s.WorkflowService_WatchWorkflowsClient.Recv()
+	if err != nil {
+		s.finish()
+		return msg, err
+	}
+	return msg, nil
+}
+
+// observedWatchEventsStream reports when a workflow.WorkflowService_WatchEventsClient ends.
+type observedWatchEventsStream struct {
+	workflow.WorkflowService_WatchEventsClient
+	*streamEnd
+}
+
+// Recv implements workflow.WorkflowService_WatchEventsClient.
+func (s *observedWatchEventsStream) Recv() (*corev1.Event, error) {
+	msg, err := s.WorkflowService_WatchEventsClient.Recv()
+	if err != nil {
+		s.finish()
+		return msg, err
+	}
+	return msg, nil
+}
+
+// observedPodLogsStream reports when a workflow.WorkflowService_PodLogsClient ends.
+type observedPodLogsStream struct {
+	workflow.WorkflowService_PodLogsClient
+	*streamEnd
+}
+
+// Recv implements workflow.WorkflowService_PodLogsClient.
+func (s *observedPodLogsStream) Recv() (*workflow.LogEntry, error) {
+	msg, err := s.WorkflowService_PodLogsClient.Recv()
+	if err != nil {
+		s.finish()
+		return msg, err
+	}
+	if msg != nil {
+		s.observer.LogBytes(len(msg.Content))
+	}
+	return msg, nil
+}
+
+// observedWorkflowLogsStream reports when a workflow.WorkflowService_WorkflowLogsClient ends.
+type observedWorkflowLogsStream struct {
+	workflow.WorkflowService_WorkflowLogsClient
+	*streamEnd
+}
+
+// Recv implements workflow.WorkflowService_WorkflowLogsClient.
+func (s *observedWorkflowLogsStream) Recv() (*workflow.LogEntry, error) {
+	msg, err := s.WorkflowService_WorkflowLogsClient.Recv()
+	if err != nil {
+		s.finish()
+		return msg, err
+	}
+	if msg != nil {
+		s.observer.LogBytes(len(msg.Content))
+	}
+	return msg, nil
+}
@@ -0,0 +1,203 @@
+package argo_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/event"
+	syncpkg "github.com/argoproj/argo-workflows/v4/pkg/apiclient/sync"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+)
+
+// recordingObserver records the calls reported by an ObservedClient.
+type recordingObserver struct {
+	calls       []string
+	errs        []error
+	openStreams int
+	logBytes    int
+	mu          sync.Mutex
+}
+
+func (o *recordingObserver) StartCall(ctx context.Context, service, method string) (context.Context, func(error)) {
+	return ctx, func(err error) {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		o.calls = append(o.calls, service+"/"+method)
+		o.errs = append(o.errs, err)
+	}
+}
+
+func (o *recordingObserver) StartStream(_, _ string) func() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.openStreams++
+	return func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		o.openStreams--
+	}
+}
+
+func (o *recordingObserver) LogBytes(n int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.logBytes += n
+}
+
+func (o *recordingObserver) streams() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.openStreams
+}
+
+func TestObservedClient_UnaryCalls(t *testing.T) {
+	wfService := &mocks.MockWorkflowServiceClient{}
+	wfService.On("GetWorkflow", mock.Anything, mock.Anything).Return(&wfv1.Workflow{}, nil)
+	wfService.On("DeleteWorkflow", mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+
+	cronService := &mocks.MockCronWorkflowServiceClient{}
+	cronService.On("ListCronWorkflows", mock.Anything, mock.Anything).Return(&wfv1.CronWorkflowList{}, nil)
+
+	client := mocks.NewMockClient("default", true)
+	client.SetWorkflowService(wfService)
+	client.SetCronWorkflowService(cronService)
+
+	observer := &recordingObserver{}
+	observed := argo.NewObservedClient(client, observer)
+
+	_, err := observed.WorkflowService().GetWorkflow(t.Context(), &workflow.WorkflowGetRequest{Name: "wf"})
+	require.NoError(t, err)
+
+	_, err = observed.WorkflowService().DeleteWorkflow(t.Context(), &workflow.WorkflowDeleteRequest{Name: "wf"})
+	require.Error(t, err)
+
+	cron, err := observed.CronWorkflowService()
+	require.NoError(t, err)
+	_, err = cron.ListCronWorkflows(t.Context(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{
+		"WorkflowService/GetWorkflow",
+		"WorkflowService/DeleteWorkflow",
+		"CronWorkflowService/ListCronWorkflows",
+	}, observer.calls)
+	require.Len(t, observer.errs, 3)
+	require.NoError(t, observer.errs[0])
+	require.Error(t, observer.errs[1])
+
+	// Non-service methods pass straight through
+	assert.Equal(t, "default", observed.DefaultNamespace())
+	assert.True(t, observed.IsArgoServerMode())
+}
+
+func TestObservedClient_EventService(t *testing.T) {
+	eventService := &mocks.MockEventServiceClient{}
+	eventService.On("ReceiveEvent", mock.Anything, mock.Anything).Return(&event.EventResponse{}, nil)
+
+	client := mocks.NewMockClient("default", true)
+	client.SetEventService(eventService)
+
+	observer := &recordingObserver{}
+	svc, err := argo.NewObservedClient(client, observer).EventService()
+	require.NoError(t, err)
+	_, err = svc.ReceiveEvent(t.Context(), &event.EventRequest{Namespace: "default"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"EventService/ReceiveEvent"}, observer.calls)
+}
+
+func TestObservedClient_SyncService(t *testing.T) {
+	syncService := &mocks.MockSyncServiceClient{}
+	syncService.On("GetSyncLimit", mock.Anything, mock.Anything).Return(&syncpkg.SyncLimitResponse{Limit: 2}, nil)
+
+	client := mocks.NewMockClient("default", true)
+	client.SetSyncService(syncService)
+
+	observer := &recordingObserver{}
+	svc, err := argo.NewObservedClient(client, observer).SyncService()
+	require.NoError(t, err)
+	resp, err := svc.GetSyncLimit(t.Context(), &syncpkg.GetSyncLimitRequest{Namespace: "default"})
+	require.NoError(t, err)
+
+	assert.Equal(t, int32(2), resp.Limit)
+	assert.Equal(t, []string{"SyncService/GetSyncLimit"}, observer.calls)
+}
+
+func TestObservedClient_ServiceError(t *testing.T) {
+	client := mocks.NewMockClient("default", false)
+	client.On("ArchivedWorkflowService").Return(nil, argo.ErrArchivedWorkflowsNotSupported)
+
+	observed := argo.NewObservedClient(client, &recordingObserver{})
+
+	svc, err := observed.ArchivedWorkflowService()
+	require.ErrorIs(t, err, argo.ErrArchivedWorkflowsNotSupported)
+	assert.Nil(t, svc)
+}
+
+func TestObservedClient_LogStream(t *testing.T) {
+	entries := []*workflow.LogEntry{
+		{PodName: "pod-1", Content: "hello"},
+		{PodName: "pod-1", Content: "world!"},
+	}
+
+	wfService := &mocks.MockWorkflowServiceClient{}
+	wfService.On("WorkflowLogs", mock.Anything, mock.Anything).Return(mocks.NewMockWorkflowLogsStream(entries), nil)
+
+	client := mocks.NewMockClient("default", true)
+	client.SetWorkflowService(wfService)
+
+	observer := &recordingObserver{}
+	observed := argo.NewObservedClient(client, observer)
+
+	stream, err := observed.WorkflowService().WorkflowLogs(t.Context(), &workflow.WorkflowLogRequest{Name: "wf"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, observer.streams())
+
+	for {
+		_, recvErr := stream.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			break
+		}
+		require.NoError(t, recvErr)
+	}
+
+	assert.Equal(t, 0, observer.streams(), "stream should be reported closed at EOF")
+	assert.Equal(t, len("hello")+len("world!"), observer.logBytes)
+
+	// Further Recv calls do not report the end again
+	_, _ = stream.Recv()
+	assert.Equal(t, 0, observer.streams())
+}
+
+func TestObservedClient_WatchCancelled(t *testing.T) {
+	wfService := &mocks.MockWorkflowServiceClient{}
+	wfService.On("WatchWorkflows", mock.Anything, mock.Anything).Return(mocks.NewMockWatchWorkflowsStream(nil), nil)
+
+	client := mocks.NewMockClient("default", true)
+	client.SetWorkflowService(wfService)
+
+	observer := &recordingObserver{}
+	observed := argo.NewObservedClient(client, observer)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	_, err := observed.WorkflowService().WatchWorkflows(ctx, &workflow.WatchWorkflowsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, observer.streams())
+
+	// Cancelling the watch context ends the stream even without a Recv call
+	cancel()
+	require.Eventually(t, func() bool {
+		return observer.streams() == 0
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,207 @@
+package argo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/cronworkflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// argoGroup is the API group of the Argo Workflows custom resources.
+const argoGroup = "argoproj.io"
+
+// ProbeCheck is the outcome of a single connectivity or permission check.
+type ProbeCheck struct {
+	Name  string `json:"name"`
+	Error string `json:"error,omitempty"`
+	OK    bool   `json:"ok"`
+}
+
+// ProbeReport is the outcome of probing the Argo connection.
+type ProbeReport struct {
+	Checks    []ProbeCheck `json:"checks"`
+	Mode      string       `json:"mode"`
+	Namespace string       `json:"namespace"`
+}
+
+// Failed returns the checks that did not pass.
+func (r *ProbeReport) Failed() []ProbeCheck {
+	var failed []ProbeCheck
+	for _, check := range r.Checks {
+		if !check.OK {
+			failed = append(failed, check)
+		}
+	}
+	return failed
+}
+
+// Err returns an error describing every failed check, or nil if all checks passed.
+func (r *ProbeReport) Err() error {
+	failed := r.Failed()
+	if len(failed) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(failed))
+	for _, check := range failed {
+		messages = append(messages, fmt.Sprintf("%s: %s", check.Name, check.Error))
+	}
+	return fmt.Errorf("%d of %d startup checks failed: %s", len(failed), len(r.Checks), strings.Join(messages, "; "))
+}
+
+// accessCheck is a permission the server needs for its core tools.
+type accessCheck struct {
+	resource    string
+	subresource string
+	group       string
+	verb        string
+}
+
+// name returns a readable label for the check, e.g. "get pods/log".
+func (c accessCheck) name() string {
+	resource := c.resource
+	if c.subresource != "" {
+		resource += "/" + c.subresource
+	}
+	return c.verb + " " + resource
+}
+
+// requiredAccess lists the permissions checked in direct Kubernetes API mode.
+func requiredAccess() []accessCheck {
+	return []accessCheck{
+		{group: argoGroup, resource: "workflows", verb: "get"},
+		{group: argoGroup, resource: "workflows", verb: "list"},
+		{group: argoGroup, resource: "workflows", verb: "watch"},
+		{group: argoGroup, resource: "workflows", verb: "create"},
+		{group: argoGroup, resource: "workflows", verb: "update"},
+		{group: argoGroup, resource: "workflows", verb: "delete"},
+		{group: argoGroup, resource: "workflowtemplates", verb: "list"},
+		{group: argoGroup, resource: "cronworkflows", verb: "list"},
+		{resource: "pods", subresource: "log", verb: "get"},
+	}
+}
+
+// CheckReachable verifies that the Argo API answers by listing at most one
+// workflow in the default namespace.
+func CheckReachable(ctx context.Context, client ClientInterface) error {
+	_, err := client.WorkflowService().ListWorkflows(ctx, &workflow.WorkflowListRequest{
+		Namespace:   client.DefaultNamespace(),
+		ListOptions: &metav1.ListOptions{Limit: 1},
+		Fields:      "metadata.resourceVersion",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list workflows: %w", err)
+	}
+	return nil
+}
+
+// ProbeConnection checks that the Argo API is reachable and that the
+// credentials grant the verbs the core tools rely on, in the default namespace.
+// In direct Kubernetes API mode permissions are checked with
+// SelfSubjectAccessReviews. The Argo Server performs its own authorization, so
+// in that mode the list endpoints of each resource type are called instead.
+func ProbeConnection(ctx context.Context, client ClientInterface) *ProbeReport {
+	report := &ProbeReport{
+		Mode:      ModeKubernetesAPI,
+		Namespace: client.DefaultNamespace(),
+	}
+	if client.IsArgoServerMode() {
+		report.Mode = ModeArgoServer
+	}
+
+	reachable := CheckReachable(ctx, client)
+	report.Checks = append(report.Checks, newProbeCheck("reach Argo API", reachable))
+	if reachable != nil {
+		// Permission checks would fail for the same reason
+		return report
+	}
+
+	if client.IsArgoServerMode() {
+		report.Checks = append(report.Checks, probeServerAccess(ctx, client)...)
+	} else {
+		report.Checks = append(report.Checks, probeKubernetesAccess(ctx, client)...)
+	}
+	return report
+}
+
+// probeServerAccess calls the Argo Server list endpoints for templates and cron workflows.
+func probeServerAccess(ctx context.Context, client ClientInterface) []ProbeCheck {
+	namespace := client.DefaultNamespace()
+	listOpts := &metav1.ListOptions{Limit: 1}
+
+	var templateErr error
+	if wftService, err := client.WorkflowTemplateService(); err != nil {
+		templateErr = err
+	} else {
+		_, templateErr = wftService.ListWorkflowTemplates(ctx, &workflowtemplate.WorkflowTemplateListRequest{
+			Namespace:   namespace,
+			ListOptions: listOpts,
+		})
+	}
+
+	var cronErr error
+	if cronService, err := client.CronWorkflowService(); err != nil {
+		cronErr = err
+	} else {
+		_, cronErr = cronService.ListCronWorkflows(ctx, &cronworkflow.ListCronWorkflowsRequest{
+			Namespace:   namespace,
+			ListOptions: listOpts,
+		})
+	}
+
+	return []ProbeCheck{
+		newProbeCheck("list workflowtemplates", templateErr),
+		newProbeCheck("list cronworkflows", cronErr),
+	}
+}
+
+// probeKubernetesAccess runs a SelfSubjectAccessReview for each required permission.
+func probeKubernetesAccess(ctx context.Context, client ClientInterface) []ProbeCheck {
+	kubeClient, err := client.KubernetesClient()
+	if err != nil {
+		return []ProbeCheck{newProbeCheck("access Kubernetes API", err)}
+	}
+
+	reviews := kubeClient.AuthorizationV1().SelfSubjectAccessReviews()
+	access := requiredAccess()
+	checks := make([]ProbeCheck, 0, len(access))
+	for _, required := range access {
+		review, reviewErr := reviews.Create(ctx, &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace:   client.DefaultNamespace(),
+					Verb:        required.verb,
+					Group:       required.group,
+					Resource:    required.resource,
+					Subresource: required.subresource,
+				},
+			},
+		}, metav1.CreateOptions{})
+
+		switch {
+		case reviewErr != nil:
+			reviewErr = fmt.Errorf("access review failed: %w", reviewErr)
+		case !review.Status.Allowed:
+			reviewErr = errors.New("permission denied")
+			if review.Status.Reason != "" {
+				reviewErr = fmt.Errorf("permission denied: %s", review.Status.Reason)
+			}
+		}
+		checks = append(checks, newProbeCheck(required.name(), reviewErr))
+	}
+	return checks
+}
+
+// newProbeCheck builds a check result from an error.
+func newProbeCheck(name string, err error) ProbeCheck {
+	if err != nil {
+		return ProbeCheck{Name: name, Error: err.Error()}
+	}
+	return ProbeCheck{Name: name, OK: true}
+}
@@ -0,0 +1,123 @@
+package argo_test
+
+import (
+	"errors"
+	"testing"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+)
+
+// newAccessReviewClientset returns a fake clientset that answers
+// SelfSubjectAccessReviews, denying the given verb on workflows.
+func newAccessReviewClientset(deniedVerb string) *fake.Clientset {
+	clientset := fake.NewClientset()
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		createAction, ok := action.(k8stesting.CreateAction)
+		if !ok {
+			return false, nil, nil
+		}
+		review, ok := createAction.GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		if !ok {
+			return false, nil, nil
+		}
+		attrs := review.Spec.ResourceAttributes
+		review.Status.Allowed = attrs.Resource != "workflows" || attrs.Verb != deniedVerb
+		return true, review, nil
+	})
+	return clientset
+}
+
+func TestProbeConnection_KubernetesMode(t *testing.T) {
+	tests := []struct {
+		name       string
+		deniedVerb string
+		wantFailed []string
+	}{
+		{
+			name: "all permissions granted",
+		},
+		{
+			name:       "delete denied",
+			deniedVerb: "delete",
+			wantFailed: []string{"delete workflows"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wfService := &mocks.MockWorkflowServiceClient{}
+			wfService.On("ListWorkflows", mock.Anything, mock.Anything).Return(&wfv1.WorkflowList{}, nil)
+
+			client := mocks.NewMockClient("argo", false)
+			client.SetWorkflowService(wfService)
+			client.SetKubernetesClient(newAccessReviewClientset(tt.deniedVerb))
+
+			report := argo.ProbeConnection(t.Context(), client)
+			assert.Equal(t, argo.ModeKubernetesAPI, report.Mode)
+			assert.Equal(t, "argo", report.Namespace)
+			assert.Greater(t, len(report.Checks), 1)
+
+			failed := make([]string, 0, len(report.Failed()))
+			for _, check := range report.Failed() {
+				failed = append(failed, check.Name)
+			}
+			assert.ElementsMatch(t, tt.wantFailed, failed)
+
+			if len(tt.wantFailed) == 0 {
+				require.NoError(t, report.Err())
+			} else {
+				require.Error(t, report.Err())
+				assert.Contains(t, report.Err().Error(), "permission denied")
+			}
+		})
+	}
+}
+
+func TestProbeConnection_ArgoServerMode(t *testing.T) {
+	wfService := &mocks.MockWorkflowServiceClient{}
+	wfService.On("ListWorkflows", mock.Anything, mock.Anything).Return(&wfv1.WorkflowList{}, nil)
+
+	wftService := &mocks.MockWorkflowTemplateServiceClient{}
+	wftService.On("ListWorkflowTemplates", mock.Anything, mock.Anything).Return(&wfv1.WorkflowTemplateList{}, nil)
+
+	cronService := &mocks.MockCronWorkflowServiceClient{}
+	cronService.On("ListCronWorkflows", mock.Anything, mock.Anything).Return(nil, errors.New("forbidden"))
+
+	client := mocks.NewMockClient("default", true)
+	client.SetWorkflowService(wfService)
+	client.SetWorkflowTemplateService(wftService)
+	client.SetCronWorkflowService(cronService)
+
+	report := argo.ProbeConnection(t.Context(), client)
+	assert.Equal(t, argo.ModeArgoServer, report.Mode)
+	require.Len(t, report.Failed(), 1)
+	assert.Equal(t, "list cronworkflows", report.Failed()[0].Name)
+	assert.Contains(t, report.Failed()[0].Error, "forbidden")
+}
+
+func TestProbeConnection_Unreachable(t *testing.T) {
+	wfService := &mocks.MockWorkflowServiceClient{}
+	wfService.On("ListWorkflows", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+	client := mocks.NewMockClient("default", true)
+	client.SetWorkflowService(wfService)
+
+	report := argo.ProbeConnection(t.Context(), client)
+
+	// Permission checks are skipped when the API cannot be reached
+	require.Len(t, report.Checks, 1)
+	assert.False(t, report.Checks[0].OK)
+	require.Error(t, report.Err())
+	assert.Contains(t, report.Err().Error(), "connection refused")
+	require.Error(t, argo.CheckReachable(t.Context(), client))
+}
@@ -0,0 +1,370 @@
+// Package bundle implements workflow export bundles: a gzipped tar archive
+// holding a workflow together with its templates, logs, artifacts, pod events
+// and diagnosis, so an incident can be inspected without access to the cluster
+// it happened on.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// FormatVersion is the version of the bundle layout written by Write.
+const FormatVersion = 1
+
+// MediaType is the MIME type of an encoded bundle.
+const MediaType = "application/gzip"
+
+// Paths of the fixed files within a bundle.
+const (
+	ManifestFile  = "manifest.json"
+	WorkflowFile  = "workflow.yaml"
+	ResolvedFile  = "resolved.yaml"
+	EventsFile    = "events.json"
+	DiagnosisFile = "diagnosis.md"
+
+	workflowTemplatesDir        = "templates/WorkflowTemplate/"
+	clusterWorkflowTemplatesDir = "templates/ClusterWorkflowTemplate/"
+	logsDir                     = "logs/"
+	artifactsDir                = "artifacts/"
+)
+
+// Manifest describes the contents of a bundle.
+type Manifest struct {
+	// ExportedAt is when the bundle was created.
+	ExportedAt time.Time `json:"exportedAt"`
+
+	// Namespace is the namespace of the exported workflow.
+	Namespace string `json:"namespace"`
+
+	// Workflow is the name of the exported workflow.
+	Workflow string `json:"workflow"`
+
+	// UID is the UID of the exported workflow.
+	UID string `json:"uid,omitempty"`
+
+	// Phase is the phase of the workflow when it was exported.
+	Phase string `json:"phase,omitempty"`
+
+	// Logs lists the pod logs in the bundle.
+	Logs []LogFile `json:"logs,omitempty"`
+
+	// Artifacts lists the output artifacts of the workflow's nodes.
+	Artifacts []Artifact `json:"artifacts,omitempty"`
+
+	// Warnings lists the parts of the workflow that could not be exported.
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Version is the bundle format version.
+	Version int `json:"version"`
+
+	// LogBudget is the total number of log bytes the export allowed.
+	LogBudget int64 `json:"logBudget,omitempty"`
+}
+
+// LogFile describes the log of one pod in a bundle.
+type LogFile struct {
+	// PodName is the name of the pod the log was read from.
+	PodName string `json:"podName"`
+
+	// NodeID is the ID of the node that ran the pod.
+	NodeID string `json:"nodeId,omitempty"`
+
+	// Bytes is the size of the log in the bundle.
+	Bytes int64 `json:"bytes"`
+
+	// Truncated is set when only the end of the log fit in the pod's share of
+	// the log budget.
+	Truncated bool `json:"truncated,omitempty"`
+
+	// Omitted is set when the log budget was exhausted before the pod's turn;
+	// the bundle has no log file for the pod.
+	Omitted bool `json:"omitted,omitempty"`
+}
+
+// Artifact describes an output artifact of a workflow node.
+type Artifact struct {
+	// NodeID is the ID of the node that produced the artifact.
+	NodeID string `json:"nodeId"`
+
+	// NodeName is the display name of the node.
+	NodeName string `json:"nodeName,omitempty"`
+
+	// Name is the name of the artifact.
+	Name string `json:"name"`
+
+	// Location is the key or URL of the artifact in its repository.
+	Location string `json:"location,omitempty"`
+
+	// Bytes is the size of the artifact content in the bundle.
+	Bytes int64 `json:"bytes,omitempty"`
+
+	// Included is set when the artifact content is part of the bundle.
+	Included bool `json:"included,omitempty"`
+}
+
+// Bundle is a decoded workflow export bundle.
+type Bundle struct {
+	// Workflow is the exported workflow, including its status.
+	Workflow *wfv1.Workflow
+
+	// Logs maps pod names to their logs.
+	Logs map[string][]byte
+
+	// ArtifactData maps ArtifactKey(nodeID, name) to the content of included artifacts.
+	ArtifactData map[string][]byte
+
+	// Resolved is the workflow manifest with its template references expanded.
+	Resolved string
+
+	// Diagnosis is the Markdown diagnosis summary of the workflow.
+	Diagnosis string
+
+	// WorkflowTemplates are the WorkflowTemplates the workflow references.
+	WorkflowTemplates []wfv1.WorkflowTemplate
+
+	// ClusterWorkflowTemplates are the ClusterWorkflowTemplates the workflow references.
+	ClusterWorkflowTemplates []wfv1.ClusterWorkflowTemplate
+
+	// Events are the Kubernetes events of the workflow and its pods.
+	Events []corev1.Event
+
+	// Manifest describes the bundle.
+	Manifest Manifest
+
+	// Size is the uncompressed size of the files read into the bundle.
+	Size int64
+}
+
+// ArtifactKey is the key of an artifact in Bundle.ArtifactData.
+func ArtifactKey(nodeID, name string) string {
+	return nodeID + "/" + name
+}
+
+// Write encodes the bundle as a gzipped tar archive.
+func (b *Bundle) Write(w io.Writer) error {
+	if b.Workflow == nil {
+		return errors.New("bundle has no workflow")
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	modTime := b.Manifest.ExportedAt
+
+	add := func(name string, data []byte) error {
+		if err := tw.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    0o644,
+			Size:    int64(len(data)),
+			ModTime: modTime,
+		}); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		return nil
+	}
+	addJSON := func(name string, v any) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", name, err)
+		}
+		return add(name, data)
+	}
+	addYAML := func(name string, v any) error {
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", name, err)
+		}
+		return add(name, data)
+	}
+
+	manifest := b.Manifest
+	manifest.Version = FormatVersion
+	if err := addJSON(ManifestFile, manifest); err != nil {
+		return err
+	}
+	if err := addYAML(WorkflowFile, b.Workflow); err != nil {
+		return err
+	}
+	if b.Resolved != "" {
+		if err := add(ResolvedFile, []byte(b.Resolved)); err != nil {
+			return err
+		}
+	}
+	if b.Diagnosis != "" {
+		if err := add(DiagnosisFile, []byte(b.Diagnosis)); err != nil {
+			return err
+		}
+	}
+	if len(b.Events) > 0 {
+		if err := addJSON(EventsFile, b.Events); err != nil {
+			return err
+		}
+	}
+	for i := range b.WorkflowTemplates {
+		if err := addYAML(workflowTemplatesDir+b.WorkflowTemplates[i].Name+".yaml", &b.WorkflowTemplates[i]); err != nil {
+			return err
+		}
+	}
+	for i := range b.ClusterWorkflowTemplates {
+		if err := addYAML(clusterWorkflowTemplatesDir+b.ClusterWorkflowTemplates[i].Name+".yaml", &b.ClusterWorkflowTemplates[i]); err != nil {
+			return err
+		}
+	}
+	for _, pod := range slices.Sorted(maps.Keys(b.Logs)) {
+		if err := add(logsDir+pod+".log", b.Logs[pod]); err != nil {
+			return err
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(b.ArtifactData)) {
+		if err := add(artifactsDir+key, b.ArtifactData[key]); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish bundle: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return fmt.Errorf("failed to finish bundle: %w", err)
+	}
+	return nil
+}
+
+// Read decodes a bundle written by Write. Reading fails once the uncompressed
+// contents exceed maxBytes, which guards against decompression bombs.
+func Read(r io.Reader, maxBytes int64) (*Bundle, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("bundle is not a gzip archive: %w", err)
+	}
+	defer gz.Close()
+
+	b := &Bundle{
+		Logs:         make(map[string][]byte),
+		ArtifactData: make(map[string][]byte),
+	}
+	var hasManifest bool
+	remaining := maxBytes
+	tr := tar.NewReader(gz)
+	for {
+		hdr, nextErr := tr.Next()
+		if errors.Is(nextErr, io.EOF) {
+			break
+		}
+		if nextErr != nil {
+			return nil, fmt.Errorf("failed to read bundle: %w", nextErr)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name, ok := cleanEntryName(hdr.Name)
+		if !ok {
+			return nil, fmt.Errorf("bundle contains an invalid path %q", hdr.Name)
+		}
+		if hdr.Size > remaining {
+			return nil, fmt.Errorf("bundle exceeds the maximum size of %d bytes", maxBytes)
+		}
+		data, readErr := io.ReadAll(io.LimitReader(tr, hdr.Size))
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, readErr)
+		}
+		remaining -= int64(len(data))
+
+		if decodeErr := b.decodeEntry(name, data); decodeErr != nil {
+			return nil, decodeErr
+		}
+		hasManifest = hasManifest || name == ManifestFile
+	}
+
+	if !hasManifest {
+		return nil, fmt.Errorf("bundle has no %s", ManifestFile)
+	}
+	if b.Manifest.Version > FormatVersion {
+		return nil, fmt.Errorf("bundle format version %d is newer than the supported version %d", b.Manifest.Version, FormatVersion)
+	}
+	if b.Workflow == nil {
+		return nil, fmt.Errorf("bundle has no %s", WorkflowFile)
+	}
+	b.Size = maxBytes - remaining
+	return b, nil
+}
+
+// decodeEntry stores the content of one archive entry in the bundle.
+func (b *Bundle) decodeEntry(name string, data []byte) error {
+	switch {
+	case name == ManifestFile:
+		if err := json.Unmarshal(data, &b.Manifest); err != nil {
+			return fmt.Errorf("failed to decode %s: %w", name, err)
+		}
+	case name == WorkflowFile:
+		var wf wfv1.Workflow
+		if err := yaml.Unmarshal(data, &wf); err != nil {
+			return fmt.Errorf("failed to decode %s: %w", name, err)
+		}
+		b.Workflow = &wf
+	case name == ResolvedFile:
+		b.Resolved = string(data)
+	case name == DiagnosisFile:
+		b.Diagnosis = string(data)
+	case name == EventsFile:
+		if err := json.Unmarshal(data, &b.Events); err != nil {
+			return fmt.Errorf("failed to decode %s: %w", name, err)
+		}
+	case strings.HasPrefix(name, workflowTemplatesDir):
+		var wft wfv1.WorkflowTemplate
+		if err := yaml.Unmarshal(data, &wft); err != nil {
+			return fmt.Errorf("failed to decode %s: %w", name, err)
+		}
+		b.WorkflowTemplates = append(b.WorkflowTemplates, wft)
+	case strings.HasPrefix(name, clusterWorkflowTemplatesDir):
+		var cwft wfv1.ClusterWorkflowTemplate
+		if err := yaml.Unmarshal(data, &cwft); err != nil {
+			return fmt.Errorf("failed to decode %s: %w", name, err)
+		}
+		b.ClusterWorkflowTemplates = append(b.ClusterWorkflowTemplates, cwft)
+	case strings.HasPrefix(name, logsDir) && strings.HasSuffix(name, ".log"):
+		b.Logs[strings.TrimSuffix(strings.TrimPrefix(name, logsDir), ".log")] = data
+	case strings.HasPrefix(name, artifactsDir):
+		b.ArtifactData[strings.TrimPrefix(name, artifactsDir)] = data
+	}
+	return nil
+}
+
+// cleanEntryName returns the cleaned name of an archive entry, rejecting
+// absolute paths and paths that escape the archive root.
+func cleanEntryName(name string) (string, bool) {
+	cleaned := path.Clean(strings.ReplaceAll(name, `\`, "/"))
+	if path.IsAbs(cleaned) || cleaned == "." || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", false
+	}
+	return cleaned, true
+}
+
+// EventTime returns the time an event last occurred.
+func EventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
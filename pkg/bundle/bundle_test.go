@@ -0,0 +1,162 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+	"time"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// testBundle is a bundle of a failed workflow with one of each kind of content.
+func testBundle() *Bundle {
+	return &Bundle{
+		Workflow: &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "wf", Namespace: "argo", UID: "uid-1", Labels: map[string]string{"team": "data"}},
+			Status: wfv1.WorkflowStatus{
+				Phase: wfv1.WorkflowFailed,
+				Nodes: wfv1.Nodes{"wf-1": {ID: "wf-1", Name: "wf.step", Phase: wfv1.NodeFailed}},
+			},
+		},
+		WorkflowTemplates: []wfv1.WorkflowTemplate{{ObjectMeta: metav1.ObjectMeta{Name: "lib", Namespace: "argo"}}},
+		ClusterWorkflowTemplates: []wfv1.ClusterWorkflowTemplate{
+			{ObjectMeta: metav1.ObjectMeta{Name: "shared"}},
+		},
+		Logs:         map[string][]byte{"wf-step-1": []byte("starting\nboom\n")},
+		ArtifactData: map[string][]byte{ArtifactKey("wf-1", "report"): []byte("ok")},
+		Events:       []corev1.Event{{Reason: "BackOff", InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "wf-step-1"}}},
+		Resolved:     "kind: Workflow\n",
+		Diagnosis:    "## Workflow\n",
+		Manifest: Manifest{
+			ExportedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			Namespace:  "argo",
+			Workflow:   "wf",
+			Logs:       []LogFile{{PodName: "wf-step-1", NodeID: "wf-1", Bytes: 14}},
+			Artifacts:  []Artifact{{NodeID: "wf-1", Name: "report", Bytes: 2, Included: true}},
+		},
+	}
+}
+
+// archive builds a gzipped tar archive of the given files.
+func archive(t *testing.T, files map[string]string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return &buf
+}
+
+func TestBundleRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, testBundle().Write(&buf))
+
+	got, err := Read(&buf, 1<<20)
+	require.NoError(t, err)
+
+	want := testBundle()
+	assert.Equal(t, FormatVersion, got.Manifest.Version)
+	assert.Equal(t, want.Manifest.Logs, got.Manifest.Logs)
+	assert.Equal(t, want.Manifest.Artifacts, got.Manifest.Artifacts)
+	assert.True(t, want.Manifest.ExportedAt.Equal(got.Manifest.ExportedAt))
+	assert.Equal(t, "wf", got.Workflow.Name)
+	assert.Equal(t, wfv1.NodeFailed, got.Workflow.Status.Nodes["wf-1"].Phase)
+	assert.Equal(t, want.Logs, got.Logs)
+	assert.Equal(t, want.ArtifactData, got.ArtifactData)
+	require.Len(t, got.WorkflowTemplates, 1)
+	assert.Equal(t, "lib", got.WorkflowTemplates[0].Name)
+	require.Len(t, got.ClusterWorkflowTemplates, 1)
+	assert.Equal(t, "shared", got.ClusterWorkflowTemplates[0].Name)
+	require.Len(t, got.Events, 1)
+	assert.Equal(t, "BackOff", got.Events[0].Reason)
+	assert.Equal(t, want.Resolved, got.Resolved)
+	assert.Equal(t, want.Diagnosis, got.Diagnosis)
+	assert.Positive(t, got.Size)
+}
+
+func TestBundleWrite_RequiresWorkflow(t *testing.T) {
+	var buf bytes.Buffer
+	require.Error(t, (&Bundle{}).Write(&buf))
+}
+
+func TestRead(t *testing.T) {
+	const workflowYAML = "metadata:\n  name: wf\n  namespace: argo\n"
+
+	tests := []struct {
+		files   map[string]string
+		name    string
+		wantErr string
+	}{
+		{
+			name:    "missing manifest",
+			files:   map[string]string{WorkflowFile: workflowYAML},
+			wantErr: "has no manifest.json",
+		},
+		{
+			name:    "missing workflow",
+			files:   map[string]string{ManifestFile: `{"version":1}`},
+			wantErr: "has no workflow.yaml",
+		},
+		{
+			name:    "newer format",
+			files:   map[string]string{ManifestFile: `{"version":99}`, WorkflowFile: workflowYAML},
+			wantErr: "newer than the supported version",
+		},
+		{
+			name:    "path traversal",
+			files:   map[string]string{ManifestFile: `{"version":1}`, "../etc/passwd": "x"},
+			wantErr: "invalid path",
+		},
+		{
+			name:    "too large",
+			files:   map[string]string{ManifestFile: `{"version":1}`, "logs/pod.log": string(make([]byte, 2048))},
+			wantErr: "exceeds the maximum size",
+		},
+		{
+			name:  "unknown files are ignored",
+			files: map[string]string{ManifestFile: `{"version":1}`, WorkflowFile: workflowYAML, "notes.txt": "hi"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, err := Read(archive(t, tt.files), 1024)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "wf", b.Workflow.Name)
+		})
+	}
+
+	t.Run("not gzip", func(t *testing.T) {
+		_, err := Read(bytes.NewBufferString("plain text"), 1024)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not a gzip archive")
+	})
+}
+
+func TestCleanEntryName(t *testing.T) {
+	name, ok := cleanEntryName("./logs/pod.log")
+	assert.True(t, ok)
+	assert.Equal(t, "logs/pod.log", name)
+
+	for _, bad := range []string{"/etc/passwd", "..", "../x", `..\x`, "."} {
+		_, ok = cleanEntryName(bad)
+		assert.False(t, ok, bad)
+	}
+}
@@ -0,0 +1,412 @@
+package bundle
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/clusterworkflowtemplate"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// NamespacePrefix marks the namespaces imported bundles are served under. A
+// Kubernetes namespace cannot contain it, so imported workflows and templates
+// never shadow live resources, and calls that modify them fail at the cluster.
+const NamespacePrefix = "bundle:"
+
+// DefaultMaxImportedBytes bounds the total size of the bundles a Client holds (512 MiB).
+const DefaultMaxImportedBytes = 512 << 20
+
+// ImportedNamespace returns the namespace the workflows imported from namespace are served under.
+func ImportedNamespace(namespace string) string {
+	if IsImportedNamespace(namespace) {
+		return namespace
+	}
+	return NamespacePrefix + namespace
+}
+
+// IsImportedNamespace reports whether namespace holds imported bundles.
+func IsImportedNamespace(namespace string) bool {
+	return strings.HasPrefix(namespace, NamespacePrefix)
+}
+
+// ImportedClusterTemplateName returns the name a ClusterWorkflowTemplate
+// imported with a workflow of namespace is served under.
+func ImportedClusterTemplateName(namespace, name string) string {
+	return ImportedNamespace(namespace) + "/" + name
+}
+
+// Host is implemented by clients that can serve imported bundles.
+type Host interface {
+	// ImportBundle makes the workflow, templates, logs and artifacts of b
+	// available through the client in ImportedNamespace of its namespace,
+	// replacing an earlier import of the same workflow. The oldest imports are
+	// evicted to stay within the client's size limit; their "namespace/name"
+	// keys are returned.
+	ImportBundle(b *Bundle) ([]string, error)
+
+	// RemoveBundle drops the import of a workflow, reporting whether it was imported.
+	RemoveBundle(namespace, name string) bool
+
+	// ImportedBundle returns the bundle a workflow was imported from, or nil
+	// if it was not imported.
+	ImportedBundle(namespace, name string) *Bundle
+
+	// BundleDir returns the directory bundles are exported to and imported
+	// from, or "" when none is configured.
+	BundleDir() string
+}
+
+// Client wraps a ClientInterface and serves imported bundles beside it.
+// Reads in an imported namespace are answered from the bundles; everything
+// else is passed to the wrapped client.
+type Client struct {
+	argo.ClientInterface
+	bundles  map[string]*Bundle
+	dir      string
+	order    []string
+	size     int64
+	maxBytes int64
+	mu       sync.RWMutex
+}
+
+// Ensure Client implements ClientInterface and Host.
+var (
+	_ argo.ClientInterface = (*Client)(nil)
+	_ Host                 = (*Client)(nil)
+)
+
+// NewClient wraps next so that imported bundles are served beside it.
+// dir is the directory bundles are exported to and imported from.
+func NewClient(next argo.ClientInterface, dir string) *Client {
+	return &Client{ClientInterface: next, bundles: make(map[string]*Bundle), dir: dir, maxBytes: DefaultMaxImportedBytes}
+}
+
+// ImportBundle implements Host. It moves the workflow and its
+// WorkflowTemplates to their imported namespace.
+func (c *Client) ImportBundle(b *Bundle) ([]string, error) {
+	if b.Size > c.maxBytes {
+		return nil, fmt.Errorf("bundle of %d bytes exceeds the %d bytes imported bundles may hold", b.Size, c.maxBytes)
+	}
+	namespace := ImportedNamespace(b.Workflow.Namespace)
+	b.Workflow.Namespace = namespace
+	for i := range b.WorkflowTemplates {
+		b.WorkflowTemplates[i].Namespace = namespace
+	}
+	key := namespace + "/" + b.Workflow.Name
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.remove(key)
+	var evicted []string
+	for len(c.order) > 0 && c.size+b.Size > c.maxBytes {
+		evicted = append(evicted, c.order[0])
+		c.remove(c.order[0])
+	}
+	c.bundles[key] = b
+	c.order = append(c.order, key)
+	c.size += b.Size
+	return evicted, nil
+}
+
+// RemoveBundle implements Host. namespace may be the workflow's original or imported namespace.
+func (c *Client) RemoveBundle(namespace, name string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.remove(ImportedNamespace(namespace) + "/" + name)
+}
+
+// ImportedBundle implements Host. namespace may be the workflow's original or imported namespace.
+func (c *Client) ImportedBundle(namespace, name string) *Bundle {
+	return c.bundle(ImportedNamespace(namespace), name)
+}
+
+// remove drops an import by key; c.mu must be held.
+func (c *Client) remove(key string) bool {
+	b, ok := c.bundles[key]
+	if !ok {
+		return false
+	}
+	delete(c.bundles, key)
+	c.order = slices.DeleteFunc(c.order, func(k string) bool { return k == key })
+	c.size -= b.Size
+	return true
+}
+
+// BundleDir implements Host.
+func (c *Client) BundleDir() string {
+	return c.dir
+}
+
+// bundle returns the imported bundle of a workflow, or nil.
+func (c *Client) bundle(namespace, name string) *Bundle {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.bundles[namespace+"/"+name]
+}
+
+// imported returns the imported bundles in a namespace, or in all namespaces
+// when namespace is empty, ordered by namespace and name.
+func (c *Client) imported(namespace string) []*Bundle {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	var result []*Bundle
+	for _, key := range slices.Sorted(maps.Keys(c.bundles)) {
+		b := c.bundles[key]
+		if namespace == "" || b.Workflow.Namespace == namespace {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// notFound returns the error of a read of an imported resource that does not exist.
+func notFound(kind, namespace, name string) error {
+	return status.Errorf(codes.NotFound, "%s %s/%s was not imported", kind, namespace, name)
+}
+
+// WorkflowService returns a workflow service client that serves imported workflows.
+func (c *Client) WorkflowService() workflow.WorkflowServiceClient {
+	return &workflowService{WorkflowServiceClient: c.ClientInterface.WorkflowService(), client: c}
+}
+
+// WorkflowTemplateService returns a workflow template service client that
+// serves the templates of imported workflows.
+func (c *Client) WorkflowTemplateService() (workflowtemplate.WorkflowTemplateServiceClient, error) {
+	next, err := c.ClientInterface.WorkflowTemplateService()
+	if err != nil {
+		return nil, err
+	}
+	return &workflowTemplateService{WorkflowTemplateServiceClient: next, client: c}, nil
+}
+
+// ClusterWorkflowTemplateService returns a cluster workflow template service
+// client that serves the templates of imported workflows.
+func (c *Client) ClusterWorkflowTemplateService() (clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient, error) {
+	next, err := c.ClientInterface.ClusterWorkflowTemplateService()
+	if err != nil {
+		return nil, err
+	}
+	return &clusterWorkflowTemplateService{ClusterWorkflowTemplateServiceClient: next, client: c}, nil
+}
+
+// OpenArtifact serves artifacts included in an imported bundle.
+func (c *Client) OpenArtifact(ctx context.Context, req *argo.ArtifactRequest) (io.ReadCloser, error) {
+	if !IsImportedNamespace(req.Namespace) {
+		return c.ClientInterface.OpenArtifact(ctx, req)
+	}
+	if b := c.bundle(req.Namespace, req.Workflow); b != nil {
+		if data, ok := b.ArtifactData[ArtifactKey(req.NodeID, req.Name)]; ok {
+			return io.NopCloser(bytes.NewReader(data)), nil
+		}
+	}
+	return nil, notFound("artifact of workflow", req.Namespace, req.Workflow+"/"+req.Name)
+}
+
+// workflowService serves imported workflows beside a workflow service client.
+type workflowService struct {
+	workflow.WorkflowServiceClient
+	client *Client
+}
+
+// GetWorkflow implements workflow.WorkflowServiceClient.
+func (s *workflowService) GetWorkflow(ctx context.Context, in *workflow.WorkflowGetRequest, opts ...grpc.CallOption) (*wfv1.Workflow, error) {
+	if !IsImportedNamespace(in.Namespace) {
+		return s.WorkflowServiceClient.GetWorkflow(ctx, in, opts...)
+	}
+	if b := s.client.bundle(in.Namespace, in.Name); b != nil {
+		return b.Workflow.DeepCopy(), nil
+	}
+	return nil, notFound("workflow", in.Namespace, in.Name)
+}
+
+// ListWorkflows implements workflow.WorkflowServiceClient. Imported workflows
+// are listed on their own in an imported namespace, and after the live
+// workflows across all namespaces; when the cluster cannot be reached only
+// the imported workflows are listed then.
+func (s *workflowService) ListWorkflows(ctx context.Context, in *workflow.WorkflowListRequest, opts ...grpc.CallOption) (*wfv1.WorkflowList, error) {
+	if in.Namespace != "" && !IsImportedNamespace(in.Namespace) {
+		return s.WorkflowServiceClient.ListWorkflows(ctx, in, opts...)
+	}
+	imported, err := s.matchingImports(in)
+	if err != nil {
+		return nil, err
+	}
+	if in.Namespace != "" {
+		return &wfv1.WorkflowList{Items: imported}, nil
+	}
+
+	list, err := s.WorkflowServiceClient.ListWorkflows(ctx, in, opts...)
+	if err != nil {
+		if len(imported) == 0 {
+			return nil, err
+		}
+		list = &wfv1.WorkflowList{}
+	}
+	if len(imported) == 0 {
+		return list, nil
+	}
+	return &wfv1.WorkflowList{ListMeta: list.ListMeta, Items: append(list.Items, imported...)}, nil
+}
+
+// matchingImports returns the imported workflows matching a list request.
+func (s *workflowService) matchingImports(in *workflow.WorkflowListRequest) (wfv1.Workflows, error) {
+	selector := labels.Everything()
+	if in.ListOptions != nil && in.ListOptions.LabelSelector != "" {
+		parsed, err := labels.Parse(in.ListOptions.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector: %w", err)
+		}
+		selector = parsed
+	}
+
+	var result wfv1.Workflows
+	for _, b := range s.client.imported(in.Namespace) {
+		if selector.Matches(labels.Set(b.Workflow.Labels)) {
+			result = append(result, *b.Workflow.DeepCopy())
+		}
+	}
+	return result, nil
+}
+
+// WorkflowLogs implements workflow.WorkflowServiceClient by replaying the logs
+// of an imported workflow, honouring the pod, grep and tail options.
+func (s *workflowService) WorkflowLogs(ctx context.Context, in *workflow.WorkflowLogRequest, opts ...grpc.CallOption) (workflow.WorkflowService_WorkflowLogsClient, error) {
+	if !IsImportedNamespace(in.Namespace) {
+		return s.WorkflowServiceClient.WorkflowLogs(ctx, in, opts...)
+	}
+	b := s.client.bundle(in.Namespace, in.Name)
+	if b == nil {
+		return nil, notFound("workflow", in.Namespace, in.Name)
+	}
+
+	var grep *regexp.Regexp
+	if in.Grep != "" {
+		var err error
+		grep, err = regexp.Compile(in.Grep)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile %q: %w", in.Grep, err)
+		}
+	}
+	var tail int64
+	if in.LogOptions != nil && in.LogOptions.TailLines != nil {
+		tail = *in.LogOptions.TailLines
+	}
+
+	var entries []*workflow.LogEntry
+	for _, pod := range slices.Sorted(maps.Keys(b.Logs)) {
+		if in.PodName != "" && pod != in.PodName {
+			continue
+		}
+		var podEntries []*workflow.LogEntry
+		scanner := bufio.NewScanner(bytes.NewReader(b.Logs[pod]))
+		scanner.Buffer(make([]byte, 0, 64*1024), len(b.Logs[pod])+1)
+		for scanner.Scan() {
+			if grep == nil || grep.MatchString(scanner.Text()) {
+				podEntries = append(podEntries, &workflow.LogEntry{PodName: pod, Content: scanner.Text()})
+			}
+		}
+		if tail > 0 && int64(len(podEntries)) > tail {
+			podEntries = podEntries[int64(len(podEntries))-tail:]
+		}
+		entries = append(entries, podEntries...)
+	}
+	return &logStream{ctx: ctx, entries: entries}, nil
+}
+
+// logStream replays log entries as a workflow.WorkflowService_WorkflowLogsClient.
+type logStream struct {
+	ctx     context.Context //nolint:containedctx // Required for grpc.ClientStream interface
+	entries []*workflow.LogEntry
+}
+
+// Recv returns the next log entry, or io.EOF once all entries were returned.
+func (s *logStream) Recv() (*workflow.LogEntry, error) {
+	if err := s.ctx.Err(); err != nil {
+		return nil, err
+	}
+	if len(s.entries) == 0 {
+		return nil, io.EOF
+	}
+	entry := s.entries[0]
+	s.entries = s.entries[1:]
+	return entry, nil
+}
+
+// Header implements grpc.ClientStream.
+func (s *logStream) Header() (metadata.MD, error) { return nil, nil }
+
+// Trailer implements grpc.ClientStream.
+func (s *logStream) Trailer() metadata.MD { return nil }
+
+// CloseSend implements grpc.ClientStream.
+func (s *logStream) CloseSend() error { return nil }
+
+// Context implements grpc.ClientStream.
+func (s *logStream) Context() context.Context { return s.ctx }
+
+// SendMsg implements grpc.ClientStream.
+func (s *logStream) SendMsg(any) error { return nil }
+
+// RecvMsg implements grpc.ClientStream.
+func (s *logStream) RecvMsg(any) error { return nil }
+
+// workflowTemplateService serves the WorkflowTemplates of imported workflows.
+type workflowTemplateService struct {
+	workflowtemplate.WorkflowTemplateServiceClient
+	client *Client
+}
+
+// GetWorkflowTemplate implements workflowtemplate.WorkflowTemplateServiceClient.
+func (s *workflowTemplateService) GetWorkflowTemplate(ctx context.Context, in *workflowtemplate.WorkflowTemplateGetRequest, opts ...grpc.CallOption) (*wfv1.WorkflowTemplate, error) {
+	if !IsImportedNamespace(in.Namespace) {
+		return s.WorkflowTemplateServiceClient.GetWorkflowTemplate(ctx, in, opts...)
+	}
+	for _, b := range s.client.imported(in.Namespace) {
+		for i := range b.WorkflowTemplates {
+			if b.WorkflowTemplates[i].Name == in.Name {
+				return b.WorkflowTemplates[i].DeepCopy(), nil
+			}
+		}
+	}
+	return nil, notFound("WorkflowTemplate", in.Namespace, in.Name)
+}
+
+// clusterWorkflowTemplateService serves the ClusterWorkflowTemplates of imported workflows.
+type clusterWorkflowTemplateService struct {
+	clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient
+	client *Client
+}
+
+// GetClusterWorkflowTemplate implements clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient.
+// Imported ClusterWorkflowTemplates are named by ImportedClusterTemplateName.
+func (s *clusterWorkflowTemplateService) GetClusterWorkflowTemplate(ctx context.Context, in *clusterworkflowtemplate.ClusterWorkflowTemplateGetRequest, opts ...grpc.CallOption) (*wfv1.ClusterWorkflowTemplate, error) {
+	namespace, name, ok := strings.Cut(in.Name, "/")
+	if !ok || !IsImportedNamespace(namespace) {
+		return s.ClusterWorkflowTemplateServiceClient.GetClusterWorkflowTemplate(ctx, in, opts...)
+	}
+	for _, b := range s.client.imported(namespace) {
+		for i := range b.ClusterWorkflowTemplates {
+			if b.ClusterWorkflowTemplates[i].Name == name {
+				return b.ClusterWorkflowTemplates[i].DeepCopy(), nil
+			}
+		}
+	}
+	return nil, notFound("ClusterWorkflowTemplate", namespace, name)
+}
@@ -0,0 +1,291 @@
+package bundle
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/clusterworkflowtemplate"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+)
+
+// newTestClient returns a bundle client with testBundle imported beside a mock client.
+func newTestClient(t *testing.T) (*Client, *mocks.MockWorkflowServiceClient) {
+	t.Helper()
+	wfService := &mocks.MockWorkflowServiceClient{}
+	next := mocks.NewMockClient("argo", true)
+	next.SetWorkflowService(wfService)
+	next.SetWorkflowTemplateService(&mocks.MockWorkflowTemplateServiceClient{})
+	next.SetClusterWorkflowTemplateService(&mocks.MockClusterWorkflowTemplateServiceClient{})
+
+	client := NewClient(next, "/exports")
+	_, err := client.ImportBundle(testBundle())
+	require.NoError(t, err)
+	return client, wfService
+}
+
+// readLogs drains a log stream into "pod: line" strings.
+func readLogs(t *testing.T, stream workflow.WorkflowService_WorkflowLogsClient) []string {
+	t.Helper()
+	var lines []string
+	for {
+		entry, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return lines
+		}
+		require.NoError(t, err)
+		lines = append(lines, entry.PodName+": "+entry.Content)
+	}
+}
+
+func TestImportedNamespace(t *testing.T) {
+	assert.Equal(t, "bundle:argo", ImportedNamespace("argo"))
+	assert.Equal(t, "bundle:argo", ImportedNamespace("bundle:argo"))
+	assert.True(t, IsImportedNamespace("bundle:argo"))
+	assert.False(t, IsImportedNamespace("argo"))
+	assert.Equal(t, "bundle:argo/shared", ImportedClusterTemplateName("argo", "shared"))
+}
+
+func TestClient_GetWorkflow(t *testing.T) {
+	client, wfService := newTestClient(t)
+	wfService.On("GetWorkflow", mock.Anything, mock.MatchedBy(func(req *workflow.WorkflowGetRequest) bool {
+		return req.Namespace == "argo" && req.Name == "wf"
+	})).Return(&wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "wf", Namespace: "argo"}}, nil)
+
+	wf, err := client.WorkflowService().GetWorkflow(t.Context(), &workflow.WorkflowGetRequest{Namespace: "bundle:argo", Name: "wf"})
+	require.NoError(t, err)
+	assert.Equal(t, "bundle:argo", wf.Namespace)
+	assert.Equal(t, wfv1.WorkflowFailed, wf.Status.Phase)
+
+	// Callers may modify the result without changing the imported workflow
+	wf.Status.Phase = wfv1.WorkflowRunning
+	again, err := client.WorkflowService().GetWorkflow(t.Context(), &workflow.WorkflowGetRequest{Namespace: "bundle:argo", Name: "wf"})
+	require.NoError(t, err)
+	assert.Equal(t, wfv1.WorkflowFailed, again.Status.Phase)
+
+	// The live workflow of the same name is not shadowed by the import
+	live, err := client.WorkflowService().GetWorkflow(t.Context(), &workflow.WorkflowGetRequest{Namespace: "argo", Name: "wf"})
+	require.NoError(t, err)
+	assert.Equal(t, "argo", live.Namespace)
+	assert.Empty(t, live.Status.Phase)
+
+	// Missing imports are not looked up in the cluster
+	_, err = client.WorkflowService().GetWorkflow(t.Context(), &workflow.WorkflowGetRequest{Namespace: "bundle:argo", Name: "other"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	assert.Equal(t, "/exports", client.BundleDir())
+}
+
+func TestClient_ListWorkflows(t *testing.T) {
+	t.Run("live namespaces list only live workflows", func(t *testing.T) {
+		client, wfService := newTestClient(t)
+		wfService.On("ListWorkflows", mock.Anything, mock.Anything).Return(&wfv1.WorkflowList{Items: wfv1.Workflows{
+			{ObjectMeta: metav1.ObjectMeta{Name: "wf", Namespace: "argo"}},
+		}}, nil)
+
+		list, err := client.WorkflowService().ListWorkflows(t.Context(), &workflow.WorkflowListRequest{Namespace: "argo"})
+		require.NoError(t, err)
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, "argo", list.Items[0].Namespace)
+	})
+
+	t.Run("imported namespaces list only imported workflows", func(t *testing.T) {
+		client, _ := newTestClient(t)
+
+		list, err := client.WorkflowService().ListWorkflows(t.Context(), &workflow.WorkflowListRequest{Namespace: "bundle:argo"})
+		require.NoError(t, err)
+		require.Len(t, list.Items, 1)
+		assert.Equal(t, wfv1.WorkflowFailed, list.Items[0].Status.Phase)
+	})
+
+	t.Run("all namespaces list imported workflows after live ones", func(t *testing.T) {
+		client, wfService := newTestClient(t)
+		wfService.On("ListWorkflows", mock.Anything, mock.Anything).Return(&wfv1.WorkflowList{Items: wfv1.Workflows{
+			{ObjectMeta: metav1.ObjectMeta{Name: "wf", Namespace: "argo"}},
+		}}, nil)
+
+		list, err := client.WorkflowService().ListWorkflows(t.Context(), &workflow.WorkflowListRequest{})
+		require.NoError(t, err)
+		require.Len(t, list.Items, 2)
+		assert.Equal(t, "argo", list.Items[0].Namespace)
+		assert.Equal(t, "bundle:argo", list.Items[1].Namespace)
+	})
+
+	t.Run("unreachable cluster lists imported workflows", func(t *testing.T) {
+		client, wfService := newTestClient(t)
+		wfService.On("ListWorkflows", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+		list, err := client.WorkflowService().ListWorkflows(t.Context(), &workflow.WorkflowListRequest{})
+		require.NoError(t, err)
+		require.Len(t, list.Items, 1)
+
+		_, err = client.WorkflowService().ListWorkflows(t.Context(), &workflow.WorkflowListRequest{Namespace: "argo"})
+		require.Error(t, err)
+	})
+
+	t.Run("label selector applies to imported workflows", func(t *testing.T) {
+		client, _ := newTestClient(t)
+
+		list, err := client.WorkflowService().ListWorkflows(t.Context(), &workflow.WorkflowListRequest{
+			Namespace:   "bundle:argo",
+			ListOptions: &metav1.ListOptions{LabelSelector: "team=ml"},
+		})
+		require.NoError(t, err)
+		assert.Empty(t, list.Items)
+
+		_, err = client.WorkflowService().ListWorkflows(t.Context(), &workflow.WorkflowListRequest{
+			Namespace:   "bundle:argo",
+			ListOptions: &metav1.ListOptions{LabelSelector: "team in ("},
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestClient_ImportBundle(t *testing.T) {
+	sized := func(name string, size int64) *Bundle {
+		return &Bundle{Workflow: &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "argo"}}, Size: size}
+	}
+
+	t.Run("oldest imports are evicted to stay within the limit", func(t *testing.T) {
+		client := NewClient(mocks.NewMockClient("argo", true), "")
+		client.maxBytes = 100
+
+		_, err := client.ImportBundle(sized("first", 40))
+		require.NoError(t, err)
+		_, err = client.ImportBundle(sized("second", 40))
+		require.NoError(t, err)
+		evicted, err := client.ImportBundle(sized("third", 40))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"bundle:argo/first"}, evicted)
+		assert.Nil(t, client.bundle("bundle:argo", "first"))
+		assert.NotNil(t, client.bundle("bundle:argo", "third"))
+
+		// Reimporting replaces the earlier import instead of evicting others
+		evicted, err = client.ImportBundle(sized("second", 50))
+		require.NoError(t, err)
+		assert.Empty(t, evicted)
+		assert.Equal(t, int64(90), client.size)
+	})
+
+	t.Run("bundles larger than the limit are refused", func(t *testing.T) {
+		client := NewClient(mocks.NewMockClient("argo", true), "")
+		client.maxBytes = 100
+
+		_, err := client.ImportBundle(sized("huge", 101))
+		require.Error(t, err)
+		assert.Empty(t, client.imported(""))
+	})
+
+	t.Run("lookup", func(t *testing.T) {
+		client, _ := newTestClient(t)
+
+		require.NotNil(t, client.ImportedBundle("argo", "wf"))
+		assert.Same(t, client.ImportedBundle("argo", "wf"), client.ImportedBundle("bundle:argo", "wf"))
+		assert.Nil(t, client.ImportedBundle("argo", "other"))
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		client, _ := newTestClient(t)
+
+		assert.True(t, client.RemoveBundle("argo", "wf"))
+		assert.False(t, client.RemoveBundle("bundle:argo", "wf"))
+		assert.Empty(t, client.imported(""))
+		assert.Zero(t, client.size)
+	})
+}
+
+func TestClient_WorkflowLogs(t *testing.T) {
+	client, _ := newTestClient(t)
+	_, err := client.ImportBundle(&Bundle{
+		Workflow: &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "multi", Namespace: "argo"}},
+		Logs: map[string][]byte{
+			"multi-b": []byte("b1\nb2 error\nb3\n"),
+			"multi-a": []byte("a1 error\na2\n"),
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		req  *workflow.WorkflowLogRequest
+		name string
+		want []string
+	}{
+		{
+			name: "all pods in name order",
+			req:  &workflow.WorkflowLogRequest{Namespace: "bundle:argo", Name: "multi"},
+			want: []string{"multi-a: a1 error", "multi-a: a2", "multi-b: b1", "multi-b: b2 error", "multi-b: b3"},
+		},
+		{
+			name: "single pod with tail",
+			req: &workflow.WorkflowLogRequest{Namespace: "bundle:argo", Name: "multi", PodName: "multi-b",
+				LogOptions: &corev1.PodLogOptions{TailLines: ptr.To[int64](2)}},
+			want: []string{"multi-b: b2 error", "multi-b: b3"},
+		},
+		{
+			name: "grep",
+			req:  &workflow.WorkflowLogRequest{Namespace: "bundle:argo", Name: "multi", Grep: "err(or)?$"},
+			want: []string{"multi-a: a1 error", "multi-b: b2 error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := client.WorkflowService().WorkflowLogs(t.Context(), tt.req)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, readLogs(t, stream))
+		})
+	}
+
+	t.Run("invalid grep", func(t *testing.T) {
+		_, err := client.WorkflowService().WorkflowLogs(t.Context(), &workflow.WorkflowLogRequest{Namespace: "bundle:argo", Name: "multi", Grep: "("})
+		require.Error(t, err)
+	})
+}
+
+func TestClient_Templates(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	wftService, err := client.WorkflowTemplateService()
+	require.NoError(t, err)
+	wft, err := wftService.GetWorkflowTemplate(t.Context(), &workflowtemplate.WorkflowTemplateGetRequest{Namespace: "bundle:argo", Name: "lib"})
+	require.NoError(t, err)
+	assert.Equal(t, "lib", wft.Name)
+	assert.Equal(t, "bundle:argo", wft.Namespace)
+	_, err = wftService.GetWorkflowTemplate(t.Context(), &workflowtemplate.WorkflowTemplateGetRequest{Namespace: "bundle:argo", Name: "other"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	cwftService, err := client.ClusterWorkflowTemplateService()
+	require.NoError(t, err)
+	cwft, err := cwftService.GetClusterWorkflowTemplate(t.Context(), &clusterworkflowtemplate.ClusterWorkflowTemplateGetRequest{Name: "bundle:argo/shared"})
+	require.NoError(t, err)
+	assert.Equal(t, "shared", cwft.Name)
+	_, err = cwftService.GetClusterWorkflowTemplate(t.Context(), &clusterworkflowtemplate.ClusterWorkflowTemplateGetRequest{Name: "bundle:argo/other"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestClient_OpenArtifact(t *testing.T) {
+	client, _ := newTestClient(t)
+
+	rc, err := client.OpenArtifact(t.Context(), &argo.ArtifactRequest{Namespace: "bundle:argo", Workflow: "wf", NodeID: "wf-1", Name: "report"})
+	require.NoError(t, err)
+	data, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(data))
+	require.NoError(t, rc.Close())
+
+	_, err = client.OpenArtifact(t.Context(), &argo.ArtifactRequest{Namespace: "bundle:argo", Workflow: "wf", NodeID: "wf-1", Name: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,227 @@
+// Package lint implements offline best-practice checks for Argo Workflows
+// manifests. Unlike the Argo Server lint API it needs no cluster: rules run
+// against the parsed WorkflowSpec and report findings with a severity that
+// can be configured per rule.
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+)
+
+// Severity is how serious a finding is.
+type Severity string
+
+// Severities, from most to least serious. SeverityOff disables a rule.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+	SeverityOff     Severity = "off"
+)
+
+// ParseSeverity parses a severity name.
+func ParseSeverity(s string) (Severity, error) {
+	switch severity := Severity(strings.ToLower(strings.TrimSpace(s))); severity {
+	case SeverityError, SeverityWarning, SeverityInfo, SeverityOff:
+		return severity, nil
+	default:
+		return "", fmt.Errorf("invalid severity %q, must be %q, %q, %q or %q", s, SeverityError, SeverityWarning, SeverityInfo, SeverityOff)
+	}
+}
+
+// rank orders severities from most to least serious.
+func (s Severity) rank() int {
+	switch s {
+	case SeverityError:
+		return 0
+	case SeverityWarning:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Finding is a single problem reported by a rule.
+type Finding struct {
+	// Rule is the name of the rule that reported the finding.
+	Rule string `json:"rule"`
+
+	// Severity is the configured severity of the rule.
+	Severity Severity `json:"severity"`
+
+	// Template is the template the finding is about, if any.
+	Template string `json:"template,omitempty"`
+
+	// Path is the location of the finding in the manifest, e.g. "spec.templates[1].container.image".
+	Path string `json:"path"`
+
+	// Message describes the problem.
+	Message string `json:"message"`
+}
+
+// Document is a manifest prepared for linting.
+type Document struct {
+	// Spec is the WorkflowSpec of the manifest.
+	Spec *wfv1.WorkflowSpec
+
+	// Kind is the manifest kind, e.g. "Workflow" or "CronWorkflow".
+	Kind string
+
+	// Name is the manifest name or generateName.
+	Name string
+
+	// SpecPath is the path of Spec in the manifest, e.g. "spec" or "spec.workflowSpec".
+	SpecPath string
+}
+
+// NewDocument returns a Document for the WorkflowSpec of a manifest of the given kind.
+func NewDocument(kind, name string, spec *wfv1.WorkflowSpec) *Document {
+	specPath := "spec"
+	if kind == "CronWorkflow" {
+		specPath = "spec.workflowSpec"
+	}
+	return &Document{Spec: spec, Kind: kind, Name: name, SpecPath: specPath}
+}
+
+// templatePath returns the path of the i-th template.
+func (d *Document) templatePath(i int) string {
+	return fmt.Sprintf("%s.templates[%d]", d.SpecPath, i)
+}
+
+// Rule is a check run by the Engine. Check reports findings without Rule and
+// Severity, which the Engine fills in.
+type Rule struct {
+	// Check returns the findings of the rule for a document.
+	Check func(doc *Document) []Finding
+
+	// Name identifies the rule, e.g. "latest-image-tag".
+	Name string
+
+	// Description explains what the rule checks.
+	Description string
+
+	// Severity is the default severity of the rule's findings.
+	Severity Severity
+}
+
+// Engine runs a set of rules with configurable severities.
+type Engine struct {
+	severities map[string]Severity
+	rules      []Rule
+}
+
+// NewEngine returns an Engine with the given rules, or the default rules if none are given.
+func NewEngine(rules ...Rule) *Engine {
+	if len(rules) == 0 {
+		rules = DefaultRules()
+	}
+	e := &Engine{severities: make(map[string]Severity)}
+	for _, rule := range rules {
+		// Later rules replace earlier ones with the same name
+		e.rules = slices.DeleteFunc(e.rules, func(r Rule) bool { return r.Name == rule.Name })
+		e.rules = append(e.rules, rule)
+	}
+	return e
+}
+
+// Register adds a rule to the engine. It fails if a rule with the same name is registered.
+func (e *Engine) Register(rule Rule) error {
+	if rule.Name == "" || rule.Check == nil {
+		return fmt.Errorf("rule must have a name and a check")
+	}
+	if _, ok := e.rule(rule.Name); ok {
+		return fmt.Errorf("rule %q is already registered", rule.Name)
+	}
+	e.rules = append(e.rules, rule)
+	return nil
+}
+
+// SetSeverity overrides the severity of a rule. SeverityOff disables it.
+func (e *Engine) SetSeverity(name string, severity Severity) error {
+	if _, ok := e.rule(name); !ok {
+		return fmt.Errorf("unknown lint rule %q, must be one of: %s", name, strings.Join(e.RuleNames(), ", "))
+	}
+	if _, err := ParseSeverity(string(severity)); err != nil {
+		return err
+	}
+	e.severities[name] = severity
+	return nil
+}
+
+// Rules returns the registered rules with their effective severities.
+func (e *Engine) Rules() []Rule {
+	rules := make([]Rule, 0, len(e.rules))
+	for _, rule := range e.rules {
+		rule.Severity = e.severity(rule)
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// RuleNames returns the names of the registered rules.
+func (e *Engine) RuleNames() []string {
+	names := make([]string, 0, len(e.rules))
+	for _, rule := range e.rules {
+		names = append(names, rule.Name)
+	}
+	return names
+}
+
+// Lint runs the enabled rules against doc and returns their findings,
+// most serious first.
+func (e *Engine) Lint(doc *Document) []Finding {
+	var findings []Finding
+	if doc == nil || doc.Spec == nil {
+		return findings
+	}
+	for _, rule := range e.rules {
+		severity := e.severity(rule)
+		if severity == SeverityOff {
+			continue
+		}
+		for _, finding := range rule.Check(doc) {
+			finding.Rule = rule.Name
+			finding.Severity = severity
+			findings = append(findings, finding)
+		}
+	}
+	slices.SortStableFunc(findings, func(a, b Finding) int { return a.Severity.rank() - b.Severity.rank() })
+	return findings
+}
+
+// rule returns the registered rule with the given name.
+func (e *Engine) rule(name string) (Rule, bool) {
+	for _, rule := range e.rules {
+		if rule.Name == name {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// severity returns the effective severity of a rule.
+func (e *Engine) severity(rule Rule) Severity {
+	if severity, ok := e.severities[rule.Name]; ok {
+		return severity
+	}
+	return rule.Severity
+}
+
+// DefaultRules returns the built-in rules.
+func DefaultRules() []Rule {
+	return []Rule{
+		ResourceRequirementsRule(),
+		RetryStrategyRule(),
+		LatestImageTagRule(),
+		ActiveDeadlineRule(),
+		UnboundedFanOutRule(),
+		UnusedParameterRule(),
+		UnusedTemplateRule(),
+		UndefinedInputReferenceRule(),
+		DAGCycleRule(),
+	}
+}
@@ -0,0 +1,103 @@
+package lint
+
+import (
+	"testing"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+// parseDocument parses a Workflow manifest into a Document.
+func parseDocument(t *testing.T, kind, manifest string) *Document {
+	t.Helper()
+	var wf wfv1.Workflow
+	require.NoError(t, yaml.Unmarshal([]byte(manifest), &wf))
+	return NewDocument(kind, wf.Name, &wf.Spec)
+}
+
+// ruleFindings runs a single rule against a Workflow manifest.
+func ruleFindings(t *testing.T, rule Rule, manifest string) []Finding {
+	t.Helper()
+	return NewEngine(rule).Lint(parseDocument(t, "Workflow", manifest))
+}
+
+func TestParseSeverity(t *testing.T) {
+	severity, err := ParseSeverity(" Warning ")
+	require.NoError(t, err)
+	assert.Equal(t, SeverityWarning, severity)
+
+	_, err = ParseSeverity("fatal")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid severity")
+}
+
+func TestNewDocument(t *testing.T) {
+	assert.Equal(t, "spec", NewDocument("Workflow", "wf", &wfv1.WorkflowSpec{}).SpecPath)
+	assert.Equal(t, "spec.workflowSpec", NewDocument("CronWorkflow", "cron", &wfv1.WorkflowSpec{}).SpecPath)
+}
+
+func TestEngine(t *testing.T) {
+	always := func(name string, severity Severity) Rule {
+		return Rule{
+			Name:     name,
+			Severity: severity,
+			Check: func(*Document) []Finding {
+				return []Finding{{Path: "spec", Message: name}}
+			},
+		}
+	}
+	doc := NewDocument("Workflow", "wf", &wfv1.WorkflowSpec{})
+
+	t.Run("findings are sorted by severity", func(t *testing.T) {
+		engine := NewEngine(always("a", SeverityInfo), always("b", SeverityError), always("c", SeverityWarning))
+		findings := engine.Lint(doc)
+		require.Len(t, findings, 3)
+		assert.Equal(t, "b", findings[0].Rule)
+		assert.Equal(t, SeverityError, findings[0].Severity)
+		assert.Equal(t, "c", findings[1].Rule)
+		assert.Equal(t, "a", findings[2].Rule)
+	})
+
+	t.Run("severity overrides and disabling", func(t *testing.T) {
+		engine := NewEngine(always("a", SeverityInfo), always("b", SeverityError))
+		require.NoError(t, engine.SetSeverity("a", SeverityError))
+		require.NoError(t, engine.SetSeverity("b", SeverityOff))
+
+		findings := engine.Lint(doc)
+		require.Len(t, findings, 1)
+		assert.Equal(t, "a", findings[0].Rule)
+		assert.Equal(t, SeverityError, findings[0].Severity)
+		assert.Equal(t, SeverityOff, engine.Rules()[1].Severity)
+	})
+
+	t.Run("unknown rule and invalid severity", func(t *testing.T) {
+		engine := NewEngine(always("a", SeverityInfo))
+		err := engine.SetSeverity("missing", SeverityError)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown lint rule")
+		require.Error(t, engine.SetSeverity("a", "fatal"))
+	})
+
+	t.Run("register custom rules", func(t *testing.T) {
+		engine := NewEngine()
+		require.NoError(t, engine.Register(always("custom", SeverityWarning)))
+		assert.Contains(t, engine.RuleNames(), "custom")
+
+		err := engine.Register(always("custom", SeverityWarning))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already registered")
+		require.Error(t, engine.Register(Rule{Name: "no-check"}))
+	})
+
+	t.Run("default rules", func(t *testing.T) {
+		names := NewEngine().RuleNames()
+		assert.Len(t, names, len(DefaultRules()))
+		assert.Contains(t, names, "dag-cycle")
+	})
+
+	t.Run("nil spec", func(t *testing.T) {
+		assert.Empty(t, NewEngine().Lint(&Document{}))
+	})
+}
@@ -0,0 +1,231 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ResourceRequirementsRule reports containers without resource requests or limits.
+// Defaults from templateDefaults and resources set through a podSpecPatch count.
+func ResourceRequirementsRule() Rule {
+	return Rule{
+		Name:        "resource-requirements",
+		Description: "Containers should set resource requests and limits so pods are scheduled predictably and cannot starve their node.",
+		Severity:    SeverityWarning,
+		Check:       checkResourceRequirements,
+	}
+}
+
+func checkResourceRequirements(doc *Document) []Finding {
+	spec := doc.Spec
+	if strings.Contains(spec.PodSpecPatch, "resources") {
+		return nil
+	}
+
+	var defaults corev1.ResourceRequirements
+	if spec.TemplateDefaults != nil && spec.TemplateDefaults.Container != nil {
+		defaults = spec.TemplateDefaults.Container.Resources
+	}
+
+	var findings []Finding
+	for i := range spec.Templates {
+		tmpl := &spec.Templates[i]
+		if strings.Contains(tmpl.PodSpecPatch, "resources") {
+			continue
+		}
+		for _, c := range mainContainers(tmpl, doc.templatePath(i)) {
+			var missing []string
+			if len(c.container.Resources.Requests) == 0 && len(defaults.Requests) == 0 {
+				missing = append(missing, "requests")
+			}
+			if len(c.container.Resources.Limits) == 0 && len(defaults.Limits) == 0 {
+				missing = append(missing, "limits")
+			}
+			if len(missing) == 0 {
+				continue
+			}
+			findings = append(findings, Finding{
+				Template: tmpl.Name,
+				Path:     c.path + ".resources",
+				Message:  fmt.Sprintf("%s has no resource %s", c.describe(), strings.Join(missing, " or ")),
+			})
+		}
+	}
+	return findings
+}
+
+// RetryStrategyRule reports template types that talk to external systems and
+// commonly fail transiently, but have no retryStrategy.
+func RetryStrategyRule() Rule {
+	return Rule{
+		Name:        "retry-strategy",
+		Description: "HTTP, resource and plugin templates depend on external systems and should set a retryStrategy.",
+		Severity:    SeverityWarning,
+		Check:       checkRetryStrategy,
+	}
+}
+
+func checkRetryStrategy(doc *Document) []Finding {
+	spec := doc.Spec
+	if spec.RetryStrategy != nil || (spec.TemplateDefaults != nil && spec.TemplateDefaults.RetryStrategy != nil) {
+		return nil
+	}
+
+	var findings []Finding
+	for i := range spec.Templates {
+		tmpl := &spec.Templates[i]
+		if tmpl.RetryStrategy != nil {
+			continue
+		}
+		var kind string
+		switch {
+		case tmpl.HTTP != nil:
+			kind = "http"
+		case tmpl.Resource != nil:
+			kind = "resource"
+		case tmpl.Plugin != nil:
+			kind = "plugin"
+		default:
+			continue
+		}
+		findings = append(findings, Finding{
+			Template: tmpl.Name,
+			Path:     doc.templatePath(i) + ".retryStrategy",
+			Message:  fmt.Sprintf("%s template %q has no retryStrategy; transient failures will fail the workflow", kind, tmpl.Name),
+		})
+	}
+	return findings
+}
+
+// LatestImageTagRule reports images that use the latest tag or no tag at all.
+func LatestImageTagRule() Rule {
+	return Rule{
+		Name:        "latest-image-tag",
+		Description: "Images should be pinned to a tag or digest; latest or untagged images make runs irreproducible.",
+		Severity:    SeverityWarning,
+		Check:       checkLatestImageTag,
+	}
+}
+
+func checkLatestImageTag(doc *Document) []Finding {
+	var findings []Finding
+	for i := range doc.Spec.Templates {
+		tmpl := &doc.Spec.Templates[i]
+		for _, c := range allContainers(tmpl, doc.templatePath(i)) {
+			image := c.container.Image
+			if image == "" || strings.Contains(image, "{{") {
+				continue
+			}
+			tag, pinned := imageTag(image)
+			if pinned {
+				continue
+			}
+			message := fmt.Sprintf("%s uses image %q without a tag", c.describe(), image)
+			if tag == "latest" {
+				message = fmt.Sprintf("%s uses the latest tag of image %q", c.describe(), image)
+			}
+			findings = append(findings, Finding{
+				Template: tmpl.Name,
+				Path:     c.path + ".image",
+				Message:  message,
+			})
+		}
+	}
+	return findings
+}
+
+// imageTag returns the tag of an image reference and whether the image is
+// pinned to a digest or a tag other than latest.
+func imageTag(image string) (string, bool) {
+	if strings.Contains(image, "@") {
+		return "", true
+	}
+	// The registry may contain a port, so only look at the last path segment
+	name := image[strings.LastIndex(image, "/")+1:]
+	_, tag, found := strings.Cut(name, ":")
+	if !found || tag == "" {
+		return "", false
+	}
+	return tag, tag != "latest"
+}
+
+// ActiveDeadlineRule reports workflows without a workflow-level activeDeadlineSeconds.
+func ActiveDeadlineRule() Rule {
+	return Rule{
+		Name:        "active-deadline",
+		Description: "Workflows should set activeDeadlineSeconds so a stuck run cannot hold resources forever.",
+		Severity:    SeverityInfo,
+		Check:       checkActiveDeadline,
+	}
+}
+
+func checkActiveDeadline(doc *Document) []Finding {
+	spec := doc.Spec
+	// The referenced template provides the rest of the spec
+	if spec.ActiveDeadlineSeconds != nil || spec.WorkflowTemplateRef != nil {
+		return nil
+	}
+	return []Finding{{
+		Path:    doc.SpecPath + ".activeDeadlineSeconds",
+		Message: "workflow has no activeDeadlineSeconds; a stuck run will never be terminated",
+	}}
+}
+
+// templateContainer is a container of a template and its path in the manifest.
+type templateContainer struct {
+	container *corev1.Container
+	role      string
+	path      string
+}
+
+// describe names the container for messages.
+func (c templateContainer) describe() string {
+	if c.container.Name == "" {
+		return c.role + " container"
+	}
+	return fmt.Sprintf("%s container %q", c.role, c.container.Name)
+}
+
+// mainContainers returns the containers that do the template's work.
+func mainContainers(tmpl *wfv1.Template, path string) []templateContainer {
+	var containers []templateContainer
+	if tmpl.Container != nil {
+		containers = append(containers, templateContainer{container: tmpl.Container, role: "main", path: path + ".container"})
+	}
+	if tmpl.Script != nil {
+		containers = append(containers, templateContainer{container: &tmpl.Script.Container, role: "script", path: path + ".script"})
+	}
+	if tmpl.ContainerSet != nil {
+		for j := range tmpl.ContainerSet.Containers {
+			containers = append(containers, templateContainer{
+				container: &tmpl.ContainerSet.Containers[j].Container,
+				role:      "containerSet",
+				path:      fmt.Sprintf("%s.containerSet.containers[%d]", path, j),
+			})
+		}
+	}
+	return containers
+}
+
+// allContainers returns the main containers plus init containers and sidecars.
+func allContainers(tmpl *wfv1.Template, path string) []templateContainer {
+	containers := mainContainers(tmpl, path)
+	for j := range tmpl.InitContainers {
+		containers = append(containers, templateContainer{
+			container: &tmpl.InitContainers[j].Container,
+			role:      "init",
+			path:      fmt.Sprintf("%s.initContainers[%d]", path, j),
+		})
+	}
+	for j := range tmpl.Sidecars {
+		containers = append(containers, templateContainer{
+			container: &tmpl.Sidecars[j].Container,
+			role:      "sidecar",
+			path:      fmt.Sprintf("%s.sidecars[%d]", path, j),
+		})
+	}
+	return containers
+}
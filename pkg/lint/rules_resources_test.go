@@ -0,0 +1,189 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceRequirementsRule(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		messages []string
+	}{
+		{
+			name: "missing requests and limits",
+			manifest: `
+spec:
+  templates:
+  - name: main
+    container:
+      image: alpine:3.19
+  - name: script
+    script:
+      image: python:3.12
+      resources:
+        requests:
+          cpu: 100m
+`,
+			messages: []string{
+				"main container has no resource requests or limits",
+				"script container has no resource limits",
+			},
+		},
+		{
+			name: "container set containers are checked",
+			manifest: `
+spec:
+  templates:
+  - name: set
+    containerSet:
+      containers:
+      - name: a
+        image: alpine:3.19
+`,
+			messages: []string{`containerSet container "a" has no resource requests or limits`},
+		},
+		{
+			name: "template defaults count",
+			manifest: `
+spec:
+  templateDefaults:
+    container:
+      resources:
+        requests:
+          cpu: 100m
+        limits:
+          memory: 1Gi
+  templates:
+  - name: main
+    container:
+      image: alpine:3.19
+`,
+		},
+		{
+			name: "pod spec patch counts",
+			manifest: `
+spec:
+  templates:
+  - name: main
+    podSpecPatch: '{"containers":[{"name":"main","resources":{"limits":{"cpu":"1"}}}]}'
+    container:
+      image: alpine:3.19
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := ruleFindings(t, ResourceRequirementsRule(), tt.manifest)
+			require.Len(t, findings, len(tt.messages))
+			for i, message := range tt.messages {
+				assert.Equal(t, message, findings[i].Message)
+			}
+		})
+	}
+
+	findings := ruleFindings(t, ResourceRequirementsRule(), tests[0].manifest)
+	assert.Equal(t, "spec.templates[0].container.resources", findings[0].Path)
+	assert.Equal(t, "main", findings[0].Template)
+}
+
+func TestRetryStrategyRule(t *testing.T) {
+	manifest := `
+spec:
+  templates:
+  - name: call
+    http:
+      url: https://example.com
+  - name: apply
+    resource:
+      action: create
+    retryStrategy:
+      limit: 3
+  - name: work
+    container:
+      image: alpine:3.19
+`
+	findings := ruleFindings(t, RetryStrategyRule(), manifest)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "call", findings[0].Template)
+	assert.Contains(t, findings[0].Message, "http template")
+
+	withDefault := manifest + `
+  retryStrategy:
+    limit: 2
+`
+	assert.Empty(t, ruleFindings(t, RetryStrategyRule(), withDefault))
+}
+
+func TestLatestImageTagRule(t *testing.T) {
+	manifest := `
+spec:
+  templates:
+  - name: main
+    container:
+      image: alpine:latest
+    sidecars:
+    - name: proxy
+      image: registry:5000/envoy
+    initContainers:
+    - name: init
+      image: busybox:1.36
+  - name: pinned
+    script:
+      image: python@sha256:abc
+  - name: templated
+    container:
+      image: "{{inputs.parameters.image}}"
+`
+	findings := ruleFindings(t, LatestImageTagRule(), manifest)
+	require.Len(t, findings, 2)
+	assert.Equal(t, `main container uses the latest tag of image "alpine:latest"`, findings[0].Message)
+	assert.Equal(t, `sidecar container "proxy" uses image "registry:5000/envoy" without a tag`, findings[1].Message)
+	assert.Equal(t, "spec.templates[0].sidecars[0].image", findings[1].Path)
+}
+
+func TestImageTag(t *testing.T) {
+	tests := []struct {
+		image  string
+		tag    string
+		pinned bool
+	}{
+		{image: "alpine", tag: "", pinned: false},
+		{image: "alpine:latest", tag: "latest", pinned: false},
+		{image: "alpine:3.19", tag: "3.19", pinned: true},
+		{image: "localhost:5000/app", tag: "", pinned: false},
+		{image: "localhost:5000/app:v1", tag: "v1", pinned: true},
+		{image: "app@sha256:abc", tag: "", pinned: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			tag, pinned := imageTag(tt.image)
+			assert.Equal(t, tt.tag, tag)
+			assert.Equal(t, tt.pinned, pinned)
+		})
+	}
+}
+
+func TestActiveDeadlineRule(t *testing.T) {
+	findings := ruleFindings(t, ActiveDeadlineRule(), `
+spec:
+  entrypoint: main
+`)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "spec.activeDeadlineSeconds", findings[0].Path)
+	assert.Equal(t, SeverityInfo, findings[0].Severity)
+
+	assert.Empty(t, ruleFindings(t, ActiveDeadlineRule(), `
+spec:
+  activeDeadlineSeconds: 3600
+`))
+	assert.Empty(t, ruleFindings(t, ActiveDeadlineRule(), `
+spec:
+  workflowTemplateRef:
+    name: base
+`))
+}
@@ -0,0 +1,514 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// maxFanOut is the largest literal withItems or withSequence expansion that
+// is not reported without a parallelism limit.
+const maxFanOut = 100
+
+// UnboundedFanOutRule reports steps and tasks that expand into many or an
+// unknown number of children while nothing limits their parallelism.
+func UnboundedFanOutRule() Rule {
+	return Rule{
+		Name:        "unbounded-fan-out",
+		Description: fmt.Sprintf("withParam, templated withSequence and withItems or withSequence expansions over %d should be bounded by parallelism.", maxFanOut),
+		Severity:    SeverityWarning,
+		Check:       checkUnboundedFanOut,
+	}
+}
+
+func checkUnboundedFanOut(doc *Document) []Finding {
+	if doc.Spec.Parallelism != nil {
+		return nil
+	}
+
+	var findings []Finding
+	for i := range doc.Spec.Templates {
+		tmpl := &doc.Spec.Templates[i]
+		if tmpl.Parallelism != nil {
+			continue
+		}
+		for _, s := range templateSteps(tmpl, doc.templatePath(i)) {
+			reason := fanOutReason(s.withItems, s.withParam, s.withSequence)
+			if reason == "" {
+				continue
+			}
+			findings = append(findings, Finding{
+				Template: tmpl.Name,
+				Path:     s.path,
+				Message:  fmt.Sprintf("%s %q %s and neither the template nor the workflow sets parallelism", s.kind, s.name, reason),
+			})
+		}
+	}
+	return findings
+}
+
+// fanOutReason describes why a loop is unbounded, or returns "" if it is not.
+func fanOutReason(withItems []wfv1.Item, withParam string, withSequence *wfv1.Sequence) string {
+	switch {
+	case withParam != "":
+		return "expands over withParam, whose size is only known at runtime,"
+	case withSequence != nil:
+		size, known := sequenceSize(withSequence)
+		if !known {
+			return "expands over a templated withSequence, whose size is only known at runtime,"
+		}
+		if size > maxFanOut {
+			return fmt.Sprintf("expands into %d children", size)
+		}
+	case len(withItems) > maxFanOut:
+		return fmt.Sprintf("expands into %d children", len(withItems))
+	}
+	return ""
+}
+
+// sequenceSize returns the number of items of a sequence and whether it is known before runtime.
+func sequenceSize(seq *wfv1.Sequence) (int, bool) {
+	if seq.Count != nil {
+		return intOrStringValue(seq.Count)
+	}
+	start, known := 0, true
+	if seq.Start != nil {
+		start, known = intOrStringValue(seq.Start)
+	}
+	end, endKnown := 0, true
+	if seq.End != nil {
+		end, endKnown = intOrStringValue(seq.End)
+	}
+	if !known || !endKnown {
+		return 0, false
+	}
+	if end < start {
+		start, end = end, start
+	}
+	return end - start + 1, true
+}
+
+// intOrStringValue returns the integer value of v, if it is not templated.
+func intOrStringValue(v *intstr.IntOrString) (int, bool) {
+	if v.Type == intstr.Int {
+		return int(v.IntVal), true
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(v.StrVal))
+	return n, err == nil
+}
+
+// UnusedParameterRule reports workflow arguments and template inputs that are never referenced.
+func UnusedParameterRule() Rule {
+	return Rule{
+		Name:        "unused-parameter",
+		Description: "Workflow argument and template input parameters should be referenced; unused ones are usually leftovers or typos.",
+		Severity:    SeverityWarning,
+		Check:       checkUnusedParameters,
+	}
+}
+
+func checkUnusedParameters(doc *Document) []Finding {
+	var findings []Finding
+	spec := doc.Spec
+
+	// Arguments of a workflow that references a template are consumed by that template
+	if spec.WorkflowTemplateRef == nil && len(spec.Arguments.Parameters) > 0 {
+		body := *spec
+		body.Arguments.Parameters = nil
+		refs := collectReferences(collectStrings(body), workflowParameterReferences)
+		for j, param := range spec.Arguments.Parameters {
+			if refs.uses(param.Name) {
+				continue
+			}
+			findings = append(findings, Finding{
+				Path:    fmt.Sprintf("%s.arguments.parameters[%d]", doc.SpecPath, j),
+				Message: fmt.Sprintf("workflow parameter %q is never referenced as {{workflow.parameters.%s}}", param.Name, param.Name),
+			})
+		}
+	}
+
+	for i := range spec.Templates {
+		tmpl := &spec.Templates[i]
+		if len(tmpl.Inputs.Parameters) == 0 {
+			continue
+		}
+		body := *tmpl
+		body.Inputs.Parameters = nil
+		refs := collectReferences(collectStrings(body), inputReferences["parameters"])
+		for j, param := range tmpl.Inputs.Parameters {
+			if refs.uses(param.Name) {
+				continue
+			}
+			findings = append(findings, Finding{
+				Template: tmpl.Name,
+				Path:     fmt.Sprintf("%s.inputs.parameters[%d]", doc.templatePath(i), j),
+				Message:  fmt.Sprintf("input parameter %q of template %q is never referenced", param.Name, tmpl.Name),
+			})
+		}
+	}
+	return findings
+}
+
+// UnusedTemplateRule reports templates of a Workflow or CronWorkflow that
+// cannot be reached from the entrypoint, exit handler or hooks.
+// WorkflowTemplates are skipped because other workflows may reference any of their templates.
+func UnusedTemplateRule() Rule {
+	return Rule{
+		Name:        "unused-template",
+		Description: "Templates of a Workflow or CronWorkflow should be reachable from the entrypoint, onExit or hooks.",
+		Severity:    SeverityWarning,
+		Check:       checkUnusedTemplates,
+	}
+}
+
+func checkUnusedTemplates(doc *Document) []Finding {
+	spec := doc.Spec
+	if doc.Kind != "Workflow" && doc.Kind != "CronWorkflow" {
+		return nil
+	}
+	if spec.WorkflowTemplateRef != nil || spec.Entrypoint == "" {
+		return nil
+	}
+
+	templates := make(map[string]*wfv1.Template, len(spec.Templates))
+	for i := range spec.Templates {
+		templates[spec.Templates[i].Name] = &spec.Templates[i]
+	}
+
+	roots := []string{spec.Entrypoint, spec.OnExit}
+	for _, hook := range spec.Hooks {
+		roots = append(roots, hook.Template)
+	}
+
+	reachable := make(map[string]bool)
+	queue := roots
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+		if name == "" || reachable[name] {
+			continue
+		}
+		// A templated reference could name any template
+		if strings.Contains(name, "{{") {
+			return nil
+		}
+		reachable[name] = true
+		if tmpl, ok := templates[name]; ok {
+			queue = append(queue, referencedTemplates(tmpl)...)
+		}
+	}
+
+	var findings []Finding
+	for i := range spec.Templates {
+		if name := spec.Templates[i].Name; !reachable[name] {
+			findings = append(findings, Finding{
+				Template: name,
+				Path:     doc.templatePath(i),
+				Message:  fmt.Sprintf("template %q is never used", name),
+			})
+		}
+	}
+	return findings
+}
+
+// referencedTemplates returns the names of the local templates a template calls.
+func referencedTemplates(tmpl *wfv1.Template) []string {
+	var names []string
+	add := func(template, onExit string, hooks wfv1.LifecycleHooks) {
+		names = append(names, template, onExit)
+		for _, hook := range hooks {
+			names = append(names, hook.Template)
+		}
+	}
+	for _, parallel := range tmpl.Steps {
+		for _, step := range parallel.Steps {
+			add(step.Template, step.OnExit, step.Hooks)
+		}
+	}
+	if tmpl.DAG != nil {
+		for _, task := range tmpl.DAG.Tasks {
+			add(task.Template, task.OnExit, task.Hooks)
+		}
+	}
+	return names
+}
+
+// UndefinedInputReferenceRule reports references to input parameters or
+// artifacts that the template does not declare.
+func UndefinedInputReferenceRule() Rule {
+	return Rule{
+		Name:        "undefined-input-reference",
+		Description: "{{inputs.parameters.x}} and {{inputs.artifacts.x}} must refer to inputs declared by the template.",
+		Severity:    SeverityError,
+		Check:       checkUndefinedInputReferences,
+	}
+}
+
+func checkUndefinedInputReferences(doc *Document) []Finding {
+	var findings []Finding
+	for i := range doc.Spec.Templates {
+		tmpl := &doc.Spec.Templates[i]
+		strs := collectStrings(*tmpl)
+		for _, kind := range []string{"parameters", "artifacts"} {
+			declared := make(map[string]bool)
+			if kind == "parameters" {
+				for _, param := range tmpl.Inputs.Parameters {
+					declared[param.Name] = true
+				}
+			} else {
+				for _, art := range tmpl.Inputs.Artifacts {
+					declared[art.Name] = true
+				}
+			}
+			for _, name := range collectReferences(strs, inputReferences[kind]).names {
+				if declared[name] {
+					continue
+				}
+				findings = append(findings, Finding{
+					Template: tmpl.Name,
+					Path:     fmt.Sprintf("%s.inputs.%s", doc.templatePath(i), kind),
+					Message:  fmt.Sprintf("template %q references {{inputs.%s.%s}} but declares no such input", tmpl.Name, kind, name),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// DAGCycleRule reports DAG templates whose task dependencies form a cycle.
+func DAGCycleRule() Rule {
+	return Rule{
+		Name:        "dag-cycle",
+		Description: "DAG task dependencies must not form a cycle.",
+		Severity:    SeverityError,
+		Check:       checkDAGCycles,
+	}
+}
+
+func checkDAGCycles(doc *Document) []Finding {
+	var findings []Finding
+	for i := range doc.Spec.Templates {
+		tmpl := &doc.Spec.Templates[i]
+		if tmpl.DAG == nil {
+			continue
+		}
+		for _, cycle := range dagCycles(tmpl.DAG.Tasks) {
+			findings = append(findings, Finding{
+				Template: tmpl.Name,
+				Path:     doc.templatePath(i) + ".dag.tasks",
+				Message:  fmt.Sprintf("DAG template %q has a dependency cycle: %s", tmpl.Name, strings.Join(cycle, " -> ")),
+			})
+		}
+	}
+	return findings
+}
+
+// dagCycles returns the dependency cycles between tasks, each starting and
+// ending with the same task.
+func dagCycles(tasks []wfv1.DAGTask) [][]string {
+	deps := make(map[string][]string, len(tasks))
+	for _, task := range tasks {
+		deps[task.Name] = nil
+	}
+	for _, task := range tasks {
+		for _, dep := range taskDependencies(task) {
+			if _, ok := deps[dep]; ok {
+				deps[task.Name] = append(deps[task.Name], dep)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(deps))
+	var cycles [][]string
+	var stack []string
+	var visit func(name string)
+	visit = func(name string) {
+		state[name] = visiting
+		stack = append(stack, name)
+		for _, dep := range deps[name] {
+			switch state[dep] {
+			case unvisited:
+				visit(dep)
+			case visiting:
+				start := slices.Index(stack, dep)
+				cycle := slices.Clone(stack[start:])
+				cycles = append(cycles, append(cycle, dep))
+			}
+		}
+		stack = stack[:len(stack)-1]
+		state[name] = done
+	}
+	for _, name := range names {
+		if state[name] == unvisited {
+			visit(name)
+		}
+	}
+	return cycles
+}
+
+// taskDependencies returns the tasks a task depends on through dependencies or depends.
+func taskDependencies(task wfv1.DAGTask) []string {
+	deps := slices.Clone(task.Dependencies)
+	if task.Depends == "" {
+		return deps
+	}
+	expr := strings.NewReplacer("&&", " ", "||", " ", "!", " ", "(", " ", ")", " ").Replace(task.Depends)
+	for _, field := range strings.Fields(expr) {
+		// Drop result suffixes such as A.Succeeded
+		name, _, _ := strings.Cut(field, ".")
+		deps = append(deps, name)
+	}
+	return deps
+}
+
+// references are the names referenced under a prefix such as "inputs.parameters".
+type references struct {
+	names []string
+
+	// all is set when the whole prefix is referenced, e.g. {{inputs.parameters}}.
+	all bool
+}
+
+// uses reports whether name is referenced.
+func (r references) uses(name string) bool {
+	return r.all || slices.Contains(r.names, name)
+}
+
+// referencePattern matches the references under a prefix such as "inputs.parameters".
+type referencePattern struct {
+	prefix  string
+	dotted  *regexp.Regexp
+	bracket *regexp.Regexp
+	whole   *regexp.Regexp
+}
+
+// Reference patterns of the prefixes the rules check, compiled once.
+var (
+	workflowParameterReferences = newReferencePattern("workflow.parameters")
+	inputReferences             = map[string]*referencePattern{
+		"parameters": newReferencePattern("inputs.parameters"),
+		"artifacts":  newReferencePattern("inputs.artifacts"),
+	}
+)
+
+// newReferencePattern compiles the patterns matching references under prefix.
+func newReferencePattern(prefix string) *referencePattern {
+	quoted := regexp.QuoteMeta(prefix)
+	return &referencePattern{
+		prefix:  prefix,
+		dotted:  regexp.MustCompile(`(?:^|[^.\w])` + quoted + `\.([\w-]+)`),
+		bracket: regexp.MustCompile(`(?:^|[^.\w])` + quoted + `\[\s*['"]([^'"]+)['"]\s*\]`),
+		whole:   regexp.MustCompile(`(?:^|[^.\w])` + quoted + `(?:[^.\[\w-]|$)`),
+	}
+}
+
+// collectReferences returns the distinct names referenced under the prefix of
+// pattern in strs, in dotted or bracket form.
+func collectReferences(strs []string, pattern *referencePattern) references {
+	var refs references
+	for _, s := range strs {
+		if !strings.Contains(s, pattern.prefix) {
+			continue
+		}
+		if pattern.whole.MatchString(s) {
+			refs.all = true
+		}
+		for _, re := range []*regexp.Regexp{pattern.dotted, pattern.bracket} {
+			for _, match := range re.FindAllStringSubmatch(s, -1) {
+				if !slices.Contains(refs.names, match[1]) {
+					refs.names = append(refs.names, match[1])
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// collectStrings returns every string key and value in the JSON form of v.
+func collectStrings(v any) []string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil
+	}
+	var tree any
+	if unmarshalErr := json.Unmarshal(data, &tree); unmarshalErr != nil {
+		return nil
+	}
+
+	var strs []string
+	var walk func(node any)
+	walk = func(node any) {
+		switch n := node.(type) {
+		case string:
+			strs = append(strs, n)
+		case []any:
+			for _, item := range n {
+				walk(item)
+			}
+		case map[string]any:
+			for key, value := range n {
+				strs = append(strs, key)
+				walk(value)
+			}
+		}
+	}
+	walk(tree)
+	return strs
+}
+
+// templateStep is a step or DAG task of a template.
+type templateStep struct {
+	withSequence *wfv1.Sequence
+	kind         string
+	name         string
+	path         string
+	withParam    string
+	withItems    []wfv1.Item
+}
+
+// templateSteps returns the steps and tasks of a template.
+func templateSteps(tmpl *wfv1.Template, path string) []templateStep {
+	var steps []templateStep
+	for j, parallel := range tmpl.Steps {
+		for k, step := range parallel.Steps {
+			steps = append(steps, templateStep{
+				withSequence: step.WithSequence,
+				kind:         "step",
+				name:         step.Name,
+				path:         fmt.Sprintf("%s.steps[%d][%d]", path, j, k),
+				withParam:    step.WithParam,
+				withItems:    step.WithItems,
+			})
+		}
+	}
+	if tmpl.DAG != nil {
+		for j, task := range tmpl.DAG.Tasks {
+			steps = append(steps, templateStep{
+				withSequence: task.WithSequence,
+				kind:         "task",
+				name:         task.Name,
+				path:         fmt.Sprintf("%s.dag.tasks[%d]", path, j),
+				withParam:    task.WithParam,
+				withItems:    task.WithItems,
+			})
+		}
+	}
+	return steps
+}
@@ -0,0 +1,221 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnboundedFanOutRule(t *testing.T) {
+	manifest := `
+spec:
+  templates:
+  - name: steps
+    steps:
+    - - name: each
+        template: work
+        withParam: "{{inputs.parameters.items}}"
+      - name: few
+        template: work
+        withItems: [a, b, c]
+  - name: dag
+    dag:
+      tasks:
+      - name: seq
+        template: work
+        withSequence:
+          count: "{{inputs.parameters.n}}"
+      - name: range
+        template: work
+        withSequence:
+          start: "1"
+          end: "500"
+      - name: small
+        template: work
+        withSequence:
+          count: "10"
+  - name: bounded
+    parallelism: 5
+    steps:
+    - - name: each
+        template: work
+        withParam: "{{inputs.parameters.items}}"
+`
+	findings := ruleFindings(t, UnboundedFanOutRule(), manifest)
+	require.Len(t, findings, 3)
+	assert.Equal(t, "spec.templates[0].steps[0][0]", findings[0].Path)
+	assert.Contains(t, findings[0].Message, "withParam")
+	assert.Contains(t, findings[1].Message, "templated withSequence")
+	assert.Contains(t, findings[2].Message, "expands into 500 children")
+
+	assert.Empty(t, ruleFindings(t, UnboundedFanOutRule(), manifest+`
+  parallelism: 10
+`))
+}
+
+func TestUnusedParameterRule(t *testing.T) {
+	manifest := `
+spec:
+  arguments:
+    parameters:
+    - name: used
+    - name: unused
+    - name: bracket
+  templates:
+  - name: main
+    inputs:
+      parameters:
+      - name: message
+      - name: message-2
+      - name: quoted
+    container:
+      image: alpine:3.19
+      args: ["{{inputs.parameters.message}} {{workflow.parameters.used}}", "{{=inputs.parameters['quoted']}}"]
+      env:
+      - name: B
+        value: "{{= workflow.parameters[\"bracket\"] }}"
+  - name: whole
+    inputs:
+      parameters:
+      - name: a
+      - name: b
+    container:
+      image: alpine:3.19
+      args: ["{{inputs.parameters}}"]
+`
+	findings := ruleFindings(t, UnusedParameterRule(), manifest)
+	require.Len(t, findings, 2)
+	assert.Equal(t, "spec.arguments.parameters[1]", findings[0].Path)
+	assert.Contains(t, findings[0].Message, `workflow parameter "unused"`)
+	assert.Equal(t, "spec.templates[0].inputs.parameters[1]", findings[1].Path)
+	assert.Contains(t, findings[1].Message, `"message-2"`)
+
+	assert.Empty(t, ruleFindings(t, UnusedParameterRule(), `
+spec:
+  workflowTemplateRef:
+    name: base
+  arguments:
+    parameters:
+    - name: passed-through
+`))
+}
+
+func TestUnusedTemplateRule(t *testing.T) {
+	manifest := `
+spec:
+  entrypoint: main
+  onExit: cleanup
+  hooks:
+    running:
+      template: notify
+  templates:
+  - name: main
+    dag:
+      tasks:
+      - name: a
+        template: work
+        hooks:
+          exit:
+            template: report
+  - name: work
+    container:
+      image: alpine:3.19
+  - name: report
+    container:
+      image: alpine:3.19
+  - name: cleanup
+    container:
+      image: alpine:3.19
+  - name: notify
+    container:
+      image: alpine:3.19
+  - name: orphan
+    container:
+      image: alpine:3.19
+`
+	findings := ruleFindings(t, UnusedTemplateRule(), manifest)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "orphan", findings[0].Template)
+	assert.Equal(t, "spec.templates[5]", findings[0].Path)
+
+	// Templates of a WorkflowTemplate may be referenced from other workflows
+	doc := parseDocument(t, "WorkflowTemplate", manifest)
+	assert.Empty(t, NewEngine(UnusedTemplateRule()).Lint(doc))
+
+	// Templated references could name any template
+	assert.Empty(t, ruleFindings(t, UnusedTemplateRule(), `
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    steps:
+    - - name: pick
+        template: "{{inputs.parameters.which}}"
+  - name: other
+    container:
+      image: alpine:3.19
+`))
+}
+
+func TestUndefinedInputReferenceRule(t *testing.T) {
+	findings := ruleFindings(t, UndefinedInputReferenceRule(), `
+spec:
+  templates:
+  - name: main
+    inputs:
+      parameters:
+      - name: message
+      artifacts:
+      - name: data
+        path: /tmp/data
+    container:
+      image: alpine:3.19
+      args:
+      - "{{inputs.parameters.message}} {{inputs.parameters.mesage}}"
+      - "{{inputs.artifacts.data.path}} {{inputs.artifacts.missing.path}}"
+      - "{{steps.prev.inputs.parameters.other}}"
+`)
+	require.Len(t, findings, 2)
+	assert.Equal(t, SeverityError, findings[0].Severity)
+	assert.Contains(t, findings[0].Message, "{{inputs.parameters.mesage}}")
+	assert.Equal(t, "spec.templates[0].inputs.parameters", findings[0].Path)
+	assert.Contains(t, findings[1].Message, "{{inputs.artifacts.missing}}")
+}
+
+func TestDAGCycleRule(t *testing.T) {
+	findings := ruleFindings(t, DAGCycleRule(), `
+spec:
+  templates:
+  - name: main
+    dag:
+      tasks:
+      - name: a
+        template: work
+        depends: "c.Succeeded || !b"
+      - name: b
+        template: work
+        dependencies: [a]
+      - name: c
+        template: work
+        depends: "(b.Failed && external)"
+      - name: d
+        template: work
+        dependencies: [a]
+`)
+	require.Len(t, findings, 1)
+	assert.Equal(t, `DAG template "main" has a dependency cycle: a -> c -> b -> a`, findings[0].Message)
+
+	assert.Empty(t, ruleFindings(t, DAGCycleRule(), `
+spec:
+  templates:
+  - name: main
+    dag:
+      tasks:
+      - name: a
+        template: work
+      - name: b
+        template: work
+        depends: a
+`))
+}
@@ -14,6 +14,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/bundle"
 )
 
 const (
@@ -22,6 +23,9 @@ const (
 
 	// maxLogBytes is the maximum total bytes of logs to include in the prompt.
 	maxLogBytes = 50000
+
+	// maxEvents is the maximum number of Kubernetes events to include in the prompt.
+	maxEvents = 30
 )
 
 // WhyDidThisFailPrompt returns the MCP prompt definition for why_did_this_fail.
@@ -96,6 +100,7 @@ type diagnosis struct {
 	Parameters   []parameterInfo
 	FailedNodes  []failedNodeInfo
 	RootCauses   []failedNodeInfo
+	Events       []corev1.Event
 }
 
 // parameterInfo represents a workflow parameter.
@@ -229,9 +234,30 @@ func gatherDiagnostics(ctx context.Context, client argo.ClientInterface, namespa
 		d.RootCauses[i].ErrorPattern = detectErrorPattern(&d.RootCauses[i])
 	}
 
+	d.Events = importedEvents(client, namespace, workflowName)
+
 	return d, nil
 }
 
+// importedEvents returns the most recent Kubernetes events recorded in the
+// bundle of an imported workflow. Live workflows have none: their events are
+// only kept for a short time and are not listed here.
+func importedEvents(client argo.ClientInterface, namespace, workflowName string) []corev1.Event {
+	host, ok := client.(bundle.Host)
+	if !ok || !bundle.IsImportedNamespace(namespace) {
+		return nil
+	}
+	b := host.ImportedBundle(namespace, workflowName)
+	if b == nil {
+		return nil
+	}
+	events := b.Events
+	if len(events) > maxEvents {
+		events = events[len(events)-maxEvents:]
+	}
+	return events
+}
+
 // findFailedNodes extracts all failed or error nodes from a workflow.
 func findFailedNodes(wf *wfv1.Workflow) []failedNodeInfo {
 	failed := make([]failedNodeInfo, 0, len(wf.Status.Nodes))
@@ -594,29 +620,51 @@ func buildPromptText(d *diagnosis) string {
 	sb.WriteString("2. The root cause (trace back to the original failure)\n")
 	sb.WriteString("3. Any suspicious inputs or upstream issues\n")
 	sb.WriteString("4. Recommended fixes\n\n")
+	writeDiagnosis(&sb, d)
+
+	sb.WriteString("\n---\n\n")
+	sb.WriteString("Based on this information, explain the failure and suggest fixes.\n")
+
+	return sb.String()
+}
+
+// DiagnosisSummary gathers the failed nodes, root causes, their logs and
+// detected error patterns of a workflow and renders them as Markdown, the
+// same diagnosis the why_did_this_fail prompt presents.
+func DiagnosisSummary(ctx context.Context, client argo.ClientInterface, namespace, workflowName string) (string, error) {
+	d, err := gatherDiagnostics(ctx, client, namespace, workflowName)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	writeDiagnosis(&sb, d)
+	return sb.String(), nil
+}
 
+// writeDiagnosis writes the workflow overview, failed nodes and error patterns of a diagnosis.
+func writeDiagnosis(sb *strings.Builder, d *diagnosis) {
 	// Workflow overview
-	fmt.Fprintf(&sb, "## Workflow: %s\n", d.WorkflowName)
-	fmt.Fprintf(&sb, "Namespace: %s\n", d.Namespace)
-	fmt.Fprintf(&sb, "Status: %s\n", d.Phase)
+	fmt.Fprintf(sb, "## Workflow: %s\n", d.WorkflowName)
+	fmt.Fprintf(sb, "Namespace: %s\n", d.Namespace)
+	fmt.Fprintf(sb, "Status: %s\n", d.Phase)
 	if d.Message != "" {
-		fmt.Fprintf(&sb, "Message: %s\n", d.Message)
+		fmt.Fprintf(sb, "Message: %s\n", d.Message)
 	}
 	if !d.StartedAt.IsZero() {
-		fmt.Fprintf(&sb, "Started: %s\n", d.StartedAt.Format(time.RFC3339))
+		fmt.Fprintf(sb, "Started: %s\n", d.StartedAt.Format(time.RFC3339))
 	}
 	if !d.FinishedAt.IsZero() {
-		fmt.Fprintf(&sb, "Finished: %s\n", d.FinishedAt.Format(time.RFC3339))
+		fmt.Fprintf(sb, "Finished: %s\n", d.FinishedAt.Format(time.RFC3339))
 	}
 	if d.Duration > 0 {
-		fmt.Fprintf(&sb, "Duration: %s\n", formatDuration(d.Duration))
+		fmt.Fprintf(sb, "Duration: %s\n", formatDuration(d.Duration))
 	}
 
 	// Workflow parameters
 	if len(d.Parameters) > 0 {
 		sb.WriteString("\n### Workflow Parameters\n")
 		for _, p := range d.Parameters {
-			fmt.Fprintf(&sb, "- %s: %s\n", p.Name, truncateString(p.Value, 200))
+			fmt.Fprintf(sb, "- %s: %s\n", p.Name, truncateString(p.Value, 200))
 		}
 	}
 
@@ -625,7 +673,7 @@ func buildPromptText(d *diagnosis) string {
 		sb.WriteString("\n## Root Cause Node(s)\n")
 		sb.WriteString("These are the nodes that appear to be the original source of failure:\n\n")
 		for _, node := range d.RootCauses {
-			writeNodeInfo(&sb, &node, true)
+			writeNodeInfo(sb, &node, true)
 		}
 	}
 
@@ -635,7 +683,15 @@ func buildPromptText(d *diagnosis) string {
 		sb.WriteString("\n## Other Failed Nodes (Cascading Failures)\n")
 		sb.WriteString("These nodes failed as a result of upstream failures:\n\n")
 		for _, node := range otherFailed {
-			writeNodeInfo(&sb, &node, false)
+			writeNodeInfo(sb, &node, false)
+		}
+	}
+
+	if len(d.Events) > 0 {
+		sb.WriteString("\n## Kubernetes Events\n")
+		sb.WriteString("Events of the workflow and its pods recorded when it was exported:\n\n")
+		for i := range d.Events {
+			writeEvent(sb, &d.Events[i])
 		}
 	}
 
@@ -644,17 +700,25 @@ func buildPromptText(d *diagnosis) string {
 		sb.WriteString("\n## Detected Error Patterns\n")
 		for _, node := range d.RootCauses {
 			if node.ErrorPattern != nil {
-				fmt.Fprintf(&sb, "\n### %s\n", node.DisplayName)
-				fmt.Fprintf(&sb, "**Pattern**: %s\n", node.ErrorPattern.Pattern)
-				fmt.Fprintf(&sb, "**Suggestion**: %s\n", node.ErrorPattern.Suggestion)
+				fmt.Fprintf(sb, "\n### %s\n", node.DisplayName)
+				fmt.Fprintf(sb, "**Pattern**: %s\n", node.ErrorPattern.Pattern)
+				fmt.Fprintf(sb, "**Suggestion**: %s\n", node.ErrorPattern.Suggestion)
 			}
 		}
 	}
+}
 
-	sb.WriteString("\n---\n\n")
-	sb.WriteString("Based on this information, explain the failure and suggest fixes.\n")
-
-	return sb.String()
+// writeEvent writes one Kubernetes event as a list item.
+func writeEvent(sb *strings.Builder, event *corev1.Event) {
+	sb.WriteString("- ")
+	if at := bundle.EventTime(*event); !at.IsZero() {
+		fmt.Fprintf(sb, "%s ", at.Format(time.RFC3339))
+	}
+	fmt.Fprintf(sb, "%s %s/%s %s: %s", event.Type, event.InvolvedObject.Kind, event.InvolvedObject.Name, event.Reason, truncateString(event.Message, 300))
+	if event.Count > 1 {
+		fmt.Fprintf(sb, " (x%d)", event.Count)
+	}
+	sb.WriteString("\n")
 }
 
 // writeNodeInfo writes detailed information about a node to the string builder.
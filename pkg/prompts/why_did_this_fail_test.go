@@ -1,6 +1,7 @@
 package prompts
 
 import (
+	"fmt"
 	"testing"
 	"time"
 	"unicode/utf8"
@@ -8,7 +9,11 @@ import (
 	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/bundle"
 )
 
 func TestWhyDidThisFailPrompt(t *testing.T) {
@@ -389,6 +394,14 @@ func TestBuildPromptText(t *testing.T) {
 				IsRootCause: false,
 			},
 		},
+		Events: []corev1.Event{{
+			Type:           corev1.EventTypeWarning,
+			Reason:         "BackOff",
+			Message:        "Back-off pulling image",
+			Count:          3,
+			LastTimestamp:  metav1.Time{Time: endTime},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "test-pipeline-process-1"},
+		}},
 	}
 
 	result := buildPromptText(d)
@@ -420,6 +433,10 @@ func TestBuildPromptText(t *testing.T) {
 	assert.Contains(t, result, "## Other Failed Nodes (Cascading Failures)")
 	assert.Contains(t, result, "### Node: cleanup")
 
+	// Verify events section
+	assert.Contains(t, result, "## Kubernetes Events")
+	assert.Contains(t, result, "- 2025-01-15T10:05:30Z Warning Pod/test-pipeline-process-1 BackOff: Back-off pulling image (x3)")
+
 	// Verify error patterns section
 	assert.Contains(t, result, "## Detected Error Patterns")
 	assert.Contains(t, result, "**Pattern**: Exit code 1")
@@ -429,6 +446,27 @@ func TestBuildPromptText(t *testing.T) {
 	assert.Contains(t, result, "Based on this information, explain the failure and suggest fixes.")
 }
 
+func TestImportedEvents(t *testing.T) {
+	client := bundle.NewClient(mocks.NewMockClient("argo", true), "")
+	events := make([]corev1.Event, maxEvents+5)
+	for i := range events {
+		events[i].Reason = fmt.Sprintf("event-%d", i)
+	}
+	_, err := client.ImportBundle(&bundle.Bundle{
+		Workflow: &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "wf", Namespace: "argo"}},
+		Events:   events,
+	})
+	require.NoError(t, err)
+
+	got := importedEvents(client, "bundle:argo", "wf")
+	require.Len(t, got, maxEvents)
+	assert.Equal(t, "event-5", got[0].Reason)
+
+	// A live workflow of the same name does not get the bundle's events
+	assert.Empty(t, importedEvents(client, "argo", "wf"))
+	assert.Empty(t, importedEvents(mocks.NewMockClient("argo", true), "bundle:argo", "wf"))
+}
+
 func TestDescribeValueFrom(t *testing.T) {
 	tests := []struct {
 		name     string
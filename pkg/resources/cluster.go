@@ -127,6 +127,13 @@ func AllClusterResources() []ClusterResourceDefinition {
 			Description: "List all CronWorkflows in the cluster with schedule and status",
 			MIMEType:    mimeTypeJSON,
 		},
+		{
+			URI:         "argo://cluster/info",
+			Name:        "cluster-info",
+			Title:       "Argo Workflows Installation Info",
+			Description: "Argo version, connection mode, managed namespace, instance ID, links, columns, and whether the archive and SSO are enabled",
+			MIMEType:    mimeTypeJSON,
+		},
 	}
 }
 
@@ -147,6 +154,13 @@ func AllClusterResourceTemplates() []ClusterResourceTemplateDefinition {
 			Description: "Get full details of a specific ClusterWorkflowTemplate",
 			MIMEType:    mimeTypeJSON,
 		},
+		{
+			URITemplate: "argo://workflows/{namespace}/{name}",
+			Name:        "workflow-status",
+			Title:       "Workflow Status",
+			Description: "Current status summary of a Workflow. Subscribe to receive resources/updated notifications as the workflow progresses",
+			MIMEType:    mimeTypeJSON,
+		},
 	}
 }
 
@@ -202,6 +216,8 @@ func clusterResourceHandler(_ string, client argo.ClientInterface) mcp.ResourceH
 			content, err = listClusterWorkflowTemplatesContent(ctx, client)
 		case strings.HasPrefix(requestURI, "argo://cluster/cron-workflows"):
 			content, err = listCronWorkflowsContent(ctx, client, namespace)
+		case strings.HasPrefix(requestURI, "argo://cluster/info"):
+			content, err = clusterInfoContent(ctx, client)
 		default:
 			return nil, mcp.ResourceNotFoundError(requestURI)
 		}
@@ -250,6 +266,14 @@ func clusterResourceTemplateHandler(uriTemplate string, client argo.ClientInterf
 			}
 			content, err = getClusterWorkflowTemplateContent(ctx, client, name)
 
+		case strings.HasPrefix(requestURI, workflowURIPrefix):
+			// Parse namespace and name from URI: argo://workflows/{namespace}/{name}
+			namespace, name, parseErr := parseWorkflowURI(requestURI)
+			if parseErr != nil {
+				return nil, parseErr
+			}
+			content, err = getWorkflowContent(ctx, client, namespace, name)
+
 		default:
 			return nil, mcp.ResourceNotFoundError(requestURI)
 		}
@@ -270,6 +294,21 @@ func clusterResourceTemplateHandler(uriTemplate string, client argo.ClientInterf
 	}
 }
 
+// clusterInfoContent returns information about the Argo Workflows installation as JSON.
+func clusterInfoContent(ctx context.Context, client argo.ClientInterface) (string, error) {
+	info, err := argo.GetClusterInfo(ctx, client)
+	if err != nil {
+		return "", fmt.Errorf("failed to get cluster info: %w", err)
+	}
+
+	data, err := json.MarshalIndent(info, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return string(data), nil
+}
+
 // listWorkflowTemplatesContent returns a JSON list of WorkflowTemplates.
 func listWorkflowTemplatesContent(ctx context.Context, client argo.ClientInterface, namespace string) (string, error) {
 	wftService, err := client.WorkflowTemplateService()
@@ -7,6 +7,8 @@ import (
 
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/clusterworkflowtemplate"
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/cronworkflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/info"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
 	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
@@ -23,7 +25,7 @@ func TestAllClusterResources(t *testing.T) {
 	resources := AllClusterResources()
 
 	// Verify we have all expected resources
-	assert.Len(t, resources, 3)
+	assert.Len(t, resources, 4)
 
 	// Verify each resource has required fields
 	for _, r := range resources {
@@ -43,13 +45,14 @@ func TestAllClusterResources(t *testing.T) {
 	assert.Contains(t, uris, "argo://cluster/workflow-templates")
 	assert.Contains(t, uris, "argo://cluster/cluster-workflow-templates")
 	assert.Contains(t, uris, "argo://cluster/cron-workflows")
+	assert.Contains(t, uris, "argo://cluster/info")
 }
 
 func TestAllClusterResourceTemplates(t *testing.T) {
 	templates := AllClusterResourceTemplates()
 
 	// Verify we have all expected templates
-	assert.Len(t, templates, 2)
+	assert.Len(t, templates, 3)
 
 	// Verify each template has required fields
 	for _, tmpl := range templates {
@@ -68,6 +71,7 @@ func TestAllClusterResourceTemplates(t *testing.T) {
 
 	assert.Contains(t, uriTemplates, "argo://cluster/workflow-templates/{namespace}/{name}")
 	assert.Contains(t, uriTemplates, "argo://cluster/cluster-workflow-templates/{name}")
+	assert.Contains(t, uriTemplates, "argo://workflows/{namespace}/{name}")
 }
 
 func TestRegisterClusterResources(t *testing.T) {
@@ -595,6 +599,27 @@ func TestClusterResourceHandler(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "cluster info",
+			uri:  "argo://cluster/info",
+			setupMock: func(m *mocks.MockClient) {
+				infoService := &mocks.MockInfoServiceClient{}
+				infoService.On("GetInfo", mock.Anything, mock.Anything).Return(&info.InfoResponse{ManagedNamespace: "argo"}, nil)
+				infoService.On("GetVersion", mock.Anything, mock.Anything).Return(&wfv1.Version{Version: "v4.0.5"}, nil)
+				infoService.On("GetUserInfo", mock.Anything, mock.Anything).Return(&info.GetUserInfoResponse{}, nil)
+				m.SetInfoService(infoService)
+
+				archiveService := &mocks.MockArchivedWorkflowServiceClient{}
+				archiveService.On("GetArchivedWorkflow", mock.Anything, mock.Anything).Return(nil, errors.New("getting archived workflows not supported"))
+				m.SetArchivedWorkflowService(archiveService)
+			},
+			wantErr: false,
+			assertions: func(t *testing.T, result *mcp.ReadResourceResult) {
+				require.Len(t, result.Contents, 1)
+				assert.Contains(t, result.Contents[0].Text, `"version": "v4.0.5"`)
+				assert.Contains(t, result.Contents[0].Text, `"managedNamespace": "argo"`)
+			},
+		},
 		{
 			name: "unknown resource",
 			uri:  "argo://cluster/unknown",
@@ -696,6 +721,49 @@ func TestClusterResourceTemplateHandler(t *testing.T) {
 				assert.Contains(t, result.Contents[0].Text, "cluster-tmpl")
 			},
 		},
+		{
+			name: "get workflow status",
+			uri:  "argo://workflows/argo/my-workflow",
+			setupMock: func(m *mocks.MockClient) {
+				mockService := &mocks.MockWorkflowServiceClient{}
+				mockService.On("GetWorkflow", mock.Anything, mock.MatchedBy(func(req *workflow.WorkflowGetRequest) bool {
+					return req.Namespace == "argo" && req.Name == "my-workflow"
+				})).Return(
+					&wfv1.Workflow{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "my-workflow",
+							Namespace: "argo",
+						},
+						Status: wfv1.WorkflowStatus{
+							Phase:    wfv1.WorkflowRunning,
+							Progress: "1/2",
+							Nodes: wfv1.Nodes{
+								"a": {Phase: wfv1.NodeSucceeded},
+								"b": {Phase: wfv1.NodeRunning},
+							},
+						},
+					},
+					nil,
+				)
+				m.SetWorkflowService(mockService)
+			},
+			wantErr: false,
+			assertions: func(t *testing.T, result *mcp.ReadResourceResult) {
+				require.Len(t, result.Contents, 1)
+				assert.Equal(t, mimeTypeJSON, result.Contents[0].MIMEType)
+				assert.Contains(t, result.Contents[0].Text, `"phase": "Running"`)
+				assert.Contains(t, result.Contents[0].Text, `"progress": "1/2"`)
+				assert.Contains(t, result.Contents[0].Text, `"Succeeded": 1`)
+			},
+		},
+		{
+			name: "invalid workflow URI format - missing name",
+			uri:  "argo://workflows/argo/",
+			setupMock: func(_ *mocks.MockClient) {
+				// No setup needed - should fail before calling service
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid workflow template URI format - missing name",
 			uri:  "argo://cluster/workflow-templates/default/",
@@ -0,0 +1,242 @@
+// Package resources implements MCP resource handlers for Argo Workflows.
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// defaultWatchRetryInterval is how long to wait before re-establishing a watch
+// that ended because the stream closed or failed.
+const defaultWatchRetryInterval = 5 * time.Second
+
+// WorkflowSubscriptions manages resources/subscribe requests for
+// argo://workflows/{namespace}/{name}. A single Argo watch is shared by every
+// session subscribed to the same workflow and is stopped once the last
+// subscriber unsubscribes or disconnects.
+type WorkflowSubscriptions struct {
+	client   argo.ClientInterface
+	server   *mcp.Server
+	watches  map[string]*workflowWatch
+	sessions map[*mcp.ServerSession]struct{}
+	// retryInterval is the delay before restarting a watch that ended.
+	retryInterval time.Duration
+	mu            sync.Mutex
+}
+
+// workflowWatch is a running watch for one workflow and the sessions interested in it.
+type workflowWatch struct {
+	cancel      context.CancelFunc
+	subscribers map[*mcp.ServerSession]struct{}
+	done        chan struct{}
+}
+
+// NewWorkflowSubscriptions creates an empty subscription manager.
+// Bind must be called before subscriptions can be accepted.
+func NewWorkflowSubscriptions() *WorkflowSubscriptions {
+	return &WorkflowSubscriptions{
+		watches:       make(map[string]*workflowWatch),
+		sessions:      make(map[*mcp.ServerSession]struct{}),
+		retryInterval: defaultWatchRetryInterval,
+	}
+}
+
+// Bind sets the MCP server used to send resources/updated notifications and the
+// Argo client used to watch workflows.
+func (w *WorkflowSubscriptions) Bind(s *mcp.Server, client argo.ClientInterface) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.server = s
+	w.client = client
+}
+
+// Subscribe handles a resources/subscribe request. It is intended to be used as
+// mcp.ServerOptions.SubscribeHandler.
+func (w *WorkflowSubscriptions) Subscribe(_ context.Context, req *mcp.SubscribeRequest) error {
+	uri := req.Params.URI
+	namespace, name, err := parseWorkflowURI(uri)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.client == nil || w.server == nil {
+		return fmt.Errorf("resource subscriptions are not available")
+	}
+
+	watch, ok := w.watches[uri]
+	if !ok {
+		watch = w.startWatch(uri, namespace, name)
+		w.watches[uri] = watch
+	}
+	watch.subscribers[req.Session] = struct{}{}
+
+	w.trackSession(req.Session)
+
+	return nil
+}
+
+// Unsubscribe handles a resources/unsubscribe request. It is intended to be used
+// as mcp.ServerOptions.UnsubscribeHandler.
+func (w *WorkflowSubscriptions) Unsubscribe(_ context.Context, req *mcp.UnsubscribeRequest) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.removeSubscriber(req.Params.URI, req.Session)
+	return nil
+}
+
+// ActiveWatches returns the number of workflow watches currently running.
+func (w *WorkflowSubscriptions) ActiveWatches() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.watches)
+}
+
+// Close stops all running watches and waits for them to exit.
+func (w *WorkflowSubscriptions) Close() {
+	w.mu.Lock()
+	watches := make([]*workflowWatch, 0, len(w.watches))
+	for uri, watch := range w.watches {
+		watch.cancel()
+		watches = append(watches, watch)
+		delete(w.watches, uri)
+	}
+	w.mu.Unlock()
+
+	for _, watch := range watches {
+		<-watch.done
+	}
+}
+
+// startWatch launches the watch goroutine for a workflow. Must be called with w.mu held.
+func (w *WorkflowSubscriptions) startWatch(uri, namespace, name string) *workflowWatch {
+	// The client context carries the Argo SDK authentication metadata, so the
+	// watch outlives the subscribe request that started it.
+	ctx, cancel := context.WithCancel(w.client.Context())
+	watch := &workflowWatch{
+		cancel:      cancel,
+		subscribers: make(map[*mcp.ServerSession]struct{}),
+		done:        make(chan struct{}),
+	}
+
+	client, server, retryInterval := w.client, w.server, w.retryInterval
+	go func() {
+		defer close(watch.done)
+		runWorkflowWatch(ctx, client, server, retryInterval, uri, namespace, name)
+	}()
+
+	slog.Info("started workflow watch", "uri", uri)
+	return watch
+}
+
+// removeSubscriber drops a session from a watch and stops the watch when it has
+// no subscribers left. Must be called with w.mu held.
+func (w *WorkflowSubscriptions) removeSubscriber(uri string, session *mcp.ServerSession) {
+	watch, ok := w.watches[uri]
+	if !ok {
+		return
+	}
+	delete(watch.subscribers, session)
+	if len(watch.subscribers) == 0 {
+		watch.cancel()
+		delete(w.watches, uri)
+		slog.Info("stopped workflow watch", "uri", uri)
+	}
+}
+
+// trackSession removes a session's subscriptions when it disconnects, since the
+// MCP SDK does not call the unsubscribe handler for closed sessions.
+// Must be called with w.mu held.
+func (w *WorkflowSubscriptions) trackSession(session *mcp.ServerSession) {
+	if session == nil {
+		return
+	}
+	if _, ok := w.sessions[session]; ok {
+		return
+	}
+	w.sessions[session] = struct{}{}
+
+	go func() {
+		_ = session.Wait()
+
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		delete(w.sessions, session)
+		for uri := range w.watches {
+			w.removeSubscriber(uri, session)
+		}
+	}()
+}
+
+// runWorkflowWatch watches a workflow until ctx is cancelled, sending a
+// resources/updated notification whenever the workflow changes.
+func runWorkflowWatch(ctx context.Context, client argo.ClientInterface, server *mcp.Server, retryInterval time.Duration, uri, namespace, name string) {
+	lastVersion := ""
+	for {
+		err := watchWorkflowOnce(ctx, client, namespace, name, func(resourceVersion string) {
+			// Skip duplicate events for a version we already announced
+			if resourceVersion != "" && resourceVersion == lastVersion {
+				return
+			}
+			lastVersion = resourceVersion
+			if notifyErr := server.ResourceUpdated(ctx, &mcp.ResourceUpdatedNotificationParams{URI: uri}); notifyErr != nil {
+				slog.Warn("failed to send resource updated notification", "uri", uri, "error", notifyErr)
+			}
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			slog.Warn("workflow watch failed, retrying", "uri", uri, "error", err, "retryIn", retryInterval)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryInterval):
+		}
+	}
+}
+
+// watchWorkflowOnce runs a single watch stream for a workflow, calling onChange
+// with the resource version of every event received. It returns nil when the
+// stream ends normally.
+func watchWorkflowOnce(ctx context.Context, client argo.ClientInterface, namespace, name string, onChange func(string)) error {
+	stream, err := client.WorkflowService().WatchWorkflows(ctx, &workflow.WatchWorkflowsRequest{
+		Namespace: namespace,
+		ListOptions: &metav1.ListOptions{
+			FieldSelector: fmt.Sprintf("metadata.name=%s", name),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch workflow: %w", err)
+	}
+
+	for {
+		event, recvErr := stream.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			return nil
+		}
+		if recvErr != nil {
+			return fmt.Errorf("failed to receive watch event: %w", recvErr)
+		}
+		if event == nil || event.Object == nil {
+			continue
+		}
+		onChange(event.Object.ResourceVersion)
+	}
+}
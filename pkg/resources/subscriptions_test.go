@@ -0,0 +1,237 @@
+package resources
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+)
+
+// chanWatchStream is a watch stream that delivers events from a channel and
+// blocks until its context is cancelled, like a long-lived Argo watch.
+type chanWatchStream struct {
+	grpc.ClientStream
+	//nolint:containedctx // Mirrors the stream context of a real watch
+	ctx    context.Context
+	events chan *workflow.WorkflowWatchEvent
+}
+
+func (s *chanWatchStream) Recv() (*workflow.WorkflowWatchEvent, error) {
+	select {
+	case <-s.ctx.Done():
+		return nil, s.ctx.Err()
+	case event := <-s.events:
+		return event, nil
+	}
+}
+
+// connectSubscriber connects an MCP client to the server and returns its
+// session along with a channel receiving resources/updated URIs.
+func connectSubscriber(t *testing.T, server *mcp.Server) (*mcp.ClientSession, <-chan string) {
+	t.Helper()
+
+	updates := make(chan string, 10)
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, &mcp.ClientOptions{
+		ResourceUpdatedHandler: func(_ context.Context, req *mcp.ResourceUpdatedNotificationRequest) {
+			updates <- req.Params.URI
+		},
+	})
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	_, err := server.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+
+	session, err := client.Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+
+	return session, updates
+}
+
+func TestParseWorkflowURI(t *testing.T) {
+	tests := []struct {
+		name          string
+		uri           string
+		wantNamespace string
+		wantName      string
+		wantErr       bool
+	}{
+		{
+			name:          "valid URI",
+			uri:           "argo://workflows/argo/my-workflow",
+			wantNamespace: "argo",
+			wantName:      "my-workflow",
+		},
+		{
+			name:    "missing name",
+			uri:     "argo://workflows/argo/",
+			wantErr: true,
+		},
+		{
+			name:    "missing namespace",
+			uri:     "argo://workflows//my-workflow",
+			wantErr: true,
+		},
+		{
+			name:    "extra segments",
+			uri:     "argo://workflows/argo/my-workflow/nodes",
+			wantErr: true,
+		},
+		{
+			name:    "wrong prefix",
+			uri:     "argo://cluster/workflow-templates/argo/my-template",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			namespace, name, err := parseWorkflowURI(tt.uri)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantNamespace, namespace)
+			assert.Equal(t, tt.wantName, name)
+		})
+	}
+}
+
+func TestWorkflowSubscriptions_SubscribeErrors(t *testing.T) {
+	t.Run("invalid URI", func(t *testing.T) {
+		subs := NewWorkflowSubscriptions()
+		subs.Bind(mcp.NewServer(&mcp.Implementation{Name: "test"}, nil), mocks.NewMockClient("default", false))
+
+		err := subs.Subscribe(t.Context(), &mcp.SubscribeRequest{
+			Params: &mcp.SubscribeParams{URI: "argo://schemas/workflow"},
+		})
+		require.Error(t, err)
+		assert.Equal(t, 0, subs.ActiveWatches())
+	})
+
+	t.Run("not bound", func(t *testing.T) {
+		subs := NewWorkflowSubscriptions()
+
+		err := subs.Subscribe(t.Context(), &mcp.SubscribeRequest{
+			Params: &mcp.SubscribeParams{URI: "argo://workflows/default/my-workflow"},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not available")
+	})
+}
+
+func TestWorkflowSubscriptions_SharedWatch(t *testing.T) {
+	const uri = "argo://workflows/argo/my-workflow"
+
+	stream := &chanWatchStream{events: make(chan *workflow.WorkflowWatchEvent, 10)}
+	watchCancelled := make(chan struct{})
+
+	wfService := &mocks.MockWorkflowServiceClient{}
+	wfService.Test(t)
+	wfService.On("WatchWorkflows", mock.Anything, mock.MatchedBy(func(req *workflow.WatchWorkflowsRequest) bool {
+		return req.Namespace == "argo" && req.ListOptions.FieldSelector == "metadata.name=my-workflow"
+	})).Run(func(args mock.Arguments) {
+		ctx, ok := args.Get(0).(context.Context)
+		require.True(t, ok)
+		stream.ctx = ctx
+		go func() {
+			<-ctx.Done()
+			close(watchCancelled)
+		}()
+	}).Return(stream, nil).Once()
+
+	client := mocks.NewMockClient("default", false)
+	client.SetWorkflowService(wfService)
+
+	subs := NewWorkflowSubscriptions()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, &mcp.ServerOptions{
+		SubscribeHandler:   subs.Subscribe,
+		UnsubscribeHandler: subs.Unsubscribe,
+	})
+	RegisterClusterResources(server, client)
+	subs.Bind(server, client)
+	defer subs.Close()
+
+	session1, updates1 := connectSubscriber(t, server)
+	session2, updates2 := connectSubscriber(t, server)
+
+	// Both sessions subscribe to the same workflow and share one watch
+	require.NoError(t, session1.Subscribe(t.Context(), &mcp.SubscribeParams{URI: uri}))
+	require.NoError(t, session2.Subscribe(t.Context(), &mcp.SubscribeParams{URI: uri}))
+	assert.Equal(t, 1, subs.ActiveWatches())
+
+	// A change to the workflow notifies every subscriber
+	stream.events <- &workflow.WorkflowWatchEvent{
+		Type: "MODIFIED",
+		Object: &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-workflow", Namespace: "argo", ResourceVersion: "2"},
+			Status:     wfv1.WorkflowStatus{Phase: wfv1.WorkflowRunning},
+		},
+	}
+	for _, updates := range []<-chan string{updates1, updates2} {
+		select {
+		case got := <-updates:
+			assert.Equal(t, uri, got)
+		case <-time.After(2 * time.Second):
+			t.Fatal("expected resources/updated notification")
+		}
+	}
+
+	// The watch survives while at least one subscriber remains
+	require.NoError(t, session1.Unsubscribe(t.Context(), &mcp.UnsubscribeParams{URI: uri}))
+	assert.Equal(t, 1, subs.ActiveWatches())
+
+	// Disconnecting the last subscriber tears the watch down
+	require.NoError(t, session2.Close())
+	require.Eventually(t, func() bool {
+		return subs.ActiveWatches() == 0
+	}, 2*time.Second, 10*time.Millisecond, "watch should stop after last subscriber leaves")
+
+	select {
+	case <-watchCancelled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("watch context should be cancelled")
+	}
+
+	require.NoError(t, session1.Close())
+	wfService.AssertExpectations(t)
+}
+
+func TestWorkflowSubscriptions_RestartsEndedWatch(t *testing.T) {
+	var watchCalls atomic.Int32
+	wfService := &mocks.MockWorkflowServiceClient{}
+	wfService.Test(t)
+	wfService.On("WatchWorkflows", mock.Anything, mock.Anything).Run(func(_ mock.Arguments) {
+		watchCalls.Add(1)
+	}).Return(mocks.NewMockWatchWorkflowsStream(nil), nil)
+
+	client := mocks.NewMockClient("default", false)
+	client.SetWorkflowService(wfService)
+
+	subs := NewWorkflowSubscriptions()
+	subs.retryInterval = 10 * time.Millisecond
+	subs.Bind(mcp.NewServer(&mcp.Implementation{Name: "test"}, nil), client)
+
+	require.NoError(t, subs.Subscribe(t.Context(), &mcp.SubscribeRequest{
+		Params: &mcp.SubscribeParams{URI: "argo://workflows/default/my-workflow"},
+	}))
+
+	// A stream that closes is re-established after the retry interval
+	require.Eventually(t, func() bool {
+		return watchCalls.Load() >= 2
+	}, 2*time.Second, 10*time.Millisecond)
+
+	subs.Close()
+	assert.Equal(t, 0, subs.ActiveWatches())
+}
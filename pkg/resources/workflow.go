@@ -0,0 +1,102 @@
+// Package resources implements MCP resource handlers for Argo Workflows.
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// workflowURIPrefix is the prefix of the subscribable argo://workflows/{namespace}/{name} resource.
+const workflowURIPrefix = "argo://workflows/"
+
+// WorkflowStatusSummary is the content served for a single workflow resource.
+type WorkflowStatusSummary struct {
+	NodeCounts      map[string]int `json:"nodeCounts,omitempty"`
+	Name            string         `json:"name"`
+	Namespace       string         `json:"namespace"`
+	UID             string         `json:"uid,omitempty"`
+	ResourceVersion string         `json:"resourceVersion,omitempty"`
+	Phase           string         `json:"phase"`
+	Message         string         `json:"message,omitempty"`
+	Progress        string         `json:"progress,omitempty"`
+	StartedAt       string         `json:"startedAt,omitempty"`
+	FinishedAt      string         `json:"finishedAt,omitempty"`
+	Completed       bool           `json:"completed"`
+}
+
+// parseWorkflowURI extracts the namespace and name from an argo://workflows/{namespace}/{name} URI.
+func parseWorkflowURI(uri string) (string, string, error) {
+	if !strings.HasPrefix(uri, workflowURIPrefix) {
+		return "", "", fmt.Errorf("invalid URI format, expected argo://workflows/{namespace}/{name}")
+	}
+	segments := strings.Split(strings.TrimPrefix(uri, workflowURIPrefix), "/")
+	if len(segments) != 2 || segments[0] == "" || segments[1] == "" {
+		return "", "", fmt.Errorf("invalid URI format, expected argo://workflows/{namespace}/{name}")
+	}
+	return segments[0], segments[1], nil
+}
+
+// buildWorkflowStatusSummary creates the status summary for a workflow.
+func buildWorkflowStatusSummary(wf *wfv1.Workflow) *WorkflowStatusSummary {
+	summary := &WorkflowStatusSummary{
+		Name:            wf.Name,
+		Namespace:       wf.Namespace,
+		UID:             string(wf.UID),
+		ResourceVersion: wf.ResourceVersion,
+		Phase:           string(wf.Status.Phase),
+		Message:         wf.Status.Message,
+		Progress:        string(wf.Status.Progress),
+		Completed:       wf.Status.Fulfilled(),
+	}
+
+	if summary.Phase == "" {
+		summary.Phase = string(wfv1.WorkflowPending)
+	}
+
+	if !wf.Status.StartedAt.IsZero() {
+		summary.StartedAt = wf.Status.StartedAt.Format(time.RFC3339)
+	}
+	if !wf.Status.FinishedAt.IsZero() {
+		summary.FinishedAt = wf.Status.FinishedAt.Format(time.RFC3339)
+	}
+
+	// Count nodes by phase
+	if len(wf.Status.Nodes) > 0 {
+		summary.NodeCounts = make(map[string]int)
+		for _, node := range wf.Status.Nodes {
+			phase := string(node.Phase)
+			if phase == "" {
+				phase = string(wfv1.NodePending)
+			}
+			summary.NodeCounts[phase]++
+		}
+	}
+
+	return summary
+}
+
+// getWorkflowContent returns the current status summary of a workflow as JSON.
+func getWorkflowContent(ctx context.Context, client argo.ClientInterface, namespace, name string) (string, error) {
+	wf, err := client.WorkflowService().GetWorkflow(ctx, &workflow.WorkflowGetRequest{
+		Namespace: namespace,
+		Name:      name,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get workflow: %w", err)
+	}
+
+	data, err := json.MarshalIndent(buildWorkflowStatusSummary(wf), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal response: %w", err)
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,730 @@
+package snapshot
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"errors"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/clusterworkflowtemplate"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/cronworkflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/event"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/info"
+	syncpkg "github.com/argoproj/argo-workflows/v4/pkg/apiclient/sync"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowarchive"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/pkg/client/clientset/versioned"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/bundle"
+)
+
+// ErrReadOnly is returned by operations that would modify a snapshot.
+var ErrReadOnly = errors.New("modifying resources is not supported by the offline snapshot backend")
+
+// Version is the Argo version reported by a snapshot client.
+const Version = "snapshot"
+
+// Client serves a snapshot through argo.ClientInterface. It behaves like an
+// Argo Server connection: completed workflows are also served as archived
+// workflows, and the Kubernetes API is not available. Operations that modify
+// resources return ErrReadOnly.
+type Client struct {
+	ctx       context.Context //nolint:containedctx // Returned by Context like the Argo SDK context
+	snapshot  *Snapshot
+	namespace string
+}
+
+// Ensure Client implements ClientInterface.
+var _ argo.ClientInterface = (*Client)(nil)
+
+// NewClient creates a client serving s. namespace is the default namespace of
+// the client.
+func NewClient(ctx context.Context, s *Snapshot, namespace string) *Client {
+	return &Client{ctx: ctx, snapshot: s, namespace: namespace}
+}
+
+// WorkflowService returns a workflow service client serving the snapshot's workflows and logs.
+func (c *Client) WorkflowService() workflow.WorkflowServiceClient {
+	return &workflowService{snapshot: c.snapshot}
+}
+
+// CronWorkflowService returns a cron workflow service client serving the snapshot's CronWorkflows.
+func (c *Client) CronWorkflowService() (cronworkflow.CronWorkflowServiceClient, error) {
+	return &cronWorkflowService{snapshot: c.snapshot}, nil
+}
+
+// WorkflowTemplateService returns a workflow template service client serving
+// the snapshot's WorkflowTemplates.
+func (c *Client) WorkflowTemplateService() (workflowtemplate.WorkflowTemplateServiceClient, error) {
+	return &workflowTemplateService{snapshot: c.snapshot}, nil
+}
+
+// ClusterWorkflowTemplateService returns a cluster workflow template service
+// client serving the snapshot's ClusterWorkflowTemplates.
+func (c *Client) ClusterWorkflowTemplateService() (clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient, error) {
+	return &clusterWorkflowTemplateService{snapshot: c.snapshot}, nil
+}
+
+// ArchivedWorkflowService returns an archived workflow service client serving
+// the snapshot's completed workflows.
+func (c *Client) ArchivedWorkflowService() (workflowarchive.ArchivedWorkflowServiceClient, error) {
+	return &archivedWorkflowService{snapshot: c.snapshot}, nil
+}
+
+// InfoService returns an info service client describing the snapshot backend.
+func (c *Client) InfoService() (info.InfoServiceClient, error) {
+	return &infoService{}, nil
+}
+
+// SyncService returns a sync service client. Snapshots hold no sync limits.
+func (c *Client) SyncService() (syncpkg.SyncServiceClient, error) {
+	return &syncService{}, nil
+}
+
+// OpenArtifact serves artifacts included in export bundles of the snapshot.
+func (c *Client) OpenArtifact(_ context.Context, req *argo.ArtifactRequest) (io.ReadCloser, error) {
+	if data, ok := c.snapshot.ArtifactData[workflowKey(req.Namespace, req.Workflow)][bundle.ArtifactKey(req.NodeID, req.Name)]; ok {
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+	return nil, status.Errorf(codes.NotFound, "artifact %q of node %q is not included in the snapshot", req.Name, req.NodeID)
+}
+
+// EventService returns ErrReadOnly, as events cannot be sent to a snapshot.
+func (c *Client) EventService() (event.EventServiceClient, error) {
+	return nil, ErrReadOnly
+}
+
+// KubernetesClient returns argo.ErrKubernetesClientNotAvailable.
+func (c *Client) KubernetesClient() (kubernetes.Interface, error) {
+	return nil, argo.ErrKubernetesClientNotAvailable
+}
+
+// WorkflowClientset returns argo.ErrKubernetesClientNotAvailable.
+func (c *Client) WorkflowClientset() (versioned.Interface, error) {
+	return nil, argo.ErrKubernetesClientNotAvailable
+}
+
+// ControllerNamespace returns the conventional workflow controller namespace.
+func (c *Client) ControllerNamespace() string {
+	return "argo"
+}
+
+// IsArgoServerMode returns true, as a snapshot is served like an Argo Server.
+func (c *Client) IsArgoServerMode() bool {
+	return true
+}
+
+// DefaultNamespace returns the default namespace configured for this client.
+func (c *Client) DefaultNamespace() string {
+	return c.namespace
+}
+
+// Context returns the context associated with this client.
+func (c *Client) Context() context.Context {
+	return c.ctx
+}
+
+// notFound returns the error the Argo Server returns for a missing resource.
+func notFound(resource, name string) error {
+	return status.Errorf(codes.NotFound, "%s.argoproj.io %q not found", resource, name)
+}
+
+// listFilter matches objects against the namespace and selectors of a list request.
+type listFilter struct {
+	labels    labels.Selector
+	fields    fields.Selector
+	namespace string
+	limit     int64
+}
+
+// newListFilter parses the selectors of a list request. An empty namespace matches all namespaces.
+func newListFilter(namespace string, opts *metav1.ListOptions) (*listFilter, error) {
+	filter := &listFilter{namespace: namespace, labels: labels.Everything(), fields: fields.Everything()}
+	if opts == nil {
+		return filter, nil
+	}
+	if opts.LabelSelector != "" {
+		selector, err := labels.Parse(opts.LabelSelector)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid label selector: %v", err)
+		}
+		filter.labels = selector
+	}
+	if opts.FieldSelector != "" {
+		selector, err := fields.ParseSelector(opts.FieldSelector)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid field selector: %v", err)
+		}
+		filter.fields = selector
+	}
+	filter.limit = opts.Limit
+	return filter, nil
+}
+
+// matches reports whether an object passes the filter.
+func (f *listFilter) matches(meta *metav1.ObjectMeta) bool {
+	if f.namespace != "" && meta.Namespace != f.namespace {
+		return false
+	}
+	if !f.labels.Matches(labels.Set(meta.Labels)) {
+		return false
+	}
+	return f.fields.Matches(fields.Set{"metadata.name": meta.Name, "metadata.namespace": meta.Namespace})
+}
+
+// full reports whether a list of n items reached the limit of the request.
+func (f *listFilter) full(n int) bool {
+	return f.limit > 0 && int64(n) >= f.limit
+}
+
+// newestFirst orders workflows by creation time, newest first, then by name.
+func newestFirst(a, b wfv1.Workflow) int {
+	if c := b.CreationTimestamp.Compare(a.CreationTimestamp.Time); c != 0 {
+		return c
+	}
+	return cmp.Compare(a.Name, b.Name)
+}
+
+// workflows returns copies of the workflows passing filter, newest first.
+func (s *Snapshot) workflows(filter *listFilter, include func(*wfv1.Workflow) bool) wfv1.Workflows {
+	sorted := slices.Clone(s.Workflows)
+	slices.SortStableFunc(sorted, newestFirst)
+
+	var result wfv1.Workflows
+	for i := range sorted {
+		if filter.full(len(result)) {
+			break
+		}
+		if filter.matches(&sorted[i].ObjectMeta) && (include == nil || include(&sorted[i])) {
+			result = append(result, *sorted[i].DeepCopy())
+		}
+	}
+	return result
+}
+
+// workflow returns the workflow with the given namespace and name, or nil.
+func (s *Snapshot) workflow(namespace, name string) *wfv1.Workflow {
+	for i := range s.Workflows {
+		if s.Workflows[i].Namespace == namespace && s.Workflows[i].Name == name {
+			return &s.Workflows[i]
+		}
+	}
+	return nil
+}
+
+// workflowService serves the workflows and logs of a snapshot.
+type workflowService struct {
+	snapshot *Snapshot
+}
+
+// CreateWorkflow implements workflow.WorkflowServiceClient.
+func (s *workflowService) CreateWorkflow(context.Context, *workflow.WorkflowCreateRequest, ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return nil, ErrReadOnly
+}
+
+// GetWorkflow implements workflow.WorkflowServiceClient.
+func (s *workflowService) GetWorkflow(_ context.Context, in *workflow.WorkflowGetRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	wf := s.snapshot.workflow(in.Namespace, in.Name)
+	if wf == nil {
+		return nil, notFound("workflows", in.Name)
+	}
+	return wf.DeepCopy(), nil
+}
+
+// ListWorkflows implements workflow.WorkflowServiceClient.
+func (s *workflowService) ListWorkflows(_ context.Context, in *workflow.WorkflowListRequest, _ ...grpc.CallOption) (*wfv1.WorkflowList, error) {
+	filter, err := newListFilter(in.Namespace, in.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+	return &wfv1.WorkflowList{Items: s.snapshot.workflows(filter, nil)}, nil
+}
+
+// WatchWorkflows implements workflow.WorkflowServiceClient. A snapshot does
+// not change, so the stream returns one ADDED event per matching workflow and ends.
+func (s *workflowService) WatchWorkflows(ctx context.Context, in *workflow.WatchWorkflowsRequest, _ ...grpc.CallOption) (workflow.WorkflowService_WatchWorkflowsClient, error) {
+	filter, err := newListFilter(in.Namespace, in.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+	var events []*workflow.WorkflowWatchEvent
+	for _, wf := range s.snapshot.workflows(filter, nil) {
+		events = append(events, &workflow.WorkflowWatchEvent{Type: "ADDED", Object: &wf})
+	}
+	return &stream[*workflow.WorkflowWatchEvent]{ctx: ctx, items: events}, nil
+}
+
+// WatchEvents implements workflow.WorkflowServiceClient. Snapshots hold no
+// Kubernetes events, so the stream ends immediately.
+func (s *workflowService) WatchEvents(ctx context.Context, _ *workflow.WatchEventsRequest, _ ...grpc.CallOption) (workflow.WorkflowService_WatchEventsClient, error) {
+	return &stream[*corev1.Event]{ctx: ctx}, nil
+}
+
+// DeleteWorkflow implements workflow.WorkflowServiceClient.
+func (s *workflowService) DeleteWorkflow(context.Context, *workflow.WorkflowDeleteRequest, ...grpc.CallOption) (*workflow.WorkflowDeleteResponse, error) {
+	return nil, ErrReadOnly
+}
+
+// RetryWorkflow implements workflow.WorkflowServiceClient.
+func (s *workflowService) RetryWorkflow(context.Context, *workflow.WorkflowRetryRequest, ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return nil, ErrReadOnly
+}
+
+// ResubmitWorkflow implements workflow.WorkflowServiceClient.
+func (s *workflowService) ResubmitWorkflow(context.Context, *workflow.WorkflowResubmitRequest, ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return nil, ErrReadOnly
+}
+
+// ResumeWorkflow implements workflow.WorkflowServiceClient.
+func (s *workflowService) ResumeWorkflow(context.Context, *workflow.WorkflowResumeRequest, ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return nil, ErrReadOnly
+}
+
+// SuspendWorkflow implements workflow.WorkflowServiceClient.
+func (s *workflowService) SuspendWorkflow(context.Context, *workflow.WorkflowSuspendRequest, ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return nil, ErrReadOnly
+}
+
+// TerminateWorkflow implements workflow.WorkflowServiceClient.
+func (s *workflowService) TerminateWorkflow(context.Context, *workflow.WorkflowTerminateRequest, ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return nil, ErrReadOnly
+}
+
+// StopWorkflow implements workflow.WorkflowServiceClient.
+func (s *workflowService) StopWorkflow(context.Context, *workflow.WorkflowStopRequest, ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return nil, ErrReadOnly
+}
+
+// SetWorkflow implements workflow.WorkflowServiceClient.
+func (s *workflowService) SetWorkflow(context.Context, *workflow.WorkflowSetRequest, ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return nil, ErrReadOnly
+}
+
+// LintWorkflow implements workflow.WorkflowServiceClient. Server-side linting
+// needs the workflow controller's validation, which a snapshot cannot provide.
+func (s *workflowService) LintWorkflow(context.Context, *workflow.WorkflowLintRequest, ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return nil, status.Error(codes.Unimplemented, "linting is not supported by the offline snapshot backend; use lint_best_practices")
+}
+
+// SubmitWorkflow implements workflow.WorkflowServiceClient.
+func (s *workflowService) SubmitWorkflow(context.Context, *workflow.WorkflowSubmitRequest, ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return nil, ErrReadOnly
+}
+
+// PodLogs implements workflow.WorkflowServiceClient.
+func (s *workflowService) PodLogs(ctx context.Context, in *workflow.WorkflowLogRequest, _ ...grpc.CallOption) (workflow.WorkflowService_PodLogsClient, error) {
+	entries, err := s.logEntries(in)
+	if err != nil {
+		return nil, err
+	}
+	return &stream[*workflow.LogEntry]{ctx: ctx, items: entries}, nil
+}
+
+// WorkflowLogs implements workflow.WorkflowServiceClient by replaying the pod
+// logs of a workflow, honouring the pod, grep and tail options.
+func (s *workflowService) WorkflowLogs(ctx context.Context, in *workflow.WorkflowLogRequest, _ ...grpc.CallOption) (workflow.WorkflowService_WorkflowLogsClient, error) {
+	entries, err := s.logEntries(in)
+	if err != nil {
+		return nil, err
+	}
+	return &stream[*workflow.LogEntry]{ctx: ctx, items: entries}, nil
+}
+
+// logEntries returns the log lines matching a log request, ordered by pod name.
+func (s *workflowService) logEntries(in *workflow.WorkflowLogRequest) ([]*workflow.LogEntry, error) {
+	if s.snapshot.workflow(in.Namespace, in.Name) == nil {
+		return nil, notFound("workflows", in.Name)
+	}
+
+	var grep *regexp.Regexp
+	if in.Grep != "" {
+		var err error
+		grep, err = regexp.Compile(in.Grep)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to compile %q: %v", in.Grep, err)
+		}
+	}
+	var tail int64
+	if in.LogOptions != nil && in.LogOptions.TailLines != nil {
+		tail = *in.LogOptions.TailLines
+	}
+
+	logs := s.snapshot.PodLogs(in.Namespace, in.Name)
+	var entries []*workflow.LogEntry
+	for _, pod := range slices.Sorted(maps.Keys(logs)) {
+		if in.PodName != "" && pod != in.PodName {
+			continue
+		}
+		var podEntries []*workflow.LogEntry
+		scanner := bufio.NewScanner(bytes.NewReader(logs[pod]))
+		scanner.Buffer(make([]byte, 0, 64*1024), len(logs[pod])+1)
+		for scanner.Scan() {
+			if grep == nil || grep.MatchString(scanner.Text()) {
+				podEntries = append(podEntries, &workflow.LogEntry{PodName: pod, Content: scanner.Text()})
+			}
+		}
+		if tail > 0 && int64(len(podEntries)) > tail {
+			podEntries = podEntries[int64(len(podEntries))-tail:]
+		}
+		entries = append(entries, podEntries...)
+	}
+	return entries, nil
+}
+
+// stream replays items as a gRPC client stream.
+type stream[T any] struct {
+	ctx   context.Context //nolint:containedctx // Required for grpc.ClientStream interface
+	items []T
+}
+
+// Recv returns the next item, or io.EOF once all items were returned.
+func (s *stream[T]) Recv() (T, error) {
+	var zero T
+	if err := s.ctx.Err(); err != nil {
+		return zero, err
+	}
+	if len(s.items) == 0 {
+		return zero, io.EOF
+	}
+	item := s.items[0]
+	s.items = s.items[1:]
+	return item, nil
+}
+
+// Header implements grpc.ClientStream.
+func (s *stream[T]) Header() (metadata.MD, error) { return nil, nil }
+
+// Trailer implements grpc.ClientStream.
+func (s *stream[T]) Trailer() metadata.MD { return nil }
+
+// CloseSend implements grpc.ClientStream.
+func (s *stream[T]) CloseSend() error { return nil }
+
+// Context implements grpc.ClientStream.
+func (s *stream[T]) Context() context.Context { return s.ctx }
+
+// SendMsg implements grpc.ClientStream.
+func (s *stream[T]) SendMsg(any) error { return nil }
+
+// RecvMsg implements grpc.ClientStream.
+func (s *stream[T]) RecvMsg(any) error { return nil }
+
+// archivedWorkflowService serves the completed workflows of a snapshot as archived workflows.
+type archivedWorkflowService struct {
+	snapshot *Snapshot
+}
+
+// completed reports whether a workflow has finished and would be archived.
+func completed(wf *wfv1.Workflow) bool {
+	return wf.Status.Fulfilled()
+}
+
+// ListArchivedWorkflows implements workflowarchive.ArchivedWorkflowServiceClient.
+func (s *archivedWorkflowService) ListArchivedWorkflows(_ context.Context, in *workflowarchive.ListArchivedWorkflowsRequest, _ ...grpc.CallOption) (*wfv1.WorkflowList, error) {
+	filter, err := newListFilter(in.Namespace, in.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+	items := s.snapshot.workflows(filter, func(wf *wfv1.Workflow) bool {
+		return completed(wf) && strings.HasPrefix(wf.Name, in.NamePrefix)
+	})
+	return &wfv1.WorkflowList{Items: items}, nil
+}
+
+// GetArchivedWorkflow implements workflowarchive.ArchivedWorkflowServiceClient.
+// Workflows are looked up by UID, or by namespace and name when no UID is given.
+func (s *archivedWorkflowService) GetArchivedWorkflow(_ context.Context, in *workflowarchive.GetArchivedWorkflowRequest, _ ...grpc.CallOption) (*wfv1.Workflow, error) {
+	for i := range s.snapshot.Workflows {
+		wf := &s.snapshot.Workflows[i]
+		if !completed(wf) {
+			continue
+		}
+		if (in.Uid != "" && string(wf.UID) == in.Uid) || (in.Uid == "" && wf.Namespace == in.Namespace && wf.Name == in.Name) {
+			return wf.DeepCopy(), nil
+		}
+	}
+	return nil, notFound("workflows", cmp.Or(in.Uid, in.Name))
+}
+
+// DeleteArchivedWorkflow implements workflowarchive.ArchivedWorkflowServiceClient.
+func (s *archivedWorkflowService) DeleteArchivedWorkflow(context.Context, *workflowarchive.DeleteArchivedWorkflowRequest, ...grpc.CallOption) (*workflowarchive.ArchivedWorkflowDeletedResponse, error) {
+	return nil, ErrReadOnly
+}
+
+// ListArchivedWorkflowLabelKeys implements workflowarchive.ArchivedWorkflowServiceClient.
+func (s *archivedWorkflowService) ListArchivedWorkflowLabelKeys(_ context.Context, in *workflowarchive.ListArchivedWorkflowLabelKeysRequest, _ ...grpc.CallOption) (*wfv1.LabelKeys, error) {
+	keys := make(map[string]bool)
+	for i := range s.snapshot.Workflows {
+		wf := &s.snapshot.Workflows[i]
+		if completed(wf) && (in.Namespace == "" || wf.Namespace == in.Namespace) {
+			for key := range wf.Labels {
+				keys[key] = true
+			}
+		}
+	}
+	return &wfv1.LabelKeys{Items: slices.Sorted(maps.Keys(keys))}, nil
+}
+
+// ListArchivedWorkflowLabelValues implements workflowarchive.ArchivedWorkflowServiceClient.
+// The label key is taken from the label selector of the request.
+func (s *archivedWorkflowService) ListArchivedWorkflowLabelValues(_ context.Context, in *workflowarchive.ListArchivedWorkflowLabelValuesRequest, _ ...grpc.CallOption) (*wfv1.LabelValues, error) {
+	var key string
+	if in.ListOptions != nil {
+		key = in.ListOptions.LabelSelector
+	}
+	values := make(map[string]bool)
+	for i := range s.snapshot.Workflows {
+		wf := &s.snapshot.Workflows[i]
+		if completed(wf) && (in.Namespace == "" || wf.Namespace == in.Namespace) {
+			if value, ok := wf.Labels[key]; ok {
+				values[value] = true
+			}
+		}
+	}
+	return &wfv1.LabelValues{Items: slices.Sorted(maps.Keys(values))}, nil
+}
+
+// RetryArchivedWorkflow implements workflowarchive.ArchivedWorkflowServiceClient.
+func (s *archivedWorkflowService) RetryArchivedWorkflow(context.Context, *workflowarchive.RetryArchivedWorkflowRequest, ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return nil, ErrReadOnly
+}
+
+// ResubmitArchivedWorkflow implements workflowarchive.ArchivedWorkflowServiceClient.
+func (s *archivedWorkflowService) ResubmitArchivedWorkflow(context.Context, *workflowarchive.ResubmitArchivedWorkflowRequest, ...grpc.CallOption) (*wfv1.Workflow, error) {
+	return nil, ErrReadOnly
+}
+
+// cronWorkflowService serves the CronWorkflows of a snapshot.
+type cronWorkflowService struct {
+	snapshot *Snapshot
+}
+
+// LintCronWorkflow implements cronworkflow.CronWorkflowServiceClient.
+func (s *cronWorkflowService) LintCronWorkflow(context.Context, *cronworkflow.LintCronWorkflowRequest, ...grpc.CallOption) (*wfv1.CronWorkflow, error) {
+	return nil, status.Error(codes.Unimplemented, "linting is not supported by the offline snapshot backend")
+}
+
+// CreateCronWorkflow implements cronworkflow.CronWorkflowServiceClient.
+func (s *cronWorkflowService) CreateCronWorkflow(context.Context, *cronworkflow.CreateCronWorkflowRequest, ...grpc.CallOption) (*wfv1.CronWorkflow, error) {
+	return nil, ErrReadOnly
+}
+
+// ListCronWorkflows implements cronworkflow.CronWorkflowServiceClient.
+func (s *cronWorkflowService) ListCronWorkflows(_ context.Context, in *cronworkflow.ListCronWorkflowsRequest, _ ...grpc.CallOption) (*wfv1.CronWorkflowList, error) {
+	filter, err := newListFilter(in.Namespace, in.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+	list := &wfv1.CronWorkflowList{}
+	for i := range s.snapshot.CronWorkflows {
+		if filter.full(len(list.Items)) {
+			break
+		}
+		if filter.matches(&s.snapshot.CronWorkflows[i].ObjectMeta) {
+			list.Items = append(list.Items, *s.snapshot.CronWorkflows[i].DeepCopy())
+		}
+	}
+	return list, nil
+}
+
+// GetCronWorkflow implements cronworkflow.CronWorkflowServiceClient.
+func (s *cronWorkflowService) GetCronWorkflow(_ context.Context, in *cronworkflow.GetCronWorkflowRequest, _ ...grpc.CallOption) (*wfv1.CronWorkflow, error) {
+	for i := range s.snapshot.CronWorkflows {
+		cwf := &s.snapshot.CronWorkflows[i]
+		if cwf.Namespace == in.Namespace && cwf.Name == in.Name {
+			return cwf.DeepCopy(), nil
+		}
+	}
+	return nil, notFound("cronworkflows", in.Name)
+}
+
+// UpdateCronWorkflow implements cronworkflow.CronWorkflowServiceClient.
+func (s *cronWorkflowService) UpdateCronWorkflow(context.Context, *cronworkflow.UpdateCronWorkflowRequest, ...grpc.CallOption) (*wfv1.CronWorkflow, error) {
+	return nil, ErrReadOnly
+}
+
+// DeleteCronWorkflow implements cronworkflow.CronWorkflowServiceClient.
+func (s *cronWorkflowService) DeleteCronWorkflow(context.Context, *cronworkflow.DeleteCronWorkflowRequest, ...grpc.CallOption) (*cronworkflow.CronWorkflowDeletedResponse, error) {
+	return nil, ErrReadOnly
+}
+
+// ResumeCronWorkflow implements cronworkflow.CronWorkflowServiceClient.
+func (s *cronWorkflowService) ResumeCronWorkflow(context.Context, *cronworkflow.CronWorkflowResumeRequest, ...grpc.CallOption) (*wfv1.CronWorkflow, error) {
+	return nil, ErrReadOnly
+}
+
+// SuspendCronWorkflow implements cronworkflow.CronWorkflowServiceClient.
+func (s *cronWorkflowService) SuspendCronWorkflow(context.Context, *cronworkflow.CronWorkflowSuspendRequest, ...grpc.CallOption) (*wfv1.CronWorkflow, error) {
+	return nil, ErrReadOnly
+}
+
+// workflowTemplateService serves the WorkflowTemplates of a snapshot.
+type workflowTemplateService struct {
+	snapshot *Snapshot
+}
+
+// CreateWorkflowTemplate implements workflowtemplate.WorkflowTemplateServiceClient.
+func (s *workflowTemplateService) CreateWorkflowTemplate(context.Context, *workflowtemplate.WorkflowTemplateCreateRequest, ...grpc.CallOption) (*wfv1.WorkflowTemplate, error) {
+	return nil, ErrReadOnly
+}
+
+// GetWorkflowTemplate implements workflowtemplate.WorkflowTemplateServiceClient.
+func (s *workflowTemplateService) GetWorkflowTemplate(_ context.Context, in *workflowtemplate.WorkflowTemplateGetRequest, _ ...grpc.CallOption) (*wfv1.WorkflowTemplate, error) {
+	for i := range s.snapshot.WorkflowTemplates {
+		wft := &s.snapshot.WorkflowTemplates[i]
+		if wft.Namespace == in.Namespace && wft.Name == in.Name {
+			return wft.DeepCopy(), nil
+		}
+	}
+	return nil, notFound("workflowtemplates", in.Name)
+}
+
+// ListWorkflowTemplates implements workflowtemplate.WorkflowTemplateServiceClient.
+func (s *workflowTemplateService) ListWorkflowTemplates(_ context.Context, in *workflowtemplate.WorkflowTemplateListRequest, _ ...grpc.CallOption) (*wfv1.WorkflowTemplateList, error) {
+	filter, err := newListFilter(in.Namespace, in.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+	list := &wfv1.WorkflowTemplateList{}
+	for i := range s.snapshot.WorkflowTemplates {
+		if filter.full(len(list.Items)) {
+			break
+		}
+		if filter.matches(&s.snapshot.WorkflowTemplates[i].ObjectMeta) {
+			list.Items = append(list.Items, *s.snapshot.WorkflowTemplates[i].DeepCopy())
+		}
+	}
+	return list, nil
+}
+
+// UpdateWorkflowTemplate implements workflowtemplate.WorkflowTemplateServiceClient.
+func (s *workflowTemplateService) UpdateWorkflowTemplate(context.Context, *workflowtemplate.WorkflowTemplateUpdateRequest, ...grpc.CallOption) (*wfv1.WorkflowTemplate, error) {
+	return nil, ErrReadOnly
+}
+
+// DeleteWorkflowTemplate implements workflowtemplate.WorkflowTemplateServiceClient.
+func (s *workflowTemplateService) DeleteWorkflowTemplate(context.Context, *workflowtemplate.WorkflowTemplateDeleteRequest, ...grpc.CallOption) (*workflowtemplate.WorkflowTemplateDeleteResponse, error) {
+	return nil, ErrReadOnly
+}
+
+// LintWorkflowTemplate implements workflowtemplate.WorkflowTemplateServiceClient.
+func (s *workflowTemplateService) LintWorkflowTemplate(context.Context, *workflowtemplate.WorkflowTemplateLintRequest, ...grpc.CallOption) (*wfv1.WorkflowTemplate, error) {
+	return nil, status.Error(codes.Unimplemented, "linting is not supported by the offline snapshot backend")
+}
+
+// clusterWorkflowTemplateService serves the ClusterWorkflowTemplates of a snapshot.
+type clusterWorkflowTemplateService struct {
+	snapshot *Snapshot
+}
+
+// CreateClusterWorkflowTemplate implements clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient.
+func (s *clusterWorkflowTemplateService) CreateClusterWorkflowTemplate(context.Context, *clusterworkflowtemplate.ClusterWorkflowTemplateCreateRequest, ...grpc.CallOption) (*wfv1.ClusterWorkflowTemplate, error) {
+	return nil, ErrReadOnly
+}
+
+// GetClusterWorkflowTemplate implements clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient.
+func (s *clusterWorkflowTemplateService) GetClusterWorkflowTemplate(_ context.Context, in *clusterworkflowtemplate.ClusterWorkflowTemplateGetRequest, _ ...grpc.CallOption) (*wfv1.ClusterWorkflowTemplate, error) {
+	for i := range s.snapshot.ClusterWorkflowTemplates {
+		if s.snapshot.ClusterWorkflowTemplates[i].Name == in.Name {
+			return s.snapshot.ClusterWorkflowTemplates[i].DeepCopy(), nil
+		}
+	}
+	return nil, notFound("clusterworkflowtemplates", in.Name)
+}
+
+// ListClusterWorkflowTemplates implements clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient.
+func (s *clusterWorkflowTemplateService) ListClusterWorkflowTemplates(_ context.Context, in *clusterworkflowtemplate.ClusterWorkflowTemplateListRequest, _ ...grpc.CallOption) (*wfv1.ClusterWorkflowTemplateList, error) {
+	filter, err := newListFilter("", in.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+	list := &wfv1.ClusterWorkflowTemplateList{}
+	for i := range s.snapshot.ClusterWorkflowTemplates {
+		if filter.full(len(list.Items)) {
+			break
+		}
+		if filter.matches(&s.snapshot.ClusterWorkflowTemplates[i].ObjectMeta) {
+			list.Items = append(list.Items, *s.snapshot.ClusterWorkflowTemplates[i].DeepCopy())
+		}
+	}
+	return list, nil
+}
+
+// UpdateClusterWorkflowTemplate implements clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient.
+func (s *clusterWorkflowTemplateService) UpdateClusterWorkflowTemplate(context.Context, *clusterworkflowtemplate.ClusterWorkflowTemplateUpdateRequest, ...grpc.CallOption) (*wfv1.ClusterWorkflowTemplate, error) {
+	return nil, ErrReadOnly
+}
+
+// DeleteClusterWorkflowTemplate implements clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient.
+func (s *clusterWorkflowTemplateService) DeleteClusterWorkflowTemplate(context.Context, *clusterworkflowtemplate.ClusterWorkflowTemplateDeleteRequest, ...grpc.CallOption) (*clusterworkflowtemplate.ClusterWorkflowTemplateDeleteResponse, error) {
+	return nil, ErrReadOnly
+}
+
+// LintClusterWorkflowTemplate implements clusterworkflowtemplate.ClusterWorkflowTemplateServiceClient.
+func (s *clusterWorkflowTemplateService) LintClusterWorkflowTemplate(context.Context, *clusterworkflowtemplate.ClusterWorkflowTemplateLintRequest, ...grpc.CallOption) (*wfv1.ClusterWorkflowTemplate, error) {
+	return nil, status.Error(codes.Unimplemented, "linting is not supported by the offline snapshot backend")
+}
+
+// infoService describes the snapshot backend.
+type infoService struct{}
+
+// GetInfo implements info.InfoServiceClient.
+func (s *infoService) GetInfo(context.Context, *info.GetInfoRequest, ...grpc.CallOption) (*info.InfoResponse, error) {
+	return &info.InfoResponse{}, nil
+}
+
+// GetVersion implements info.InfoServiceClient.
+func (s *infoService) GetVersion(context.Context, *info.GetVersionRequest, ...grpc.CallOption) (*wfv1.Version, error) {
+	return &wfv1.Version{Version: Version}, nil
+}
+
+// GetUserInfo implements info.InfoServiceClient.
+func (s *infoService) GetUserInfo(context.Context, *info.GetUserInfoRequest, ...grpc.CallOption) (*info.GetUserInfoResponse, error) {
+	return &info.GetUserInfoResponse{}, nil
+}
+
+// CollectEvent implements info.InfoServiceClient.
+func (s *infoService) CollectEvent(context.Context, *info.CollectEventRequest, ...grpc.CallOption) (*info.CollectEventResponse, error) {
+	return &info.CollectEventResponse{}, nil
+}
+
+// syncService reports that a snapshot holds no sync limits.
+type syncService struct{}
+
+// CreateSyncLimit implements syncpkg.SyncServiceClient.
+func (s *syncService) CreateSyncLimit(context.Context, *syncpkg.CreateSyncLimitRequest, ...grpc.CallOption) (*syncpkg.SyncLimitResponse, error) {
+	return nil, ErrReadOnly
+}
+
+// GetSyncLimit implements syncpkg.SyncServiceClient.
+func (s *syncService) GetSyncLimit(_ context.Context, in *syncpkg.GetSyncLimitRequest, _ ...grpc.CallOption) (*syncpkg.SyncLimitResponse, error) {
+	return nil, status.Errorf(codes.NotFound, "sync limit %s/%s not found", in.Namespace, in.Key)
+}
+
+// UpdateSyncLimit implements syncpkg.SyncServiceClient.
+func (s *syncService) UpdateSyncLimit(context.Context, *syncpkg.UpdateSyncLimitRequest, ...grpc.CallOption) (*syncpkg.SyncLimitResponse, error) {
+	return nil, ErrReadOnly
+}
+
+// DeleteSyncLimit implements syncpkg.SyncServiceClient.
+func (s *syncService) DeleteSyncLimit(context.Context, *syncpkg.DeleteSyncLimitRequest, ...grpc.CallOption) (*syncpkg.DeleteSyncLimitResponse, error) {
+	return nil, ErrReadOnly
+}
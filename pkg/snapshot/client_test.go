@@ -0,0 +1,269 @@
+package snapshot
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/clusterworkflowtemplate"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/cronworkflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/info"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowarchive"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// newTestClient returns a client serving testSnapshotFiles.
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+	dir := t.TempDir()
+	writeFiles(t, dir, testSnapshotFiles)
+	s, err := Load(dir)
+	require.NoError(t, err)
+	return NewClient(t.Context(), s, "argo")
+}
+
+// readLogs drains a log stream into "pod: line" strings.
+func readLogs(t *testing.T, stream workflow.WorkflowService_WorkflowLogsClient) []string {
+	t.Helper()
+	var lines []string
+	for {
+		entry, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return lines
+		}
+		require.NoError(t, err)
+		lines = append(lines, entry.PodName+": "+entry.Content)
+	}
+}
+
+func TestClient_Mode(t *testing.T) {
+	client := newTestClient(t)
+
+	assert.True(t, client.IsArgoServerMode())
+	assert.Equal(t, "argo", client.DefaultNamespace())
+	assert.Equal(t, "argo", client.ControllerNamespace())
+
+	_, err := client.KubernetesClient()
+	require.ErrorIs(t, err, argo.ErrKubernetesClientNotAvailable)
+	_, err = client.EventService()
+	require.ErrorIs(t, err, ErrReadOnly)
+
+	infoService, err := client.InfoService()
+	require.NoError(t, err)
+	version, err := infoService.GetVersion(t.Context(), &info.GetVersionRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, Version, version.Version)
+}
+
+func TestClient_GetWorkflow(t *testing.T) {
+	client := newTestClient(t)
+
+	wf, err := client.WorkflowService().GetWorkflow(t.Context(), &workflow.WorkflowGetRequest{Namespace: "argo", Name: "failed"})
+	require.NoError(t, err)
+	assert.Equal(t, "Failed", string(wf.Status.Phase))
+	assert.Len(t, wf.Status.Nodes, 1)
+
+	// Callers may modify the result without changing the snapshot
+	wf.Status.Phase = "Running"
+	again, err := client.WorkflowService().GetWorkflow(t.Context(), &workflow.WorkflowGetRequest{Namespace: "argo", Name: "failed"})
+	require.NoError(t, err)
+	assert.Equal(t, "Failed", string(again.Status.Phase))
+
+	_, err = client.WorkflowService().GetWorkflow(t.Context(), &workflow.WorkflowGetRequest{Namespace: "dev", Name: "failed"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestClient_ListWorkflows(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *workflow.WorkflowListRequest
+		want    []string
+	}{
+		{
+			name:    "namespace, newest first",
+			request: &workflow.WorkflowListRequest{Namespace: "argo"},
+			want:    []string{"running", "failed"},
+		},
+		{
+			name:    "all namespaces",
+			request: &workflow.WorkflowListRequest{},
+			want:    []string{"other", "running", "failed"},
+		},
+		{
+			name:    "label selector",
+			request: &workflow.WorkflowListRequest{ListOptions: &metav1.ListOptions{LabelSelector: "team=data"}},
+			want:    []string{"failed"},
+		},
+		{
+			name:    "field selector",
+			request: &workflow.WorkflowListRequest{ListOptions: &metav1.ListOptions{FieldSelector: "metadata.name=other"}},
+			want:    []string{"other"},
+		},
+		{
+			name:    "limit",
+			request: &workflow.WorkflowListRequest{ListOptions: &metav1.ListOptions{Limit: 1}},
+			want:    []string{"other"},
+		},
+	}
+
+	client := newTestClient(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := client.WorkflowService().ListWorkflows(t.Context(), tt.request)
+			require.NoError(t, err)
+			var names []string
+			for _, wf := range list.Items {
+				names = append(names, wf.Name)
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+
+	t.Run("invalid selector", func(t *testing.T) {
+		_, err := client.WorkflowService().ListWorkflows(t.Context(), &workflow.WorkflowListRequest{
+			ListOptions: &metav1.ListOptions{LabelSelector: "team in (("},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func TestClient_WatchWorkflows(t *testing.T) {
+	client := newTestClient(t)
+
+	stream, err := client.WorkflowService().WatchWorkflows(t.Context(), &workflow.WatchWorkflowsRequest{
+		Namespace:   "argo",
+		ListOptions: &metav1.ListOptions{FieldSelector: "metadata.name=failed"},
+	})
+	require.NoError(t, err)
+
+	event, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "ADDED", event.Type)
+	assert.Equal(t, "failed", event.Object.Name)
+
+	_, err = stream.Recv()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestClient_WorkflowLogs(t *testing.T) {
+	client := newTestClient(t)
+
+	t.Run("logs stored without a namespace", func(t *testing.T) {
+		stream, err := client.WorkflowService().WorkflowLogs(t.Context(), &workflow.WorkflowLogRequest{Namespace: "argo", Name: "failed"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"failed-main-1: starting", "failed-main-1: boom"}, readLogs(t, stream))
+	})
+
+	t.Run("grep and tail", func(t *testing.T) {
+		stream, err := client.WorkflowService().WorkflowLogs(t.Context(), &workflow.WorkflowLogRequest{
+			Namespace:  "argo",
+			Name:       "failed",
+			Grep:       "o",
+			LogOptions: &corev1.PodLogOptions{TailLines: ptr.To[int64](1)},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"failed-main-1: boom"}, readLogs(t, stream))
+	})
+
+	t.Run("logs stored with a namespace", func(t *testing.T) {
+		stream, err := client.WorkflowService().WorkflowLogs(t.Context(), &workflow.WorkflowLogRequest{Namespace: "dev", Name: "other"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"other-main-1: done"}, readLogs(t, stream))
+	})
+
+	t.Run("unknown workflow", func(t *testing.T) {
+		_, err := client.WorkflowService().WorkflowLogs(t.Context(), &workflow.WorkflowLogRequest{Namespace: "argo", Name: "missing"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestClient_ArchivedWorkflows(t *testing.T) {
+	client := newTestClient(t)
+	archive, err := client.ArchivedWorkflowService()
+	require.NoError(t, err)
+
+	list, err := archive.ListArchivedWorkflows(t.Context(), &workflowarchive.ListArchivedWorkflowsRequest{Namespace: "argo"})
+	require.NoError(t, err)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "failed", list.Items[0].Name)
+
+	wf, err := archive.GetArchivedWorkflow(t.Context(), &workflowarchive.GetArchivedWorkflowRequest{Uid: "uid-failed"})
+	require.NoError(t, err)
+	assert.Equal(t, "failed", wf.Name)
+
+	_, err = archive.GetArchivedWorkflow(t.Context(), &workflowarchive.GetArchivedWorkflowRequest{Namespace: "argo", Name: "running"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	keys, err := archive.ListArchivedWorkflowLabelKeys(t.Context(), &workflowarchive.ListArchivedWorkflowLabelKeysRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team"}, keys.Items)
+}
+
+func TestClient_Templates(t *testing.T) {
+	client := newTestClient(t)
+
+	wftService, err := client.WorkflowTemplateService()
+	require.NoError(t, err)
+	wft, err := wftService.GetWorkflowTemplate(t.Context(), &workflowtemplate.WorkflowTemplateGetRequest{Namespace: "argo", Name: "lib"})
+	require.NoError(t, err)
+	assert.Equal(t, "lib", wft.Name)
+	wftList, err := wftService.ListWorkflowTemplates(t.Context(), &workflowtemplate.WorkflowTemplateListRequest{Namespace: "dev"})
+	require.NoError(t, err)
+	assert.Empty(t, wftList.Items)
+
+	cwftService, err := client.ClusterWorkflowTemplateService()
+	require.NoError(t, err)
+	cwftList, err := cwftService.ListClusterWorkflowTemplates(t.Context(), &clusterworkflowtemplate.ClusterWorkflowTemplateListRequest{})
+	require.NoError(t, err)
+	require.Len(t, cwftList.Items, 1)
+	_, err = cwftService.GetClusterWorkflowTemplate(t.Context(), &clusterworkflowtemplate.ClusterWorkflowTemplateGetRequest{Name: "missing"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	cronService, err := client.CronWorkflowService()
+	require.NoError(t, err)
+	cwf, err := cronService.GetCronWorkflow(t.Context(), &cronworkflow.GetCronWorkflowRequest{Namespace: "argo", Name: "nightly"})
+	require.NoError(t, err)
+	assert.Equal(t, "nightly", cwf.Name)
+}
+
+func TestClient_ReadOnly(t *testing.T) {
+	client := newTestClient(t)
+
+	_, err := client.WorkflowService().SubmitWorkflow(t.Context(), &workflow.WorkflowSubmitRequest{})
+	require.ErrorIs(t, err, ErrReadOnly)
+	_, err = client.WorkflowService().DeleteWorkflow(t.Context(), &workflow.WorkflowDeleteRequest{Namespace: "argo", Name: "failed"})
+	require.ErrorIs(t, err, ErrReadOnly)
+
+	cronService, err := client.CronWorkflowService()
+	require.NoError(t, err)
+	_, err = cronService.SuspendCronWorkflow(t.Context(), &cronworkflow.CronWorkflowSuspendRequest{})
+	require.ErrorIs(t, err, ErrReadOnly)
+
+	// The snapshot is unchanged
+	_, err = client.WorkflowService().GetWorkflow(t.Context(), &workflow.WorkflowGetRequest{Namespace: "argo", Name: "failed"})
+	require.NoError(t, err)
+}
+
+func TestClient_OpenArtifact(t *testing.T) {
+	client := newTestClient(t)
+	client.snapshot.ArtifactData["argo/failed"] = map[string][]byte{"failed/report": []byte("ok")}
+
+	reader, err := client.OpenArtifact(t.Context(), &argo.ArtifactRequest{Namespace: "argo", Workflow: "failed", NodeID: "failed", Name: "report"})
+	require.NoError(t, err)
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(data))
+
+	_, err = client.OpenArtifact(t.Context(), &argo.ArtifactRequest{Namespace: "argo", Workflow: "failed", NodeID: "failed", Name: "other"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
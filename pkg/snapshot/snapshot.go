@@ -0,0 +1,317 @@
+// Package snapshot implements an offline Argo client backend: a directory of
+// exported Workflow, WorkflowTemplate, ClusterWorkflowTemplate and CronWorkflow
+// manifests, pod logs and export bundles served through argo.ClientInterface,
+// so the read-only tools work without access to a cluster.
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/bundle"
+)
+
+// LogsDir is the directory of a snapshot holding pod logs. Logs are read from
+// LogsDir/<workflow>/<pod>.log or LogsDir/<namespace>/<workflow>/<pod>.log.
+const LogsDir = "logs"
+
+// maxBundleBytes limits the uncompressed size of an export bundle in a snapshot.
+const maxBundleBytes = 1 << 30
+
+// Resource kinds read from manifests.
+const (
+	kindWorkflow                = "Workflow"
+	kindWorkflowTemplate        = "WorkflowTemplate"
+	kindClusterWorkflowTemplate = "ClusterWorkflowTemplate"
+	kindCronWorkflow            = "CronWorkflow"
+)
+
+// Snapshot holds the resources loaded from a snapshot directory.
+type Snapshot struct {
+	// Logs maps workflow keys to the logs of their pods, keyed by pod name.
+	// Logs stored without a namespace use an empty namespace in the key.
+	Logs map[string]map[string][]byte
+
+	// ArtifactData maps workflow keys to the artifacts included in export
+	// bundles, keyed by bundle.ArtifactKey.
+	ArtifactData map[string]map[string][]byte
+
+	// Workflows are the loaded workflows, including their status.
+	Workflows []wfv1.Workflow
+
+	// WorkflowTemplates are the loaded WorkflowTemplates.
+	WorkflowTemplates []wfv1.WorkflowTemplate
+
+	// ClusterWorkflowTemplates are the loaded ClusterWorkflowTemplates.
+	ClusterWorkflowTemplates []wfv1.ClusterWorkflowTemplate
+
+	// CronWorkflows are the loaded CronWorkflows.
+	CronWorkflows []wfv1.CronWorkflow
+}
+
+// workflowKey returns the key of a workflow in Snapshot.Logs and Snapshot.ArtifactData.
+func workflowKey(namespace, name string) string {
+	return namespace + "/" + name
+}
+
+// Load reads a snapshot directory. YAML and JSON files may hold several
+// documents or a List; documents of other kinds are ignored. Gzipped export
+// bundles (.tgz, .tar.gz) contribute their workflow, templates, logs and
+// artifacts. Later files replace resources of the same name read earlier.
+func Load(dir string) (*Snapshot, error) {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("snapshot %s is not a directory", dir)
+	}
+
+	s := &Snapshot{
+		Logs:         make(map[string]map[string][]byte),
+		ArtifactData: make(map[string]map[string][]byte),
+	}
+	err = filepath.WalkDir(dir, func(path string, entry fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		rel, relErr := filepath.Rel(dir, path)
+		if relErr != nil {
+			return relErr
+		}
+		return s.loadFile(path, filepath.ToSlash(rel))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// loadFile adds the contents of one file to the snapshot based on its name.
+func (s *Snapshot) loadFile(path, rel string) error {
+	name := strings.ToLower(rel)
+	switch {
+	case strings.HasPrefix(rel, LogsDir+"/") && strings.HasSuffix(name, ".log"):
+		return s.loadLog(path, rel)
+	case strings.HasSuffix(name, ".tgz"), strings.HasSuffix(name, ".tar.gz"):
+		return s.loadBundle(path, rel)
+	case strings.HasSuffix(name, ".yaml"), strings.HasSuffix(name, ".yml"), strings.HasSuffix(name, ".json"):
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+		if err := s.decodeManifests(data); err != nil {
+			return fmt.Errorf("failed to decode %s: %w", rel, err)
+		}
+	}
+	return nil
+}
+
+// loadLog stores a pod log found under LogsDir.
+func (s *Snapshot) loadLog(path, rel string) error {
+	parts := strings.Split(strings.TrimPrefix(rel, LogsDir+"/"), "/")
+	var namespace, workflow string
+	switch len(parts) {
+	case 2:
+		workflow = parts[0]
+	case 3:
+		namespace, workflow = parts[0], parts[1]
+	default:
+		// Not laid out as <workflow>/<pod>.log or <namespace>/<workflow>/<pod>.log
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", rel, err)
+	}
+	s.addLog(workflowKey(namespace, workflow), strings.TrimSuffix(parts[len(parts)-1], ".log"), data)
+	return nil
+}
+
+// addLog stores the log of a pod of a workflow.
+func (s *Snapshot) addLog(key, pod string, data []byte) {
+	if s.Logs[key] == nil {
+		s.Logs[key] = make(map[string][]byte)
+	}
+	s.Logs[key][pod] = data
+}
+
+// loadBundle adds the contents of an export bundle.
+func (s *Snapshot) loadBundle(path, rel string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", rel, err)
+	}
+	defer f.Close()
+
+	b, err := bundle.Read(f, maxBundleBytes)
+	if err != nil {
+		return fmt.Errorf("failed to read bundle %s: %w", rel, err)
+	}
+
+	s.addWorkflow(*b.Workflow)
+	for _, wft := range b.WorkflowTemplates {
+		s.addWorkflowTemplate(wft)
+	}
+	for _, cwft := range b.ClusterWorkflowTemplates {
+		s.addClusterWorkflowTemplate(cwft)
+	}
+	key := workflowKey(b.Workflow.Namespace, b.Workflow.Name)
+	for pod, data := range b.Logs {
+		s.addLog(key, pod, data)
+	}
+	if len(b.ArtifactData) > 0 {
+		s.ArtifactData[key] = b.ArtifactData
+	}
+	return nil
+}
+
+// typeMeta reads the kind of a manifest.
+type typeMeta struct {
+	Kind  string            `json:"kind"`
+	Items []json.RawMessage `json:"items"`
+}
+
+// decodeManifests adds the resources of a YAML or JSON stream.
+func (s *Snapshot) decodeManifests(data []byte) error {
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if len(raw) == 0 || string(raw) == "null" {
+			continue
+		}
+		if err := s.decodeManifest(raw, ""); err != nil {
+			return err
+		}
+	}
+}
+
+// decodeManifest adds one resource, or the items of a list. defaultKind is
+// used when the manifest has no kind, as for the items of a WorkflowList.
+func (s *Snapshot) decodeManifest(raw json.RawMessage, defaultKind string) error {
+	var meta typeMeta
+	if err := yaml.Unmarshal(raw, &meta); err != nil {
+		return err
+	}
+	kind := meta.Kind
+	if kind == "" {
+		kind = defaultKind
+	}
+
+	if strings.HasSuffix(kind, "List") {
+		for _, item := range meta.Items {
+			if err := s.decodeManifest(item, strings.TrimSuffix(kind, "List")); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch kind {
+	case kindWorkflow:
+		var wf wfv1.Workflow
+		if err := yaml.Unmarshal(raw, &wf); err != nil {
+			return err
+		}
+		s.addWorkflow(wf)
+	case kindWorkflowTemplate:
+		var wft wfv1.WorkflowTemplate
+		if err := yaml.Unmarshal(raw, &wft); err != nil {
+			return err
+		}
+		s.addWorkflowTemplate(wft)
+	case kindClusterWorkflowTemplate:
+		var cwft wfv1.ClusterWorkflowTemplate
+		if err := yaml.Unmarshal(raw, &cwft); err != nil {
+			return err
+		}
+		s.addClusterWorkflowTemplate(cwft)
+	case kindCronWorkflow:
+		var cwf wfv1.CronWorkflow
+		if err := yaml.Unmarshal(raw, &cwf); err != nil {
+			return err
+		}
+		s.addCronWorkflow(cwf)
+	}
+	return nil
+}
+
+// sameObject reports whether two objects have the same namespace and name.
+func sameObject(a, b *metav1.ObjectMeta) bool {
+	return a.Namespace == b.Namespace && a.Name == b.Name
+}
+
+// addWorkflow adds a workflow, replacing one of the same name.
+func (s *Snapshot) addWorkflow(wf wfv1.Workflow) {
+	for i := range s.Workflows {
+		if sameObject(&s.Workflows[i].ObjectMeta, &wf.ObjectMeta) {
+			s.Workflows[i] = wf
+			return
+		}
+	}
+	s.Workflows = append(s.Workflows, wf)
+}
+
+// addWorkflowTemplate adds a WorkflowTemplate, replacing one of the same name.
+func (s *Snapshot) addWorkflowTemplate(wft wfv1.WorkflowTemplate) {
+	for i := range s.WorkflowTemplates {
+		if sameObject(&s.WorkflowTemplates[i].ObjectMeta, &wft.ObjectMeta) {
+			s.WorkflowTemplates[i] = wft
+			return
+		}
+	}
+	s.WorkflowTemplates = append(s.WorkflowTemplates, wft)
+}
+
+// addClusterWorkflowTemplate adds a ClusterWorkflowTemplate, replacing one of the same name.
+func (s *Snapshot) addClusterWorkflowTemplate(cwft wfv1.ClusterWorkflowTemplate) {
+	for i := range s.ClusterWorkflowTemplates {
+		if s.ClusterWorkflowTemplates[i].Name == cwft.Name {
+			s.ClusterWorkflowTemplates[i] = cwft
+			return
+		}
+	}
+	s.ClusterWorkflowTemplates = append(s.ClusterWorkflowTemplates, cwft)
+}
+
+// addCronWorkflow adds a CronWorkflow, replacing one of the same name.
+func (s *Snapshot) addCronWorkflow(cwf wfv1.CronWorkflow) {
+	for i := range s.CronWorkflows {
+		if sameObject(&s.CronWorkflows[i].ObjectMeta, &cwf.ObjectMeta) {
+			s.CronWorkflows[i] = cwf
+			return
+		}
+	}
+	s.CronWorkflows = append(s.CronWorkflows, cwf)
+}
+
+// PodLogs returns the pod logs of a workflow, keyed by pod name. Logs stored
+// without a namespace are used when none were stored for the workflow's namespace.
+func (s *Snapshot) PodLogs(namespace, name string) map[string][]byte {
+	if logs, ok := s.Logs[workflowKey(namespace, name)]; ok {
+		return logs
+	}
+	return s.Logs[workflowKey("", name)]
+}
@@ -0,0 +1,177 @@
+package snapshot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/bundle"
+)
+
+// writeFiles writes files relative to dir, creating parent directories.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+}
+
+// testSnapshotFiles is a snapshot directory with one of each kind of content.
+var testSnapshotFiles = map[string]string{
+	"workflows.yaml": `apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: failed
+  namespace: argo
+  uid: uid-failed
+  labels:
+    team: data
+  creationTimestamp: "2026-01-01T00:00:00Z"
+status:
+  phase: Failed
+  finishedAt: "2026-01-01T00:05:00Z"
+  nodes:
+    failed:
+      id: failed
+      name: failed
+      type: Pod
+      phase: Failed
+---
+apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: running
+  namespace: argo
+  creationTimestamp: "2026-01-02T00:00:00Z"
+status:
+  phase: Running
+`,
+	"list.json": `{"kind": "WorkflowList", "items": [
+  {"metadata": {"name": "other", "namespace": "dev", "creationTimestamp": "2026-01-03T00:00:00Z"}, "status": {"phase": "Succeeded", "finishedAt": "2026-01-03T00:01:00Z"}}
+]}`,
+	"templates/lib.yaml": `apiVersion: argoproj.io/v1alpha1
+kind: WorkflowTemplate
+metadata:
+  name: lib
+  namespace: argo
+---
+apiVersion: argoproj.io/v1alpha1
+kind: ClusterWorkflowTemplate
+metadata:
+  name: shared
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+`,
+	"cron.yml": `apiVersion: argoproj.io/v1alpha1
+kind: CronWorkflow
+metadata:
+  name: nightly
+  namespace: argo
+spec:
+  schedules: ["0 0 * * *"]
+`,
+	"logs/failed/failed-main-1.log":         "starting\nboom\n",
+	"logs/dev/other/other-main-1.log":       "done\n",
+	"logs/too/deeply/nested/pod.log":        "ignored\n",
+	"notes/empty.yaml":                      "",
+	"notes/comment-only.yaml":               "# nothing here\n",
+	"templates/override/lib.yaml":           "kind: WorkflowTemplate\nmetadata:\n  name: lib\n  namespace: argo\n  labels:\n    replaced: \"true\"\n",
+	"logs/running/running-main-1.log":       "working\n",
+	"cron-list.yaml":                        "kind: CronWorkflowList\nitems: []\n",
+	"templates/unrelated/kustomization.yml": "resources: []\n",
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, testSnapshotFiles)
+
+	s, err := Load(dir)
+	require.NoError(t, err)
+
+	var workflows []string
+	for _, wf := range s.Workflows {
+		workflows = append(workflows, wf.Namespace+"/"+wf.Name)
+	}
+	assert.ElementsMatch(t, []string{"argo/failed", "argo/running", "dev/other"}, workflows)
+
+	require.Len(t, s.WorkflowTemplates, 1)
+	assert.Equal(t, "true", s.WorkflowTemplates[0].Labels["replaced"])
+	require.Len(t, s.ClusterWorkflowTemplates, 1)
+	assert.Equal(t, "shared", s.ClusterWorkflowTemplates[0].Name)
+	require.Len(t, s.CronWorkflows, 1)
+	assert.Equal(t, []string{"0 0 * * *"}, s.CronWorkflows[0].Spec.Schedules)
+
+	assert.Equal(t, "starting\nboom\n", string(s.PodLogs("argo", "failed")["failed-main-1"]))
+	assert.Equal(t, "done\n", string(s.PodLogs("dev", "other")["other-main-1"]))
+	assert.Nil(t, s.PodLogs("argo", "other"))
+	assert.Len(t, s.Logs, 3)
+}
+
+func TestLoad_Bundle(t *testing.T) {
+	b := &bundle.Bundle{
+		Workflow: &wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "exported", Namespace: "argo"},
+			Status:     wfv1.WorkflowStatus{Phase: wfv1.WorkflowFailed},
+		},
+		WorkflowTemplates: []wfv1.WorkflowTemplate{{ObjectMeta: metav1.ObjectMeta{Name: "lib", Namespace: "argo"}}},
+		Logs:              map[string][]byte{"exported-main-1": []byte("boom\n")},
+		ArtifactData:      map[string][]byte{bundle.ArtifactKey("exported-1", "report"): []byte("ok")},
+		Manifest:          bundle.Manifest{Namespace: "argo", Workflow: "exported"},
+	}
+	dir := t.TempDir()
+	f, err := os.Create(filepath.Join(dir, "exported.tar.gz"))
+	require.NoError(t, err)
+	require.NoError(t, b.Write(f))
+	require.NoError(t, f.Close())
+
+	s, err := Load(dir)
+	require.NoError(t, err)
+
+	require.Len(t, s.Workflows, 1)
+	assert.Equal(t, "exported", s.Workflows[0].Name)
+	require.Len(t, s.WorkflowTemplates, 1)
+	assert.Equal(t, "boom\n", string(s.PodLogs("argo", "exported")["exported-main-1"]))
+	assert.Equal(t, "ok", string(s.ArtifactData["argo/exported"][bundle.ArtifactKey("exported-1", "report")]))
+}
+
+func TestLoad_Errors(t *testing.T) {
+	t.Run("missing directory", func(t *testing.T) {
+		_, err := Load(filepath.Join(t.TempDir(), "missing"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to open snapshot")
+	})
+
+	t.Run("not a directory", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"wf.yaml": "kind: Workflow\n"})
+		_, err := Load(filepath.Join(dir, "wf.yaml"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is not a directory")
+	})
+
+	t.Run("invalid manifest", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"wf.yaml": "kind: Workflow\nmetadata: [\n"})
+		_, err := Load(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to decode wf.yaml")
+	})
+
+	t.Run("invalid bundle", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{"wf.tgz": "not gzip"})
+		_, err := Load(dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to read bundle wf.tgz")
+	})
+}
@@ -0,0 +1,134 @@
+package toolerror
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+// Detail is the machine-readable description of a tool error.
+type Detail struct {
+	// Category is the stable class of the error.
+	Category Category `json:"category"`
+
+	// Code is a machine-readable code, such as NotFound or ArchiveRequiresArgoServer.
+	Code string `json:"code"`
+
+	// Field is the input field that caused the error, if known.
+	Field string `json:"field,omitempty"`
+
+	// Hint tells the caller how to fix the error.
+	Hint string `json:"hint,omitempty"`
+
+	// Message is the original error message.
+	Message string `json:"message"`
+}
+
+// Payload is the structured content of a tool error result.
+type Payload struct {
+	// Error describes the error.
+	Error Detail `json:"error"`
+}
+
+// NewDetail returns the detail of a classified error.
+func NewDetail(classified *Error) Detail {
+	return Detail{
+		Category: classified.Category,
+		Code:     classified.Code,
+		Field:    classified.Field,
+		Hint:     classified.Hint,
+		Message:  classified.Error(),
+	}
+}
+
+// Middleware returns receiving middleware that classifies failed tool calls.
+// The result keeps IsError, gets the error as a Payload in its structured
+// content, and its text gains the category, code, field and hint after the
+// original message. It must be added before other middleware that inspects
+// tool errors, so that it runs innermost.
+func Middleware() mcp.Middleware {
+	return func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			result, err := next(ctx, method, req)
+			if err != nil {
+				return result, err
+			}
+
+			callResult, ok := result.(*mcp.CallToolResult)
+			if !ok || !callResult.IsError {
+				return result, err
+			}
+
+			Annotate(callResult)
+			return callResult, nil
+		}
+	}
+}
+
+// Annotate classifies the error of a failed tool result in place. The error
+// set with SetError is used when present, otherwise the text content.
+func Annotate(result *mcp.CallToolResult) {
+	if _, done := result.StructuredContent.(*Payload); done {
+		return
+	}
+
+	cause := result.GetError()
+	if cause == nil {
+		cause = textError(resultText(result))
+	}
+	classified := Classify(cause)
+	payload := &Payload{Error: NewDetail(classified)}
+
+	result.StructuredContent = payload
+	result.Content = []mcp.Content{&mcp.TextContent{Text: Format(payload.Error)}}
+}
+
+// Format renders detail as the text content of an error result: the
+// original message first, then the classification and the hint.
+func Format(detail Detail) string {
+	var text strings.Builder
+	text.WriteString(detail.Message)
+	fmt.Fprintf(&text, "\nError category: %s (code: %s", detail.Category, detail.Code)
+	if detail.Field != "" {
+		fmt.Fprintf(&text, ", field: %s", detail.Field)
+	}
+	text.WriteString(")")
+	if detail.Hint != "" {
+		fmt.Fprintf(&text, "\nHint: %s", detail.Hint)
+	}
+	return text.String()
+}
+
+// FromResult returns the error detail of a result annotated by Middleware.
+func FromResult(result mcp.Result) (Detail, bool) {
+	callResult, ok := result.(*mcp.CallToolResult)
+	if !ok || !callResult.IsError {
+		return Detail{}, false
+	}
+	payload, ok := callResult.StructuredContent.(*Payload)
+	if !ok {
+		return Detail{}, false
+	}
+	return payload.Error, true
+}
+
+// resultText concatenates the text content of result.
+func resultText(result *mcp.CallToolResult) string {
+	var text strings.Builder
+	for _, content := range result.Content {
+		if textContent, ok := content.(*mcp.TextContent); ok {
+			text.WriteString(textContent.Text)
+		}
+	}
+	return text.String()
+}
+
+// textError is an error that only has a message.
+type textError string
+
+// Error returns the message.
+func (e textError) Error() string {
+	return string(e)
+}
@@ -0,0 +1,132 @@
+package toolerror
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+type archiveInput struct {
+	Name string `json:"name,omitempty"`
+}
+
+// connectTestServer serves a single "get_archived" tool with the middleware
+// installed and returns a connected client session.
+func connectTestServer(t *testing.T) *mcp.ClientSession {
+	t.Helper()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, nil)
+	server.AddReceivingMiddleware(Middleware())
+	mcp.AddTool(server, &mcp.Tool{Name: "get_archived"}, func(_ context.Context, _ *mcp.CallToolRequest, input archiveInput) (*mcp.CallToolResult, any, error) {
+		if input.Name == "" {
+			return nil, nil, Invalid("name", errors.New("workflow name cannot be empty"))
+		}
+		return nil, nil, fmt.Errorf("failed to get archived workflow service: %w", argo.ErrArchivedWorkflowsNotSupported)
+	})
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	_, err := server.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+
+	client := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil)
+	session, err := client.Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+
+	return session
+}
+
+// structuredError decodes the structured error of a tool result received by a client.
+func structuredError(t *testing.T, result *mcp.CallToolResult) Detail {
+	t.Helper()
+
+	data, err := json.Marshal(result.StructuredContent)
+	require.NoError(t, err)
+	var payload Payload
+	require.NoError(t, json.Unmarshal(data, &payload))
+	return payload.Error
+}
+
+func TestMiddleware(t *testing.T) {
+	session := connectTestServer(t)
+
+	t.Run("unsupported mode", func(t *testing.T) {
+		result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "get_archived", Arguments: map[string]any{"name": "old"}})
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+
+		assert.Equal(t, Detail{
+			Category: CategoryUnsupportedMode,
+			Code:     CodeArchiveRequiresArgoServer,
+			Hint:     hintArchiveRequiresArgoServer,
+			Message:  "failed to get archived workflow service: archived workflows are only supported with Argo Server connection",
+		}, structuredError(t, result))
+
+		require.Len(t, result.Content, 1)
+		text, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, "failed to get archived workflow service: archived workflows are only supported with Argo Server connection\n"+
+			"Error category: unsupported_mode (code: ArchiveRequiresArgoServer)\n"+
+			"Hint: "+hintArchiveRequiresArgoServer, text.Text)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "get_archived", Arguments: map[string]any{"name": 1}})
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+
+		detail := structuredError(t, result)
+		assert.Equal(t, CategoryInvalid, detail.Category)
+		assert.Equal(t, hintArgumentValidationFailures, detail.Hint)
+	})
+
+	t.Run("invalid field", func(t *testing.T) {
+		result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "get_archived", Arguments: map[string]any{}})
+		require.NoError(t, err)
+		require.True(t, result.IsError)
+
+		detail := structuredError(t, result)
+		assert.Equal(t, CategoryInvalid, detail.Category)
+		assert.Equal(t, "name", detail.Field)
+
+		text, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, text.Text, "workflow name cannot be empty\nError category: invalid (code: InvalidArgument, field: name)")
+	})
+}
+
+func TestAnnotate(t *testing.T) {
+	t.Run("classifies text-only error results", func(t *testing.T) {
+		result := &mcp.CallToolResult{IsError: true, Content: []mcp.Content{&mcp.TextContent{Text: "rpc error: code = NotFound desc = not found"}}}
+		Annotate(result)
+
+		detail, ok := FromResult(result)
+		require.True(t, ok)
+		assert.Equal(t, CategoryNotFound, detail.Category)
+		assert.Equal(t, "rpc error: code = NotFound desc = not found", detail.Message)
+	})
+
+	t.Run("is idempotent", func(t *testing.T) {
+		result := &mcp.CallToolResult{}
+		result.SetError(errors.New("conflict"))
+		Annotate(result)
+		Annotate(result)
+
+		text, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, Format(result.StructuredContent.(*Payload).Error), text.Text)
+	})
+
+	t.Run("ignores successful results", func(t *testing.T) {
+		_, ok := FromResult(&mcp.CallToolResult{})
+		assert.False(t, ok)
+	})
+}
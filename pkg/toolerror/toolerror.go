@@ -0,0 +1,307 @@
+// Package toolerror classifies the errors returned by tool handlers into
+// stable categories, so that agents can tell a missing workflow from a
+// missing permission or an unreachable server. Errors from the Argo Server
+// (gRPC status codes), the Kubernetes API (StatusError reasons) and the
+// client's mode checks are mapped to a category, a machine-readable code,
+// the offending input field when known, and a remediation hint.
+package toolerror
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// Category is the stable class of a tool error.
+type Category string
+
+// Error categories. CategoryInternal covers everything that could not be
+// classified.
+const (
+	CategoryNotFound        Category = "not_found"
+	CategoryForbidden       Category = "forbidden"
+	CategoryConflict        Category = "conflict"
+	CategoryInvalid         Category = "invalid"
+	CategoryUnavailable     Category = "unavailable"
+	CategoryUnsupportedMode Category = "unsupported_mode"
+	CategoryInternal        Category = "internal"
+)
+
+// Codes for errors that don't come with a gRPC code or Kubernetes reason.
+const (
+	CodeArchiveRequiresArgoServer  = "ArchiveRequiresArgoServer"
+	CodeEventsRequireArgoServer    = "EventsRequireArgoServer"
+	CodeArtifactsRequireArgoServer = "ArtifactsRequireArgoServer"
+	CodeRequiresKubernetesAPI      = "RequiresKubernetesAPI"
+	CodeDeadlineExceeded           = "DeadlineExceeded"
+	CodeInvalidArgument            = "InvalidArgument"
+	CodeUnknown                    = "Unknown"
+)
+
+// Remediation hints for the errors that have a specific fix.
+const (
+	hintArchiveRequiresArgoServer  = "archived workflows are served by the Argo Server; restart the MCP server with --argo-server (ARGO_SERVER)"
+	hintEventsRequireArgoServer    = "workflow events are received by the Argo Server; restart the MCP server with --argo-server (ARGO_SERVER)"
+	hintArtifactsRequireArgoServer = "artifacts are downloaded through the Argo Server; restart the MCP server with --argo-server (ARGO_SERVER)"
+	hintRequiresKubernetesAPI      = "this operation talks to the Kubernetes API directly; restart the MCP server without --argo-server, using a kubeconfig or in-cluster credentials"
+	hintArgumentValidationFailures = "check the tool's input schema and the value of the named field"
+)
+
+// Error is a classified tool error. It wraps the original error, whose
+// message is kept unchanged.
+type Error struct {
+	// Err is the original error.
+	Err error
+
+	// Category is the stable class of the error.
+	Category Category
+
+	// Code is a machine-readable code, such as a gRPC code name or a
+	// Kubernetes status reason.
+	Code string
+
+	// Field is the input field that caused the error, if known.
+	Field string
+
+	// Hint tells the caller how to fix the error.
+	Hint string
+}
+
+// Error returns the message of the original error.
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return string(e.Category)
+	}
+	return e.Err.Error()
+}
+
+// Unwrap returns the original error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// New returns err classified as category with the given code.
+func New(category Category, code string, err error) *Error {
+	return &Error{Err: err, Category: category, Code: code}
+}
+
+// Invalid returns err classified as an invalid value of the input field.
+func Invalid(field string, err error) *Error {
+	return &Error{Err: err, Category: CategoryInvalid, Code: CodeInvalidArgument, Field: field}
+}
+
+// WithHint returns a copy of e with its remediation hint replaced.
+func (e *Error) WithHint(hint string) *Error {
+	withHint := *e
+	withHint.Hint = hint
+	return &withHint
+}
+
+// Classify returns the classification of err, or nil if err is nil. Errors
+// already classified with New or Invalid keep their category, and get the
+// default hint of the category if they have none.
+func Classify(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	classified := classify(err)
+	if classified.Hint == "" {
+		classified.Hint = DefaultHint(classified.Category)
+	}
+	return classified
+}
+
+// classify maps err to a category, most specific source first.
+func classify(err error) *Error {
+	var toolErr *Error
+	if errors.As(err, &toolErr) {
+		classified := *toolErr
+		classified.Err = err
+		return &classified
+	}
+
+	switch {
+	case errors.Is(err, argo.ErrArchivedWorkflowsNotSupported):
+		return &Error{Err: err, Category: CategoryUnsupportedMode, Code: CodeArchiveRequiresArgoServer, Hint: hintArchiveRequiresArgoServer}
+	case errors.Is(err, argo.ErrEventsNotSupported):
+		return &Error{Err: err, Category: CategoryUnsupportedMode, Code: CodeEventsRequireArgoServer, Hint: hintEventsRequireArgoServer}
+	case errors.Is(err, argo.ErrArtifactsNotSupported):
+		return &Error{Err: err, Category: CategoryUnsupportedMode, Code: CodeArtifactsRequireArgoServer, Hint: hintArtifactsRequireArgoServer}
+	case errors.Is(err, argo.ErrKubernetesClientNotAvailable):
+		return &Error{Err: err, Category: CategoryUnsupportedMode, Code: CodeRequiresKubernetesAPI, Hint: hintRequiresKubernetesAPI}
+	}
+
+	var apiStatus apierrors.APIStatus
+	if errors.As(err, &apiStatus) && apiStatus.Status().Reason != metav1.StatusReasonUnknown {
+		return fromStatus(err, apiStatus.Status())
+	}
+
+	if st, ok := status.FromError(err); ok && st.Code() != codes.OK && st.Code() != codes.Unknown {
+		return &Error{Err: err, Category: fromCode(st.Code()), Code: st.Code().String(), Field: fieldFromMessage(st.Message())}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return &Error{Err: err, Category: CategoryUnavailable, Code: CodeDeadlineExceeded}
+	}
+
+	message := err.Error()
+	category := ClassifyMessage(message)
+	classified := &Error{Err: err, Category: category, Code: CodeUnknown}
+	if category == CategoryInvalid {
+		classified.Code = CodeInvalidArgument
+		classified.Field = fieldFromMessage(message)
+		if strings.HasPrefix(message, `validating "arguments"`) {
+			classified.Hint = hintArgumentValidationFailures
+		}
+	}
+	return classified
+}
+
+// fromCode maps a gRPC status code, as returned by the Argo Server, to a category.
+func fromCode(code codes.Code) Category {
+	switch code {
+	case codes.NotFound:
+		return CategoryNotFound
+	case codes.PermissionDenied, codes.Unauthenticated:
+		return CategoryForbidden
+	case codes.AlreadyExists, codes.Aborted, codes.FailedPrecondition:
+		return CategoryConflict
+	case codes.InvalidArgument, codes.OutOfRange:
+		return CategoryInvalid
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Canceled:
+		return CategoryUnavailable
+	case codes.Unimplemented:
+		return CategoryUnsupportedMode
+	case codes.OK, codes.Unknown, codes.Internal, codes.DataLoss:
+		return CategoryInternal
+	default:
+		return CategoryInternal
+	}
+}
+
+// fromStatus maps a Kubernetes API status to a category. The field is taken
+// from the first cause that names one.
+func fromStatus(err error, st metav1.Status) *Error {
+	classified := &Error{Err: err, Code: string(st.Reason)}
+	switch st.Reason {
+	case metav1.StatusReasonNotFound, metav1.StatusReasonGone:
+		classified.Category = CategoryNotFound
+	case metav1.StatusReasonForbidden, metav1.StatusReasonUnauthorized:
+		classified.Category = CategoryForbidden
+	case metav1.StatusReasonAlreadyExists, metav1.StatusReasonConflict:
+		classified.Category = CategoryConflict
+	case metav1.StatusReasonInvalid, metav1.StatusReasonBadRequest, metav1.StatusReasonRequestEntityTooLarge:
+		classified.Category = CategoryInvalid
+	case metav1.StatusReasonServerTimeout, metav1.StatusReasonTimeout, metav1.StatusReasonTooManyRequests,
+		metav1.StatusReasonServiceUnavailable:
+		classified.Category = CategoryUnavailable
+	case metav1.StatusReasonMethodNotAllowed, metav1.StatusReasonNotAcceptable, metav1.StatusReasonUnsupportedMediaType:
+		classified.Category = CategoryUnsupportedMode
+	default:
+		classified.Category = CategoryInternal
+	}
+
+	if st.Details != nil {
+		for _, cause := range st.Details.Causes {
+			if cause.Field != "" {
+				classified.Field = cause.Field
+				break
+			}
+		}
+	}
+	return classified
+}
+
+// ClassifyMessage classifies an error from its message alone. It is the
+// fallback for errors that lost their gRPC status or Kubernetes reason.
+// Status codes are checked first, then validation phrasing, and only then
+// keywords, so that "invalid timeout" is not mistaken for a timeout.
+func ClassifyMessage(message string) Category {
+	lower := strings.ToLower(message)
+	switch {
+	case strings.Contains(lower, "code = notfound"):
+		return CategoryNotFound
+	case containsAny(lower, "code = permissiondenied", "code = unauthenticated"):
+		return CategoryForbidden
+	case containsAny(lower, "code = alreadyexists", "code = aborted"):
+		return CategoryConflict
+	case containsAny(lower, "code = unavailable", "code = deadlineexceeded"):
+		return CategoryUnavailable
+	case strings.Contains(lower, "code = unimplemented"):
+		return CategoryUnsupportedMode
+	case containsAny(lower, "code = invalidargument", "invalid ", "invalid:", "cannot be empty", "is required", "failed to parse", "must be", `validating "arguments"`):
+		return CategoryInvalid
+	case strings.Contains(lower, "not found"):
+		return CategoryNotFound
+	case containsAny(lower, "forbidden", "unauthorized", "permission denied"):
+		return CategoryForbidden
+	case containsAny(lower, "already exists", "conflict", "the object has been modified"):
+		return CategoryConflict
+	case containsAny(lower, "connection refused", "deadline exceeded", "no such host", "timeout"):
+		return CategoryUnavailable
+	case strings.Contains(lower, "not supported"):
+		return CategoryUnsupportedMode
+	default:
+		return CategoryInternal
+	}
+}
+
+// DefaultHint returns the generic remediation hint of category.
+func DefaultHint(category Category) string {
+	switch category {
+	case CategoryNotFound:
+		return "check the name and namespace; list the resources to find the right one"
+	case CategoryForbidden:
+		return "the server's credentials lack RBAC permission for this operation; grant it or use a namespace the server can access"
+	case CategoryConflict:
+		return "the resource already exists or changed since it was read; fetch it again and retry"
+	case CategoryInvalid:
+		return "fix the input and retry"
+	case CategoryUnavailable:
+		return "the Argo Server or Kubernetes API did not respond; check connectivity and retry"
+	case CategoryUnsupportedMode:
+		return "this operation is not supported by the server's connection mode"
+	case CategoryInternal:
+		return "unexpected error; check the server logs"
+	default:
+		return ""
+	}
+}
+
+// fieldPattern matches the input field named by common validation messages.
+var fieldPattern = regexp.MustCompile(`(?:^|: )(?:[a-z]+ )?([a-zA-Z.]+) (?:cannot be empty|is required|must be)|invalid ([a-zA-Z.]+) (?:format|value)|missing properties: \["([^"]+)"`)
+
+// fieldFromMessage extracts the input field named by common validation
+// messages such as "workflow name cannot be empty", "invalid manifest format"
+// or the SDK's `missing properties: ["name"]`.
+func fieldFromMessage(message string) string {
+	match := fieldPattern.FindStringSubmatch(message)
+	if match == nil {
+		return ""
+	}
+	for _, group := range match[1:] {
+		if group != "" {
+			return group
+		}
+	}
+	return ""
+}
+
+// containsAny reports whether s contains any of the substrings.
+func containsAny(s string, substrings ...string) bool {
+	for _, sub := range substrings {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+package toolerror
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+func TestClassify(t *testing.T) {
+	workflows := schema.GroupResource{Group: "argoproj.io", Resource: "workflows"}
+
+	tests := []struct {
+		err          error
+		name         string
+		wantCategory Category
+		wantCode     string
+		wantField    string
+		wantHint     string
+	}{
+		{
+			name:         "archive without argo server",
+			err:          fmt.Errorf("failed to get archived workflow service: %w", argo.ErrArchivedWorkflowsNotSupported),
+			wantCategory: CategoryUnsupportedMode,
+			wantCode:     CodeArchiveRequiresArgoServer,
+			wantHint:     hintArchiveRequiresArgoServer,
+		},
+		{
+			name:         "events without argo server",
+			err:          fmt.Errorf("failed to get event service: %w", argo.ErrEventsNotSupported),
+			wantCategory: CategoryUnsupportedMode,
+			wantCode:     CodeEventsRequireArgoServer,
+			wantHint:     hintEventsRequireArgoServer,
+		},
+		{
+			name:         "artifacts without argo server",
+			err:          fmt.Errorf("failed to open artifact: %w", argo.ErrArtifactsNotSupported),
+			wantCategory: CategoryUnsupportedMode,
+			wantCode:     CodeArtifactsRequireArgoServer,
+			wantHint:     hintArtifactsRequireArgoServer,
+		},
+		{
+			name:         "kubernetes API in argo server mode",
+			err:          fmt.Errorf("failed to get kubernetes client: %w", argo.ErrKubernetesClientNotAvailable),
+			wantCategory: CategoryUnsupportedMode,
+			wantCode:     CodeRequiresKubernetesAPI,
+			wantHint:     hintRequiresKubernetesAPI,
+		},
+		{
+			name:         "grpc not found",
+			err:          fmt.Errorf("failed to get workflow: %w", status.Error(codes.NotFound, `workflows.argoproj.io "x" not found`)),
+			wantCategory: CategoryNotFound,
+			wantCode:     "NotFound",
+		},
+		{
+			name:         "grpc permission denied",
+			err:          status.Error(codes.PermissionDenied, "denied"),
+			wantCategory: CategoryForbidden,
+			wantCode:     "PermissionDenied",
+		},
+		{
+			name:         "grpc already exists",
+			err:          status.Error(codes.AlreadyExists, "already exists"),
+			wantCategory: CategoryConflict,
+			wantCode:     "AlreadyExists",
+		},
+		{
+			name:         "grpc unavailable",
+			err:          status.Error(codes.Unavailable, "connection refused"),
+			wantCategory: CategoryUnavailable,
+			wantCode:     "Unavailable",
+		},
+		{
+			name:         "grpc invalid argument names the field",
+			err:          status.Error(codes.InvalidArgument, "spec.entrypoint is required"),
+			wantCategory: CategoryInvalid,
+			wantCode:     "InvalidArgument",
+			wantField:    "spec.entrypoint",
+		},
+		{
+			name:         "kubernetes not found",
+			err:          fmt.Errorf("failed to get workflow: %w", apierrors.NewNotFound(workflows, "x")),
+			wantCategory: CategoryNotFound,
+			wantCode:     "NotFound",
+		},
+		{
+			name:         "kubernetes forbidden",
+			err:          apierrors.NewForbidden(workflows, "x", errors.New("no RBAC")),
+			wantCategory: CategoryForbidden,
+			wantCode:     "Forbidden",
+		},
+		{
+			name:         "kubernetes conflict",
+			err:          apierrors.NewConflict(workflows, "x", errors.New("the object has been modified")),
+			wantCategory: CategoryConflict,
+			wantCode:     "Conflict",
+		},
+		{
+			name: "kubernetes invalid names the field",
+			err: apierrors.NewInvalid(schema.GroupKind{Group: "argoproj.io", Kind: "Workflow"}, "x", field.ErrorList{
+				field.Required(field.NewPath("spec", "templates"), ""),
+			}),
+			wantCategory: CategoryInvalid,
+			wantCode:     "Invalid",
+			wantField:    "spec.templates",
+		},
+		{
+			name:         "kubernetes timeout",
+			err:          apierrors.NewTimeoutError("slow", 1),
+			wantCategory: CategoryUnavailable,
+			wantCode:     "Timeout",
+		},
+		{
+			name:         "context deadline",
+			err:          fmt.Errorf("failed to list workflows: %w", context.DeadlineExceeded),
+			wantCategory: CategoryUnavailable,
+			wantCode:     CodeDeadlineExceeded,
+		},
+		{
+			name:         "already classified",
+			err:          fmt.Errorf("submit: %w", Invalid("parameters", errors.New("bad parameter"))),
+			wantCategory: CategoryInvalid,
+			wantCode:     CodeInvalidArgument,
+			wantField:    "parameters",
+		},
+		{
+			name:         "already classified with hint",
+			err:          New(CategoryConflict, "NotSuspended", errors.New("workflow is not suspended")).WithHint("resume only suspended workflows"),
+			wantCategory: CategoryConflict,
+			wantCode:     "NotSuspended",
+			wantHint:     "resume only suspended workflows",
+		},
+		{
+			name:         "message naming a field",
+			err:          errors.New("manifest cannot be empty"),
+			wantCategory: CategoryInvalid,
+			wantCode:     CodeInvalidArgument,
+			wantField:    "manifest",
+		},
+		{
+			name:         "argument validation",
+			err:          errors.New(`validating "arguments": missing properties: ["name"]`),
+			wantCategory: CategoryInvalid,
+			wantCode:     CodeInvalidArgument,
+			wantField:    "name",
+			wantHint:     hintArgumentValidationFailures,
+		},
+		{
+			name:         "unclassified",
+			err:          errors.New("something unexpected"),
+			wantCategory: CategoryInternal,
+			wantCode:     CodeUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			classified := Classify(tt.err)
+			require.NotNil(t, classified)
+
+			assert.Equal(t, tt.wantCategory, classified.Category)
+			assert.Equal(t, tt.wantCode, classified.Code)
+			assert.Equal(t, tt.wantField, classified.Field)
+			assert.Equal(t, tt.err.Error(), classified.Error())
+			require.ErrorIs(t, classified, tt.err)
+
+			wantHint := tt.wantHint
+			if wantHint == "" {
+				wantHint = DefaultHint(tt.wantCategory)
+			}
+			assert.Equal(t, wantHint, classified.Hint)
+		})
+	}
+
+	assert.Nil(t, Classify(nil))
+}
+
+func TestClassifyMessage(t *testing.T) {
+	tests := []struct {
+		message string
+		want    Category
+	}{
+		{"rpc error: code = NotFound desc = workflows.argoproj.io \"x\" not found", CategoryNotFound},
+		{"rpc error: code = PermissionDenied desc = permission denied", CategoryForbidden},
+		{"Operation cannot be fulfilled: the object has been modified", CategoryConflict},
+		{"context deadline exceeded", CategoryUnavailable},
+		{"rpc error: code = Unimplemented desc = unknown method", CategoryUnsupportedMode},
+		{"workflow name cannot be empty", CategoryInvalid},
+		{`invalid timeout format: time: invalid duration "soon"`, CategoryInvalid},
+		{"invalid timeout: must be a positive duration", CategoryInvalid},
+		{"dial tcp 10.0.0.1:2746: i/o timeout", CategoryUnavailable},
+		{`workflows.argoproj.io "invalid-input" not found`, CategoryNotFound},
+		{"something unexpected", CategoryInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyMessage(tt.message))
+		})
+	}
+}
+
+func TestFieldFromMessage(t *testing.T) {
+	assert.Equal(t, "name", fieldFromMessage("workflow name cannot be empty"))
+	assert.Equal(t, "manifest", fieldFromMessage("manifest cannot be empty"))
+	assert.Equal(t, "parameter", fieldFromMessage(`invalid parameter format "x", expected key=value`))
+	assert.Empty(t, fieldFromMessage("something unexpected"))
+}
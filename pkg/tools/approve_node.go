@@ -0,0 +1,243 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	wfutil "github.com/argoproj/argo-workflows/v4/workflow/util"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/utils/ptr"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
+)
+
+// Decisions recorded by approve_node and reject_node.
+const (
+	DecisionApproved = "approved"
+	DecisionRejected = "rejected"
+)
+
+// NodeDecisionInput defines the input parameters for the approve_node and reject_node tools.
+type NodeDecisionInput struct {
+	// OutputParameters are the values for the node's supplied output parameters.
+	OutputParameters map[string]string `json:"outputParameters,omitempty" jsonschema:"Values for the output parameters the node expects (see list_pending_approvals)"`
+
+	// Namespace is the Kubernetes namespace (uses default if not specified).
+	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace (uses default if not specified)"`
+
+	// Name is the workflow name.
+	Name string `json:"name" jsonschema:"Workflow name,required"`
+
+	// NodeID selects the suspend node by ID.
+	NodeID string `json:"nodeId,omitempty" jsonschema:"ID of the suspend node (optional when the workflow has a single waiting node)"`
+
+	// NodeName selects the suspend node by display name.
+	NodeName string `json:"nodeName,omitempty" jsonschema:"Display name of the suspend node"`
+
+	// NodeFieldSelector selects the suspend nodes with a field selector.
+	NodeFieldSelector string `json:"nodeFieldSelector,omitempty" jsonschema:"Field selector for the suspend nodes (e.g. templateName=approve)"`
+
+	// Message is recorded as the node message.
+	Message string `json:"message,omitempty" jsonschema:"Message recorded on the node, such as who decided and why"`
+}
+
+// NodeDecisionOutput defines the output for the approve_node and reject_node tools.
+type NodeDecisionOutput struct {
+	// OutputParameters are the output parameter values that were set.
+	OutputParameters map[string]string `json:"outputParameters,omitempty"`
+
+	// Name is the workflow name.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the workflow.
+	Namespace string `json:"namespace"`
+
+	// Decision is approved or rejected.
+	Decision string `json:"decision"`
+
+	// Phase is the workflow phase after the decision.
+	Phase string `json:"phase"`
+
+	// Nodes lists the display names of the nodes that were decided.
+	Nodes []string `json:"nodes"`
+}
+
+// ApproveNodeTool returns the MCP tool definition for approve_node.
+func ApproveNodeTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "approve_node",
+		Description: "Approve a suspended node of a workflow, like argo node set --phase Succeeded: the node succeeds with the given " +
+			"output parameters and the workflow continues. Every output parameter without a default must be given.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr.To(false),
+		},
+	}
+}
+
+// ApproveNodeHandler returns a handler function for the approve_node tool.
+func ApproveNodeHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, NodeDecisionInput) (*mcp.CallToolResult, *NodeDecisionOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input NodeDecisionInput) (*mcp.CallToolResult, *NodeDecisionOutput, error) {
+		return decideSuspendNode(ctx, client, input, wfv1.NodeSucceeded)
+	}
+}
+
+// decideSuspendNode sets the phase and output parameters of the active
+// suspend nodes of a workflow selected by input.
+func decideSuspendNode(ctx context.Context, client argo.ClientInterface, input NodeDecisionInput, phase wfv1.NodePhase) (*mcp.CallToolResult, *NodeDecisionOutput, error) {
+	name, err := ValidateName(input.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	namespace := ResolveNamespace(input.Namespace, client)
+
+	selector, err := buildNodeFieldSelector(input.NodeFieldSelector, input.NodeName, "")
+	if err != nil {
+		return nil, nil, err
+	}
+	if nodeID := strings.TrimSpace(input.NodeID); nodeID != "" {
+		selector = strings.TrimPrefix(selector+",id="+fields.EscapeValue(nodeID), ",")
+	}
+
+	wfService := client.WorkflowService()
+	wf, err := wfService.GetWorkflow(ctx, &workflow.WorkflowGetRequest{Name: name, Namespace: namespace})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get workflow: %w", err)
+	}
+
+	nodes, selector, err := selectSuspendNodes(wf, selector)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, node := range nodes {
+		if err = checkSuppliedOutputs(node, input.OutputParameters, phase == wfv1.NodeSucceeded); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	req := &workflow.WorkflowSetRequest{
+		Name:              name,
+		Namespace:         namespace,
+		NodeFieldSelector: selector,
+		Message:           input.Message,
+		Phase:             string(phase),
+	}
+	if len(input.OutputParameters) > 0 {
+		outputs, marshalErr := json.Marshal(input.OutputParameters)
+		if marshalErr != nil {
+			return nil, nil, fmt.Errorf("failed to encode output parameters: %w", marshalErr)
+		}
+		req.OutputParameters = string(outputs)
+	}
+
+	updated, err := wfService.SetWorkflow(ctx, req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to set node %s of workflow %q: %w", selector, name, err)
+	}
+
+	output := &NodeDecisionOutput{
+		OutputParameters: input.OutputParameters,
+		Name:             name,
+		Namespace:        namespace,
+		Decision:         DecisionApproved,
+		Phase:            string(updated.Status.Phase),
+	}
+	if phase != wfv1.NodeSucceeded {
+		output.Decision = DecisionRejected
+	}
+	for _, node := range nodes {
+		output.Nodes = append(output.Nodes, node.DisplayName)
+	}
+
+	resultText := fmt.Sprintf("Node(s) %s of workflow %q in namespace %q %s. Workflow phase: %s",
+		strings.Join(output.Nodes, ", "), name, namespace, output.Decision, output.Phase)
+	for _, key := range slices.Sorted(maps.Keys(input.OutputParameters)) {
+		resultText += fmt.Sprintf("\n  %s=%s", key, input.OutputParameters[key])
+	}
+	return TextResult(resultText), output, nil
+}
+
+// selectSuspendNodes returns the active suspend nodes of wf matching
+// selector. Without a selector the workflow must have a single waiting node,
+// which is then selected by ID.
+func selectSuspendNodes(wf *wfv1.Workflow, selector string) ([]wfv1.NodeStatus, string, error) {
+	waiting := pendingApprovals(wf, time.Now())
+	if len(waiting) == 0 {
+		return nil, "", toolerror.New(toolerror.CategoryConflict, "NoPendingApproval",
+			fmt.Errorf("workflow %q has no suspended nodes waiting for approval", wf.Name)).
+			WithHint("use list_pending_approvals to find workflows waiting for approval")
+	}
+
+	names := make([]string, 0, len(waiting))
+	for _, approval := range waiting {
+		names = append(names, fmt.Sprintf("%s (id %s)", approval.NodeName, approval.NodeID))
+	}
+
+	if selector == "" {
+		if len(waiting) > 1 {
+			return nil, "", toolerror.Invalid("nodeName", fmt.Errorf("workflow %q has %d suspended nodes waiting, choose one of: %s",
+				wf.Name, len(waiting), strings.Join(names, ", ")))
+		}
+		selector = "id=" + fields.EscapeValue(waiting[0].NodeID)
+	}
+
+	parsed, err := fields.ParseSelector(selector)
+	if err != nil {
+		return nil, "", toolerror.Invalid("nodeFieldSelector", fmt.Errorf("invalid node field selector %q: %w", selector, err))
+	}
+
+	var nodes []wfv1.NodeStatus
+	for _, approval := range waiting {
+		node := wf.Status.Nodes[approval.NodeID]
+		if wfutil.SelectorMatchesNode(parsed, node) {
+			nodes = append(nodes, node)
+		}
+	}
+	if len(nodes) == 0 {
+		return nil, "", toolerror.New(toolerror.CategoryNotFound, "NodeNotFound",
+			fmt.Errorf("no suspended node of workflow %q matches %s; waiting: %s", wf.Name, selector, strings.Join(names, ", "))).
+			WithHint("select a waiting node by nodeId or nodeName")
+	}
+	return nodes, selector, nil
+}
+
+// checkSuppliedOutputs checks values against the supplied output parameters
+// of a suspend node. Approvals must give every parameter without a default.
+func checkSuppliedOutputs(node wfv1.NodeStatus, values map[string]string, requireAll bool) error {
+	expected := expectedOutputs(node)
+	byName := make(map[string]ExpectedOutput, len(expected))
+	var missing []string
+	for _, output := range expected {
+		byName[output.Name] = output
+		if _, ok := values[output.Name]; requireAll && output.Required && !ok {
+			missing = append(missing, output.Name)
+		}
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		output, ok := byName[key]
+		if !ok {
+			return toolerror.Invalid("outputParameters", fmt.Errorf("node %q does not expect output parameter %q; expected: %s",
+				node.DisplayName, key, strings.Join(slices.Sorted(maps.Keys(byName)), ", ")))
+		}
+		if len(output.Enum) > 0 && !slices.Contains(output.Enum, values[key]) {
+			return toolerror.Invalid("outputParameters", fmt.Errorf("invalid value %q for output parameter %q, must be one of: %s",
+				values[key], key, strings.Join(output.Enum, ", ")))
+		}
+	}
+
+	if len(missing) > 0 {
+		return toolerror.Invalid("outputParameters", fmt.Errorf("missing required output parameter(s) %s of node %q",
+			strings.Join(missing, ", "), node.DisplayName))
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
+)
+
+func TestApproveNodeTool(t *testing.T) {
+	tool := ApproveNodeTool()
+
+	assert.Equal(t, "approve_node", tool.Name)
+	assert.Contains(t, tool.Description, "output parameters")
+	require.NotNil(t, tool.Annotations)
+	require.NotNil(t, tool.Annotations.DestructiveHint)
+	assert.False(t, *tool.Annotations.DestructiveHint)
+}
+
+func TestApproveNodeHandler(t *testing.T) {
+	tests := []struct {
+		setupMock func(*mocks.MockWorkflowServiceClient)
+		validate  func(*testing.T, *NodeDecisionOutput, *mcp.CallToolResult)
+		name      string
+		wantErr   string
+		wantField string
+		input     NodeDecisionInput
+	}{
+		{
+			name:  "success - approves the only waiting node with outputs",
+			input: NodeDecisionInput{Name: "deploy", OutputParameters: map[string]string{"decision": "yes"}, Message: "ok by alice"},
+			setupMock: func(m *mocks.MockWorkflowServiceClient) {
+				m.On("GetWorkflow", mock.Anything, &workflow.WorkflowGetRequest{Name: "deploy", Namespace: "argo"}).
+					Return(approvalWorkflow("deploy", time.Minute), nil)
+				m.On("SetWorkflow", mock.Anything, &workflow.WorkflowSetRequest{
+					Name:              "deploy",
+					Namespace:         "argo",
+					NodeFieldSelector: "id=deploy-2",
+					Message:           "ok by alice",
+					Phase:             "Succeeded",
+					OutputParameters:  `{"decision":"yes"}`,
+				}).Return(&wfv1.Workflow{Status: wfv1.WorkflowStatus{Phase: wfv1.WorkflowRunning}}, nil)
+			},
+			validate: func(t *testing.T, output *NodeDecisionOutput, result *mcp.CallToolResult) {
+				assert.Equal(t, DecisionApproved, output.Decision)
+				assert.Equal(t, []string{"approve"}, output.Nodes)
+				assert.Equal(t, "Running", output.Phase)
+				text, ok := result.Content[0].(*mcp.TextContent)
+				require.True(t, ok)
+				assert.Equal(t, "Node(s) approve of workflow \"deploy\" in namespace \"argo\" approved. Workflow phase: Running\n  decision=yes", text.Text)
+			},
+		},
+		{
+			name:  "success - selects by node name",
+			input: NodeDecisionInput{Name: "deploy", NodeName: "approve", OutputParameters: map[string]string{"decision": "no", "comment": "later"}},
+			setupMock: func(m *mocks.MockWorkflowServiceClient) {
+				m.On("GetWorkflow", mock.Anything, mock.Anything).Return(approvalWorkflow("deploy", time.Minute), nil)
+				m.On("SetWorkflow", mock.Anything, mock.MatchedBy(func(req *workflow.WorkflowSetRequest) bool {
+					return req.NodeFieldSelector == "displayName=approve" && req.OutputParameters == `{"comment":"later","decision":"no"}`
+				})).Return(&wfv1.Workflow{}, nil)
+			},
+			validate: func(t *testing.T, output *NodeDecisionOutput, _ *mcp.CallToolResult) {
+				assert.Equal(t, map[string]string{"decision": "no", "comment": "later"}, output.OutputParameters)
+			},
+		},
+		{
+			name:  "error - missing required output",
+			input: NodeDecisionInput{Name: "deploy", NodeID: "deploy-2"},
+			setupMock: func(m *mocks.MockWorkflowServiceClient) {
+				m.On("GetWorkflow", mock.Anything, mock.Anything).Return(approvalWorkflow("deploy", time.Minute), nil)
+			},
+			wantErr:   `missing required output parameter(s) decision of node "approve"`,
+			wantField: "outputParameters",
+		},
+		{
+			name:  "error - value outside enum",
+			input: NodeDecisionInput{Name: "deploy", OutputParameters: map[string]string{"decision": "maybe"}},
+			setupMock: func(m *mocks.MockWorkflowServiceClient) {
+				m.On("GetWorkflow", mock.Anything, mock.Anything).Return(approvalWorkflow("deploy", time.Minute), nil)
+			},
+			wantErr:   `invalid value "maybe" for output parameter "decision", must be one of: yes, no`,
+			wantField: "outputParameters",
+		},
+		{
+			name:  "error - unexpected output",
+			input: NodeDecisionInput{Name: "deploy", OutputParameters: map[string]string{"decision": "yes", "fixed": "y"}},
+			setupMock: func(m *mocks.MockWorkflowServiceClient) {
+				m.On("GetWorkflow", mock.Anything, mock.Anything).Return(approvalWorkflow("deploy", time.Minute), nil)
+			},
+			wantErr:   `node "approve" does not expect output parameter "fixed"; expected: comment, decision`,
+			wantField: "outputParameters",
+		},
+		{
+			name:  "error - no matching node",
+			input: NodeDecisionInput{Name: "deploy", NodeName: "build"},
+			setupMock: func(m *mocks.MockWorkflowServiceClient) {
+				m.On("GetWorkflow", mock.Anything, mock.Anything).Return(approvalWorkflow("deploy", time.Minute), nil)
+			},
+			wantErr: `no suspended node of workflow "deploy" matches displayName=build; waiting: approve (id deploy-2)`,
+		},
+		{
+			name:  "error - nothing waiting",
+			input: NodeDecisionInput{Name: "done"},
+			setupMock: func(m *mocks.MockWorkflowServiceClient) {
+				m.On("GetWorkflow", mock.Anything, mock.Anything).Return(&wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "done"}}, nil)
+			},
+			wantErr: `workflow "done" has no suspended nodes waiting for approval`,
+		},
+		{
+			name:      "error - empty name",
+			input:     NodeDecisionInput{},
+			setupMock: func(_ *mocks.MockWorkflowServiceClient) {},
+			wantErr:   "workflow name cannot be empty",
+			wantField: "name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := newMockClient(t, "argo", true)
+			mockService := newMockWorkflowService(t)
+			mockClient.SetWorkflowService(mockService)
+			tt.setupMock(mockService)
+			defer mockService.AssertExpectations(t)
+
+			result, output, err := ApproveNodeHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, tt.input)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				assert.Equal(t, tt.wantField, toolerror.Classify(err).Field)
+				mockService.AssertNotCalled(t, "SetWorkflow", mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			tt.validate(t, output, result)
+		})
+	}
+}
+
+func TestSelectSuspendNodes(t *testing.T) {
+	wf := approvalWorkflow("deploy", time.Minute)
+	second := wf.Status.Nodes["deploy-2"]
+	second.ID = "deploy-3"
+	second.DisplayName = "approve-again"
+	wf.Status.Nodes["deploy-3"] = second
+
+	_, _, err := selectSuspendNodes(wf, "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has 2 suspended nodes waiting, choose one of: approve (id deploy-2), approve-again (id deploy-3)")
+
+	nodes, selector, err := selectSuspendNodes(wf, "templateName=approval")
+	require.NoError(t, err)
+	assert.Equal(t, "templateName=approval", selector)
+	assert.Len(t, nodes, 2)
+}
@@ -177,11 +177,23 @@ type RetryArchivedWorkflowInput struct {
 	// Namespace to retry in (uses original if not specified).
 	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace to retry in (uses original if not specified)"`
 
-	// NodeFieldSelector selects specific nodes to retry.
-	NodeFieldSelector string `json:"nodeFieldSelector,omitempty" jsonschema:"Selector to filter nodes to retry"`
+	// NodeFieldSelector selects successful nodes to restart.
+	NodeFieldSelector string `json:"nodeFieldSelector,omitempty" jsonschema:"Field selector for successful nodes to restart with restartSuccessful (e.g. displayName=build or templateName=test)"`
+
+	// NodeName restarts the node with this display name and its subtree.
+	NodeName string `json:"nodeName,omitempty" jsonschema:"Restart the node with this display name and its subtree, even if it succeeded (implies restartSuccessful)"`
+
+	// TemplateName restarts the nodes running this template and their subtrees.
+	TemplateName string `json:"templateName,omitempty" jsonschema:"Restart the nodes running this template and their subtrees, even if they succeeded (implies restartSuccessful)"`
+
+	// Parameters are parameter overrides in key=value format.
+	Parameters []string `json:"parameters,omitempty" jsonschema:"Parameter overrides in key=value format"`
 
 	// RestartSuccessful restarts successful nodes as well.
-	RestartSuccessful bool `json:"restartSuccessful,omitempty" jsonschema:"Restart successful nodes as well"`
+	RestartSuccessful bool `json:"restartSuccessful,omitempty" jsonschema:"Also restart successful nodes matching nodeFieldSelector"`
+
+	// Preview only reports what the retry would do.
+	Preview bool `json:"preview,omitempty" jsonschema:"Only show which nodes would be re-run, reset and kept, without retrying"`
 }
 
 // RetryArchivedWorkflowOutput defines the output for the retry_archived_workflow tool.
@@ -197,13 +209,17 @@ type RetryArchivedWorkflowOutput struct {
 
 	// Message describes the result.
 	Message string `json:"message"`
+
+	// Preview describes what the retry would do. Set only for previews.
+	Preview *RetryPreview `json:"preview,omitempty"`
 }
 
 // RetryArchivedWorkflowTool returns the MCP tool definition for retry_archived_workflow.
 func RetryArchivedWorkflowTool() *mcp.Tool {
 	return &mcp.Tool{
-		Name:        "retry_archived_workflow",
-		Description: "Retry a failed archived workflow. Requires Argo Server connection (not available in direct K8s mode).",
+		Name: "retry_archived_workflow",
+		Description: "Retry a failed archived workflow, with the same node selection, parameter override and preview options as retry_workflow. " +
+			"Requires Argo Server connection (not available in direct K8s mode).",
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: ptr.To(true),
 		},
@@ -218,18 +234,47 @@ func RetryArchivedWorkflowHandler(client argo.ClientInterface) func(context.Cont
 			return nil, nil, fmt.Errorf("workflow UID cannot be empty")
 		}
 
+		selector, err := buildNodeFieldSelector(input.NodeFieldSelector, input.NodeName, input.TemplateName)
+		if err != nil {
+			return nil, nil, err
+		}
+		restartSuccessful := input.RestartSuccessful || strings.TrimSpace(input.NodeName) != "" || strings.TrimSpace(input.TemplateName) != ""
+		if err = validateParameters(input.Parameters); err != nil {
+			return nil, nil, err
+		}
+
 		// Get the archived workflow service client
 		archiveService, err := client.ArchivedWorkflowService()
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to get archived workflow service: %w", err)
 		}
 
+		if input.Preview {
+			archived, getErr := archiveService.GetArchivedWorkflow(ctx, &workflowarchive.GetArchivedWorkflowRequest{Uid: input.UID})
+			if getErr != nil {
+				return nil, nil, fmt.Errorf("failed to get archived workflow: %w", getErr)
+			}
+			preview, previewErr := previewRetry(ctx, archived, restartSuccessful, selector, input.Parameters)
+			if previewErr != nil {
+				return nil, nil, previewErr
+			}
+			output := &RetryArchivedWorkflowOutput{
+				Name:      archived.Name,
+				Namespace: archived.Namespace,
+				UID:       string(archived.UID),
+				Message:   formatRetryPreview(archived.Name, preview),
+				Preview:   preview,
+			}
+			return TextResult(output.Message), output, nil
+		}
+
 		// Retry the archived workflow
 		wf, err := archiveService.RetryArchivedWorkflow(ctx, &workflowarchive.RetryArchivedWorkflowRequest{
 			Uid:               input.UID,
 			Namespace:         input.Namespace,
-			RestartSuccessful: input.RestartSuccessful,
-			NodeFieldSelector: input.NodeFieldSelector,
+			RestartSuccessful: restartSuccessful,
+			NodeFieldSelector: selector,
+			Parameters:        input.Parameters,
 		})
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to retry archived workflow: %w", err)
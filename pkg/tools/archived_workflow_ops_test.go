@@ -507,6 +507,48 @@ func TestRetryArchivedWorkflowHandler(t *testing.T) {
 				assert.Equal(t, "my-workflow-selector", output.Name)
 			},
 		},
+		{
+			name: "success - preview with parameter overrides",
+			input: RetryArchivedWorkflowInput{
+				UID:        "etl-uid",
+				NodeName:   "extract",
+				Parameters: []string{"date=2026-10-02"},
+				Preview:    true,
+			},
+			setupMock: func(m *mocks.MockArchivedWorkflowServiceClient) {
+				m.On("GetArchivedWorkflow", mock.Anything, &workflowarchive.GetArchivedWorkflowRequest{Uid: "etl-uid"}).
+					Return(failedDAGWorkflow(), nil)
+			},
+			wantErr: false,
+			validate: func(t *testing.T, output *RetryArchivedWorkflowOutput, _ *mcp.CallToolResult) {
+				assert.Equal(t, "etl", output.Name)
+				require.NotNil(t, output.Preview)
+				assert.Equal(t, []string{"extract", "transform"}, previewNames(output.Preview.Rerun))
+				assert.Empty(t, output.Preview.UnknownParameters)
+				assert.Contains(t, output.Message, "nothing was changed")
+			},
+		},
+		{
+			name: "success - retry with parameter overrides",
+			input: RetryArchivedWorkflowInput{
+				UID:          "etl-uid",
+				TemplateName: "transform",
+				Parameters:   []string{"date=2026-10-02"},
+			},
+			setupMock: func(m *mocks.MockArchivedWorkflowServiceClient) {
+				m.On("RetryArchivedWorkflow", mock.Anything, &workflowarchive.RetryArchivedWorkflowRequest{
+					Uid:               "etl-uid",
+					RestartSuccessful: true,
+					NodeFieldSelector: "templateName=transform",
+					Parameters:        []string{"date=2026-10-02"},
+				}).Return(&wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "etl", Namespace: "argo"}}, nil)
+			},
+			wantErr: false,
+			validate: func(t *testing.T, output *RetryArchivedWorkflowOutput, _ *mcp.CallToolResult) {
+				assert.Equal(t, "etl", output.Name)
+				assert.Nil(t, output.Preview)
+			},
+		},
 		{
 			name: "error - empty UID",
 			input: RetryArchivedWorkflowInput{
@@ -0,0 +1,282 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/cronworkflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/workflow/common"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/utils/ptr"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
+)
+
+// Limits for the number of runs a single backfill may submit.
+const (
+	defaultBackfillMaxRuns = 10
+	maxBackfillRuns        = 100
+)
+
+// defaultBackfillTimeout bounds how long a sequential backfill waits for runs to complete.
+const defaultBackfillTimeout = 30 * time.Minute
+
+// Backfill run statuses.
+const (
+	BackfillStatusPlanned      = "planned"       // dry run, would be submitted
+	BackfillStatusSubmitted    = "submitted"     // submitted by this backfill
+	BackfillStatusExists       = "exists"        // a workflow for this time already exists
+	BackfillStatusNotSubmitted = "not_submitted" // skipped because the backfill timed out
+)
+
+// BackfillCronWorkflowInput defines the input parameters for the backfill_cron_workflow tool.
+type BackfillCronWorkflowInput struct {
+	// Namespace is the Kubernetes namespace (uses default if not specified).
+	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace (uses default if not specified)"`
+
+	// Name is the CronWorkflow name.
+	Name string `json:"name" jsonschema:"CronWorkflow name,required"`
+
+	// Start is the beginning of the time range to backfill.
+	Start string `json:"start" jsonschema:"RFC3339 start of the time range to backfill (inclusive),required"`
+
+	// End is the end of the time range to backfill. Must not be in the future.
+	End string `json:"end" jsonschema:"RFC3339 end of the time range to backfill (inclusive, not in the future),required"`
+
+	// MaxRuns is a safety cap on the number of runs submitted.
+	MaxRuns int `json:"maxRuns,omitempty" jsonschema:"Maximum number of runs to submit (default 10, max 100). The backfill is refused if the range contains more runs"`
+
+	// Timeout bounds how long runs are awaited when they must not overlap.
+	Timeout string `json:"timeout,omitempty" jsonschema:"Maximum time to wait for runs to complete when concurrencyPolicy is Forbid or Replace (e.g. 30m, default 30m)"`
+
+	// DryRun lists the runs that would be submitted without submitting them.
+	DryRun bool `json:"dryRun,omitempty" jsonschema:"List the runs that would be submitted without submitting them"`
+}
+
+// BackfillRun is a single run of a backfill.
+type BackfillRun struct {
+	ScheduledTime string `json:"scheduledTime"`
+	Workflow      string `json:"workflow"`
+	Status        string `json:"status"`
+	Phase         string `json:"phase,omitempty"`
+}
+
+// BackfillCronWorkflowOutput defines the output for the backfill_cron_workflow tool.
+type BackfillCronWorkflowOutput struct {
+	Name              string        `json:"name"`
+	Namespace         string        `json:"namespace"`
+	ConcurrencyPolicy string        `json:"concurrencyPolicy"`
+	Runs              []BackfillRun `json:"runs"`
+	Submitted         int           `json:"submitted"`
+	Skipped           int           `json:"skipped"`
+	Sequential        bool          `json:"sequential"`
+	DryRun            bool          `json:"dryRun,omitempty"`
+	TimedOut          bool          `json:"timedOut,omitempty"`
+}
+
+// BackfillCronWorkflowTool returns the MCP tool definition for backfill_cron_workflow.
+func BackfillCronWorkflowTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "backfill_cron_workflow",
+		Description: "Submit the runs a CronWorkflow would have started in a past time range, each with its scheduled time injected. " +
+			"Runs are named like the controller names them, so times that already ran are skipped. " +
+			"With concurrencyPolicy Forbid or Replace, runs are submitted one at a time and each is awaited before the next. " +
+			"Use dryRun to list the runs first.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr.To(false),
+		},
+	}
+}
+
+// BackfillCronWorkflowHandler returns a handler function for the backfill_cron_workflow tool.
+func BackfillCronWorkflowHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, BackfillCronWorkflowInput) (*mcp.CallToolResult, *BackfillCronWorkflowOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input BackfillCronWorkflowInput) (*mcp.CallToolResult, *BackfillCronWorkflowOutput, error) {
+		name, err := ValidateName(input.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		start, err := time.Parse(time.RFC3339, input.Start)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid start time, expected RFC3339: %w", err)
+		}
+		end, err := time.Parse(time.RFC3339, input.End)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid end time, expected RFC3339: %w", err)
+		}
+		if end.Before(start) {
+			return nil, nil, fmt.Errorf("end must not be before start")
+		}
+		if end.After(time.Now()) {
+			return nil, nil, fmt.Errorf("end must not be in the future, future runs are scheduled by the controller")
+		}
+
+		maxRuns := input.MaxRuns
+		if maxRuns <= 0 {
+			maxRuns = defaultBackfillMaxRuns
+		}
+		if maxRuns > maxBackfillRuns {
+			return nil, nil, fmt.Errorf("maxRuns must be at most %d", maxBackfillRuns)
+		}
+
+		timeout := defaultBackfillTimeout
+		if input.Timeout != "" {
+			timeout, err = time.ParseDuration(input.Timeout)
+			if err != nil {
+				return nil, nil, toolerror.Invalid("timeout", fmt.Errorf("invalid timeout format: %w", err))
+			}
+			if timeout <= 0 {
+				return nil, nil, toolerror.Invalid("timeout", errors.New("invalid timeout: must be a positive duration"))
+			}
+		}
+
+		namespace := ResolveNamespace(input.Namespace, client)
+
+		cronService, err := client.CronWorkflowService()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get cron workflow service: %w", err)
+		}
+
+		cw, err := cronService.GetCronWorkflow(ctx, &cronworkflow.GetCronWorkflowRequest{
+			Name:      name,
+			Namespace: namespace,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get cron workflow: %w", err)
+		}
+
+		schedules, location, err := parseCronSchedules(&cw.Spec)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		times := fireTimesBetween(schedules, start, end, maxRuns)
+		if len(times) > maxRuns {
+			return nil, nil, fmt.Errorf("the time range contains more than %d runs; narrow the range or raise maxRuns (max %d)", maxRuns, maxBackfillRuns)
+		}
+
+		policy := cw.Spec.ConcurrencyPolicy
+		if policy == "" {
+			policy = wfv1.AllowConcurrent
+		}
+		output := &BackfillCronWorkflowOutput{
+			Name:              name,
+			Namespace:         namespace,
+			ConcurrencyPolicy: string(policy),
+			Runs:              make([]BackfillRun, 0, len(times)),
+			Sequential:        policy == wfv1.ForbidConcurrent || policy == wfv1.ReplaceConcurrent,
+			DryRun:            input.DryRun,
+		}
+		if len(times) == 0 {
+			return TextResult(fmt.Sprintf("CronWorkflow %q has no scheduled runs between %s and %s", name,
+				start.In(location).Format(time.RFC3339), end.In(location).Format(time.RFC3339))), output, nil
+		}
+
+		if input.DryRun {
+			for _, t := range times {
+				output.Runs = append(output.Runs, backfillRun(name, t, location, BackfillStatusPlanned))
+			}
+			return TextResult(formatBackfill(output)), output, nil
+		}
+
+		// Runs of a CronWorkflow that forbids overlap must not start next to an active one
+		if output.Sequential && len(cw.Status.Active) > 0 {
+			return nil, nil, fmt.Errorf("CronWorkflow %q has %d active workflow(s) and concurrencyPolicy %s; wait for them to complete before backfilling",
+				name, len(cw.Status.Active), policy)
+		}
+
+		waitCtx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		wfService := client.WorkflowService()
+		for i, t := range times {
+			run := backfillRun(name, t, location, BackfillStatusSubmitted)
+
+			_, submitErr := wfService.SubmitWorkflow(ctx, &workflow.WorkflowSubmitRequest{
+				Namespace:    namespace,
+				ResourceKind: "cronwf",
+				ResourceName: name,
+				SubmitOptions: &wfv1.SubmitOpts{
+					Name:        run.Workflow,
+					Annotations: common.AnnotationKeyCronWfScheduledTime + "=" + t.UTC().Format(time.RFC3339),
+				},
+			})
+			if submitErr != nil {
+				if apierrors.IsAlreadyExists(submitErr) || status.Code(submitErr) == codes.AlreadyExists {
+					run.Status = BackfillStatusExists
+					output.Skipped++
+					output.Runs = append(output.Runs, run)
+					continue
+				}
+				return nil, nil, fmt.Errorf("failed to submit run scheduled at %s (%d of %d runs submitted): %w",
+					run.ScheduledTime, output.Submitted, len(times), submitErr)
+			}
+			output.Submitted++
+
+			if output.Sequential {
+				last, timedOut, waitErr := waitForWorkflow(waitCtx, wfService, namespace, run.Workflow)
+				if waitErr != nil {
+					return nil, nil, waitErr
+				}
+				if last != nil {
+					run.Phase = string(last.Status.Phase)
+				}
+				if timedOut {
+					output.TimedOut = true
+					output.Runs = append(output.Runs, run)
+					for _, remaining := range times[i+1:] {
+						output.Runs = append(output.Runs, backfillRun(name, remaining, location, BackfillStatusNotSubmitted))
+						output.Skipped++
+					}
+					break
+				}
+			}
+			output.Runs = append(output.Runs, run)
+		}
+
+		return TextResult(formatBackfill(output)), output, nil
+	}
+}
+
+// backfillRun returns the run of a backfill scheduled at t.
+func backfillRun(cronName string, t time.Time, location *time.Location, runStatus string) BackfillRun {
+	return BackfillRun{
+		ScheduledTime: t.In(location).Format(time.RFC3339),
+		Workflow:      cronChildWorkflowName(cronName, t),
+		Status:        runStatus,
+	}
+}
+
+// formatBackfill builds the human-readable backfill summary.
+func formatBackfill(output *BackfillCronWorkflowOutput) string {
+	var sb strings.Builder
+	if output.DryRun {
+		fmt.Fprintf(&sb, "Dry run: backfilling CronWorkflow %q would submit %d run(s)", output.Name, len(output.Runs))
+	} else {
+		fmt.Fprintf(&sb, "Backfilled CronWorkflow %q in namespace %q: %d submitted, %d skipped", output.Name, output.Namespace, output.Submitted, output.Skipped)
+	}
+	if output.Sequential {
+		fmt.Fprintf(&sb, " (one at a time, concurrencyPolicy %s)", output.ConcurrencyPolicy)
+	}
+	sb.WriteString("\n")
+	for _, run := range output.Runs {
+		fmt.Fprintf(&sb, "\n  %s  %s  %s", run.ScheduledTime, run.Workflow, run.Status)
+		if run.Phase != "" {
+			fmt.Fprintf(&sb, " (%s)", run.Phase)
+		}
+	}
+	if output.TimedOut {
+		sb.WriteString("\n\nTimed out waiting for a run to complete; the remaining runs were not submitted.")
+	}
+	return sb.String()
+}
@@ -0,0 +1,226 @@
+package tools
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+)
+
+func TestBackfillCronWorkflowTool(t *testing.T) {
+	tool := BackfillCronWorkflowTool()
+
+	assert.Equal(t, "backfill_cron_workflow", tool.Name)
+	assert.Contains(t, tool.Description, "concurrencyPolicy")
+	require.NotNil(t, tool.Annotations)
+	assert.False(t, *tool.Annotations.DestructiveHint)
+}
+
+// backfillCronWorkflow returns an hourly CronWorkflow with the given concurrency policy.
+func backfillCronWorkflow(policy wfv1.ConcurrencyPolicy) *wfv1.CronWorkflow {
+	return &wfv1.CronWorkflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "hourly", Namespace: "default"},
+		Spec: wfv1.CronWorkflowSpec{
+			Schedules:         []string{"0 * * * *"},
+			ConcurrencyPolicy: policy,
+		},
+	}
+}
+
+// submittedAt matches a submit request for the run of hourly scheduled at t.
+func submittedAt(t time.Time) any {
+	return mock.MatchedBy(func(req *workflow.WorkflowSubmitRequest) bool {
+		return req.ResourceKind == "cronwf" &&
+			req.ResourceName == "hourly" &&
+			req.SubmitOptions.Name == cronChildWorkflowName("hourly", t) &&
+			req.SubmitOptions.Annotations == "workflows.argoproj.io/scheduled-time="+t.Format(time.RFC3339)
+	})
+}
+
+func TestBackfillCronWorkflowHandler(t *testing.T) {
+	start := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	input := BackfillCronWorkflowInput{
+		Name:  "hourly",
+		Start: start.Format(time.RFC3339),
+		End:   end.Format(time.RFC3339),
+	}
+	completed := func(name string) *mocks.MockWatchWorkflowsStream {
+		return mocks.NewMockWatchWorkflowsStream([]*workflow.WorkflowWatchEvent{
+			mocks.NewWatchEvent("MODIFIED", &wfv1.Workflow{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Status:     wfv1.WorkflowStatus{Phase: wfv1.WorkflowSucceeded},
+			}),
+		})
+	}
+
+	tests := []struct {
+		setupMock func(*mocks.MockCronWorkflowServiceClient, *mocks.MockWorkflowServiceClient)
+		validate  func(*testing.T, *BackfillCronWorkflowOutput, *mcp.CallToolResult)
+		input     func() BackfillCronWorkflowInput
+		name      string
+		wantErr   string
+	}{
+		{
+			name: "allow submits every run and skips existing ones",
+			setupMock: func(c *mocks.MockCronWorkflowServiceClient, w *mocks.MockWorkflowServiceClient) {
+				c.On("GetCronWorkflow", mock.Anything, mock.Anything).Return(backfillCronWorkflow(""), nil)
+				w.On("SubmitWorkflow", mock.Anything, submittedAt(start)).Return(&wfv1.Workflow{}, nil)
+				w.On("SubmitWorkflow", mock.Anything, submittedAt(start.Add(time.Hour))).
+					Return(nil, status.Error(codes.AlreadyExists, "already exists"))
+				w.On("SubmitWorkflow", mock.Anything, submittedAt(end)).Return(&wfv1.Workflow{}, nil)
+			},
+			validate: func(t *testing.T, output *BackfillCronWorkflowOutput, result *mcp.CallToolResult) {
+				assert.False(t, output.Sequential)
+				assert.Equal(t, "Allow", output.ConcurrencyPolicy)
+				assert.Equal(t, 2, output.Submitted)
+				assert.Equal(t, 1, output.Skipped)
+				require.Len(t, output.Runs, 3)
+				assert.Equal(t, BackfillStatusExists, output.Runs[1].Status)
+				assert.Equal(t, "hourly-1736935200", output.Runs[0].Workflow)
+
+				text, ok := result.Content[0].(*mcp.TextContent)
+				require.True(t, ok)
+				assert.Contains(t, text.Text, "2 submitted, 1 skipped")
+			},
+		},
+		{
+			name: "forbid waits for each run",
+			setupMock: func(c *mocks.MockCronWorkflowServiceClient, w *mocks.MockWorkflowServiceClient) {
+				c.On("GetCronWorkflow", mock.Anything, mock.Anything).Return(backfillCronWorkflow(wfv1.ForbidConcurrent), nil)
+				for _, ts := range []time.Time{start, start.Add(time.Hour), end} {
+					name := cronChildWorkflowName("hourly", ts)
+					w.On("SubmitWorkflow", mock.Anything, submittedAt(ts)).Return(&wfv1.Workflow{}, nil).Once()
+					w.On("WatchWorkflows", mock.Anything, mock.MatchedBy(func(req *workflow.WatchWorkflowsRequest) bool {
+						return req.ListOptions.FieldSelector == "metadata.name="+name
+					})).Return(completed(name), nil).Once()
+				}
+			},
+			validate: func(t *testing.T, output *BackfillCronWorkflowOutput, _ *mcp.CallToolResult) {
+				assert.True(t, output.Sequential)
+				assert.Equal(t, 3, output.Submitted)
+				for _, run := range output.Runs {
+					assert.Equal(t, "Succeeded", run.Phase)
+				}
+			},
+		},
+		{
+			name: "forbid refuses while a run is active",
+			setupMock: func(c *mocks.MockCronWorkflowServiceClient, _ *mocks.MockWorkflowServiceClient) {
+				cw := backfillCronWorkflow(wfv1.ForbidConcurrent)
+				cw.Status.Active = []corev1.ObjectReference{{Name: "hourly-1"}}
+				c.On("GetCronWorkflow", mock.Anything, mock.Anything).Return(cw, nil)
+			},
+			wantErr: "wait for them to complete",
+		},
+		{
+			name: "dry run lists runs without submitting",
+			input: func() BackfillCronWorkflowInput {
+				dry := input
+				dry.DryRun = true
+				return dry
+			},
+			setupMock: func(c *mocks.MockCronWorkflowServiceClient, _ *mocks.MockWorkflowServiceClient) {
+				c.On("GetCronWorkflow", mock.Anything, mock.Anything).Return(backfillCronWorkflow(wfv1.ReplaceConcurrent), nil)
+			},
+			validate: func(t *testing.T, output *BackfillCronWorkflowOutput, _ *mcp.CallToolResult) {
+				assert.True(t, output.DryRun)
+				assert.Zero(t, output.Submitted)
+				require.Len(t, output.Runs, 3)
+				assert.Equal(t, BackfillStatusPlanned, output.Runs[0].Status)
+			},
+		},
+		{
+			name: "range over the cap is refused",
+			input: func() BackfillCronWorkflowInput {
+				capped := input
+				capped.MaxRuns = 2
+				return capped
+			},
+			setupMock: func(c *mocks.MockCronWorkflowServiceClient, _ *mocks.MockWorkflowServiceClient) {
+				c.On("GetCronWorkflow", mock.Anything, mock.Anything).Return(backfillCronWorkflow(""), nil)
+			},
+			wantErr: "more than 2 runs",
+		},
+		{
+			name: "submit failure",
+			setupMock: func(c *mocks.MockCronWorkflowServiceClient, w *mocks.MockWorkflowServiceClient) {
+				c.On("GetCronWorkflow", mock.Anything, mock.Anything).Return(backfillCronWorkflow(""), nil)
+				w.On("SubmitWorkflow", mock.Anything, mock.Anything).Return(nil, errors.New("forbidden"))
+			},
+			wantErr: "0 of 3 runs submitted",
+		},
+		{
+			name: "future end is refused",
+			input: func() BackfillCronWorkflowInput {
+				future := input
+				future.End = time.Now().Add(time.Hour).Format(time.RFC3339)
+				return future
+			},
+			wantErr: "must not be in the future",
+		},
+		{
+			name: "end before start is refused",
+			input: func() BackfillCronWorkflowInput {
+				reversed := input
+				reversed.Start, reversed.End = input.End, input.Start
+				return reversed
+			},
+			wantErr: "end must not be before start",
+		},
+		{
+			name: "maxRuns is capped",
+			input: func() BackfillCronWorkflowInput {
+				capped := input
+				capped.MaxRuns = 1000
+				return capped
+			},
+			wantErr: "maxRuns must be at most 100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := newMockClient(t, "default", true)
+			cronService := newMockCronWorkflowService(t)
+			wfService := newMockWorkflowService(t)
+			mockClient.SetCronWorkflowService(cronService)
+			mockClient.SetWorkflowService(wfService)
+			if tt.setupMock != nil {
+				tt.setupMock(cronService, wfService)
+			}
+			defer cronService.AssertExpectations(t)
+			defer wfService.AssertExpectations(t)
+
+			in := input
+			if tt.input != nil {
+				in = tt.input()
+			}
+
+			handler := BackfillCronWorkflowHandler(mockClient)
+			result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, in)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, output)
+			tt.validate(t, output, result)
+		})
+	}
+}
@@ -35,7 +35,7 @@ type CreateClusterWorkflowTemplateOutput struct {
 func CreateClusterWorkflowTemplateTool() *mcp.Tool {
 	return &mcp.Tool{
 		Name:        "create_cluster_workflow_template",
-		Description: "Create or update a ClusterWorkflowTemplate from a YAML manifest. If the template already exists, it will be updated.",
+		Description: "Create or update a ClusterWorkflowTemplate from a YAML manifest. If the template already exists, it will be overwritten; use update_cluster_workflow_template to change an existing template safely.",
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: ptr.To(true),
 		},
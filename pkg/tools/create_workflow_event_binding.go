@@ -0,0 +1,149 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/expr-lang/expr"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
+)
+
+// KindWorkflowEventBinding is the WorkflowEventBinding manifest kind.
+const KindWorkflowEventBinding = "WorkflowEventBinding"
+
+// CreateWorkflowEventBindingInput defines the input parameters for the create_workflow_event_binding tool.
+type CreateWorkflowEventBindingInput struct {
+	// Namespace is the Kubernetes namespace (uses default if not specified).
+	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace (uses default if not specified)"`
+
+	// Manifest is the WorkflowEventBinding YAML manifest.
+	Manifest string `json:"manifest" jsonschema:"WorkflowEventBinding YAML manifest,required"`
+}
+
+// CreateWorkflowEventBindingOutput defines the output for the create_workflow_event_binding tool.
+type CreateWorkflowEventBindingOutput struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	CreatedAt string `json:"createdAt,omitempty"`
+	Created   bool   `json:"created"`
+}
+
+// CreateWorkflowEventBindingTool returns the MCP tool definition for create_workflow_event_binding.
+func CreateWorkflowEventBindingTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "create_workflow_event_binding",
+		Description: "Create or update a WorkflowEventBinding from a YAML manifest. The binding submits its WorkflowTemplate when an " +
+			"event sent with send_workflow_event matches its selector. Requires direct Kubernetes API access.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr.To(true),
+		},
+	}
+}
+
+// CreateWorkflowEventBindingHandler returns a handler function for the create_workflow_event_binding tool.
+func CreateWorkflowEventBindingHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, CreateWorkflowEventBindingInput) (*mcp.CallToolResult, *CreateWorkflowEventBindingOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input CreateWorkflowEventBindingInput) (*mcp.CallToolResult, *CreateWorkflowEventBindingOutput, error) {
+		if strings.TrimSpace(input.Manifest) == "" {
+			return nil, nil, toolerror.Invalid("manifest", errors.New("manifest cannot be empty"))
+		}
+
+		var binding wfv1.WorkflowEventBinding
+		if err := yaml.UnmarshalStrict([]byte(input.Manifest), &binding); err != nil {
+			return nil, nil, toolerror.Invalid("manifest", fmt.Errorf("failed to parse workflow event binding manifest: %w", err))
+		}
+		if err := validateEventBinding(&binding); err != nil {
+			return nil, nil, err
+		}
+
+		namespace := ResolveNamespace(input.Namespace, client)
+		binding.Namespace = namespace
+
+		wfClient, err := client.WorkflowClientset()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get workflow clientset: %w", err)
+		}
+		bindings := wfClient.ArgoprojV1alpha1().WorkflowEventBindings(namespace)
+
+		created := true
+		result, err := bindings.Create(ctx, &binding, metav1.CreateOptions{})
+		if apierrors.IsAlreadyExists(err) {
+			existing, getErr := bindings.Get(ctx, binding.Name, metav1.GetOptions{})
+			if getErr != nil {
+				return nil, nil, fmt.Errorf("failed to get existing workflow event binding for update: %w", getErr)
+			}
+			binding.ResourceVersion = existing.ResourceVersion
+			result, err = bindings.Update(ctx, &binding, metav1.UpdateOptions{})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to update workflow event binding: %w", err)
+			}
+			created = false
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("failed to create workflow event binding: %w", err)
+		}
+
+		output := &CreateWorkflowEventBindingOutput{
+			Name:      result.Name,
+			Namespace: result.Namespace,
+			Created:   created,
+		}
+		if !result.CreationTimestamp.IsZero() {
+			output.CreatedAt = result.CreationTimestamp.Format(time.RFC3339)
+		}
+
+		verb := "created"
+		if !created {
+			verb = "updated"
+		}
+		return TextResult(fmt.Sprintf("WorkflowEventBinding %q %s in namespace %q", output.Name, verb, output.Namespace)), output, nil
+	}
+}
+
+// validateEventBinding checks the fields the Argo Server needs to dispatch
+// events to a binding, including that its expressions compile.
+func validateEventBinding(binding *wfv1.WorkflowEventBinding) error {
+	if binding.Kind != "" && binding.Kind != KindWorkflowEventBinding {
+		return toolerror.Invalid("manifest", fmt.Errorf("manifest must be a WorkflowEventBinding, got %q", binding.Kind))
+	}
+	if binding.Name == "" {
+		return toolerror.Invalid("manifest", errors.New("workflow event binding must have metadata.name"))
+	}
+
+	selector := strings.TrimSpace(binding.Spec.Event.Selector)
+	if selector == "" {
+		return toolerror.Invalid("manifest", errors.New("workflow event binding must have spec.event.selector"))
+	}
+	if _, err := expr.Compile(selector); err != nil {
+		return toolerror.Invalid("manifest", fmt.Errorf("invalid spec.event.selector %q: %w", selector, err))
+	}
+
+	submit := binding.Spec.Submit
+	if submit == nil {
+		return nil
+	}
+	if submit.WorkflowTemplateRef.Name == "" {
+		return toolerror.Invalid("manifest", errors.New("spec.submit.workflowTemplateRef.name is required"))
+	}
+	if submit.Arguments != nil {
+		for _, param := range submit.Arguments.Parameters {
+			if param.ValueFrom == nil || param.ValueFrom.Event == "" {
+				continue
+			}
+			if _, err := expr.Compile(param.ValueFrom.Event); err != nil {
+				return toolerror.Invalid("manifest", fmt.Errorf("invalid event expression of parameter %q: %w", param.Name, err))
+			}
+		}
+	}
+	return nil
+}
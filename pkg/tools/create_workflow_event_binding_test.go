@@ -0,0 +1,131 @@
+package tools
+
+import (
+	"testing"
+
+	wffake "github.com/argoproj/argo-workflows/v4/pkg/client/clientset/versioned/fake"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
+)
+
+const pushEventBindingManifest = `apiVersion: argoproj.io/v1alpha1
+kind: WorkflowEventBinding
+metadata:
+  name: on-push
+spec:
+  event:
+    selector: payload.repo == "argo" && discriminator == "push"
+  submit:
+    workflowTemplateRef:
+      name: build
+    arguments:
+      parameters:
+        - name: commit
+          valueFrom:
+            event: payload.commit
+`
+
+func TestCreateWorkflowEventBindingTool(t *testing.T) {
+	tool := CreateWorkflowEventBindingTool()
+
+	assert.Equal(t, "create_workflow_event_binding", tool.Name)
+	require.NotNil(t, tool.Annotations)
+	require.NotNil(t, tool.Annotations.DestructiveHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+}
+
+func TestCreateWorkflowEventBindingHandler(t *testing.T) {
+	t.Run("creates a new binding", func(t *testing.T) {
+		clientset := wffake.NewSimpleClientset()
+		mockClient := newMockClient(t, "argo", false)
+		mockClient.SetWorkflowClientset(clientset)
+
+		result, output, err := CreateWorkflowEventBindingHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, CreateWorkflowEventBindingInput{Manifest: pushEventBindingManifest})
+		require.NoError(t, err)
+		assert.True(t, output.Created)
+		assert.Equal(t, "argo", output.Namespace)
+
+		stored, err := clientset.ArgoprojV1alpha1().WorkflowEventBindings("argo").Get(t.Context(), "on-push", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "build", stored.Spec.Submit.WorkflowTemplateRef.Name)
+
+		text, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, `WorkflowEventBinding "on-push" created in namespace "argo"`, text.Text)
+	})
+
+	t.Run("updates an existing binding", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", false)
+		mockClient.SetWorkflowClientset(wffake.NewSimpleClientset(pushEventBinding("on-push", "other")))
+
+		_, output, err := CreateWorkflowEventBindingHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, CreateWorkflowEventBindingInput{Manifest: pushEventBindingManifest})
+		require.NoError(t, err)
+		assert.False(t, output.Created)
+	})
+
+	t.Run("argo server mode", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", true)
+		mockClient.On("WorkflowClientset").Return(nil, argo.ErrKubernetesClientNotAvailable)
+
+		_, _, err := CreateWorkflowEventBindingHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, CreateWorkflowEventBindingInput{Manifest: pushEventBindingManifest})
+		require.ErrorIs(t, err, argo.ErrKubernetesClientNotAvailable)
+		assert.Equal(t, toolerror.CodeRequiresKubernetesAPI, toolerror.Classify(err).Code)
+	})
+}
+
+func TestValidateEventBinding(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		wantErr  string
+	}{
+		{name: "valid", manifest: pushEventBindingManifest},
+		{
+			name:     "wrong kind",
+			manifest: "kind: WorkflowTemplate\nmetadata:\n  name: x\n",
+			wantErr:  `manifest must be a WorkflowEventBinding, got "WorkflowTemplate"`,
+		},
+		{
+			name:     "missing selector",
+			manifest: "kind: WorkflowEventBinding\nmetadata:\n  name: x\nspec:\n  event: {}\n",
+			wantErr:  "must have spec.event.selector",
+		},
+		{
+			name:     "selector does not compile",
+			manifest: "kind: WorkflowEventBinding\nmetadata:\n  name: x\nspec:\n  event:\n    selector: payload.repo ==\n",
+			wantErr:  `invalid spec.event.selector "payload.repo =="`,
+		},
+		{
+			name: "parameter expression does not compile",
+			manifest: "kind: WorkflowEventBinding\nmetadata:\n  name: x\nspec:\n  event:\n    selector: \"true\"\n  submit:\n" +
+				"    workflowTemplateRef:\n      name: build\n    arguments:\n      parameters:\n        - name: p\n          valueFrom:\n            event: payload.(\n",
+			wantErr: `invalid event expression of parameter "p"`,
+		},
+		{
+			name:     "missing template",
+			manifest: "kind: WorkflowEventBinding\nmetadata:\n  name: x\nspec:\n  event:\n    selector: \"true\"\n  submit:\n    workflowTemplateRef: {}\n",
+			wantErr:  "spec.submit.workflowTemplateRef.name is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := newMockClient(t, "argo", false)
+			mockClient.SetWorkflowClientset(wffake.NewSimpleClientset())
+
+			_, _, err := CreateWorkflowEventBindingHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, CreateWorkflowEventBindingInput{Manifest: tt.manifest})
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Equal(t, "manifest", toolerror.Classify(err).Field)
+		})
+	}
+}
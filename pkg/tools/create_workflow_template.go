@@ -39,7 +39,7 @@ type CreateWorkflowTemplateOutput struct {
 func CreateWorkflowTemplateTool() *mcp.Tool {
 	return &mcp.Tool{
 		Name:        "create_workflow_template",
-		Description: "Create or update a WorkflowTemplate from a YAML manifest. If the template already exists, it will be updated.",
+		Description: "Create or update a WorkflowTemplate from a YAML manifest. If the template already exists, it will be overwritten; use update_workflow_template to change an existing template safely.",
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: ptr.To(true),
 		},
@@ -0,0 +1,110 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/robfig/cron/v3"
+)
+
+// defaultCronTimezone is the timezone assumed when a CronWorkflow does not set
+// one. The controller uses its machine's local time, which is UTC in the
+// published controller images.
+const defaultCronTimezone = "UTC"
+
+// cronSchedule is a parsed entry of a CronWorkflow's spec.schedules.
+type cronSchedule struct {
+	schedule   cron.Schedule
+	expression string
+}
+
+// parseCronSchedules parses the schedules of a CronWorkflow the same way the
+// controller does, in the CronWorkflow's timezone. It returns the schedules
+// and the timezone they are evaluated in.
+func parseCronSchedules(spec *wfv1.CronWorkflowSpec) ([]cronSchedule, *time.Location, error) {
+	expressions := spec.GetSchedules()
+	if len(expressions) == 0 {
+		return nil, nil, fmt.Errorf("cron workflow has no schedules")
+	}
+
+	timezone := spec.Timezone
+	if timezone == "" {
+		timezone = defaultCronTimezone
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid timezone %q: %w", spec.Timezone, err)
+	}
+
+	schedules := make([]cronSchedule, 0, len(expressions))
+	for _, expression := range expressions {
+		schedule, parseErr := cron.ParseStandard("CRON_TZ=" + timezone + " " + expression)
+		if parseErr != nil {
+			return nil, nil, fmt.Errorf("invalid schedule %q: %w", expression, parseErr)
+		}
+		schedules = append(schedules, cronSchedule{expression: expression, schedule: schedule})
+	}
+	return schedules, location, nil
+}
+
+// nextFireTimes returns the next count fire times of schedule strictly after from.
+func nextFireTimes(schedule cron.Schedule, from time.Time, count int) []time.Time {
+	times := make([]time.Time, 0, count)
+	next := from
+	for range count {
+		next = schedule.Next(next)
+		if next.IsZero() {
+			break
+		}
+		times = append(times, next)
+	}
+	return times
+}
+
+// fireTimesBetween returns the fire times of all schedules in [start, end],
+// sorted and without duplicates. It stops after limit+1 times so callers can
+// detect ranges that exceed their limit without enumerating them.
+func fireTimesBetween(schedules []cronSchedule, start, end time.Time, limit int) []time.Time {
+	var times []time.Time
+	for _, s := range schedules {
+		// Next returns times strictly after its argument, truncated to the second
+		next := s.schedule.Next(start.Add(-time.Second))
+		for n := 0; !next.IsZero() && !next.After(end) && n <= limit; n++ {
+			if !next.Before(start) {
+				times = append(times, next)
+			}
+			next = s.schedule.Next(next)
+		}
+	}
+
+	slices.SortFunc(times, func(a, b time.Time) int { return a.Compare(b) })
+	times = slices.CompactFunc(times, func(a, b time.Time) bool { return a.Equal(b) })
+	if len(times) > limit+1 {
+		times = times[:limit+1]
+	}
+	return times
+}
+
+// latestMissedRun mirrors the controller's catch-up logic: it returns the most
+// recent fire time between the last scheduled run and now that did not run,
+// or the zero time when no run was missed.
+func latestMissedRun(schedules []cronSchedule, lastScheduled, now time.Time) time.Time {
+	var missed time.Time
+	for _, s := range schedules {
+		for next := s.schedule.Next(lastScheduled); !next.IsZero() && next.Before(now); next = s.schedule.Next(next) {
+			if next.After(missed) {
+				missed = next
+			}
+		}
+	}
+	return missed
+}
+
+// cronChildWorkflowName returns the name the controller gives the workflow it
+// creates for a run scheduled at scheduledTime.
+func cronChildWorkflowName(cronName string, scheduledTime time.Time) string {
+	return fmt.Sprintf("%s-%d", cronName, scheduledTime.Unix())
+}
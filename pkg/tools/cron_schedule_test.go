@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCronSchedules(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantErr  string
+		wantZone string
+		spec     wfv1.CronWorkflowSpec
+	}{
+		{
+			name:     "defaults to UTC",
+			spec:     wfv1.CronWorkflowSpec{Schedules: []string{"0 * * * *"}},
+			wantZone: "UTC",
+		},
+		{
+			name:     "uses timezone",
+			spec:     wfv1.CronWorkflowSpec{Schedules: []string{"0 9 * * *", "@daily"}, Timezone: "Asia/Tokyo"},
+			wantZone: "Asia/Tokyo",
+		},
+		{
+			name:    "invalid timezone",
+			spec:    wfv1.CronWorkflowSpec{Schedules: []string{"0 * * * *"}, Timezone: "Mars/Olympus"},
+			wantErr: "invalid timezone",
+		},
+		{
+			name:    "invalid schedule",
+			spec:    wfv1.CronWorkflowSpec{Schedules: []string{"every day"}},
+			wantErr: "invalid schedule",
+		},
+		{
+			name:    "no schedules",
+			spec:    wfv1.CronWorkflowSpec{},
+			wantErr: "no schedules",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedules, location, err := parseCronSchedules(&tt.spec)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, schedules, len(tt.spec.Schedules))
+			assert.Equal(t, tt.wantZone, location.String())
+		})
+	}
+}
+
+func TestNextFireTimes_Timezone(t *testing.T) {
+	schedules, location, err := parseCronSchedules(&wfv1.CronWorkflowSpec{
+		Schedules: []string{"0 9 * * *"},
+		Timezone:  "Asia/Tokyo",
+	})
+	require.NoError(t, err)
+
+	from := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC) // 09:00 in Tokyo
+	times := nextFireTimes(schedules[0].schedule, from, 2)
+
+	require.Len(t, times, 2)
+	assert.Equal(t, "2025-01-16T09:00:00+09:00", times[0].In(location).Format(time.RFC3339))
+	assert.Equal(t, "2025-01-17T09:00:00+09:00", times[1].In(location).Format(time.RFC3339))
+}
+
+func TestFireTimesBetween(t *testing.T) {
+	schedules, _, err := parseCronSchedules(&wfv1.CronWorkflowSpec{
+		Schedules: []string{"0 * * * *", "0 */2 * * *"},
+	})
+	require.NoError(t, err)
+
+	start := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 15, 13, 0, 0, 0, time.UTC)
+
+	t.Run("merges schedules and includes both ends", func(t *testing.T) {
+		times := fireTimesBetween(schedules, start, end, 10)
+		require.Len(t, times, 4)
+		assert.Equal(t, start, times[0].UTC())
+		assert.Equal(t, end, times[3].UTC())
+	})
+
+	t.Run("stops after the limit", func(t *testing.T) {
+		times := fireTimesBetween(schedules, start, end, 2)
+		assert.Len(t, times, 3)
+	})
+}
+
+func TestLatestMissedRun(t *testing.T) {
+	schedules, _, err := parseCronSchedules(&wfv1.CronWorkflowSpec{Schedules: []string{"0 * * * *"}})
+	require.NoError(t, err)
+
+	last := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC),
+		latestMissedRun(schedules, last, time.Date(2025, 1, 15, 12, 30, 0, 0, time.UTC)).UTC())
+	assert.True(t, latestMissedRun(schedules, last, time.Date(2025, 1, 15, 10, 30, 0, 0, time.UTC)).IsZero())
+}
+
+func TestCronChildWorkflowName(t *testing.T) {
+	assert.Equal(t, "nightly-1736935200", cronChildWorkflowName("nightly", time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)))
+}
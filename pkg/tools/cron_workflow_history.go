@@ -0,0 +1,634 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/cronworkflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowarchive"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/workflow/common"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// Bounds for the history window.
+const (
+	defaultHistoryLookback = 7 * 24 * time.Hour
+	defaultHistoryLimit    = 50
+	maxHistoryLimit        = 500
+	maxHistoryFireTimes    = 10000
+)
+
+// Bounds for paging through the archived runs of a CronWorkflow.
+const (
+	historyArchivePageSize = 500
+	maxHistoryArchivedRuns = 10000
+)
+
+// historyGracePeriod is how long after a fire time a run may take to appear
+// before the fire time is reported as missed.
+const historyGracePeriod = time.Minute
+
+// Statuses of a slot in the cron workflow history.
+const (
+	CronRunStatusRan     = "ran"     // a workflow ran for this slot
+	CronRunStatusMissed  = "missed"  // no workflow ran at a fire time
+	CronRunStatusSkipped = "skipped" // concurrencyPolicy Forbid skipped the fire time
+	CronRunStatusUnknown = "unknown" // the fire time is older than the retained history
+)
+
+// Flags raised on runs in the cron workflow history.
+const (
+	CronRunFlagOverlapping      = "overlapping"       // started while an earlier run was still running
+	CronRunFlagExceededInterval = "exceeded_interval" // ran longer than the interval to the next fire time
+	CronRunFlagUnscheduled      = "unscheduled"       // not started at a fire time, e.g. a manual trigger
+)
+
+// Sources of runs in the cron workflow history.
+const (
+	runSourceLive     = "live"
+	runSourceArchived = "archived"
+)
+
+// CronWorkflowHistoryInput defines the input parameters for the cron_workflow_history tool.
+type CronWorkflowHistoryInput struct {
+	// Namespace is the Kubernetes namespace (uses default if not specified).
+	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace (uses default if not specified)"`
+
+	// Name is the CronWorkflow name.
+	Name string `json:"name" jsonschema:"CronWorkflow name,required"`
+
+	// Since is the start of the history window.
+	Since string `json:"since,omitempty" jsonschema:"RFC3339 start of the history window (default 7 days ago)"`
+
+	// Limit is the maximum number of history entries returned.
+	Limit int `json:"limit,omitempty" jsonschema:"Maximum number of most recent history entries to return (default 50, max 500). The summary and streaks cover the whole window"`
+}
+
+// CronHistoryRun is one entry of the cron workflow history: a fire time, a
+// run, or both.
+type CronHistoryRun struct {
+	ScheduledTime string   `json:"scheduledTime"`
+	Status        string   `json:"status"`
+	Workflow      string   `json:"workflow,omitempty"`
+	UID           string   `json:"uid,omitempty"`
+	Source        string   `json:"source,omitempty"`
+	Phase         string   `json:"phase,omitempty"`
+	StartedAt     string   `json:"startedAt,omitempty"`
+	FinishedAt    string   `json:"finishedAt,omitempty"`
+	Duration      string   `json:"duration,omitempty"`
+	Flags         []string `json:"flags,omitempty"`
+}
+
+// CronHistorySummary counts the entries of the cron workflow history.
+type CronHistorySummary struct {
+	ExpectedRuns     int `json:"expectedRuns"`
+	Runs             int `json:"runs"`
+	Succeeded        int `json:"succeeded"`
+	Failed           int `json:"failed"`
+	Running          int `json:"running"`
+	Missed           int `json:"missed"`
+	Skipped          int `json:"skipped"`
+	Unknown          int `json:"unknown,omitempty"`
+	Overlapping      int `json:"overlapping"`
+	ExceededInterval int `json:"exceededInterval"`
+	Unscheduled      int `json:"unscheduled"`
+}
+
+// CronHistoryStreaks describes consecutive successes and failures of completed runs.
+type CronHistoryStreaks struct {
+	CurrentPhase   string `json:"currentPhase,omitempty"`
+	Current        int    `json:"current"`
+	LongestSuccess int    `json:"longestSuccess"`
+	LongestFailure int    `json:"longestFailure"`
+}
+
+// CronWorkflowHistoryOutput defines the output for the cron_workflow_history tool.
+type CronWorkflowHistoryOutput struct {
+	Name              string             `json:"name"`
+	Namespace         string             `json:"namespace"`
+	Timezone          string             `json:"timezone"`
+	ConcurrencyPolicy string             `json:"concurrencyPolicy"`
+	Since             string             `json:"since"`
+	Until             string             `json:"until"`
+	History           []CronHistoryRun   `json:"history"`
+	Warnings          []string           `json:"warnings,omitempty"`
+	Streaks           CronHistoryStreaks `json:"streaks"`
+	Summary           CronHistorySummary `json:"summary"`
+	ArchiveIncluded   bool               `json:"archiveIncluded"`
+	Truncated         bool               `json:"truncated,omitempty"`
+}
+
+// CronWorkflowHistoryTool returns the MCP tool definition for cron_workflow_history.
+func CronWorkflowHistoryTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "cron_workflow_history",
+		Description: "Show the run history of a CronWorkflow, correlating its live and archived workflows with the fire times of its schedules. " +
+			"Flags missed runs, runs skipped by concurrencyPolicy Forbid, overlapping runs and runs that took longer than the interval between schedules, " +
+			"and reports success and failure streaks.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+}
+
+// cronRun is a child workflow of a CronWorkflow.
+type cronRun struct {
+	scheduled time.Time
+	started   time.Time
+	finished  time.Time
+	name      string
+	uid       string
+	source    string
+	phase     wfv1.WorkflowPhase
+	flags     []string
+}
+
+// running reports whether the run had not finished when it was observed.
+func (r *cronRun) running() bool {
+	return r.finished.IsZero() && !isWorkflowCompleted(r.phase)
+}
+
+// CronWorkflowHistoryHandler returns a handler function for the cron_workflow_history tool.
+func CronWorkflowHistoryHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, CronWorkflowHistoryInput) (*mcp.CallToolResult, *CronWorkflowHistoryOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input CronWorkflowHistoryInput) (*mcp.CallToolResult, *CronWorkflowHistoryOutput, error) {
+		name, err := ValidateName(input.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		limit := input.Limit
+		if limit <= 0 {
+			limit = defaultHistoryLimit
+		}
+		if limit > maxHistoryLimit {
+			return nil, nil, fmt.Errorf("limit must be at most %d", maxHistoryLimit)
+		}
+
+		now := time.Now()
+		since := now.Add(-defaultHistoryLookback)
+		if input.Since != "" {
+			since, err = time.Parse(time.RFC3339, input.Since)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid since time, expected RFC3339: %w", err)
+			}
+			if !since.Before(now) {
+				return nil, nil, fmt.Errorf("since must be in the past")
+			}
+		}
+
+		namespace := ResolveNamespace(input.Namespace, client)
+
+		cronService, err := client.CronWorkflowService()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get cron workflow service: %w", err)
+		}
+
+		cw, err := cronService.GetCronWorkflow(ctx, &cronworkflow.GetCronWorkflowRequest{
+			Name:      name,
+			Namespace: namespace,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get cron workflow: %w", err)
+		}
+
+		schedules, location, err := parseCronSchedules(&cw.Spec)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Fire times before the CronWorkflow existed were never expected to run
+		if created := cw.CreationTimestamp.Time; !created.IsZero() && created.After(since) {
+			since = created
+		}
+		fireTimes := fireTimesBetween(schedules, since, now, maxHistoryFireTimes)
+		if len(fireTimes) > maxHistoryFireTimes {
+			return nil, nil, fmt.Errorf("the history window contains more than %d fire times; use a later since", maxHistoryFireTimes)
+		}
+
+		policy := cw.Spec.ConcurrencyPolicy
+		if policy == "" {
+			policy = wfv1.AllowConcurrent
+		}
+		output := &CronWorkflowHistoryOutput{
+			Name:              name,
+			Namespace:         namespace,
+			Timezone:          location.String(),
+			ConcurrencyPolicy: string(policy),
+			Since:             since.In(location).Format(time.RFC3339),
+			Until:             now.In(location).Format(time.RFC3339),
+		}
+
+		runs, err := listCronRuns(ctx, client, namespace, name, output)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		history := buildCronHistory(schedules, fireTimes, runs, since, now, policy, output.ArchiveIncluded)
+		output.History = make([]CronHistoryRun, 0, len(history))
+		for i := range history {
+			output.Summary.add(&history[i])
+			output.History = append(output.History, history[i].format(location, now))
+		}
+		output.Summary.ExpectedRuns = len(fireTimes)
+		output.Streaks = cronStreaks(runs, since)
+		output.Warnings = append(output.Warnings, cronHistoryWarnings(cw, output)...)
+
+		if len(output.History) > limit {
+			output.History = output.History[len(output.History)-limit:]
+			output.Truncated = true
+		}
+
+		return TextResult(formatCronHistory(output)), output, nil
+	}
+}
+
+// listCronRuns returns the child workflows of a CronWorkflow from the cluster
+// and, when available, the workflow archive. Archive failures are reported as
+// warnings on output since live history is still useful on its own.
+func listCronRuns(ctx context.Context, client argo.ClientInterface, namespace, name string, output *CronWorkflowHistoryOutput) ([]*cronRun, error) {
+	selector := common.LabelKeyCronWorkflow + "=" + name
+
+	listResp, err := client.WorkflowService().ListWorkflows(ctx, &workflow.WorkflowListRequest{
+		Namespace:   namespace,
+		ListOptions: &metav1.ListOptions{LabelSelector: selector},
+		Fields:      "items.metadata,items.status.phase,items.status.startedAt,items.status.finishedAt",
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflows: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var runs []*cronRun
+	for i := range listResp.Items {
+		run := newCronRun(name, &listResp.Items[i], runSourceLive)
+		seen[run.uid] = true
+		runs = append(runs, run)
+	}
+
+	if !client.IsArgoServerMode() {
+		output.Warnings = append(output.Warnings, "the workflow archive is only available in Argo Server mode, so only workflows still in the cluster are included")
+		return runs, nil
+	}
+
+	archived, truncated, err := listArchivedCronRuns(ctx, client, namespace, selector)
+	if err != nil {
+		output.Warnings = append(output.Warnings, fmt.Sprintf("archived workflows could not be listed, so only workflows still in the cluster are included: %v", err))
+		return runs, nil
+	}
+	output.ArchiveIncluded = true
+	if truncated {
+		output.Warnings = append(output.Warnings, fmt.Sprintf("only the first %d archived workflows were included", maxHistoryArchivedRuns))
+	}
+	for i := range archived {
+		run := newCronRun(name, &archived[i], runSourceArchived)
+		if !seen[run.uid] {
+			seen[run.uid] = true
+			runs = append(runs, run)
+		}
+	}
+	return runs, nil
+}
+
+// listArchivedCronRuns pages through the archived workflows matching selector,
+// stopping after maxHistoryArchivedRuns and reporting whether more were left.
+func listArchivedCronRuns(ctx context.Context, client argo.ClientInterface, namespace, selector string) ([]wfv1.Workflow, bool, error) {
+	archiveService, err := client.ArchivedWorkflowService()
+	if err != nil {
+		return nil, false, err
+	}
+
+	var items []wfv1.Workflow
+	listOptions := &metav1.ListOptions{LabelSelector: selector, Limit: historyArchivePageSize}
+	for {
+		page, err := archiveService.ListArchivedWorkflows(ctx, &workflowarchive.ListArchivedWorkflowsRequest{
+			Namespace:   namespace,
+			ListOptions: listOptions,
+		})
+		if err != nil {
+			return nil, false, err
+		}
+		items = append(items, page.Items...)
+		if page.Continue == "" {
+			return items, false, nil
+		}
+		if len(items) >= maxHistoryArchivedRuns {
+			return items[:maxHistoryArchivedRuns], true, nil
+		}
+		listOptions = &metav1.ListOptions{LabelSelector: selector, Limit: historyArchivePageSize, Continue: page.Continue}
+	}
+}
+
+// newCronRun converts a child workflow of the named CronWorkflow into a run.
+func newCronRun(cronName string, wf *wfv1.Workflow, source string) *cronRun {
+	return &cronRun{
+		scheduled: cronRunScheduledTime(cronName, wf),
+		started:   wf.Status.StartedAt.Time,
+		finished:  wf.Status.FinishedAt.Time,
+		name:      wf.Name,
+		uid:       string(wf.UID),
+		source:    source,
+		phase:     wf.Status.Phase,
+	}
+}
+
+// cronRunScheduledTime returns the time a child workflow was scheduled for:
+// the scheduled-time annotation, the timestamp the controller puts in the
+// name, or the creation time for workflows that carry neither.
+func cronRunScheduledTime(cronName string, wf *wfv1.Workflow) time.Time {
+	if value, ok := wf.Annotations[common.AnnotationKeyCronWfScheduledTime]; ok {
+		if scheduled, err := time.Parse(time.RFC3339, value); err == nil {
+			return scheduled
+		}
+	}
+	if suffix, ok := strings.CutPrefix(wf.Name, cronName+"-"); ok {
+		if unix, err := strconv.ParseInt(suffix, 10, 64); err == nil {
+			return time.Unix(unix, 0)
+		}
+	}
+	return wf.CreationTimestamp.Time
+}
+
+// cronHistoryEntry is a fire time, a run, or a run at a fire time.
+type cronHistoryEntry struct {
+	scheduledTime time.Time
+	run           *cronRun
+	status        string
+}
+
+// buildCronHistory matches runs to fire times and classifies each fire time
+// and run. Fire times without a run count as missed, or as skipped when
+// concurrencyPolicy Forbid was holding them back behind a running workflow;
+// fire times within historyGracePeriod of now are left out until their run
+// has had time to appear.
+// Without the archive, fire times older than the oldest retained run are
+// unknown since their workflows may have been garbage collected.
+func buildCronHistory(schedules []cronSchedule, fireTimes []time.Time, runs []*cronRun, since, now time.Time, policy wfv1.ConcurrencyPolicy, complete bool) []cronHistoryEntry {
+	flagCronRuns(schedules, runs, now)
+
+	byTime := make(map[int64]*cronRun, len(runs))
+	for _, run := range runs {
+		if _, dup := byTime[run.scheduled.Unix()]; !dup {
+			byTime[run.scheduled.Unix()] = run
+		}
+	}
+
+	var oldest time.Time
+	for _, run := range runs {
+		if oldest.IsZero() || run.scheduled.Before(oldest) {
+			oldest = run.scheduled
+		}
+	}
+
+	matched := make(map[*cronRun]bool, len(runs))
+	entries := make([]cronHistoryEntry, 0, len(fireTimes)+len(runs))
+	for _, fire := range fireTimes {
+		entry := cronHistoryEntry{scheduledTime: fire}
+		switch run := byTime[fire.Unix()]; {
+		case run != nil:
+			entry.run = run
+			entry.status = CronRunStatusRan
+			matched[run] = true
+		case fire.After(now.Add(-historyGracePeriod)):
+			// The run may not have appeared yet
+			continue
+		case !complete && (oldest.IsZero() || fire.Before(oldest)):
+			entry.status = CronRunStatusUnknown
+		case policy == wfv1.ForbidConcurrent && runningAt(runs, fire):
+			entry.status = CronRunStatusSkipped
+		default:
+			entry.status = CronRunStatusMissed
+		}
+		entries = append(entries, entry)
+	}
+
+	// Runs that do not match a fire time were triggered outside the schedule
+	for _, run := range runs {
+		if matched[run] || run.scheduled.Before(since) {
+			continue
+		}
+		run.flags = append(run.flags, CronRunFlagUnscheduled)
+		entries = append(entries, cronHistoryEntry{scheduledTime: run.scheduled, run: run, status: CronRunStatusRan})
+	}
+
+	slices.SortStableFunc(entries, func(a, b cronHistoryEntry) int { return a.scheduledTime.Compare(b.scheduledTime) })
+	return entries
+}
+
+// flagCronRuns flags runs that overlapped an earlier run or ran longer than
+// the interval to the next fire time.
+func flagCronRuns(schedules []cronSchedule, runs []*cronRun, now time.Time) {
+	slices.SortFunc(runs, func(a, b *cronRun) int { return a.startOrScheduled().Compare(b.startOrScheduled()) })
+
+	var busyUntil time.Time
+	for _, run := range runs {
+		start := run.startOrScheduled()
+		end := run.finished
+		if run.running() {
+			end = now
+		}
+
+		if start.Before(busyUntil) {
+			run.flags = append(run.flags, CronRunFlagOverlapping)
+		}
+		if end.After(busyUntil) {
+			busyUntil = end
+		}
+
+		if !run.started.IsZero() && end.Sub(run.started) > nextInterval(schedules, run.scheduled) {
+			run.flags = append(run.flags, CronRunFlagExceededInterval)
+		}
+	}
+}
+
+// startOrScheduled returns when the run started, or when it was scheduled if
+// it has not started.
+func (r *cronRun) startOrScheduled() time.Time {
+	if r.started.IsZero() {
+		return r.scheduled
+	}
+	return r.started
+}
+
+// nextInterval returns the time from t to the next fire time of any schedule.
+func nextInterval(schedules []cronSchedule, t time.Time) time.Duration {
+	interval := time.Duration(math.MaxInt64)
+	for _, s := range schedules {
+		if next := s.schedule.Next(t); !next.IsZero() && next.Sub(t) < interval {
+			interval = next.Sub(t)
+		}
+	}
+	return interval
+}
+
+// runningAt reports whether any run was running at t.
+func runningAt(runs []*cronRun, t time.Time) bool {
+	for _, run := range runs {
+		if run.startOrScheduled().After(t) {
+			continue
+		}
+		if run.running() || run.finished.After(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// add counts entry in the summary.
+func (s *CronHistorySummary) add(entry *cronHistoryEntry) {
+	switch entry.status {
+	case CronRunStatusMissed:
+		s.Missed++
+	case CronRunStatusSkipped:
+		s.Skipped++
+	case CronRunStatusUnknown:
+		s.Unknown++
+	}
+	if entry.run == nil {
+		return
+	}
+
+	s.Runs++
+	switch {
+	case entry.run.phase == wfv1.WorkflowSucceeded:
+		s.Succeeded++
+	case entry.run.phase == wfv1.WorkflowFailed || entry.run.phase == wfv1.WorkflowError:
+		s.Failed++
+	case entry.run.running():
+		s.Running++
+	}
+	for _, flag := range entry.run.flags {
+		switch flag {
+		case CronRunFlagOverlapping:
+			s.Overlapping++
+		case CronRunFlagExceededInterval:
+			s.ExceededInterval++
+		case CronRunFlagUnscheduled:
+			s.Unscheduled++
+		}
+	}
+}
+
+// format converts the entry to its output form.
+func (e *cronHistoryEntry) format(location *time.Location, now time.Time) CronHistoryRun {
+	out := CronHistoryRun{
+		ScheduledTime: e.scheduledTime.In(location).Format(time.RFC3339),
+		Status:        e.status,
+	}
+	if e.run == nil {
+		return out
+	}
+
+	out.Workflow = e.run.name
+	out.UID = e.run.uid
+	out.Source = e.run.source
+	out.Phase = string(e.run.phase)
+	out.Flags = e.run.flags
+	if !e.run.started.IsZero() {
+		out.StartedAt = e.run.started.In(location).Format(time.RFC3339)
+		end := e.run.finished
+		if end.IsZero() {
+			end = now
+		}
+		out.Duration = formatDuration(end.Sub(e.run.started))
+	}
+	if !e.run.finished.IsZero() {
+		out.FinishedAt = e.run.finished.In(location).Format(time.RFC3339)
+	}
+	return out
+}
+
+// cronStreaks computes the success and failure streaks of the runs completed
+// since the given time, in scheduled order.
+func cronStreaks(runs []*cronRun, since time.Time) CronHistoryStreaks {
+	completed := make([]*cronRun, 0, len(runs))
+	for _, run := range runs {
+		if isWorkflowCompleted(run.phase) && !run.scheduled.Before(since) {
+			completed = append(completed, run)
+		}
+	}
+	slices.SortStableFunc(completed, func(a, b *cronRun) int { return a.scheduled.Compare(b.scheduled) })
+
+	var streaks CronHistoryStreaks
+	for _, run := range completed {
+		phase := string(run.phase)
+		if phase == string(wfv1.WorkflowError) {
+			phase = string(wfv1.WorkflowFailed)
+		}
+		if phase == streaks.CurrentPhase {
+			streaks.Current++
+		} else {
+			streaks.CurrentPhase = phase
+			streaks.Current = 1
+		}
+		if phase == string(wfv1.WorkflowSucceeded) {
+			streaks.LongestSuccess = max(streaks.LongestSuccess, streaks.Current)
+		} else {
+			streaks.LongestFailure = max(streaks.LongestFailure, streaks.Current)
+		}
+	}
+	return streaks
+}
+
+// cronHistoryWarnings explains conditions that make missed fire times expected.
+func cronHistoryWarnings(cw *wfv1.CronWorkflow, output *CronWorkflowHistoryOutput) []string {
+	var warnings []string
+	if output.Summary.Missed == 0 {
+		return nil
+	}
+	if cw.Spec.Suspend {
+		warnings = append(warnings, "the CronWorkflow is suspended; fire times while it was suspended are reported as missed")
+	}
+	if cw.Spec.When != "" {
+		warnings = append(warnings, fmt.Sprintf("fire times where the when expression %q was false are reported as missed", cw.Spec.When))
+	}
+	if cw.Status.Phase == wfv1.StoppedPhase {
+		warnings = append(warnings, "the CronWorkflow has been stopped by its stopStrategy; fire times after it stopped are reported as missed")
+	}
+	return warnings
+}
+
+// formatCronHistory builds the human-readable history.
+func formatCronHistory(output *CronWorkflowHistoryOutput) string {
+	var sb strings.Builder
+	s := output.Summary
+	fmt.Fprintf(&sb, "CronWorkflow %q history from %s to %s (%s)\n", output.Name, output.Since, output.Until, output.Timezone)
+	fmt.Fprintf(&sb, "Expected runs: %d, runs: %d (%d succeeded, %d failed, %d running)\n", s.ExpectedRuns, s.Runs, s.Succeeded, s.Failed, s.Running)
+	fmt.Fprintf(&sb, "Missed: %d, skipped (Forbid): %d, overlapping: %d, exceeded interval: %d, unscheduled: %d\n",
+		s.Missed, s.Skipped, s.Overlapping, s.ExceededInterval, s.Unscheduled)
+	if s.Unknown > 0 {
+		fmt.Fprintf(&sb, "Unknown (older than retained workflows): %d\n", s.Unknown)
+	}
+	if output.Streaks.CurrentPhase != "" {
+		fmt.Fprintf(&sb, "Current streak: %d %s, longest success streak: %d, longest failure streak: %d\n",
+			output.Streaks.Current, output.Streaks.CurrentPhase, output.Streaks.LongestSuccess, output.Streaks.LongestFailure)
+	}
+
+	for _, entry := range output.History {
+		if entry.Status == CronRunStatusRan && len(entry.Flags) == 0 {
+			continue
+		}
+		line := fmt.Sprintf("\n  %s  %s", entry.ScheduledTime, entry.Status)
+		if entry.Workflow != "" {
+			line += fmt.Sprintf("  %s (%s)", entry.Workflow, entry.Phase)
+		}
+		if len(entry.Flags) > 0 {
+			line += "  [" + strings.Join(entry.Flags, ", ") + "]"
+		}
+		sb.WriteString(line)
+	}
+	for _, warning := range output.Warnings {
+		fmt.Fprintf(&sb, "\nWarning: %s", warning)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
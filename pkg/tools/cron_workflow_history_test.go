@@ -0,0 +1,263 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowarchive"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestCronWorkflowHistoryTool(t *testing.T) {
+	tool := CronWorkflowHistoryTool()
+
+	assert.Equal(t, "cron_workflow_history", tool.Name)
+	assert.Contains(t, tool.Description, "missed")
+	require.NotNil(t, tool.Annotations)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+}
+
+// childWorkflow returns a child workflow of the "hourly" CronWorkflow. A zero
+// finished time leaves the workflow running.
+func childWorkflow(uid string, scheduled, started, finished time.Time, phase wfv1.WorkflowPhase) wfv1.Workflow {
+	wf := wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        cronChildWorkflowName("hourly", scheduled),
+			UID:         types.UID(uid),
+			Annotations: map[string]string{"workflows.argoproj.io/scheduled-time": scheduled.Format(time.RFC3339)},
+		},
+		Status: wfv1.WorkflowStatus{
+			Phase:     phase,
+			StartedAt: metav1.Time{Time: started},
+		},
+	}
+	if !finished.IsZero() {
+		wf.Status.FinishedAt = metav1.Time{Time: finished}
+	}
+	return wf
+}
+
+func TestCronWorkflowHistoryHandler(t *testing.T) {
+	// Fire at least 29 minutes away from now so no fire time is within the grace period
+	now := time.Now().UTC().Truncate(time.Minute)
+	minute := (now.Minute() + 30) % 60
+	var fires []time.Time
+	for next := now.Add(-3 * time.Hour); len(fires) < 3; next = next.Add(time.Minute) {
+		if next.Minute() == minute {
+			fires = append(fires, next)
+			next = next.Add(59 * time.Minute)
+		}
+	}
+	since := now.Add(-3*time.Hour - time.Minute).Format(time.RFC3339)
+
+	cron := func(policy wfv1.ConcurrencyPolicy) *wfv1.CronWorkflow {
+		return &wfv1.CronWorkflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "hourly", Namespace: "default"},
+			Spec: wfv1.CronWorkflowSpec{
+				Schedules:         []string{fmt.Sprintf("%d * * * *", minute)},
+				ConcurrencyPolicy: policy,
+			},
+		}
+	}
+	selector := mock.MatchedBy(func(req *workflow.WorkflowListRequest) bool {
+		return req.ListOptions.LabelSelector == "workflows.argoproj.io/cron-workflow=hourly"
+	})
+
+	t.Run("correlates live and archived runs", func(t *testing.T) {
+		// The first run fails after running into the second fire time, which
+		// Forbid skips. The third run is archived; a manual run overlaps it.
+		first := childWorkflow("a", fires[0], fires[0], fires[1].Add(10*time.Minute), wfv1.WorkflowFailed)
+		third := childWorkflow("c", fires[2], fires[2], fires[2].Add(5*time.Minute), wfv1.WorkflowSucceeded)
+		archivedThird := third
+		archivedThird.Annotations = nil
+		manual := childWorkflow("d", fires[2].Add(2*time.Minute), fires[2].Add(2*time.Minute), time.Time{}, wfv1.WorkflowRunning)
+		manual.Name = "hourly-manual"
+
+		mockClient := newMockClient(t, "default", true)
+		cronService := newMockCronWorkflowService(t)
+		wfService := newMockWorkflowService(t)
+		archiveService := newMockArchivedWorkflowService(t)
+		mockClient.SetCronWorkflowService(cronService)
+		mockClient.SetWorkflowService(wfService)
+		mockClient.SetArchivedWorkflowService(archiveService)
+
+		cronService.On("GetCronWorkflow", mock.Anything, mock.Anything).Return(cron(wfv1.ForbidConcurrent), nil)
+		wfService.On("ListWorkflows", mock.Anything, selector).Return(&wfv1.WorkflowList{Items: []wfv1.Workflow{first, third, manual}}, nil)
+		archiveService.On("ListArchivedWorkflows", mock.Anything, mock.MatchedBy(func(req *workflowarchive.ListArchivedWorkflowsRequest) bool {
+			return req.Namespace == "default" && req.ListOptions.LabelSelector == "workflows.argoproj.io/cron-workflow=hourly"
+		})).Return(&wfv1.WorkflowList{Items: []wfv1.Workflow{archivedThird}}, nil)
+
+		handler := CronWorkflowHistoryHandler(mockClient)
+		result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, CronWorkflowHistoryInput{Name: "hourly", Since: since})
+		require.NoError(t, err)
+
+		assert.True(t, output.ArchiveIncluded)
+		assert.Equal(t, CronHistorySummary{
+			ExpectedRuns:     3,
+			Runs:             3,
+			Succeeded:        1,
+			Failed:           1,
+			Running:          1,
+			Skipped:          1,
+			Overlapping:      1,
+			ExceededInterval: 1,
+			Unscheduled:      1,
+		}, output.Summary)
+		assert.Equal(t, CronHistoryStreaks{CurrentPhase: "Succeeded", Current: 1, LongestSuccess: 1, LongestFailure: 1}, output.Streaks)
+
+		require.Len(t, output.History, 4)
+		assert.Equal(t, CronRunStatusRan, output.History[0].Status)
+		assert.Equal(t, []string{CronRunFlagExceededInterval}, output.History[0].Flags)
+		assert.Equal(t, CronRunStatusSkipped, output.History[1].Status)
+		assert.Equal(t, runSourceLive, output.History[2].Source)
+		assert.Equal(t, "hourly-manual", output.History[3].Workflow)
+		assert.ElementsMatch(t, []string{CronRunFlagOverlapping, CronRunFlagUnscheduled}, output.History[3].Flags)
+
+		text, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, text.Text, "skipped (Forbid): 1")
+	})
+
+	t.Run("without the archive older fire times are unknown", func(t *testing.T) {
+		mockClient := newMockClient(t, "default", false)
+		cronService := newMockCronWorkflowService(t)
+		wfService := newMockWorkflowService(t)
+		mockClient.SetCronWorkflowService(cronService)
+		mockClient.SetWorkflowService(wfService)
+
+		// Only the second run is retained: the first fire time may have been
+		// garbage collected, but the third was missed
+		cronService.On("GetCronWorkflow", mock.Anything, mock.Anything).Return(cron(""), nil)
+		wfService.On("ListWorkflows", mock.Anything, selector).Return(&wfv1.WorkflowList{Items: []wfv1.Workflow{
+			childWorkflow("b", fires[1], fires[1], fires[1].Add(time.Minute), wfv1.WorkflowSucceeded),
+		}}, nil)
+
+		handler := CronWorkflowHistoryHandler(mockClient)
+		_, output, err := handler(t.Context(), &mcp.CallToolRequest{}, CronWorkflowHistoryInput{Name: "hourly", Since: since, Limit: 2})
+		require.NoError(t, err)
+
+		assert.False(t, output.ArchiveIncluded)
+		assert.Equal(t, 1, output.Summary.Unknown)
+		assert.Equal(t, 1, output.Summary.Missed)
+		assert.Contains(t, output.Warnings[0], "Argo Server mode")
+		assert.True(t, output.Truncated)
+		require.Len(t, output.History, 2)
+		assert.Equal(t, CronRunStatusMissed, output.History[1].Status)
+	})
+
+	t.Run("archive errors are warnings", func(t *testing.T) {
+		mockClient := newMockClient(t, "default", true)
+		cronService := newMockCronWorkflowService(t)
+		wfService := newMockWorkflowService(t)
+		archiveService := newMockArchivedWorkflowService(t)
+		mockClient.SetCronWorkflowService(cronService)
+		mockClient.SetWorkflowService(wfService)
+		mockClient.SetArchivedWorkflowService(archiveService)
+
+		cronService.On("GetCronWorkflow", mock.Anything, mock.Anything).Return(cron(""), nil)
+		wfService.On("ListWorkflows", mock.Anything, selector).Return(&wfv1.WorkflowList{}, nil)
+		archiveService.On("ListArchivedWorkflows", mock.Anything, mock.Anything).Return(nil, errors.New("archive disabled"))
+
+		handler := CronWorkflowHistoryHandler(mockClient)
+		_, output, err := handler(t.Context(), &mcp.CallToolRequest{}, CronWorkflowHistoryInput{Name: "hourly", Since: since})
+		require.NoError(t, err)
+
+		assert.False(t, output.ArchiveIncluded)
+		assert.Equal(t, 3, output.Summary.Unknown)
+		assert.Contains(t, output.Warnings[0], "archive disabled")
+	})
+
+	t.Run("archived runs are paged through", func(t *testing.T) {
+		first := childWorkflow("a", fires[0], fires[0], fires[0].Add(5*time.Minute), wfv1.WorkflowSucceeded)
+		third := childWorkflow("c", fires[2], fires[2], fires[2].Add(5*time.Minute), wfv1.WorkflowSucceeded)
+		mockClient := newMockClient(t, "default", true)
+		cronService := newMockCronWorkflowService(t)
+		wfService := newMockWorkflowService(t)
+		archiveService := newMockArchivedWorkflowService(t)
+		mockClient.SetCronWorkflowService(cronService)
+		mockClient.SetWorkflowService(wfService)
+		mockClient.SetArchivedWorkflowService(archiveService)
+
+		cronService.On("GetCronWorkflow", mock.Anything, mock.Anything).Return(cron(""), nil)
+		wfService.On("ListWorkflows", mock.Anything, selector).Return(&wfv1.WorkflowList{}, nil)
+		firstPage := &wfv1.WorkflowList{Items: []wfv1.Workflow{first}}
+		firstPage.Continue = "500"
+		archiveService.On("ListArchivedWorkflows", mock.Anything, mock.MatchedBy(func(req *workflowarchive.ListArchivedWorkflowsRequest) bool {
+			return req.ListOptions.Limit == historyArchivePageSize && req.ListOptions.Continue == ""
+		})).Return(firstPage, nil).Once()
+		archiveService.On("ListArchivedWorkflows", mock.Anything, mock.MatchedBy(func(req *workflowarchive.ListArchivedWorkflowsRequest) bool {
+			return req.ListOptions.Continue == "500"
+		})).Return(&wfv1.WorkflowList{Items: []wfv1.Workflow{third}}, nil).Once()
+
+		handler := CronWorkflowHistoryHandler(mockClient)
+		_, output, err := handler(t.Context(), &mcp.CallToolRequest{}, CronWorkflowHistoryInput{Name: "hourly", Since: since})
+		require.NoError(t, err)
+
+		assert.True(t, output.ArchiveIncluded)
+		assert.Equal(t, 2, output.Summary.Runs)
+		archiveService.AssertExpectations(t)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		mockClient := newMockClient(t, "default", true)
+		handler := CronWorkflowHistoryHandler(mockClient)
+
+		_, _, err := handler(t.Context(), &mcp.CallToolRequest{}, CronWorkflowHistoryInput{Name: "hourly", Limit: 1000})
+		require.ErrorContains(t, err, "limit must be at most 500")
+
+		_, _, err = handler(t.Context(), &mcp.CallToolRequest{}, CronWorkflowHistoryInput{Name: "hourly", Since: "yesterday"})
+		require.ErrorContains(t, err, "invalid since time")
+
+		_, _, err = handler(t.Context(), &mcp.CallToolRequest{}, CronWorkflowHistoryInput{})
+		require.Error(t, err)
+	})
+}
+
+func TestCronRunScheduledTime(t *testing.T) {
+	scheduled := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	created := metav1.Time{Time: scheduled.Add(time.Second)}
+
+	annotated := wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{
+		Name:        "hourly-abc12",
+		Annotations: map[string]string{"workflows.argoproj.io/scheduled-time": "2025-01-15T10:00:00Z"},
+	}}
+	named := wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "hourly-1736935200", CreationTimestamp: created}}
+	other := wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "hourly-abc12", CreationTimestamp: created}}
+
+	assert.True(t, scheduled.Equal(cronRunScheduledTime("hourly", &annotated)))
+	assert.True(t, scheduled.Equal(cronRunScheduledTime("hourly", &named)))
+	assert.True(t, created.Time.Equal(cronRunScheduledTime("hourly", &other)))
+}
+
+func TestBuildCronHistory_GracePeriod(t *testing.T) {
+	schedules, _, err := parseCronSchedules(&wfv1.CronWorkflowSpec{Schedules: []string{"* * * * *"}})
+	require.NoError(t, err)
+
+	// A run started at the fire time 30s ago matches it; the fire time
+	// without a run is not yet missed
+	now := time.Date(2025, 1, 15, 10, 2, 30, 0, time.UTC)
+	since := now.Add(-2 * time.Minute)
+	recent := &cronRun{scheduled: now.Add(-30 * time.Second), started: now.Add(-30 * time.Second), name: "every-minute-1", phase: wfv1.WorkflowRunning}
+	fireTimes := fireTimesBetween(schedules, since, now, maxHistoryFireTimes)
+	require.Len(t, fireTimes, 2)
+
+	history := buildCronHistory(schedules, fireTimes, []*cronRun{recent}, since, now, wfv1.AllowConcurrent, true)
+	require.Len(t, history, 2)
+	assert.Equal(t, CronRunStatusMissed, history[0].status)
+	assert.Equal(t, CronRunStatusRan, history[1].status)
+	assert.Same(t, recent, history[1].run)
+	assert.Empty(t, recent.flags)
+
+	history = buildCronHistory(schedules, fireTimes, nil, since, now, wfv1.AllowConcurrent, true)
+	require.Len(t, history, 1)
+	assert.Equal(t, CronRunStatusMissed, history[0].status)
+}
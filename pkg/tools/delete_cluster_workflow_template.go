@@ -16,6 +16,9 @@ import (
 type DeleteClusterWorkflowTemplateInput struct {
 	// Name is the ClusterWorkflowTemplate name.
 	Name string `json:"name" jsonschema:"ClusterWorkflowTemplate name,required"`
+
+	// Force deletes the template even if other resources reference it.
+	Force bool `json:"force,omitempty" jsonschema:"Delete even if WorkflowTemplates, ClusterWorkflowTemplates or CronWorkflows in any namespace still reference it"`
 }
 
 // DeleteClusterWorkflowTemplateOutput defines the output for the delete_cluster_workflow_template tool.
@@ -31,7 +34,7 @@ type DeleteClusterWorkflowTemplateOutput struct {
 func DeleteClusterWorkflowTemplateTool() *mcp.Tool {
 	return &mcp.Tool{
 		Name:        "delete_cluster_workflow_template",
-		Description: "Delete a ClusterWorkflowTemplate",
+		Description: "Delete a ClusterWorkflowTemplate. Refuses if WorkflowTemplates, ClusterWorkflowTemplates or CronWorkflows in any namespace still reference it (see find_template_usages), or if they cannot all be checked, unless force is set.",
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: ptr.To(true),
 		},
@@ -47,6 +50,20 @@ func DeleteClusterWorkflowTemplateHandler(client argo.ClientInterface) func(cont
 			return nil, nil, err
 		}
 
+		// Refuse to break the resources that still reference the template
+		if !input.Force {
+			scan, scanErr := findTemplateUsages(ctx, client, templateTarget{name: name, clusterScope: true}, "", 0)
+			if scanErr != nil {
+				return nil, nil, fmt.Errorf("failed to check for usages, set force to delete anyway: %w", scanErr)
+			}
+			if len(scan.usages) > 0 {
+				return nil, nil, templateInUseError(KindClusterWorkflowTemplate, name, scan.usages)
+			}
+			if len(scan.warnings) > 0 {
+				return nil, nil, incompleteUsageScanError(KindClusterWorkflowTemplate, name, scan.warnings)
+			}
+		}
+
 		// Get the cluster workflow template service client
 		cwftService, err := client.ClusterWorkflowTemplateService()
 		if err != nil {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/clusterworkflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -143,6 +144,42 @@ func TestDeleteClusterWorkflowTemplateHandler(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "error - refuses while referenced",
+			input: DeleteClusterWorkflowTemplateInput{
+				Name: "my-cluster-template",
+			},
+			setupMock: func(m *mocks.MockClusterWorkflowTemplateServiceClient) {
+				caller := usingWorkflowTemplate("caller", "my-cluster-template", true)
+				m.On("ListClusterWorkflowTemplates", mock.Anything, mock.Anything).Return(&wfv1.ClusterWorkflowTemplateList{
+					Items: []wfv1.ClusterWorkflowTemplate{{ObjectMeta: caller.ObjectMeta, Spec: caller.Spec}},
+				}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error - refuses when usages cannot be checked",
+			input: DeleteClusterWorkflowTemplateInput{
+				Name: "my-cluster-template",
+			},
+			setupMock: func(m *mocks.MockClusterWorkflowTemplateServiceClient) {
+				m.On("ListClusterWorkflowTemplates", mock.Anything, mock.Anything).Return(nil, status.Error(codes.PermissionDenied, "forbidden"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "success - force skips the usage check",
+			input: DeleteClusterWorkflowTemplateInput{
+				Name:  "my-cluster-template",
+				Force: true,
+			},
+			setupMock: func(m *mocks.MockClusterWorkflowTemplateServiceClient) {
+				m.On("DeleteClusterWorkflowTemplate", mock.Anything, mock.Anything).Return(&clusterworkflowtemplate.ClusterWorkflowTemplateDeleteResponse{}, nil)
+			},
+			validate: func(t *testing.T, output *DeleteClusterWorkflowTemplateOutput, _ *mcp.CallToolResult) {
+				assert.Equal(t, "my-cluster-template", output.Name)
+			},
+		},
 		{
 			name: "error - API error (connection refused)",
 			input: DeleteClusterWorkflowTemplateInput{
@@ -167,6 +204,7 @@ func TestDeleteClusterWorkflowTemplateHandler(t *testing.T) {
 
 			// Setup mock expectations
 			tt.setupMock(mockService)
+			allowUsageScan(t, mockClient, nil, mockService)
 
 			// Verify mock expectations even on error paths
 			defer mockService.AssertExpectations(t)
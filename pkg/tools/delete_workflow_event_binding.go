@@ -0,0 +1,75 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
+)
+
+// DeleteWorkflowEventBindingInput defines the input parameters for the delete_workflow_event_binding tool.
+type DeleteWorkflowEventBindingInput struct {
+	// Namespace is the Kubernetes namespace (uses default if not specified).
+	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace (uses default if not specified)"`
+
+	// Name is the WorkflowEventBinding name.
+	Name string `json:"name" jsonschema:"WorkflowEventBinding name,required"`
+}
+
+// DeleteWorkflowEventBindingOutput defines the output for the delete_workflow_event_binding tool.
+type DeleteWorkflowEventBindingOutput struct {
+	// Name is the deleted binding name.
+	Name string `json:"name"`
+
+	// Namespace is the namespace where the binding was deleted.
+	Namespace string `json:"namespace"`
+
+	// Message provides confirmation of the deletion.
+	Message string `json:"message"`
+}
+
+// DeleteWorkflowEventBindingTool returns the MCP tool definition for delete_workflow_event_binding.
+func DeleteWorkflowEventBindingTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "delete_workflow_event_binding",
+		Description: "Delete a WorkflowEventBinding, so that matching events no longer submit workflows. Requires direct Kubernetes API access.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr.To(true),
+		},
+	}
+}
+
+// DeleteWorkflowEventBindingHandler returns a handler function for the delete_workflow_event_binding tool.
+func DeleteWorkflowEventBindingHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, DeleteWorkflowEventBindingInput) (*mcp.CallToolResult, *DeleteWorkflowEventBindingOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input DeleteWorkflowEventBindingInput) (*mcp.CallToolResult, *DeleteWorkflowEventBindingOutput, error) {
+		name := strings.TrimSpace(input.Name)
+		if name == "" {
+			return nil, nil, toolerror.Invalid("name", errors.New("workflow event binding name cannot be empty"))
+		}
+		namespace := ResolveNamespace(input.Namespace, client)
+
+		wfClient, err := client.WorkflowClientset()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get workflow clientset: %w", err)
+		}
+		err = wfClient.ArgoprojV1alpha1().WorkflowEventBindings(namespace).Delete(ctx, name, metav1.DeleteOptions{})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to delete workflow event binding: %w", err)
+		}
+
+		output := &DeleteWorkflowEventBindingOutput{
+			Name:      name,
+			Namespace: namespace,
+			Message:   fmt.Sprintf("WorkflowEventBinding %q deleted successfully", name),
+		}
+		return TextResult(fmt.Sprintf("WorkflowEventBinding %q in namespace %q deleted successfully", name, namespace)), output, nil
+	}
+}
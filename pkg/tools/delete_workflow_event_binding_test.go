@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"testing"
+
+	wffake "github.com/argoproj/argo-workflows/v4/pkg/client/clientset/versioned/fake"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
+)
+
+func TestDeleteWorkflowEventBindingTool(t *testing.T) {
+	tool := DeleteWorkflowEventBindingTool()
+
+	assert.Equal(t, "delete_workflow_event_binding", tool.Name)
+	require.NotNil(t, tool.Annotations)
+	require.NotNil(t, tool.Annotations.DestructiveHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+}
+
+func TestDeleteWorkflowEventBindingHandler(t *testing.T) {
+	t.Run("deletes the binding", func(t *testing.T) {
+		clientset := wffake.NewSimpleClientset(pushEventBinding("on-push", "argo"))
+		mockClient := newMockClient(t, "argo", false)
+		mockClient.SetWorkflowClientset(clientset)
+
+		_, output, err := DeleteWorkflowEventBindingHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, DeleteWorkflowEventBindingInput{Name: "on-push"})
+		require.NoError(t, err)
+		assert.Equal(t, `WorkflowEventBinding "on-push" deleted successfully`, output.Message)
+
+		_, err = clientset.ArgoprojV1alpha1().WorkflowEventBindings("argo").Get(t.Context(), "on-push", metav1.GetOptions{})
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", false)
+		mockClient.SetWorkflowClientset(wffake.NewSimpleClientset())
+
+		_, _, err := DeleteWorkflowEventBindingHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, DeleteWorkflowEventBindingInput{Name: "missing"})
+		require.Error(t, err)
+		assert.Equal(t, toolerror.CategoryNotFound, toolerror.Classify(err).Category)
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		_, _, err := DeleteWorkflowEventBindingHandler(newMockClient(t, "argo", false))(t.Context(), &mcp.CallToolRequest{}, DeleteWorkflowEventBindingInput{Name: " "})
+		require.Error(t, err)
+		assert.Equal(t, "name", toolerror.Classify(err).Field)
+	})
+}
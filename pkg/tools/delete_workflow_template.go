@@ -19,6 +19,9 @@ type DeleteWorkflowTemplateInput struct {
 
 	// Name is the WorkflowTemplate name.
 	Name string `json:"name" jsonschema:"WorkflowTemplate name,required"`
+
+	// Force deletes the template even if other resources reference it.
+	Force bool `json:"force,omitempty" jsonschema:"Delete even if WorkflowTemplates, ClusterWorkflowTemplates or CronWorkflows still reference it"`
 }
 
 // DeleteWorkflowTemplateOutput defines the output for the delete_workflow_template tool.
@@ -37,7 +40,7 @@ type DeleteWorkflowTemplateOutput struct {
 func DeleteWorkflowTemplateTool() *mcp.Tool {
 	return &mcp.Tool{
 		Name:        "delete_workflow_template",
-		Description: "Delete a WorkflowTemplate",
+		Description: "Delete a WorkflowTemplate. Refuses if WorkflowTemplates, ClusterWorkflowTemplates or CronWorkflows still reference it (see find_template_usages), or if they cannot all be checked, unless force is set.",
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: ptr.To(true),
 		},
@@ -56,6 +59,20 @@ func DeleteWorkflowTemplateHandler(client argo.ClientInterface) func(context.Con
 		// Determine namespace
 		namespace := ResolveNamespace(input.Namespace, client)
 
+		// Refuse to break the resources that still reference the template
+		if !input.Force {
+			scan, scanErr := findTemplateUsages(ctx, client, templateTarget{name: name}, namespace, 0)
+			if scanErr != nil {
+				return nil, nil, fmt.Errorf("failed to check for usages, set force to delete anyway: %w", scanErr)
+			}
+			if len(scan.usages) > 0 {
+				return nil, nil, templateInUseError(KindWorkflowTemplate, name, scan.usages)
+			}
+			if len(scan.warnings) > 0 {
+				return nil, nil, incompleteUsageScanError(KindWorkflowTemplate, name, scan.warnings)
+			}
+		}
+
 		// Get the workflow template service client
 		wftService, err := client.WorkflowTemplateService()
 		if err != nil {
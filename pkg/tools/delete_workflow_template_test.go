@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -175,6 +176,33 @@ func TestDeleteWorkflowTemplateHandler(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "error - refuses while referenced",
+			input: DeleteWorkflowTemplateInput{
+				Namespace: "default",
+				Name:      "my-template",
+			},
+			setupMock: func(m *mocks.MockWorkflowTemplateServiceClient) {
+				m.On("ListWorkflowTemplates", mock.Anything, mock.Anything).Return(&wfv1.WorkflowTemplateList{
+					Items: []wfv1.WorkflowTemplate{usingWorkflowTemplate("caller", "my-template", false)},
+				}, nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "success - force skips the usage check",
+			input: DeleteWorkflowTemplateInput{
+				Namespace: "default",
+				Name:      "my-template",
+				Force:     true,
+			},
+			setupMock: func(m *mocks.MockWorkflowTemplateServiceClient) {
+				m.On("DeleteWorkflowTemplate", mock.Anything, mock.Anything).Return(&workflowtemplate.WorkflowTemplateDeleteResponse{}, nil)
+			},
+			validate: func(t *testing.T, output *DeleteWorkflowTemplateOutput, _ *mcp.CallToolResult) {
+				assert.Equal(t, "my-template", output.Name)
+			},
+		},
 		{
 			name: "error - API error (connection refused)",
 			input: DeleteWorkflowTemplateInput{
@@ -200,6 +228,7 @@ func TestDeleteWorkflowTemplateHandler(t *testing.T) {
 
 			// Setup mock expectations
 			tt.setupMock(mockService)
+			allowUsageScan(t, mockClient, mockService, nil)
 
 			// Verify mock expectations even on error paths
 			defer mockService.AssertExpectations(t)
@@ -223,3 +252,20 @@ func TestDeleteWorkflowTemplateHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestDeleteWorkflowTemplateHandler_SkipsClusterTemplateScan(t *testing.T) {
+	// ClusterWorkflowTemplates cannot reference a WorkflowTemplate, so a
+	// server without cluster-scope RBAC can still delete one
+	mockClient := newMockClient(t, "argo", true)
+	mockService := newMockWorkflowTemplateService(t)
+	mockClient.SetWorkflowTemplateService(mockService)
+	mockCwftService := newMockClusterWorkflowTemplateService(t)
+	mockClient.SetClusterWorkflowTemplateService(mockCwftService)
+	mockService.On("DeleteWorkflowTemplate", mock.Anything, mock.Anything).Return(&workflowtemplate.WorkflowTemplateDeleteResponse{}, nil)
+	allowUsageScan(t, mockClient, mockService, mockCwftService)
+
+	_, output, err := DeleteWorkflowTemplateHandler(mockClient)(t.Context(), nil, DeleteWorkflowTemplateInput{Name: "my-template"})
+	require.NoError(t, err)
+	assert.Equal(t, "my-template", output.Name)
+	mockCwftService.AssertNotCalled(t, "ListClusterWorkflowTemplates", mock.Anything, mock.Anything)
+}
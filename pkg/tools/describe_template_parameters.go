@@ -0,0 +1,280 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/utils/ptr"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// Parameter sources reported by describe_template_parameters.
+const (
+	// ParameterSourceArguments marks a parameter declared in spec.arguments.
+	ParameterSourceArguments = "arguments"
+	// ParameterSourceEntrypoint marks an entrypoint input not declared in spec.arguments.
+	ParameterSourceEntrypoint = "entrypoint"
+)
+
+// jsonSchemaDraft is the JSON Schema dialect of generated parameter schemas.
+const jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// DescribeTemplateParametersInput defines the input parameters for the describe_template_parameters tool.
+type DescribeTemplateParametersInput struct {
+	// Namespace is the Kubernetes namespace (uses default if not specified).
+	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace (uses default if not specified)"`
+
+	// Name is the template name.
+	Name string `json:"name,omitempty" jsonschema:"WorkflowTemplate or ClusterWorkflowTemplate name (mutually exclusive with manifest)"`
+
+	// ClusterScope selects a ClusterWorkflowTemplate instead of a WorkflowTemplate.
+	ClusterScope bool `json:"clusterScope,omitempty" jsonschema:"Describe a ClusterWorkflowTemplate instead of a WorkflowTemplate"`
+
+	// Manifest describes a manifest offline instead of a template in the cluster.
+	Manifest string `json:"manifest,omitempty" jsonschema:"Workflow, WorkflowTemplate, ClusterWorkflowTemplate or CronWorkflow YAML to describe offline (mutually exclusive with name)"`
+}
+
+// ParameterSchema is a flat JSON Schema object for a template's parameters.
+// It only uses the subset of JSON Schema that MCP elicitation accepts.
+type ParameterSchema struct {
+	// Properties maps parameter names to their schemas.
+	Properties map[string]ParameterProperty `json:"properties"`
+
+	// Schema is the JSON Schema dialect.
+	Schema string `json:"$schema,omitempty"`
+
+	// Type is always "object".
+	Type string `json:"type"`
+
+	// Required lists the parameters that have no value.
+	Required []string `json:"required,omitempty"`
+}
+
+// ParameterProperty is the JSON Schema of a single parameter.
+type ParameterProperty struct {
+	// Default is the value used when the parameter is not set.
+	Default *string `json:"default,omitempty"`
+
+	// Type is always "string", as Argo parameters are strings.
+	Type string `json:"type"`
+
+	// Title is the parameter name.
+	Title string `json:"title,omitempty"`
+
+	// Description is the parameter description.
+	Description string `json:"description,omitempty"`
+
+	// Enum lists the allowed values.
+	Enum []string `json:"enum,omitempty"`
+}
+
+// TemplateParameter describes a parameter accepted at submission.
+type TemplateParameter struct {
+	// Default is the value used when the parameter is not set.
+	Default *string `json:"default,omitempty"`
+
+	// Name is the parameter name.
+	Name string `json:"name"`
+
+	// Description is the parameter description.
+	Description string `json:"description,omitempty"`
+
+	// Source is where the parameter is declared: arguments or entrypoint.
+	Source string `json:"source"`
+
+	// Enum lists the allowed values.
+	Enum []string `json:"enum,omitempty"`
+
+	// Required indicates the parameter has no value and must be provided.
+	Required bool `json:"required"`
+}
+
+// DescribeTemplateParametersOutput defines the output for the describe_template_parameters tool.
+type DescribeTemplateParametersOutput struct {
+	// Kind is the kind of the described resource.
+	Kind string `json:"kind"`
+
+	// Name is the resource name.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of a WorkflowTemplate read from the cluster.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Entrypoint is the entrypoint template.
+	Entrypoint string `json:"entrypoint,omitempty"`
+
+	// Parameters lists the parameters in declaration order.
+	Parameters []TemplateParameter `json:"parameters"`
+
+	// Schema is the JSON Schema of the parameters.
+	Schema ParameterSchema `json:"schema"`
+}
+
+// DescribeTemplateParametersTool returns the MCP tool definition for describe_template_parameters.
+func DescribeTemplateParametersTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "describe_template_parameters",
+		Description: "Describe the parameters a WorkflowTemplate or ClusterWorkflowTemplate accepts at submission as a JSON Schema, " +
+			"with descriptions, defaults, enum choices and which parameters are required. Also works offline on a manifest.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+}
+
+// DescribeTemplateParametersHandler returns a handler function for the describe_template_parameters tool.
+func DescribeTemplateParametersHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, DescribeTemplateParametersInput) (*mcp.CallToolResult, *DescribeTemplateParametersOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input DescribeTemplateParametersInput) (*mcp.CallToolResult, *DescribeTemplateParametersOutput, error) {
+		hasManifest := strings.TrimSpace(input.Manifest) != ""
+		if hasManifest == (strings.TrimSpace(input.Name) != "") {
+			return nil, nil, fmt.Errorf("exactly one of name or manifest must be provided")
+		}
+
+		output := &DescribeTemplateParametersOutput{}
+		var spec *wfv1.WorkflowSpec
+		if hasManifest {
+			const maxManifestBytes = 1 << 20 // 1 MiB
+			if len(input.Manifest) > maxManifestBytes {
+				return nil, nil, fmt.Errorf("manifest too large (%d bytes), max %d", len(input.Manifest), maxManifestBytes)
+			}
+			var err error
+			spec, output.Kind, output.Name, err = extractWorkflowSpec(input.Manifest)
+			if err != nil {
+				return nil, nil, err
+			}
+		} else {
+			name, err := ValidateName(input.Name)
+			if err != nil {
+				return nil, nil, err
+			}
+			output.Kind = templateRefKind(input.ClusterScope)
+			output.Name = name
+			if !input.ClusterScope {
+				output.Namespace = ResolveNamespace(input.Namespace, client)
+			}
+			source := &liveTemplateSource{client: client, namespace: output.Namespace}
+			spec, err = source.templateSpec(ctx, input.ClusterScope, name)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		output.Entrypoint = spec.Entrypoint
+		output.Parameters = templateParameters(spec)
+		output.Schema = parameterSchema(output.Parameters)
+
+		return TextResult(formatTemplateParameters(output)), output, nil
+	}
+}
+
+// templateParameters lists the parameters a submission of spec accepts: the
+// workflow arguments, followed by entrypoint inputs the arguments don't set.
+func templateParameters(spec *wfv1.WorkflowSpec) []TemplateParameter {
+	params := make([]TemplateParameter, 0, len(spec.Arguments.Parameters))
+	for _, param := range spec.Arguments.Parameters {
+		// An argument's value is its default, anything else is required
+		info := newTemplateParameter(param, ParameterSourceArguments)
+		switch {
+		case param.Value != nil:
+			info.Default = ptr.To(param.Value.String())
+		case param.Default != nil:
+			info.Default = ptr.To(param.Default.String())
+		}
+		info.Required = info.Default == nil && param.ValueFrom == nil
+		params = append(params, info)
+	}
+
+	idx := slices.IndexFunc(spec.Templates, func(tmpl wfv1.Template) bool { return tmpl.Name == spec.Entrypoint })
+	if idx < 0 {
+		return params
+	}
+	for _, param := range spec.Templates[idx].Inputs.Parameters {
+		if slices.ContainsFunc(params, func(p TemplateParameter) bool { return p.Name == param.Name }) {
+			continue
+		}
+		info := newTemplateParameter(param, ParameterSourceEntrypoint)
+		switch {
+		case param.Value != nil:
+			// A fixed input value can't be overridden from the arguments
+			continue
+		case param.Default != nil:
+			info.Default = ptr.To(param.Default.String())
+		}
+		info.Required = info.Default == nil && param.ValueFrom == nil
+		params = append(params, info)
+	}
+	return params
+}
+
+// newTemplateParameter copies the name, description and enum of param.
+func newTemplateParameter(param wfv1.Parameter, source string) TemplateParameter {
+	info := TemplateParameter{Name: param.Name, Source: source}
+	if param.Description != nil {
+		info.Description = param.Description.String()
+	}
+	for _, value := range param.Enum {
+		info.Enum = append(info.Enum, string(value))
+	}
+	return info
+}
+
+// parameterSchema builds the JSON Schema of params.
+func parameterSchema(params []TemplateParameter) ParameterSchema {
+	schema := ParameterSchema{
+		Schema:     jsonSchemaDraft,
+		Type:       "object",
+		Properties: make(map[string]ParameterProperty, len(params)),
+	}
+	for _, param := range params {
+		schema.Properties[param.Name] = ParameterProperty{
+			Type:        "string",
+			Title:       param.Name,
+			Description: param.Description,
+			Default:     param.Default,
+			Enum:        param.Enum,
+		}
+		if param.Required {
+			schema.Required = append(schema.Required, param.Name)
+		}
+	}
+	return schema
+}
+
+// formatTemplateParameters formats the parameters as human-readable text.
+func formatTemplateParameters(output *DescribeTemplateParametersOutput) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %q", output.Kind, output.Name)
+	if output.Namespace != "" {
+		fmt.Fprintf(&sb, " in namespace %q", output.Namespace)
+	}
+	if len(output.Parameters) == 0 {
+		sb.WriteString(" takes no parameters")
+		return sb.String()
+	}
+	fmt.Fprintf(&sb, " takes %d parameter(s), %d required:", len(output.Parameters), len(output.Schema.Required))
+
+	for _, param := range output.Parameters {
+		fmt.Fprintf(&sb, "\n  %s", param.Name)
+		switch {
+		case param.Required:
+			sb.WriteString(" (required)")
+		case param.Default != nil:
+			fmt.Fprintf(&sb, " (default %q)", *param.Default)
+		default:
+			sb.WriteString(" (from valueFrom)")
+		}
+		if len(param.Enum) > 0 {
+			fmt.Fprintf(&sb, " one of: %s", strings.Join(param.Enum, ", "))
+		}
+		if param.Description != "" {
+			fmt.Fprintf(&sb, " - %s", param.Description)
+		}
+	}
+	return sb.String()
+}
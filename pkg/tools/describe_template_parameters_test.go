@@ -0,0 +1,157 @@
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/clusterworkflowtemplate"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+)
+
+// parameterizedSpec has a required argument, an enum with a default, a
+// valueFrom argument and entrypoint inputs.
+func parameterizedSpec() wfv1.WorkflowSpec {
+	return wfv1.WorkflowSpec{
+		Entrypoint: "main",
+		Arguments: wfv1.Arguments{Parameters: []wfv1.Parameter{
+			{Name: "image", Description: wfv1.AnyStringPtr("Image to deploy")},
+			{Name: "env", Value: wfv1.AnyStringPtr("dev"), Enum: []wfv1.AnyString{"dev", "staging", "prod"}},
+			{Name: "config", ValueFrom: &wfv1.ValueFrom{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "settings"}, Key: "config"}}},
+		}},
+		Templates: []wfv1.Template{{
+			Name: "main",
+			Inputs: wfv1.Inputs{Parameters: []wfv1.Parameter{
+				{Name: "image"},
+				{Name: "replicas", Default: wfv1.AnyStringPtr("1")},
+				{Name: "region"},
+				{Name: "fixed", Value: wfv1.AnyStringPtr("x")},
+			}},
+			Container: &corev1.Container{Image: "alpine:3.19"},
+		}},
+	}
+}
+
+func TestDescribeTemplateParametersTool(t *testing.T) {
+	tool := DescribeTemplateParametersTool()
+
+	assert.Equal(t, "describe_template_parameters", tool.Name)
+	assert.Contains(t, tool.Description, "JSON Schema")
+	require.NotNil(t, tool.Annotations)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+}
+
+func TestDescribeTemplateParametersHandler(t *testing.T) {
+	t.Run("describes a WorkflowTemplate", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", true)
+		wftService := newMockWorkflowTemplateService(t)
+		mockClient.SetWorkflowTemplateService(wftService)
+		wftService.On("GetWorkflowTemplate", mock.Anything, &workflowtemplate.WorkflowTemplateGetRequest{Name: "deploy", Namespace: "argo"}).
+			Return(&wfv1.WorkflowTemplate{Spec: parameterizedSpec()}, nil)
+		defer wftService.AssertExpectations(t)
+
+		handler := DescribeTemplateParametersHandler(mockClient)
+		result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, DescribeTemplateParametersInput{Name: "deploy"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "WorkflowTemplate", output.Kind)
+		assert.Equal(t, "argo", output.Namespace)
+		assert.Equal(t, "main", output.Entrypoint)
+		assert.Equal(t, []TemplateParameter{
+			{Name: "image", Description: "Image to deploy", Source: ParameterSourceArguments, Required: true},
+			{Name: "env", Default: ptr.To("dev"), Source: ParameterSourceArguments, Enum: []string{"dev", "staging", "prod"}},
+			{Name: "config", Source: ParameterSourceArguments},
+			{Name: "replicas", Default: ptr.To("1"), Source: ParameterSourceEntrypoint},
+			{Name: "region", Source: ParameterSourceEntrypoint, Required: true},
+		}, output.Parameters)
+
+		assert.Equal(t, []string{"image", "region"}, output.Schema.Required)
+		schema, err := json.Marshal(output.Schema)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"required": ["image", "region"],
+			"properties": {
+				"image": {"type": "string", "title": "image", "description": "Image to deploy"},
+				"env": {"type": "string", "title": "env", "default": "dev", "enum": ["dev", "staging", "prod"]},
+				"config": {"type": "string", "title": "config"},
+				"replicas": {"type": "string", "title": "replicas", "default": "1"},
+				"region": {"type": "string", "title": "region"}
+			}
+		}`, string(schema))
+
+		text, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, text.Text, `WorkflowTemplate "deploy" in namespace "argo" takes 5 parameter(s), 2 required:`)
+		assert.Contains(t, text.Text, "\n  image (required) - Image to deploy")
+		assert.Contains(t, text.Text, `env (default "dev") one of: dev, staging, prod`)
+		assert.Contains(t, text.Text, "config (from valueFrom)")
+	})
+
+	t.Run("describes a ClusterWorkflowTemplate", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", true)
+		cwftService := newMockClusterWorkflowTemplateService(t)
+		mockClient.SetClusterWorkflowTemplateService(cwftService)
+		cwftService.On("GetClusterWorkflowTemplate", mock.Anything, &clusterworkflowtemplate.ClusterWorkflowTemplateGetRequest{Name: "shared"}).
+			Return(&wfv1.ClusterWorkflowTemplate{}, nil)
+		defer cwftService.AssertExpectations(t)
+
+		handler := DescribeTemplateParametersHandler(mockClient)
+		result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, DescribeTemplateParametersInput{Name: "shared", ClusterScope: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, "ClusterWorkflowTemplate", output.Kind)
+		assert.Empty(t, output.Namespace)
+		assert.Empty(t, output.Parameters)
+
+		text, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, `ClusterWorkflowTemplate "shared" takes no parameters`, text.Text)
+	})
+
+	t.Run("describes a manifest offline", func(t *testing.T) {
+		handler := DescribeTemplateParametersHandler(nil)
+		_, output, err := handler(t.Context(), &mcp.CallToolRequest{}, DescribeTemplateParametersInput{Manifest: `apiVersion: argoproj.io/v1alpha1
+kind: CronWorkflow
+metadata:
+  name: nightly
+spec:
+  schedules: ["0 2 * * *"]
+  workflowSpec:
+    arguments:
+      parameters:
+      - name: date
+`})
+		require.NoError(t, err)
+		assert.Equal(t, "CronWorkflow", output.Kind)
+		assert.Equal(t, "nightly", output.Name)
+		assert.Equal(t, []string{"date"}, output.Schema.Required)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		handler := DescribeTemplateParametersHandler(nil)
+		_, _, err := handler(t.Context(), &mcp.CallToolRequest{}, DescribeTemplateParametersInput{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exactly one of name or manifest")
+
+		_, _, err = handler(t.Context(), &mcp.CallToolRequest{}, DescribeTemplateParametersInput{Name: "a", Manifest: "kind: Workflow"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exactly one of name or manifest")
+
+		mockClient := newMockClient(t, "argo", true)
+		wftService := newMockWorkflowTemplateService(t)
+		mockClient.SetWorkflowTemplateService(wftService)
+		wftService.On("GetWorkflowTemplate", mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+		_, _, err = DescribeTemplateParametersHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, DescribeTemplateParametersInput{Name: "missing"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `failed to get WorkflowTemplate "missing"`)
+	})
+}
@@ -0,0 +1,642 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/clusterworkflowtemplate"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	wfutil "github.com/argoproj/argo-workflows/v4/workflow/util"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/bundle"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/prompts"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
+)
+
+const (
+	// defaultExportLogBudget is the default total size of the logs in a bundle (5 MiB).
+	defaultExportLogBudget = 5 << 20
+
+	// maxExportLogBudget is the largest log budget an export may ask for (50 MiB).
+	maxExportLogBudget = 50 << 20
+
+	// minExportPodLogBytes is the smallest share of the log budget a pod's log
+	// is exported with (4 KiB); pods left without it are omitted.
+	minExportPodLogBytes = 4 << 10
+
+	// defaultExportArtifactBytes is the default size limit of an included artifact (1 MiB).
+	defaultExportArtifactBytes = 1 << 20
+
+	// maxExportArtifactBytes is the largest per-artifact limit an export may ask for (10 MiB).
+	maxExportArtifactBytes = 10 << 20
+
+	// maxExportArtifactTotal bounds the combined size of the included artifacts (20 MiB).
+	maxExportArtifactTotal = 20 << 20
+)
+
+// Export destinations.
+const (
+	// ExportDestinationResource returns the bundle as an embedded resource.
+	ExportDestinationResource = "resource"
+
+	// ExportDestinationDirectory writes the bundle to the configured export directory.
+	ExportDestinationDirectory = "directory"
+)
+
+// ExportWorkflowInput defines the input parameters for the export_workflow tool.
+type ExportWorkflowInput struct {
+	// LogBudgetBytes is the total size of the logs to include.
+	LogBudgetBytes *int64 `json:"logBudgetBytes,omitempty" jsonschema:"Total size of the logs to include in bytes (default: 5 MiB, max: 50 MiB)"`
+
+	// MaxArtifactBytes is the size limit of an included artifact.
+	MaxArtifactBytes *int64 `json:"maxArtifactBytes,omitempty" jsonschema:"Size limit of an included artifact in bytes (default: 1 MiB, max: 10 MiB)"`
+
+	// Namespace is the Kubernetes namespace (uses default if not specified).
+	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace (uses default if not specified)"`
+
+	// Name is the workflow name.
+	Name string `json:"name" jsonschema:"Workflow name,required"`
+
+	// Destination is where the bundle goes.
+	Destination string `json:"destination,omitempty" jsonschema:"Where to put the bundle: 'resource' returns it as an embedded resource, 'directory' writes it to the configured export directory (default: directory when one is configured, otherwise resource),enum=resource,enum=directory"`
+
+	// IncludeArtifacts downloads small output artifacts into the bundle.
+	IncludeArtifacts bool `json:"includeArtifacts,omitempty" jsonschema:"Include the content of output artifacts up to maxArtifactBytes (requires Argo Server)"`
+}
+
+// ExportWorkflowOutput defines the output of the export_workflow tool.
+type ExportWorkflowOutput struct {
+	// ExportedAt is when the bundle was created.
+	ExportedAt string `json:"exportedAt"`
+
+	// Namespace is the namespace of the exported workflow.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the exported workflow.
+	Name string `json:"name"`
+
+	// Phase is the phase of the workflow.
+	Phase string `json:"phase,omitempty"`
+
+	// Path is the file the bundle was written to.
+	Path string `json:"path,omitempty"`
+
+	// URI is the URI of the embedded bundle resource.
+	URI string `json:"uri,omitempty"`
+
+	// Warnings lists the parts of the workflow that could not be exported.
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Bytes is the size of the compressed bundle.
+	Bytes int `json:"bytes"`
+
+	// Nodes is the number of nodes of the workflow.
+	Nodes int `json:"nodes"`
+
+	// Templates is the number of referenced templates in the bundle.
+	Templates int `json:"templates"`
+
+	// Logs is the number of pod logs in the bundle.
+	Logs int `json:"logs"`
+
+	// TruncatedLogs is the number of pod logs cut short by the log budget.
+	TruncatedLogs int `json:"truncatedLogs,omitempty"`
+
+	// OmittedLogs lists the pods whose logs did not fit in the log budget.
+	OmittedLogs []string `json:"omittedLogs,omitempty"`
+
+	// LogBytes is the total size of the logs in the bundle.
+	LogBytes int64 `json:"logBytes"`
+
+	// Artifacts is the number of output artifacts listed in the bundle.
+	Artifacts int `json:"artifacts"`
+
+	// IncludedArtifacts is the number of artifacts whose content is in the bundle.
+	IncludedArtifacts int `json:"includedArtifacts,omitempty"`
+
+	// Events is the number of Kubernetes events in the bundle.
+	Events int `json:"events"`
+}
+
+// ExportWorkflowTool returns the MCP tool definition for export_workflow.
+func ExportWorkflowTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "export_workflow",
+		Description: "Export a workflow as a self-contained tar.gz bundle for sharing an incident: the manifest and status, " +
+			"resolved templates, node logs up to a size budget, artifact listings and optionally small artifacts, pod events " +
+			"and a diagnosis summary. The bundle is returned as an embedded resource or written to the configured export " +
+			"directory, and can be loaded elsewhere with import_workflow_bundle.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+}
+
+// ExportWorkflowHandler returns a handler function for the export_workflow tool.
+func ExportWorkflowHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, ExportWorkflowInput) (*mcp.CallToolResult, *ExportWorkflowOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input ExportWorkflowInput) (*mcp.CallToolResult, *ExportWorkflowOutput, error) {
+		name, err := ValidateName(input.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		namespace := ResolveNamespace(input.Namespace, client)
+
+		logBudget, err := exportLimit("logBudgetBytes", input.LogBudgetBytes, defaultExportLogBudget, maxExportLogBudget)
+		if err != nil {
+			return nil, nil, err
+		}
+		maxArtifact, err := exportLimit("maxArtifactBytes", input.MaxArtifactBytes, defaultExportArtifactBytes, maxExportArtifactBytes)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var dir string
+		if host, ok := client.(bundle.Host); ok {
+			dir = host.BundleDir()
+		}
+		destination := strings.TrimSpace(input.Destination)
+		switch destination {
+		case "":
+			destination = ExportDestinationResource
+			if dir != "" {
+				destination = ExportDestinationDirectory
+			}
+		case ExportDestinationResource:
+		case ExportDestinationDirectory:
+			if dir == "" {
+				return nil, nil, toolerror.Invalid("destination", errors.New("no export directory is configured (set --export-dir or MCP_EXPORT_DIR)"))
+			}
+		default:
+			return nil, nil, toolerror.Invalid("destination", fmt.Errorf("invalid destination %q, must be %q or %q",
+				destination, ExportDestinationResource, ExportDestinationDirectory))
+		}
+
+		wf, err := client.WorkflowService().GetWorkflow(ctx, &workflow.WorkflowGetRequest{
+			Namespace: namespace,
+			Name:      name,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get workflow: %w", err)
+		}
+
+		b := &bundle.Bundle{
+			Workflow:     wf,
+			Logs:         make(map[string][]byte),
+			ArtifactData: make(map[string][]byte),
+			Manifest: bundle.Manifest{
+				ExportedAt: time.Now().UTC().Truncate(time.Second),
+				Namespace:  namespace,
+				Workflow:   name,
+				UID:        string(wf.UID),
+				Phase:      string(wf.Status.Phase),
+				LogBudget:  logBudget,
+			},
+		}
+		e := &workflowExporter{client: client, bundle: b, namespace: namespace}
+		e.exportTemplates(ctx)
+		e.exportLogs(ctx, logBudget)
+		e.exportArtifacts(ctx, input.IncludeArtifacts, maxArtifact)
+		e.exportEvents(ctx)
+		e.exportDiagnosis(ctx)
+
+		var buf bytes.Buffer
+		if writeErr := b.Write(&buf); writeErr != nil {
+			return nil, nil, fmt.Errorf("failed to write bundle: %w", writeErr)
+		}
+
+		output := buildExportWorkflowOutput(b, buf.Len())
+		if destination == ExportDestinationDirectory {
+			path, writeErr := writeExportBundle(dir, b.Manifest, buf.Bytes())
+			if writeErr != nil {
+				return nil, nil, writeErr
+			}
+			output.Path = path
+			return TextResult(formatExportWorkflow(output)), output, nil
+		}
+
+		output.URI = fmt.Sprintf("argo://bundles/%s/%s.tar.gz", namespace, name)
+		result := TextResult(formatExportWorkflow(output))
+		result.Content = append(result.Content, &mcp.EmbeddedResource{
+			Resource: &mcp.ResourceContents{
+				URI:      output.URI,
+				MIMEType: bundle.MediaType,
+				Blob:     buf.Bytes(),
+			},
+		})
+		return result, output, nil
+	}
+}
+
+// exportLimit returns the value of an optional size limit, checking it against its maximum.
+func exportLimit(field string, value *int64, def, maxValue int64) (int64, error) {
+	if value == nil {
+		return def, nil
+	}
+	if *value <= 0 || *value > maxValue {
+		return 0, toolerror.Invalid(field, fmt.Errorf("%s must be between 1 and %d", field, maxValue))
+	}
+	return *value, nil
+}
+
+// workflowExporter collects the contents of a bundle. Parts that cannot be
+// collected are recorded as warnings rather than failing the export.
+type workflowExporter struct {
+	client    argo.ClientInterface
+	bundle    *bundle.Bundle
+	namespace string
+}
+
+// warn records a part of the workflow that could not be exported.
+func (e *workflowExporter) warn(format string, args ...any) {
+	e.bundle.Manifest.Warnings = append(e.bundle.Manifest.Warnings, fmt.Sprintf(format, args...))
+}
+
+// exportTemplates resolves the workflow's template references and adds the
+// resolved manifest and every referenced template to the bundle.
+func (e *workflowExporter) exportTemplates(ctx context.Context) {
+	wf := e.bundle.Workflow
+	manifest, err := yaml.Marshal(&wfv1.Workflow{
+		TypeMeta:   metav1.TypeMeta{APIVersion: wfv1.WorkflowSchemaGroupVersionKind.GroupVersion().String(), Kind: KindWorkflow},
+		ObjectMeta: metav1.ObjectMeta{Name: wf.Name, Namespace: wf.Namespace, Labels: wf.Labels, Annotations: wf.Annotations},
+		Spec:       wf.Spec,
+	})
+	if err != nil {
+		e.warn("failed to encode workflow manifest: %v", err)
+		return
+	}
+
+	resolved, err := resolveManifest(ctx, e.client, e.namespace, "", KindWorkflow, wf.Name, wf.Spec.DeepCopy())
+	if err != nil {
+		e.warn("failed to resolve templates: %v", err)
+		return
+	}
+	e.bundle.Manifest.Warnings = append(e.bundle.Manifest.Warnings, resolved.Warnings...)
+	if e.bundle.Resolved, err = renderResolvedManifest(string(manifest), KindWorkflow, resolved.Spec); err != nil {
+		e.warn("failed to render resolved manifest: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, dep := range resolved.Dependencies {
+		key := dep.Kind + "/" + dep.Name
+		if dep.Depth == 0 || dep.Cycle || seen[key] {
+			continue
+		}
+		seen[key] = true
+		if dep.Kind == KindClusterWorkflowTemplate {
+			e.exportClusterWorkflowTemplate(ctx, dep.Name)
+		} else {
+			e.exportWorkflowTemplate(ctx, dep.Name)
+		}
+	}
+}
+
+// exportWorkflowTemplate adds a referenced WorkflowTemplate to the bundle.
+func (e *workflowExporter) exportWorkflowTemplate(ctx context.Context, name string) {
+	service, err := e.client.WorkflowTemplateService()
+	if err != nil {
+		e.warn("failed to get WorkflowTemplate %q: %v", name, err)
+		return
+	}
+	wft, err := service.GetWorkflowTemplate(ctx, &workflowtemplate.WorkflowTemplateGetRequest{Namespace: e.namespace, Name: name})
+	if err != nil {
+		e.warn("failed to get WorkflowTemplate %q: %v", name, err)
+		return
+	}
+	e.bundle.WorkflowTemplates = append(e.bundle.WorkflowTemplates, *wft)
+}
+
+// exportClusterWorkflowTemplate adds a referenced ClusterWorkflowTemplate to the bundle.
+func (e *workflowExporter) exportClusterWorkflowTemplate(ctx context.Context, name string) {
+	service, err := e.client.ClusterWorkflowTemplateService()
+	if err != nil {
+		e.warn("failed to get ClusterWorkflowTemplate %q: %v", name, err)
+		return
+	}
+	cwft, err := service.GetClusterWorkflowTemplate(ctx, &clusterworkflowtemplate.ClusterWorkflowTemplateGetRequest{Name: name})
+	if err != nil {
+		e.warn("failed to get ClusterWorkflowTemplate %q: %v", name, err)
+		return
+	}
+	e.bundle.ClusterWorkflowTemplates = append(e.bundle.ClusterWorkflowTemplates, *cwft)
+}
+
+// exportLogs adds the main container logs of the workflow's pods to the
+// bundle. Failed pods go first and every pod gets an equal share of what is
+// left of the budget, keeping the end of logs that do not fit. Once the
+// remaining budget is below minExportPodLogBytes, the remaining pods are
+// recorded as omitted.
+func (e *workflowExporter) exportLogs(ctx context.Context, budget int64) {
+	pods := exportLogPods(e.bundle.Workflow)
+	minShare := min(minExportPodLogBytes, budget)
+	remaining := budget
+	var omitted int
+	for i, pod := range pods {
+		if remaining < minShare {
+			omitted++
+			e.bundle.Manifest.Logs = append(e.bundle.Manifest.Logs, bundle.LogFile{PodName: pod.name, NodeID: pod.nodeID, Omitted: true})
+			continue
+		}
+		share := min(max(remaining/int64(len(pods)-i), minShare), remaining)
+		data, truncated, err := e.podLogs(ctx, pod.name, share)
+		if err != nil {
+			e.warn("failed to get logs of pod %s: %v", pod.name, err)
+		}
+		if len(data) == 0 && !truncated {
+			continue
+		}
+		remaining -= int64(len(data))
+		e.bundle.Logs[pod.name] = data
+		e.bundle.Manifest.Logs = append(e.bundle.Manifest.Logs, bundle.LogFile{
+			PodName:   pod.name,
+			NodeID:    pod.nodeID,
+			Bytes:     int64(len(data)),
+			Truncated: truncated,
+		})
+	}
+	if omitted > 0 {
+		e.warn("log budget of %d bytes exhausted; the logs of %d pod(s) were omitted", budget, omitted)
+	}
+}
+
+// podLogs reads the main container log of a pod, keeping the last lines that
+// fit in limit bytes. It reports whether earlier lines were dropped.
+func (e *workflowExporter) podLogs(ctx context.Context, pod string, limit int64) ([]byte, bool, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := e.client.WorkflowService().WorkflowLogs(ctx, &workflow.WorkflowLogRequest{
+		Namespace:  e.namespace,
+		Name:       e.bundle.Workflow.Name,
+		PodName:    pod,
+		LogOptions: &corev1.PodLogOptions{Container: containerMain},
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	var lines []string
+	var size int64
+	truncated := false
+	for {
+		entry, recvErr := stream.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			break
+		}
+		if recvErr != nil {
+			err = fmt.Errorf("log stream ended early: %w", recvErr)
+			break
+		}
+		if entry.PodName != "" && entry.PodName != pod {
+			continue
+		}
+		lines = append(lines, entry.Content)
+		size += int64(len(entry.Content) + 1)
+		for size > limit {
+			size -= int64(len(lines[0]) + 1)
+			lines = lines[1:]
+			truncated = true
+		}
+	}
+
+	var buf bytes.Buffer
+	for _, line := range lines {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), truncated, err
+}
+
+// exportLogPod is a pod whose logs may be exported.
+type exportLogPod struct {
+	name   string
+	nodeID string
+	failed bool
+}
+
+// exportLogPods lists the pods of a workflow in the order their logs are
+// exported: failed pods first, then by name. Pod nodes that were skipped or
+// omitted never ran a pod and are left out.
+func exportLogPods(wf *wfv1.Workflow) []exportLogPod {
+	var pods []exportLogPod
+	for name, id := range workflowPodNodes(wf) {
+		node := wf.Status.Nodes[id]
+		if node.Phase == wfv1.NodeSkipped || node.Phase == wfv1.NodeOmitted {
+			continue
+		}
+		pods = append(pods, exportLogPod{name: name, nodeID: id, failed: node.FailedOrError()})
+	}
+	slices.SortFunc(pods, func(a, b exportLogPod) int {
+		if a.failed != b.failed {
+			if a.failed {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.name, b.name)
+	})
+	return pods
+}
+
+// workflowPodNodes maps the names of the pods of a workflow to their node IDs.
+func workflowPodNodes(wf *wfv1.Workflow) map[string]string {
+	version := wfutil.GetWorkflowPodNameVersion(wf)
+	pods := make(map[string]string)
+	for id, node := range wf.Status.Nodes {
+		if node.Type == wfv1.NodeTypePod {
+			pods[wfutil.GeneratePodName(wf.Name, node.Name, wfutil.GetTemplateFromNode(node), id, version)] = id
+		}
+	}
+	return pods
+}
+
+// exportArtifacts lists the output artifacts of all nodes and, when include is
+// set, adds the content of those no larger than maxBytes.
+func (e *workflowExporter) exportArtifacts(ctx context.Context, include bool, maxBytes int64) {
+	wf := e.bundle.Workflow
+	var total int64
+	for _, id := range slices.Sorted(maps.Keys(wf.Status.Nodes)) {
+		node := wf.Status.Nodes[id]
+		if node.Outputs == nil {
+			continue
+		}
+		for i := range node.Outputs.Artifacts {
+			art := &node.Outputs.Artifacts[i]
+			location, _ := art.GetKey()
+			entry := bundle.Artifact{NodeID: id, NodeName: node.DisplayName, Name: art.Name, Location: location}
+
+			if include {
+				data, err := e.downloadArtifact(ctx, id, art.Name, maxBytes)
+				switch {
+				case errors.Is(err, argo.ErrArtifactsNotSupported):
+					e.warn("artifact contents were not included: %v", err)
+					include = false
+				case err != nil:
+					e.warn("artifact %s of node %s was not included: %v", art.Name, id, err)
+				case total+int64(len(data)) > maxExportArtifactTotal:
+					e.warn("artifact %s of node %s was not included: the artifacts in a bundle are limited to %d bytes", art.Name, id, maxExportArtifactTotal)
+				default:
+					total += int64(len(data))
+					e.bundle.ArtifactData[bundle.ArtifactKey(id, art.Name)] = data
+					entry.Bytes = int64(len(data))
+					entry.Included = true
+				}
+			}
+			e.bundle.Manifest.Artifacts = append(e.bundle.Manifest.Artifacts, entry)
+		}
+	}
+}
+
+// downloadArtifact reads an output artifact, failing when it is larger than maxBytes.
+func (e *workflowExporter) downloadArtifact(ctx context.Context, nodeID, name string, maxBytes int64) ([]byte, error) {
+	rc, err := e.client.OpenArtifact(ctx, &argo.ArtifactRequest{
+		Namespace: e.namespace,
+		Workflow:  e.bundle.Workflow.Name,
+		NodeID:    nodeID,
+		Name:      name,
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, maxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("larger than %d bytes", maxBytes)
+	}
+	return data, nil
+}
+
+// exportEvents adds the Kubernetes events of the workflow and its pods.
+// Events are only available in direct Kubernetes API mode.
+func (e *workflowExporter) exportEvents(ctx context.Context) {
+	kube, err := e.client.KubernetesClient()
+	if err != nil {
+		e.warn("pod events were not included: %v", err)
+		return
+	}
+	list, err := kube.CoreV1().Events(e.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		e.warn("pod events were not included: %v", err)
+		return
+	}
+
+	wf := e.bundle.Workflow
+	pods := workflowPodNodes(wf)
+	for _, event := range list.Items {
+		obj := event.InvolvedObject
+		_, isPod := pods[obj.Name]
+		if (obj.Kind == "Pod" && isPod) || (obj.Kind == KindWorkflow && obj.Name == wf.Name) {
+			e.bundle.Events = append(e.bundle.Events, event)
+		}
+	}
+	slices.SortStableFunc(e.bundle.Events, func(a, b corev1.Event) int {
+		return bundle.EventTime(a).Compare(bundle.EventTime(b))
+	})
+}
+
+// exportDiagnosis adds the diagnosis summary of the why_did_this_fail prompt.
+func (e *workflowExporter) exportDiagnosis(ctx context.Context) {
+	summary, err := prompts.DiagnosisSummary(ctx, e.client, e.namespace, e.bundle.Workflow.Name)
+	if err != nil {
+		e.warn("failed to build diagnosis summary: %v", err)
+		return
+	}
+	e.bundle.Diagnosis = summary
+}
+
+// buildExportWorkflowOutput summarises the contents of a bundle.
+func buildExportWorkflowOutput(b *bundle.Bundle, size int) *ExportWorkflowOutput {
+	output := &ExportWorkflowOutput{
+		ExportedAt: b.Manifest.ExportedAt.Format(time.RFC3339),
+		Namespace:  b.Manifest.Namespace,
+		Name:       b.Manifest.Workflow,
+		Phase:      b.Manifest.Phase,
+		Bytes:      size,
+		Nodes:      len(b.Workflow.Status.Nodes),
+		Templates:  len(b.WorkflowTemplates) + len(b.ClusterWorkflowTemplates),
+		Artifacts:  len(b.Manifest.Artifacts),
+		Events:     len(b.Events),
+		Warnings:   b.Manifest.Warnings,
+	}
+	for _, log := range b.Manifest.Logs {
+		if log.Omitted {
+			output.OmittedLogs = append(output.OmittedLogs, log.PodName)
+			continue
+		}
+		output.Logs++
+		output.LogBytes += log.Bytes
+		if log.Truncated {
+			output.TruncatedLogs++
+		}
+	}
+	for _, art := range b.Manifest.Artifacts {
+		if art.Included {
+			output.IncludedArtifacts++
+		}
+	}
+	return output
+}
+
+// writeExportBundle writes an encoded bundle to the export directory and returns its path.
+func writeExportBundle(dir string, manifest bundle.Manifest, data []byte) (string, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create export directory: %w", err)
+	}
+	name := fmt.Sprintf("%s-%s-%s.tar.gz", manifest.Namespace, manifest.Workflow, manifest.ExportedAt.Format("20060102T150405Z"))
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write bundle: %w", err)
+	}
+	return path, nil
+}
+
+// formatExportWorkflow builds the human-readable export summary.
+func formatExportWorkflow(output *ExportWorkflowOutput) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Exported workflow %s/%s (%s) as a %d byte bundle\n", output.Namespace, output.Name, output.Phase, output.Bytes)
+	if output.Path != "" {
+		fmt.Fprintf(&sb, "Written to %s\n", output.Path)
+	} else {
+		fmt.Fprintf(&sb, "Attached as %s\n", output.URI)
+	}
+
+	fmt.Fprintf(&sb, "\nNodes: %d, templates: %d, events: %d\n", output.Nodes, output.Templates, output.Events)
+	fmt.Fprintf(&sb, "Logs: %d pod(s), %d bytes", output.Logs, output.LogBytes)
+	if output.TruncatedLogs > 0 {
+		fmt.Fprintf(&sb, " (%d truncated)", output.TruncatedLogs)
+	}
+	sb.WriteString("\n")
+	if len(output.OmittedLogs) > 0 {
+		fmt.Fprintf(&sb, "Omitted logs: %s\n", strings.Join(output.OmittedLogs, ", "))
+	}
+	fmt.Fprintf(&sb, "Artifacts: %d listed, %d included\n", output.Artifacts, output.IncludedArtifacts)
+
+	if len(output.Warnings) > 0 {
+		sb.WriteString("\nWarnings:\n")
+		for _, warning := range output.Warnings {
+			fmt.Fprintf(&sb, "- %s\n", warning)
+		}
+	}
+	sb.WriteString("\nLoad the bundle with import_workflow_bundle to inspect it without the cluster.\n")
+	return sb.String()
+}
@@ -0,0 +1,255 @@
+package tools
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/bundle"
+)
+
+// exportFixture is a failed workflow using a WorkflowTemplate, with one pod
+// node that produced an artifact. Pods are named after their node IDs.
+func exportFixture() *wfv1.Workflow {
+	return &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "wf",
+			Namespace:   "argo",
+			UID:         "uid-1",
+			Annotations: map[string]string{"workflows.argoproj.io/pod-name-format": "v1"},
+		},
+		Spec: wfv1.WorkflowSpec{WorkflowTemplateRef: &wfv1.WorkflowTemplateRef{Name: "lib"}},
+		Status: wfv1.WorkflowStatus{
+			Phase:   wfv1.WorkflowFailed,
+			Message: "child 'wf-1' failed",
+			Nodes: wfv1.Nodes{
+				"wf": {ID: "wf", Name: "wf", DisplayName: "wf", Type: wfv1.NodeTypeSteps, Phase: wfv1.NodeFailed, Children: []string{"wf-1"}},
+				"wf-1": {
+					ID: "wf-1", Name: "wf[0].build", DisplayName: "build", Type: wfv1.NodeTypePod, TemplateName: "build",
+					Phase: wfv1.NodeFailed, Message: "Error (exit code 1)",
+					Outputs: &wfv1.Outputs{Artifacts: wfv1.Artifacts{{
+						Name:             "report",
+						ArtifactLocation: wfv1.ArtifactLocation{S3: &wfv1.S3Artifact{Key: "wf/wf-1/report.tgz"}},
+					}}},
+				},
+			},
+		},
+	}
+}
+
+// setupExportMocks returns a direct-mode mock client serving exportFixture.
+func setupExportMocks(t *testing.T) *mocks.MockClient {
+	t.Helper()
+	mockClient := newMockClient(t, "argo", false)
+	wfService := newMockWorkflowService(t)
+	mockClient.SetWorkflowService(wfService)
+
+	wfService.On("GetWorkflow", mock.Anything, &workflow.WorkflowGetRequest{Namespace: "argo", Name: "wf"}).
+		Return(exportFixture(), nil)
+	// The export streams all logs first; the diagnosis then asks for the failed node's logs
+	wfService.On("WorkflowLogs", mock.Anything, mock.Anything).Return(mocks.NewMockWorkflowLogsStream([]*workflow.LogEntry{
+		mocks.NewLogEntry("wf-1", "compiling"),
+		mocks.NewLogEntry("wf-1", "error: missing dependency"),
+	}), nil).Once()
+	wfService.On("WorkflowLogs", mock.Anything, mock.Anything).Return(mocks.NewMockWorkflowLogsStream(nil), nil)
+
+	wftService := &mocks.MockWorkflowTemplateServiceClient{}
+	wftService.On("GetWorkflowTemplate", mock.Anything, mock.Anything).Return(&wfv1.WorkflowTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "lib", Namespace: "argo"},
+		Spec: wfv1.WorkflowSpec{
+			Entrypoint: "build",
+			Templates:  []wfv1.Template{{Name: "build", Container: &corev1.Container{Image: "golang"}}},
+		},
+	}, nil)
+	mockClient.SetWorkflowTemplateService(wftService)
+
+	mockClient.SetKubernetesClient(fake.NewClientset(
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "e1", Namespace: "argo"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "wf-1"},
+			Reason:         "BackOff",
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "e2", Namespace: "argo"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "unrelated"},
+			Reason:         "Pulled",
+		},
+	))
+	return mockClient
+}
+
+func TestExportWorkflowTool(t *testing.T) {
+	tool := ExportWorkflowTool()
+
+	assert.Equal(t, "export_workflow", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	require.NotNil(t, tool.Annotations)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+}
+
+func TestExportWorkflowHandler(t *testing.T) {
+	t.Run("returns the bundle as an embedded resource", func(t *testing.T) {
+		mockClient := setupExportMocks(t)
+		mockClient.On("OpenArtifact", mock.Anything, &argo.ArtifactRequest{
+			Namespace: "argo", Workflow: "wf", NodeID: "wf-1", Name: "report",
+		}).Return(io.NopCloser(strings.NewReader("report data")), nil)
+
+		handler := ExportWorkflowHandler(mockClient)
+		result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, ExportWorkflowInput{Name: "wf", IncludeArtifacts: true})
+		require.NoError(t, err)
+
+		assert.Empty(t, output.Warnings)
+		assert.Equal(t, "argo://bundles/argo/wf.tar.gz", output.URI)
+		assert.Equal(t, 2, output.Nodes)
+		assert.Equal(t, 1, output.Templates)
+		assert.Equal(t, 1, output.Logs)
+		assert.Equal(t, 1, output.Artifacts)
+		assert.Equal(t, 1, output.IncludedArtifacts)
+		assert.Equal(t, 1, output.Events)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "Exported workflow argo/wf (Failed)")
+
+		require.Len(t, result.Content, 2)
+		resource, ok := result.Content[1].(*mcp.EmbeddedResource)
+		require.True(t, ok)
+		assert.Equal(t, bundle.MediaType, resource.Resource.MIMEType)
+		assert.Equal(t, output.Bytes, len(resource.Resource.Blob))
+
+		b, err := bundle.Read(bytes.NewReader(resource.Resource.Blob), 1<<20)
+		require.NoError(t, err)
+		assert.Equal(t, "uid-1", b.Manifest.UID)
+		assert.Equal(t, "compiling\nerror: missing dependency\n", string(b.Logs["wf-1"]))
+		assert.Equal(t, []bundle.LogFile{{PodName: "wf-1", NodeID: "wf-1", Bytes: 36}}, b.Manifest.Logs)
+		assert.Equal(t, []bundle.Artifact{{
+			NodeID: "wf-1", NodeName: "build", Name: "report", Location: "wf/wf-1/report.tgz", Bytes: 11, Included: true,
+		}}, b.Manifest.Artifacts)
+		assert.Equal(t, "report data", string(b.ArtifactData[bundle.ArtifactKey("wf-1", "report")]))
+		require.Len(t, b.WorkflowTemplates, 1)
+		assert.Equal(t, "lib", b.WorkflowTemplates[0].Name)
+		assert.Contains(t, b.Resolved, "image: golang")
+		require.Len(t, b.Events, 1)
+		assert.Equal(t, "BackOff", b.Events[0].Reason)
+		assert.Contains(t, b.Diagnosis, "### Node: build (ROOT CAUSE)")
+	})
+
+	t.Run("writes to the export directory within the log budget", func(t *testing.T) {
+		dir := t.TempDir()
+		client := bundle.NewClient(setupExportMocks(t), dir)
+
+		handler := ExportWorkflowHandler(client)
+		_, output, err := handler(t.Context(), &mcp.CallToolRequest{}, ExportWorkflowInput{Name: "wf", LogBudgetBytes: ptr.To[int64](30)})
+		require.NoError(t, err)
+
+		assert.Empty(t, output.URI)
+		assert.Equal(t, dir, filepath.Dir(output.Path))
+		// Only the end of the log fits
+		assert.Equal(t, 1, output.TruncatedLogs)
+		assert.Equal(t, int64(26), output.LogBytes)
+		assert.Empty(t, output.Warnings)
+
+		data, err := os.ReadFile(output.Path)
+		require.NoError(t, err)
+		assert.Len(t, data, output.Bytes)
+	})
+
+	t.Run("artifacts require argo server", func(t *testing.T) {
+		mockClient := setupExportMocks(t)
+		mockClient.On("OpenArtifact", mock.Anything, mock.Anything).Return(nil, argo.ErrArtifactsNotSupported)
+
+		handler := ExportWorkflowHandler(mockClient)
+		_, output, err := handler(t.Context(), &mcp.CallToolRequest{}, ExportWorkflowInput{Name: "wf", IncludeArtifacts: true})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, output.Artifacts)
+		assert.Zero(t, output.IncludedArtifacts)
+		require.Len(t, output.Warnings, 1)
+		assert.Contains(t, output.Warnings[0], "artifact contents were not included")
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		handler := ExportWorkflowHandler(newMockClient(t, "argo", false))
+
+		_, _, err := handler(t.Context(), &mcp.CallToolRequest{}, ExportWorkflowInput{})
+		require.Error(t, err)
+
+		_, _, err = handler(t.Context(), &mcp.CallToolRequest{}, ExportWorkflowInput{Name: "wf", Destination: ExportDestinationDirectory})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no export directory is configured")
+
+		_, _, err = handler(t.Context(), &mcp.CallToolRequest{}, ExportWorkflowInput{Name: "wf", Destination: "s3"})
+		require.Error(t, err)
+
+		_, _, err = handler(t.Context(), &mcp.CallToolRequest{}, ExportWorkflowInput{Name: "wf", LogBudgetBytes: ptr.To[int64](0)})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "logBudgetBytes must be between 1 and")
+	})
+
+	t.Run("get error", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", false)
+		wfService := newMockWorkflowService(t)
+		mockClient.SetWorkflowService(wfService)
+		wfService.On("GetWorkflow", mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+
+		handler := ExportWorkflowHandler(mockClient)
+		_, _, err := handler(t.Context(), &mcp.CallToolRequest{}, ExportWorkflowInput{Name: "wf"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get workflow")
+	})
+}
+
+func TestExportLogs(t *testing.T) {
+	wf := exportFixture()
+	wf.Status.Nodes["wf"] = wfv1.NodeStatus{
+		ID: "wf", Name: "wf", Type: wfv1.NodeTypeSteps, Phase: wfv1.NodeFailed, Children: []string{"wf-0", "wf-1", "wf-2"},
+	}
+	wf.Status.Nodes["wf-0"] = wfv1.NodeStatus{ID: "wf-0", Name: "wf[0].setup", Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded}
+	wf.Status.Nodes["wf-2"] = wfv1.NodeStatus{ID: "wf-2", Name: "wf[1].skipped", Type: wfv1.NodeTypePod, Phase: wfv1.NodeSkipped}
+
+	mockClient := newMockClient(t, "argo", false)
+	wfService := newMockWorkflowService(t)
+	mockClient.SetWorkflowService(wfService)
+	podLogs := func(pod string) any {
+		return mock.MatchedBy(func(req *workflow.WorkflowLogRequest) bool { return req.PodName == pod })
+	}
+	// The failed pod is exported first and takes the budget
+	wfService.On("WorkflowLogs", mock.Anything, podLogs("wf-1")).Return(mocks.NewMockWorkflowLogsStream([]*workflow.LogEntry{
+		mocks.NewLogEntry("wf-1", "compiling"),
+		mocks.NewLogEntry("wf-1", "error: missing dependency"),
+	}), nil)
+
+	e := &workflowExporter{
+		client:    mockClient,
+		namespace: "argo",
+		bundle:    &bundle.Bundle{Workflow: wf, Logs: make(map[string][]byte)},
+	}
+	e.exportLogs(t.Context(), 40)
+
+	assert.Equal(t, []bundle.LogFile{
+		{PodName: "wf-1", NodeID: "wf-1", Bytes: 36},
+		{PodName: "wf-0", NodeID: "wf-0", Omitted: true},
+	}, e.bundle.Manifest.Logs)
+	assert.Equal(t, "compiling\nerror: missing dependency\n", string(e.bundle.Logs["wf-1"]))
+	require.Len(t, e.bundle.Manifest.Warnings, 1)
+	assert.Contains(t, e.bundle.Manifest.Warnings[0], "the logs of 1 pod(s) were omitted")
+
+	output := buildExportWorkflowOutput(e.bundle, 0)
+	assert.Equal(t, 1, output.Logs)
+	assert.Equal(t, []string{"wf-0"}, output.OmittedLogs)
+	assert.Contains(t, formatExportWorkflow(output), "Omitted logs: wf-0")
+}
@@ -0,0 +1,371 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/clusterworkflowtemplate"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/cronworkflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// Limits for the number of Workflows scanned for usages.
+const (
+	defaultUsageWorkflowLimit = 100
+	maxUsageWorkflowLimit     = 1000
+)
+
+// maxListedUsages bounds how many usages a refused deletion lists.
+const maxListedUsages = 10
+
+// Reference types of a template usage.
+const (
+	UsageTemplateRef         = "templateRef"
+	UsageWorkflowTemplateRef = "workflowTemplateRef"
+)
+
+// FindTemplateUsagesInput defines the input parameters for the find_template_usages tool.
+type FindTemplateUsagesInput struct {
+	// Namespace is the namespace to scan (uses default if not specified).
+	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace of the WorkflowTemplate and to scan (uses default if not specified). For a ClusterWorkflowTemplate all namespaces are scanned unless set"`
+
+	// Name is the WorkflowTemplate or ClusterWorkflowTemplate name.
+	Name string `json:"name" jsonschema:"WorkflowTemplate or ClusterWorkflowTemplate name,required"`
+
+	// Template restricts the search to references to one of its templates.
+	Template string `json:"template,omitempty" jsonschema:"Only find references to this template of it. workflowTemplateRefs use every template and always match"`
+
+	// ClusterScope looks for usages of a ClusterWorkflowTemplate.
+	ClusterScope bool `json:"clusterScope,omitempty" jsonschema:"Find usages of a ClusterWorkflowTemplate instead of a WorkflowTemplate"`
+
+	// IncludeWorkflows also scans the first Workflows listed.
+	IncludeWorkflows bool `json:"includeWorkflows,omitempty" jsonschema:"Also scan Workflows, up to workflowLimit in the order the API lists them (not by recency)"`
+
+	// WorkflowLimit bounds how many Workflows are scanned.
+	WorkflowLimit int `json:"workflowLimit,omitempty" jsonschema:"Maximum number of Workflows to scan, the first ones listed, when includeWorkflows is set (default 100, max 1000)"`
+}
+
+// TemplateUsage is a reference to the template from another resource.
+type TemplateUsage struct {
+	// Kind is the kind of the referencing resource.
+	Kind string `json:"kind"`
+
+	// Name is the name of the referencing resource.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the referencing resource, empty for ClusterWorkflowTemplates.
+	Namespace string `json:"namespace,omitempty"`
+
+	// Template is the template of the referencing resource that holds the reference.
+	Template string `json:"template,omitempty"`
+
+	// Path is the location of the reference in the referencing resource.
+	Path string `json:"path"`
+
+	// Type is templateRef or workflowTemplateRef.
+	Type string `json:"type"`
+
+	// RefTemplate is the referenced template, empty for a workflowTemplateRef.
+	RefTemplate string `json:"refTemplate,omitempty"`
+}
+
+// FindTemplateUsagesOutput defines the output for the find_template_usages tool.
+type FindTemplateUsagesOutput struct {
+	Kind      string          `json:"kind"`
+	Name      string          `json:"name"`
+	Namespace string          `json:"namespace,omitempty"`
+	Template  string          `json:"template,omitempty"`
+	Usages    []TemplateUsage `json:"usages"`
+	Warnings  []string        `json:"warnings,omitempty"`
+	Scanned   int             `json:"scanned"`
+}
+
+// FindTemplateUsagesTool returns the MCP tool definition for find_template_usages.
+func FindTemplateUsagesTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "find_template_usages",
+		Description: "Find what references a WorkflowTemplate or ClusterWorkflowTemplate before deleting or changing it. " +
+			"Scans WorkflowTemplates, CronWorkflows, ClusterWorkflowTemplates (for a ClusterWorkflowTemplate) and optionally the first Workflows listed " +
+			"for templateRef and workflowTemplateRef, and can narrow the search to one inner template.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+}
+
+// FindTemplateUsagesHandler returns a handler function for the find_template_usages tool.
+func FindTemplateUsagesHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, FindTemplateUsagesInput) (*mcp.CallToolResult, *FindTemplateUsagesOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input FindTemplateUsagesInput) (*mcp.CallToolResult, *FindTemplateUsagesOutput, error) {
+		name, err := ValidateName(input.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		workflowLimit := 0
+		if input.IncludeWorkflows {
+			workflowLimit = input.WorkflowLimit
+			if workflowLimit <= 0 {
+				workflowLimit = defaultUsageWorkflowLimit
+			}
+			if workflowLimit > maxUsageWorkflowLimit {
+				return nil, nil, fmt.Errorf("workflowLimit must be at most %d", maxUsageWorkflowLimit)
+			}
+		} else if input.WorkflowLimit != 0 {
+			return nil, nil, fmt.Errorf("workflowLimit requires includeWorkflows")
+		}
+
+		// References to a ClusterWorkflowTemplate can come from any namespace
+		namespace := strings.TrimSpace(input.Namespace)
+		if namespace == "" && !input.ClusterScope {
+			namespace = client.DefaultNamespace()
+		}
+
+		target := templateTarget{name: name, template: strings.TrimSpace(input.Template), clusterScope: input.ClusterScope}
+		scan, err := findTemplateUsages(ctx, client, target, namespace, workflowLimit)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		output := &FindTemplateUsagesOutput{
+			Kind:      templateRefKind(target.clusterScope),
+			Name:      name,
+			Namespace: namespace,
+			Template:  target.template,
+			Usages:    scan.usages,
+			Warnings:  scan.warnings,
+			Scanned:   scan.scanned,
+		}
+		if output.Usages == nil {
+			output.Usages = []TemplateUsage{}
+		}
+
+		return TextResult(formatTemplateUsages(output)), output, nil
+	}
+}
+
+// templateTarget is the WorkflowTemplate or ClusterWorkflowTemplate whose usages are searched.
+type templateTarget struct {
+	name         string
+	template     string
+	clusterScope bool
+}
+
+// matches reports whether a templateRef points at the target.
+func (t templateTarget) matches(ref *wfv1.TemplateRef) bool {
+	return ref != nil && ref.Name == t.name && ref.ClusterScope == t.clusterScope &&
+		(t.template == "" || ref.Template == t.template)
+}
+
+// templateUsageScan is the result of scanning for usages of a template.
+type templateUsageScan struct {
+	usages   []TemplateUsage
+	warnings []string
+	scanned  int
+}
+
+// findTemplateUsages scans the resources in namespace, or all namespaces if
+// empty, for references to target. The first workflowLimit Workflows listed
+// are scanned if workflowLimit is positive; the list is not ordered by recency.
+func findTemplateUsages(ctx context.Context, client argo.ClientInterface, target templateTarget, namespace string, workflowLimit int) (*templateUsageScan, error) {
+	scan := &templateUsageScan{}
+	targetKind := templateRefKind(target.clusterScope)
+
+	wftService, err := client.WorkflowTemplateService()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow template service: %w", err)
+	}
+	wftList, err := wftService.ListWorkflowTemplates(ctx, &workflowtemplate.WorkflowTemplateListRequest{Namespace: namespace})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow templates: %w", err)
+	}
+	for i := range wftList.Items {
+		wft := &wftList.Items[i]
+		scan.scanned++
+		if targetKind == KindWorkflowTemplate && wft.Name == target.name && wft.Namespace == namespace {
+			continue
+		}
+		scan.add(KindWorkflowTemplate, wft.ObjectMeta, &wft.Spec, "spec", target)
+	}
+
+	// ClusterWorkflowTemplates can only reference other ClusterWorkflowTemplates,
+	// and namespaced users may not be allowed to list them
+	if target.clusterScope {
+		cwftService, err := client.ClusterWorkflowTemplateService()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cluster workflow template service: %w", err)
+		}
+		cwftList, err := cwftService.ListClusterWorkflowTemplates(ctx, &clusterworkflowtemplate.ClusterWorkflowTemplateListRequest{})
+		if err != nil {
+			scan.warnings = append(scan.warnings, fmt.Sprintf("ClusterWorkflowTemplates were not scanned: %v", err))
+		} else {
+			for i := range cwftList.Items {
+				cwft := &cwftList.Items[i]
+				scan.scanned++
+				if cwft.Name == target.name {
+					continue
+				}
+				scan.add(KindClusterWorkflowTemplate, cwft.ObjectMeta, &cwft.Spec, "spec", target)
+			}
+		}
+	}
+
+	cronService, err := client.CronWorkflowService()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cron workflow service: %w", err)
+	}
+	cronList, err := cronService.ListCronWorkflows(ctx, &cronworkflow.ListCronWorkflowsRequest{Namespace: namespace})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list cron workflows: %w", err)
+	}
+	for i := range cronList.Items {
+		cw := &cronList.Items[i]
+		scan.scanned++
+		scan.add(KindCronWorkflow, cw.ObjectMeta, &cw.Spec.WorkflowSpec, "spec.workflowSpec", target)
+	}
+
+	if workflowLimit > 0 {
+		wfList, listErr := client.WorkflowService().ListWorkflows(ctx, &workflow.WorkflowListRequest{
+			Namespace:   namespace,
+			ListOptions: &metav1.ListOptions{Limit: int64(workflowLimit)},
+		})
+		if listErr != nil {
+			return nil, fmt.Errorf("failed to list workflows: %w", listErr)
+		}
+		for i := range wfList.Items {
+			wf := &wfList.Items[i]
+			scan.scanned++
+			scan.add(KindWorkflow, wf.ObjectMeta, &wf.Spec, "spec", target)
+		}
+		if wfList.Continue != "" {
+			scan.warnings = append(scan.warnings, fmt.Sprintf("only the first %d workflows listed were scanned", workflowLimit))
+		}
+	}
+
+	return scan, nil
+}
+
+// add records the references to target in the spec of a resource.
+func (s *templateUsageScan) add(kind string, meta metav1.ObjectMeta, spec *wfv1.WorkflowSpec, specPath string, target templateTarget) {
+	usage := func(template, path, usageType, refTemplate string) {
+		s.usages = append(s.usages, TemplateUsage{
+			Kind:        kind,
+			Name:        meta.Name,
+			Namespace:   meta.Namespace,
+			Template:    template,
+			Path:        path,
+			Type:        usageType,
+			RefTemplate: refTemplate,
+		})
+	}
+	hooks := func(template, path string, hooks wfv1.LifecycleHooks) {
+		for _, event := range slices.Sorted(maps.Keys(hooks)) {
+			if hook := hooks[event]; target.matches(hook.TemplateRef) {
+				usage(template, fmt.Sprintf("%s.hooks.%s", path, event), UsageTemplateRef, hook.TemplateRef.Template)
+			}
+		}
+	}
+
+	// A workflowTemplateRef uses every template of the referenced template
+	if ref := spec.WorkflowTemplateRef; ref != nil && ref.Name == target.name && ref.ClusterScope == target.clusterScope {
+		usage("", specPath+".workflowTemplateRef", UsageWorkflowTemplateRef, "")
+	}
+	hooks("", specPath, spec.Hooks)
+
+	for i := range spec.Templates {
+		tmpl := &spec.Templates[i]
+		path := fmt.Sprintf("%s.templates[%d]", specPath, i)
+		for j, parallel := range tmpl.Steps {
+			for k, step := range parallel.Steps {
+				stepPath := fmt.Sprintf("%s.steps[%d][%d]", path, j, k)
+				if target.matches(step.TemplateRef) {
+					usage(tmpl.Name, stepPath, UsageTemplateRef, step.TemplateRef.Template)
+				}
+				hooks(tmpl.Name, stepPath, step.Hooks)
+			}
+		}
+		if tmpl.DAG != nil {
+			for j, task := range tmpl.DAG.Tasks {
+				taskPath := fmt.Sprintf("%s.dag.tasks[%d]", path, j)
+				if target.matches(task.TemplateRef) {
+					usage(tmpl.Name, taskPath, UsageTemplateRef, task.TemplateRef.Template)
+				}
+				hooks(tmpl.Name, taskPath, task.Hooks)
+			}
+		}
+	}
+}
+
+// describe formats a usage for messages.
+func (u TemplateUsage) describe() string {
+	var sb strings.Builder
+	sb.WriteString(u.Kind + " ")
+	if u.Namespace != "" {
+		sb.WriteString(u.Namespace + "/")
+	}
+	sb.WriteString(u.Name)
+	if u.Template != "" {
+		fmt.Fprintf(&sb, " template %q", u.Template)
+	}
+	if u.RefTemplate != "" {
+		fmt.Fprintf(&sb, " -> %q", u.RefTemplate)
+	} else if u.Type == UsageWorkflowTemplateRef {
+		sb.WriteString(" (workflowTemplateRef)")
+	}
+	return sb.String()
+}
+
+// templateInUseError returns the error of a deletion refused because of usages.
+func templateInUseError(kind, name string, usages []TemplateUsage) error {
+	described := make([]string, 0, min(len(usages), maxListedUsages))
+	for _, u := range usages[:min(len(usages), maxListedUsages)] {
+		described = append(described, u.describe())
+	}
+	if len(usages) > maxListedUsages {
+		described = append(described, fmt.Sprintf("and %d more", len(usages)-maxListedUsages))
+	}
+	return fmt.Errorf("%s %q is still referenced by %d usage(s): %s; update them first or set force to delete anyway",
+		kind, name, len(usages), strings.Join(described, "; "))
+}
+
+// incompleteUsageScanError returns the error of a deletion refused because
+// some resources could not be scanned for usages.
+func incompleteUsageScanError(kind, name string, warnings []string) error {
+	return fmt.Errorf("could not check all usages of %s %q: %s; set force to delete anyway",
+		kind, name, strings.Join(warnings, "; "))
+}
+
+// formatTemplateUsages builds the human-readable usage report.
+func formatTemplateUsages(output *FindTemplateUsagesOutput) string {
+	var sb strings.Builder
+	target := fmt.Sprintf("%s %q", output.Kind, output.Name)
+	if output.Template != "" {
+		target += fmt.Sprintf(" template %q", output.Template)
+	}
+	scope := "all namespaces"
+	if output.Namespace != "" {
+		scope = fmt.Sprintf("namespace %q", output.Namespace)
+	}
+
+	if len(output.Usages) == 0 {
+		fmt.Fprintf(&sb, "No usages of %s found in %s (%d resources scanned)", target, scope, output.Scanned)
+	} else {
+		fmt.Fprintf(&sb, "Found %d usage(s) of %s in %s (%d resources scanned):\n", len(output.Usages), target, scope, output.Scanned)
+		for _, u := range output.Usages {
+			fmt.Fprintf(&sb, "\n  %s\n    at %s", u.describe(), u.Path)
+		}
+	}
+	for _, warning := range output.Warnings {
+		fmt.Fprintf(&sb, "\n\nWarning: %s", warning)
+	}
+	return sb.String()
+}
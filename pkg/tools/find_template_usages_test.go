@@ -0,0 +1,259 @@
+package tools
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/clusterworkflowtemplate"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/cronworkflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+)
+
+// usingWorkflowTemplate returns a WorkflowTemplate whose DAG calls template
+// "build" of target through a templateRef.
+func usingWorkflowTemplate(name, target string, clusterScope bool) wfv1.WorkflowTemplate {
+	return wfv1.WorkflowTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: wfv1.WorkflowSpec{Templates: []wfv1.Template{{
+			Name: "main",
+			DAG: &wfv1.DAGTemplate{Tasks: []wfv1.DAGTask{
+				{Name: "local", Template: "build"},
+				{Name: "remote", TemplateRef: &wfv1.TemplateRef{Name: target, Template: "build", ClusterScope: clusterScope}},
+			}},
+		}}},
+	}
+}
+
+// allowUsageScan lets the mocks answer a scan for template usages with empty
+// lists. Expectations registered before it take precedence, and services that
+// are nil are created and set on the client.
+func allowUsageScan(t *testing.T, client *mocks.MockClient, wftService *mocks.MockWorkflowTemplateServiceClient, cwftService *mocks.MockClusterWorkflowTemplateServiceClient) {
+	t.Helper()
+	if wftService == nil {
+		wftService = newMockWorkflowTemplateService(t)
+		client.SetWorkflowTemplateService(wftService)
+	}
+	if cwftService == nil {
+		cwftService = newMockClusterWorkflowTemplateService(t)
+		client.SetClusterWorkflowTemplateService(cwftService)
+	}
+	cronService := newMockCronWorkflowService(t)
+	client.SetCronWorkflowService(cronService)
+
+	wftService.On("ListWorkflowTemplates", mock.Anything, mock.Anything).Return(&wfv1.WorkflowTemplateList{}, nil).Maybe()
+	cwftService.On("ListClusterWorkflowTemplates", mock.Anything, mock.Anything).Return(&wfv1.ClusterWorkflowTemplateList{}, nil).Maybe()
+	cronService.On("ListCronWorkflows", mock.Anything, mock.Anything).Return(&wfv1.CronWorkflowList{}, nil).Maybe()
+}
+
+func TestFindTemplateUsagesTool(t *testing.T) {
+	tool := FindTemplateUsagesTool()
+
+	assert.Equal(t, "find_template_usages", tool.Name)
+	assert.Contains(t, tool.Description, "templateRef")
+	require.NotNil(t, tool.Annotations)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+}
+
+func TestFindTemplateUsagesHandler(t *testing.T) {
+	cronUsingTemplate := wfv1.CronWorkflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "default"},
+		Spec: wfv1.CronWorkflowSpec{WorkflowSpec: wfv1.WorkflowSpec{
+			WorkflowTemplateRef: &wfv1.WorkflowTemplateRef{Name: "lib"},
+			Hooks: wfv1.LifecycleHooks{
+				"exit": {TemplateRef: &wfv1.TemplateRef{Name: "lib", Template: "notify"}},
+			},
+		}},
+	}
+
+	type services struct {
+		wft  *mocks.MockWorkflowTemplateServiceClient
+		cwft *mocks.MockClusterWorkflowTemplateServiceClient
+		cron *mocks.MockCronWorkflowServiceClient
+		wf   *mocks.MockWorkflowServiceClient
+	}
+
+	tests := []struct {
+		setupMock func(services)
+		validate  func(*testing.T, *FindTemplateUsagesOutput, *mcp.CallToolResult)
+		name      string
+		wantErr   string
+		input     FindTemplateUsagesInput
+	}{
+		{
+			name:  "finds usages of a WorkflowTemplate",
+			input: FindTemplateUsagesInput{Namespace: "default", Name: "lib"},
+			setupMock: func(s services) {
+				s.wft.On("ListWorkflowTemplates", mock.Anything, &workflowtemplate.WorkflowTemplateListRequest{Namespace: "default"}).
+					Return(&wfv1.WorkflowTemplateList{Items: []wfv1.WorkflowTemplate{
+						usingWorkflowTemplate("caller", "lib", false),
+						usingWorkflowTemplate("cluster-caller", "lib", true),
+						usingWorkflowTemplate("lib", "lib", false),
+					}}, nil)
+				s.cron.On("ListCronWorkflows", mock.Anything, &cronworkflow.ListCronWorkflowsRequest{Namespace: "default"}).
+					Return(&wfv1.CronWorkflowList{Items: []wfv1.CronWorkflow{cronUsingTemplate}}, nil)
+			},
+			validate: func(t *testing.T, output *FindTemplateUsagesOutput, result *mcp.CallToolResult) {
+				assert.Equal(t, "WorkflowTemplate", output.Kind)
+				assert.Equal(t, 4, output.Scanned)
+				require.Len(t, output.Usages, 3)
+				assert.Equal(t, TemplateUsage{
+					Kind:        "WorkflowTemplate",
+					Name:        "caller",
+					Namespace:   "default",
+					Template:    "main",
+					Path:        "spec.templates[0].dag.tasks[1]",
+					Type:        UsageTemplateRef,
+					RefTemplate: "build",
+				}, output.Usages[0])
+				assert.Equal(t, UsageWorkflowTemplateRef, output.Usages[1].Type)
+				assert.Equal(t, "spec.workflowSpec.workflowTemplateRef", output.Usages[1].Path)
+				assert.Equal(t, "spec.workflowSpec.hooks.exit", output.Usages[2].Path)
+
+				text, ok := result.Content[0].(*mcp.TextContent)
+				require.True(t, ok)
+				assert.Contains(t, text.Text, `Found 3 usage(s) of WorkflowTemplate "lib"`)
+				assert.Contains(t, text.Text, `WorkflowTemplate default/caller template "main" -> "build"`)
+			},
+		},
+		{
+			name:  "narrows to an inner template",
+			input: FindTemplateUsagesInput{Namespace: "default", Name: "lib", Template: "notify"},
+			setupMock: func(s services) {
+				s.wft.On("ListWorkflowTemplates", mock.Anything, mock.Anything).
+					Return(&wfv1.WorkflowTemplateList{Items: []wfv1.WorkflowTemplate{usingWorkflowTemplate("caller", "lib", false)}}, nil)
+				s.cron.On("ListCronWorkflows", mock.Anything, mock.Anything).
+					Return(&wfv1.CronWorkflowList{Items: []wfv1.CronWorkflow{cronUsingTemplate}}, nil)
+			},
+			validate: func(t *testing.T, output *FindTemplateUsagesOutput, _ *mcp.CallToolResult) {
+				// The workflowTemplateRef uses every template, the hook calls notify
+				require.Len(t, output.Usages, 2)
+				assert.Equal(t, "nightly", output.Usages[0].Name)
+				assert.Equal(t, "notify", output.Usages[1].RefTemplate)
+			},
+		},
+		{
+			name:  "cluster template across namespaces with workflows",
+			input: FindTemplateUsagesInput{Name: "lib", ClusterScope: true, IncludeWorkflows: true, WorkflowLimit: 1},
+			setupMock: func(s services) {
+				s.wft.On("ListWorkflowTemplates", mock.Anything, &workflowtemplate.WorkflowTemplateListRequest{Namespace: ""}).
+					Return(&wfv1.WorkflowTemplateList{}, nil)
+				s.cwft.On("ListClusterWorkflowTemplates", mock.Anything, &clusterworkflowtemplate.ClusterWorkflowTemplateListRequest{}).
+					Return(nil, errors.New("forbidden"))
+				s.cron.On("ListCronWorkflows", mock.Anything, mock.Anything).Return(&wfv1.CronWorkflowList{}, nil)
+				caller := usingWorkflowTemplate("run-1", "lib", true)
+				s.wf.On("ListWorkflows", mock.Anything, mock.MatchedBy(func(req *workflow.WorkflowListRequest) bool {
+					return req.Namespace == "" && req.ListOptions.Limit == 1
+				})).Return(&wfv1.WorkflowList{
+					ListMeta: metav1.ListMeta{Continue: "more"},
+					Items:    []wfv1.Workflow{{ObjectMeta: caller.ObjectMeta, Spec: caller.Spec}},
+				}, nil)
+			},
+			validate: func(t *testing.T, output *FindTemplateUsagesOutput, result *mcp.CallToolResult) {
+				assert.Equal(t, "ClusterWorkflowTemplate", output.Kind)
+				assert.Empty(t, output.Namespace)
+				require.Len(t, output.Usages, 1)
+				assert.Equal(t, "Workflow", output.Usages[0].Kind)
+				require.Len(t, output.Warnings, 2)
+				assert.Contains(t, output.Warnings[0], "ClusterWorkflowTemplates were not scanned")
+				assert.Contains(t, output.Warnings[1], "only the first 1 workflows listed")
+
+				text, ok := result.Content[0].(*mcp.TextContent)
+				require.True(t, ok)
+				assert.Contains(t, text.Text, "in all namespaces")
+			},
+		},
+		{
+			name:  "no usages",
+			input: FindTemplateUsagesInput{Name: "lib"},
+			setupMock: func(s services) {
+				s.wft.On("ListWorkflowTemplates", mock.Anything, &workflowtemplate.WorkflowTemplateListRequest{Namespace: "argo"}).
+					Return(&wfv1.WorkflowTemplateList{}, nil)
+				s.cron.On("ListCronWorkflows", mock.Anything, mock.Anything).Return(&wfv1.CronWorkflowList{}, nil)
+			},
+			validate: func(t *testing.T, output *FindTemplateUsagesOutput, result *mcp.CallToolResult) {
+				assert.Empty(t, output.Usages)
+				assert.NotNil(t, output.Usages)
+
+				text, ok := result.Content[0].(*mcp.TextContent)
+				require.True(t, ok)
+				assert.Contains(t, text.Text, `No usages of WorkflowTemplate "lib" found in namespace "argo"`)
+			},
+		},
+		{
+			name:  "list failure",
+			input: FindTemplateUsagesInput{Name: "lib"},
+			setupMock: func(s services) {
+				s.wft.On("ListWorkflowTemplates", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+			},
+			wantErr: "failed to list workflow templates",
+		},
+		{
+			name:    "workflowLimit without includeWorkflows",
+			input:   FindTemplateUsagesInput{Name: "lib", WorkflowLimit: 5},
+			wantErr: "workflowLimit requires includeWorkflows",
+		},
+		{
+			name:    "empty name",
+			input:   FindTemplateUsagesInput{},
+			wantErr: "name cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := newMockClient(t, "argo", true)
+			s := services{
+				wft:  newMockWorkflowTemplateService(t),
+				cwft: newMockClusterWorkflowTemplateService(t),
+				cron: newMockCronWorkflowService(t),
+				wf:   newMockWorkflowService(t),
+			}
+			mockClient.SetWorkflowTemplateService(s.wft)
+			mockClient.SetClusterWorkflowTemplateService(s.cwft)
+			mockClient.SetCronWorkflowService(s.cron)
+			mockClient.SetWorkflowService(s.wf)
+			if tt.setupMock != nil {
+				tt.setupMock(s)
+			}
+			defer s.wft.AssertExpectations(t)
+			defer s.cwft.AssertExpectations(t)
+			defer s.cron.AssertExpectations(t)
+			defer s.wf.AssertExpectations(t)
+
+			handler := FindTemplateUsagesHandler(mockClient)
+			result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, tt.input)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, output)
+			tt.validate(t, output, result)
+		})
+	}
+}
+
+func TestTemplateInUseError(t *testing.T) {
+	usages := make([]TemplateUsage, 12)
+	for i := range usages {
+		usages[i] = TemplateUsage{Kind: "CronWorkflow", Name: "nightly", Namespace: "default", Type: UsageWorkflowTemplateRef}
+	}
+
+	err := templateInUseError("WorkflowTemplate", "lib", usages)
+	assert.Contains(t, err.Error(), `WorkflowTemplate "lib" is still referenced by 12 usage(s)`)
+	assert.Contains(t, err.Error(), "CronWorkflow default/nightly (workflowTemplateRef)")
+	assert.Contains(t, err.Error(), "and 2 more")
+	assert.Contains(t, err.Error(), "set force")
+}
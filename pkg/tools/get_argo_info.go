@@ -0,0 +1,69 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// GetArgoInfoInput defines the input parameters for the get_argo_info tool.
+type GetArgoInfoInput struct{}
+
+// GetArgoInfoOutput defines the output for the get_argo_info tool.
+type GetArgoInfoOutput = argo.ClusterInfo
+
+// GetArgoInfoTool returns the MCP tool definition for get_argo_info.
+func GetArgoInfoTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "get_argo_info",
+		Description: "Get information about the connected Argo Workflows installation: version, connection mode, " +
+			"managed namespace, instance ID (direct Kubernetes mode only), configured links and columns, whether the workflow archive and SSO are enabled, " +
+			"and the identity of the current user. Use this to check which tools and features are available.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+}
+
+// GetArgoInfoHandler returns a handler function for the get_argo_info tool.
+func GetArgoInfoHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, GetArgoInfoInput) (*mcp.CallToolResult, *GetArgoInfoOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, _ GetArgoInfoInput) (*mcp.CallToolResult, *GetArgoInfoOutput, error) {
+		output, err := argo.GetClusterInfo(ctx, client)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get Argo info: %w", err)
+		}
+
+		return TextResult(formatArgoInfo(output)), output, nil
+	}
+}
+
+// formatArgoInfo builds a human-readable summary of the cluster info.
+func formatArgoInfo(info *argo.ClusterInfo) string {
+	var sb strings.Builder
+
+	version := info.Version
+	if version == "" {
+		version = "unknown"
+	}
+	fmt.Fprintf(&sb, "Argo Workflows %s (connected via %s)", version, info.Mode)
+
+	if info.ManagedNamespace != "" {
+		fmt.Fprintf(&sb, "\nManaged namespace: %s", info.ManagedNamespace)
+	}
+	if info.InstanceID != "" {
+		fmt.Fprintf(&sb, "\nInstance ID: %s", info.InstanceID)
+	}
+	fmt.Fprintf(&sb, "\nArchive enabled: %t (archive tools available: %t)", info.ArchiveEnabled, info.ArchiveToolsAvailable)
+	fmt.Fprintf(&sb, "\nSSO enabled: %t", info.SSOEnabled)
+
+	for _, warning := range info.Warnings {
+		fmt.Fprintf(&sb, "\nWarning: %s", warning)
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/info"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+)
+
+func TestGetArgoInfoTool(t *testing.T) {
+	tool := GetArgoInfoTool()
+
+	assert.Equal(t, "get_argo_info", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	require.NotNil(t, tool.Annotations)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+}
+
+func TestGetArgoInfoHandler(t *testing.T) {
+	t.Run("argo server mode", func(t *testing.T) {
+		infoService := &mocks.MockInfoServiceClient{}
+		infoService.On("GetInfo", mock.Anything, mock.Anything).Return(&info.InfoResponse{ManagedNamespace: "argo"}, nil)
+		infoService.On("GetVersion", mock.Anything, mock.Anything).Return(&wfv1.Version{Version: "v4.0.5"}, nil)
+		infoService.On("GetUserInfo", mock.Anything, mock.Anything).Return(&info.GetUserInfoResponse{}, nil)
+
+		archiveService := &mocks.MockArchivedWorkflowServiceClient{}
+		archiveService.On("GetArchivedWorkflow", mock.Anything, mock.Anything).Return(nil, status.Error(codes.NotFound, "not found"))
+
+		client := newMockClient(t, "default", true)
+		client.SetInfoService(infoService)
+		client.SetArchivedWorkflowService(archiveService)
+
+		handler := GetArgoInfoHandler(client)
+		result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, GetArgoInfoInput{})
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		require.NotNil(t, output)
+
+		assert.Equal(t, argo.ModeArgoServer, output.Mode)
+		assert.Equal(t, "v4.0.5", output.Version)
+		assert.Equal(t, "argo", output.ManagedNamespace)
+		assert.True(t, output.ArchiveToolsAvailable)
+
+		text, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, text.Text, "Argo Workflows v4.0.5")
+		assert.Contains(t, text.Text, "Managed namespace: argo")
+	})
+
+	t.Run("info service error", func(t *testing.T) {
+		client := newMockClient(t, "default", true)
+		client.On("InfoService").Return(nil, errors.New("connection refused"))
+
+		handler := GetArgoInfoHandler(client)
+		_, _, err := handler(t.Context(), &mcp.CallToolRequest{}, GetArgoInfoInput{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get Argo info")
+	})
+}
@@ -0,0 +1,463 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	syncpkg "github.com/argoproj/argo-workflows/v4/pkg/apiclient/sync"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/workflow/common"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+const (
+	// SyncTypeSemaphore is a semaphore lock.
+	SyncTypeSemaphore = "semaphore"
+
+	// SyncTypeMutex is a mutex lock.
+	SyncTypeMutex = "mutex"
+
+	// LockKindConfigMap is a semaphore whose limit is stored in a ConfigMap.
+	LockKindConfigMap = "ConfigMap"
+
+	// LockKindDatabase is a semaphore or mutex shared through the sync database.
+	LockKindDatabase = "Database"
+
+	// LockKindMutex is a mutex local to the workflow controller.
+	LockKindMutex = "Mutex"
+)
+
+// GetSynchronizationStatusInput defines the input parameters for the get_synchronization_status tool.
+type GetSynchronizationStatusInput struct {
+	// Namespace is the Kubernetes namespace (uses default if not specified).
+	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace (uses default if not specified)"`
+}
+
+// SyncLockWaiter is a workflow, or a node of one, queued for a lock.
+type SyncLockWaiter struct {
+	// Priority is the workflow priority; higher priorities are served first.
+	Priority *int32 `json:"priority,omitempty"`
+
+	// Namespace is the namespace of the workflow.
+	Namespace string `json:"namespace"`
+
+	// Workflow is the workflow name.
+	Workflow string `json:"workflow"`
+
+	// NodeID is set when a template, rather than the whole workflow, is waiting.
+	NodeID string `json:"nodeId,omitempty"`
+
+	// NodeName is the display name of the waiting node.
+	NodeName string `json:"nodeName,omitempty"`
+
+	// WaitingSince is when the waiter was queued, in RFC3339 format.
+	WaitingSince string `json:"waitingSince,omitempty"`
+
+	// WaitingSeconds is how long the waiter has been queued.
+	WaitingSeconds int64 `json:"waitingSeconds"`
+
+	// Position is the estimated position in the queue, starting at 1.
+	Position int `json:"position"`
+}
+
+// SyncLock is a semaphore or mutex used by workflows of a namespace.
+type SyncLock struct {
+	// Limit is how many holders the lock admits, when known.
+	Limit *int32 `json:"limit,omitempty"`
+
+	// Key is the lock key used by the workflow controller.
+	Key string `json:"key"`
+
+	// Type is semaphore or mutex.
+	Type string `json:"type"`
+
+	// Kind is ConfigMap, Database or Mutex.
+	Kind string `json:"kind"`
+
+	// Namespace is the namespace of the lock.
+	Namespace string `json:"namespace"`
+
+	// Name is the ConfigMap, database lock or mutex name.
+	Name string `json:"name"`
+
+	// ConfigMapKey is the ConfigMap key holding the limit of a ConfigMap semaphore.
+	ConfigMapKey string `json:"configMapKey,omitempty"`
+
+	// LimitError is set when the limit could not be looked up.
+	LimitError string `json:"limitError,omitempty"`
+
+	// Holders lists the workflows and nodes holding the lock, as namespace/workflow[/nodeId].
+	Holders []string `json:"holders"`
+
+	// Waiters lists the queue of the lock, in estimated order of service.
+	Waiters []SyncLockWaiter `json:"waiters"`
+}
+
+// GetSynchronizationStatusOutput defines the output for the get_synchronization_status tool.
+type GetSynchronizationStatusOutput struct {
+	// Namespace is the namespace that was inspected.
+	Namespace string `json:"namespace"`
+
+	// Locks lists the locks in use, ordered by key.
+	Locks []SyncLock `json:"locks"`
+}
+
+// SyncWait explains why a workflow, or one of its nodes, is not running yet.
+type SyncWait struct {
+	// Lock is the key of the lock being waited for.
+	Lock string `json:"lock"`
+
+	// Type is semaphore or mutex.
+	Type string `json:"type"`
+
+	// Kind is ConfigMap, Database or Mutex.
+	Kind string `json:"kind,omitempty"`
+
+	// NodeID is set when a template, rather than the whole workflow, is waiting.
+	NodeID string `json:"nodeId,omitempty"`
+
+	// NodeName is the display name of the waiting node.
+	NodeName string `json:"nodeName,omitempty"`
+
+	// Message is the controller's message about the wait, if any.
+	Message string `json:"message,omitempty"`
+
+	// WaitingSince is when the wait started, in RFC3339 format.
+	WaitingSince string `json:"waitingSince,omitempty"`
+
+	// Holders lists the current holders of the lock.
+	Holders []string `json:"holders,omitempty"`
+
+	// WaitingSeconds is how long the wait has lasted.
+	WaitingSeconds int64 `json:"waitingSeconds"`
+}
+
+// GetSynchronizationStatusTool returns the MCP tool definition for get_synchronization_status.
+func GetSynchronizationStatusTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "get_synchronization_status",
+		Description: "Report the semaphores (ConfigMap and database backed) and mutexes used by the workflows of a namespace: " +
+			"their limits, current holders and the queue of waiting workflows and nodes with how long each has waited",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+}
+
+// GetSynchronizationStatusHandler returns a handler function for the get_synchronization_status tool.
+func GetSynchronizationStatusHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, GetSynchronizationStatusInput) (*mcp.CallToolResult, *GetSynchronizationStatusOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input GetSynchronizationStatusInput) (*mcp.CallToolResult, *GetSynchronizationStatusOutput, error) {
+		namespace := ResolveNamespace(input.Namespace, client)
+
+		wfService := client.WorkflowService()
+		listResp, err := wfService.ListWorkflows(ctx, &workflow.WorkflowListRequest{
+			Namespace:   namespace,
+			ListOptions: &metav1.ListOptions{LabelSelector: common.LabelKeyCompleted + "!=true"},
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list workflows: %w", err)
+		}
+
+		now := time.Now()
+		locks := map[string]*SyncLock{}
+		lockFor := func(key, syncType string) *SyncLock {
+			lock, ok := locks[key]
+			if !ok {
+				lock = newSyncLock(key, syncType)
+				locks[key] = lock
+			}
+			return lock
+		}
+
+		for i := range listResp.Items {
+			wf := &listResp.Items[i]
+			if wf.Status.Synchronization == nil {
+				continue
+			}
+			// Lists don't carry offloaded or compressed node status
+			if len(wf.Status.Nodes) == 0 && (wf.Status.IsOffloadNodeStatus() || wf.Status.CompressedNodes != "") {
+				wf, err = wfService.GetWorkflow(ctx, &workflow.WorkflowGetRequest{Name: wf.Name, Namespace: wf.Namespace})
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to get workflow %q: %w", listResp.Items[i].Name, err)
+				}
+			}
+
+			if sem := wf.Status.Synchronization.Semaphore; sem != nil {
+				for _, holding := range sem.Holding {
+					lock := lockFor(holding.Semaphore, SyncTypeSemaphore)
+					for _, holder := range holding.Holders {
+						lock.Holders = append(lock.Holders, qualifyHolder(wf, holder))
+					}
+				}
+			}
+			if mtx := wf.Status.Synchronization.Mutex; mtx != nil {
+				for _, holding := range mtx.Holding {
+					lock := lockFor(holding.Mutex, SyncTypeMutex)
+					lock.Holders = append(lock.Holders, qualifyHolder(wf, holding.Holder))
+				}
+			}
+			for _, wait := range syncWaits(wf, now) {
+				lock := lockFor(wait.Lock, wait.Type)
+				lock.Holders = append(lock.Holders, wait.Holders...)
+				lock.Waiters = append(lock.Waiters, SyncLockWaiter{
+					Priority:       wf.Spec.Priority,
+					Namespace:      wf.Namespace,
+					Workflow:       wf.Name,
+					NodeID:         wait.NodeID,
+					NodeName:       wait.NodeName,
+					WaitingSince:   wait.WaitingSince,
+					WaitingSeconds: wait.WaitingSeconds,
+				})
+			}
+		}
+
+		output := &GetSynchronizationStatusOutput{Namespace: namespace, Locks: make([]SyncLock, 0, len(locks))}
+		var syncService syncpkg.SyncServiceClient
+		var syncErr error
+		for _, lock := range locks {
+			if lock.Type == SyncTypeSemaphore && syncService == nil && syncErr == nil {
+				syncService, syncErr = client.SyncService()
+			}
+			lookupSyncLimit(ctx, syncService, syncErr, lock)
+			finishSyncLock(lock)
+			output.Locks = append(output.Locks, *lock)
+		}
+		slices.SortFunc(output.Locks, func(a, b SyncLock) int {
+			return strings.Compare(a.Key, b.Key)
+		})
+
+		return TextResult(formatSynchronizationStatus(output)), output, nil
+	}
+}
+
+// parseLockKey splits a lock key of the workflow controller, such as
+// "ns/ConfigMap/name/key", "ns/Database/name" or "ns/Mutex/name".
+func parseLockKey(key string) (namespace, kind, name, configMapKey string) {
+	parts := strings.SplitN(key, "/", 3)
+	if len(parts) < 3 {
+		return "", "", key, ""
+	}
+	namespace, kind, name = parts[0], parts[1], parts[2]
+	if kind == LockKindConfigMap {
+		if cm, cmKey, ok := strings.Cut(name, "/"); ok {
+			name, configMapKey = cm, cmKey
+		}
+	}
+	return namespace, kind, name, configMapKey
+}
+
+// newSyncLock returns an empty lock for key.
+func newSyncLock(key, syncType string) *SyncLock {
+	namespace, kind, name, configMapKey := parseLockKey(key)
+	lock := &SyncLock{
+		Key:          key,
+		Type:         syncType,
+		Kind:         kind,
+		Namespace:    namespace,
+		Name:         name,
+		ConfigMapKey: configMapKey,
+		Holders:      []string{},
+		Waiters:      []SyncLockWaiter{},
+	}
+	if syncType == SyncTypeMutex {
+		lock.Limit = ptr.To[int32](1)
+	}
+	return lock
+}
+
+// lookupSyncLimit sets the limit of a semaphore from the sync service.
+// Failures are recorded on the lock, so one unreadable limit does not hide
+// the holders and waiters of every lock.
+func lookupSyncLimit(ctx context.Context, syncService syncpkg.SyncServiceClient, syncErr error, lock *SyncLock) {
+	if lock.Type != SyncTypeSemaphore {
+		return
+	}
+	if syncErr != nil {
+		lock.LimitError = syncErr.Error()
+		return
+	}
+
+	req := &syncpkg.GetSyncLimitRequest{Namespace: lock.Namespace}
+	switch lock.Kind {
+	case LockKindConfigMap:
+		req.Type = syncpkg.SyncConfigType_CONFIGMAP
+		req.CmName = lock.Name
+		req.Key = lock.ConfigMapKey
+	case LockKindDatabase:
+		req.Type = syncpkg.SyncConfigType_DATABASE
+		req.Key = lock.Name
+	default:
+		lock.LimitError = fmt.Sprintf("unknown semaphore kind %q", lock.Kind)
+		return
+	}
+
+	resp, err := syncService.GetSyncLimit(ctx, req)
+	if err != nil {
+		lock.LimitError = fmt.Sprintf("failed to get sync limit: %v", err)
+		return
+	}
+	lock.Limit = ptr.To(resp.Limit)
+}
+
+// finishSyncLock deduplicates the holders of a lock and orders its waiters
+// the way the workflow controller serves them: highest priority first, then
+// longest waiting.
+func finishSyncLock(lock *SyncLock) {
+	slices.Sort(lock.Holders)
+	lock.Holders = slices.Compact(lock.Holders)
+
+	slices.SortStableFunc(lock.Waiters, func(a, b SyncLockWaiter) int {
+		if c := cmp.Compare(ptr.Deref(b.Priority, 0), ptr.Deref(a.Priority, 0)); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.WaitingSeconds, a.WaitingSeconds); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Workflow+"/"+a.NodeID, b.Workflow+"/"+b.NodeID)
+	})
+	for i := range lock.Waiters {
+		lock.Waiters[i].Position = i + 1
+	}
+}
+
+// qualifyHolder returns the namespace/workflow[/nodeId] form of a lock
+// holder. Older controllers record only the node ID of the holding workflow.
+func qualifyHolder(wf *wfv1.Workflow, holder string) string {
+	if wfv1.CheckHolderKeyVersion(holder) == wfv1.HoldingNameV2 {
+		return holder
+	}
+	if holder == "" || holder == wf.Name {
+		return wf.Namespace + "/" + wf.Name
+	}
+	return wf.Namespace + "/" + wf.Name + "/" + holder
+}
+
+// syncWaits returns the locks wf, or its nodes, are queued for. A lock
+// waited for by no node is waited for by the workflow itself.
+func syncWaits(wf *wfv1.Workflow, now time.Time) []SyncWait {
+	status := wf.Status.Synchronization
+	if status == nil {
+		return nil
+	}
+
+	holders := map[string][]string{}
+	types := map[string]string{}
+	var keys []string
+	if status.Semaphore != nil {
+		for _, waiting := range status.Semaphore.Waiting {
+			keys = append(keys, waiting.Semaphore)
+			types[waiting.Semaphore] = SyncTypeSemaphore
+			for _, holder := range waiting.Holders {
+				holders[waiting.Semaphore] = append(holders[waiting.Semaphore], qualifyHolder(wf, holder))
+			}
+		}
+	}
+	if status.Mutex != nil {
+		for _, waiting := range status.Mutex.Waiting {
+			keys = append(keys, waiting.Mutex)
+			types[waiting.Mutex] = SyncTypeMutex
+			if waiting.Holder != "" {
+				holders[waiting.Mutex] = []string{qualifyHolder(wf, waiting.Holder)}
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	var waits []SyncWait
+	nodeWaits := map[string]bool{}
+	for id, node := range wf.Status.Nodes {
+		if node.SynchronizationStatus == nil || node.SynchronizationStatus.Waiting == "" || node.Fulfilled() {
+			continue
+		}
+		key := node.SynchronizationStatus.Waiting
+		syncType, ok := types[key]
+		if !ok {
+			continue
+		}
+		nodeWaits[key] = true
+		waits = append(waits, newSyncWait(key, syncType, holders[key], node.Message, node.StartedAt, now, id, node.DisplayName))
+	}
+
+	for _, key := range keys {
+		if nodeWaits[key] {
+			continue
+		}
+		message := ""
+		if strings.HasPrefix(wf.Status.Message, "Waiting for") {
+			message = wf.Status.Message
+		}
+		waits = append(waits, newSyncWait(key, types[key], holders[key], message, wf.CreationTimestamp, now, "", ""))
+	}
+
+	slices.SortFunc(waits, func(a, b SyncWait) int {
+		return strings.Compare(a.Lock+"/"+a.NodeName, b.Lock+"/"+b.NodeName)
+	})
+	return waits
+}
+
+// newSyncWait builds a SyncWait for the lock key.
+func newSyncWait(key, syncType string, holders []string, message string, since metav1.Time, now time.Time, nodeID, nodeName string) SyncWait {
+	_, kind, _, _ := parseLockKey(key)
+	wait := SyncWait{
+		Lock:     key,
+		Type:     syncType,
+		Kind:     kind,
+		NodeID:   nodeID,
+		NodeName: nodeName,
+		Message:  message,
+		Holders:  holders,
+	}
+	if !since.IsZero() {
+		wait.WaitingSince = since.Format(time.RFC3339)
+		wait.WaitingSeconds = int64(now.Sub(since.Time).Seconds())
+	}
+	return wait
+}
+
+// formatSynchronizationStatus renders the synchronization status as text.
+func formatSynchronizationStatus(output *GetSynchronizationStatusOutput) string {
+	if len(output.Locks) == 0 {
+		return fmt.Sprintf("No semaphores or mutexes are held or waited for in namespace %q", output.Namespace)
+	}
+
+	var text strings.Builder
+	fmt.Fprintf(&text, "%d lock(s) in use in namespace %q:", len(output.Locks), output.Namespace)
+	for _, lock := range output.Locks {
+		fmt.Fprintf(&text, "\n- %s %s", lock.Type, lock.Key)
+		switch {
+		case lock.Limit != nil:
+			fmt.Fprintf(&text, ": %d/%d held", len(lock.Holders), *lock.Limit)
+		case lock.LimitError != "":
+			fmt.Fprintf(&text, ": %d held, limit unknown (%s)", len(lock.Holders), lock.LimitError)
+		default:
+			fmt.Fprintf(&text, ": %d held", len(lock.Holders))
+		}
+		fmt.Fprintf(&text, ", %d waiting", len(lock.Waiters))
+		for _, holder := range lock.Holders {
+			fmt.Fprintf(&text, "\n    holder %s", holder)
+		}
+		for _, waiter := range lock.Waiters {
+			fmt.Fprintf(&text, "\n    #%d %s/%s", waiter.Position, waiter.Namespace, waiter.Workflow)
+			if waiter.NodeName != "" {
+				fmt.Fprintf(&text, " node %q", waiter.NodeName)
+			}
+			fmt.Fprintf(&text, " waiting %s", (time.Duration(waiter.WaitingSeconds) * time.Second).String())
+			if waiter.Priority != nil {
+				fmt.Fprintf(&text, " (priority %d)", *waiter.Priority)
+			}
+		}
+	}
+	return text.String()
+}
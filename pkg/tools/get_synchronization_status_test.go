@@ -0,0 +1,277 @@
+package tools
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	syncpkg "github.com/argoproj/argo-workflows/v4/pkg/apiclient/sync"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+)
+
+const (
+	testSemaphoreKey = "argo/ConfigMap/limits/deploy"
+	testDBSemaphore  = "argo/Database/shared"
+	testMutexKey     = "argo/Mutex/release"
+)
+
+// holdingWorkflow is a running workflow holding the deploy semaphore and the release mutex.
+func holdingWorkflow() wfv1.Workflow {
+	return wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "holder", Namespace: "argo"},
+		Status: wfv1.WorkflowStatus{
+			Phase: wfv1.WorkflowRunning,
+			Synchronization: &wfv1.SynchronizationStatus{
+				Semaphore: &wfv1.SemaphoreStatus{Holding: []wfv1.SemaphoreHolding{
+					{Semaphore: testSemaphoreKey, Holders: []string{"argo/holder"}},
+				}},
+				Mutex: &wfv1.MutexStatus{Holding: []wfv1.MutexHolding{
+					{Mutex: testMutexKey, Holder: "holder-123"},
+				}},
+			},
+		},
+	}
+}
+
+// waitingWorkflow is a pending workflow queued for the deploy semaphore.
+func waitingWorkflow(name string, waiting time.Duration, priority *int32) wfv1.Workflow {
+	return wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "argo", CreationTimestamp: metav1.NewTime(time.Now().Add(-waiting))},
+		Spec:       wfv1.WorkflowSpec{Priority: priority},
+		Status: wfv1.WorkflowStatus{
+			Phase:   wfv1.WorkflowPending,
+			Message: "Waiting for argo/ConfigMap/limits/deploy lock. Lock status: 0/1",
+			Synchronization: &wfv1.SynchronizationStatus{
+				Semaphore: &wfv1.SemaphoreStatus{Waiting: []wfv1.SemaphoreHolding{
+					{Semaphore: testSemaphoreKey, Holders: []string{"argo/holder"}},
+				}},
+			},
+		},
+	}
+}
+
+// nodeWaitingWorkflow is a running workflow whose "release" node is queued for the release mutex.
+func nodeWaitingWorkflow() wfv1.Workflow {
+	return wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "nodes", Namespace: "argo", CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour))},
+		Status: wfv1.WorkflowStatus{
+			Phase: wfv1.WorkflowRunning,
+			Nodes: wfv1.Nodes{
+				"nodes-1": {ID: "nodes-1", Name: "nodes[0].release", DisplayName: "release", Type: wfv1.NodeTypePod,
+					Phase: wfv1.NodePending, Message: "Waiting for argo/Mutex/release lock. Lock status: 0/1",
+					StartedAt:             metav1.NewTime(time.Now().Add(-2 * time.Minute)),
+					SynchronizationStatus: &wfv1.NodeSynchronizationStatus{Waiting: testMutexKey}},
+			},
+			Synchronization: &wfv1.SynchronizationStatus{
+				Semaphore: &wfv1.SemaphoreStatus{Holding: []wfv1.SemaphoreHolding{
+					{Semaphore: testDBSemaphore, Holders: []string{"argo/nodes/nodes-0"}},
+				}},
+				Mutex: &wfv1.MutexStatus{Waiting: []wfv1.MutexHolding{
+					{Mutex: testMutexKey, Holder: "argo/holder/holder-123"},
+				}},
+			},
+		},
+	}
+}
+
+func TestGetSynchronizationStatusTool(t *testing.T) {
+	tool := GetSynchronizationStatusTool()
+
+	assert.Equal(t, "get_synchronization_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	require.NotNil(t, tool.Annotations)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+}
+
+func TestGetSynchronizationStatusHandler(t *testing.T) {
+	t.Run("reports holders, limits and queues", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", true)
+		wfService := newMockWorkflowService(t)
+		mockClient.SetWorkflowService(wfService)
+		syncService := &mocks.MockSyncServiceClient{}
+		mockClient.SetSyncService(syncService)
+
+		wfService.On("ListWorkflows", mock.Anything, &workflow.WorkflowListRequest{
+			Namespace:   "argo",
+			ListOptions: &metav1.ListOptions{LabelSelector: "workflows.argoproj.io/completed!=true"},
+		}).Return(&wfv1.WorkflowList{Items: wfv1.Workflows{
+			holdingWorkflow(),
+			waitingWorkflow("recent", time.Minute, nil),
+			waitingWorkflow("old", time.Hour, nil),
+			waitingWorkflow("urgent", time.Second, ptr.To[int32](10)),
+			nodeWaitingWorkflow(),
+		}}, nil)
+		syncService.On("GetSyncLimit", mock.Anything, &syncpkg.GetSyncLimitRequest{
+			Type: syncpkg.SyncConfigType_CONFIGMAP, Namespace: "argo", CmName: "limits", Key: "deploy",
+		}).Return(&syncpkg.SyncLimitResponse{Limit: 1}, nil)
+		syncService.On("GetSyncLimit", mock.Anything, &syncpkg.GetSyncLimitRequest{
+			Type: syncpkg.SyncConfigType_DATABASE, Namespace: "argo", Key: "shared",
+		}).Return(nil, errors.New("database sync is not configured"))
+		defer wfService.AssertExpectations(t)
+		defer syncService.AssertExpectations(t)
+
+		handler := GetSynchronizationStatusHandler(mockClient)
+		result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, GetSynchronizationStatusInput{})
+		require.NoError(t, err)
+
+		assert.Equal(t, "argo", output.Namespace)
+		require.Len(t, output.Locks, 3)
+
+		semaphore := output.Locks[0]
+		assert.Equal(t, testSemaphoreKey, semaphore.Key)
+		assert.Equal(t, SyncTypeSemaphore, semaphore.Type)
+		assert.Equal(t, LockKindConfigMap, semaphore.Kind)
+		assert.Equal(t, "limits", semaphore.Name)
+		assert.Equal(t, "deploy", semaphore.ConfigMapKey)
+		assert.Equal(t, ptr.To[int32](1), semaphore.Limit)
+		assert.Equal(t, []string{"argo/holder"}, semaphore.Holders)
+		require.Len(t, semaphore.Waiters, 3)
+		assert.Equal(t, "urgent", semaphore.Waiters[0].Workflow)
+		assert.Equal(t, "old", semaphore.Waiters[1].Workflow)
+		assert.Equal(t, 2, semaphore.Waiters[1].Position)
+		assert.GreaterOrEqual(t, semaphore.Waiters[1].WaitingSeconds, int64(3600))
+		assert.Equal(t, "recent", semaphore.Waiters[2].Workflow)
+
+		database := output.Locks[1]
+		assert.Equal(t, testDBSemaphore, database.Key)
+		assert.Equal(t, LockKindDatabase, database.Kind)
+		assert.Nil(t, database.Limit)
+		assert.Contains(t, database.LimitError, "database sync is not configured")
+		assert.Equal(t, []string{"argo/nodes/nodes-0"}, database.Holders)
+		assert.Empty(t, database.Waiters)
+
+		mutex := output.Locks[2]
+		assert.Equal(t, testMutexKey, mutex.Key)
+		assert.Equal(t, SyncTypeMutex, mutex.Type)
+		assert.Equal(t, ptr.To[int32](1), mutex.Limit)
+		assert.Equal(t, []string{"argo/holder/holder-123"}, mutex.Holders)
+		require.Len(t, mutex.Waiters, 1)
+		assert.Equal(t, "nodes", mutex.Waiters[0].Workflow)
+		assert.Equal(t, "nodes-1", mutex.Waiters[0].NodeID)
+		assert.Equal(t, "release", mutex.Waiters[0].NodeName)
+
+		text := result.Content[0].(*mcp.TextContent).Text
+		assert.Contains(t, text, "3 lock(s) in use")
+		assert.Contains(t, text, "semaphore argo/ConfigMap/limits/deploy: 1/1 held, 3 waiting")
+		assert.Contains(t, text, "limit unknown")
+		assert.Contains(t, text, "#1 argo/urgent")
+	})
+
+	t.Run("records a missing sync service on every semaphore", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", false)
+		wfService := newMockWorkflowService(t)
+		mockClient.SetWorkflowService(wfService)
+		mockClient.On("SyncService").Return(nil, errors.New("sync service unavailable"))
+
+		wfService.On("ListWorkflows", mock.Anything, mock.Anything).
+			Return(&wfv1.WorkflowList{Items: wfv1.Workflows{holdingWorkflow()}}, nil)
+
+		handler := GetSynchronizationStatusHandler(mockClient)
+		_, output, err := handler(t.Context(), &mcp.CallToolRequest{}, GetSynchronizationStatusInput{})
+		require.NoError(t, err)
+
+		require.Len(t, output.Locks, 2)
+		assert.Equal(t, "sync service unavailable", output.Locks[0].LimitError)
+		assert.Equal(t, ptr.To[int32](1), output.Locks[1].Limit)
+		mockClient.AssertNumberOfCalls(t, "SyncService", 1)
+	})
+
+	t.Run("reports when nothing is locked", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", true)
+		wfService := newMockWorkflowService(t)
+		mockClient.SetWorkflowService(wfService)
+
+		wfService.On("ListWorkflows", mock.Anything, mock.Anything).
+			Return(&wfv1.WorkflowList{Items: wfv1.Workflows{{ObjectMeta: metav1.ObjectMeta{Name: "free", Namespace: "argo"}}}}, nil)
+
+		handler := GetSynchronizationStatusHandler(mockClient)
+		result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, GetSynchronizationStatusInput{Namespace: "argo"})
+		require.NoError(t, err)
+
+		assert.Empty(t, output.Locks)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "No semaphores or mutexes")
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", true)
+		wfService := newMockWorkflowService(t)
+		mockClient.SetWorkflowService(wfService)
+
+		wfService.On("ListWorkflows", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+		handler := GetSynchronizationStatusHandler(mockClient)
+		_, _, err := handler(t.Context(), &mcp.CallToolRequest{}, GetSynchronizationStatusInput{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list workflows")
+	})
+}
+
+func TestParseLockKey(t *testing.T) {
+	tests := []struct {
+		key, namespace, kind, name, configMapKey string
+	}{
+		{key: "argo/ConfigMap/limits/deploy", namespace: "argo", kind: LockKindConfigMap, name: "limits", configMapKey: "deploy"},
+		{key: "argo/Database/shared", namespace: "argo", kind: LockKindDatabase, name: "shared"},
+		{key: "argo/Mutex/release", namespace: "argo", kind: LockKindMutex, name: "release"},
+		{key: "legacy", name: "legacy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			namespace, kind, name, configMapKey := parseLockKey(tt.key)
+			assert.Equal(t, tt.namespace, namespace)
+			assert.Equal(t, tt.kind, kind)
+			assert.Equal(t, tt.name, name)
+			assert.Equal(t, tt.configMapKey, configMapKey)
+		})
+	}
+}
+
+func TestSyncWaits(t *testing.T) {
+	t.Run("workflow level wait", func(t *testing.T) {
+		wf := waitingWorkflow("queued", time.Hour, nil)
+		waits := syncWaits(&wf, time.Now())
+
+		require.Len(t, waits, 1)
+		assert.Equal(t, testSemaphoreKey, waits[0].Lock)
+		assert.Equal(t, SyncTypeSemaphore, waits[0].Type)
+		assert.Empty(t, waits[0].NodeID)
+		assert.Equal(t, []string{"argo/holder"}, waits[0].Holders)
+		assert.Contains(t, waits[0].Message, "Lock status: 0/1")
+		assert.GreaterOrEqual(t, waits[0].WaitingSeconds, int64(3600))
+	})
+
+	t.Run("node level wait", func(t *testing.T) {
+		wf := nodeWaitingWorkflow()
+		waits := syncWaits(&wf, time.Now())
+
+		require.Len(t, waits, 1)
+		assert.Equal(t, testMutexKey, waits[0].Lock)
+		assert.Equal(t, "nodes-1", waits[0].NodeID)
+		assert.Equal(t, "release", waits[0].NodeName)
+		assert.Equal(t, []string{"argo/holder/holder-123"}, waits[0].Holders)
+		assert.Less(t, waits[0].WaitingSeconds, int64(3600))
+	})
+
+	t.Run("not waiting", func(t *testing.T) {
+		wf := holdingWorkflow()
+		assert.Empty(t, syncWaits(&wf, time.Now()))
+	})
+}
+
+func TestQualifyHolder(t *testing.T) {
+	wf := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "wf", Namespace: "argo"}}
+
+	assert.Equal(t, "argo/other", qualifyHolder(wf, "argo/other"))
+	assert.Equal(t, "argo/other/node", qualifyHolder(wf, "argo/other/node"))
+	assert.Equal(t, "argo/wf", qualifyHolder(wf, "wf"))
+	assert.Equal(t, "argo/wf/wf-123", qualifyHolder(wf, "wf-123"))
+}
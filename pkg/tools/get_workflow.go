@@ -47,6 +47,8 @@ type GetWorkflowOutput struct {
 	Progress string `json:"progress,omitempty"`
 	// Parameters are the workflow input parameters.
 	Parameters []ParameterInfo `json:"parameters,omitempty"`
+	// WaitingOn lists the semaphores and mutexes the workflow or its nodes are queued for.
+	WaitingOn []SyncWait `json:"waitingOn,omitempty"`
 }
 
 // ParameterInfo represents a workflow parameter.
@@ -184,6 +186,11 @@ func buildGetWorkflowOutput(wf *wfv1.Workflow) *GetWorkflowOutput {
 		output.NodeSummary = buildNodeSummary(wf.Status.Nodes)
 	}
 
+	// Explain synchronization waits
+	if !wf.Status.Fulfilled() {
+		output.WaitingOn = syncWaits(wf, time.Now())
+	}
+
 	return output
 }
 
@@ -0,0 +1,155 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"google.golang.org/grpc/codes"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
+)
+
+// GetWorkflowEventBindingInput defines the input parameters for the get_workflow_event_binding tool.
+type GetWorkflowEventBindingInput struct {
+	// Namespace is the Kubernetes namespace (uses default if not specified).
+	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace (uses default if not specified)"`
+
+	// Name is the WorkflowEventBinding name.
+	Name string `json:"name" jsonschema:"WorkflowEventBinding name,required"`
+}
+
+// EventBindingParameter is a workflow argument set from an event.
+type EventBindingParameter struct {
+	// Name is the parameter name.
+	Name string `json:"name"`
+
+	// Value is a literal value.
+	Value string `json:"value,omitempty"`
+
+	// Event is the expression evaluated against the event.
+	Event string `json:"event,omitempty"`
+}
+
+// GetWorkflowEventBindingOutput defines the output for the get_workflow_event_binding tool.
+type GetWorkflowEventBindingOutput struct {
+	// Labels are the labels of the binding.
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// WorkflowLabels are the labels set on submitted workflows.
+	WorkflowLabels map[string]string `json:"workflowLabels,omitempty"`
+
+	// Binding summarizes the binding.
+	Binding WorkflowEventBindingSummary `json:"binding"`
+
+	// WorkflowName is the name, or generateName, of submitted workflows.
+	WorkflowName string `json:"workflowName,omitempty"`
+
+	// Parameters are the workflow arguments set from the event.
+	Parameters []EventBindingParameter `json:"parameters,omitempty"`
+}
+
+// GetWorkflowEventBindingTool returns the MCP tool definition for get_workflow_event_binding.
+func GetWorkflowEventBindingTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "get_workflow_event_binding",
+		Description: "Get a WorkflowEventBinding: its event selector, the template it submits and the arguments it takes from the event",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+}
+
+// GetWorkflowEventBindingHandler returns a handler function for the get_workflow_event_binding tool.
+func GetWorkflowEventBindingHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, GetWorkflowEventBindingInput) (*mcp.CallToolResult, *GetWorkflowEventBindingOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input GetWorkflowEventBindingInput) (*mcp.CallToolResult, *GetWorkflowEventBindingOutput, error) {
+		name := strings.TrimSpace(input.Name)
+		if name == "" {
+			return nil, nil, toolerror.Invalid("name", errors.New("workflow event binding name cannot be empty"))
+		}
+		namespace := ResolveNamespace(input.Namespace, client)
+
+		binding, err := getEventBinding(ctx, client, namespace, name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		output := &GetWorkflowEventBindingOutput{
+			Labels:  binding.Labels,
+			Binding: eventBindingSummary(binding),
+		}
+		if submit := binding.Spec.Submit; submit != nil {
+			output.WorkflowLabels = submit.Labels
+			output.WorkflowName = submit.Name
+			if output.WorkflowName == "" {
+				output.WorkflowName = submit.GenerateName
+			}
+			if submit.Arguments != nil {
+				for _, param := range submit.Arguments.Parameters {
+					parameter := EventBindingParameter{Name: param.Name}
+					if param.Value != nil {
+						parameter.Value = param.Value.String()
+					}
+					if param.ValueFrom != nil {
+						parameter.Event = param.ValueFrom.Event
+					}
+					output.Parameters = append(output.Parameters, parameter)
+				}
+			}
+		}
+
+		var text strings.Builder
+		summary := output.Binding
+		fmt.Fprintf(&text, "WorkflowEventBinding %q in namespace %q\nSelector: %s", summary.Name, summary.Namespace, summary.Selector)
+		if summary.WorkflowTemplate != "" {
+			kind := KindWorkflowTemplate
+			if summary.ClusterScope {
+				kind = KindClusterWorkflowTemplate
+			}
+			fmt.Fprintf(&text, "\nSubmits: %s %s", kind, summary.WorkflowTemplate)
+		}
+		for _, param := range output.Parameters {
+			if param.Event != "" {
+				fmt.Fprintf(&text, "\n  %s = %s", param.Name, param.Event)
+			} else {
+				fmt.Fprintf(&text, "\n  %s = %q", param.Name, param.Value)
+			}
+		}
+		return TextResult(text.String()), output, nil
+	}
+}
+
+// getEventBinding gets a workflow event binding. The Argo Server has no API
+// to get a single binding, so in that mode the namespace is listed instead.
+func getEventBinding(ctx context.Context, client argo.ClientInterface, namespace, name string) (*wfv1.WorkflowEventBinding, error) {
+	if !client.IsArgoServerMode() {
+		wfClient, err := client.WorkflowClientset()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workflow clientset: %w", err)
+		}
+		binding, err := wfClient.ArgoprojV1alpha1().WorkflowEventBindings(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workflow event binding: %w", err)
+		}
+		return binding, nil
+	}
+
+	bindings, err := listEventBindings(ctx, client, namespace, "")
+	if err != nil {
+		return nil, err
+	}
+	for i := range bindings {
+		if bindings[i].Name == name {
+			return &bindings[i], nil
+		}
+	}
+	return nil, toolerror.New(toolerror.CategoryNotFound, codes.NotFound.String(),
+		fmt.Errorf("workflow event binding %q not found in namespace %q", name, namespace)).
+		WithHint("use list_workflow_event_bindings to see the bindings of the namespace")
+}
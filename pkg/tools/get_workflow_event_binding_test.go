@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"testing"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	wffake "github.com/argoproj/argo-workflows/v4/pkg/client/clientset/versioned/fake"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
+)
+
+func TestGetWorkflowEventBindingTool(t *testing.T) {
+	tool := GetWorkflowEventBindingTool()
+
+	assert.Equal(t, "get_workflow_event_binding", tool.Name)
+	require.NotNil(t, tool.Annotations)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+}
+
+func TestGetWorkflowEventBindingHandler(t *testing.T) {
+	t.Run("direct mode gets the binding", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", false)
+		mockClient.SetWorkflowClientset(wffake.NewSimpleClientset(pushEventBinding("on-push", "argo")))
+
+		result, output, err := GetWorkflowEventBindingHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, GetWorkflowEventBindingInput{Name: "on-push"})
+		require.NoError(t, err)
+		assert.Equal(t, "on-push", output.Binding.Name)
+		assert.Equal(t, map[string]string{"team": "ci"}, output.Labels)
+		assert.Equal(t, "build-", output.WorkflowName)
+		assert.Equal(t, []EventBindingParameter{
+			{Name: "commit", Event: "payload.commit"},
+			{Name: "env", Value: "ci"},
+		}, output.Parameters)
+
+		text, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, text.Text, "Submits: WorkflowTemplate build")
+		assert.Contains(t, text.Text, "commit = payload.commit")
+		assert.Contains(t, text.Text, `env = "ci"`)
+	})
+
+	t.Run("argo server mode finds the binding in the list", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", true)
+		eventService := newMockEventService(t)
+		mockClient.SetEventService(eventService)
+		eventService.On("ListWorkflowEventBindings", mock.Anything, mock.Anything).
+			Return(&wfv1.WorkflowEventBindingList{Items: []wfv1.WorkflowEventBinding{*pushEventBinding("on-push", "argo")}}, nil)
+
+		_, output, err := GetWorkflowEventBindingHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, GetWorkflowEventBindingInput{Name: "on-push"})
+		require.NoError(t, err)
+		assert.Equal(t, "build", output.Binding.WorkflowTemplate)
+
+		_, _, err = GetWorkflowEventBindingHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, GetWorkflowEventBindingInput{Name: "missing"})
+		require.Error(t, err)
+		assert.Equal(t, toolerror.CategoryNotFound, toolerror.Classify(err).Category)
+	})
+
+	t.Run("empty name", func(t *testing.T) {
+		_, _, err := GetWorkflowEventBindingHandler(newMockClient(t, "argo", false))(t.Context(), &mcp.CallToolRequest{}, GetWorkflowEventBindingInput{})
+		require.Error(t, err)
+		assert.Equal(t, "name", toolerror.Classify(err).Field)
+	})
+}
@@ -196,6 +196,43 @@ func TestBuildGetWorkflowOutput(t *testing.T) {
 				assert.Equal(t, "value", output.Parameters[1].Value)
 			},
 		},
+		{
+			name: "workflow waiting for a semaphore",
+			workflow: &wfv1.Workflow{
+				ObjectMeta: metav1.ObjectMeta{Name: "queued", Namespace: "argo"},
+				Status: wfv1.WorkflowStatus{
+					Phase:   wfv1.WorkflowPending,
+					Message: "Waiting for argo/ConfigMap/limits/deploy lock. Lock status: 0/1",
+					Synchronization: &wfv1.SynchronizationStatus{
+						Semaphore: &wfv1.SemaphoreStatus{Waiting: []wfv1.SemaphoreHolding{
+							{Semaphore: "argo/ConfigMap/limits/deploy", Holders: []string{"argo/holder"}},
+						}},
+					},
+				},
+			},
+			validate: func(t *testing.T, output *GetWorkflowOutput) {
+				require.Len(t, output.WaitingOn, 1)
+				assert.Equal(t, "argo/ConfigMap/limits/deploy", output.WaitingOn[0].Lock)
+				assert.Equal(t, SyncTypeSemaphore, output.WaitingOn[0].Type)
+				assert.Equal(t, []string{"argo/holder"}, output.WaitingOn[0].Holders)
+				assert.Contains(t, output.WaitingOn[0].Message, "Lock status: 0/1")
+			},
+		},
+		{
+			name: "completed workflow with stale synchronization status",
+			workflow: &wfv1.Workflow{
+				ObjectMeta: metav1.ObjectMeta{Name: "done", Namespace: "argo"},
+				Status: wfv1.WorkflowStatus{
+					Phase: wfv1.WorkflowSucceeded,
+					Synchronization: &wfv1.SynchronizationStatus{
+						Mutex: &wfv1.MutexStatus{Waiting: []wfv1.MutexHolding{{Mutex: "argo/Mutex/release", Holder: "argo/other"}}},
+					},
+				},
+			},
+			validate: func(t *testing.T, output *GetWorkflowOutput) {
+				assert.Empty(t, output.WaitingOn)
+			},
+		},
 	}
 
 	for _, tt := range tests {
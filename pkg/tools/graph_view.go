@@ -0,0 +1,654 @@
+package tools
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+)
+
+// Kinds of collapsed graph nodes.
+const (
+	collapsedFanOut           = "fanOut"
+	collapsedSucceededSubtree = "succeededSubtree"
+)
+
+// Defaults of the large-graph rendering options.
+const (
+	defaultFocusDepth    = 2
+	defaultLevelsPerPage = 10
+)
+
+// fanOutPattern matches the display names Argo gives to the nodes expanded
+// from withItems, withParam and withSequence: name(index:item).
+var fanOutPattern = regexp.MustCompile(`^(.+?)\((\d+):.*\)$`)
+
+// phaseOrder is the order phases are listed in counts.
+var phaseOrder = []wfv1.NodePhase{
+	wfv1.NodeSucceeded, wfv1.NodeFailed, wfv1.NodeError, wfv1.NodeRunning,
+	wfv1.NodePending, wfv1.NodeSkipped, wfv1.NodeOmitted, nodePhaseLost,
+}
+
+// phaseSeverity is the order in which the phase of a group of nodes is
+// chosen: a group takes the first phase any of its nodes is in.
+var phaseSeverity = []wfv1.NodePhase{
+	wfv1.NodeError, wfv1.NodeFailed, wfv1.NodeRunning, wfv1.NodePending,
+	nodePhaseLost, wfv1.NodeSucceeded, wfv1.NodeSkipped, wfv1.NodeOmitted,
+}
+
+// CollapsedGraphNode describes a node of a rendered graph that stands for
+// several workflow nodes.
+type CollapsedGraphNode struct {
+	// ID is the ID of the node drawn in place of the collapsed nodes.
+	ID string `json:"id"`
+
+	// Kind is fanOut for a withItems/withParam fan-out, or succeededSubtree
+	// for the descendants of a node that all succeeded.
+	Kind string `json:"kind"`
+
+	// Name is the display name of the fan-out or of the subtree's root.
+	Name string `json:"name"`
+
+	// Phases counts the collapsed nodes per phase: the items of a fan-out, or
+	// the hidden descendants of a subtree.
+	Phases map[string]int `json:"phases"`
+
+	// NodeCount is the number of workflow nodes no longer drawn.
+	NodeCount int `json:"nodeCount"`
+}
+
+// graphOptions are the large-graph rendering options of render_workflow_graph.
+type graphOptions struct {
+	focus             string
+	depth             int
+	page              int
+	levelsPerPage     int
+	collapseFanOuts   bool
+	collapseSucceeded bool
+}
+
+// newGraphOptions validates the large-graph options of an input and applies their defaults.
+func newGraphOptions(input RenderWorkflowGraphInput) (*graphOptions, error) {
+	if input.Depth < 0 {
+		return nil, fmt.Errorf("depth must not be negative, got %d", input.Depth)
+	}
+	if input.Page < 0 {
+		return nil, fmt.Errorf("page must not be negative, got %d", input.Page)
+	}
+	if input.LevelsPerPage < 0 {
+		return nil, fmt.Errorf("levelsPerPage must not be negative, got %d", input.LevelsPerPage)
+	}
+
+	opts := &graphOptions{
+		focus:             strings.TrimSpace(input.Focus),
+		depth:             input.Depth,
+		page:              input.Page,
+		levelsPerPage:     input.LevelsPerPage,
+		collapseFanOuts:   input.CollapseFanOuts,
+		collapseSucceeded: input.CollapseSucceeded,
+	}
+	if opts.depth == 0 {
+		opts.depth = defaultFocusDepth
+	}
+	if opts.levelsPerPage > 0 && opts.page == 0 {
+		opts.page = 1
+	}
+	if opts.levelsPerPage == 0 {
+		opts.levelsPerPage = defaultLevelsPerPage
+	}
+	return opts, nil
+}
+
+// active reports whether any large-graph option is set.
+func (o *graphOptions) active() bool {
+	return o.collapseFanOuts || o.collapseSucceeded || o.focus != "" || o.page > 0
+}
+
+// graphView is the part of a workflow's node graph drawn when large-graph
+// options are set. It holds only renderable nodes, with their children
+// rewired past virtual nodes such as step groups, so that collapsed and
+// omitted nodes can be removed without breaking edges.
+type graphView struct {
+	nodes     wfv1.Nodes
+	focus     string
+	collapsed []CollapsedGraphNode
+
+	// omitted counts the nodes left out by focus and pagination.
+	omitted    int
+	levels     int
+	page       int
+	totalPages int
+}
+
+// newGraphView builds the view of nodes selected by opts: focus first, then
+// fan-out and succeeded-subtree collapsing, then pagination by DAG level.
+func newGraphView(nodes wfv1.Nodes, opts *graphOptions) (*graphView, error) {
+	v := &graphView{nodes: make(wfv1.Nodes)}
+	for id, node := range nodes {
+		if isRenderableNode(&node) {
+			node.Children = renderableDescendants(nodes, node.Children)
+			v.nodes[id] = node
+		}
+	}
+
+	if opts.focus != "" {
+		if err := v.focusOn(opts.focus, opts.depth); err != nil {
+			return nil, err
+		}
+	}
+	if opts.collapseFanOuts {
+		v.collapseFanOuts()
+	}
+	if opts.collapseSucceeded {
+		v.collapseSucceeded()
+	}
+	if opts.page > 0 {
+		if err := v.paginate(opts.page, opts.levelsPerPage); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// renderableDescendants returns the renderable nodes among ids, replacing
+// virtual nodes by their nearest renderable descendants.
+func renderableDescendants(nodes wfv1.Nodes, ids []string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	var visit func(ids []string)
+	visit = func(ids []string) {
+		for _, id := range ids {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			node, ok := nodes[id]
+			switch {
+			case !ok:
+			case isRenderableNode(&node):
+				result = append(result, id)
+			default:
+				visit(node.Children)
+			}
+		}
+	}
+	visit(ids)
+	return result
+}
+
+// sortedIDs returns the IDs of the nodes in the view in order.
+func (v *graphView) sortedIDs() []string {
+	ids := make([]string, 0, len(v.nodes))
+	for id := range v.nodes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// parents returns the parents of every node in the view that has any.
+func (v *graphView) parents() map[string][]string {
+	parents := make(map[string][]string)
+	for _, id := range v.sortedIDs() {
+		for _, childID := range v.nodes[id].Children {
+			parents[childID] = append(parents[childID], id)
+		}
+	}
+	return parents
+}
+
+// nodeLevels returns the DAG level of every node: the length of the longest path
+// to it from a node without parents.
+func (v *graphView) nodeLevels() map[string]int {
+	parents := v.parents()
+	pending := make(map[string]int, len(v.nodes))
+	var queue []string
+	for _, id := range v.sortedIDs() {
+		pending[id] = len(parents[id])
+		if pending[id] == 0 {
+			queue = append(queue, id)
+		}
+	}
+
+	levels := make(map[string]int, len(v.nodes))
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, childID := range v.nodes[id].Children {
+			levels[childID] = max(levels[childID], levels[id]+1)
+			pending[childID]--
+			if pending[childID] == 0 {
+				queue = append(queue, childID)
+			}
+		}
+	}
+	return levels
+}
+
+// topologicalOrder returns the node IDs ordered by DAG level, then by ID.
+func (v *graphView) topologicalOrder() []string {
+	levels := v.nodeLevels()
+	ids := v.sortedIDs()
+	sort.SliceStable(ids, func(i, j int) bool { return levels[ids[i]] < levels[ids[j]] })
+	return ids
+}
+
+// dominators returns the immediate dominator of every node in order, a
+// topological order of the view: the closest node every path to it from a
+// node without parents passes through. Nodes reached from several nodes
+// without parents have none.
+func (v *graphView) dominators(order []string) map[string]string {
+	parents := v.parents()
+	idom := make(map[string]string, len(order))
+	depth := make(map[string]int, len(order))
+	for _, id := range order {
+		dominator := ""
+		for i, parentID := range parents[id] {
+			if _, ok := depth[parentID]; !ok {
+				dominator = ""
+				break
+			}
+			if i == 0 {
+				dominator = parentID
+				continue
+			}
+			dominator = commonDominator(idom, depth, dominator, parentID)
+			if dominator == "" {
+				break
+			}
+		}
+		idom[id] = dominator
+		if dominator != "" {
+			depth[id] = depth[dominator] + 1
+		} else {
+			depth[id] = 0
+		}
+	}
+	return idom
+}
+
+// commonDominator returns the closest node that dominates both a and b, or
+// "" if there is none.
+func commonDominator(idom map[string]string, depth map[string]int, a, b string) string {
+	for a != b {
+		if a == "" || b == "" {
+			return ""
+		}
+		switch {
+		case depth[a] > depth[b]:
+			a = idom[a]
+		case depth[b] > depth[a]:
+			b = idom[b]
+		default:
+			a, b = idom[a], idom[b]
+		}
+	}
+	return a
+}
+
+// dominatedBy returns, for every node, the nodes it immediately dominates, in order.
+func dominatedBy(order []string, idom map[string]string) map[string][]string {
+	dominated := make(map[string][]string)
+	for _, id := range order {
+		if dominator := idom[id]; dominator != "" {
+			dominated[dominator] = append(dominated[dominator], id)
+		}
+	}
+	return dominated
+}
+
+// dominatedSubtree returns id and every node it dominates: the nodes that
+// cannot be reached from the roots of the view without passing through it.
+func dominatedSubtree(dominated map[string][]string, id string) []string {
+	subtree := []string{id}
+	for i := 0; i < len(subtree); i++ {
+		subtree = append(subtree, dominated[subtree[i]]...)
+	}
+	return subtree
+}
+
+// replaceAll removes the nodes in removed from the view and draws keepers in
+// their place. Every removed node maps to the ID of the keeper drawn for it:
+// the keeper takes over the children of its removed nodes, and edges to
+// removed nodes point to their keeper instead.
+func (v *graphView) replaceAll(removed map[string]string, keepers []wfv1.NodeStatus) {
+	if len(removed) == 0 {
+		return
+	}
+	byKeeper := make(map[string][]string)
+	for id, keeperID := range removed {
+		byKeeper[keeperID] = append(byKeeper[keeperID], id)
+	}
+	rewire := func(selfID string, children []string) []string {
+		var rewired []string
+		seen := make(map[string]bool, len(children))
+		for _, childID := range children {
+			if keeperID, ok := removed[childID]; ok {
+				childID = keeperID
+			}
+			if childID != selfID && !seen[childID] {
+				seen[childID] = true
+				rewired = append(rewired, childID)
+			}
+		}
+		return rewired
+	}
+
+	for i, keep := range keepers {
+		children := slices.Clone(keep.Children)
+		ids := byKeeper[keep.ID]
+		sort.Strings(ids)
+		for _, id := range ids {
+			children = append(children, v.nodes[id].Children...)
+		}
+		keepers[i].Children = rewire(keep.ID, children)
+	}
+	for id := range removed {
+		delete(v.nodes, id)
+	}
+	for id, node := range v.nodes {
+		if slices.ContainsFunc(node.Children, func(childID string) bool { _, ok := removed[childID]; return ok }) {
+			node.Children = rewire(id, node.Children)
+			v.nodes[id] = node
+		}
+	}
+	for _, keep := range keepers {
+		v.nodes[keep.ID] = keep
+	}
+}
+
+// fanOutGroup is a set of sibling nodes expanded from one withItems or withParam step.
+type fanOutGroup struct {
+	name    string
+	members []string
+}
+
+// fanOuts returns the fan-outs of the view, outermost first. Siblings are
+// the children of one node whose display names share the name(index:item) base.
+func (v *graphView) fanOuts() []fanOutGroup {
+	var groups []fanOutGroup
+	seen := make(map[string]bool)
+	for _, id := range v.topologicalOrder() {
+		byName := make(map[string][]string)
+		var names []string
+		for _, childID := range v.nodes[id].Children {
+			match := fanOutPattern.FindStringSubmatch(v.nodes[childID].DisplayName)
+			if match == nil || seen[childID] {
+				continue
+			}
+			if _, ok := byName[match[1]]; !ok {
+				names = append(names, match[1])
+			}
+			byName[match[1]] = append(byName[match[1]], childID)
+		}
+		for _, name := range names {
+			if members := byName[name]; len(members) > 1 {
+				for _, member := range members {
+					seen[member] = true
+				}
+				groups = append(groups, fanOutGroup{name: name, members: members})
+			}
+		}
+	}
+	return groups
+}
+
+// collapseFanOuts draws every fan-out, with the nodes only reachable through
+// its items, as one node labelled with the number of items per phase.
+func (v *graphView) collapseFanOuts() {
+	groups := v.fanOuts()
+	order := v.topologicalOrder()
+	dominated := dominatedBy(order, v.dominators(order))
+
+	removed := make(map[string]string)
+	var keepers []wfv1.NodeStatus
+	for _, group := range groups {
+		var members []string
+		for _, id := range group.members {
+			if _, ok := v.nodes[id]; ok && removed[id] == "" {
+				members = append(members, id)
+			}
+		}
+		if len(members) < 2 {
+			continue
+		}
+		sort.Strings(members)
+
+		// Only the nodes an item dominates are hidden: joins after the
+		// fan-out are reached from several items and stay drawn
+		first := v.nodes[members[0]]
+		id := "fanout-" + first.ID
+		phases := make(map[wfv1.NodePhase]int)
+		count := 0
+		for _, member := range members {
+			phases[v.nodes[member].Phase]++
+			for _, hiddenID := range dominatedSubtree(dominated, member) {
+				if removed[hiddenID] == "" {
+					removed[hiddenID] = id
+					count++
+				}
+			}
+		}
+
+		keepers = append(keepers, wfv1.NodeStatus{
+			ID:           id,
+			Name:         id,
+			DisplayName:  fmt.Sprintf("%s: %d items, %s", group.name, len(members), formatPhaseCounts(phases)),
+			TemplateName: first.TemplateName,
+			Phase:        groupPhase(phases),
+		})
+		v.collapsed = append(v.collapsed, CollapsedGraphNode{
+			ID:        id,
+			Kind:      collapsedFanOut,
+			Name:      group.name,
+			Phases:    phaseCountsByName(phases),
+			NodeCount: count,
+		})
+	}
+	v.replaceAll(removed, keepers)
+}
+
+// collapseSucceeded draws succeeded nodes without the descendants only
+// reachable through them, when those all succeeded too.
+func (v *graphView) collapseSucceeded() {
+	order := v.topologicalOrder()
+	dominated := dominatedBy(order, v.dominators(order))
+
+	// finished tells whether a node and every node it dominates succeeded
+	// or did not need to run
+	finished := make(map[string]bool, len(order))
+	for _, id := range slices.Backward(order) {
+		finished[id] = isFinishedPhase(v.nodes[id].Phase)
+		for _, childID := range dominated[id] {
+			finished[id] = finished[id] && finished[childID]
+		}
+	}
+
+	removed := make(map[string]string)
+	var keepers []wfv1.NodeStatus
+	for _, id := range order {
+		node := v.nodes[id]
+		if _, ok := removed[id]; ok || node.Phase != wfv1.NodeSucceeded || len(dominated[id]) == 0 || !finished[id] {
+			continue
+		}
+		phases := make(map[wfv1.NodePhase]int)
+		hidden := dominatedSubtree(dominated, id)[1:]
+		for _, hiddenID := range hidden {
+			phases[v.nodes[hiddenID].Phase]++
+			removed[hiddenID] = id
+		}
+
+		name := getNodeDisplayName(&node)
+		node.DisplayName = fmt.Sprintf("%s +%d succeeded", name, len(hidden))
+		keepers = append(keepers, node)
+		v.collapsed = append(v.collapsed, CollapsedGraphNode{
+			ID:        id,
+			Kind:      collapsedSucceededSubtree,
+			Name:      name,
+			Phases:    phaseCountsByName(phases),
+			NodeCount: len(hidden),
+		})
+	}
+	v.replaceAll(removed, keepers)
+}
+
+// isFinishedPhase reports whether a node in phase succeeded or did not need to run.
+func isFinishedPhase(phase wfv1.NodePhase) bool {
+	return phase == wfv1.NodeSucceeded || phase == wfv1.NodeSkipped || phase == wfv1.NodeOmitted
+}
+
+// focusOn keeps the node matching target, by ID, name or display name, and
+// its ancestors and descendants up to depth levels away.
+func (v *graphView) focusOn(target string, depth int) error {
+	focus := ""
+	for _, id := range v.sortedIDs() {
+		node := v.nodes[id]
+		if id == target || node.Name == target {
+			focus = id
+			break
+		}
+		if focus == "" && node.DisplayName == target {
+			focus = id
+		}
+	}
+	if focus == "" {
+		return fmt.Errorf("focus node %q not found in workflow (use a node ID, name or display name)", target)
+	}
+	v.focus = focus
+
+	parents := v.parents()
+	keep := map[string]bool{focus: true}
+	for _, next := range []func(string) []string{
+		func(id string) []string { return v.nodes[id].Children },
+		func(id string) []string { return parents[id] },
+	} {
+		frontier := []string{focus}
+		for range depth {
+			var following []string
+			for _, id := range frontier {
+				for _, nextID := range next(id) {
+					if !keep[nextID] {
+						keep[nextID] = true
+						following = append(following, nextID)
+					}
+				}
+			}
+			frontier = following
+		}
+	}
+	v.keepOnly(keep)
+	return nil
+}
+
+// paginate keeps the nodes on one page of DAG levels.
+func (v *graphView) paginate(page, levelsPerPage int) error {
+	levels := v.nodeLevels()
+	for _, level := range levels {
+		v.levels = max(v.levels, level+1)
+	}
+	if len(v.nodes) > 0 {
+		v.levels = max(v.levels, 1)
+	}
+	v.page = page
+	v.totalPages = max(1, (v.levels+levelsPerPage-1)/levelsPerPage)
+	if page > v.totalPages {
+		return fmt.Errorf("page %d is out of range: the graph has %d levels on %d pages of %d levels", page, v.levels, v.totalPages, levelsPerPage)
+	}
+
+	first := (page - 1) * levelsPerPage
+	keep := make(map[string]bool)
+	for id := range v.nodes {
+		if level := levels[id]; level >= first && level < first+levelsPerPage {
+			keep[id] = true
+		}
+	}
+	v.keepOnly(keep)
+	return nil
+}
+
+// keepOnly removes the nodes not in keep and the edges to them.
+func (v *graphView) keepOnly(keep map[string]bool) {
+	for id, node := range v.nodes {
+		if !keep[id] {
+			delete(v.nodes, id)
+			v.omitted++
+			continue
+		}
+		node.Children = slices.DeleteFunc(slices.Clone(node.Children), func(childID string) bool { return !keep[childID] })
+		v.nodes[id] = node
+	}
+}
+
+// groupPhase returns the phase drawn for a group of nodes.
+func groupPhase(phases map[wfv1.NodePhase]int) wfv1.NodePhase {
+	for _, phase := range phaseSeverity {
+		if phases[phase] > 0 {
+			return phase
+		}
+	}
+	return wfv1.NodePending
+}
+
+// formatPhaseCounts lists phase counts, e.g. "118 Succeeded, 2 Failed".
+func formatPhaseCounts(phases map[wfv1.NodePhase]int) string {
+	var parts []string
+	for _, phase := range phaseOrder {
+		if phases[phase] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", phases[phase], phase))
+		}
+	}
+	if unknown := countUnknownPhases(phases); unknown > 0 {
+		parts = append(parts, fmt.Sprintf("%d Unknown", unknown))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// countUnknownPhases counts the nodes in phases not listed in phaseOrder.
+func countUnknownPhases(phases map[wfv1.NodePhase]int) int {
+	unknown := 0
+	for phase, count := range phases {
+		if !slices.Contains(phaseOrder, phase) {
+			unknown += count
+		}
+	}
+	return unknown
+}
+
+// phaseCountsByName converts phase counts for output.
+func phaseCountsByName(phases map[wfv1.NodePhase]int) map[string]int {
+	counts := make(map[string]int, len(phases))
+	for phase, count := range phases {
+		name := string(phase)
+		if name == "" {
+			name = string(wfv1.NodePending)
+		}
+		counts[name] += count
+	}
+	return counts
+}
+
+// graphViewSummary describes what a view drew, for the tool's text result.
+func graphViewSummary(v *graphView) string {
+	summary := fmt.Sprintf("\nDrew %d nodes", len(v.nodes))
+	if v.focus != "" {
+		summary += fmt.Sprintf(" around node %q", v.focus)
+	}
+	if v.totalPages > 0 {
+		summary += fmt.Sprintf(" on page %d of %d (%d DAG levels)", v.page, v.totalPages, v.levels)
+	}
+	if v.omitted > 0 {
+		summary += fmt.Sprintf("; %d nodes left out", v.omitted)
+	}
+	for _, collapsed := range v.collapsed {
+		switch collapsed.Kind {
+		case collapsedFanOut:
+			summary += fmt.Sprintf("\nCollapsed fan-out %q into %s (%d nodes)", collapsed.Name, collapsed.ID, collapsed.NodeCount)
+		case collapsedSucceededSubtree:
+			summary += fmt.Sprintf("\nCollapsed %d succeeded nodes under %q", collapsed.NodeCount, collapsed.Name)
+		}
+	}
+	return summary
+}
@@ -0,0 +1,270 @@
+package tools
+
+import (
+	"fmt"
+	"testing"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/fake"
+)
+
+func TestRenderWorkflowGraphHandler_LargeGraphOptions(t *testing.T) {
+	client := fake.NewClient(t.Context(), "default")
+	wf := createEmptyWorkflow("fan-out", "default")
+	wf.Status.Nodes = createFanOutNodes()
+	require.NoError(t, client.AddWorkflow(wf))
+
+	t.Run("collapse fan-outs", func(t *testing.T) {
+		result, output, err := RenderWorkflowGraphHandler(client)(t.Context(), nil, RenderWorkflowGraphInput{Name: "fan-out", CollapseFanOuts: true})
+		require.NoError(t, err)
+		assert.Equal(t, 13, output.NodeCount)
+		assert.Equal(t, 4, output.RenderedNodeCount)
+		assert.Contains(t, output.Graph, "[process: 5 items, 4 Succeeded, 1 Failed]:::failed")
+		assert.NotContains(t, output.Graph, "process(0:a)")
+		require.Len(t, output.Collapsed, 1)
+		assert.Equal(t, collapsedFanOut, output.Collapsed[0].Kind)
+		assert.Equal(t, 10, output.Collapsed[0].NodeCount)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, `Collapsed fan-out "process"`)
+	})
+
+	t.Run("paginate", func(t *testing.T) {
+		_, output, err := RenderWorkflowGraphHandler(client)(t.Context(), nil, RenderWorkflowGraphInput{Name: "fan-out", Format: "ascii", LevelsPerPage: 2})
+		require.NoError(t, err)
+		assert.Equal(t, 1, output.Page)
+		assert.Equal(t, 3, output.TotalPages)
+		assert.Equal(t, 5, output.Levels)
+		assert.Equal(t, 2, output.RenderedNodeCount)
+		assert.NotContains(t, output.Graph, "cleanup")
+	})
+
+	t.Run("invalid depth", func(t *testing.T) {
+		_, _, err := RenderWorkflowGraphHandler(client)(t.Context(), nil, RenderWorkflowGraphInput{Name: "fan-out", Focus: "prep", Depth: -1})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "depth must not be negative")
+	})
+
+	t.Run("unknown focus node", func(t *testing.T) {
+		_, _, err := RenderWorkflowGraphHandler(client)(t.Context(), nil, RenderWorkflowGraphInput{Name: "fan-out", Focus: "missing"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `focus node "missing" not found`)
+	})
+
+	t.Run("without options the graph is unchanged", func(t *testing.T) {
+		_, output, err := RenderWorkflowGraphHandler(client)(t.Context(), nil, RenderWorkflowGraphInput{Name: "fan-out"})
+		require.NoError(t, err)
+		assert.Contains(t, output.Graph, "process(0:a)")
+		assert.Empty(t, output.Collapsed)
+		assert.Zero(t, output.RenderedNodeCount)
+	})
+}
+
+func TestGraphView_CollapseFanOuts(t *testing.T) {
+	view, err := newGraphView(createFanOutNodes(), &graphOptions{collapseFanOuts: true})
+	require.NoError(t, err)
+
+	require.Len(t, view.collapsed, 1)
+	collapsed := view.collapsed[0]
+	assert.Equal(t, "process", collapsed.Name)
+	assert.Equal(t, map[string]int{"Succeeded": 4, "Failed": 1}, collapsed.Phases)
+
+	aggregate := view.nodes[collapsed.ID]
+	assert.Equal(t, wfv1.NodeFailed, aggregate.Phase)
+	assert.Equal(t, []string{"report"}, aggregate.Children)
+	assert.Equal(t, []string{collapsed.ID}, view.nodes["prep"].Children)
+	assert.NotContains(t, view.nodes, "cleanup-0")
+}
+
+func TestGraphView_CollapseSucceeded(t *testing.T) {
+	t.Run("every descendant succeeded", func(t *testing.T) {
+		nodes := createDAGWorkflow("dag", "default").Status.Nodes
+		deploy := nodes["node-3"]
+		deploy.Phase = wfv1.NodeSucceeded
+		nodes["node-3"] = deploy
+
+		view, err := newGraphView(nodes, &graphOptions{collapseSucceeded: true})
+		require.NoError(t, err)
+		require.Len(t, view.nodes, 1)
+		assert.Equal(t, "build-image +2 succeeded", view.nodes["node-1"].DisplayName)
+		require.Len(t, view.collapsed, 1)
+		assert.Equal(t, collapsedSucceededSubtree, view.collapsed[0].Kind)
+		assert.Equal(t, 2, view.collapsed[0].NodeCount)
+	})
+
+	t.Run("a descendant is still running", func(t *testing.T) {
+		view, err := newGraphView(createDAGWorkflow("dag", "default").Status.Nodes, &graphOptions{collapseSucceeded: true})
+		require.NoError(t, err)
+		assert.Len(t, view.nodes, 3)
+		assert.Empty(t, view.collapsed)
+	})
+}
+
+func TestGraphView_LargeGraphs(t *testing.T) {
+	t.Run("succeeded chain", func(t *testing.T) {
+		view, err := newGraphView(createChainNodes(4000), &graphOptions{collapseSucceeded: true})
+		require.NoError(t, err)
+		require.Len(t, view.nodes, 1)
+		assert.Equal(t, "run-step-0 +3999 succeeded", view.nodes["step-0"].DisplayName)
+		assert.Empty(t, view.nodes["step-0"].Children)
+	})
+
+	t.Run("staged fan-out collapses fan-outs", func(t *testing.T) {
+		view, err := newGraphView(createStagedFanOutNodes(4, 1000), &graphOptions{collapseFanOuts: true})
+		require.NoError(t, err)
+		require.Len(t, view.collapsed, 4)
+		for _, collapsed := range view.collapsed {
+			assert.Equal(t, 1000, collapsed.NodeCount)
+		}
+		assert.Len(t, view.nodes, 9)
+		assert.Equal(t, []string{"fanout-stage-1-0000"}, view.nodes["join-0"].Children)
+		assert.Equal(t, []string{"join-1"}, view.nodes["fanout-stage-1-0000"].Children)
+	})
+
+	t.Run("staged fan-out still running collapses nothing", func(t *testing.T) {
+		view, err := newGraphView(createStagedFanOutNodes(4, 1000), &graphOptions{collapseSucceeded: true})
+		require.NoError(t, err)
+		assert.Empty(t, view.collapsed)
+		assert.Len(t, view.nodes, 4005)
+	})
+}
+
+func TestGraphView_Focus(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		depth  int
+		want   []string
+	}{
+		{name: "by ID", target: "step-2", depth: 1, want: []string{"step-1", "step-2", "step-3"}},
+		{name: "by display name", target: "run-step-4", depth: 2, want: []string{"step-2", "step-3", "step-4"}},
+		{name: "by node name", target: "chain.step-0", depth: 1, want: []string{"step-0", "step-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view, err := newGraphView(createChainNodes(5), &graphOptions{focus: tt.target, depth: tt.depth})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, view.sortedIDs())
+			assert.Equal(t, 5-len(tt.want), view.omitted)
+		})
+	}
+}
+
+func TestGraphView_Paginate(t *testing.T) {
+	view, err := newGraphView(createChainNodes(5), &graphOptions{page: 2, levelsPerPage: 2})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"step-2", "step-3"}, view.sortedIDs())
+	assert.Equal(t, []string{"step-3"}, view.nodes["step-2"].Children)
+	assert.Empty(t, view.nodes["step-3"].Children)
+	assert.Equal(t, 5, view.levels)
+	assert.Equal(t, 3, view.totalPages)
+
+	_, err = newGraphView(createChainNodes(5), &graphOptions{page: 4, levelsPerPage: 2})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "page 4 is out of range")
+}
+
+func TestGraphView_RewiresVirtualNodes(t *testing.T) {
+	nodes := wfv1.Nodes{
+		"steps":   {ID: "steps", DisplayName: "steps", Type: wfv1.NodeTypeSteps, Children: []string{"group-0"}},
+		"group-0": {ID: "group-0", Type: wfv1.NodeTypeStepGroup, Children: []string{"a", "b"}},
+		"a":       {ID: "a", DisplayName: "a", Type: wfv1.NodeTypePod},
+		"b":       {ID: "b", DisplayName: "b", Type: wfv1.NodeTypePod},
+	}
+
+	view, err := newGraphView(nodes, &graphOptions{page: 1, levelsPerPage: defaultLevelsPerPage})
+	require.NoError(t, err)
+	assert.NotContains(t, view.nodes, "group-0")
+	assert.Equal(t, []string{"a", "b"}, view.nodes["steps"].Children)
+	assert.Equal(t, 2, view.levels)
+}
+
+func TestNewGraphOptions(t *testing.T) {
+	opts, err := newGraphOptions(RenderWorkflowGraphInput{})
+	require.NoError(t, err)
+	assert.False(t, opts.active())
+
+	opts, err = newGraphOptions(RenderWorkflowGraphInput{LevelsPerPage: 3})
+	require.NoError(t, err)
+	assert.True(t, opts.active())
+	assert.Equal(t, 1, opts.page)
+
+	opts, err = newGraphOptions(RenderWorkflowGraphInput{Focus: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, defaultFocusDepth, opts.depth)
+
+	_, err = newGraphOptions(RenderWorkflowGraphInput{Page: -1})
+	require.Error(t, err)
+}
+
+// createFanOutNodes returns a DAG whose prep task fans out to five process
+// items, each with a cleanup task, joined by a report task.
+func createFanOutNodes() wfv1.Nodes {
+	nodes := wfv1.Nodes{
+		"fan-out": {ID: "fan-out", Name: "fan-out", DisplayName: "fan-out", Type: wfv1.NodeTypeDAG, Phase: wfv1.NodeRunning, Children: []string{"prep"}},
+		"prep":    {ID: "prep", Name: "fan-out.prep", DisplayName: "prep", Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded},
+		"report":  {ID: "report", Name: "fan-out.report", DisplayName: "report", Type: wfv1.NodeTypePod, Phase: wfv1.NodePending},
+	}
+	prep := nodes["prep"]
+	for i, item := range []string{"a", "b", "c", "d", "e"} {
+		phase := wfv1.NodeSucceeded
+		if i == 3 {
+			phase = wfv1.NodeFailed
+		}
+		processID := fmt.Sprintf("process-%d", i)
+		cleanupID := fmt.Sprintf("cleanup-%d", i)
+		prep.Children = append(prep.Children, processID)
+		nodes[processID] = wfv1.NodeStatus{
+			ID: processID, Name: fmt.Sprintf("fan-out.process(%d:%s)", i, item), DisplayName: fmt.Sprintf("process(%d:%s)", i, item),
+			Type: wfv1.NodeTypePod, Phase: phase, Children: []string{cleanupID},
+		}
+		nodes[cleanupID] = wfv1.NodeStatus{
+			ID: cleanupID, Name: fmt.Sprintf("fan-out.cleanup(%d)", i), DisplayName: fmt.Sprintf("cleanup-%d", i),
+			Type: wfv1.NodeTypePod, Phase: phase, Children: []string{"report"},
+		}
+	}
+	nodes["prep"] = prep
+	return nodes
+}
+
+// createChainNodes returns n pod nodes that run one after another.
+func createChainNodes(n int) wfv1.Nodes {
+	nodes := make(wfv1.Nodes, n)
+	for i := range n {
+		id := fmt.Sprintf("step-%d", i)
+		node := wfv1.NodeStatus{ID: id, Name: "chain." + id, DisplayName: "run-" + id, Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded}
+		if i < n-1 {
+			node.Children = []string{fmt.Sprintf("step-%d", i+1)}
+		}
+		nodes[id] = node
+	}
+	return nodes
+}
+
+// createStagedFanOutNodes returns stages of items pod nodes each, run one
+// stage after another: every stage fans out from a join node and is joined
+// again, and the last join is still running.
+func createStagedFanOutNodes(stages, items int) wfv1.Nodes {
+	nodes := make(wfv1.Nodes, stages*(items+1)+1)
+	for stage := 0; stage <= stages; stage++ {
+		joinID := fmt.Sprintf("join-%d", stage)
+		join := wfv1.NodeStatus{ID: joinID, Name: "staged." + joinID, DisplayName: joinID, Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded}
+		if stage == stages {
+			join.Phase = wfv1.NodeRunning
+			nodes[joinID] = join
+			break
+		}
+		for i := range items {
+			itemID := fmt.Sprintf("stage-%d-%04d", stage+1, i)
+			join.Children = append(join.Children, itemID)
+			nodes[itemID] = wfv1.NodeStatus{
+				ID: itemID, Name: fmt.Sprintf("staged.stage-%d(%d:%d)", stage+1, i, i), DisplayName: fmt.Sprintf("stage-%d(%d:%d)", stage+1, i, i),
+				Type: wfv1.NodeTypePod, Phase: wfv1.NodeSucceeded, Children: []string{fmt.Sprintf("join-%d", stage+1)},
+			}
+		}
+		nodes[joinID] = join
+	}
+	return nodes
+}
@@ -2,12 +2,15 @@
 package tools
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/apimachinery/pkg/fields"
 
 	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
 )
 
 // ResolveNamespace returns the trimmed namespace if provided, otherwise falls back
@@ -24,7 +27,7 @@ func ResolveNamespace(namespace string, client argo.ClientInterface) string {
 func ValidateName(name string) (string, error) {
 	name = strings.TrimSpace(name)
 	if name == "" {
-		return "", fmt.Errorf("workflow name cannot be empty")
+		return "", toolerror.Invalid("name", errors.New("workflow name cannot be empty"))
 	}
 	return name, nil
 }
@@ -37,3 +40,49 @@ func TextResult(text string) *mcp.CallToolResult {
 		},
 	}
 }
+
+// parseParameter splits a "key=value" parameter override into its trimmed key
+// and its value.
+func parseParameter(param string) (string, string, error) {
+	key, value, found := strings.Cut(param, "=")
+	if !found {
+		return "", "", toolerror.Invalid("parameters", fmt.Errorf("invalid parameter format %q, expected key=value", param))
+	}
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return "", "", toolerror.Invalid("parameters", fmt.Errorf("invalid parameter format %q, key cannot be empty", param))
+	}
+	return key, value, nil
+}
+
+// validateParameters checks that parameter overrides are in key=value format.
+func validateParameters(params []string) error {
+	for _, param := range params {
+		if _, _, err := parseParameter(param); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildNodeFieldSelector combines a node field selector with the display and
+// template name shortcuts, and checks that the result parses.
+func buildNodeFieldSelector(selector, nodeName, templateName string) (string, error) {
+	var terms []string
+	if selector = strings.TrimSpace(selector); selector != "" {
+		terms = append(terms, selector)
+	}
+	if nodeName = strings.TrimSpace(nodeName); nodeName != "" {
+		terms = append(terms, "displayName="+fields.EscapeValue(nodeName))
+	}
+	if templateName = strings.TrimSpace(templateName); templateName != "" {
+		terms = append(terms, "templateName="+fields.EscapeValue(templateName))
+	}
+
+	combined := strings.Join(terms, ",")
+	if _, err := fields.ParseSelector(combined); err != nil {
+		return "", toolerror.Invalid("nodeFieldSelector", fmt.Errorf("invalid node field selector %q: %w", combined, err)).
+			WithHint("use comma-separated field=value terms on displayName, templateName, phase, name, id, templateRef.name or inputs.parameters.<name>.value")
+	}
+	return combined, nil
+}
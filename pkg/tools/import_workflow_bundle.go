@@ -0,0 +1,446 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/utils/ptr"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/bundle"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
+)
+
+const (
+	// maxImportBundleBytes bounds the size of a compressed bundle to import (64 MiB).
+	maxImportBundleBytes = 64 << 20
+
+	// maxImportBundleContentBytes bounds the uncompressed contents of an imported bundle (256 MiB).
+	maxImportBundleContentBytes = 256 << 20
+)
+
+// ImportWorkflowBundleInput defines the input parameters for the import_workflow_bundle tool.
+type ImportWorkflowBundleInput struct {
+	// Path is the bundle file, relative to the export directory.
+	Path string `json:"path,omitempty" jsonschema:"Path of the bundle file within the configured export directory"`
+
+	// Data is the base64-encoded bundle.
+	Data string `json:"data,omitempty" jsonschema:"Base64-encoded tar.gz bundle as produced by export_workflow"`
+}
+
+// ImportWorkflowBundleOutput defines the output of the import_workflow_bundle tool.
+type ImportWorkflowBundleOutput struct {
+	// ExportedAt is when the bundle was created.
+	ExportedAt string `json:"exportedAt,omitempty"`
+
+	// Namespace is the namespace the imported workflow is served under.
+	Namespace string `json:"namespace"`
+
+	// SourceNamespace is the namespace the workflow was exported from.
+	SourceNamespace string `json:"sourceNamespace"`
+
+	// Name is the name of the imported workflow.
+	Name string `json:"name"`
+
+	// Phase is the phase of the workflow when it was exported.
+	Phase string `json:"phase,omitempty"`
+
+	// Warnings lists the parts of the workflow that were missing from the export.
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Nodes is the number of nodes of the workflow.
+	Nodes int `json:"nodes"`
+
+	// Templates is the number of referenced templates in the bundle.
+	Templates int `json:"templates"`
+
+	// Logs is the number of pod logs in the bundle.
+	Logs int `json:"logs"`
+
+	// Artifacts is the number of artifacts whose content is in the bundle.
+	Artifacts int `json:"artifacts"`
+
+	// Events is the number of Kubernetes events in the bundle.
+	Events int `json:"events"`
+
+	// HasDiagnosis is set when the bundle contains a diagnosis summary.
+	HasDiagnosis bool `json:"hasDiagnosis,omitempty"`
+
+	// Evicted lists the earlier imports dropped to make room for this one.
+	Evicted []string `json:"evicted,omitempty"`
+}
+
+// ImportWorkflowBundleTool returns the MCP tool definition for import_workflow_bundle.
+func ImportWorkflowBundleTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "import_workflow_bundle",
+		Description: "Load a bundle produced by export_workflow so that read-only tools such as get_workflow, " +
+			"get_workflow_node, logs_workflow, render_workflow_graph and the why_did_this_fail prompt operate on it " +
+			"without access to the original cluster. Imported workflows are served in the namespace bundle:<namespace>, " +
+			"so they never replace live workflows, and the oldest imports are dropped when imports grow too large. " +
+			"Read the diagnosis and Kubernetes events recorded at export with get_workflow_bundle, and drop an import with remove_workflow_bundle.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr.To(false),
+			IdempotentHint:  true,
+		},
+	}
+}
+
+// ImportWorkflowBundleHandler returns a handler function for the import_workflow_bundle tool.
+func ImportWorkflowBundleHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, ImportWorkflowBundleInput) (*mcp.CallToolResult, *ImportWorkflowBundleOutput, error) {
+	return func(_ context.Context, _ *mcp.CallToolRequest, input ImportWorkflowBundleInput) (*mcp.CallToolResult, *ImportWorkflowBundleOutput, error) {
+		host, ok := client.(bundle.Host)
+		if !ok {
+			return nil, nil, errors.New("this server does not support importing bundles")
+		}
+
+		path := strings.TrimSpace(input.Path)
+		data := strings.TrimSpace(input.Data)
+		if (path == "") == (data == "") {
+			return nil, nil, toolerror.Invalid("path", errors.New("exactly one of path or data must be provided"))
+		}
+
+		var raw []byte
+		var err error
+		if path != "" {
+			raw, err = readBundleFile(host.BundleDir(), path)
+		} else {
+			raw, err = decodeBundleData(data)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		b, err := bundle.Read(bytes.NewReader(raw), maxImportBundleContentBytes)
+		if err != nil {
+			return nil, nil, toolerror.Invalid("bundle", err)
+		}
+		if b.Workflow.Namespace == "" {
+			b.Workflow.Namespace = b.Manifest.Namespace
+		}
+		if b.Workflow.Name == "" || b.Workflow.Namespace == "" {
+			return nil, nil, toolerror.Invalid("bundle", errors.New("bundle workflow has no name or namespace"))
+		}
+		sourceNamespace := b.Workflow.Namespace
+		evicted, err := host.ImportBundle(b)
+		if err != nil {
+			return nil, nil, toolerror.Invalid("bundle", err)
+		}
+
+		output := buildImportWorkflowBundleOutput(b)
+		output.SourceNamespace = sourceNamespace
+		output.Evicted = evicted
+		return TextResult(formatImportWorkflowBundle(output)), output, nil
+	}
+}
+
+// readBundleFile reads a bundle file from the export directory. Paths that
+// leave the directory, including through symlinks, are rejected.
+func readBundleFile(dir, path string) ([]byte, error) {
+	if dir == "" {
+		return nil, toolerror.Invalid("path", errors.New("no export directory is configured (set --export-dir or MCP_EXPORT_DIR); pass the bundle as data instead"))
+	}
+	if filepath.IsAbs(path) {
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return nil, toolerror.Invalid("path", fmt.Errorf("path %q is not within the export directory", path))
+		}
+		path = rel
+	}
+
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open export directory: %w", err)
+	}
+	defer root.Close()
+
+	f, err := root.Open(path)
+	if err != nil {
+		return nil, toolerror.Invalid("path", fmt.Errorf("failed to open bundle: %w", err))
+	}
+	defer f.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(f, maxImportBundleBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle: %w", err)
+	}
+	if len(raw) > maxImportBundleBytes {
+		return nil, toolerror.Invalid("path", fmt.Errorf("bundle too large, max %d bytes", maxImportBundleBytes))
+	}
+	return raw, nil
+}
+
+// decodeBundleData decodes a base64-encoded bundle.
+func decodeBundleData(data string) ([]byte, error) {
+	if base64.StdEncoding.DecodedLen(len(data)) > maxImportBundleBytes {
+		return nil, toolerror.Invalid("data", fmt.Errorf("bundle too large, max %d bytes", maxImportBundleBytes))
+	}
+	raw, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, toolerror.Invalid("data", fmt.Errorf("bundle data is not valid base64: %w", err))
+	}
+	return raw, nil
+}
+
+// buildImportWorkflowBundleOutput summarises an imported bundle.
+func buildImportWorkflowBundleOutput(b *bundle.Bundle) *ImportWorkflowBundleOutput {
+	output := &ImportWorkflowBundleOutput{
+		Namespace:    b.Workflow.Namespace,
+		Name:         b.Workflow.Name,
+		Phase:        string(b.Workflow.Status.Phase),
+		Warnings:     b.Manifest.Warnings,
+		Nodes:        len(b.Workflow.Status.Nodes),
+		Templates:    len(b.WorkflowTemplates) + len(b.ClusterWorkflowTemplates),
+		Logs:         len(b.Logs),
+		Artifacts:    len(b.ArtifactData),
+		Events:       len(b.Events),
+		HasDiagnosis: b.Diagnosis != "",
+	}
+	if !b.Manifest.ExportedAt.IsZero() {
+		output.ExportedAt = b.Manifest.ExportedAt.Format(time.RFC3339)
+	}
+	return output
+}
+
+// formatImportWorkflowBundle builds the human-readable import summary.
+func formatImportWorkflowBundle(output *ImportWorkflowBundleOutput) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Imported workflow %s/%s (%s) as %s/%s", output.SourceNamespace, output.Name, output.Phase, output.Namespace, output.Name)
+	if output.ExportedAt != "" {
+		fmt.Fprintf(&sb, ", exported at %s", output.ExportedAt)
+	}
+	sb.WriteString("\n\n")
+	fmt.Fprintf(&sb, "Nodes: %d, templates: %d, pod logs: %d, artifacts: %d, events: %d\n",
+		output.Nodes, output.Templates, output.Logs, output.Artifacts, output.Events)
+
+	if len(output.Warnings) > 0 {
+		sb.WriteString("\nThe export reported these gaps:\n")
+		for _, warning := range output.Warnings {
+			fmt.Fprintf(&sb, "- %s\n", warning)
+		}
+	}
+	for _, key := range output.Evicted {
+		fmt.Fprintf(&sb, "\nDropped the earlier import %s to stay within the import size limit.", key)
+	}
+	if len(output.Evicted) > 0 {
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "\nRead-only tools now serve this workflow from the bundle in namespace %q.\n", output.Namespace)
+	return sb.String()
+}
+
+// RemoveWorkflowBundleInput defines the input parameters for the remove_workflow_bundle tool.
+type RemoveWorkflowBundleInput struct {
+	// Namespace is the original or imported namespace of the workflow.
+	Namespace string `json:"namespace" jsonschema:"Namespace the workflow was exported from, or the bundle:<namespace> it is served under,required"`
+
+	// Name is the name of the imported workflow.
+	Name string `json:"name" jsonschema:"Name of the imported workflow,required"`
+}
+
+// RemoveWorkflowBundleOutput defines the output of the remove_workflow_bundle tool.
+type RemoveWorkflowBundleOutput struct {
+	// Namespace is the namespace the workflow was served under.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the workflow.
+	Name string `json:"name"`
+}
+
+// RemoveWorkflowBundleTool returns the MCP tool definition for remove_workflow_bundle.
+func RemoveWorkflowBundleTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "remove_workflow_bundle",
+		Description: "Drop a workflow loaded with import_workflow_bundle, freeing the memory its bundle holds. Live workflows are not affected.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr.To(false),
+			IdempotentHint:  true,
+		},
+	}
+}
+
+// RemoveWorkflowBundleHandler returns a handler function for the remove_workflow_bundle tool.
+func RemoveWorkflowBundleHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, RemoveWorkflowBundleInput) (*mcp.CallToolResult, *RemoveWorkflowBundleOutput, error) {
+	return func(_ context.Context, _ *mcp.CallToolRequest, input RemoveWorkflowBundleInput) (*mcp.CallToolResult, *RemoveWorkflowBundleOutput, error) {
+		host, ok := client.(bundle.Host)
+		if !ok {
+			return nil, nil, errors.New("this server does not support importing bundles")
+		}
+
+		name, err := ValidateName(input.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		namespace := strings.TrimSpace(input.Namespace)
+		if namespace == "" {
+			return nil, nil, toolerror.Invalid("namespace", errors.New("namespace cannot be empty"))
+		}
+
+		output := &RemoveWorkflowBundleOutput{Namespace: bundle.ImportedNamespace(namespace), Name: name}
+		if !host.RemoveBundle(namespace, name) {
+			return nil, nil, toolerror.New(toolerror.CategoryNotFound, "BundleNotImported", fmt.Errorf("workflow %s/%s was not imported", output.Namespace, name))
+		}
+		return TextResult(fmt.Sprintf("Removed imported workflow %s/%s", output.Namespace, name)), output, nil
+	}
+}
+
+// GetWorkflowBundleInput defines the input parameters for the get_workflow_bundle tool.
+type GetWorkflowBundleInput struct {
+	// Namespace is the original or imported namespace of the workflow.
+	Namespace string `json:"namespace" jsonschema:"Namespace the workflow was exported from, or the bundle:<namespace> it is served under,required"`
+
+	// Name is the name of the imported workflow.
+	Name string `json:"name" jsonschema:"Name of the imported workflow,required"`
+}
+
+// BundleEvent is a Kubernetes event recorded in a bundle.
+type BundleEvent struct {
+	// Time is when the event last occurred.
+	Time string `json:"time,omitempty"`
+
+	// Type is Normal or Warning.
+	Type string `json:"type"`
+
+	// Object is the kind and name of the object the event is about, e.g. Pod/wf-step-1.
+	Object string `json:"object"`
+
+	// Reason is the short reason of the event, e.g. BackOff.
+	Reason string `json:"reason"`
+
+	// Message is the event's message.
+	Message string `json:"message,omitempty"`
+
+	// Count is how many times the event occurred.
+	Count int32 `json:"count,omitempty"`
+}
+
+// GetWorkflowBundleOutput defines the output of the get_workflow_bundle tool.
+type GetWorkflowBundleOutput struct {
+	// ExportedAt is when the bundle was created.
+	ExportedAt string `json:"exportedAt,omitempty"`
+
+	// Namespace is the namespace the imported workflow is served under.
+	Namespace string `json:"namespace"`
+
+	// SourceNamespace is the namespace the workflow was exported from.
+	SourceNamespace string `json:"sourceNamespace"`
+
+	// Name is the name of the imported workflow.
+	Name string `json:"name"`
+
+	// Diagnosis is the why_did_this_fail diagnosis recorded at export, in Markdown.
+	Diagnosis string `json:"diagnosis,omitempty"`
+
+	// Warnings lists the parts of the workflow that were missing from the export.
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Events are the Kubernetes events of the workflow and its pods, oldest first.
+	Events []BundleEvent `json:"events,omitempty"`
+}
+
+// GetWorkflowBundleTool returns the MCP tool definition for get_workflow_bundle.
+func GetWorkflowBundleTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "get_workflow_bundle",
+		Description: "Show what a bundle loaded with import_workflow_bundle recorded at export beyond the workflow itself: " +
+			"the failure diagnosis, the Kubernetes events of the workflow and its pods, and any gaps in the export.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+}
+
+// GetWorkflowBundleHandler returns a handler function for the get_workflow_bundle tool.
+func GetWorkflowBundleHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, GetWorkflowBundleInput) (*mcp.CallToolResult, *GetWorkflowBundleOutput, error) {
+	return func(_ context.Context, _ *mcp.CallToolRequest, input GetWorkflowBundleInput) (*mcp.CallToolResult, *GetWorkflowBundleOutput, error) {
+		host, ok := client.(bundle.Host)
+		if !ok {
+			return nil, nil, errors.New("this server does not support importing bundles")
+		}
+
+		name, err := ValidateName(input.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		namespace := strings.TrimSpace(input.Namespace)
+		if namespace == "" {
+			return nil, nil, toolerror.Invalid("namespace", errors.New("namespace cannot be empty"))
+		}
+
+		b := host.ImportedBundle(namespace, name)
+		if b == nil {
+			return nil, nil, toolerror.New(toolerror.CategoryNotFound, "BundleNotImported",
+				fmt.Errorf("workflow %s/%s was not imported", bundle.ImportedNamespace(namespace), name))
+		}
+
+		output := &GetWorkflowBundleOutput{
+			Namespace:       b.Workflow.Namespace,
+			SourceNamespace: b.Manifest.Namespace,
+			Name:            b.Workflow.Name,
+			Diagnosis:       b.Diagnosis,
+			Warnings:        b.Manifest.Warnings,
+		}
+		if !b.Manifest.ExportedAt.IsZero() {
+			output.ExportedAt = b.Manifest.ExportedAt.Format(time.RFC3339)
+		}
+		for _, event := range b.Events {
+			bundleEvent := BundleEvent{
+				Type:    event.Type,
+				Object:  event.InvolvedObject.Kind + "/" + event.InvolvedObject.Name,
+				Reason:  event.Reason,
+				Message: event.Message,
+				Count:   event.Count,
+			}
+			if at := bundle.EventTime(event); !at.IsZero() {
+				bundleEvent.Time = at.Format(time.RFC3339)
+			}
+			output.Events = append(output.Events, bundleEvent)
+		}
+		return TextResult(formatGetWorkflowBundle(output)), output, nil
+	}
+}
+
+// formatGetWorkflowBundle builds the human-readable bundle contents.
+func formatGetWorkflowBundle(output *GetWorkflowBundleOutput) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Bundle of workflow %s/%s, served as %s/%s", output.SourceNamespace, output.Name, output.Namespace, output.Name)
+	if output.ExportedAt != "" {
+		fmt.Fprintf(&sb, ", exported at %s", output.ExportedAt)
+	}
+	sb.WriteString("\n")
+
+	if len(output.Warnings) > 0 {
+		sb.WriteString("\nThe export reported these gaps:\n")
+		for _, warning := range output.Warnings {
+			fmt.Fprintf(&sb, "- %s\n", warning)
+		}
+	}
+
+	sb.WriteString("\nEvents:\n")
+	if len(output.Events) == 0 {
+		sb.WriteString("  (none recorded)\n")
+	}
+	for _, event := range output.Events {
+		fmt.Fprintf(&sb, "  %s %s %s %s: %s", event.Time, event.Type, event.Object, event.Reason, event.Message)
+		if event.Count > 1 {
+			fmt.Fprintf(&sb, " (x%d)", event.Count)
+		}
+		sb.WriteString("\n")
+	}
+
+	if output.Diagnosis != "" {
+		sb.WriteString("\nDiagnosis at export:\n\n")
+		sb.WriteString(output.Diagnosis)
+	}
+	return sb.String()
+}
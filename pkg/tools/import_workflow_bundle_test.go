@@ -0,0 +1,202 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/bundle"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
+)
+
+// encodedBundle returns an encoded bundle of exportFixture with one log.
+func encodedBundle(t *testing.T) []byte {
+	t.Helper()
+	b := &bundle.Bundle{
+		Workflow: exportFixture(),
+		Logs:     map[string][]byte{"wf-1": []byte("compiling\n")},
+		Manifest: bundle.Manifest{
+			ExportedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+			Namespace:  "argo",
+			Workflow:   "wf",
+			Warnings:   []string{"pod events were not included"},
+		},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, b.Write(&buf))
+	return buf.Bytes()
+}
+
+func TestImportWorkflowBundleTool(t *testing.T) {
+	tool := ImportWorkflowBundleTool()
+
+	assert.Equal(t, "import_workflow_bundle", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	require.NotNil(t, tool.Annotations)
+	assert.False(t, tool.Annotations.ReadOnlyHint)
+	assert.True(t, tool.Annotations.IdempotentHint)
+}
+
+func TestImportWorkflowBundleHandler(t *testing.T) {
+	t.Run("imports base64 data", func(t *testing.T) {
+		client := bundle.NewClient(newMockClient(t, "argo", false), "")
+
+		handler := ImportWorkflowBundleHandler(client)
+		result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, ImportWorkflowBundleInput{
+			Data: base64.StdEncoding.EncodeToString(encodedBundle(t)),
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, "bundle:argo", output.Namespace)
+		assert.Equal(t, "argo", output.SourceNamespace)
+		assert.Equal(t, "wf", output.Name)
+		assert.Equal(t, "Failed", output.Phase)
+		assert.Equal(t, "2026-03-01T12:00:00Z", output.ExportedAt)
+		assert.Equal(t, 2, output.Nodes)
+		assert.Equal(t, 1, output.Logs)
+		assert.Equal(t, []string{"pod events were not included"}, output.Warnings)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "Imported workflow argo/wf (Failed) as bundle:argo/wf")
+
+		// Read-only tools now see the imported workflow without the cluster
+		wf, err := client.WorkflowService().GetWorkflow(t.Context(), &workflow.WorkflowGetRequest{Namespace: "bundle:argo", Name: "wf"})
+		require.NoError(t, err)
+		assert.Equal(t, wfv1.WorkflowFailed, wf.Status.Phase)
+
+		_, nodeOutput, err := GetWorkflowNodeHandler(client)(t.Context(), &mcp.CallToolRequest{}, GetWorkflowNodeInput{
+			Namespace: "bundle:argo", WorkflowName: "wf", NodeName: "build",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Error (exit code 1)", nodeOutput.Message)
+	})
+
+	t.Run("imports a file from the export directory", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "wf.tar.gz"), encodedBundle(t), 0o600))
+		client := bundle.NewClient(newMockClient(t, "argo", false), dir)
+		handler := ImportWorkflowBundleHandler(client)
+
+		_, output, err := handler(t.Context(), &mcp.CallToolRequest{}, ImportWorkflowBundleInput{Path: "wf.tar.gz"})
+		require.NoError(t, err)
+		assert.Equal(t, "wf", output.Name)
+
+		_, _, err = handler(t.Context(), &mcp.CallToolRequest{}, ImportWorkflowBundleInput{Path: filepath.Join(dir, "wf.tar.gz")})
+		require.NoError(t, err)
+
+		_, _, err = handler(t.Context(), &mcp.CallToolRequest{}, ImportWorkflowBundleInput{Path: "../wf.tar.gz"})
+		require.Error(t, err)
+
+		_, _, err = handler(t.Context(), &mcp.CallToolRequest{}, ImportWorkflowBundleInput{Path: "/etc/passwd"})
+		require.Error(t, err)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		handler := ImportWorkflowBundleHandler(bundle.NewClient(newMockClient(t, "argo", false), ""))
+
+		_, _, err := handler(t.Context(), &mcp.CallToolRequest{}, ImportWorkflowBundleInput{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exactly one of path or data")
+
+		_, _, err = handler(t.Context(), &mcp.CallToolRequest{}, ImportWorkflowBundleInput{Path: "wf.tar.gz"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no export directory is configured")
+
+		_, _, err = handler(t.Context(), &mcp.CallToolRequest{}, ImportWorkflowBundleInput{Data: "not base64!"})
+		require.Error(t, err)
+
+		_, _, err = handler(t.Context(), &mcp.CallToolRequest{}, ImportWorkflowBundleInput{
+			Data: base64.StdEncoding.EncodeToString([]byte("not a bundle")),
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not a gzip archive")
+	})
+
+	t.Run("bundle without a workflow name", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, (&bundle.Bundle{Workflow: &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Namespace: "argo"}}}).Write(&buf))
+
+		handler := ImportWorkflowBundleHandler(bundle.NewClient(newMockClient(t, "argo", false), ""))
+		_, _, err := handler(t.Context(), &mcp.CallToolRequest{}, ImportWorkflowBundleInput{
+			Data: base64.StdEncoding.EncodeToString(buf.Bytes()),
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no name or namespace")
+	})
+
+	t.Run("client without bundle support", func(t *testing.T) {
+		handler := ImportWorkflowBundleHandler(newMockClient(t, "argo", false))
+		_, _, err := handler(t.Context(), &mcp.CallToolRequest{}, ImportWorkflowBundleInput{Data: "eA=="})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not support importing bundles")
+	})
+}
+
+func TestRemoveWorkflowBundleHandler(t *testing.T) {
+	client := bundle.NewClient(newMockClient(t, "argo", false), "")
+	_, _, err := ImportWorkflowBundleHandler(client)(t.Context(), &mcp.CallToolRequest{}, ImportWorkflowBundleInput{
+		Data: base64.StdEncoding.EncodeToString(encodedBundle(t)),
+	})
+	require.NoError(t, err)
+
+	handler := RemoveWorkflowBundleHandler(client)
+	result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, RemoveWorkflowBundleInput{Namespace: "argo", Name: "wf"})
+	require.NoError(t, err)
+	assert.Equal(t, "bundle:argo", output.Namespace)
+	assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "Removed imported workflow bundle:argo/wf")
+
+	_, err = client.WorkflowService().GetWorkflow(t.Context(), &workflow.WorkflowGetRequest{Namespace: "bundle:argo", Name: "wf"})
+	require.Error(t, err)
+
+	_, _, err = handler(t.Context(), &mcp.CallToolRequest{}, RemoveWorkflowBundleInput{Namespace: "bundle:argo", Name: "wf"})
+	require.Error(t, err)
+	assert.Equal(t, toolerror.CategoryNotFound, toolerror.Classify(err).Category)
+
+	_, _, err = handler(t.Context(), &mcp.CallToolRequest{}, RemoveWorkflowBundleInput{Name: "wf"})
+	require.Error(t, err)
+}
+
+func TestGetWorkflowBundleHandler(t *testing.T) {
+	client := bundle.NewClient(newMockClient(t, "argo", false), "")
+	_, err := client.ImportBundle(&bundle.Bundle{
+		Workflow:  exportFixture(),
+		Diagnosis: "## Workflow: wf\nStatus: Failed\n",
+		Events: []corev1.Event{{
+			Type:           corev1.EventTypeWarning,
+			Reason:         "BackOff",
+			Message:        "Back-off restarting failed container",
+			Count:          4,
+			LastTimestamp:  metav1.Time{Time: time.Date(2026, 3, 1, 11, 58, 0, 0, time.UTC)},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "wf-compile-1"},
+		}},
+		Manifest: bundle.Manifest{Namespace: "argo", Workflow: "wf"},
+	})
+	require.NoError(t, err)
+
+	handler := GetWorkflowBundleHandler(client)
+	result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, GetWorkflowBundleInput{Namespace: "bundle:argo", Name: "wf"})
+	require.NoError(t, err)
+	assert.Equal(t, "argo", output.SourceNamespace)
+	assert.Equal(t, "bundle:argo", output.Namespace)
+	assert.Contains(t, output.Diagnosis, "Status: Failed")
+	assert.Equal(t, []BundleEvent{{
+		Time: "2026-03-01T11:58:00Z", Type: "Warning", Object: "Pod/wf-compile-1", Reason: "BackOff",
+		Message: "Back-off restarting failed container", Count: 4,
+	}}, output.Events)
+	text := result.Content[0].(*mcp.TextContent).Text
+	assert.Contains(t, text, "Warning Pod/wf-compile-1 BackOff: Back-off restarting failed container (x4)")
+	assert.Contains(t, text, "Diagnosis at export:")
+
+	_, _, err = handler(t.Context(), &mcp.CallToolRequest{}, GetWorkflowBundleInput{Namespace: "argo", Name: "other"})
+	require.Error(t, err)
+	assert.Equal(t, toolerror.CategoryNotFound, toolerror.Classify(err).Category)
+}
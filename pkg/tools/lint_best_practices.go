@@ -0,0 +1,122 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/lint"
+)
+
+// LintBestPracticesInput defines the input parameters for the lint_best_practices tool.
+type LintBestPracticesInput struct {
+	// Manifest is the manifest to check.
+	Manifest string `json:"manifest" jsonschema:"Workflow, WorkflowTemplate, ClusterWorkflowTemplate, or CronWorkflow YAML manifest,required"`
+
+	// Severities overrides the severity of rules by name.
+	Severities map[string]string `json:"severities,omitempty" jsonschema:"Severity overrides by rule name: error, warning, info, or off to disable the rule"`
+}
+
+// LintBestPracticesOutput defines the output for the lint_best_practices tool.
+type LintBestPracticesOutput struct {
+	Kind     string         `json:"kind"`
+	Name     string         `json:"name,omitempty"`
+	Findings []lint.Finding `json:"findings"`
+	Errors   int            `json:"errors"`
+	Warnings int            `json:"warnings"`
+	Infos    int            `json:"infos"`
+	Valid    bool           `json:"valid"`
+}
+
+// LintBestPracticesTool returns the MCP tool definition for lint_best_practices.
+func LintBestPracticesTool() *mcp.Tool {
+	rules := lint.DefaultRules()
+	descriptions := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		descriptions = append(descriptions, fmt.Sprintf("%s (%s)", rule.Name, rule.Severity))
+	}
+	return &mcp.Tool{
+		Name: "lint_best_practices",
+		Description: "Check a Workflow, WorkflowTemplate, ClusterWorkflowTemplate, or CronWorkflow manifest against best practices offline, without a cluster. " +
+			"Complements lint_workflow, which validates against the Argo Server. " +
+			"Rules (default severity): " + strings.Join(descriptions, ", ") + ". " +
+			"Override severities per rule, or set a rule to off to disable it.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+}
+
+// LintBestPracticesHandler returns a handler function for the lint_best_practices tool.
+// Note: This tool doesn't require the Argo client since it works purely from YAML.
+func LintBestPracticesHandler() func(context.Context, *mcp.CallToolRequest, LintBestPracticesInput) (*mcp.CallToolResult, *LintBestPracticesOutput, error) {
+	return func(_ context.Context, _ *mcp.CallToolRequest, input LintBestPracticesInput) (*mcp.CallToolResult, *LintBestPracticesOutput, error) {
+		// Validate manifest is provided
+		if strings.TrimSpace(input.Manifest) == "" {
+			return nil, nil, fmt.Errorf("manifest cannot be empty")
+		}
+
+		// Guard against oversized manifests (DoS hardening)
+		const maxManifestBytes = 1 << 20 // 1 MiB
+		if len(input.Manifest) > maxManifestBytes {
+			return nil, nil, fmt.Errorf("manifest too large (%d bytes), max %d", len(input.Manifest), maxManifestBytes)
+		}
+
+		engine := lint.NewEngine()
+		for _, rule := range slices.Sorted(maps.Keys(input.Severities)) {
+			severity, parseErr := lint.ParseSeverity(input.Severities[rule])
+			if parseErr != nil {
+				return nil, nil, fmt.Errorf("rule %q: %w", rule, parseErr)
+			}
+			if setErr := engine.SetSeverity(rule, severity); setErr != nil {
+				return nil, nil, setErr
+			}
+		}
+
+		spec, kind, name, err := extractWorkflowSpec(input.Manifest)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		output := &LintBestPracticesOutput{
+			Kind:     kind,
+			Name:     name,
+			Findings: engine.Lint(lint.NewDocument(kind, name, spec)),
+		}
+		if output.Findings == nil {
+			output.Findings = []lint.Finding{}
+		}
+		for _, finding := range output.Findings {
+			switch finding.Severity {
+			case lint.SeverityError:
+				output.Errors++
+			case lint.SeverityWarning:
+				output.Warnings++
+			default:
+				output.Infos++
+			}
+		}
+		output.Valid = output.Errors == 0
+
+		return TextResult(formatLintFindings(output)), output, nil
+	}
+}
+
+// formatLintFindings builds the human-readable lint report.
+func formatLintFindings(output *LintBestPracticesOutput) string {
+	if len(output.Findings) == 0 {
+		return fmt.Sprintf("%s %q follows all best-practice rules", output.Kind, output.Name)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %q: %d error(s), %d warning(s), %d info\n", output.Kind, output.Name, output.Errors, output.Warnings, output.Infos)
+	for _, finding := range output.Findings {
+		fmt.Fprintf(&sb, "\n  [%s] %s: %s\n    at %s", finding.Severity, finding.Rule, finding.Message, finding.Path)
+	}
+	return sb.String()
+}
@@ -0,0 +1,149 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/lint"
+)
+
+const bestPracticesManifest = `apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: lint-me-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    dag:
+      tasks:
+      - name: a
+        template: work
+        dependencies: [b]
+      - name: b
+        template: work
+        dependencies: [a]
+  - name: work
+    container:
+      image: alpine:latest
+`
+
+func TestLintBestPracticesTool(t *testing.T) {
+	tool := LintBestPracticesTool()
+
+	assert.Equal(t, "lint_best_practices", tool.Name)
+	assert.Contains(t, tool.Description, "dag-cycle (error)")
+	require.NotNil(t, tool.Annotations)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+}
+
+func TestLintBestPracticesHandler(t *testing.T) {
+	tests := []struct {
+		validate func(*testing.T, *LintBestPracticesOutput, *mcp.CallToolResult)
+		name     string
+		wantErr  string
+		input    LintBestPracticesInput
+	}{
+		{
+			name:  "reports findings by severity",
+			input: LintBestPracticesInput{Manifest: bestPracticesManifest},
+			validate: func(t *testing.T, output *LintBestPracticesOutput, result *mcp.CallToolResult) {
+				assert.Equal(t, "Workflow", output.Kind)
+				assert.Equal(t, "lint-me-", output.Name)
+				assert.False(t, output.Valid)
+				assert.Equal(t, 1, output.Errors)
+				assert.Equal(t, 2, output.Warnings)
+				assert.Equal(t, 1, output.Infos)
+				require.NotEmpty(t, output.Findings)
+				assert.Equal(t, "dag-cycle", output.Findings[0].Rule)
+
+				text, ok := result.Content[0].(*mcp.TextContent)
+				require.True(t, ok)
+				assert.Contains(t, text.Text, "[error] dag-cycle")
+				assert.Contains(t, text.Text, "at spec.templates[1].container.image")
+			},
+		},
+		{
+			name: "severity overrides",
+			input: LintBestPracticesInput{
+				Manifest: bestPracticesManifest,
+				Severities: map[string]string{
+					"dag-cycle":             "warning",
+					"active-deadline":       "off",
+					"resource-requirements": "off",
+					"latest-image-tag":      "ERROR",
+				},
+			},
+			validate: func(t *testing.T, output *LintBestPracticesOutput, _ *mcp.CallToolResult) {
+				assert.Equal(t, 1, output.Errors)
+				assert.Equal(t, 1, output.Warnings)
+				assert.Zero(t, output.Infos)
+				assert.Equal(t, "latest-image-tag", output.Findings[0].Rule)
+				assert.Equal(t, lint.SeverityError, output.Findings[0].Severity)
+			},
+		},
+		{
+			name: "clean cron workflow",
+			input: LintBestPracticesInput{Manifest: `apiVersion: argoproj.io/v1alpha1
+kind: CronWorkflow
+metadata:
+  name: nightly
+spec:
+  schedules: ["0 2 * * *"]
+  workflowSpec:
+    entrypoint: main
+    activeDeadlineSeconds: 3600
+    templates:
+    - name: main
+      container:
+        image: alpine:3.19
+        resources:
+          requests: {cpu: 100m}
+          limits: {memory: 128Mi}
+`},
+			validate: func(t *testing.T, output *LintBestPracticesOutput, result *mcp.CallToolResult) {
+				assert.True(t, output.Valid)
+				assert.Empty(t, output.Findings)
+
+				text, ok := result.Content[0].(*mcp.TextContent)
+				require.True(t, ok)
+				assert.Contains(t, text.Text, "follows all best-practice rules")
+			},
+		},
+		{
+			name:    "unknown rule",
+			input:   LintBestPracticesInput{Manifest: bestPracticesManifest, Severities: map[string]string{"no-such-rule": "off"}},
+			wantErr: "unknown lint rule",
+		},
+		{
+			name:    "invalid severity",
+			input:   LintBestPracticesInput{Manifest: bestPracticesManifest, Severities: map[string]string{"dag-cycle": "fatal"}},
+			wantErr: "invalid severity",
+		},
+		{
+			name:    "empty manifest",
+			input:   LintBestPracticesInput{Manifest: "  "},
+			wantErr: "manifest cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := LintBestPracticesHandler()
+			result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, tt.input)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, output)
+			tt.validate(t, output, result)
+		})
+	}
+}
@@ -0,0 +1,237 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/workflow/common"
+	wfutil "github.com/argoproj/argo-workflows/v4/workflow/util"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// ListPendingApprovalsInput defines the input parameters for the list_pending_approvals tool.
+type ListPendingApprovalsInput struct {
+	// Namespace is the Kubernetes namespace; empty means all namespaces.
+	Namespace *string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace (uses default if not specified. use empty string for all namespaces)"`
+
+	// Labels is a label selector for the workflows to search.
+	Labels string `json:"labels,omitempty" jsonschema:"Label selector for the workflows to search (e.g. 'app=myapp')"`
+}
+
+// ExpectedOutput is an output parameter a suspended node waits to be given.
+type ExpectedOutput struct {
+	// Default is used when the parameter is not supplied.
+	Default *string `json:"default,omitempty"`
+
+	// Name is the parameter name.
+	Name string `json:"name"`
+
+	// Description describes the parameter.
+	Description string `json:"description,omitempty"`
+
+	// Enum lists the allowed values.
+	Enum []string `json:"enum,omitempty"`
+
+	// Required is true when the parameter has no default and must be supplied to approve.
+	Required bool `json:"required"`
+}
+
+// PendingApproval is a suspend node waiting to be approved or rejected.
+type PendingApproval struct {
+	// Namespace is the namespace of the workflow.
+	Namespace string `json:"namespace"`
+
+	// Workflow is the workflow name.
+	Workflow string `json:"workflow"`
+
+	// NodeID is the node ID, usable with approve_node and reject_node.
+	NodeID string `json:"nodeId"`
+
+	// NodeName is the node display name.
+	NodeName string `json:"nodeName"`
+
+	// TemplateName is the suspend template of the node.
+	TemplateName string `json:"templateName,omitempty"`
+
+	// Message is the node message, if any.
+	Message string `json:"message,omitempty"`
+
+	// WaitingSince is when the node started waiting, in RFC3339 format.
+	WaitingSince string `json:"waitingSince,omitempty"`
+
+	// ExpectedOutputs lists the output parameters the node waits for.
+	ExpectedOutputs []ExpectedOutput `json:"expectedOutputs,omitempty"`
+
+	// WaitingSeconds is how long the node has been waiting.
+	WaitingSeconds int64 `json:"waitingSeconds"`
+}
+
+// ListPendingApprovalsOutput defines the output for the list_pending_approvals tool.
+type ListPendingApprovalsOutput struct {
+	// Approvals lists the waiting nodes, longest waiting first.
+	Approvals []PendingApproval `json:"approvals"`
+
+	// Total is the number of waiting nodes.
+	Total int `json:"total"`
+}
+
+// ListPendingApprovalsTool returns the MCP tool definition for list_pending_approvals.
+func ListPendingApprovalsTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "list_pending_approvals",
+		Description: "List suspend nodes of running workflows that are waiting for approval, with the output parameters " +
+			"they expect and how long they have been waiting. Approve or reject them with approve_node and reject_node.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+}
+
+// ListPendingApprovalsHandler returns a handler function for the list_pending_approvals tool.
+func ListPendingApprovalsHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, ListPendingApprovalsInput) (*mcp.CallToolResult, *ListPendingApprovalsOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input ListPendingApprovalsInput) (*mcp.CallToolResult, *ListPendingApprovalsOutput, error) {
+		namespace := client.DefaultNamespace()
+		if input.Namespace != nil {
+			namespace = strings.TrimSpace(*input.Namespace)
+		}
+
+		labelSelector := common.LabelKeyPhase + "=" + string(wfv1.WorkflowRunning)
+		if labels := strings.TrimSpace(input.Labels); labels != "" {
+			labelSelector += "," + labels
+		}
+
+		wfService := client.WorkflowService()
+		listResp, err := wfService.ListWorkflows(ctx, &workflow.WorkflowListRequest{
+			Namespace:   namespace,
+			ListOptions: &metav1.ListOptions{LabelSelector: labelSelector},
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list workflows: %w", err)
+		}
+
+		now := time.Now()
+		output := &ListPendingApprovalsOutput{Approvals: []PendingApproval{}}
+		for i := range listResp.Items {
+			wf := &listResp.Items[i]
+			// Lists don't carry offloaded or compressed node status
+			if len(wf.Status.Nodes) == 0 && (wf.Status.IsOffloadNodeStatus() || wf.Status.CompressedNodes != "") {
+				wf, err = wfService.GetWorkflow(ctx, &workflow.WorkflowGetRequest{Name: wf.Name, Namespace: wf.Namespace})
+				if err != nil {
+					return nil, nil, fmt.Errorf("failed to get workflow %q: %w", listResp.Items[i].Name, err)
+				}
+			}
+			output.Approvals = append(output.Approvals, pendingApprovals(wf, now)...)
+		}
+		slices.SortStableFunc(output.Approvals, func(a, b PendingApproval) int {
+			return cmp.Compare(b.WaitingSeconds, a.WaitingSeconds)
+		})
+		output.Total = len(output.Approvals)
+
+		return TextResult(formatPendingApprovals(output.Approvals, namespace)), output, nil
+	}
+}
+
+// pendingApprovals returns the active suspend nodes of wf, ordered by name.
+func pendingApprovals(wf *wfv1.Workflow, now time.Time) []PendingApproval {
+	var approvals []PendingApproval
+	for id, node := range wf.Status.Nodes {
+		if !node.IsActiveSuspendNode() {
+			continue
+		}
+
+		approval := PendingApproval{
+			Namespace:       wf.Namespace,
+			Workflow:        wf.Name,
+			NodeID:          id,
+			NodeName:        node.DisplayName,
+			TemplateName:    wfutil.GetTemplateFromNode(node),
+			Message:         node.Message,
+			ExpectedOutputs: expectedOutputs(node),
+		}
+		if !node.StartedAt.IsZero() {
+			approval.WaitingSince = node.StartedAt.Format(time.RFC3339)
+			approval.WaitingSeconds = int64(now.Sub(node.StartedAt.Time).Seconds())
+		}
+		approvals = append(approvals, approval)
+	}
+	slices.SortFunc(approvals, func(a, b PendingApproval) int {
+		return strings.Compare(a.NodeName, b.NodeName)
+	})
+	return approvals
+}
+
+// expectedOutputs returns the output parameters of a suspend node that are
+// still waiting for a supplied value.
+func expectedOutputs(node wfv1.NodeStatus) []ExpectedOutput {
+	if node.Outputs == nil {
+		return nil
+	}
+
+	var outputs []ExpectedOutput
+	for _, param := range node.Outputs.Parameters {
+		if param.ValueFrom == nil || param.ValueFrom.Supplied == nil {
+			continue
+		}
+		output := ExpectedOutput{Name: param.Name, Required: param.ValueFrom.Default == nil}
+		if param.Description != nil {
+			output.Description = param.Description.String()
+		}
+		if param.ValueFrom.Default != nil {
+			output.Default = ptr.To(param.ValueFrom.Default.String())
+		}
+		for _, value := range param.Enum {
+			output.Enum = append(output.Enum, value.String())
+		}
+		outputs = append(outputs, output)
+	}
+	return outputs
+}
+
+// formatPendingApprovals renders the pending approvals as text.
+func formatPendingApprovals(approvals []PendingApproval, namespace string) string {
+	var text strings.Builder
+	scope := fmt.Sprintf("in namespace %q", namespace)
+	if namespace == "" {
+		scope = "across all namespaces"
+	}
+	if len(approvals) == 0 {
+		return "No suspended nodes are waiting for approval " + scope
+	}
+
+	fmt.Fprintf(&text, "%d node(s) waiting for approval %s:", len(approvals), scope)
+	for _, approval := range approvals {
+		fmt.Fprintf(&text, "\n- %s/%s node %q (id %s), waiting %s",
+			approval.Namespace, approval.Workflow, approval.NodeName, approval.NodeID,
+			(time.Duration(approval.WaitingSeconds) * time.Second).String())
+		if approval.Message != "" {
+			fmt.Fprintf(&text, ": %s", approval.Message)
+		}
+		for _, output := range approval.ExpectedOutputs {
+			fmt.Fprintf(&text, "\n    output %s", output.Name)
+			switch {
+			case output.Required:
+				text.WriteString(" (required)")
+			case output.Default != nil:
+				fmt.Fprintf(&text, " (default %q)", *output.Default)
+			}
+			if len(output.Enum) > 0 {
+				fmt.Fprintf(&text, " one of: %s", strings.Join(output.Enum, ", "))
+			}
+			if output.Description != "" {
+				fmt.Fprintf(&text, " - %s", output.Description)
+			}
+		}
+	}
+	return text.String()
+}
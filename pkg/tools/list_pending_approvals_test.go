@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+// approvalWorkflow is a running workflow whose "approve" node waits for a
+// required "decision" output and an optional "comment" output.
+func approvalWorkflow(name string, waiting time.Duration) *wfv1.Workflow {
+	return &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "argo"},
+		Status: wfv1.WorkflowStatus{
+			Phase: wfv1.WorkflowRunning,
+			Nodes: wfv1.Nodes{
+				name: {ID: name, Name: name, DisplayName: name, Type: wfv1.NodeTypeSteps, Phase: wfv1.NodeRunning,
+					Children: []string{name + "-1", name + "-2"}},
+				name + "-1": {ID: name + "-1", Name: name + "[0].build", DisplayName: "build", Type: wfv1.NodeTypePod,
+					Phase: wfv1.NodeSucceeded},
+				name + "-2": {
+					ID: name + "-2", Name: name + "[1].approve", DisplayName: "approve", TemplateName: "approval",
+					Type: wfv1.NodeTypeSuspend, Phase: wfv1.NodeRunning, Message: "Deploy to prod?",
+					StartedAt: metav1.NewTime(time.Now().Add(-waiting)),
+					Outputs: &wfv1.Outputs{Parameters: []wfv1.Parameter{
+						{Name: "decision", Description: wfv1.AnyStringPtr("yes or no"), Enum: []wfv1.AnyString{"yes", "no"},
+							ValueFrom: &wfv1.ValueFrom{Supplied: &wfv1.SuppliedValueFrom{}}},
+						{Name: "comment", ValueFrom: &wfv1.ValueFrom{Supplied: &wfv1.SuppliedValueFrom{}, Default: wfv1.AnyStringPtr("")}},
+						{Name: "fixed", Value: wfv1.AnyStringPtr("x")},
+					}},
+				},
+			},
+		},
+	}
+}
+
+func TestListPendingApprovalsTool(t *testing.T) {
+	tool := ListPendingApprovalsTool()
+
+	assert.Equal(t, "list_pending_approvals", tool.Name)
+	assert.Contains(t, tool.Description, "approve_node")
+	require.NotNil(t, tool.Annotations)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+}
+
+func TestListPendingApprovalsHandler(t *testing.T) {
+	t.Run("lists waiting nodes across namespaces, longest waiting first", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", true)
+		wfService := newMockWorkflowService(t)
+		mockClient.SetWorkflowService(wfService)
+
+		offloaded := wfv1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{Name: "big", Namespace: "argo"},
+			Status:     wfv1.WorkflowStatus{Phase: wfv1.WorkflowRunning, OffloadNodeStatusVersion: "v1"},
+		}
+		wfService.On("ListWorkflows", mock.Anything, &workflow.WorkflowListRequest{
+			ListOptions: &metav1.ListOptions{LabelSelector: "workflows.argoproj.io/phase=Running,team=data"},
+		}).Return(&wfv1.WorkflowList{Items: wfv1.Workflows{*approvalWorkflow("recent", time.Minute), offloaded}}, nil)
+		wfService.On("GetWorkflow", mock.Anything, &workflow.WorkflowGetRequest{Name: "big", Namespace: "argo"}).
+			Return(approvalWorkflow("big", time.Hour), nil)
+		defer wfService.AssertExpectations(t)
+
+		handler := ListPendingApprovalsHandler(mockClient)
+		result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, ListPendingApprovalsInput{Namespace: ptr.To(""), Labels: "team=data"})
+		require.NoError(t, err)
+
+		require.Equal(t, 2, output.Total)
+		first := output.Approvals[0]
+		assert.Equal(t, "big", first.Workflow)
+		assert.Equal(t, "big-2", first.NodeID)
+		assert.Equal(t, "approve", first.NodeName)
+		assert.Equal(t, "approval", first.TemplateName)
+		assert.Equal(t, "Deploy to prod?", first.Message)
+		assert.InDelta(t, 3600, first.WaitingSeconds, 5)
+		assert.Equal(t, []ExpectedOutput{
+			{Name: "decision", Description: "yes or no", Enum: []string{"yes", "no"}, Required: true},
+			{Name: "comment", Default: ptr.To("")},
+		}, first.ExpectedOutputs)
+		assert.Equal(t, "recent", output.Approvals[1].Workflow)
+
+		text, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, text.Text, "2 node(s) waiting for approval across all namespaces:")
+		assert.Contains(t, text.Text, `argo/big node "approve" (id big-2), waiting 1h0m`)
+		assert.Contains(t, text.Text, "output decision (required) one of: yes, no - yes or no")
+		assert.Contains(t, text.Text, `output comment (default "")`)
+	})
+
+	t.Run("nothing waiting", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", true)
+		wfService := newMockWorkflowService(t)
+		mockClient.SetWorkflowService(wfService)
+		wfService.On("ListWorkflows", mock.Anything, mock.MatchedBy(func(req *workflow.WorkflowListRequest) bool {
+			return req.Namespace == "argo"
+		})).Return(&wfv1.WorkflowList{}, nil)
+
+		result, output, err := ListPendingApprovalsHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, ListPendingApprovalsInput{})
+		require.NoError(t, err)
+		assert.Empty(t, output.Approvals)
+		text, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Equal(t, `No suspended nodes are waiting for approval in namespace "argo"`, text.Text)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", true)
+		wfService := newMockWorkflowService(t)
+		mockClient.SetWorkflowService(wfService)
+		wfService.On("ListWorkflows", mock.Anything, mock.Anything).Return(nil, errors.New("forbidden"))
+
+		_, _, err := ListPendingApprovalsHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, ListPendingApprovalsInput{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list workflows")
+	})
+}
@@ -0,0 +1,142 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/event"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// ListWorkflowEventBindingsInput defines the input parameters for the list_workflow_event_bindings tool.
+type ListWorkflowEventBindingsInput struct {
+	// Namespace is the Kubernetes namespace (uses default if not specified).
+	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace (uses default if not specified)"`
+
+	// Labels is the label selector to filter bindings.
+	Labels string `json:"labels,omitempty" jsonschema:"Label selector (e.g. 'app=myapp')"`
+}
+
+// WorkflowEventBindingSummary represents a concise summary of a workflow event binding.
+type WorkflowEventBindingSummary struct {
+	// Name is the binding name.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the binding.
+	Namespace string `json:"namespace"`
+
+	// Selector is the expression an event must match.
+	Selector string `json:"selector"`
+
+	// WorkflowTemplate is the template submitted when an event matches.
+	WorkflowTemplate string `json:"workflowTemplate,omitempty"`
+
+	// CreatedAt is when the binding was created.
+	CreatedAt string `json:"createdAt,omitempty"`
+
+	// ClusterScope is true when WorkflowTemplate is a ClusterWorkflowTemplate.
+	ClusterScope bool `json:"clusterScope,omitempty"`
+}
+
+// ListWorkflowEventBindingsOutput defines the output for the list_workflow_event_bindings tool.
+type ListWorkflowEventBindingsOutput struct {
+	// Bindings is the list of binding summaries.
+	Bindings []WorkflowEventBindingSummary `json:"bindings"`
+
+	// Total is the number of bindings.
+	Total int `json:"total"`
+}
+
+// ListWorkflowEventBindingsTool returns the MCP tool definition for list_workflow_event_bindings.
+func ListWorkflowEventBindingsTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name:        "list_workflow_event_bindings",
+		Description: "List WorkflowEventBindings in a namespace, with the event selector and the template each one submits",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+}
+
+// ListWorkflowEventBindingsHandler returns a handler function for the list_workflow_event_bindings tool.
+func ListWorkflowEventBindingsHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, ListWorkflowEventBindingsInput) (*mcp.CallToolResult, *ListWorkflowEventBindingsOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input ListWorkflowEventBindingsInput) (*mcp.CallToolResult, *ListWorkflowEventBindingsOutput, error) {
+		namespace := ResolveNamespace(input.Namespace, client)
+
+		bindings, err := listEventBindings(ctx, client, namespace, strings.TrimSpace(input.Labels))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		output := &ListWorkflowEventBindingsOutput{Bindings: make([]WorkflowEventBindingSummary, 0, len(bindings))}
+		for i := range bindings {
+			output.Bindings = append(output.Bindings, eventBindingSummary(&bindings[i]))
+		}
+		output.Total = len(output.Bindings)
+
+		var text strings.Builder
+		fmt.Fprintf(&text, "Found %d workflow event binding(s) in namespace %q", output.Total, namespace)
+		for _, binding := range output.Bindings {
+			fmt.Fprintf(&text, "\n- %s: %s", binding.Name, binding.Selector)
+			if binding.WorkflowTemplate != "" {
+				fmt.Fprintf(&text, " -> %s", binding.WorkflowTemplate)
+			}
+		}
+		return TextResult(text.String()), output, nil
+	}
+}
+
+// listEventBindings lists the workflow event bindings of namespace, through
+// the Argo Server event API or the Kubernetes API depending on the mode.
+func listEventBindings(ctx context.Context, client argo.ClientInterface, namespace, labels string) ([]wfv1.WorkflowEventBinding, error) {
+	listOpts := &metav1.ListOptions{LabelSelector: labels}
+
+	if client.IsArgoServerMode() {
+		eventService, err := client.EventService()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get event service: %w", err)
+		}
+		list, err := eventService.ListWorkflowEventBindings(ctx, &event.ListWorkflowEventBindingsRequest{
+			Namespace:   namespace,
+			ListOptions: listOpts,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list workflow event bindings: %w", err)
+		}
+		return list.Items, nil
+	}
+
+	wfClient, err := client.WorkflowClientset()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow clientset: %w", err)
+	}
+	list, err := wfClient.ArgoprojV1alpha1().WorkflowEventBindings(namespace).List(ctx, *listOpts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workflow event bindings: %w", err)
+	}
+	return list.Items, nil
+}
+
+// eventBindingSummary summarizes a workflow event binding.
+func eventBindingSummary(binding *wfv1.WorkflowEventBinding) WorkflowEventBindingSummary {
+	summary := WorkflowEventBindingSummary{
+		Name:      binding.Name,
+		Namespace: binding.Namespace,
+		Selector:  binding.Spec.Event.Selector,
+	}
+	if submit := binding.Spec.Submit; submit != nil {
+		summary.WorkflowTemplate = submit.WorkflowTemplateRef.Name
+		summary.ClusterScope = submit.WorkflowTemplateRef.ClusterScope
+	}
+	if !binding.CreationTimestamp.IsZero() {
+		summary.CreatedAt = binding.CreationTimestamp.Format(time.RFC3339)
+	}
+	return summary
+}
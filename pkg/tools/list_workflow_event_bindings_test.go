@@ -0,0 +1,100 @@
+package tools
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/event"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	wffake "github.com/argoproj/argo-workflows/v4/pkg/client/clientset/versioned/fake"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+)
+
+// pushEventBinding is a binding that submits the "build" template for push
+// events of the given repository, taking the commit from the payload.
+func pushEventBinding(name, repo string) *wfv1.WorkflowEventBinding {
+	return &wfv1.WorkflowEventBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "argo", Labels: map[string]string{"team": "ci"}},
+		Spec: wfv1.WorkflowEventBindingSpec{
+			Event: wfv1.Event{Selector: `payload.repo == "` + repo + `" && discriminator == "push"`},
+			Submit: &wfv1.Submit{
+				WorkflowTemplateRef: wfv1.WorkflowTemplateRef{Name: "build"},
+				ObjectMeta:          metav1.ObjectMeta{GenerateName: "build-"},
+				Arguments: &wfv1.Arguments{Parameters: []wfv1.Parameter{
+					{Name: "commit", ValueFrom: &wfv1.ValueFrom{Event: "payload.commit"}},
+					{Name: "env", Value: wfv1.AnyStringPtr("ci")},
+				}},
+			},
+		},
+	}
+}
+
+// newMockEventService creates a new mock event service client.
+func newMockEventService(t *testing.T) *mocks.MockEventServiceClient {
+	t.Helper()
+	m := &mocks.MockEventServiceClient{}
+	m.Test(t)
+	return m
+}
+
+func TestListWorkflowEventBindingsTool(t *testing.T) {
+	tool := ListWorkflowEventBindingsTool()
+
+	assert.Equal(t, "list_workflow_event_bindings", tool.Name)
+	require.NotNil(t, tool.Annotations)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+}
+
+func TestListWorkflowEventBindingsHandler(t *testing.T) {
+	t.Run("argo server mode lists through the event API", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", true)
+		eventService := newMockEventService(t)
+		mockClient.SetEventService(eventService)
+		eventService.On("ListWorkflowEventBindings", mock.Anything, &event.ListWorkflowEventBindingsRequest{
+			Namespace:   "argo",
+			ListOptions: &metav1.ListOptions{LabelSelector: "team=ci"},
+		}).Return(&wfv1.WorkflowEventBindingList{Items: []wfv1.WorkflowEventBinding{*pushEventBinding("on-push", "argo")}}, nil)
+		defer eventService.AssertExpectations(t)
+
+		result, output, err := ListWorkflowEventBindingsHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, ListWorkflowEventBindingsInput{Labels: "team=ci"})
+		require.NoError(t, err)
+		require.Equal(t, 1, output.Total)
+		assert.Equal(t, WorkflowEventBindingSummary{
+			Name:             "on-push",
+			Namespace:        "argo",
+			Selector:         `payload.repo == "argo" && discriminator == "push"`,
+			WorkflowTemplate: "build",
+		}, output.Bindings[0])
+
+		text, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, text.Text, `Found 1 workflow event binding(s) in namespace "argo"`)
+		assert.Contains(t, text.Text, `- on-push: payload.repo == "argo" && discriminator == "push" -> build`)
+	})
+
+	t.Run("direct mode lists through the Kubernetes API", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", false)
+		mockClient.SetWorkflowClientset(wffake.NewSimpleClientset(pushEventBinding("on-push", "argo"), pushEventBinding("on-tag", "argo")))
+
+		_, output, err := ListWorkflowEventBindingsHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, ListWorkflowEventBindingsInput{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, output.Total)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", true)
+		eventService := newMockEventService(t)
+		mockClient.SetEventService(eventService)
+		eventService.On("ListWorkflowEventBindings", mock.Anything, mock.Anything).Return(nil, errors.New("forbidden"))
+
+		_, _, err := ListWorkflowEventBindingsHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, ListWorkflowEventBindingsInput{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list workflow event bindings")
+	})
+}
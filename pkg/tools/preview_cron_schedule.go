@@ -0,0 +1,269 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/cronworkflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// Limits for the number of fire times previewed per schedule.
+const (
+	defaultPreviewCount = 5
+	maxPreviewCount     = 100
+)
+
+// PreviewCronScheduleInput defines the input parameters for the preview_cron_schedule tool.
+type PreviewCronScheduleInput struct {
+	// Namespace is the Kubernetes namespace (uses default if not specified).
+	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace (uses default if not specified)"`
+
+	// Name is the name of a live CronWorkflow to preview.
+	Name string `json:"name,omitempty" jsonschema:"Name of a live CronWorkflow to preview (alternative to manifest)"`
+
+	// Manifest is a CronWorkflow YAML manifest to preview.
+	Manifest string `json:"manifest,omitempty" jsonschema:"CronWorkflow YAML manifest to preview (alternative to name)"`
+
+	// Count is the number of fire times to compute per schedule.
+	Count int `json:"count,omitempty" jsonschema:"Number of fire times to compute per schedule (default 5, max 100)"`
+
+	// From is the time to compute fire times after. Defaults to now.
+	From string `json:"from,omitempty" jsonschema:"RFC3339 time to compute fire times after (default now)"`
+}
+
+// CronFireTime is a single scheduled run of a CronWorkflow.
+type CronFireTime struct {
+	// ScheduledTime is when the run is scheduled, in the CronWorkflow's timezone.
+	ScheduledTime string `json:"scheduledTime"`
+
+	// StartDeadline is the latest time the run can still start when it is
+	// missed, derived from startingDeadlineSeconds.
+	StartDeadline string `json:"startDeadline,omitempty"`
+}
+
+// CronSchedulePreview holds the upcoming fire times of one schedule.
+type CronSchedulePreview struct {
+	Schedule string         `json:"schedule"`
+	NextRuns []CronFireTime `json:"nextRuns"`
+}
+
+// CronMissedRun describes a run the controller missed since the last scheduled run.
+type CronMissedRun struct {
+	ScheduledTime     string `json:"scheduledTime"`
+	LastScheduledTime string `json:"lastScheduledTime"`
+	Reason            string `json:"reason"`
+	WillRun           bool   `json:"willRun"`
+}
+
+// PreviewCronScheduleOutput defines the output for the preview_cron_schedule tool.
+type PreviewCronScheduleOutput struct {
+	StartingDeadlineSeconds *int64                `json:"startingDeadlineSeconds,omitempty"`
+	MissedRun               *CronMissedRun        `json:"missedRun,omitempty"`
+	Name                    string                `json:"name,omitempty"`
+	Namespace               string                `json:"namespace,omitempty"`
+	Timezone                string                `json:"timezone"`
+	From                    string                `json:"from"`
+	ConcurrencyPolicy       string                `json:"concurrencyPolicy,omitempty"`
+	Schedules               []CronSchedulePreview `json:"schedules"`
+	Warnings                []string              `json:"warnings,omitempty"`
+}
+
+// PreviewCronScheduleTool returns the MCP tool definition for preview_cron_schedule.
+func PreviewCronScheduleTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "preview_cron_schedule",
+		Description: "Preview the next fire times of each schedule of a CronWorkflow, from a manifest or a live CronWorkflow. " +
+			"Times are computed in the CronWorkflow's timezone and include the start deadline from startingDeadlineSeconds. " +
+			"For live CronWorkflows, also reports whether a missed run will still be started.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+}
+
+// PreviewCronScheduleHandler returns a handler function for the preview_cron_schedule tool.
+func PreviewCronScheduleHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, PreviewCronScheduleInput) (*mcp.CallToolResult, *PreviewCronScheduleOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input PreviewCronScheduleInput) (*mcp.CallToolResult, *PreviewCronScheduleOutput, error) {
+		hasName := strings.TrimSpace(input.Name) != ""
+		hasManifest := strings.TrimSpace(input.Manifest) != ""
+		switch {
+		case hasName && hasManifest:
+			return nil, nil, fmt.Errorf("provide either name or manifest, not both")
+		case !hasName && !hasManifest:
+			return nil, nil, fmt.Errorf("either name or manifest is required")
+		}
+
+		count := input.Count
+		if count <= 0 {
+			count = defaultPreviewCount
+		}
+		if count > maxPreviewCount {
+			return nil, nil, fmt.Errorf("count must be at most %d", maxPreviewCount)
+		}
+
+		now := time.Now()
+		from := now
+		if input.From != "" {
+			parsed, parseErr := time.Parse(time.RFC3339, input.From)
+			if parseErr != nil {
+				return nil, nil, fmt.Errorf("invalid from time, expected RFC3339: %w", parseErr)
+			}
+			from = parsed
+		}
+
+		var cw *wfv1.CronWorkflow
+		if hasManifest {
+			if len(input.Manifest) > maxUpdateBytes {
+				return nil, nil, fmt.Errorf("manifest too large (%d bytes), max %d", len(input.Manifest), maxUpdateBytes)
+			}
+			cw = &wfv1.CronWorkflow{}
+			if err := yaml.UnmarshalStrict([]byte(input.Manifest), cw); err != nil {
+				return nil, nil, fmt.Errorf("failed to parse cron workflow manifest: %w", err)
+			}
+			if cw.Kind != "" && cw.Kind != KindCronWorkflow {
+				return nil, nil, fmt.Errorf("manifest must be a CronWorkflow, got %q", cw.Kind)
+			}
+		} else {
+			name, err := ValidateName(input.Name)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			cronService, err := client.CronWorkflowService()
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get cron workflow service: %w", err)
+			}
+
+			cw, err = cronService.GetCronWorkflow(ctx, &cronworkflow.GetCronWorkflowRequest{
+				Name:      name,
+				Namespace: ResolveNamespace(input.Namespace, client),
+			})
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get cron workflow: %w", err)
+			}
+		}
+
+		schedules, location, err := parseCronSchedules(&cw.Spec)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		output := &PreviewCronScheduleOutput{
+			Name:                    cw.Name,
+			Namespace:               cw.Namespace,
+			Timezone:                location.String(),
+			From:                    from.In(location).Format(time.RFC3339),
+			StartingDeadlineSeconds: cw.Spec.StartingDeadlineSeconds,
+			ConcurrencyPolicy:       string(cw.Spec.ConcurrencyPolicy),
+			Schedules:               make([]CronSchedulePreview, 0, len(schedules)),
+			Warnings:                cronScheduleWarnings(cw),
+		}
+
+		for _, s := range schedules {
+			preview := CronSchedulePreview{Schedule: s.expression}
+			for _, t := range nextFireTimes(s.schedule, from, count) {
+				preview.NextRuns = append(preview.NextRuns, cronFireTime(t.In(location), cw.Spec.StartingDeadlineSeconds))
+			}
+			output.Schedules = append(output.Schedules, preview)
+		}
+
+		if hasName && cw.Status.LastScheduledTime != nil && !cw.Status.LastScheduledTime.IsZero() {
+			output.MissedRun = missedCronRun(cw, schedules, location, now)
+		}
+
+		return TextResult(formatCronPreview(output)), output, nil
+	}
+}
+
+// cronFireTime returns the fire time at t with the start deadline applied.
+func cronFireTime(t time.Time, startingDeadlineSeconds *int64) CronFireTime {
+	fire := CronFireTime{ScheduledTime: t.Format(time.RFC3339)}
+	if startingDeadlineSeconds != nil {
+		fire.StartDeadline = t.Add(time.Duration(*startingDeadlineSeconds) * time.Second).Format(time.RFC3339)
+	}
+	return fire
+}
+
+// missedCronRun reports the latest run missed since the last scheduled run and
+// whether the controller will still start it, or nil if no run was missed.
+func missedCronRun(cw *wfv1.CronWorkflow, schedules []cronSchedule, location *time.Location, now time.Time) *CronMissedRun {
+	lastScheduled := cw.Status.LastScheduledTime.Time
+	missed := latestMissedRun(schedules, lastScheduled, now)
+	if missed.IsZero() {
+		return nil
+	}
+
+	run := &CronMissedRun{
+		ScheduledTime:     missed.In(location).Format(time.RFC3339),
+		LastScheduledTime: lastScheduled.In(location).Format(time.RFC3339),
+	}
+	deadline := cw.Spec.StartingDeadlineSeconds
+	switch {
+	case cw.IsUsingNewSchedule():
+		run.Reason = "the schedule changed since the last run, so missed runs are not started"
+	case deadline == nil:
+		run.Reason = "startingDeadlineSeconds is not set, so missed runs are not started"
+	case now.Before(missed.Add(time.Duration(*deadline) * time.Second)):
+		run.WillRun = true
+		run.Reason = fmt.Sprintf("the run is within startingDeadlineSeconds (%ds) and will start when the controller next reconciles", *deadline)
+	default:
+		run.Reason = fmt.Sprintf("the run is past startingDeadlineSeconds (%ds) and will not be started", *deadline)
+	}
+	return run
+}
+
+// cronScheduleWarnings returns conditions under which scheduled runs do not
+// happen at the previewed times.
+func cronScheduleWarnings(cw *wfv1.CronWorkflow) []string {
+	var warnings []string
+	if cw.Spec.Suspend {
+		warnings = append(warnings, "the CronWorkflow is suspended, no runs will be scheduled until it is resumed")
+	}
+	if cw.Status.Phase == wfv1.StoppedPhase {
+		warnings = append(warnings, "the CronWorkflow has been stopped by its stopStrategy, no further runs will be scheduled")
+	} else if cw.Spec.StopStrategy != nil {
+		warnings = append(warnings, fmt.Sprintf("scheduling stops when the stopStrategy expression %q is true", cw.Spec.StopStrategy.Expression))
+	}
+	if cw.Spec.When != "" {
+		warnings = append(warnings, fmt.Sprintf("runs are skipped when the when expression %q is false", cw.Spec.When))
+	}
+	if cw.Spec.Timezone == "" {
+		warnings = append(warnings, fmt.Sprintf("no timezone is set, times assume the controller runs in %s", defaultCronTimezone))
+	}
+	return warnings
+}
+
+// formatCronPreview builds the human-readable preview.
+func formatCronPreview(output *PreviewCronScheduleOutput) string {
+	var sb strings.Builder
+	if output.Name != "" {
+		fmt.Fprintf(&sb, "CronWorkflow %q schedule preview (%s)\n", output.Name, output.Timezone)
+	} else {
+		fmt.Fprintf(&sb, "CronWorkflow schedule preview (%s)\n", output.Timezone)
+	}
+	for _, preview := range output.Schedules {
+		fmt.Fprintf(&sb, "\n%s:\n", preview.Schedule)
+		for _, run := range preview.NextRuns {
+			if run.StartDeadline != "" {
+				fmt.Fprintf(&sb, "  %s (start by %s)\n", run.ScheduledTime, run.StartDeadline)
+			} else {
+				fmt.Fprintf(&sb, "  %s\n", run.ScheduledTime)
+			}
+		}
+	}
+	if output.MissedRun != nil {
+		fmt.Fprintf(&sb, "\nMissed run at %s: %s\n", output.MissedRun.ScheduledTime, output.MissedRun.Reason)
+	}
+	for _, warning := range output.Warnings {
+		fmt.Fprintf(&sb, "\nWarning: %s", warning)
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
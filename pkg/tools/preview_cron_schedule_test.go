@@ -0,0 +1,203 @@
+package tools
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+)
+
+func TestPreviewCronScheduleTool(t *testing.T) {
+	tool := PreviewCronScheduleTool()
+
+	assert.Equal(t, "preview_cron_schedule", tool.Name)
+	assert.Contains(t, tool.Description, "startingDeadlineSeconds")
+	require.NotNil(t, tool.Annotations)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+}
+
+// liveCronWorkflow returns an hourly CronWorkflow last scheduled at lastScheduled.
+func liveCronWorkflow(lastScheduled time.Time, deadline *int64) *wfv1.CronWorkflow {
+	cw := &wfv1.CronWorkflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "hourly", Namespace: "default"},
+		Spec: wfv1.CronWorkflowSpec{
+			Schedules:               []string{"0 * * * *"},
+			StartingDeadlineSeconds: deadline,
+		},
+		Status: wfv1.CronWorkflowStatus{
+			LastScheduledTime: &metav1.Time{Time: lastScheduled},
+		},
+	}
+	// The controller records the schedule it last ran with
+	cw.SetSchedules(cw.Spec.GetSchedulesWithTimezone())
+	return cw
+}
+
+func TestPreviewCronScheduleHandler(t *testing.T) {
+	manifest := `apiVersion: argoproj.io/v1alpha1
+kind: CronWorkflow
+metadata:
+  name: reports
+spec:
+  schedules:
+    - "0 9 * * 1-5"
+    - "30 17 * * 5"
+  timezone: Europe/London
+  startingDeadlineSeconds: 600
+  workflowSpec:
+    entrypoint: main
+    templates:
+    - name: main
+      container:
+        image: alpine:3.20
+`
+	threeHoursAgo := time.Now().Add(-3 * time.Hour)
+
+	tests := []struct {
+		setupMock func(*mocks.MockCronWorkflowServiceClient)
+		validate  func(*testing.T, *PreviewCronScheduleOutput, *mcp.CallToolResult)
+		name      string
+		wantErr   string
+		input     PreviewCronScheduleInput
+	}{
+		{
+			name:  "manifest with timezone and deadline",
+			input: PreviewCronScheduleInput{Manifest: manifest, Count: 3, From: "2025-07-04T12:00:00Z"},
+			validate: func(t *testing.T, output *PreviewCronScheduleOutput, result *mcp.CallToolResult) {
+				assert.Equal(t, "reports", output.Name)
+				assert.Equal(t, "Europe/London", output.Timezone)
+				require.Len(t, output.Schedules, 2)
+
+				weekdays := output.Schedules[0]
+				require.Len(t, weekdays.NextRuns, 3)
+				// 2025-07-04 is a Friday; British Summer Time is UTC+1
+				assert.Equal(t, "2025-07-07T09:00:00+01:00", weekdays.NextRuns[0].ScheduledTime)
+				assert.Equal(t, "2025-07-07T09:10:00+01:00", weekdays.NextRuns[0].StartDeadline)
+
+				fridays := output.Schedules[1]
+				assert.Equal(t, "2025-07-04T17:30:00+01:00", fridays.NextRuns[0].ScheduledTime)
+
+				assert.Nil(t, output.MissedRun)
+				assert.Empty(t, output.Warnings)
+
+				text, ok := result.Content[0].(*mcp.TextContent)
+				require.True(t, ok)
+				assert.Contains(t, text.Text, "start by 2025-07-07T09:10:00+01:00")
+			},
+		},
+		{
+			name:  "live cron within starting deadline catches up",
+			input: PreviewCronScheduleInput{Name: "hourly"},
+			setupMock: func(m *mocks.MockCronWorkflowServiceClient) {
+				m.On("GetCronWorkflow", mock.Anything, mock.Anything).Return(liveCronWorkflow(threeHoursAgo, ptr.To[int64](7200)), nil)
+			},
+			validate: func(t *testing.T, output *PreviewCronScheduleOutput, _ *mcp.CallToolResult) {
+				require.Len(t, output.Schedules[0].NextRuns, defaultPreviewCount)
+				require.NotNil(t, output.MissedRun)
+				assert.True(t, output.MissedRun.WillRun)
+				assert.Contains(t, output.Warnings, "no timezone is set, times assume the controller runs in UTC")
+			},
+		},
+		{
+			name:  "live cron without starting deadline skips missed run",
+			input: PreviewCronScheduleInput{Name: "hourly"},
+			setupMock: func(m *mocks.MockCronWorkflowServiceClient) {
+				m.On("GetCronWorkflow", mock.Anything, mock.Anything).Return(liveCronWorkflow(threeHoursAgo, nil), nil)
+			},
+			validate: func(t *testing.T, output *PreviewCronScheduleOutput, _ *mcp.CallToolResult) {
+				require.NotNil(t, output.MissedRun)
+				assert.False(t, output.MissedRun.WillRun)
+				assert.Contains(t, output.MissedRun.Reason, "startingDeadlineSeconds is not set")
+			},
+		},
+		{
+			name:  "changed schedule skips missed run",
+			input: PreviewCronScheduleInput{Name: "hourly"},
+			setupMock: func(m *mocks.MockCronWorkflowServiceClient) {
+				cw := liveCronWorkflow(threeHoursAgo, ptr.To[int64](7200))
+				cw.SetSchedules([]string{"0 0 * * *"})
+				m.On("GetCronWorkflow", mock.Anything, mock.Anything).Return(cw, nil)
+			},
+			validate: func(t *testing.T, output *PreviewCronScheduleOutput, _ *mcp.CallToolResult) {
+				require.NotNil(t, output.MissedRun)
+				assert.False(t, output.MissedRun.WillRun)
+				assert.Contains(t, output.MissedRun.Reason, "schedule changed")
+			},
+		},
+		{
+			name:  "suspended cron warns",
+			input: PreviewCronScheduleInput{Name: "hourly"},
+			setupMock: func(m *mocks.MockCronWorkflowServiceClient) {
+				cw := liveCronWorkflow(time.Now(), nil)
+				cw.Spec.Suspend = true
+				m.On("GetCronWorkflow", mock.Anything, mock.Anything).Return(cw, nil)
+			},
+			validate: func(t *testing.T, output *PreviewCronScheduleOutput, _ *mcp.CallToolResult) {
+				assert.Nil(t, output.MissedRun)
+				assert.Contains(t, output.Warnings[0], "suspended")
+			},
+		},
+		{
+			name:  "cron not found",
+			input: PreviewCronScheduleInput{Name: "missing"},
+			setupMock: func(m *mocks.MockCronWorkflowServiceClient) {
+				m.On("GetCronWorkflow", mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+			},
+			wantErr: "failed to get cron workflow",
+		},
+		{
+			name:    "name and manifest are exclusive",
+			input:   PreviewCronScheduleInput{Name: "hourly", Manifest: manifest},
+			wantErr: "either name or manifest",
+		},
+		{
+			name:    "count is capped",
+			input:   PreviewCronScheduleInput{Manifest: manifest, Count: 1000},
+			wantErr: "count must be at most 100",
+		},
+		{
+			name:    "invalid from",
+			input:   PreviewCronScheduleInput{Manifest: manifest, From: "tomorrow"},
+			wantErr: "invalid from time",
+		},
+		{
+			name:    "wrong kind",
+			input:   PreviewCronScheduleInput{Manifest: "kind: Workflow\nmetadata:\n  name: x\n"},
+			wantErr: "must be a CronWorkflow",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := newMockClient(t, "default", true)
+			mockService := newMockCronWorkflowService(t)
+			mockClient.SetCronWorkflowService(mockService)
+			if tt.setupMock != nil {
+				tt.setupMock(mockService)
+			}
+			defer mockService.AssertExpectations(t)
+
+			handler := PreviewCronScheduleHandler(mockClient)
+			result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, tt.input)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, output)
+			tt.validate(t, output, result)
+		})
+	}
+}
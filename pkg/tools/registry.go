@@ -29,17 +29,30 @@ func AllTools() []ToolRegistrar {
 		RegisterResubmitWorkflow,
 		RegisterSuspendWorkflow,
 		RegisterResumeWorkflow,
+		RegisterListPendingApprovals,
+		RegisterApproveNode,
+		RegisterRejectNode,
+		RegisterGetSynchronizationStatus,
+		RegisterWorkflowQueueStatus,
 		RegisterStopWorkflow,
 		RegisterTerminateWorkflow,
 		RegisterRenderWorkflowGraph,
 		RegisterRenderManifestGraph,
+		RegisterLintBestPractices,
+		RegisterResolveWorkflow,
+		RegisterScaffoldWorkflow,
 		RegisterListWorkflowTemplates,
 		RegisterGetWorkflowTemplate,
+		RegisterDescribeTemplateParameters,
+		RegisterSubmitWorkflowTemplate,
 		RegisterCreateWorkflowTemplate,
+		RegisterUpdateWorkflowTemplate,
 		RegisterDeleteWorkflowTemplate,
+		RegisterFindTemplateUsages,
 		RegisterListClusterWorkflowTemplates,
 		RegisterGetClusterWorkflowTemplate,
 		RegisterCreateClusterWorkflowTemplate,
+		RegisterUpdateClusterWorkflowTemplate,
 		RegisterDeleteClusterWorkflowTemplate,
 		RegisterListCronWorkflows,
 		RegisterGetCronWorkflow,
@@ -47,11 +60,25 @@ func AllTools() []ToolRegistrar {
 		RegisterDeleteCronWorkflow,
 		RegisterSuspendCronWorkflow,
 		RegisterResumeCronWorkflow,
+		RegisterPreviewCronSchedule,
+		RegisterBackfillCronWorkflow,
+		RegisterCronWorkflowHistory,
+		RegisterTriggerCronWorkflow,
+		RegisterListWorkflowEventBindings,
+		RegisterGetWorkflowEventBinding,
+		RegisterCreateWorkflowEventBinding,
+		RegisterDeleteWorkflowEventBinding,
+		RegisterSendWorkflowEvent,
 		RegisterGetWorkflowNode,
+		RegisterExportWorkflow,
+		RegisterImportWorkflowBundle,
+		RegisterGetWorkflowBundle,
+		RegisterRemoveWorkflowBundle,
 		RegisterDeleteArchivedWorkflow,
 		RegisterResubmitArchivedWorkflow,
 		RegisterRetryArchivedWorkflow,
 		RegisterConvertWorkflow,
+		RegisterGetArgoInfo,
 	}
 }
 
@@ -139,6 +166,31 @@ func RegisterResumeWorkflow(s *mcp.Server, client argo.ClientInterface) {
 	mcp.AddTool(s, ResumeWorkflowTool(), ResumeWorkflowHandler(client))
 }
 
+// RegisterListPendingApprovals registers the list_pending_approvals tool.
+func RegisterListPendingApprovals(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, ListPendingApprovalsTool(), ListPendingApprovalsHandler(client))
+}
+
+// RegisterApproveNode registers the approve_node tool.
+func RegisterApproveNode(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, ApproveNodeTool(), ApproveNodeHandler(client))
+}
+
+// RegisterGetSynchronizationStatus registers the get_synchronization_status tool.
+func RegisterGetSynchronizationStatus(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, GetSynchronizationStatusTool(), GetSynchronizationStatusHandler(client))
+}
+
+// RegisterWorkflowQueueStatus registers the workflow_queue_status tool.
+func RegisterWorkflowQueueStatus(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, WorkflowQueueStatusTool(), WorkflowQueueStatusHandler(client))
+}
+
+// RegisterRejectNode registers the reject_node tool.
+func RegisterRejectNode(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, RejectNodeTool(), RejectNodeHandler(client))
+}
+
 // RegisterStopWorkflow registers the stop_workflow tool.
 func RegisterStopWorkflow(s *mcp.Server, client argo.ClientInterface) {
 	mcp.AddTool(s, StopWorkflowTool(), StopWorkflowHandler(client))
@@ -159,11 +211,26 @@ func RegisterGetWorkflowTemplate(s *mcp.Server, client argo.ClientInterface) {
 	mcp.AddTool(s, GetWorkflowTemplateTool(), GetWorkflowTemplateHandler(client))
 }
 
+// RegisterDescribeTemplateParameters registers the describe_template_parameters tool.
+func RegisterDescribeTemplateParameters(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, DescribeTemplateParametersTool(), DescribeTemplateParametersHandler(client))
+}
+
+// RegisterSubmitWorkflowTemplate registers the submit_workflow_template tool.
+func RegisterSubmitWorkflowTemplate(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, SubmitWorkflowTemplateTool(), SubmitWorkflowTemplateHandler(client))
+}
+
 // RegisterCreateWorkflowTemplate registers the create_workflow_template tool.
 func RegisterCreateWorkflowTemplate(s *mcp.Server, client argo.ClientInterface) {
 	mcp.AddTool(s, CreateWorkflowTemplateTool(), CreateWorkflowTemplateHandler(client))
 }
 
+// RegisterUpdateWorkflowTemplate registers the update_workflow_template tool.
+func RegisterUpdateWorkflowTemplate(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, UpdateWorkflowTemplateTool(), UpdateWorkflowTemplateHandler(client))
+}
+
 // RegisterDeleteWorkflowTemplate registers the delete_workflow_template tool.
 func RegisterDeleteWorkflowTemplate(s *mcp.Server, client argo.ClientInterface) {
 	mcp.AddTool(s, DeleteWorkflowTemplateTool(), DeleteWorkflowTemplateHandler(client))
@@ -180,8 +247,28 @@ func RegisterRenderWorkflowGraph(s *mcp.Server, client argo.ClientInterface) {
 }
 
 // RegisterRenderManifestGraph registers the render_manifest_graph tool.
-func RegisterRenderManifestGraph(s *mcp.Server, _ argo.ClientInterface) {
-	mcp.AddTool(s, RenderManifestGraphTool(), RenderManifestGraphHandler())
+func RegisterRenderManifestGraph(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, RenderManifestGraphTool(), RenderManifestGraphHandler(client))
+}
+
+// RegisterLintBestPractices registers the lint_best_practices tool.
+func RegisterLintBestPractices(s *mcp.Server, _ argo.ClientInterface) {
+	mcp.AddTool(s, LintBestPracticesTool(), LintBestPracticesHandler())
+}
+
+// RegisterScaffoldWorkflow registers the scaffold_workflow tool.
+func RegisterScaffoldWorkflow(s *mcp.Server, _ argo.ClientInterface) {
+	mcp.AddTool(s, ScaffoldWorkflowTool(), ScaffoldWorkflowHandler())
+}
+
+// RegisterResolveWorkflow registers the resolve_workflow tool.
+func RegisterResolveWorkflow(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, ResolveWorkflowTool(), ResolveWorkflowHandler(client))
+}
+
+// RegisterFindTemplateUsages registers the find_template_usages tool.
+func RegisterFindTemplateUsages(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, FindTemplateUsagesTool(), FindTemplateUsagesHandler(client))
 }
 
 // RegisterGetClusterWorkflowTemplate registers the get_cluster_workflow_template tool.
@@ -194,6 +281,11 @@ func RegisterCreateClusterWorkflowTemplate(s *mcp.Server, client argo.ClientInte
 	mcp.AddTool(s, CreateClusterWorkflowTemplateTool(), CreateClusterWorkflowTemplateHandler(client))
 }
 
+// RegisterUpdateClusterWorkflowTemplate registers the update_cluster_workflow_template tool.
+func RegisterUpdateClusterWorkflowTemplate(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, UpdateClusterWorkflowTemplateTool(), UpdateClusterWorkflowTemplateHandler(client))
+}
+
 // RegisterDeleteClusterWorkflowTemplate registers the delete_cluster_workflow_template tool.
 func RegisterDeleteClusterWorkflowTemplate(s *mcp.Server, client argo.ClientInterface) {
 	mcp.AddTool(s, DeleteClusterWorkflowTemplateTool(), DeleteClusterWorkflowTemplateHandler(client))
@@ -229,11 +321,76 @@ func RegisterResumeCronWorkflow(s *mcp.Server, client argo.ClientInterface) {
 	mcp.AddTool(s, ResumeCronWorkflowTool(), ResumeCronWorkflowHandler(client))
 }
 
+// RegisterPreviewCronSchedule registers the preview_cron_schedule tool.
+func RegisterPreviewCronSchedule(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, PreviewCronScheduleTool(), PreviewCronScheduleHandler(client))
+}
+
+// RegisterBackfillCronWorkflow registers the backfill_cron_workflow tool.
+func RegisterBackfillCronWorkflow(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, BackfillCronWorkflowTool(), BackfillCronWorkflowHandler(client))
+}
+
+// RegisterCronWorkflowHistory registers the cron_workflow_history tool.
+func RegisterCronWorkflowHistory(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, CronWorkflowHistoryTool(), CronWorkflowHistoryHandler(client))
+}
+
+// RegisterTriggerCronWorkflow registers the trigger_cron_workflow tool.
+func RegisterTriggerCronWorkflow(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, TriggerCronWorkflowTool(), TriggerCronWorkflowHandler(client))
+}
+
+// RegisterListWorkflowEventBindings registers the list_workflow_event_bindings tool.
+func RegisterListWorkflowEventBindings(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, ListWorkflowEventBindingsTool(), ListWorkflowEventBindingsHandler(client))
+}
+
+// RegisterGetWorkflowEventBinding registers the get_workflow_event_binding tool.
+func RegisterGetWorkflowEventBinding(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, GetWorkflowEventBindingTool(), GetWorkflowEventBindingHandler(client))
+}
+
+// RegisterCreateWorkflowEventBinding registers the create_workflow_event_binding tool.
+func RegisterCreateWorkflowEventBinding(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, CreateWorkflowEventBindingTool(), CreateWorkflowEventBindingHandler(client))
+}
+
+// RegisterDeleteWorkflowEventBinding registers the delete_workflow_event_binding tool.
+func RegisterDeleteWorkflowEventBinding(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, DeleteWorkflowEventBindingTool(), DeleteWorkflowEventBindingHandler(client))
+}
+
+// RegisterSendWorkflowEvent registers the send_workflow_event tool.
+func RegisterSendWorkflowEvent(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, SendWorkflowEventTool(), SendWorkflowEventHandler(client))
+}
+
 // RegisterGetWorkflowNode registers the get_workflow_node tool.
 func RegisterGetWorkflowNode(s *mcp.Server, client argo.ClientInterface) {
 	mcp.AddTool(s, GetWorkflowNodeTool(), GetWorkflowNodeHandler(client))
 }
 
+// RegisterExportWorkflow registers the export_workflow tool.
+func RegisterExportWorkflow(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, ExportWorkflowTool(), ExportWorkflowHandler(client))
+}
+
+// RegisterImportWorkflowBundle registers the import_workflow_bundle tool.
+func RegisterImportWorkflowBundle(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, ImportWorkflowBundleTool(), ImportWorkflowBundleHandler(client))
+}
+
+// RegisterGetWorkflowBundle registers the get_workflow_bundle tool.
+func RegisterGetWorkflowBundle(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, GetWorkflowBundleTool(), GetWorkflowBundleHandler(client))
+}
+
+// RegisterRemoveWorkflowBundle registers the remove_workflow_bundle tool.
+func RegisterRemoveWorkflowBundle(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, RemoveWorkflowBundleTool(), RemoveWorkflowBundleHandler(client))
+}
+
 // RegisterDeleteArchivedWorkflow registers the delete_archived_workflow tool.
 func RegisterDeleteArchivedWorkflow(s *mcp.Server, client argo.ClientInterface) {
 	mcp.AddTool(s, DeleteArchivedWorkflowTool(), DeleteArchivedWorkflowHandler(client))
@@ -253,3 +410,8 @@ func RegisterRetryArchivedWorkflow(s *mcp.Server, client argo.ClientInterface) {
 func RegisterConvertWorkflow(s *mcp.Server, _ argo.ClientInterface) {
 	mcp.AddTool(s, ConvertWorkflowTool(), ConvertWorkflowHandler())
 }
+
+// RegisterGetArgoInfo registers the get_argo_info tool.
+func RegisterGetArgoInfo(s *mcp.Server, client argo.ClientInterface) {
+	mcp.AddTool(s, GetArgoInfoTool(), GetArgoInfoHandler(client))
+}
@@ -0,0 +1,31 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/utils/ptr"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// RejectNodeTool returns the MCP tool definition for reject_node.
+func RejectNodeTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "reject_node",
+		Description: "Reject a suspended node of a workflow, like argo node set --phase Failed: the node fails with an optional " +
+			"message and output parameters, and the workflow fails unless the step continues on failure.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr.To(true),
+		},
+	}
+}
+
+// RejectNodeHandler returns a handler function for the reject_node tool.
+func RejectNodeHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, NodeDecisionInput) (*mcp.CallToolResult, *NodeDecisionOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input NodeDecisionInput) (*mcp.CallToolResult, *NodeDecisionOutput, error) {
+		return decideSuspendNode(ctx, client, input, wfv1.NodeFailed)
+	}
+}
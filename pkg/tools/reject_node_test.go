@@ -0,0 +1,61 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRejectNodeTool(t *testing.T) {
+	tool := RejectNodeTool()
+
+	assert.Equal(t, "reject_node", tool.Name)
+	assert.Contains(t, tool.Description, "Failed")
+	require.NotNil(t, tool.Annotations)
+	require.NotNil(t, tool.Annotations.DestructiveHint)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+}
+
+func TestRejectNodeHandler(t *testing.T) {
+	t.Run("fails the node without requiring outputs", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", true)
+		wfService := newMockWorkflowService(t)
+		mockClient.SetWorkflowService(wfService)
+		wfService.On("GetWorkflow", mock.Anything, mock.Anything).Return(approvalWorkflow("deploy", time.Minute), nil)
+		wfService.On("SetWorkflow", mock.Anything, &workflow.WorkflowSetRequest{
+			Name:              "deploy",
+			Namespace:         "argo",
+			NodeFieldSelector: "id=deploy-2",
+			Message:           "not today",
+			Phase:             "Failed",
+		}).Return(&wfv1.Workflow{Status: wfv1.WorkflowStatus{Phase: wfv1.WorkflowFailed}}, nil)
+		defer wfService.AssertExpectations(t)
+
+		result, output, err := RejectNodeHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, NodeDecisionInput{Name: "deploy", Message: "not today"})
+		require.NoError(t, err)
+		assert.Equal(t, DecisionRejected, output.Decision)
+		assert.Equal(t, "Failed", output.Phase)
+		text, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, text.Text, `Node(s) approve of workflow "deploy" in namespace "argo" rejected`)
+	})
+
+	t.Run("still checks output values", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", true)
+		wfService := newMockWorkflowService(t)
+		mockClient.SetWorkflowService(wfService)
+		wfService.On("GetWorkflow", mock.Anything, mock.Anything).Return(approvalWorkflow("deploy", time.Minute), nil)
+
+		_, _, err := RejectNodeHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, NodeDecisionInput{
+			Name: "deploy", OutputParameters: map[string]string{"decision": "maybe"},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid value "maybe"`)
+	})
+}
@@ -10,6 +10,8 @@ import (
 	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"sigs.k8s.io/yaml"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
 )
 
 // Kind constants for manifest types.
@@ -50,6 +52,15 @@ type RenderManifestGraphInput struct {
 
 	// Format is the output format (mermaid, ascii, dot, or svg).
 	Format string `json:"format,omitempty" jsonschema:"Output format: mermaid (default), ascii, dot, or svg,enum=mermaid,enum=ascii,enum=dot,enum=svg"`
+
+	// Resolve expands workflowTemplateRef and templateRefs before rendering, as resolve_workflow does.
+	Resolve bool `json:"resolve,omitempty" jsonschema:"Expand workflowTemplateRef and templateRefs before rendering, so referenced templates appear in the graph"`
+
+	// Namespace is the namespace WorkflowTemplates are looked up in when resolving.
+	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace to look up WorkflowTemplates in when resolving (uses default if not specified)"`
+
+	// Bundle is a multi-document YAML of templates to resolve against offline.
+	Bundle string `json:"bundle,omitempty" jsonschema:"Multi-document YAML of WorkflowTemplates and ClusterWorkflowTemplates to resolve against offline instead of the cluster"`
 }
 
 // RenderManifestGraphOutput defines the output for the render_manifest_graph tool.
@@ -61,13 +72,14 @@ type RenderManifestGraphOutput struct {
 	Kind      string `json:"kind"`
 	Name      string `json:"name,omitempty"`
 	NodeCount int    `json:"nodeCount"`
+	Resolved  bool   `json:"resolved,omitempty"`
 }
 
 // RenderManifestGraphTool returns the MCP tool definition for render_manifest_graph.
 func RenderManifestGraphTool() *mcp.Tool {
 	return &mcp.Tool{
 		Name:        "render_manifest_graph",
-		Description: "Render an Argo Workflow manifest (YAML) as a graph showing the DAG structure and dependencies, without submitting it. Supports Workflow, WorkflowTemplate, ClusterWorkflowTemplate, and CronWorkflow manifests. Supports Mermaid, ASCII, DOT, and SVG formats.",
+		Description: "Render an Argo Workflow manifest (YAML) as a graph showing the DAG structure and dependencies, without submitting it. Supports Workflow, WorkflowTemplate, ClusterWorkflowTemplate, and CronWorkflow manifests. Supports Mermaid, ASCII, DOT, and SVG formats. Set resolve to expand templateRefs first, against the cluster or an offline bundle.",
 		Annotations: &mcp.ToolAnnotations{
 			ReadOnlyHint: true,
 		},
@@ -75,8 +87,8 @@ func RenderManifestGraphTool() *mcp.Tool {
 }
 
 // RenderManifestGraphHandler returns a handler function for the render_manifest_graph tool.
-// Note: This tool doesn't require the Argo client since it works purely from YAML.
-func RenderManifestGraphHandler() func(context.Context, *mcp.CallToolRequest, RenderManifestGraphInput) (*mcp.CallToolResult, *RenderManifestGraphOutput, error) {
+// The client is only used to look up templates when resolving references against the cluster.
+func RenderManifestGraphHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, RenderManifestGraphInput) (*mcp.CallToolResult, *RenderManifestGraphOutput, error) {
 	return func(ctx context.Context, _ *mcp.CallToolRequest, input RenderManifestGraphInput) (*mcp.CallToolResult, *RenderManifestGraphOutput, error) {
 		// Validate manifest is provided
 		if strings.TrimSpace(input.Manifest) == "" {
@@ -103,6 +115,13 @@ func RenderManifestGraphHandler() func(context.Context, *mcp.CallToolRequest, Re
 		if err != nil {
 			return nil, nil, err
 		}
+		if input.Resolve {
+			resolved, resolveErr := resolveManifest(ctx, client, ResolveNamespace(input.Namespace, client), input.Bundle, kind, name, spec)
+			if resolveErr != nil {
+				return nil, nil, resolveErr
+			}
+			spec = resolved.Spec
+		}
 
 		// Build the graph structure from the spec
 		nodes, err := buildGraphFromSpec(spec)
@@ -134,10 +153,14 @@ func RenderManifestGraphHandler() func(context.Context, *mcp.CallToolRequest, Re
 			NodeCount: len(nodes),
 			Kind:      kind,
 			Name:      name,
+			Resolved:  input.Resolve,
 		}
 
 		// Build human-readable result
 		resultText := fmt.Sprintf("Rendered %s %q as %s graph with %d nodes", kind, name, format, output.NodeCount)
+		if output.Resolved {
+			resultText += " (template references resolved)"
+		}
 
 		result := &mcp.CallToolResult{
 			Content: []mcp.Content{
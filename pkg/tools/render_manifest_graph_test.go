@@ -7,6 +7,8 @@ import (
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/fake"
 )
 
 func TestRenderManifestGraphTool(t *testing.T) {
@@ -229,7 +231,7 @@ func TestRenderManifestGraphHandler(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			handler := RenderManifestGraphHandler()
+			handler := RenderManifestGraphHandler(nil)
 			result, output, err := handler(t.Context(), nil, tt.input)
 
 			if tt.expectedError != "" {
@@ -403,7 +405,7 @@ func TestManifestTooLarge(t *testing.T) {
 	// Create a manifest larger than 1 MiB
 	largeManifest := strings.Repeat("x", 1<<20+1)
 
-	handler := RenderManifestGraphHandler()
+	handler := RenderManifestGraphHandler(nil)
 	_, _, err := handler(t.Context(), nil, RenderManifestGraphInput{
 		Manifest: largeManifest,
 	})
@@ -585,3 +587,36 @@ spec:
         image: alpine
         command: [echo, hello]
 `
+
+func TestRenderManifestGraphResolved(t *testing.T) {
+	handler := RenderManifestGraphHandler(fake.NewClient(t.Context(), "default"))
+
+	// The entrypoint comes from the referenced WorkflowTemplate
+	manifest := `apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: from-lib-
+spec:
+  entrypoint: build
+  workflowTemplateRef:
+    name: lib
+`
+	_, output, err := handler(t.Context(), nil, RenderManifestGraphInput{
+		Manifest: manifest,
+		Format:   FormatMermaid,
+		Resolve:  true,
+		Bundle:   resolverBundle,
+	})
+	require.NoError(t, err)
+	assert.True(t, output.Resolved)
+	assert.Equal(t, 2, output.NodeCount)
+	assert.Contains(t, output.Graph, "compile")
+	assert.Contains(t, output.Graph, "shared-upload")
+
+	_, _, err = handler(t.Context(), nil, RenderManifestGraphInput{
+		Manifest: templateRefWorkflowManifest,
+		Resolve:  true,
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"lib"`)
+}
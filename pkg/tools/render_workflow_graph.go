@@ -8,10 +8,13 @@ import (
 	"strings"
 
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowarchive"
 	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
 )
 
 const (
@@ -34,8 +37,23 @@ const (
 	nodeStatusError     = "error"
 	nodeStatusSkipped   = "skipped"
 	nodeStatusOmitted   = "omitted"
+	nodeStatusLost      = "lost"
 )
 
+// nodePhaseLost marks placeholder nodes for children whose status is missing
+// from the workflow, e.g. because it was offloaded and could not be retrieved.
+const nodePhaseLost wfv1.NodePhase = "Lost"
+
+// Sources of a rendered workflow.
+const (
+	graphSourceLive     = "live"
+	graphSourceArchived = "archived"
+)
+
+// errOffloadNotSupported is the message of the error the Argo API returns
+// without an Argo Server when a workflow's node status is offloaded.
+const errOffloadNotSupported = "offload node status is not supported"
+
 // nodeColorDefault is the fallback DOT colour for unrecognised or pending phases.
 const nodeColorDefault = "#9ca3af"
 
@@ -47,11 +65,32 @@ type RenderWorkflowGraphInput struct {
 	// Namespace is the Kubernetes namespace (uses default if not specified).
 	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace (uses default if not specified)"`
 
-	// Name is the workflow name.
-	Name string `json:"name" jsonschema:"Workflow name,required"`
+	// Name is the workflow name. Workflows no longer in the cluster are looked up in the archive.
+	Name string `json:"name,omitempty" jsonschema:"Workflow name; looked up in the archive when it is no longer in the cluster (required unless uid is given)"`
+
+	// UID is the UID of an archived workflow, as an alternative to Name; giving both is an error.
+	UID string `json:"uid,omitempty" jsonschema:"UID of an archived workflow to render instead of a workflow by name (requires Argo Server)"`
 
 	// Format is the output format (mermaid, ascii, dot, or svg).
 	Format string `json:"format,omitempty" jsonschema:"Output format: mermaid (default), ascii, dot, or svg,enum=mermaid,enum=ascii,enum=dot,enum=svg"`
+
+	// Focus is the ID, name or display name of the node to draw with its neighbourhood.
+	Focus string `json:"focus,omitempty" jsonschema:"Only draw this node (ID, name or display name) and its ancestors and descendants up to depth levels away"`
+
+	// Depth is how many levels of ancestors and descendants of Focus to draw.
+	Depth int `json:"depth,omitempty" jsonschema:"Levels of ancestors and descendants drawn around the focus node (default: 2)"`
+
+	// Page is the 1-based page of DAG levels to draw.
+	Page int `json:"page,omitempty" jsonschema:"Only draw this page of DAG levels, starting at 1 (default: all levels unless levelsPerPage is set)"`
+
+	// LevelsPerPage is the number of DAG levels on a page.
+	LevelsPerPage int `json:"levelsPerPage,omitempty" jsonschema:"Number of DAG levels per page (default: 10)"`
+
+	// CollapseFanOuts draws each withItems/withParam fan-out as a single node.
+	CollapseFanOuts bool `json:"collapseFanOuts,omitempty" jsonschema:"Draw each withItems/withParam fan-out as one node with the number of items per phase"`
+
+	// CollapseSucceeded hides the descendants of succeeded nodes when they all succeeded.
+	CollapseSucceeded bool `json:"collapseSucceeded,omitempty" jsonschema:"Collapse subtrees in which every node succeeded into their root node"`
 }
 
 // RenderWorkflowGraphOutput defines the output for the render_workflow_graph tool.
@@ -62,15 +101,51 @@ type RenderWorkflowGraphOutput struct {
 	// Format is the format used for rendering.
 	Format string `json:"format"`
 
-	// NodeCount is the number of nodes in the graph.
+	// Source is where the workflow was read from: live or archived.
+	Source string `json:"source"`
+
+	// UID is the UID of the rendered workflow.
+	UID string `json:"uid,omitempty"`
+
+	// LostNodes are the IDs of nodes whose status is missing from the workflow.
+	// They are drawn as placeholders labelled "status lost".
+	LostNodes []string `json:"lostNodes,omitempty"`
+
+	// Warnings explain node status that could not be retrieved.
+	Warnings []string `json:"warnings,omitempty"`
+
+	// NodeCount is the number of nodes with a status in the graph.
 	NodeCount int `json:"nodeCount"`
+
+	// RenderedNodeCount is the number of nodes drawn when large-graph options are set.
+	RenderedNodeCount int `json:"renderedNodeCount,omitempty"`
+
+	// OmittedNodeCount is the number of nodes left out by focus and pagination.
+	OmittedNodeCount int `json:"omittedNodeCount,omitempty"`
+
+	// Collapsed lists the drawn nodes that stand for several workflow nodes.
+	Collapsed []CollapsedGraphNode `json:"collapsed,omitempty"`
+
+	// Focus is the ID of the node the graph is focused on.
+	Focus string `json:"focus,omitempty"`
+
+	// Page is the page of DAG levels drawn.
+	Page int `json:"page,omitempty"`
+
+	// TotalPages is the number of pages of DAG levels.
+	TotalPages int `json:"totalPages,omitempty"`
+
+	// Levels is the number of DAG levels in the graph before pagination.
+	Levels int `json:"levels,omitempty"`
 }
 
 // RenderWorkflowGraphTool returns the MCP tool definition for render_workflow_graph.
 func RenderWorkflowGraphTool() *mcp.Tool {
 	return &mcp.Tool{
-		Name:        "render_workflow_graph",
-		Description: "Render an Argo Workflow as a graph showing the DAG structure, step dependencies, and node statuses. Supports Mermaid, ASCII, DOT, and SVG formats.",
+		Name: "render_workflow_graph",
+		Description: "Render an Argo Workflow as a graph showing the DAG structure, step dependencies, and node statuses. Supports Mermaid, ASCII, DOT, and SVG formats. " +
+			"Renders archived workflows by uid, or by name once they left the cluster, and marks nodes whose status was offloaded and could not be retrieved. " +
+			"For large workflows, collapse withItems/withParam fan-outs and succeeded subtrees, focus on a node's neighbourhood, or paginate by DAG level.",
 		Annotations: &mcp.ToolAnnotations{
 			ReadOnlyHint: true,
 		},
@@ -80,9 +155,12 @@ func RenderWorkflowGraphTool() *mcp.Tool {
 // RenderWorkflowGraphHandler returns a handler function for the render_workflow_graph tool.
 func RenderWorkflowGraphHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, RenderWorkflowGraphInput) (*mcp.CallToolResult, *RenderWorkflowGraphOutput, error) {
 	return func(ctx context.Context, _ *mcp.CallToolRequest, input RenderWorkflowGraphInput) (*mcp.CallToolResult, *RenderWorkflowGraphOutput, error) {
-		// Validate name is provided
-		if strings.TrimSpace(input.Name) == "" {
-			return nil, nil, fmt.Errorf("workflow name cannot be empty")
+		// Validate name or UID is provided
+		if strings.TrimSpace(input.Name) == "" && strings.TrimSpace(input.UID) == "" {
+			return nil, nil, fmt.Errorf("workflow name cannot be empty (or give the uid of an archived workflow)")
+		}
+		if strings.TrimSpace(input.Name) != "" && strings.TrimSpace(input.UID) != "" {
+			return nil, nil, toolerror.Invalid("uid", fmt.Errorf("give either the workflow name or the uid of an archived workflow, not both"))
 		}
 
 		// Determine namespace
@@ -103,16 +181,29 @@ func RenderWorkflowGraphHandler(client argo.ClientInterface) func(context.Contex
 			includeStatus = *input.IncludeStatus
 		}
 
-		// Get the workflow service client
-		wfService := client.WorkflowService()
+		opts, err := newGraphOptions(input)
+		if err != nil {
+			return nil, nil, err
+		}
 
-		// Get the workflow
-		wf, err := wfService.GetWorkflow(ctx, &workflow.WorkflowGetRequest{
-			Namespace: namespace,
-			Name:      input.Name,
-		})
+		// Get the workflow, live or archived
+		wf, source, err := getGraphWorkflow(ctx, client, namespace, input)
 		if err != nil {
-			return nil, nil, fmt.Errorf("failed to get workflow: %w", err)
+			return nil, nil, err
+		}
+		nodeCount := len(wf.Status.Nodes)
+		warnings := nodeStatusWarnings(wf)
+		lostNodes := markLostNodes(wf)
+
+		// Select the nodes to draw for large graphs
+		renderWf := wf
+		var view *graphView
+		if opts.active() {
+			view, err = newGraphView(wf.Status.Nodes, opts)
+			if err != nil {
+				return nil, nil, err
+			}
+			renderWf = &wfv1.Workflow{ObjectMeta: wf.ObjectMeta, Status: wfv1.WorkflowStatus{Phase: wf.Status.Phase, Nodes: view.nodes}}
 		}
 
 		// Render the graph
@@ -120,13 +211,13 @@ func RenderWorkflowGraphHandler(client argo.ClientInterface) func(context.Contex
 		var renderErr error
 		switch format {
 		case FormatMermaid:
-			graph = renderMermaidGraph(wf, includeStatus)
+			graph = renderMermaidGraph(renderWf, includeStatus)
 		case FormatASCII:
-			graph = renderASCIIGraph(wf, includeStatus)
+			graph = renderASCIIGraph(renderWf, includeStatus)
 		case FormatDOT:
-			graph = renderDOTGraph(wf, includeStatus)
+			graph = renderDOTGraph(renderWf, includeStatus)
 		case FormatSVG:
-			dot := renderDOTGraph(wf, includeStatus)
+			dot := renderDOTGraph(renderWf, includeStatus)
 			graph, renderErr = dotToSVG(ctx, dot)
 			if renderErr != nil {
 				return nil, nil, fmt.Errorf("failed to render SVG: %w", renderErr)
@@ -136,11 +227,36 @@ func RenderWorkflowGraphHandler(client argo.ClientInterface) func(context.Contex
 		output := &RenderWorkflowGraphOutput{
 			Graph:     graph,
 			Format:    format,
-			NodeCount: len(wf.Status.Nodes),
+			Source:    source,
+			UID:       string(wf.UID),
+			LostNodes: lostNodes,
+			Warnings:  warnings,
+			NodeCount: nodeCount,
+		}
+		if view != nil {
+			output.RenderedNodeCount = len(view.nodes)
+			output.OmittedNodeCount = view.omitted
+			output.Collapsed = view.collapsed
+			output.Focus = view.focus
+			output.Page = view.page
+			output.TotalPages = view.totalPages
+			output.Levels = view.levels
 		}
 
 		// Build human-readable result
-		resultText := fmt.Sprintf("Rendered workflow %q as %s graph with %d nodes", input.Name, format, output.NodeCount)
+		resultText := fmt.Sprintf("Rendered workflow %q as %s graph with %d nodes", wf.Name, format, output.NodeCount)
+		if source == graphSourceArchived {
+			resultText += " from the workflow archive"
+		}
+		if len(lostNodes) > 0 {
+			resultText += fmt.Sprintf("; %d nodes have no status and are labelled \"status lost\"", len(lostNodes))
+		}
+		if view != nil {
+			resultText += graphViewSummary(view)
+		}
+		for _, warning := range warnings {
+			resultText += "\nWarning: " + warning
+		}
 
 		result := &mcp.CallToolResult{
 			Content: []mcp.Content{
@@ -152,6 +268,137 @@ func RenderWorkflowGraphHandler(client argo.ClientInterface) func(context.Contex
 	}
 }
 
+// getGraphWorkflow returns the workflow to render and where it was read from:
+// an archived workflow by UID, or a live workflow by name that falls back to
+// the archive once the workflow left the cluster. Offloaded node status the
+// API could not hydrate is read from the archive or left empty.
+func getGraphWorkflow(ctx context.Context, client argo.ClientInterface, namespace string, input RenderWorkflowGraphInput) (*wfv1.Workflow, string, error) {
+	if uid := strings.TrimSpace(input.UID); uid != "" {
+		wf, err := getArchivedWorkflow(ctx, client, uid)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get archived workflow: %w", err)
+		}
+		return wf, graphSourceArchived, nil
+	}
+
+	name := strings.TrimSpace(input.Name)
+	wf, err := client.WorkflowService().GetWorkflow(ctx, &workflow.WorkflowGetRequest{Namespace: namespace, Name: name})
+	switch {
+	case err == nil:
+	case strings.Contains(err.Error(), errOffloadNotSupported):
+		// Without an Argo Server the offloaded nodes cannot be read, but the
+		// workflow itself can, so its missing nodes are reported as lost
+		wf, err = getWorkflowResource(ctx, client, namespace, name)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get workflow: %w", err)
+		}
+	case client.IsArgoServerMode() && toolerror.Classify(err).Category == toolerror.CategoryNotFound:
+		archived, archiveErr := findArchivedWorkflow(ctx, client, namespace, name)
+		if archiveErr != nil || archived == nil {
+			return nil, "", fmt.Errorf("failed to get workflow: %w", err)
+		}
+		return archived, graphSourceArchived, nil
+	default:
+		return nil, "", fmt.Errorf("failed to get workflow: %w", err)
+	}
+
+	// The archive keeps the full node status of completed workflows
+	if len(wf.Status.Nodes) == 0 && wf.Status.IsOffloadNodeStatus() && wf.Status.Fulfilled() && client.IsArgoServerMode() {
+		if archived, archiveErr := getArchivedWorkflow(ctx, client, string(wf.UID)); archiveErr == nil {
+			wf.Status.Nodes = archived.Status.Nodes
+		}
+	}
+	return wf, graphSourceLive, nil
+}
+
+// getArchivedWorkflow returns an archived workflow by UID.
+func getArchivedWorkflow(ctx context.Context, client argo.ClientInterface, uid string) (*wfv1.Workflow, error) {
+	archiveService, err := client.ArchivedWorkflowService()
+	if err != nil {
+		return nil, err
+	}
+	return archiveService.GetArchivedWorkflow(ctx, &workflowarchive.GetArchivedWorkflowRequest{Uid: uid})
+}
+
+// findArchivedWorkflow returns the most recently created archived workflow
+// with the given name, or nil if none was archived.
+func findArchivedWorkflow(ctx context.Context, client argo.ClientInterface, namespace, name string) (*wfv1.Workflow, error) {
+	archiveService, err := client.ArchivedWorkflowService()
+	if err != nil {
+		return nil, err
+	}
+	list, err := archiveService.ListArchivedWorkflows(ctx, &workflowarchive.ListArchivedWorkflowsRequest{
+		Namespace:  namespace,
+		NamePrefix: name,
+		NameFilter: "Exact",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var newest *wfv1.Workflow
+	for i := range list.Items {
+		item := &list.Items[i]
+		if item.Name == name && (newest == nil || item.CreationTimestamp.After(newest.CreationTimestamp.Time)) {
+			newest = item
+		}
+	}
+	if newest == nil {
+		return nil, nil
+	}
+	// Archive listings leave out node status
+	return archiveService.GetArchivedWorkflow(ctx, &workflowarchive.GetArchivedWorkflowRequest{Uid: string(newest.UID)})
+}
+
+// getWorkflowResource reads a workflow from the Kubernetes API without
+// hydrating its node status.
+func getWorkflowResource(ctx context.Context, client argo.ClientInterface, namespace, name string) (*wfv1.Workflow, error) {
+	wfClient, err := client.WorkflowClientset()
+	if err != nil {
+		return nil, err
+	}
+	return wfClient.ArgoprojV1alpha1().Workflows(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// nodeStatusWarnings explains why a workflow has no node status to render.
+func nodeStatusWarnings(wf *wfv1.Workflow) []string {
+	if len(wf.Status.Nodes) > 0 || !wf.Status.IsOffloadNodeStatus() {
+		return nil
+	}
+	return []string{fmt.Sprintf("the node status of workflow %q was offloaded to the persistence database (version %s) and could not be retrieved, so its nodes cannot be shown",
+		wf.Name, wf.Status.OffloadNodeStatusVersion)}
+}
+
+// markLostNodes adds a placeholder for every child node that is referenced
+// but has no status, and returns the IDs of those nodes in order. Placeholders
+// have the phase nodePhaseLost and are labelled "status lost".
+func markLostNodes(wf *wfv1.Workflow) []string {
+	lost := make(map[string]bool)
+	for _, node := range wf.Status.Nodes {
+		for _, childID := range node.Children {
+			if _, ok := wf.Status.Nodes[childID]; !ok {
+				lost[childID] = true
+			}
+		}
+	}
+	if len(lost) == 0 {
+		return nil
+	}
+
+	lostNodes := make([]string, 0, len(lost))
+	for id := range lost {
+		lostNodes = append(lostNodes, id)
+		wf.Status.Nodes[id] = wfv1.NodeStatus{
+			ID:          id,
+			Name:        id,
+			DisplayName: "status lost: " + id,
+			Phase:       nodePhaseLost,
+		}
+	}
+	sort.Strings(lostNodes)
+	return lostNodes
+}
+
 // renderMermaidGraph renders a workflow as a Mermaid flowchart.
 func renderMermaidGraph(wf *wfv1.Workflow, includeStatus bool) string {
 	if len(wf.Status.Nodes) == 0 {
@@ -211,6 +458,7 @@ func renderMermaidGraph(wf *wfv1.Workflow, includeStatus bool) string {
 		sb.WriteString("    classDef error fill:#dc2626,color:#fff,stroke:#b91c1c\n")
 		sb.WriteString("    classDef skipped fill:#d1d5db,color:#374151,stroke:#9ca3af\n")
 		sb.WriteString("    classDef omitted fill:#e5e7eb,color:#6b7280,stroke:#d1d5db\n")
+		sb.WriteString("    classDef lost fill:#fff,color:#6b7280,stroke:#6b7280,stroke-dasharray:5 5\n")
 	}
 
 	return sb.String()
@@ -321,8 +569,12 @@ func renderDOTGraph(wf *wfv1.Workflow, includeStatus bool) string {
 		// Add node definition with styling
 		if includeStatus {
 			color := getNodeColor(node.Phase)
-			fmt.Fprintf(&sb, "    \"%s\" [label=\"%s\", fillcolor=\"%s\", style=filled];\n",
-				safeID, displayName, color)
+			style := "filled"
+			if node.Phase == nodePhaseLost {
+				style = "\"filled,dashed\""
+			}
+			fmt.Fprintf(&sb, "    \"%s\" [label=\"%s\", fillcolor=\"%s\", style=%s];\n",
+				safeID, displayName, color, style)
 		} else {
 			fmt.Fprintf(&sb, "    \"%s\" [label=\"%s\"];\n", safeID, displayName)
 		}
@@ -444,6 +696,8 @@ func getNodeClassName(phase wfv1.NodePhase) string {
 		return nodeStatusSkipped
 	case wfv1.NodeOmitted:
 		return nodeStatusOmitted
+	case nodePhaseLost:
+		return nodeStatusLost
 	default:
 		return nodeStatusPending
 	}
@@ -466,6 +720,8 @@ func getStatusSymbol(phase wfv1.NodePhase) string {
 		return "⊘"
 	case wfv1.NodeOmitted:
 		return "⊗"
+	case nodePhaseLost:
+		return "?"
 	default:
 		return "○"
 	}
@@ -488,6 +744,8 @@ func getNodeColor(phase wfv1.NodePhase) string {
 		return "#d1d5db"
 	case wfv1.NodeOmitted:
 		return "#e5e7eb"
+	case nodePhaseLost:
+		return "#ffffff"
 	default:
 		return nodeColorDefault
 	}
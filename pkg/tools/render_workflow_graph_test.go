@@ -5,13 +5,20 @@ import (
 	"testing"
 
 	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowarchive"
 	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	wffake "github.com/argoproj/argo-workflows/v4/pkg/client/clientset/versioned/fake"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/fake"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
 )
 
 func TestRenderWorkflowGraphTool(t *testing.T) {
@@ -175,6 +182,107 @@ func TestRenderWorkflowGraphHandler(t *testing.T) {
 	}
 }
 
+func TestRenderWorkflowGraphHandler_Archived(t *testing.T) {
+	client := fake.NewClient(t.Context(), "default")
+	wf := createDAGWorkflow("archived-workflow", "default")
+	wf.Status.Phase = wfv1.WorkflowSucceeded
+	require.NoError(t, client.AddWorkflow(wf))
+	live, err := client.WorkflowService().GetWorkflow(t.Context(), &workflow.WorkflowGetRequest{Namespace: "default", Name: wf.Name})
+	require.NoError(t, err)
+	_, err = client.WorkflowService().DeleteWorkflow(t.Context(), &workflow.WorkflowDeleteRequest{Namespace: "default", Name: wf.Name})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		input RenderWorkflowGraphInput
+	}{
+		{name: "by uid", input: RenderWorkflowGraphInput{UID: string(live.UID)}},
+		{name: "by name once deleted", input: RenderWorkflowGraphInput{Name: wf.Name}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, output, err := RenderWorkflowGraphHandler(client)(t.Context(), nil, tt.input)
+			require.NoError(t, err)
+			assert.Equal(t, graphSourceArchived, output.Source)
+			assert.Equal(t, string(live.UID), output.UID)
+			assert.Equal(t, 3, output.NodeCount)
+			assert.Contains(t, output.Graph, "build-image")
+			assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "from the workflow archive")
+		})
+	}
+
+	t.Run("unknown name", func(t *testing.T) {
+		_, _, err := RenderWorkflowGraphHandler(client)(t.Context(), nil, RenderWorkflowGraphInput{Name: "missing"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get workflow")
+	})
+
+	t.Run("name and uid together", func(t *testing.T) {
+		_, _, err := RenderWorkflowGraphHandler(client)(t.Context(), nil, RenderWorkflowGraphInput{Name: wf.Name, UID: string(live.UID)})
+		require.Error(t, err)
+		assert.Equal(t, toolerror.CategoryInvalid, toolerror.Classify(err).Category)
+		assert.Equal(t, "uid", toolerror.Classify(err).Field)
+	})
+}
+
+func TestRenderWorkflowGraphHandler_OffloadedNodes(t *testing.T) {
+	offloaded := createEmptyWorkflow("offloaded", "default")
+	offloaded.Status.Phase = wfv1.WorkflowSucceeded
+	offloaded.Status.Nodes = nil
+	offloaded.Status.OffloadNodeStatusVersion = "fnv:123"
+
+	t.Run("nodes are read from the archive", func(t *testing.T) {
+		mockClient := newMockClient(t, "default", true)
+		mockWfService := newMockWorkflowService(t)
+		mockClient.SetWorkflowService(mockWfService)
+		mockArchive := newMockArchivedWorkflowService(t)
+		mockClient.SetArchivedWorkflowService(mockArchive)
+		mockWfService.On("GetWorkflow", mock.Anything, mock.Anything).Return(offloaded.DeepCopy(), nil)
+		mockArchive.On("GetArchivedWorkflow", mock.Anything, &workflowarchive.GetArchivedWorkflowRequest{Uid: "test-uid"}).
+			Return(createDAGWorkflow("offloaded", "default"), nil)
+
+		_, output, err := RenderWorkflowGraphHandler(mockClient)(t.Context(), nil, RenderWorkflowGraphInput{Name: "offloaded"})
+		require.NoError(t, err)
+		assert.Equal(t, graphSourceLive, output.Source)
+		assert.Equal(t, 3, output.NodeCount)
+		assert.Empty(t, output.Warnings)
+		mockArchive.AssertExpectations(t)
+	})
+
+	t.Run("without an Argo Server the nodes are reported as lost", func(t *testing.T) {
+		mockClient := newMockClient(t, "default", false)
+		mockWfService := newMockWorkflowService(t)
+		mockClient.SetWorkflowService(mockWfService)
+		mockClient.SetWorkflowClientset(wffake.NewSimpleClientset(offloaded.DeepCopy()))
+		mockWfService.On("GetWorkflow", mock.Anything, mock.Anything).
+			Return(nil, status.Error(codes.Internal, "offload node status is not supported"))
+
+		result, output, err := RenderWorkflowGraphHandler(mockClient)(t.Context(), nil, RenderWorkflowGraphInput{Name: "offloaded", Format: "ascii"})
+		require.NoError(t, err)
+		assert.Equal(t, 0, output.NodeCount)
+		require.Len(t, output.Warnings, 1)
+		assert.Contains(t, output.Warnings[0], "offloaded")
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "Warning:")
+	})
+}
+
+func TestMarkLostNodes(t *testing.T) {
+	wf := createDAGWorkflow("partial", "default")
+	delete(wf.Status.Nodes, "node-3")
+
+	lost := markLostNodes(wf)
+	assert.Equal(t, []string{"node-3"}, lost)
+	assert.Equal(t, nodePhaseLost, wf.Status.Nodes["node-3"].Phase)
+
+	mermaid := renderMermaidGraph(wf, true)
+	assert.Contains(t, mermaid, "node_3[status lost: node-3]:::lost")
+	assert.Contains(t, mermaid, "node_1 --> node_3")
+	assert.Contains(t, renderASCIIGraph(wf, true), "status lost: node-3 ?")
+	assert.Contains(t, renderDOTGraph(wf, true), `style="filled,dashed"`)
+
+	assert.Nil(t, markLostNodes(createDAGWorkflow("complete", "default")))
+}
+
 func TestRenderMermaidGraph(t *testing.T) {
 	tests := []struct {
 		workflow      *wfv1.Workflow
@@ -419,6 +527,7 @@ func TestGetStatusSymbol(t *testing.T) {
 		{"error", wfv1.NodeError, "⚠"},
 		{"skipped", wfv1.NodeSkipped, "⊘"},
 		{"omitted", wfv1.NodeOmitted, "⊗"},
+		{"lost", nodePhaseLost, "?"},
 		{"unknown", wfv1.NodePhase("unknown"), "○"},
 	}
 
@@ -443,6 +552,7 @@ func TestGetNodeClassName(t *testing.T) {
 		{"error", wfv1.NodeError, "error"},
 		{"skipped", wfv1.NodeSkipped, "skipped"},
 		{"omitted", wfv1.NodeOmitted, "omitted"},
+		{"lost", nodePhaseLost, "lost"},
 		{"unknown", wfv1.NodePhase("unknown"), "pending"},
 	}
 
@@ -467,6 +577,7 @@ func TestGetNodeColor(t *testing.T) {
 		{"error", wfv1.NodeError, "#dc2626"},
 		{"skipped", wfv1.NodeSkipped, "#d1d5db"},
 		{"omitted", wfv1.NodeOmitted, "#e5e7eb"},
+		{"lost", nodePhaseLost, "#ffffff"},
 		{"unknown", wfv1.NodePhase("unknown"), "#9ca3af"},
 	}
 
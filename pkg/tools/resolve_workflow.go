@@ -0,0 +1,154 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// ResolveWorkflowInput defines the input parameters for the resolve_workflow tool.
+type ResolveWorkflowInput struct {
+	// Namespace is the namespace WorkflowTemplates are looked up in (uses default if not specified).
+	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace to look up WorkflowTemplates in (uses default if not specified)"`
+
+	// Manifest is the manifest to resolve.
+	Manifest string `json:"manifest" jsonschema:"Workflow, WorkflowTemplate, ClusterWorkflowTemplate, or CronWorkflow YAML manifest,required"`
+
+	// Bundle is a multi-document YAML of WorkflowTemplates and ClusterWorkflowTemplates to resolve against offline.
+	Bundle string `json:"bundle,omitempty" jsonschema:"Multi-document YAML of the WorkflowTemplates and ClusterWorkflowTemplates to resolve against offline. When set the cluster is not contacted"`
+}
+
+// ResolveWorkflowOutput defines the output for the resolve_workflow tool.
+type ResolveWorkflowOutput struct {
+	// Kind is the kind of the manifest.
+	Kind string `json:"kind"`
+
+	// Name is the name of the manifest.
+	Name string `json:"name,omitempty"`
+
+	// Manifest is the self-contained manifest with all references expanded.
+	Manifest string `json:"manifest"`
+
+	// Dependencies is the dependency tree in depth-first order, starting with the manifest itself.
+	Dependencies []TemplateDependency `json:"dependencies"`
+
+	// Cycles lists reference cycles, which are kept as recursive template calls.
+	Cycles []string `json:"cycles,omitempty"`
+
+	// Warnings lists references that could not be resolved statically.
+	Warnings []string `json:"warnings,omitempty"`
+
+	// ImportedTemplates is the number of templates copied into the manifest.
+	ImportedTemplates int `json:"importedTemplates"`
+
+	// Offline is set when references were resolved against the bundle.
+	Offline bool `json:"offline"`
+}
+
+// ResolveWorkflowTool returns the MCP tool definition for resolve_workflow.
+func ResolveWorkflowTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "resolve_workflow",
+		Description: "Expand the workflowTemplateRef and every templateRef of a manifest, recursively and with cycle detection, into a single self-contained manifest. " +
+			"References are resolved against live WorkflowTemplates and ClusterWorkflowTemplates, or offline against a bundle of manifests. " +
+			"Returns the resolved manifest and a dependency tree showing which templates came from where.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+}
+
+// ResolveWorkflowHandler returns a handler function for the resolve_workflow tool.
+func ResolveWorkflowHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, ResolveWorkflowInput) (*mcp.CallToolResult, *ResolveWorkflowOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input ResolveWorkflowInput) (*mcp.CallToolResult, *ResolveWorkflowOutput, error) {
+		// Validate manifest is provided
+		if strings.TrimSpace(input.Manifest) == "" {
+			return nil, nil, fmt.Errorf("manifest cannot be empty")
+		}
+
+		// Guard against oversized manifests (DoS hardening)
+		const maxManifestBytes = 1 << 20 // 1 MiB
+		if len(input.Manifest) > maxManifestBytes {
+			return nil, nil, fmt.Errorf("manifest too large (%d bytes), max %d", len(input.Manifest), maxManifestBytes)
+		}
+
+		spec, kind, name, err := extractWorkflowSpec(input.Manifest)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		resolved, err := resolveManifest(ctx, client, ResolveNamespace(input.Namespace, client), input.Bundle, kind, name, spec)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		manifest, err := renderResolvedManifest(input.Manifest, kind, resolved.Spec)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		output := &ResolveWorkflowOutput{
+			Kind:              kind,
+			Name:              name,
+			Manifest:          manifest,
+			Dependencies:      resolved.Dependencies,
+			Cycles:            resolved.Cycles,
+			Warnings:          resolved.Warnings,
+			ImportedTemplates: resolved.Imported,
+			Offline:           strings.TrimSpace(input.Bundle) != "",
+		}
+
+		return TextResult(formatResolvedWorkflow(output)), output, nil
+	}
+}
+
+// formatResolvedWorkflow builds the human-readable resolution report.
+func formatResolvedWorkflow(output *ResolveWorkflowOutput) string {
+	var sb strings.Builder
+	source := "the cluster"
+	if output.Offline {
+		source = "the bundle"
+	}
+	fmt.Fprintf(&sb, "Resolved %s %q against %s: %d template(s) imported\n", output.Kind, output.Name, source, output.ImportedTemplates)
+
+	sb.WriteString("\nDependencies:\n")
+	for _, dep := range output.Dependencies {
+		sb.WriteString(strings.Repeat("  ", dep.Depth+1))
+		fmt.Fprintf(&sb, "%s/%s", dep.Kind, dep.Name)
+		if dep.Template != "" {
+			fmt.Fprintf(&sb, " template %q", dep.Template)
+		}
+		if dep.ResolvedAs != "" {
+			fmt.Fprintf(&sb, " -> %s", dep.ResolvedAs)
+		}
+		switch {
+		case dep.Cycle:
+			sb.WriteString(" (cycle)")
+		case dep.Reused:
+			sb.WriteString(" (reused)")
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(output.Cycles) > 0 {
+		sb.WriteString("\nReference cycles (kept as recursive calls):\n")
+		for _, cycle := range output.Cycles {
+			fmt.Fprintf(&sb, "  %s\n", cycle)
+		}
+	}
+	if len(output.Warnings) > 0 {
+		sb.WriteString("\nWarnings:\n")
+		for _, warning := range output.Warnings {
+			fmt.Fprintf(&sb, "  %s\n", warning)
+		}
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(output.Manifest)
+	return sb.String()
+}
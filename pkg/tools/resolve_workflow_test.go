@@ -0,0 +1,135 @@
+package tools
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+)
+
+const templateRefWorkflowManifest = `apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: app-
+  labels:
+    team: data
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    steps:
+    - - name: build
+        templateRef:
+          name: lib
+          template: build
+`
+
+func TestResolveWorkflowTool(t *testing.T) {
+	tool := ResolveWorkflowTool()
+
+	assert.Equal(t, "resolve_workflow", tool.Name)
+	assert.Contains(t, tool.Description, "templateRef")
+	require.NotNil(t, tool.Annotations)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+}
+
+func TestResolveWorkflowHandler(t *testing.T) {
+	tests := []struct {
+		setupMock func(*mocks.MockWorkflowTemplateServiceClient)
+		validate  func(*testing.T, *ResolveWorkflowOutput, *mcp.CallToolResult)
+		name      string
+		wantErr   string
+		input     ResolveWorkflowInput
+	}{
+		{
+			name:  "resolves offline against a bundle",
+			input: ResolveWorkflowInput{Manifest: templateRefWorkflowManifest, Bundle: resolverBundle},
+			validate: func(t *testing.T, output *ResolveWorkflowOutput, result *mcp.CallToolResult) {
+				assert.True(t, output.Offline)
+				assert.Equal(t, "Workflow", output.Kind)
+				assert.Equal(t, "app-", output.Name)
+				assert.Equal(t, 3, output.ImportedTemplates)
+				assert.Len(t, output.Dependencies, 4)
+
+				var wf wfv1.Workflow
+				require.NoError(t, yaml.UnmarshalStrict([]byte(output.Manifest), &wf))
+				assert.Equal(t, "data", wf.Labels["team"])
+				assert.Len(t, wf.Spec.Templates, 4)
+				assert.Equal(t, "lib-build", wf.Spec.Templates[0].Steps[0].Steps[0].Template)
+
+				text, ok := result.Content[0].(*mcp.TextContent)
+				require.True(t, ok)
+				assert.Contains(t, text.Text, "against the bundle: 3 template(s) imported")
+				assert.Contains(t, text.Text, `    WorkflowTemplate/lib template "build" -> lib-build`)
+			},
+		},
+		{
+			name:  "resolves against the cluster",
+			input: ResolveWorkflowInput{Namespace: "team-a", Manifest: templateRefWorkflowManifest},
+			setupMock: func(m *mocks.MockWorkflowTemplateServiceClient) {
+				m.On("GetWorkflowTemplate", mock.Anything, &workflowtemplate.WorkflowTemplateGetRequest{Name: "lib", Namespace: "team-a"}).
+					Return(&wfv1.WorkflowTemplate{Spec: wfv1.WorkflowSpec{
+						Templates: []wfv1.Template{{Name: "build", Container: &corev1.Container{Image: "golang:1.25"}}},
+					}}, nil)
+			},
+			validate: func(t *testing.T, output *ResolveWorkflowOutput, _ *mcp.CallToolResult) {
+				assert.False(t, output.Offline)
+				assert.Equal(t, 1, output.ImportedTemplates)
+				assert.Contains(t, output.Manifest, "golang:1.25")
+				assert.NotContains(t, output.Manifest, "templateRef")
+			},
+		},
+		{
+			name:  "lookup failure",
+			input: ResolveWorkflowInput{Manifest: templateRefWorkflowManifest},
+			setupMock: func(m *mocks.MockWorkflowTemplateServiceClient) {
+				m.On("GetWorkflowTemplate", mock.Anything, mock.Anything).Return(nil, errors.New("forbidden"))
+			},
+			wantErr: `failed to get WorkflowTemplate "lib"`,
+		},
+		{
+			name:    "invalid bundle",
+			input:   ResolveWorkflowInput{Manifest: templateRefWorkflowManifest, Bundle: "kind: CronWorkflow\nmetadata:\n  name: x\n"},
+			wantErr: "bundles may only contain",
+		},
+		{
+			name:    "empty manifest",
+			input:   ResolveWorkflowInput{},
+			wantErr: "manifest cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := newMockClient(t, "default", true)
+			wftService := newMockWorkflowTemplateService(t)
+			mockClient.SetWorkflowTemplateService(wftService)
+			if tt.setupMock != nil {
+				tt.setupMock(wftService)
+			}
+			defer wftService.AssertExpectations(t)
+
+			handler := ResolveWorkflowHandler(mockClient)
+			result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, tt.input)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, output)
+			tt.validate(t, output, result)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/logging"
+	wfutil "github.com/argoproj/argo-workflows/v4/workflow/util"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
+)
+
+// maxPreviewNodesShown caps the nodes listed per group in the preview text.
+const maxPreviewNodesShown = 20
+
+// RetryPreviewNode is a node in a retry preview.
+type RetryPreviewNode struct {
+	// ID is the node ID.
+	ID string `json:"id"`
+
+	// DisplayName is the node display name.
+	DisplayName string `json:"displayName"`
+
+	// TemplateName is the template the node runs.
+	TemplateName string `json:"templateName,omitempty"`
+
+	// Type is the node type.
+	Type string `json:"type"`
+
+	// Phase is the node phase before the retry.
+	Phase string `json:"phase"`
+}
+
+// RetryPreview describes what a retry would do to the nodes of a workflow.
+type RetryPreview struct {
+	// Rerun lists the nodes that are deleted and run again.
+	Rerun []RetryPreviewNode `json:"rerun"`
+
+	// Reset lists the nodes that are kept but set back to Running, such as
+	// the DAGs and step groups containing re-run nodes.
+	Reset []RetryPreviewNode `json:"reset"`
+
+	// Kept lists the nodes whose results are reused.
+	Kept []RetryPreviewNode `json:"kept"`
+
+	// PodsToDelete lists the pods that are deleted before the retry.
+	PodsToDelete []string `json:"podsToDelete,omitempty"`
+
+	// UnknownParameters lists overridden parameters that are not workflow arguments.
+	UnknownParameters []string `json:"unknownParameters,omitempty"`
+}
+
+// previewRetry runs Argo's retry logic on a copy of wf and compares the
+// nodes before and after, without changing anything in the cluster.
+func previewRetry(ctx context.Context, wf *wfv1.Workflow, restartSuccessful bool, selector string, parameters []string) (*RetryPreview, error) {
+	// The retry logic logs through the context and panics without a logger
+	if logging.GetLoggerFromContextOrNil(ctx) == nil {
+		ctx = logging.WithLogger(ctx, logging.NewSlogLoggerCustom(logging.Error, logging.Text, io.Discard))
+	}
+
+	original := wf.DeepCopy()
+	retried, podsToDelete, err := wfutil.FormulateRetryWorkflow(ctx, wf.DeepCopy(), restartSuccessful, selector, parameters)
+	if err != nil {
+		return nil, toolerror.New(toolerror.CategoryConflict, "NotRetryable", fmt.Errorf("workflow %q cannot be retried: %w", wf.Name, err)).
+			WithHint("only Failed or Errored workflows can be retried; retrying a Succeeded workflow needs restartSuccessful and a node selector")
+	}
+
+	preview := &RetryPreview{
+		Rerun:        []RetryPreviewNode{},
+		Reset:        []RetryPreviewNode{},
+		Kept:         []RetryPreviewNode{},
+		PodsToDelete: podsToDelete,
+	}
+	slices.Sort(preview.PodsToDelete)
+
+	ids := make([]string, 0, len(original.Status.Nodes))
+	for id := range original.Status.Nodes {
+		ids = append(ids, id)
+	}
+	slices.SortFunc(ids, func(a, b string) int {
+		return strings.Compare(original.Status.Nodes[a].Name, original.Status.Nodes[b].Name)
+	})
+
+	for _, id := range ids {
+		node := original.Status.Nodes[id]
+		previewNode := RetryPreviewNode{
+			ID:           id,
+			DisplayName:  node.DisplayName,
+			TemplateName: wfutil.GetTemplateFromNode(node),
+			Type:         string(node.Type),
+			Phase:        string(node.Phase),
+		}
+		after, getErr := retried.Status.Nodes.Get(id)
+		switch {
+		case getErr != nil:
+			preview.Rerun = append(preview.Rerun, previewNode)
+		case after.Phase == node.Phase:
+			preview.Kept = append(preview.Kept, previewNode)
+		default:
+			preview.Reset = append(preview.Reset, previewNode)
+		}
+	}
+
+	arguments := make(map[string]bool, len(original.Spec.Arguments.Parameters))
+	for _, param := range original.Spec.Arguments.Parameters {
+		arguments[param.Name] = true
+	}
+	for _, param := range parameters {
+		if key, _, _ := strings.Cut(param, "="); !arguments[key] {
+			preview.UnknownParameters = append(preview.UnknownParameters, key)
+		}
+	}
+
+	return preview, nil
+}
+
+// formatRetryPreview renders a retry preview for the workflow named name.
+func formatRetryPreview(name string, preview *RetryPreview) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "Retry preview for workflow %q (nothing was changed): %d node(s) re-run, %d reset, %d kept",
+		name, len(preview.Rerun), len(preview.Reset), len(preview.Kept))
+
+	writeNodes := func(title string, nodes []RetryPreviewNode) {
+		if len(nodes) == 0 {
+			return
+		}
+		fmt.Fprintf(&text, "\n%s:", title)
+		for i, node := range nodes {
+			if i == maxPreviewNodesShown {
+				fmt.Fprintf(&text, "\n  ... and %d more", len(nodes)-i)
+				break
+			}
+			fmt.Fprintf(&text, "\n  %s (%s, %s)", node.DisplayName, node.Type, node.Phase)
+		}
+	}
+	writeNodes("Re-run", preview.Rerun)
+	writeNodes("Reset to Running", preview.Reset)
+
+	if len(preview.PodsToDelete) > 0 {
+		fmt.Fprintf(&text, "\nPods to delete: %d", len(preview.PodsToDelete))
+	}
+	if len(preview.UnknownParameters) > 0 {
+		fmt.Fprintf(&text, "\nWarning: parameter(s) %s are not workflow arguments and will be added",
+			strings.Join(preview.UnknownParameters, ", "))
+	}
+	return text.String()
+}
@@ -0,0 +1,120 @@
+package tools
+
+import (
+	"testing"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
+)
+
+// failedDAGWorkflow is a failed DAG where extract succeeded and transform,
+// which depends on it, failed.
+func failedDAGWorkflow() *wfv1.Workflow {
+	return &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "etl", Namespace: "argo", UID: "etl-uid"},
+		Spec: wfv1.WorkflowSpec{
+			Entrypoint: "main",
+			Arguments:  wfv1.Arguments{Parameters: []wfv1.Parameter{{Name: "date", Value: wfv1.AnyStringPtr("2026-10-01")}}},
+		},
+		Status: wfv1.WorkflowStatus{
+			Phase: wfv1.WorkflowFailed,
+			Nodes: wfv1.Nodes{
+				"etl": {ID: "etl", Name: "etl", DisplayName: "etl", Type: wfv1.NodeTypeDAG, TemplateName: "main",
+					Phase: wfv1.NodeFailed, Children: []string{"etl-1"}},
+				"etl-1": {ID: "etl-1", Name: "etl.extract", DisplayName: "extract", Type: wfv1.NodeTypePod, TemplateName: "extract",
+					Phase: wfv1.NodeSucceeded, BoundaryID: "etl", Children: []string{"etl-2"}},
+				"etl-2": {ID: "etl-2", Name: "etl.transform", DisplayName: "transform", Type: wfv1.NodeTypePod, TemplateName: "transform",
+					Phase: wfv1.NodeFailed, BoundaryID: "etl"},
+			},
+		},
+	}
+}
+
+// previewNames returns the display names of preview nodes.
+func previewNames(nodes []RetryPreviewNode) []string {
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.DisplayName)
+	}
+	return names
+}
+
+func TestBuildNodeFieldSelector(t *testing.T) {
+	selector, err := buildNodeFieldSelector("", "", "")
+	require.NoError(t, err)
+	assert.Empty(t, selector)
+
+	selector, err = buildNodeFieldSelector(" phase=Succeeded ", "extract", "load")
+	require.NoError(t, err)
+	assert.Equal(t, "phase=Succeeded,displayName=extract,templateName=load", selector)
+
+	selector, err = buildNodeFieldSelector("", "a,b", "")
+	require.NoError(t, err)
+	assert.Equal(t, `displayName=a\,b`, selector)
+
+	_, err = buildNodeFieldSelector("displayName", "", "")
+	require.Error(t, err)
+	assert.Equal(t, "nodeFieldSelector", toolerror.Classify(err).Field)
+}
+
+func TestParseParameter(t *testing.T) {
+	key, value, err := parseParameter(" date =2026-10-02=x")
+	require.NoError(t, err)
+	assert.Equal(t, "date", key)
+	assert.Equal(t, "2026-10-02=x", value)
+
+	require.NoError(t, validateParameters([]string{"date=2026-10-02", "empty="}))
+
+	err = validateParameters([]string{"date"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid parameter format "date", expected key=value`)
+	assert.Equal(t, "parameters", toolerror.Classify(err).Field)
+
+	_, _, err = parseParameter(" =value")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "key cannot be empty")
+	assert.Equal(t, "parameters", toolerror.Classify(err).Field)
+}
+
+func TestPreviewRetry(t *testing.T) {
+	t.Run("failed nodes re-run", func(t *testing.T) {
+		wf := failedDAGWorkflow()
+		preview, err := previewRetry(t.Context(), wf, false, "", nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"transform"}, previewNames(preview.Rerun))
+		assert.Equal(t, []string{"etl"}, previewNames(preview.Reset))
+		assert.Equal(t, []string{"extract"}, previewNames(preview.Kept))
+		assert.Len(t, preview.PodsToDelete, 1)
+		assert.Equal(t, wfv1.NodeSucceeded, wf.Status.Nodes["etl-1"].Phase, "the workflow must not be changed")
+	})
+
+	t.Run("selected successful node re-runs with its subtree", func(t *testing.T) {
+		preview, err := previewRetry(t.Context(), failedDAGWorkflow(), true, "displayName=extract", []string{"date=2026-10-02", "region=eu"})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"extract", "transform"}, previewNames(preview.Rerun))
+		assert.Equal(t, []string{"etl"}, previewNames(preview.Reset))
+		assert.Empty(t, preview.Kept)
+		assert.Equal(t, []string{"region"}, preview.UnknownParameters)
+
+		text := formatRetryPreview("etl", preview)
+		assert.Contains(t, text, `Retry preview for workflow "etl" (nothing was changed): 2 node(s) re-run, 1 reset, 0 kept`)
+		assert.Contains(t, text, "Re-run:\n  extract (Pod, Succeeded)\n  transform (Pod, Failed)")
+		assert.Contains(t, text, "Reset to Running:\n  etl (DAG, Failed)")
+		assert.Contains(t, text, "Warning: parameter(s) region are not workflow arguments")
+	})
+
+	t.Run("running workflows cannot be retried", func(t *testing.T) {
+		wf := failedDAGWorkflow()
+		wf.Status.Phase = wfv1.WorkflowRunning
+		_, err := previewRetry(t.Context(), wf, false, "", nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `workflow "etl" cannot be retried`)
+		assert.Equal(t, toolerror.CategoryConflict, toolerror.Classify(err).Category)
+	})
+}
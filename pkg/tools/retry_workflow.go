@@ -17,9 +17,12 @@ import (
 type RetryWorkflowInput struct {
 	Namespace         string   `json:"namespace,omitempty" jsonschema:"Kubernetes namespace (uses default if not specified)"`
 	Name              string   `json:"name" jsonschema:"Workflow name,required"`
-	NodeFieldSelector string   `json:"nodeFieldSelector,omitempty" jsonschema:"Selector for nodes to restart (e.g. phase=Failed)"`
+	NodeFieldSelector string   `json:"nodeFieldSelector,omitempty" jsonschema:"Field selector for successful nodes to restart with restartSuccessful (e.g. displayName=build or templateName=test)"`
+	NodeName          string   `json:"nodeName,omitempty" jsonschema:"Restart the node with this display name and its subtree, even if it succeeded (implies restartSuccessful)"`
+	TemplateName      string   `json:"templateName,omitempty" jsonschema:"Restart the nodes running this template and their subtrees, even if they succeeded (implies restartSuccessful)"`
 	Parameters        []string `json:"parameters,omitempty" jsonschema:"Parameter overrides in key=value format"`
-	RestartSuccessful bool     `json:"restartSuccessful,omitempty" jsonschema:"Also restart successful nodes"`
+	RestartSuccessful bool     `json:"restartSuccessful,omitempty" jsonschema:"Also restart successful nodes matching nodeFieldSelector"`
+	Preview           bool     `json:"preview,omitempty" jsonschema:"Only show which nodes would be re-run, reset and kept, without retrying"`
 }
 
 // RetryWorkflowOutput defines the output for the retry_workflow tool.
@@ -38,13 +41,22 @@ type RetryWorkflowOutput struct {
 
 	// Message provides additional status information.
 	Message string `json:"message,omitempty"`
+
+	// NodeFieldSelector is the selector sent to the retry, including the
+	// node and template name shortcuts.
+	NodeFieldSelector string `json:"nodeFieldSelector,omitempty"`
+
+	// Preview describes what the retry would do. Set only for previews.
+	Preview *RetryPreview `json:"preview,omitempty"`
 }
 
 // RetryWorkflowTool returns the MCP tool definition for retry_workflow.
 func RetryWorkflowTool() *mcp.Tool {
 	return &mcp.Tool{
-		Name:        "retry_workflow",
-		Description: "Retry a failed Argo Workflow from the point of failure",
+		Name: "retry_workflow",
+		Description: "Retry a failed Argo Workflow from the point of failure. Failed nodes always re-run; " +
+			"nodeName, templateName or nodeFieldSelector also re-run selected successful nodes and their subtrees, " +
+			"and parameters override workflow arguments. Set preview to see which nodes would be re-run, reset and kept first.",
 		Annotations: &mcp.ToolAnnotations{
 			DestructiveHint: ptr.To(true),
 		},
@@ -54,27 +66,50 @@ func RetryWorkflowTool() *mcp.Tool {
 // RetryWorkflowHandler returns a handler function for the retry_workflow tool.
 func RetryWorkflowHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, RetryWorkflowInput) (*mcp.CallToolResult, *RetryWorkflowOutput, error) {
 	return func(ctx context.Context, _ *mcp.CallToolRequest, input RetryWorkflowInput) (*mcp.CallToolResult, *RetryWorkflowOutput, error) {
-		// Validate and normalize name
-		workflowName := strings.TrimSpace(input.Name)
-		if workflowName == "" {
-			return nil, nil, fmt.Errorf("workflow name cannot be empty")
+		workflowName, err := ValidateName(input.Name)
+		if err != nil {
+			return nil, nil, err
 		}
+		namespace := ResolveNamespace(input.Namespace, client)
 
-		// Determine namespace
-		namespace := strings.TrimSpace(input.Namespace)
-		if namespace == "" {
-			namespace = client.DefaultNamespace()
+		selector, err := buildNodeFieldSelector(input.NodeFieldSelector, input.NodeName, input.TemplateName)
+		if err != nil {
+			return nil, nil, err
+		}
+		restartSuccessful := input.RestartSuccessful || strings.TrimSpace(input.NodeName) != "" || strings.TrimSpace(input.TemplateName) != ""
+		if err = validateParameters(input.Parameters); err != nil {
+			return nil, nil, err
 		}
 
 		// Get the workflow service client
 		wfService := client.WorkflowService()
 
+		if input.Preview {
+			wf, getErr := wfService.GetWorkflow(ctx, &workflow.WorkflowGetRequest{Name: workflowName, Namespace: namespace})
+			if getErr != nil {
+				return nil, nil, fmt.Errorf("failed to get workflow: %w", getErr)
+			}
+			preview, previewErr := previewRetry(ctx, wf, restartSuccessful, selector, input.Parameters)
+			if previewErr != nil {
+				return nil, nil, previewErr
+			}
+			output := &RetryWorkflowOutput{
+				Name:              wf.Name,
+				Namespace:         namespace,
+				UID:               string(wf.UID),
+				Phase:             string(wf.Status.Phase),
+				NodeFieldSelector: selector,
+				Preview:           preview,
+			}
+			return TextResult(formatRetryPreview(wf.Name, preview)), output, nil
+		}
+
 		// Build the retry request
 		req := &workflow.WorkflowRetryRequest{
 			Name:              workflowName,
 			Namespace:         namespace,
-			RestartSuccessful: input.RestartSuccessful,
-			NodeFieldSelector: input.NodeFieldSelector,
+			RestartSuccessful: restartSuccessful,
+			NodeFieldSelector: selector,
 			Parameters:        input.Parameters,
 		}
 
@@ -86,11 +121,12 @@ func RetryWorkflowHandler(client argo.ClientInterface) func(context.Context, *mc
 
 		// Build the output
 		output := &RetryWorkflowOutput{
-			Name:      retriedWf.Name,
-			Namespace: retriedWf.Namespace,
-			UID:       string(retriedWf.UID),
-			Phase:     string(retriedWf.Status.Phase),
-			Message:   retriedWf.Status.Message,
+			Name:              retriedWf.Name,
+			Namespace:         retriedWf.Namespace,
+			UID:               string(retriedWf.UID),
+			Phase:             string(retriedWf.Status.Phase),
+			Message:           retriedWf.Status.Message,
+			NodeFieldSelector: selector,
 		}
 
 		// Set a default phase if empty
@@ -193,6 +193,76 @@ func TestRetryWorkflowHandler(t *testing.T) {
 				assert.Equal(t, "argo", output.Namespace)
 			},
 		},
+		{
+			name: "success - node name restarts a successful subtree",
+			input: RetryWorkflowInput{
+				Name:       "etl",
+				NodeName:   "extract",
+				Parameters: []string{"date=2026-10-02"},
+			},
+			setupMock: func(m *mocks.MockWorkflowServiceClient) {
+				m.On("RetryWorkflow", mock.Anything, &workflow.WorkflowRetryRequest{
+					Name:              "etl",
+					Namespace:         "argo",
+					RestartSuccessful: true,
+					NodeFieldSelector: "displayName=extract",
+					Parameters:        []string{"date=2026-10-02"},
+				}).Return(&wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "etl", Namespace: "argo"}}, nil)
+			},
+			wantErr:       false,
+			expectAPICall: true,
+			validate: func(t *testing.T, output *RetryWorkflowOutput, _ *mcp.CallToolResult) {
+				assert.Equal(t, "displayName=extract", output.NodeFieldSelector)
+				assert.Nil(t, output.Preview)
+			},
+		},
+		{
+			name: "success - preview does not retry",
+			input: RetryWorkflowInput{
+				Name:         "etl",
+				TemplateName: "extract",
+				Preview:      true,
+			},
+			setupMock: func(m *mocks.MockWorkflowServiceClient) {
+				m.On("GetWorkflow", mock.Anything, &workflow.WorkflowGetRequest{Name: "etl", Namespace: "argo"}).
+					Return(failedDAGWorkflow(), nil)
+			},
+			wantErr:       false,
+			expectAPICall: false,
+			validate: func(t *testing.T, output *RetryWorkflowOutput, result *mcp.CallToolResult) {
+				assert.Equal(t, "Failed", output.Phase)
+				assert.Equal(t, "templateName=extract", output.NodeFieldSelector)
+				require.NotNil(t, output.Preview)
+				assert.Equal(t, []string{"extract", "transform"}, previewNames(output.Preview.Rerun))
+				text, ok := result.Content[0].(*mcp.TextContent)
+				require.True(t, ok)
+				assert.Contains(t, text.Text, "Retry preview")
+			},
+		},
+		{
+			name: "error - invalid node field selector",
+			input: RetryWorkflowInput{
+				Name:              "etl",
+				NodeFieldSelector: "displayName",
+			},
+			setupMock: func(_ *mocks.MockWorkflowServiceClient) {
+				// No mock needed - should fail validation before API call
+			},
+			wantErr:       true,
+			expectAPICall: false,
+		},
+		{
+			name: "error - invalid parameter",
+			input: RetryWorkflowInput{
+				Name:       "etl",
+				Parameters: []string{"date"},
+			},
+			setupMock: func(_ *mocks.MockWorkflowServiceClient) {
+				// No mock needed - should fail validation before API call
+			},
+			wantErr:       true,
+			expectAPICall: false,
+		},
 		{
 			name: "error - empty name",
 			input: RetryWorkflowInput{
@@ -280,6 +350,9 @@ func TestRetryWorkflowHandler(t *testing.T) {
 			require.NoError(t, err)
 			require.NotNil(t, output)
 			tt.validate(t, output, result)
+			if !tt.expectAPICall {
+				mockService.AssertNotCalled(t, "RetryWorkflow", mock.Anything, mock.Anything)
+			}
 		})
 	}
 }
@@ -0,0 +1,581 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/lint"
+)
+
+// Scaffold defaults applied when a step or the input leaves them unset.
+const (
+	scaffoldEntrypoint       = "main"
+	scaffoldActiveDeadline   = time.Hour
+	scaffoldCPURequest       = "100m"
+	scaffoldMemoryRequest    = "128Mi"
+	scaffoldMemoryLimit      = "512Mi"
+	maxScaffoldSteps         = 200
+	maxScaffoldRetries       = 100
+	scaffoldArtifactSelector = "/"
+)
+
+// ScaffoldParameter describes a workflow parameter.
+type ScaffoldParameter struct {
+	// Value is the default value. Parameters without one must be set at submission.
+	Value *string `json:"value,omitempty" jsonschema:"Default value; omit to make the parameter required at submission"`
+
+	// Name is the parameter name.
+	Name string `json:"name" jsonschema:"Parameter name,required"`
+
+	// Description is the parameter description.
+	Description string `json:"description,omitempty" jsonschema:"Parameter description"`
+
+	// Enum lists the allowed values.
+	Enum []string `json:"enum,omitempty" jsonschema:"Allowed values"`
+}
+
+// ScaffoldOutput describes an output parameter or artifact of a step.
+type ScaffoldOutput struct {
+	// Name is the output name.
+	Name string `json:"name" jsonschema:"Output name,required"`
+
+	// Path is the file in the container the output is read from.
+	Path string `json:"path" jsonschema:"Path of the file in the container,required"`
+}
+
+// ScaffoldInputArtifact describes an artifact a step takes from another step.
+type ScaffoldInputArtifact struct {
+	// Name is the artifact name inside the step.
+	Name string `json:"name" jsonschema:"Artifact name,required"`
+
+	// Path is where the artifact is placed in the container.
+	Path string `json:"path" jsonschema:"Path the artifact is placed at in the container,required"`
+
+	// From is the producing step and its output artifact as step/artifact.
+	From string `json:"from" jsonschema:"Output artifact of another step as step/artifact,required"`
+}
+
+// ScaffoldResources sets the resources of a step's container.
+type ScaffoldResources struct {
+	// CPURequest is the CPU request (e.g. 100m).
+	CPURequest string `json:"cpuRequest,omitempty" jsonschema:"CPU request (e.g. 100m)"`
+
+	// MemoryRequest is the memory request (e.g. 128Mi).
+	MemoryRequest string `json:"memoryRequest,omitempty" jsonschema:"Memory request (e.g. 128Mi)"`
+
+	// CPULimit is the CPU limit.
+	CPULimit string `json:"cpuLimit,omitempty" jsonschema:"CPU limit (e.g. 1)"`
+
+	// MemoryLimit is the memory limit.
+	MemoryLimit string `json:"memoryLimit,omitempty" jsonschema:"Memory limit (e.g. 512Mi)"`
+}
+
+// ScaffoldStep describes a step of the pipeline.
+type ScaffoldStep struct {
+	// Resources sets the container resources.
+	Resources *ScaffoldResources `json:"resources,omitempty" jsonschema:"Container resources (default: 100m CPU and 128Mi memory requested, 512Mi memory limit)"`
+
+	// Env sets environment variables.
+	Env map[string]string `json:"env,omitempty" jsonschema:"Environment variables"`
+
+	// Inputs maps input parameter names to their values.
+	Inputs map[string]string `json:"inputs,omitempty" jsonschema:"Input parameters and their values, e.g. {{workflow.parameters.env}} or {{tasks.build.outputs.parameters.tag}}; use them as {{inputs.parameters.NAME}}"`
+
+	// Name is the step name.
+	Name string `json:"name" jsonschema:"Step name (lowercase letters, digits and dashes),required"`
+
+	// Image is the container image.
+	Image string `json:"image" jsonschema:"Container image, pinned to a tag or digest,required"`
+
+	// RetryPolicy selects which failures are retried.
+	RetryPolicy string `json:"retryPolicy,omitempty" jsonschema:"Retry policy: Always, OnFailure, OnError or OnTransientError (default OnFailure)"`
+
+	// Command is the container command.
+	Command []string `json:"command,omitempty" jsonschema:"Container command"`
+
+	// Args are the container arguments.
+	Args []string `json:"args,omitempty" jsonschema:"Container arguments"`
+
+	// Dependencies lists the steps that must succeed first.
+	Dependencies []string `json:"dependencies,omitempty" jsonschema:"Steps that must complete first; steps referenced through {{tasks.NAME...}} or artifacts are added automatically"`
+
+	// InputArtifacts are artifacts taken from other steps.
+	InputArtifacts []ScaffoldInputArtifact `json:"inputArtifacts,omitempty" jsonschema:"Artifacts taken from other steps"`
+
+	// OutputParameters are parameters read from files after the step.
+	OutputParameters []ScaffoldOutput `json:"outputParameters,omitempty" jsonschema:"Output parameters read from files"`
+
+	// OutputArtifacts are artifacts saved after the step.
+	OutputArtifacts []ScaffoldOutput `json:"outputArtifacts,omitempty" jsonschema:"Output artifacts saved from files or directories"`
+
+	// Retries is the retry limit.
+	Retries int `json:"retries,omitempty" jsonschema:"Number of retries on failure"`
+}
+
+// ScaffoldWorkflowInput defines the input parameters for the scaffold_workflow tool.
+type ScaffoldWorkflowInput struct {
+	// Labels are labels to add to the manifest.
+	Labels map[string]string `json:"labels,omitempty" jsonschema:"Labels to add to the manifest"`
+
+	// Kind is the kind of manifest to generate.
+	Kind string `json:"kind,omitempty" jsonschema:"Manifest kind: Workflow, WorkflowTemplate, ClusterWorkflowTemplate or CronWorkflow (default Workflow)"`
+
+	// Name is the manifest name, used as generateName prefix for Workflows.
+	Name string `json:"name" jsonschema:"Manifest name, used as the generateName prefix for a Workflow,required"`
+
+	// Namespace is the namespace to put in the manifest.
+	Namespace string `json:"namespace,omitempty" jsonschema:"Namespace to set in the manifest metadata"`
+
+	// ServiceAccountName is the service account the workflow runs as.
+	ServiceAccountName string `json:"serviceAccountName,omitempty" jsonschema:"Service account the workflow pods run as"`
+
+	// ActiveDeadline bounds the workflow duration.
+	ActiveDeadline string `json:"activeDeadline,omitempty" jsonschema:"Maximum workflow duration (e.g. 30m or 2h). Default: 1h"`
+
+	// Timezone is the time zone of the CronWorkflow schedules.
+	Timezone string `json:"timezone,omitempty" jsonschema:"Time zone of the schedules for a CronWorkflow (e.g. Europe/London)"`
+
+	// Schedules are the cron schedules of a CronWorkflow.
+	Schedules []string `json:"schedules,omitempty" jsonschema:"Cron schedules, required for a CronWorkflow"`
+
+	// Parameters are the workflow parameters.
+	Parameters []ScaffoldParameter `json:"parameters,omitempty" jsonschema:"Workflow parameters, referenced as {{workflow.parameters.NAME}}"`
+
+	// Steps are the steps of the pipeline.
+	Steps []ScaffoldStep `json:"steps" jsonschema:"Pipeline steps, run as a DAG ordered by their dependencies,required"`
+}
+
+// ScaffoldWorkflowOutput defines the output for the scaffold_workflow tool.
+type ScaffoldWorkflowOutput struct {
+	// Kind is the kind of the generated manifest.
+	Kind string `json:"kind"`
+
+	// Name is the manifest name.
+	Name string `json:"name"`
+
+	// Manifest is the generated YAML.
+	Manifest string `json:"manifest"`
+
+	// Findings are the best-practice findings for the manifest.
+	Findings []lint.Finding `json:"findings,omitempty"`
+
+	// Steps is the number of steps.
+	Steps int `json:"steps"`
+}
+
+// ScaffoldWorkflowTool returns the MCP tool definition for scaffold_workflow.
+func ScaffoldWorkflowTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "scaffold_workflow",
+		Description: "Generate a Workflow, WorkflowTemplate, ClusterWorkflowTemplate or CronWorkflow manifest from a structured list of steps " +
+			"with images, commands, parameters, dependencies, retries, artifacts and resources. " +
+			"Steps run as a DAG. The YAML is checked with the lint_best_practices rules; remaining warnings are returned as findings. " +
+			"Works offline, nothing is created in the cluster.",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+}
+
+// ScaffoldWorkflowHandler returns a handler function for the scaffold_workflow tool.
+// Note: This tool doesn't require the Argo client since it only generates YAML.
+func ScaffoldWorkflowHandler() func(context.Context, *mcp.CallToolRequest, ScaffoldWorkflowInput) (*mcp.CallToolResult, *ScaffoldWorkflowOutput, error) {
+	return func(_ context.Context, _ *mcp.CallToolRequest, input ScaffoldWorkflowInput) (*mcp.CallToolResult, *ScaffoldWorkflowOutput, error) {
+		name := strings.TrimSpace(input.Name)
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return nil, nil, fmt.Errorf("invalid name %q: %s", name, strings.Join(errs, "; "))
+		}
+
+		kind := input.Kind
+		if kind == "" {
+			kind = KindWorkflow
+		}
+		switch kind {
+		case KindWorkflow, KindWorkflowTemplate, KindClusterWorkflowTemplate:
+			if len(input.Schedules) > 0 || input.Timezone != "" {
+				return nil, nil, fmt.Errorf("schedules and timezone are only valid for a %s", KindCronWorkflow)
+			}
+		case KindCronWorkflow:
+			if len(input.Schedules) == 0 {
+				return nil, nil, fmt.Errorf("a %s needs at least one schedule", KindCronWorkflow)
+			}
+			if _, _, cronErr := parseCronSchedules(&wfv1.CronWorkflowSpec{Schedules: input.Schedules, Timezone: input.Timezone}); cronErr != nil {
+				return nil, nil, cronErr
+			}
+		default:
+			return nil, nil, fmt.Errorf("unsupported kind %q, must be %s, %s, %s or %s",
+				kind, KindWorkflow, KindWorkflowTemplate, KindClusterWorkflowTemplate, KindCronWorkflow)
+		}
+
+		for key, value := range input.Labels {
+			if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+				return nil, nil, fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, "; "))
+			}
+			if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+				return nil, nil, fmt.Errorf("invalid value for label %q: %s", key, strings.Join(errs, "; "))
+			}
+		}
+
+		deadline := scaffoldActiveDeadline
+		if input.ActiveDeadline != "" {
+			var err error
+			deadline, err = time.ParseDuration(input.ActiveDeadline)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid activeDeadline format: %w", err)
+			}
+			if deadline < time.Second {
+				return nil, nil, fmt.Errorf("invalid activeDeadline: must be at least 1s")
+			}
+		}
+
+		spec, err := scaffoldSpec(input)
+		if err != nil {
+			return nil, nil, err
+		}
+		spec.ServiceAccountName = input.ServiceAccountName
+		spec.ActiveDeadlineSeconds = ptr.To(int64(deadline / time.Second))
+
+		manifest, err := scaffoldManifest(kind, name, input, spec)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// The generated spec must at least pass the offline rules at error severity
+		findings := lint.NewEngine().Lint(lint.NewDocument(kind, name, spec))
+		var errs []string
+		for _, finding := range findings {
+			if finding.Severity == lint.SeverityError {
+				errs = append(errs, fmt.Sprintf("%s: %s", finding.Rule, finding.Message))
+			}
+		}
+		if len(errs) > 0 {
+			return nil, nil, fmt.Errorf("the described workflow is invalid: %s", strings.Join(errs, "; "))
+		}
+
+		output := &ScaffoldWorkflowOutput{
+			Kind:     kind,
+			Name:     name,
+			Manifest: manifest,
+			Findings: findings,
+			Steps:    len(input.Steps),
+		}
+
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "Scaffolded %s %q with %d step(s)", kind, name, output.Steps)
+		for _, finding := range findings {
+			fmt.Fprintf(&sb, "\n  [%s] %s: %s", finding.Severity, finding.Rule, finding.Message)
+		}
+		fmt.Fprintf(&sb, "\n\n%s", manifest)
+		return TextResult(sb.String()), output, nil
+	}
+}
+
+// scaffoldSpec builds a workflow spec running the steps as a DAG.
+func scaffoldSpec(input ScaffoldWorkflowInput) (*wfv1.WorkflowSpec, error) {
+	if len(input.Steps) == 0 {
+		return nil, fmt.Errorf("at least one step is required")
+	}
+	if len(input.Steps) > maxScaffoldSteps {
+		return nil, fmt.Errorf("too many steps (%d), max %d", len(input.Steps), maxScaffoldSteps)
+	}
+
+	spec := &wfv1.WorkflowSpec{Entrypoint: scaffoldEntrypoint}
+
+	for _, param := range input.Parameters {
+		if strings.TrimSpace(param.Name) == "" {
+			return nil, fmt.Errorf("workflow parameter names cannot be empty")
+		}
+		if slices.ContainsFunc(spec.Arguments.Parameters, func(p wfv1.Parameter) bool { return p.Name == param.Name }) {
+			return nil, fmt.Errorf("duplicate workflow parameter %q", param.Name)
+		}
+		if param.Value != nil && len(param.Enum) > 0 && !slices.Contains(param.Enum, *param.Value) {
+			return nil, fmt.Errorf("value %q of workflow parameter %q is not one of its enum values", *param.Value, param.Name)
+		}
+		p := wfv1.Parameter{Name: param.Name}
+		if param.Value != nil {
+			p.Value = wfv1.AnyStringPtr(*param.Value)
+		}
+		if param.Description != "" {
+			p.Description = wfv1.AnyStringPtr(param.Description)
+		}
+		for _, value := range param.Enum {
+			p.Enum = append(p.Enum, wfv1.AnyString(value))
+		}
+		spec.Arguments.Parameters = append(spec.Arguments.Parameters, p)
+	}
+
+	steps := make(map[string]*ScaffoldStep, len(input.Steps))
+	for i := range input.Steps {
+		step := &input.Steps[i]
+		if errs := validation.IsDNS1123Label(step.Name); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid step name %q: %s", step.Name, strings.Join(errs, "; "))
+		}
+		if step.Name == scaffoldEntrypoint {
+			return nil, fmt.Errorf("step name %q is reserved for the entrypoint", scaffoldEntrypoint)
+		}
+		if _, ok := steps[step.Name]; ok {
+			return nil, fmt.Errorf("duplicate step %q", step.Name)
+		}
+		steps[step.Name] = step
+	}
+
+	dag := &wfv1.DAGTemplate{}
+	for i := range input.Steps {
+		step := &input.Steps[i]
+		tmpl, err := scaffoldTemplate(step)
+		if err != nil {
+			return nil, fmt.Errorf("step %q: %w", step.Name, err)
+		}
+		task, err := scaffoldTask(step, steps)
+		if err != nil {
+			return nil, fmt.Errorf("step %q: %w", step.Name, err)
+		}
+		spec.Templates = append(spec.Templates, *tmpl)
+		dag.Tasks = append(dag.Tasks, *task)
+	}
+	spec.Templates = slices.Insert(spec.Templates, 0, wfv1.Template{Name: scaffoldEntrypoint, DAG: dag})
+	return spec, nil
+}
+
+// scaffoldTemplate builds the container template of a step.
+func scaffoldTemplate(step *ScaffoldStep) (*wfv1.Template, error) {
+	if strings.TrimSpace(step.Image) == "" {
+		return nil, fmt.Errorf("image is required")
+	}
+
+	resources, err := scaffoldResources(step.Resources)
+	if err != nil {
+		return nil, err
+	}
+
+	container := &corev1.Container{
+		Image:     step.Image,
+		Command:   step.Command,
+		Args:      step.Args,
+		Resources: resources,
+	}
+	for _, key := range slices.Sorted(maps.Keys(step.Env)) {
+		if errs := validation.IsEnvVarName(key); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid environment variable %q: %s", key, strings.Join(errs, "; "))
+		}
+		container.Env = append(container.Env, corev1.EnvVar{Name: key, Value: step.Env[key]})
+	}
+
+	tmpl := &wfv1.Template{Name: step.Name, Container: container}
+	for _, key := range slices.Sorted(maps.Keys(step.Inputs)) {
+		tmpl.Inputs.Parameters = append(tmpl.Inputs.Parameters, wfv1.Parameter{Name: key})
+	}
+	for _, artifact := range step.InputArtifacts {
+		if artifact.Name == "" || artifact.Path == "" {
+			return nil, fmt.Errorf("input artifacts need a name and a path")
+		}
+		tmpl.Inputs.Artifacts = append(tmpl.Inputs.Artifacts, wfv1.Artifact{Name: artifact.Name, Path: artifact.Path})
+	}
+	for _, output := range step.OutputParameters {
+		if output.Name == "" || output.Path == "" {
+			return nil, fmt.Errorf("output parameters need a name and a path")
+		}
+		tmpl.Outputs.Parameters = append(tmpl.Outputs.Parameters, wfv1.Parameter{
+			Name:      output.Name,
+			ValueFrom: &wfv1.ValueFrom{Path: output.Path},
+		})
+	}
+	for _, output := range step.OutputArtifacts {
+		if output.Name == "" || output.Path == "" {
+			return nil, fmt.Errorf("output artifacts need a name and a path")
+		}
+		tmpl.Outputs.Artifacts = append(tmpl.Outputs.Artifacts, wfv1.Artifact{Name: output.Name, Path: output.Path})
+	}
+
+	if step.Retries < 0 || step.Retries > maxScaffoldRetries {
+		return nil, fmt.Errorf("retries must be between 0 and %d", maxScaffoldRetries)
+	}
+	if step.RetryPolicy != "" && step.Retries == 0 {
+		return nil, fmt.Errorf("retryPolicy requires retries")
+	}
+	if step.Retries > 0 {
+		policy := wfv1.RetryPolicy(step.RetryPolicy)
+		switch policy {
+		case "":
+			policy = wfv1.RetryPolicyOnFailure
+		case wfv1.RetryPolicyAlways, wfv1.RetryPolicyOnFailure, wfv1.RetryPolicyOnError, wfv1.RetryPolicyOnTransientError:
+		default:
+			return nil, fmt.Errorf("invalid retryPolicy %q, must be Always, OnFailure, OnError or OnTransientError", step.RetryPolicy)
+		}
+		limit := intstr.Parse(strconv.Itoa(step.Retries))
+		tmpl.RetryStrategy = &wfv1.RetryStrategy{Limit: &limit, RetryPolicy: policy}
+	}
+	return tmpl, nil
+}
+
+// scaffoldResources parses the resources of a step, applying the defaults
+// when none are given.
+func scaffoldResources(res *ScaffoldResources) (corev1.ResourceRequirements, error) {
+	if res == nil {
+		res = &ScaffoldResources{
+			CPURequest:    scaffoldCPURequest,
+			MemoryRequest: scaffoldMemoryRequest,
+			MemoryLimit:   scaffoldMemoryLimit,
+		}
+	}
+
+	requirements := corev1.ResourceRequirements{}
+	quantities := []struct {
+		list  *corev1.ResourceList
+		name  corev1.ResourceName
+		value string
+		field string
+	}{
+		{&requirements.Requests, corev1.ResourceCPU, res.CPURequest, "cpuRequest"},
+		{&requirements.Requests, corev1.ResourceMemory, res.MemoryRequest, "memoryRequest"},
+		{&requirements.Limits, corev1.ResourceCPU, res.CPULimit, "cpuLimit"},
+		{&requirements.Limits, corev1.ResourceMemory, res.MemoryLimit, "memoryLimit"},
+	}
+	for _, q := range quantities {
+		if q.value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(q.value)
+		if err != nil {
+			return requirements, fmt.Errorf("invalid %s %q: %w", q.field, q.value, err)
+		}
+		if *q.list == nil {
+			*q.list = corev1.ResourceList{}
+		}
+		(*q.list)[q.name] = quantity
+	}
+	return requirements, nil
+}
+
+// taskReference matches references to the outputs of another DAG task.
+var taskReference = regexp.MustCompile(`\{\{\s*tasks\.([a-zA-Z0-9-]+)\.`)
+
+// scaffoldTask builds the DAG task running a step. References to other
+// tasks in input values and artifacts become dependencies.
+func scaffoldTask(step *ScaffoldStep, steps map[string]*ScaffoldStep) (*wfv1.DAGTask, error) {
+	task := &wfv1.DAGTask{Name: step.Name, Template: step.Name}
+	deps := make(map[string]bool)
+	for _, dep := range step.Dependencies {
+		deps[dep] = true
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(step.Inputs)) {
+		value := step.Inputs[key]
+		task.Arguments.Parameters = append(task.Arguments.Parameters, wfv1.Parameter{Name: key, Value: wfv1.AnyStringPtr(value)})
+		for _, match := range taskReference.FindAllStringSubmatch(value, -1) {
+			deps[match[1]] = true
+		}
+	}
+
+	for _, artifact := range step.InputArtifacts {
+		from, output, ok := strings.Cut(artifact.From, scaffoldArtifactSelector)
+		if !ok || from == "" || output == "" {
+			return nil, fmt.Errorf("input artifact %q: from must be step/artifact, got %q", artifact.Name, artifact.From)
+		}
+		producer, ok := steps[from]
+		if !ok {
+			return nil, fmt.Errorf("input artifact %q: unknown step %q", artifact.Name, from)
+		}
+		if !slices.ContainsFunc(producer.OutputArtifacts, func(o ScaffoldOutput) bool { return o.Name == output }) {
+			return nil, fmt.Errorf("input artifact %q: step %q has no output artifact %q", artifact.Name, from, output)
+		}
+		task.Arguments.Artifacts = append(task.Arguments.Artifacts, wfv1.Artifact{
+			Name: artifact.Name,
+			From: fmt.Sprintf("{{tasks.%s.outputs.artifacts.%s}}", from, output),
+		})
+		deps[from] = true
+	}
+
+	for _, dep := range slices.Sorted(maps.Keys(deps)) {
+		if dep == step.Name {
+			return nil, fmt.Errorf("cannot depend on itself")
+		}
+		if _, ok := steps[dep]; !ok {
+			return nil, fmt.Errorf("unknown dependency %q", dep)
+		}
+		task.Dependencies = append(task.Dependencies, dep)
+	}
+	return task, nil
+}
+
+// scaffoldManifest wraps spec in a manifest of kind and renders it as YAML,
+// leaving out empty fields.
+func scaffoldManifest(kind, name string, input ScaffoldWorkflowInput, spec *wfv1.WorkflowSpec) (string, error) {
+	meta := metav1.ObjectMeta{Name: name, Namespace: input.Namespace, Labels: input.Labels}
+	typeMeta := metav1.TypeMeta{APIVersion: wfv1.SchemeGroupVersion.String(), Kind: kind}
+
+	var obj any
+	switch kind {
+	case KindWorkflow:
+		meta.Name = ""
+		meta.GenerateName = name + "-"
+		obj = &wfv1.Workflow{TypeMeta: typeMeta, ObjectMeta: meta, Spec: *spec}
+	case KindWorkflowTemplate:
+		obj = &wfv1.WorkflowTemplate{TypeMeta: typeMeta, ObjectMeta: meta, Spec: *spec}
+	case KindClusterWorkflowTemplate:
+		meta.Namespace = ""
+		obj = &wfv1.ClusterWorkflowTemplate{TypeMeta: typeMeta, ObjectMeta: meta, Spec: *spec}
+	default:
+		obj = &wfv1.CronWorkflow{TypeMeta: typeMeta, ObjectMeta: meta, Spec: wfv1.CronWorkflowSpec{
+			Schedules:    input.Schedules,
+			Timezone:     input.Timezone,
+			WorkflowSpec: *spec,
+		}}
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize manifest: %w", err)
+	}
+	var fields map[string]any
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return "", fmt.Errorf("failed to serialize manifest: %w", err)
+	}
+	delete(fields, "status")
+	pruneEmpty(fields)
+
+	out, err := yaml.Marshal(fields)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize manifest to YAML: %w", err)
+	}
+	return string(out), nil
+}
+
+// pruneEmpty removes null values and empty objects from fields, recursively.
+func pruneEmpty(fields map[string]any) {
+	for key, value := range fields {
+		switch v := value.(type) {
+		case nil:
+			delete(fields, key)
+		case map[string]any:
+			pruneEmpty(v)
+			if len(v) == 0 {
+				delete(fields, key)
+			}
+		case []any:
+			for _, item := range v {
+				if m, ok := item.(map[string]any); ok {
+					pruneEmpty(m)
+				}
+			}
+		}
+	}
+}
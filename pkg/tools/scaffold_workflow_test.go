@@ -0,0 +1,272 @@
+package tools
+
+import (
+	"testing"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/yaml"
+)
+
+// pipelineSteps builds, tests and publishes an image, passing a parameter
+// and an artifact between the steps.
+func pipelineSteps() []ScaffoldStep {
+	return []ScaffoldStep{
+		{
+			Name:             "build",
+			Image:            "golang:1.25",
+			Command:          []string{"sh", "-c"},
+			Args:             []string{"echo building for {{inputs.parameters.env}} && go build -o /out/app ./... && git rev-parse HEAD > /tmp/sha"},
+			Env:              map[string]string{"CGO_ENABLED": "0", "GOOS": "linux"},
+			Inputs:           map[string]string{"env": "{{workflow.parameters.env}}"},
+			OutputParameters: []ScaffoldOutput{{Name: "sha", Path: "/tmp/sha"}},
+			OutputArtifacts:  []ScaffoldOutput{{Name: "binary", Path: "/out/app"}},
+			Retries:          2,
+		},
+		{
+			Name:           "test",
+			Image:          "golang:1.25",
+			Command:        []string{"/work/app", "--self-test"},
+			InputArtifacts: []ScaffoldInputArtifact{{Name: "app", Path: "/work/app", From: "build/binary"}},
+			Resources:      &ScaffoldResources{CPURequest: "500m", MemoryRequest: "256Mi", CPULimit: "1", MemoryLimit: "1Gi"},
+		},
+		{
+			Name:         "publish",
+			Image:        "alpine:3.19",
+			Command:      []string{"echo", "{{inputs.parameters.sha}}"},
+			Inputs:       map[string]string{"sha": "{{tasks.build.outputs.parameters.sha}}"},
+			Dependencies: []string{"test"},
+			Retries:      3,
+			RetryPolicy:  "Always",
+		},
+	}
+}
+
+func TestScaffoldWorkflowTool(t *testing.T) {
+	tool := ScaffoldWorkflowTool()
+
+	assert.Equal(t, "scaffold_workflow", tool.Name)
+	assert.Contains(t, tool.Description, "lint_best_practices")
+	require.NotNil(t, tool.Annotations)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+}
+
+func TestScaffoldWorkflowHandler(t *testing.T) {
+	tests := []struct {
+		validate func(*testing.T, *ScaffoldWorkflowOutput, *mcp.CallToolResult)
+		name     string
+		wantErr  string
+		input    ScaffoldWorkflowInput
+	}{
+		{
+			name: "workflow with parameters, artifacts and retries",
+			input: ScaffoldWorkflowInput{
+				Name:       "ci",
+				Namespace:  "builds",
+				Labels:     map[string]string{"team": "platform"},
+				Parameters: []ScaffoldParameter{{Name: "env", Value: ptr.To("dev"), Enum: []string{"dev", "prod"}, Description: "Target"}},
+				Steps:      pipelineSteps(),
+			},
+			validate: func(t *testing.T, output *ScaffoldWorkflowOutput, result *mcp.CallToolResult) {
+				assert.Equal(t, "Workflow", output.Kind)
+				assert.Equal(t, 3, output.Steps)
+				assert.Empty(t, output.Findings)
+
+				var wf wfv1.Workflow
+				require.NoError(t, yaml.UnmarshalStrict([]byte(output.Manifest), &wf))
+				assert.Equal(t, "ci-", wf.GenerateName)
+				assert.Equal(t, "builds", wf.Namespace)
+				assert.Equal(t, "platform", wf.Labels["team"])
+				assert.Equal(t, "main", wf.Spec.Entrypoint)
+				assert.Equal(t, int64(3600), *wf.Spec.ActiveDeadlineSeconds)
+				assert.Equal(t, "dev", wf.Spec.Arguments.Parameters[0].Value.String())
+
+				require.Len(t, wf.Spec.Templates, 4)
+				tasks := wf.Spec.Templates[0].DAG.Tasks
+				assert.Empty(t, tasks[0].Dependencies)
+				assert.Equal(t, []string{"build"}, tasks[1].Dependencies)
+				assert.Equal(t, "{{tasks.build.outputs.artifacts.binary}}", tasks[1].Arguments.Artifacts[0].From)
+				assert.Equal(t, []string{"build", "test"}, tasks[2].Dependencies)
+				assert.Equal(t, "{{tasks.build.outputs.parameters.sha}}", tasks[2].Arguments.Parameters[0].Value.String())
+
+				build := wf.Spec.Templates[1]
+				assert.Equal(t, "CGO_ENABLED", build.Container.Env[0].Name)
+				assert.Equal(t, "100m", build.Container.Resources.Requests.Cpu().String())
+				assert.Equal(t, "512Mi", build.Container.Resources.Limits.Memory().String())
+				assert.Equal(t, "2", build.RetryStrategy.Limit.String())
+				assert.Equal(t, wfv1.RetryPolicyOnFailure, build.RetryStrategy.RetryPolicy)
+				assert.Equal(t, "/tmp/sha", build.Outputs.Parameters[0].ValueFrom.Path)
+
+				test := wf.Spec.Templates[2]
+				assert.Equal(t, "1Gi", test.Container.Resources.Limits.Memory().String())
+				assert.Nil(t, test.RetryStrategy)
+				assert.Equal(t, wfv1.RetryPolicyAlways, wf.Spec.Templates[3].RetryStrategy.RetryPolicy)
+
+				// Empty fields are left out
+				assert.NotContains(t, output.Manifest, "status")
+				assert.NotContains(t, output.Manifest, "creationTimestamp")
+				assert.NotContains(t, output.Manifest, "{}")
+
+				text, ok := result.Content[0].(*mcp.TextContent)
+				require.True(t, ok)
+				assert.Contains(t, text.Text, `Scaffolded Workflow "ci" with 3 step(s)`)
+				assert.Contains(t, text.Text, "generateName: ci-")
+			},
+		},
+		{
+			name: "cron workflow",
+			input: ScaffoldWorkflowInput{
+				Kind:           KindCronWorkflow,
+				Name:           "nightly",
+				Schedules:      []string{"0 2 * * *"},
+				Timezone:       "Europe/London",
+				ActiveDeadline: "30m",
+				Steps:          []ScaffoldStep{{Name: "report", Image: "alpine:3.19", Command: []string{"date"}}},
+			},
+			validate: func(t *testing.T, output *ScaffoldWorkflowOutput, _ *mcp.CallToolResult) {
+				var cron wfv1.CronWorkflow
+				require.NoError(t, yaml.UnmarshalStrict([]byte(output.Manifest), &cron))
+				assert.Equal(t, "nightly", cron.Name)
+				assert.Equal(t, []string{"0 2 * * *"}, cron.Spec.Schedules)
+				assert.Equal(t, "Europe/London", cron.Spec.Timezone)
+				assert.Equal(t, int64(1800), *cron.Spec.WorkflowSpec.ActiveDeadlineSeconds)
+			},
+		},
+		{
+			name: "cluster workflow template reports best-practice findings",
+			input: ScaffoldWorkflowInput{
+				Kind:       KindClusterWorkflowTemplate,
+				Name:       "shared",
+				Namespace:  "ignored",
+				Parameters: []ScaffoldParameter{{Name: "unused"}},
+				Steps:      []ScaffoldStep{{Name: "run", Image: "alpine"}},
+			},
+			validate: func(t *testing.T, output *ScaffoldWorkflowOutput, result *mcp.CallToolResult) {
+				var cwft wfv1.ClusterWorkflowTemplate
+				require.NoError(t, yaml.UnmarshalStrict([]byte(output.Manifest), &cwft))
+				assert.Empty(t, cwft.Namespace)
+				assert.Nil(t, cwft.Spec.Arguments.Parameters[0].Value)
+
+				rules := make([]string, 0, len(output.Findings))
+				for _, finding := range output.Findings {
+					rules = append(rules, finding.Rule)
+				}
+				assert.ElementsMatch(t, []string{"latest-image-tag", "unused-parameter"}, rules)
+
+				text, ok := result.Content[0].(*mcp.TextContent)
+				require.True(t, ok)
+				assert.Contains(t, text.Text, "[warning] latest-image-tag")
+			},
+		},
+		{
+			name: "undefined input reference",
+			input: ScaffoldWorkflowInput{Name: "wf", Steps: []ScaffoldStep{
+				{Name: "a", Image: "alpine:3.19", Command: []string{"echo", "{{inputs.parameters.missing}}"}},
+			}},
+			wantErr: "the described workflow is invalid: undefined-input-reference",
+		},
+		{
+			name: "dependency cycle",
+			input: ScaffoldWorkflowInput{Name: "wf", Steps: []ScaffoldStep{
+				{Name: "a", Image: "alpine:3.19", Dependencies: []string{"b"}},
+				{Name: "b", Image: "alpine:3.19", Dependencies: []string{"a"}},
+			}},
+			wantErr: "dag-cycle",
+		},
+		{
+			name: "unknown dependency",
+			input: ScaffoldWorkflowInput{Name: "wf", Steps: []ScaffoldStep{
+				{Name: "a", Image: "alpine:3.19", Inputs: map[string]string{"x": "{{tasks.ghost.outputs.result}}"}},
+			}},
+			wantErr: `step "a": unknown dependency "ghost"`,
+		},
+		{
+			name: "missing output artifact",
+			input: ScaffoldWorkflowInput{Name: "wf", Steps: []ScaffoldStep{
+				{Name: "a", Image: "alpine:3.19"},
+				{Name: "b", Image: "alpine:3.19", InputArtifacts: []ScaffoldInputArtifact{{Name: "x", Path: "/x", From: "a/out"}}},
+			}},
+			wantErr: `step "a" has no output artifact "out"`,
+		},
+		{
+			name:    "duplicate step",
+			input:   ScaffoldWorkflowInput{Name: "wf", Steps: []ScaffoldStep{{Name: "a", Image: "x:1"}, {Name: "a", Image: "x:1"}}},
+			wantErr: `duplicate step "a"`,
+		},
+		{
+			name:    "reserved step name",
+			input:   ScaffoldWorkflowInput{Name: "wf", Steps: []ScaffoldStep{{Name: "main", Image: "x:1"}}},
+			wantErr: "reserved for the entrypoint",
+		},
+		{
+			name:    "invalid quantity",
+			input:   ScaffoldWorkflowInput{Name: "wf", Steps: []ScaffoldStep{{Name: "a", Image: "x:1", Resources: &ScaffoldResources{MemoryLimit: "lots"}}}},
+			wantErr: `invalid memoryLimit "lots"`,
+		},
+		{
+			name:    "invalid retry policy",
+			input:   ScaffoldWorkflowInput{Name: "wf", Steps: []ScaffoldStep{{Name: "a", Image: "x:1", Retries: 1, RetryPolicy: "Sometimes"}}},
+			wantErr: `invalid retryPolicy "Sometimes"`,
+		},
+		{
+			name:    "missing image",
+			input:   ScaffoldWorkflowInput{Name: "wf", Steps: []ScaffoldStep{{Name: "a"}}},
+			wantErr: "image is required",
+		},
+		{
+			name:    "enum default outside enum",
+			input:   ScaffoldWorkflowInput{Name: "wf", Parameters: []ScaffoldParameter{{Name: "p", Value: ptr.To("c"), Enum: []string{"a", "b"}}}, Steps: []ScaffoldStep{{Name: "a", Image: "x:1"}}},
+			wantErr: "not one of its enum values",
+		},
+		{
+			name:    "cron workflow without schedule",
+			input:   ScaffoldWorkflowInput{Kind: KindCronWorkflow, Name: "wf", Steps: []ScaffoldStep{{Name: "a", Image: "x:1"}}},
+			wantErr: "needs at least one schedule",
+		},
+		{
+			name:    "invalid schedule",
+			input:   ScaffoldWorkflowInput{Kind: KindCronWorkflow, Name: "wf", Schedules: []string{"every day"}, Steps: []ScaffoldStep{{Name: "a", Image: "x:1"}}},
+			wantErr: `invalid schedule "every day"`,
+		},
+		{
+			name:    "schedule on a workflow",
+			input:   ScaffoldWorkflowInput{Name: "wf", Schedules: []string{"0 * * * *"}, Steps: []ScaffoldStep{{Name: "a", Image: "x:1"}}},
+			wantErr: "only valid for a CronWorkflow",
+		},
+		{
+			name:    "unsupported kind",
+			input:   ScaffoldWorkflowInput{Kind: "Pod", Name: "wf", Steps: []ScaffoldStep{{Name: "a", Image: "x:1"}}},
+			wantErr: `unsupported kind "Pod"`,
+		},
+		{
+			name:    "no steps",
+			input:   ScaffoldWorkflowInput{Name: "wf"},
+			wantErr: "at least one step is required",
+		},
+		{
+			name:    "invalid name",
+			input:   ScaffoldWorkflowInput{Name: "My Workflow"},
+			wantErr: `invalid name "My Workflow"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := ScaffoldWorkflowHandler()
+			result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, tt.input)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, output)
+			tt.validate(t, output, result)
+		})
+	}
+}
@@ -0,0 +1,308 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/event"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/util/expr/argoexpr"
+	jsonutil "github.com/argoproj/argo-workflows/v4/util/json"
+	"github.com/argoproj/argo-workflows/v4/workflow/common"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
+)
+
+const (
+	// defaultEventWait is how long send_workflow_event waits for submitted workflows by default.
+	defaultEventWait = 10 * time.Second
+
+	// maxEventWait caps how long send_workflow_event waits for submitted workflows.
+	maxEventWait = time.Minute
+
+	// eventPollInterval is how often submitted workflows are looked up.
+	eventPollInterval = time.Second
+)
+
+// SendWorkflowEventInput defines the input parameters for the send_workflow_event tool.
+type SendWorkflowEventInput struct {
+	// Payload is the JSON event payload.
+	Payload any `json:"payload,omitempty" jsonschema:"JSON event payload, available to binding selectors and parameters as 'payload'"`
+
+	// Namespace is the Kubernetes namespace (uses default if not specified).
+	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace whose bindings receive the event (uses default if not specified)"`
+
+	// Discriminator is passed to binding selectors as 'discriminator'.
+	Discriminator string `json:"discriminator,omitempty" jsonschema:"Optional discriminator, available to binding selectors as 'discriminator'"`
+
+	// Wait is how long to wait for submitted workflows.
+	Wait string `json:"wait,omitempty" jsonschema:"How long to wait for submitted workflows to appear (e.g. 10s, max 1m, 0s to not wait). Default: 10s"`
+}
+
+// EventBindingMatch is the evaluation of a binding's selector against an event.
+type EventBindingMatch struct {
+	// Name is the binding name.
+	Name string `json:"name"`
+
+	// WorkflowTemplate is the template the binding submits.
+	WorkflowTemplate string `json:"workflowTemplate,omitempty"`
+
+	// Error is set when the selector could not be evaluated.
+	Error string `json:"error,omitempty"`
+
+	// Matched is true when the selector matched the event.
+	Matched bool `json:"matched"`
+}
+
+// SubmittedWorkflow is a workflow submitted by a binding in response to an event.
+type SubmittedWorkflow struct {
+	// Name is the workflow name.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the workflow.
+	Namespace string `json:"namespace"`
+
+	// Binding is the binding that submitted the workflow.
+	Binding string `json:"binding"`
+
+	// Phase is the workflow phase.
+	Phase string `json:"phase,omitempty"`
+}
+
+// SendWorkflowEventOutput defines the output for the send_workflow_event tool.
+type SendWorkflowEventOutput struct {
+	// Namespace is the namespace the event was sent to.
+	Namespace string `json:"namespace"`
+
+	// Discriminator is the event discriminator.
+	Discriminator string `json:"discriminator,omitempty"`
+
+	// Bindings lists the evaluation of every binding in the namespace.
+	Bindings []EventBindingMatch `json:"bindings"`
+
+	// Matched lists the names of the bindings whose selector matched.
+	Matched []string `json:"matched"`
+
+	// Workflows lists the workflows submitted by the matched bindings.
+	Workflows []SubmittedWorkflow `json:"workflows"`
+
+	// Pending lists matched bindings for which no workflow was seen while waiting.
+	Pending []string `json:"pending,omitempty"`
+}
+
+// SendWorkflowEventTool returns the MCP tool definition for send_workflow_event.
+func SendWorkflowEventTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "send_workflow_event",
+		Description: "Send a JSON event to the Argo Server event endpoint (/api/v1/events/{namespace}/{discriminator}), like a webhook. " +
+			"Reports which WorkflowEventBindings matched the event and which workflows they submitted. Requires Argo Server mode.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr.To(false),
+		},
+	}
+}
+
+// SendWorkflowEventHandler returns a handler function for the send_workflow_event tool.
+func SendWorkflowEventHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, SendWorkflowEventInput) (*mcp.CallToolResult, *SendWorkflowEventOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input SendWorkflowEventInput) (*mcp.CallToolResult, *SendWorkflowEventOutput, error) {
+		wait := defaultEventWait
+		if input.Wait != "" {
+			parsed, err := time.ParseDuration(input.Wait)
+			if err != nil || parsed < 0 || parsed > maxEventWait {
+				return nil, nil, toolerror.Invalid("wait", fmt.Errorf("invalid wait %q: must be a duration between 0s and %s", input.Wait, maxEventWait))
+			}
+			wait = parsed
+		}
+
+		payload := &wfv1.Item{}
+		if input.Payload != nil {
+			data, err := json.Marshal(input.Payload)
+			if err != nil {
+				return nil, nil, toolerror.Invalid("payload", fmt.Errorf("failed to encode payload: %w", err))
+			}
+			if err = json.Unmarshal(data, payload); err != nil {
+				return nil, nil, toolerror.Invalid("payload", fmt.Errorf("invalid payload: %w", err))
+			}
+		}
+
+		namespace := ResolveNamespace(input.Namespace, client)
+		discriminator := strings.TrimSpace(input.Discriminator)
+
+		eventService, err := client.EventService()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get event service: %w", err)
+		}
+
+		bindings, err := listEventBindings(ctx, client, namespace, "")
+		if err != nil {
+			return nil, nil, err
+		}
+		output := &SendWorkflowEventOutput{
+			Namespace:     namespace,
+			Discriminator: discriminator,
+			Bindings:      matchEventBindings(bindings, namespace, discriminator, payload),
+			Matched:       []string{},
+			Workflows:     []SubmittedWorkflow{},
+		}
+		sentAt := time.Now().Truncate(time.Second)
+
+		_, err = eventService.ReceiveEvent(ctx, &event.EventRequest{
+			Namespace:     namespace,
+			Discriminator: discriminator,
+			Payload:       payload,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to send event: %w", err)
+		}
+
+		var submitting []string
+		for _, match := range output.Bindings {
+			if !match.Matched {
+				continue
+			}
+			output.Matched = append(output.Matched, match.Name)
+			if match.WorkflowTemplate != "" {
+				submitting = append(submitting, match.Name)
+			}
+		}
+
+		if len(submitting) > 0 {
+			output.Workflows, err = waitForEventWorkflows(ctx, client.WorkflowService(), namespace, submitting, sentAt, wait)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, name := range submitting {
+				if !slices.ContainsFunc(output.Workflows, func(wf SubmittedWorkflow) bool { return wf.Binding == name }) {
+					output.Pending = append(output.Pending, name)
+				}
+			}
+		}
+
+		return TextResult(formatSendWorkflowEvent(output)), output, nil
+	}
+}
+
+// matchEventBindings evaluates the selector of every binding against the
+// event, with the same environment the Argo Server uses. Request metadata is
+// empty because send_workflow_event sends no X- headers.
+func matchEventBindings(bindings []wfv1.WorkflowEventBinding, namespace, discriminator string, payload *wfv1.Item) []EventBindingMatch {
+	matches := make([]EventBindingMatch, 0, len(bindings))
+	env, envErr := jsonutil.Jsonify(map[string]any{
+		"namespace":     namespace,
+		"discriminator": discriminator,
+		"metadata":      map[string]any{},
+		"payload":       payload,
+	})
+
+	for i := range bindings {
+		binding := &bindings[i]
+		match := EventBindingMatch{Name: binding.Name}
+		if binding.Spec.Submit != nil {
+			match.WorkflowTemplate = binding.Spec.Submit.WorkflowTemplateRef.Name
+		}
+		if envErr != nil {
+			match.Error = envErr.Error()
+		} else if matched, err := argoexpr.EvalBool(binding.Spec.Event.Selector, env); err != nil {
+			match.Error = err.Error()
+		} else {
+			match.Matched = matched
+		}
+		matches = append(matches, match)
+	}
+	slices.SortFunc(matches, func(a, b EventBindingMatch) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return matches
+}
+
+// waitForEventWorkflows looks up the workflows the bindings submitted since
+// sentAt, until every binding has one or wait elapses. The Argo Server
+// dispatches events asynchronously, so they may take a moment to appear.
+func waitForEventWorkflows(ctx context.Context, wfService workflow.WorkflowServiceClient, namespace string, bindings []string, sentAt time.Time, wait time.Duration) ([]SubmittedWorkflow, error) {
+	deadline := time.Now().Add(wait)
+	labelSelector := fmt.Sprintf("%s in (%s)", common.LabelKeyWorkflowEventBinding, strings.Join(bindings, ","))
+
+	for {
+		list, err := wfService.ListWorkflows(ctx, &workflow.WorkflowListRequest{
+			Namespace:   namespace,
+			ListOptions: &metav1.ListOptions{LabelSelector: labelSelector},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list submitted workflows: %w", err)
+		}
+
+		workflows := []SubmittedWorkflow{}
+		seen := map[string]bool{}
+		for _, wf := range list.Items {
+			if wf.CreationTimestamp.Time.Before(sentAt) {
+				continue
+			}
+			binding := wf.Labels[common.LabelKeyWorkflowEventBinding]
+			seen[binding] = true
+			workflows = append(workflows, SubmittedWorkflow{
+				Name:      wf.Name,
+				Namespace: wf.Namespace,
+				Binding:   binding,
+				Phase:     string(wf.Status.Phase),
+			})
+		}
+		slices.SortFunc(workflows, func(a, b SubmittedWorkflow) int {
+			return strings.Compare(a.Binding+"/"+a.Name, b.Binding+"/"+b.Name)
+		})
+
+		if len(seen) >= len(bindings) || !time.Now().Add(eventPollInterval).Before(deadline) {
+			return workflows, nil
+		}
+		select {
+		case <-ctx.Done():
+			return workflows, nil
+		case <-time.After(eventPollInterval):
+		}
+	}
+}
+
+// formatSendWorkflowEvent renders the result of send_workflow_event as text.
+func formatSendWorkflowEvent(output *SendWorkflowEventOutput) string {
+	var text strings.Builder
+	fmt.Fprintf(&text, "Event sent to namespace %q", output.Namespace)
+	if output.Discriminator != "" {
+		fmt.Fprintf(&text, " with discriminator %q", output.Discriminator)
+	}
+	fmt.Fprintf(&text, ". %d of %d binding(s) matched", len(output.Matched), len(output.Bindings))
+
+	for _, match := range output.Bindings {
+		switch {
+		case match.Error != "":
+			fmt.Fprintf(&text, "\n- %s: selector error: %s", match.Name, match.Error)
+		case match.Matched && match.WorkflowTemplate != "":
+			fmt.Fprintf(&text, "\n- %s: matched, submits %s", match.Name, match.WorkflowTemplate)
+		case match.Matched:
+			fmt.Fprintf(&text, "\n- %s: matched", match.Name)
+		}
+	}
+
+	if len(output.Workflows) > 0 {
+		text.WriteString("\nSubmitted workflows:")
+		for _, wf := range output.Workflows {
+			fmt.Fprintf(&text, "\n- %s (binding %s)", wf.Name, wf.Binding)
+			if wf.Phase != "" {
+				fmt.Fprintf(&text, ": %s", wf.Phase)
+			}
+		}
+	}
+	if len(output.Pending) > 0 {
+		fmt.Fprintf(&text, "\nNo workflow seen yet for binding(s) %s; events are dispatched asynchronously, list workflows with label %s to check later",
+			strings.Join(output.Pending, ", "), common.LabelKeyWorkflowEventBinding)
+	}
+	return text.String()
+}
@@ -0,0 +1,142 @@
+package tools
+
+import (
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/event"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
+)
+
+func TestSendWorkflowEventTool(t *testing.T) {
+	tool := SendWorkflowEventTool()
+
+	assert.Equal(t, "send_workflow_event", tool.Name)
+	assert.Contains(t, tool.Description, "/api/v1/events/{namespace}/{discriminator}")
+	require.NotNil(t, tool.Annotations)
+	require.NotNil(t, tool.Annotations.DestructiveHint)
+	assert.False(t, *tool.Annotations.DestructiveHint)
+}
+
+func TestSendWorkflowEventHandler(t *testing.T) {
+	broken := pushEventBinding("broken", "argo")
+	broken.Spec.Event.Selector = "payload.repo =="
+	bindings := &wfv1.WorkflowEventBindingList{Items: []wfv1.WorkflowEventBinding{
+		*pushEventBinding("on-push", "argo"),
+		*pushEventBinding("other-repo", "other"),
+		*broken,
+	}}
+
+	t.Run("reports matched bindings and submitted workflows", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", true)
+		eventService := newMockEventService(t)
+		wfService := newMockWorkflowService(t)
+		mockClient.SetEventService(eventService)
+		mockClient.SetWorkflowService(wfService)
+
+		eventService.On("ListWorkflowEventBindings", mock.Anything, mock.Anything).Return(bindings, nil)
+		eventService.On("ReceiveEvent", mock.Anything, mock.MatchedBy(func(req *event.EventRequest) bool {
+			payload, err := req.Payload.MarshalJSON()
+			return err == nil && req.Namespace == "argo" && req.Discriminator == "push" &&
+				string(payload) == `{"commit":"abc123","repo":"argo"}`
+		})).Return(&event.EventResponse{}, nil)
+		wfService.On("ListWorkflows", mock.Anything, &workflow.WorkflowListRequest{
+			Namespace:   "argo",
+			ListOptions: &metav1.ListOptions{LabelSelector: "workflows.argoproj.io/workflow-event-binding in (on-push)"},
+		}).Return(&wfv1.WorkflowList{Items: wfv1.Workflows{
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "build-old", Namespace: "argo", CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+					Labels: map[string]string{"workflows.argoproj.io/workflow-event-binding": "on-push"},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "build-x7k2p", Namespace: "argo", CreationTimestamp: metav1.Now(),
+					Labels: map[string]string{"workflows.argoproj.io/workflow-event-binding": "on-push"},
+				},
+				Status: wfv1.WorkflowStatus{Phase: wfv1.WorkflowRunning},
+			},
+		}}, nil)
+		defer eventService.AssertExpectations(t)
+		defer wfService.AssertExpectations(t)
+
+		result, output, err := SendWorkflowEventHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, SendWorkflowEventInput{
+			Payload:       map[string]any{"repo": "argo", "commit": "abc123"},
+			Discriminator: "push",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"on-push"}, output.Matched)
+		require.Len(t, output.Bindings, 3)
+		assert.Equal(t, "broken", output.Bindings[0].Name)
+		assert.Contains(t, output.Bindings[0].Error, "unexpected token EOF")
+		assert.Equal(t, []SubmittedWorkflow{{Name: "build-x7k2p", Namespace: "argo", Binding: "on-push", Phase: "Running"}}, output.Workflows)
+		assert.Empty(t, output.Pending)
+
+		text, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, text.Text, `Event sent to namespace "argo" with discriminator "push". 1 of 3 binding(s) matched`)
+		assert.Contains(t, text.Text, "- on-push: matched, submits build")
+		assert.Contains(t, text.Text, "- broken: selector error:")
+		assert.Contains(t, text.Text, "- build-x7k2p (binding on-push): Running")
+	})
+
+	t.Run("reports bindings whose workflows did not appear", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", true)
+		eventService := newMockEventService(t)
+		wfService := newMockWorkflowService(t)
+		mockClient.SetEventService(eventService)
+		mockClient.SetWorkflowService(wfService)
+
+		eventService.On("ListWorkflowEventBindings", mock.Anything, mock.Anything).Return(bindings, nil)
+		eventService.On("ReceiveEvent", mock.Anything, mock.Anything).Return(&event.EventResponse{}, nil)
+		wfService.On("ListWorkflows", mock.Anything, mock.Anything).Return(&wfv1.WorkflowList{}, nil)
+
+		result, output, err := SendWorkflowEventHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, SendWorkflowEventInput{
+			Payload: map[string]any{"repo": "argo"}, Discriminator: "push", Wait: "0s",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"on-push"}, output.Pending)
+		text, ok := result.Content[0].(*mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, text.Text, "No workflow seen yet for binding(s) on-push")
+	})
+
+	t.Run("no binding matches", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", true)
+		eventService := newMockEventService(t)
+		mockClient.SetEventService(eventService)
+		eventService.On("ListWorkflowEventBindings", mock.Anything, mock.Anything).Return(bindings, nil)
+		eventService.On("ReceiveEvent", mock.Anything, mock.Anything).Return(&event.EventResponse{}, nil)
+
+		_, output, err := SendWorkflowEventHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, SendWorkflowEventInput{})
+		require.NoError(t, err)
+		assert.Empty(t, output.Matched)
+		assert.Empty(t, output.Workflows)
+	})
+
+	t.Run("direct mode", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", false)
+		mockClient.On("EventService").Return(nil, argo.ErrEventsNotSupported)
+
+		_, _, err := SendWorkflowEventHandler(mockClient)(t.Context(), &mcp.CallToolRequest{}, SendWorkflowEventInput{})
+		require.ErrorIs(t, err, argo.ErrEventsNotSupported)
+		assert.Equal(t, toolerror.CodeEventsRequireArgoServer, toolerror.Classify(err).Code)
+	})
+
+	t.Run("invalid wait", func(t *testing.T) {
+		_, _, err := SendWorkflowEventHandler(newMockClient(t, "argo", true))(t.Context(), &mcp.CallToolRequest{}, SendWorkflowEventInput{Wait: "5m"})
+		require.Error(t, err)
+		assert.Equal(t, "wait", toolerror.Classify(err).Field)
+	})
+}
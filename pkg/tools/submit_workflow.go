@@ -165,14 +165,9 @@ func SubmitWorkflowHandler(client argo.ClientInterface) func(context.Context, *m
 // Parameters should be in "key=value" format.
 func applyParameterOverrides(wf *wfv1.Workflow, params []string) error {
 	for _, param := range params {
-		parts := strings.SplitN(param, "=", 2)
-		if len(parts) != 2 {
-			return fmt.Errorf("invalid parameter format %q, expected key=value", param)
-		}
-		key := strings.TrimSpace(parts[0])
-		value := parts[1]
-		if key == "" {
-			return fmt.Errorf("invalid parameter format %q, key cannot be empty", param)
+		key, value, err := parseParameter(param)
+		if err != nil {
+			return err
 		}
 
 		// Find and update the parameter in the workflow spec
@@ -0,0 +1,236 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/utils/ptr"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// SubmitWorkflowTemplateInput defines the input parameters for the submit_workflow_template tool.
+type SubmitWorkflowTemplateInput struct {
+	// Labels are additional labels to add to the workflow.
+	Labels map[string]string `json:"labels,omitempty" jsonschema:"Additional labels to add"`
+
+	// Namespace is the Kubernetes namespace (uses default if not specified).
+	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace (uses default if not specified)"`
+
+	// Name is the template name.
+	Name string `json:"name" jsonschema:"WorkflowTemplate or ClusterWorkflowTemplate name,required"`
+
+	// GenerateName overrides the generateName of the new workflow.
+	GenerateName string `json:"generateName,omitempty" jsonschema:"Override metadata.generateName of the new workflow"`
+
+	// Parameters are parameter values in key=value format.
+	Parameters []string `json:"parameters,omitempty" jsonschema:"Parameter values in key=value format"`
+
+	// ClusterScope submits from a ClusterWorkflowTemplate instead of a WorkflowTemplate.
+	ClusterScope bool `json:"clusterScope,omitempty" jsonschema:"Submit from a ClusterWorkflowTemplate instead of a WorkflowTemplate"`
+}
+
+// SubmitWorkflowTemplateOutput defines the output for the submit_workflow_template tool.
+type SubmitWorkflowTemplateOutput struct {
+	// Name is the name of the new workflow.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the new workflow.
+	Namespace string `json:"namespace"`
+
+	// UID is the unique identifier of the new workflow.
+	UID string `json:"uid"`
+
+	// Template is the kind and name of the template the workflow was submitted from.
+	Template string `json:"template"`
+
+	// Phase is the initial workflow phase.
+	Phase string `json:"phase"`
+
+	// Elicited lists the parameters the user provided when asked.
+	Elicited []string `json:"elicited,omitempty"`
+}
+
+// SubmitWorkflowTemplateTool returns the MCP tool definition for submit_workflow_template.
+func SubmitWorkflowTemplateTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "submit_workflow_template",
+		Description: "Submit a workflow from a WorkflowTemplate or ClusterWorkflowTemplate, like argo submit --from. " +
+			"Parameter values are checked against the template's enums, and required parameters that are missing " +
+			"are asked from the user with a form when the client supports elicitation. See describe_template_parameters.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr.To(false),
+		},
+	}
+}
+
+// SubmitWorkflowTemplateHandler returns a handler function for the submit_workflow_template tool.
+func SubmitWorkflowTemplateHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, SubmitWorkflowTemplateInput) (*mcp.CallToolResult, *SubmitWorkflowTemplateOutput, error) {
+	return func(ctx context.Context, req *mcp.CallToolRequest, input SubmitWorkflowTemplateInput) (*mcp.CallToolResult, *SubmitWorkflowTemplateOutput, error) {
+		name, err := ValidateName(input.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		values := make(map[string]string, len(input.Parameters))
+		for _, param := range input.Parameters {
+			key, value, parseErr := parseParameter(param)
+			if parseErr != nil {
+				return nil, nil, parseErr
+			}
+			values[key] = value
+		}
+
+		labels, err := formatSubmitLabels(input.Labels)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		kind := templateRefKind(input.ClusterScope)
+		namespace := ResolveNamespace(input.Namespace, client)
+		source := &liveTemplateSource{client: client, namespace: namespace}
+		spec, err := source.templateSpec(ctx, input.ClusterScope, name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		params := templateParameters(spec)
+		if err = checkParameterEnums(params, values); err != nil {
+			return nil, nil, err
+		}
+
+		var missing []TemplateParameter
+		for _, param := range params {
+			if _, ok := values[param.Name]; param.Required && !ok {
+				missing = append(missing, param)
+			}
+		}
+
+		output := &SubmitWorkflowTemplateOutput{Template: kind + "/" + name}
+		if len(missing) > 0 {
+			var session *mcp.ServerSession
+			if req != nil {
+				session = req.Session
+			}
+			elicited, elicitErr := elicitParameters(ctx, session, kind, name, missing)
+			if elicitErr != nil {
+				return nil, nil, elicitErr
+			}
+			for _, param := range missing {
+				values[param.Name] = elicited[param.Name]
+				output.Elicited = append(output.Elicited, param.Name)
+			}
+			if err = checkParameterEnums(params, elicited); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		// Keep the caller's order, then the elicited parameters
+		submitParams := slices.Clone(input.Parameters)
+		for _, paramName := range output.Elicited {
+			submitParams = append(submitParams, paramName+"="+values[paramName])
+		}
+
+		wf, err := client.WorkflowService().SubmitWorkflow(ctx, &workflow.WorkflowSubmitRequest{
+			Namespace:    namespace,
+			ResourceKind: kind,
+			ResourceName: name,
+			SubmitOptions: &wfv1.SubmitOpts{
+				GenerateName: input.GenerateName,
+				Parameters:   submitParams,
+				Labels:       labels,
+			},
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to submit workflow from %s %q: %w", kind, name, err)
+		}
+
+		output.Name = wf.Name
+		output.Namespace = wf.Namespace
+		output.UID = string(wf.UID)
+		output.Phase = string(wf.Status.Phase)
+		if output.Namespace == "" {
+			output.Namespace = namespace
+		}
+		if output.Phase == "" {
+			output.Phase = PhasePending
+		}
+
+		resultText := fmt.Sprintf("Submitted workflow %q in namespace %q from %s %q (UID: %s)",
+			output.Name, output.Namespace, kind, name, output.UID)
+		if len(output.Elicited) > 0 {
+			resultText += fmt.Sprintf("\nParameters provided by the user: %s", strings.Join(output.Elicited, ", "))
+		}
+		return TextResult(resultText), output, nil
+	}
+}
+
+// checkParameterEnums checks values against the enums of params.
+func checkParameterEnums(params []TemplateParameter, values map[string]string) error {
+	for _, param := range params {
+		value, ok := values[param.Name]
+		if ok && len(param.Enum) > 0 && !slices.Contains(param.Enum, value) {
+			return fmt.Errorf("invalid value %q for parameter %q, must be one of: %s", value, param.Name, strings.Join(param.Enum, ", "))
+		}
+	}
+	return nil
+}
+
+// elicitParameters asks the user for the missing parameters with a form built
+// from their schema. It fails if the client can't show forms or the user
+// doesn't submit one.
+func elicitParameters(ctx context.Context, session *mcp.ServerSession, kind, name string, missing []TemplateParameter) (map[string]string, error) {
+	names := make([]string, 0, len(missing))
+	for _, param := range missing {
+		names = append(names, param.Name)
+	}
+
+	if !supportsElicitation(session) {
+		return nil, fmt.Errorf("missing required parameter(s) %s of %s %q; provide them as key=value parameters (see describe_template_parameters)",
+			strings.Join(names, ", "), kind, name)
+	}
+
+	schema := parameterSchema(missing)
+	schema.Schema = ""
+	result, err := session.Elicit(ctx, &mcp.ElicitParams{
+		Mode:            "form",
+		Message:         fmt.Sprintf("Submitting %s %q needs values for: %s", kind, name, strings.Join(names, ", ")),
+		RequestedSchema: schema,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to ask for missing parameter(s) %s: %w", strings.Join(names, ", "), err)
+	}
+	if result.Action != "accept" {
+		return nil, fmt.Errorf("submission cancelled: the user chose to %s providing parameter(s) %s", result.Action, strings.Join(names, ", "))
+	}
+
+	values := make(map[string]string, len(missing))
+	for _, paramName := range names {
+		value, ok := result.Content[paramName]
+		if !ok {
+			return nil, fmt.Errorf("the user did not provide parameter %q", paramName)
+		}
+		values[paramName] = fmt.Sprint(value)
+	}
+	return values, nil
+}
+
+// supportsElicitation reports whether the client of session can show forms.
+func supportsElicitation(session *mcp.ServerSession) bool {
+	if session == nil {
+		return false
+	}
+	params := session.InitializeParams()
+	if params == nil || params.Capabilities == nil || params.Capabilities.Elicitation == nil {
+		return false
+	}
+	caps := params.Capabilities.Elicitation
+	// Clients that declare neither mode predate URL elicitation and support forms
+	return caps.Form != nil || caps.URL == nil
+}
@@ -0,0 +1,282 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/clusterworkflowtemplate"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+)
+
+// submitTemplateMocks holds the services submit_workflow_template uses.
+type submitTemplateMocks struct {
+	wft  *mocks.MockWorkflowTemplateServiceClient
+	cwft *mocks.MockClusterWorkflowTemplateServiceClient
+	wf   *mocks.MockWorkflowServiceClient
+}
+
+// newSubmitTemplateMocks returns a client in namespace argo with mock template
+// and workflow services.
+func newSubmitTemplateMocks(t *testing.T) (*submitTemplateMocks, *mocks.MockClient) {
+	t.Helper()
+	m := &submitTemplateMocks{
+		wft:  newMockWorkflowTemplateService(t),
+		cwft: newMockClusterWorkflowTemplateService(t),
+		wf:   newMockWorkflowService(t),
+	}
+	client := newMockClient(t, "argo", true)
+	client.SetWorkflowTemplateService(m.wft)
+	client.SetClusterWorkflowTemplateService(m.cwft)
+	client.SetWorkflowService(m.wf)
+	t.Cleanup(func() {
+		m.wft.AssertExpectations(t)
+		m.cwft.AssertExpectations(t)
+		m.wf.AssertExpectations(t)
+	})
+	return m, client
+}
+
+// callWithElicitation calls submit_workflow_template through an in-memory MCP
+// session whose client answers elicitation requests with elicit, or doesn't
+// support elicitation when elicit is nil.
+func callWithElicitation(t *testing.T, client argo.ClientInterface, input map[string]any, elicit func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error)) *mcp.CallToolResult {
+	t.Helper()
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "test-server", Version: "1.0.0"}, nil)
+	RegisterSubmitWorkflowTemplate(server, client)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	_, err := server.Connect(t.Context(), serverTransport, nil)
+	require.NoError(t, err)
+
+	mcpClient := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, &mcp.ClientOptions{
+		ElicitationHandler: elicit,
+	})
+	session, err := mcpClient.Connect(t.Context(), clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+
+	result, err := session.CallTool(t.Context(), &mcp.CallToolParams{Name: "submit_workflow_template", Arguments: input})
+	require.NoError(t, err)
+	return result
+}
+
+// resultText returns the text of the first content block of result.
+func resultText(t *testing.T, result *mcp.CallToolResult) string {
+	t.Helper()
+	require.NotEmpty(t, result.Content)
+	text, ok := result.Content[0].(*mcp.TextContent)
+	require.True(t, ok)
+	return text.Text
+}
+
+func TestSubmitWorkflowTemplateTool(t *testing.T) {
+	tool := SubmitWorkflowTemplateTool()
+
+	assert.Equal(t, "submit_workflow_template", tool.Name)
+	assert.Contains(t, tool.Description, "elicitation")
+	require.NotNil(t, tool.Annotations)
+	require.NotNil(t, tool.Annotations.DestructiveHint)
+	assert.False(t, *tool.Annotations.DestructiveHint)
+}
+
+func TestSubmitWorkflowTemplateHandler(t *testing.T) {
+	submitted := &wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "deploy-abc12", Namespace: "argo", UID: "uid-1"}}
+
+	tests := []struct {
+		setupMock func(*submitTemplateMocks)
+		validate  func(*testing.T, *SubmitWorkflowTemplateOutput, *mcp.CallToolResult)
+		name      string
+		wantErr   string
+		input     SubmitWorkflowTemplateInput
+	}{
+		{
+			name: "submits with all required parameters",
+			input: SubmitWorkflowTemplateInput{
+				Name:         "deploy",
+				GenerateName: "release-",
+				Parameters:   []string{"image=app:v2", "region=eu", "env=prod"},
+				Labels:       map[string]string{"team": "web"},
+			},
+			setupMock: func(m *submitTemplateMocks) {
+				m.wft.On("GetWorkflowTemplate", mock.Anything, &workflowtemplate.WorkflowTemplateGetRequest{Name: "deploy", Namespace: "argo"}).
+					Return(&wfv1.WorkflowTemplate{Spec: parameterizedSpec()}, nil)
+				m.wf.On("SubmitWorkflow", mock.Anything, &workflow.WorkflowSubmitRequest{
+					Namespace:    "argo",
+					ResourceKind: "WorkflowTemplate",
+					ResourceName: "deploy",
+					SubmitOptions: &wfv1.SubmitOpts{
+						GenerateName: "release-",
+						Parameters:   []string{"image=app:v2", "region=eu", "env=prod"},
+						Labels:       "team=web",
+					},
+				}).Return(submitted, nil)
+			},
+			validate: func(t *testing.T, output *SubmitWorkflowTemplateOutput, result *mcp.CallToolResult) {
+				assert.Equal(t, "deploy-abc12", output.Name)
+				assert.Equal(t, "WorkflowTemplate/deploy", output.Template)
+				assert.Equal(t, PhasePending, output.Phase)
+				assert.Empty(t, output.Elicited)
+				assert.Equal(t, `Submitted workflow "deploy-abc12" in namespace "argo" from WorkflowTemplate "deploy" (UID: uid-1)`, resultText(t, result))
+			},
+		},
+		{
+			name:  "submits from a ClusterWorkflowTemplate",
+			input: SubmitWorkflowTemplateInput{Name: "shared", ClusterScope: true, Namespace: "team-a"},
+			setupMock: func(m *submitTemplateMocks) {
+				m.cwft.On("GetClusterWorkflowTemplate", mock.Anything, &clusterworkflowtemplate.ClusterWorkflowTemplateGetRequest{Name: "shared"}).
+					Return(&wfv1.ClusterWorkflowTemplate{}, nil)
+				m.wf.On("SubmitWorkflow", mock.Anything, mock.MatchedBy(func(req *workflow.WorkflowSubmitRequest) bool {
+					return req.Namespace == "team-a" && req.ResourceKind == "ClusterWorkflowTemplate" && req.ResourceName == "shared"
+				})).Return(&wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "shared-x"}}, nil)
+			},
+			validate: func(t *testing.T, output *SubmitWorkflowTemplateOutput, _ *mcp.CallToolResult) {
+				assert.Equal(t, "team-a", output.Namespace)
+				assert.Equal(t, "ClusterWorkflowTemplate/shared", output.Template)
+			},
+		},
+		{
+			name:  "missing parameters without elicitation support",
+			input: SubmitWorkflowTemplateInput{Name: "deploy", Parameters: []string{"image=app:v2"}},
+			setupMock: func(m *submitTemplateMocks) {
+				m.wft.On("GetWorkflowTemplate", mock.Anything, mock.Anything).Return(&wfv1.WorkflowTemplate{Spec: parameterizedSpec()}, nil)
+			},
+			wantErr: `missing required parameter(s) region of WorkflowTemplate "deploy"`,
+		},
+		{
+			name:  "value outside the enum",
+			input: SubmitWorkflowTemplateInput{Name: "deploy", Parameters: []string{"env=qa"}},
+			setupMock: func(m *submitTemplateMocks) {
+				m.wft.On("GetWorkflowTemplate", mock.Anything, mock.Anything).Return(&wfv1.WorkflowTemplate{Spec: parameterizedSpec()}, nil)
+			},
+			wantErr: `invalid value "qa" for parameter "env", must be one of: dev, staging, prod`,
+		},
+		{
+			name:  "submit failure",
+			input: SubmitWorkflowTemplateInput{Name: "shared", ClusterScope: true},
+			setupMock: func(m *submitTemplateMocks) {
+				m.cwft.On("GetClusterWorkflowTemplate", mock.Anything, mock.Anything).Return(&wfv1.ClusterWorkflowTemplate{}, nil)
+				m.wf.On("SubmitWorkflow", mock.Anything, mock.Anything).Return(nil, errors.New("forbidden"))
+			},
+			wantErr: `failed to submit workflow from ClusterWorkflowTemplate "shared"`,
+		},
+		{
+			name:    "invalid parameter format",
+			input:   SubmitWorkflowTemplateInput{Name: "deploy", Parameters: []string{"image"}},
+			wantErr: "expected key=value",
+		},
+		{
+			name:    "invalid label",
+			input:   SubmitWorkflowTemplateInput{Name: "deploy", Labels: map[string]string{"bad key": "x"}},
+			wantErr: `invalid label key "bad key"`,
+		},
+		{
+			name:    "empty name",
+			input:   SubmitWorkflowTemplateInput{},
+			wantErr: "name cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, mockClient := newSubmitTemplateMocks(t)
+			if tt.setupMock != nil {
+				tt.setupMock(m)
+			}
+
+			handler := SubmitWorkflowTemplateHandler(mockClient)
+			result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, tt.input)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, output)
+			tt.validate(t, output, result)
+		})
+	}
+}
+
+func TestSubmitWorkflowTemplateElicitation(t *testing.T) {
+	t.Run("asks for missing parameters with a form", func(t *testing.T) {
+		m, mockClient := newSubmitTemplateMocks(t)
+		m.wft.On("GetWorkflowTemplate", mock.Anything, mock.Anything).Return(&wfv1.WorkflowTemplate{Spec: parameterizedSpec()}, nil)
+		m.wf.On("SubmitWorkflow", mock.Anything, mock.MatchedBy(func(req *workflow.WorkflowSubmitRequest) bool {
+			return assert.Equal(t, []string{"env=staging", "image=app:v3", "region=us"}, req.SubmitOptions.Parameters)
+		})).Return(&wfv1.Workflow{ObjectMeta: metav1.ObjectMeta{Name: "deploy-x", Namespace: "argo"}}, nil)
+
+		var asked *mcp.ElicitParams
+		result := callWithElicitation(t, mockClient, map[string]any{"name": "deploy", "parameters": []string{"env=staging"}},
+			func(_ context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+				asked = req.Params
+				return &mcp.ElicitResult{Action: "accept", Content: map[string]any{"image": "app:v3", "region": "us"}}, nil
+			})
+
+		require.False(t, result.IsError, resultText(t, result))
+		require.NotNil(t, asked)
+		assert.Equal(t, "form", asked.Mode)
+		assert.Equal(t, `Submitting WorkflowTemplate "deploy" needs values for: image, region`, asked.Message)
+		schema, ok := asked.RequestedSchema.(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, []any{"image", "region"}, schema["required"])
+		assert.Len(t, schema["properties"], 2)
+
+		assert.Contains(t, resultText(t, result), "Parameters provided by the user: image, region")
+	})
+
+	t.Run("offers enum choices and rejects values outside them", func(t *testing.T) {
+		m, mockClient := newSubmitTemplateMocks(t)
+		spec := parameterizedSpec()
+		spec.Arguments.Parameters[1].Value = nil
+		m.wft.On("GetWorkflowTemplate", mock.Anything, mock.Anything).Return(&wfv1.WorkflowTemplate{Spec: spec}, nil)
+
+		var choices any
+		result := callWithElicitation(t, mockClient, map[string]any{"name": "deploy", "parameters": []string{"image=a", "region=b"}},
+			func(_ context.Context, req *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+				properties, _ := req.Params.RequestedSchema.(map[string]any)["properties"].(map[string]any)
+				choices = properties["env"].(map[string]any)["enum"]
+				return &mcp.ElicitResult{Action: "accept", Content: map[string]any{"env": "qa"}}, nil
+			})
+
+		assert.Equal(t, []any{"dev", "staging", "prod"}, choices)
+		require.True(t, result.IsError)
+		assert.Contains(t, resultText(t, result), "elicitation result content does not match requested schema")
+	})
+
+	t.Run("user declines", func(t *testing.T) {
+		m, mockClient := newSubmitTemplateMocks(t)
+		m.wft.On("GetWorkflowTemplate", mock.Anything, mock.Anything).Return(&wfv1.WorkflowTemplate{Spec: parameterizedSpec()}, nil)
+
+		result := callWithElicitation(t, mockClient, map[string]any{"name": "deploy"},
+			func(context.Context, *mcp.ElicitRequest) (*mcp.ElicitResult, error) {
+				return &mcp.ElicitResult{Action: "decline"}, nil
+			})
+
+		require.True(t, result.IsError)
+		assert.Contains(t, resultText(t, result), "submission cancelled: the user chose to decline providing parameter(s) image, region")
+	})
+
+	t.Run("client without elicitation", func(t *testing.T) {
+		m, mockClient := newSubmitTemplateMocks(t)
+		m.wft.On("GetWorkflowTemplate", mock.Anything, mock.Anything).Return(&wfv1.WorkflowTemplate{Spec: parameterizedSpec()}, nil)
+
+		result := callWithElicitation(t, mockClient, map[string]any{"name": "deploy"}, nil)
+
+		require.True(t, result.IsError)
+		assert.Contains(t, resultText(t, result), "missing required parameter(s) image, region")
+	})
+}
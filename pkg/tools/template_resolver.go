@@ -0,0 +1,442 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/clusterworkflowtemplate"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	wfutil "github.com/argoproj/argo-workflows/v4/workflow/util"
+	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/yaml"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// maxBundleBytes bounds the size of an offline template bundle.
+const maxBundleBytes = 4 << 20 // 4 MiB
+
+// TemplateDependency is a node of the dependency tree of a resolved manifest,
+// listed in depth-first order.
+type TemplateDependency struct {
+	// Kind is the kind of the manifest the template came from.
+	Kind string `json:"kind"`
+
+	// Name is the name of the manifest the template came from.
+	Name string `json:"name"`
+
+	// Template is the referenced template, empty for a whole-spec workflowTemplateRef.
+	Template string `json:"template,omitempty"`
+
+	// ResolvedAs is the name of the template in the resolved manifest.
+	ResolvedAs string `json:"resolvedAs,omitempty"`
+
+	// Depth is the depth of the node in the tree; the manifest itself is at depth 0.
+	Depth int `json:"depth"`
+
+	// Reused is set when the template was already resolved through another reference.
+	Reused bool `json:"reused,omitempty"`
+
+	// Cycle is set when the reference leads back to a template that is being resolved.
+	Cycle bool `json:"cycle,omitempty"`
+}
+
+// resolvedManifest is a WorkflowSpec with its template references expanded.
+type resolvedManifest struct {
+	Spec         *wfv1.WorkflowSpec
+	Dependencies []TemplateDependency
+	Cycles       []string
+	Warnings     []string
+	Imported     int
+}
+
+// templateSource looks up the WorkflowTemplates and ClusterWorkflowTemplates that references point at.
+type templateSource interface {
+	templateSpec(ctx context.Context, clusterScope bool, name string) (*wfv1.WorkflowSpec, error)
+}
+
+// liveTemplateSource looks templates up in the cluster.
+type liveTemplateSource struct {
+	client    argo.ClientInterface
+	namespace string
+}
+
+func (s *liveTemplateSource) templateSpec(ctx context.Context, clusterScope bool, name string) (*wfv1.WorkflowSpec, error) {
+	if clusterScope {
+		cwftService, err := s.client.ClusterWorkflowTemplateService()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cluster workflow template service: %w", err)
+		}
+		cwft, err := cwftService.GetClusterWorkflowTemplate(ctx, &clusterworkflowtemplate.ClusterWorkflowTemplateGetRequest{
+			Name: name,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ClusterWorkflowTemplate %q: %w", name, err)
+		}
+		return &cwft.Spec, nil
+	}
+
+	wftService, err := s.client.WorkflowTemplateService()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workflow template service: %w", err)
+	}
+	wft, err := wftService.GetWorkflowTemplate(ctx, &workflowtemplate.WorkflowTemplateGetRequest{
+		Name:      name,
+		Namespace: s.namespace,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get WorkflowTemplate %q: %w", name, err)
+	}
+	return &wft.Spec, nil
+}
+
+// bundleTemplateSource looks templates up in a bundle of manifests.
+type bundleTemplateSource struct {
+	specs map[string]*wfv1.WorkflowSpec
+}
+
+func (s *bundleTemplateSource) templateSpec(_ context.Context, clusterScope bool, name string) (*wfv1.WorkflowSpec, error) {
+	kind := templateRefKind(clusterScope)
+	spec, ok := s.specs[kind+"/"+name]
+	if !ok {
+		return nil, fmt.Errorf("%s %q not found in bundle", kind, name)
+	}
+	return spec, nil
+}
+
+// parseTemplateBundle parses a multi-document YAML bundle of WorkflowTemplates
+// and ClusterWorkflowTemplates.
+func parseTemplateBundle(bundle string) (*bundleTemplateSource, error) {
+	if len(bundle) > maxBundleBytes {
+		return nil, fmt.Errorf("bundle too large (%d bytes), max %d", len(bundle), maxBundleBytes)
+	}
+
+	source := &bundleTemplateSource{specs: make(map[string]*wfv1.WorkflowSpec)}
+	reader := k8syaml.NewYAMLReader(bufio.NewReader(strings.NewReader(bundle)))
+	for i := 1; ; i++ {
+		doc, readErr := reader.Read()
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read bundle document %d: %w", i, readErr)
+		}
+		if strings.TrimSpace(string(doc)) == "" {
+			continue
+		}
+
+		spec, kind, name, err := extractWorkflowSpec(string(doc))
+		if err != nil {
+			return nil, fmt.Errorf("bundle document %d: %w", i, err)
+		}
+		if kind != KindWorkflowTemplate && kind != KindClusterWorkflowTemplate {
+			return nil, fmt.Errorf("bundle document %d: bundles may only contain %s and %s manifests, got %s",
+				i, KindWorkflowTemplate, KindClusterWorkflowTemplate, kind)
+		}
+		key := kind + "/" + name
+		if _, ok := source.specs[key]; ok {
+			return nil, fmt.Errorf("bundle document %d: duplicate %s %q", i, kind, name)
+		}
+		source.specs[key] = spec
+	}
+	return source, nil
+}
+
+// templateRefKind returns the kind a reference with the given scope points at.
+func templateRefKind(clusterScope bool) string {
+	if clusterScope {
+		return KindClusterWorkflowTemplate
+	}
+	return KindWorkflowTemplate
+}
+
+// resolveManifest expands the workflowTemplateRef and templateRefs of a
+// manifest's spec against the bundle if one is given, or the cluster otherwise.
+func resolveManifest(ctx context.Context, client argo.ClientInterface, namespace, bundle, kind, name string, spec *wfv1.WorkflowSpec) (*resolvedManifest, error) {
+	var source templateSource
+	switch {
+	case strings.TrimSpace(bundle) != "":
+		bundleSource, err := parseTemplateBundle(bundle)
+		if err != nil {
+			return nil, err
+		}
+		source = bundleSource
+	case client != nil:
+		source = &liveTemplateSource{client: client, namespace: namespace}
+	default:
+		return nil, fmt.Errorf("resolving template references requires a cluster connection or a bundle")
+	}
+
+	r := &templateResolver{
+		source:   source,
+		specs:    make(map[string]*wfv1.WorkflowSpec),
+		imported: make(map[string]string),
+		names:    make(map[string]bool),
+		result: &resolvedManifest{
+			Dependencies: []TemplateDependency{{Kind: kind, Name: name}},
+		},
+	}
+	if err := r.resolve(ctx, spec); err != nil {
+		return nil, err
+	}
+	return r.result, nil
+}
+
+// templateOrigin is the WorkflowTemplate or ClusterWorkflowTemplate an imported template came from.
+type templateOrigin struct {
+	name         string
+	clusterScope bool
+}
+
+// templateResolver expands template references into a single WorkflowSpec.
+type templateResolver struct {
+	source   templateSource
+	specs    map[string]*wfv1.WorkflowSpec
+	imported map[string]string
+	names    map[string]bool
+	result   *resolvedManifest
+	added    []wfv1.Template
+	path     []string
+}
+
+// resolve expands the references of spec into r.result.Spec.
+func (r *templateResolver) resolve(ctx context.Context, spec *wfv1.WorkflowSpec) error {
+	spec = spec.DeepCopy()
+
+	if ref := spec.WorkflowTemplateRef; ref != nil {
+		kind := templateRefKind(ref.ClusterScope)
+		wftSpec, err := r.lookup(ctx, ref.ClusterScope, ref.Name)
+		if err != nil {
+			return err
+		}
+		if wftSpec.WorkflowTemplateRef != nil {
+			return fmt.Errorf("%s %q itself uses workflowTemplateRef, which Argo does not support", kind, ref.Name)
+		}
+		// Merge the way the controller does: the workflow's own fields win
+		joined, err := wfutil.JoinWorkflowSpec(spec, wftSpec, nil)
+		if err != nil {
+			return fmt.Errorf("failed to merge %s %q: %w", kind, ref.Name, err)
+		}
+		spec = &joined.Spec
+		spec.WorkflowTemplateRef = nil
+		r.result.Dependencies = append(r.result.Dependencies, TemplateDependency{Kind: kind, Name: ref.Name, Depth: 1})
+	}
+
+	for _, tmpl := range spec.Templates {
+		r.names[tmpl.Name] = true
+	}
+	for i := range spec.Templates {
+		if err := r.resolveTemplate(ctx, &spec.Templates[i], nil, 1); err != nil {
+			return err
+		}
+	}
+	hooks, err := r.resolveHooks(ctx, spec.Hooks, nil, 1)
+	if err != nil {
+		return err
+	}
+	spec.Hooks = hooks
+
+	spec.Templates = append(spec.Templates, r.added...)
+	r.result.Spec = spec
+	r.result.Imported = len(r.added)
+	return nil
+}
+
+// resolveTemplate expands the references of a template's steps, tasks and hooks.
+// Plain template names refer to templates of origin, or of the manifest if origin is nil.
+func (r *templateResolver) resolveTemplate(ctx context.Context, tmpl *wfv1.Template, origin *templateOrigin, depth int) error {
+	for j := range tmpl.Steps {
+		for k := range tmpl.Steps[j].Steps {
+			step := &tmpl.Steps[j].Steps[k]
+			if err := r.resolveCall(ctx, &step.Template, &step.TemplateRef, origin, depth); err != nil {
+				return err
+			}
+			if err := r.resolveCall(ctx, &step.OnExit, nil, origin, depth); err != nil {
+				return err
+			}
+			hooks, err := r.resolveHooks(ctx, step.Hooks, origin, depth)
+			if err != nil {
+				return err
+			}
+			step.Hooks = hooks
+		}
+	}
+	if tmpl.DAG != nil {
+		for j := range tmpl.DAG.Tasks {
+			task := &tmpl.DAG.Tasks[j]
+			if err := r.resolveCall(ctx, &task.Template, &task.TemplateRef, origin, depth); err != nil {
+				return err
+			}
+			if err := r.resolveCall(ctx, &task.OnExit, nil, origin, depth); err != nil {
+				return err
+			}
+			hooks, err := r.resolveHooks(ctx, task.Hooks, origin, depth)
+			if err != nil {
+				return err
+			}
+			task.Hooks = hooks
+		}
+	}
+	return nil
+}
+
+// resolveHooks expands the references of lifecycle hooks.
+func (r *templateResolver) resolveHooks(ctx context.Context, hooks wfv1.LifecycleHooks, origin *templateOrigin, depth int) (wfv1.LifecycleHooks, error) {
+	for _, event := range slices.Sorted(maps.Keys(hooks)) {
+		hook := hooks[event]
+		if err := r.resolveCall(ctx, &hook.Template, &hook.TemplateRef, origin, depth); err != nil {
+			return nil, err
+		}
+		hooks[event] = hook
+	}
+	return hooks, nil
+}
+
+// resolveCall rewrites a call to a templateRef, or to a template of origin,
+// into a call to the imported template.
+func (r *templateResolver) resolveCall(ctx context.Context, template *string, ref **wfv1.TemplateRef, origin *templateOrigin, depth int) error {
+	var target templateOrigin
+	var templateName string
+	switch {
+	case ref != nil && *ref != nil:
+		target = templateOrigin{name: (*ref).Name, clusterScope: (*ref).ClusterScope}
+		templateName = (*ref).Template
+	case origin != nil && *template != "":
+		target = *origin
+		templateName = *template
+	default:
+		return nil
+	}
+
+	if strings.Contains(target.name, "{{") || strings.Contains(templateName, "{{") {
+		r.result.Warnings = append(r.result.Warnings, fmt.Sprintf(
+			"templated reference to template %q of %s %q cannot be resolved statically", templateName, templateRefKind(target.clusterScope), target.name))
+		return nil
+	}
+
+	local, err := r.importTemplate(ctx, target, templateName, depth)
+	if err != nil {
+		return err
+	}
+	*template = local
+	if ref != nil {
+		*ref = nil
+	}
+	return nil
+}
+
+// importTemplate copies a template of a WorkflowTemplate or ClusterWorkflowTemplate
+// into the resolved manifest and returns its local name.
+func (r *templateResolver) importTemplate(ctx context.Context, origin templateOrigin, templateName string, depth int) (string, error) {
+	kind := templateRefKind(origin.clusterScope)
+	key := kind + "/" + origin.name + "/" + templateName
+	dep := TemplateDependency{Kind: kind, Name: origin.name, Template: templateName, Depth: depth}
+
+	if local, ok := r.imported[key]; ok {
+		dep.ResolvedAs = local
+		if start := slices.Index(r.path, key); start >= 0 {
+			// Recursion stays a reference to the template being resolved
+			dep.Cycle = true
+			r.result.Cycles = append(r.result.Cycles, strings.Join(append(slices.Clone(r.path[start:]), key), " -> "))
+		} else {
+			dep.Reused = true
+		}
+		r.result.Dependencies = append(r.result.Dependencies, dep)
+		return local, nil
+	}
+
+	spec, err := r.lookup(ctx, origin.clusterScope, origin.name)
+	if err != nil {
+		return "", err
+	}
+	idx := slices.IndexFunc(spec.Templates, func(t wfv1.Template) bool { return t.Name == templateName })
+	if idx < 0 {
+		return "", fmt.Errorf("template %q not found in %s %q", templateName, kind, origin.name)
+	}
+
+	local := r.localName(origin.name + "-" + templateName)
+	r.imported[key] = local
+	dep.ResolvedAs = local
+	r.result.Dependencies = append(r.result.Dependencies, dep)
+
+	tmpl := spec.Templates[idx].DeepCopy()
+	tmpl.Name = local
+	r.path = append(r.path, key)
+	err = r.resolveTemplate(ctx, tmpl, &origin, depth+1)
+	r.path = r.path[:len(r.path)-1]
+	if err != nil {
+		return "", err
+	}
+	r.added = append(r.added, *tmpl)
+	return local, nil
+}
+
+// lookup returns the spec of a WorkflowTemplate or ClusterWorkflowTemplate, fetching it once.
+func (r *templateResolver) lookup(ctx context.Context, clusterScope bool, name string) (*wfv1.WorkflowSpec, error) {
+	key := templateRefKind(clusterScope) + "/" + name
+	if spec, ok := r.specs[key]; ok {
+		return spec, nil
+	}
+	spec, err := r.source.templateSpec(ctx, clusterScope, name)
+	if err != nil {
+		return nil, err
+	}
+	r.specs[key] = spec
+	return spec, nil
+}
+
+// localName returns an unused template name based on base.
+func (r *templateResolver) localName(base string) string {
+	name := base
+	for i := 2; r.names[name]; i++ {
+		name = fmt.Sprintf("%s-%d", base, i)
+	}
+	r.names[name] = true
+	return name
+}
+
+// renderResolvedManifest returns manifest as YAML with its spec replaced by spec.
+func renderResolvedManifest(manifest, kind string, spec *wfv1.WorkflowSpec) (string, error) {
+	var obj map[string]any
+	if err := yaml.Unmarshal([]byte(manifest), &obj); err != nil {
+		return "", fmt.Errorf("failed to parse manifest: %w", err)
+	}
+	if obj == nil {
+		obj = make(map[string]any)
+	}
+
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal resolved spec: %w", err)
+	}
+	var specObj map[string]any
+	if unmarshalErr := json.Unmarshal(specJSON, &specObj); unmarshalErr != nil {
+		return "", fmt.Errorf("failed to marshal resolved spec: %w", unmarshalErr)
+	}
+
+	if kind == KindCronWorkflow {
+		cronSpec, _ := obj["spec"].(map[string]any)
+		if cronSpec == nil {
+			cronSpec = make(map[string]any)
+		}
+		cronSpec["workflowSpec"] = specObj
+		obj["spec"] = cronSpec
+	} else {
+		obj["spec"] = specObj
+	}
+
+	out, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal resolved manifest: %w", err)
+	}
+	return string(out), nil
+}
@@ -0,0 +1,257 @@
+package tools
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/clusterworkflowtemplate"
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+// resolverBundle has a WorkflowTemplate whose templates call each other, a
+// ClusterWorkflowTemplate and a reference cycle between the two.
+const resolverBundle = `apiVersion: argoproj.io/v1alpha1
+kind: WorkflowTemplate
+metadata:
+  name: lib
+spec:
+  templates:
+  - name: build
+    steps:
+    - - name: compile
+        template: compile
+      - name: publish
+        templateRef:
+          name: shared
+          template: upload
+          clusterScope: true
+  - name: compile
+    container:
+      image: golang:1.25
+  - name: loop
+    steps:
+    - - name: back
+        templateRef:
+          name: shared
+          template: bounce
+          clusterScope: true
+---
+apiVersion: argoproj.io/v1alpha1
+kind: ClusterWorkflowTemplate
+metadata:
+  name: shared
+spec:
+  templates:
+  - name: upload
+    container:
+      image: alpine:3.19
+  - name: bounce
+    steps:
+    - - name: again
+        templateRef:
+          name: lib
+          template: loop
+`
+
+func TestResolveManifest(t *testing.T) {
+	t.Run("expands references recursively from a bundle", func(t *testing.T) {
+		spec, kind, name, err := extractWorkflowSpec(`apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  name: app
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    dag:
+      tasks:
+      - name: build
+        templateRef:
+          name: lib
+          template: build
+      - name: upload
+        templateRef:
+          name: shared
+          template: upload
+          clusterScope: true
+  - name: lib-build
+    container:
+      image: alpine:3.19
+`)
+		require.NoError(t, err)
+
+		resolved, err := resolveManifest(t.Context(), nil, "", resolverBundle, kind, name, spec)
+		require.NoError(t, err)
+
+		names := make([]string, 0, len(resolved.Spec.Templates))
+		for _, tmpl := range resolved.Spec.Templates {
+			names = append(names, tmpl.Name)
+		}
+		// lib-build is taken by a local template, so the import is renamed
+		assert.Equal(t, []string{"main", "lib-build", "lib-compile", "shared-upload", "lib-build-2"}, names)
+		assert.Equal(t, 3, resolved.Imported)
+
+		tasks := resolved.Spec.Templates[0].DAG.Tasks
+		assert.Equal(t, "lib-build-2", tasks[0].Template)
+		assert.Nil(t, tasks[0].TemplateRef)
+		assert.Equal(t, "shared-upload", tasks[1].Template)
+
+		build := resolved.Spec.Templates[4]
+		assert.Equal(t, "lib-compile", build.Steps[0].Steps[0].Template)
+		assert.Equal(t, "shared-upload", build.Steps[0].Steps[1].Template)
+
+		assert.Equal(t, []TemplateDependency{
+			{Kind: "Workflow", Name: "app"},
+			{Kind: "WorkflowTemplate", Name: "lib", Template: "build", ResolvedAs: "lib-build-2", Depth: 1},
+			{Kind: "WorkflowTemplate", Name: "lib", Template: "compile", ResolvedAs: "lib-compile", Depth: 2},
+			{Kind: "ClusterWorkflowTemplate", Name: "shared", Template: "upload", ResolvedAs: "shared-upload", Depth: 2},
+			{Kind: "ClusterWorkflowTemplate", Name: "shared", Template: "upload", ResolvedAs: "shared-upload", Depth: 1, Reused: true},
+		}, resolved.Dependencies)
+		assert.Empty(t, resolved.Cycles)
+	})
+
+	t.Run("detects cycles and keeps them as recursion", func(t *testing.T) {
+		spec := &wfv1.WorkflowSpec{
+			Entrypoint: "main",
+			Templates: []wfv1.Template{{
+				Name: "main",
+				Steps: []wfv1.ParallelSteps{{Steps: []wfv1.WorkflowStep{{
+					Name:        "start",
+					TemplateRef: &wfv1.TemplateRef{Name: "lib", Template: "loop"},
+				}}}},
+			}},
+		}
+
+		resolved, err := resolveManifest(t.Context(), nil, "", resolverBundle, "Workflow", "loopy", spec)
+		require.NoError(t, err)
+
+		require.Len(t, resolved.Cycles, 1)
+		assert.Equal(t, "WorkflowTemplate/lib/loop -> ClusterWorkflowTemplate/shared/bounce -> WorkflowTemplate/lib/loop", resolved.Cycles[0])
+		bounce := resolved.Spec.Templates[1]
+		assert.Equal(t, "shared-bounce", bounce.Name)
+		assert.Equal(t, "lib-loop", bounce.Steps[0].Steps[0].Template)
+		assert.True(t, resolved.Dependencies[3].Cycle)
+
+		// The input spec is not modified
+		assert.NotNil(t, spec.Templates[0].Steps[0].Steps[0].TemplateRef)
+	})
+
+	t.Run("merges workflowTemplateRef from the cluster", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", true)
+		wftService := newMockWorkflowTemplateService(t)
+		cwftService := newMockClusterWorkflowTemplateService(t)
+		mockClient.SetWorkflowTemplateService(wftService)
+		mockClient.SetClusterWorkflowTemplateService(cwftService)
+
+		wftService.On("GetWorkflowTemplate", mock.Anything, &workflowtemplate.WorkflowTemplateGetRequest{Name: "base", Namespace: "argo"}).
+			Return(&wfv1.WorkflowTemplate{Spec: wfv1.WorkflowSpec{
+				Entrypoint: "main",
+				Arguments:  wfv1.Arguments{Parameters: []wfv1.Parameter{{Name: "env", Value: wfv1.AnyStringPtr("dev")}}},
+				Templates: []wfv1.Template{{
+					Name: "main",
+					Steps: []wfv1.ParallelSteps{{Steps: []wfv1.WorkflowStep{{
+						Name:        "notify",
+						TemplateRef: &wfv1.TemplateRef{Name: "notify", Template: "slack", ClusterScope: true},
+					}}}},
+				}},
+			}}, nil).Once()
+		cwftService.On("GetClusterWorkflowTemplate", mock.Anything, &clusterworkflowtemplate.ClusterWorkflowTemplateGetRequest{Name: "notify"}).
+			Return(&wfv1.ClusterWorkflowTemplate{Spec: wfv1.WorkflowSpec{
+				Templates: []wfv1.Template{{Name: "slack", HTTP: &wfv1.HTTP{URL: "https://hooks.example.com"}}},
+			}}, nil).Once()
+		defer wftService.AssertExpectations(t)
+		defer cwftService.AssertExpectations(t)
+
+		spec := &wfv1.WorkflowSpec{
+			WorkflowTemplateRef: &wfv1.WorkflowTemplateRef{Name: "base"},
+			Arguments:           wfv1.Arguments{Parameters: []wfv1.Parameter{{Name: "env", Value: wfv1.AnyStringPtr("prod")}}},
+		}
+		resolved, err := resolveManifest(t.Context(), mockClient, "argo", "", "Workflow", "from-ref", spec)
+		require.NoError(t, err)
+
+		assert.Nil(t, resolved.Spec.WorkflowTemplateRef)
+		assert.Equal(t, "main", resolved.Spec.Entrypoint)
+		assert.Equal(t, "prod", resolved.Spec.Arguments.Parameters[0].Value.String())
+		require.Len(t, resolved.Spec.Templates, 2)
+		assert.Equal(t, "notify-slack", resolved.Spec.Templates[0].Steps[0].Steps[0].Template)
+		assert.Equal(t, TemplateDependency{Kind: "WorkflowTemplate", Name: "base", Depth: 1}, resolved.Dependencies[1])
+	})
+
+	t.Run("templated references are left alone", func(t *testing.T) {
+		spec := &wfv1.WorkflowSpec{Templates: []wfv1.Template{{
+			Name: "main",
+			Steps: []wfv1.ParallelSteps{{Steps: []wfv1.WorkflowStep{{
+				Name:        "pick",
+				TemplateRef: &wfv1.TemplateRef{Name: "lib", Template: "{{inputs.parameters.which}}"},
+			}}}},
+		}}}
+		resolved, err := resolveManifest(t.Context(), nil, "", resolverBundle, "Workflow", "wf", spec)
+		require.NoError(t, err)
+		require.Len(t, resolved.Warnings, 1)
+		assert.Contains(t, resolved.Warnings[0], "cannot be resolved statically")
+		assert.NotNil(t, resolved.Spec.Templates[0].Steps[0].Steps[0].TemplateRef)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		missingTemplate := &wfv1.WorkflowSpec{Templates: []wfv1.Template{{
+			Name: "main",
+			DAG: &wfv1.DAGTemplate{Tasks: []wfv1.DAGTask{{
+				Name:        "a",
+				TemplateRef: &wfv1.TemplateRef{Name: "lib", Template: "missing"},
+			}}},
+		}}}
+		_, err := resolveManifest(t.Context(), nil, "", resolverBundle, "Workflow", "wf", missingTemplate)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `template "missing" not found in WorkflowTemplate "lib"`)
+
+		missingManifest := &wfv1.WorkflowSpec{WorkflowTemplateRef: &wfv1.WorkflowTemplateRef{Name: "other"}}
+		_, err = resolveManifest(t.Context(), nil, "", resolverBundle, "Workflow", "wf", missingManifest)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `WorkflowTemplate "other" not found in bundle`)
+
+		_, err = resolveManifest(t.Context(), nil, "", "", "Workflow", "wf", missingManifest)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "requires a cluster connection or a bundle")
+
+		mockClient := newMockClient(t, "argo", true)
+		wftService := newMockWorkflowTemplateService(t)
+		mockClient.SetWorkflowTemplateService(wftService)
+		wftService.On("GetWorkflowTemplate", mock.Anything, mock.Anything).Return(nil, errors.New("not found"))
+		_, err = resolveManifest(t.Context(), mockClient, "argo", "", "Workflow", "wf", missingManifest)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `failed to get WorkflowTemplate "other"`)
+	})
+}
+
+func TestParseTemplateBundle(t *testing.T) {
+	source, err := parseTemplateBundle(resolverBundle + "\n---\n")
+	require.NoError(t, err)
+	assert.Len(t, source.specs, 2)
+
+	_, err = parseTemplateBundle(resolverBundle + "\n---\nkind: Workflow\nmetadata:\n  name: wf\n")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bundles may only contain")
+
+	_, err = parseTemplateBundle(resolverBundle + "\n---\n" + resolverBundle)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `duplicate WorkflowTemplate "lib"`)
+}
+
+func TestRenderResolvedManifest(t *testing.T) {
+	spec := &wfv1.WorkflowSpec{Entrypoint: "main", Templates: []wfv1.Template{{Name: "main"}}}
+
+	out, err := renderResolvedManifest("kind: CronWorkflow\nmetadata:\n  name: nightly\nspec:\n  schedules: ['0 2 * * *']\n  workflowSpec:\n    workflowTemplateRef:\n      name: base\n", KindCronWorkflow, spec)
+	require.NoError(t, err)
+
+	var cron wfv1.CronWorkflow
+	require.NoError(t, yaml.Unmarshal([]byte(out), &cron))
+	assert.Equal(t, "nightly", cron.Name)
+	assert.Equal(t, []string{"0 2 * * *"}, cron.Spec.Schedules)
+	assert.Nil(t, cron.Spec.WorkflowSpec.WorkflowTemplateRef)
+	assert.Equal(t, "main", cron.Spec.WorkflowSpec.Entrypoint)
+}
@@ -0,0 +1,306 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/pmezard/go-difflib/difflib"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	jsonpatch "gopkg.in/evanphx/json-patch.v4"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/yaml"
+)
+
+// Patch types accepted by the update tools.
+const (
+	PatchTypeMerge     = "merge"     // JSON merge patch (RFC 7386)
+	PatchTypeStrategic = "strategic" // Kubernetes strategic merge patch
+	PatchTypeJSON      = "json"      // JSON patch (RFC 6902)
+)
+
+// maxUpdateBytes bounds the size of manifests and patches accepted by the update tools.
+const maxUpdateBytes = 1 << 20 // 1 MiB
+
+// updateSource validates that exactly one of manifest and patch is set and
+// returns the patch type to use, defaulting to a JSON merge patch.
+func updateSource(manifest, patch, patchType string) (string, error) {
+	hasManifest := strings.TrimSpace(manifest) != ""
+	hasPatch := strings.TrimSpace(patch) != ""
+	switch {
+	case hasManifest && hasPatch:
+		return "", fmt.Errorf("provide either manifest or patch, not both")
+	case !hasManifest && !hasPatch:
+		return "", fmt.Errorf("either manifest or patch is required")
+	}
+
+	if len(manifest) > maxUpdateBytes || len(patch) > maxUpdateBytes {
+		return "", fmt.Errorf("manifest or patch too large, max %d bytes", maxUpdateBytes)
+	}
+
+	if !hasPatch {
+		return "", nil
+	}
+	switch patchType = strings.TrimSpace(patchType); patchType {
+	case "":
+		return PatchTypeMerge, nil
+	case PatchTypeMerge, PatchTypeStrategic, PatchTypeJSON:
+		return patchType, nil
+	default:
+		return "", fmt.Errorf("invalid patchType %q, must be %q, %q or %q", patchType, PatchTypeMerge, PatchTypeStrategic, PatchTypeJSON)
+	}
+}
+
+// applyPatch applies a JSON or YAML patch of the given type to original and
+// decodes the result into out. original and out must be the same type, which
+// also provides the merge keys for strategic merge patches.
+func applyPatch(original any, patch, patchType string, out any) error {
+	originalJSON, err := json.Marshal(original)
+	if err != nil {
+		return fmt.Errorf("failed to encode current resource: %w", err)
+	}
+
+	patchJSON, err := yaml.YAMLToJSON([]byte(patch))
+	if err != nil {
+		return fmt.Errorf("failed to parse patch: %w", err)
+	}
+
+	var patched []byte
+	switch patchType {
+	case PatchTypeStrategic:
+		patched, err = strategicpatch.StrategicMergePatch(originalJSON, patchJSON, original)
+	case PatchTypeJSON:
+		var ops jsonpatch.Patch
+		ops, err = jsonpatch.DecodePatch(patchJSON)
+		if err == nil {
+			patched, err = ops.Apply(originalJSON)
+		}
+	default:
+		patched, err = jsonpatch.MergePatch(originalJSON, patchJSON)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to apply %s patch: %w", patchType, err)
+	}
+
+	if decodeErr := yaml.UnmarshalStrict(patched, out); decodeErr != nil {
+		return fmt.Errorf("patched resource is invalid: %w", decodeErr)
+	}
+	return nil
+}
+
+// checkResourceVersion returns a conflict error when the caller based its
+// change on a different version of the resource than the live one.
+func checkResourceVersion(kind, name, expected, current string) error {
+	if expected == "" || expected == current {
+		return nil
+	}
+	return fmt.Errorf("%s %q has been modified since it was read (resourceVersion %s, expected %s); "+
+		"get it again and reapply your changes", kind, name, current, expected)
+}
+
+// isConflict reports whether err is a Kubernetes conflict, which the Argo API
+// reports when an update is based on an outdated resourceVersion.
+func isConflict(err error) bool {
+	return apierrors.IsConflict(err) || status.Code(err) == codes.AlreadyExists
+}
+
+// diffDocument is the part of a resource compared by the update tools.
+type diffDocument struct {
+	Spec     any          `json:"spec"`
+	Metadata diffMetadata `json:"metadata"`
+}
+
+// diffMetadata holds the user-editable metadata compared by the update tools.
+type diffMetadata struct {
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// unifiedDiff returns a unified diff of the YAML encoding of before and after,
+// or an empty string when they are equal.
+func unifiedDiff(name string, before, after diffDocument) (string, error) {
+	beforeYAML, err := yaml.Marshal(before)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode current resource: %w", err)
+	}
+	afterYAML, err := yaml.Marshal(after)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode updated resource: %w", err)
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(string(beforeYAML)),
+		B:        difflib.SplitLines(string(afterYAML)),
+		FromFile: "a/" + name,
+		ToFile:   "b/" + name,
+		Context:  3,
+	})
+}
+
+// templateUpdate is an update of a WorkflowTemplate or ClusterWorkflowTemplate
+// from a full manifest or a patch.
+type templateUpdate struct {
+	// kind is KindWorkflowTemplate or KindClusterWorkflowTemplate.
+	kind string
+
+	// namespace is empty for a ClusterWorkflowTemplate.
+	namespace string
+
+	name            string
+	manifest        string
+	patch           string
+	patchType       string
+	resourceVersion string
+	dryRun          bool
+}
+
+// templateUpdateAPI holds the API calls for the kind of template being updated.
+type templateUpdateAPI[T any] struct {
+	get    func(ctx context.Context, name string) (*T, error)
+	lint   func(ctx context.Context, tmpl *T) error
+	update func(ctx context.Context, tmpl *T) (*T, error)
+}
+
+// templateObject is implemented by *wfv1.WorkflowTemplate and *wfv1.ClusterWorkflowTemplate.
+type templateObject[T any] interface {
+	*T
+	wfv1.WorkflowSpecHolder
+	GetObjectKind() schema.ObjectKind
+}
+
+// templateUpdateResult is the outcome of a template update.
+type templateUpdateResult struct {
+	name                    string
+	previousResourceVersion string
+	resourceVersion         string
+	diff                    string
+	text                    string
+	updated                 bool
+}
+
+// updateTemplate applies a template update. Changes based on an outdated
+// resourceVersion are rejected, and the change is diffed and linted before it
+// is applied; a dry run or an unchanged template stops before the update.
+func updateTemplate[T any, PT templateObject[T]](ctx context.Context, u templateUpdate, api templateUpdateAPI[T]) (*templateUpdateResult, error) {
+	noun := "workflow template"
+	if u.kind == KindClusterWorkflowTemplate {
+		noun = "cluster workflow template"
+	}
+	where := ""
+	if u.namespace != "" {
+		where = fmt.Sprintf(" in namespace %q", u.namespace)
+	}
+
+	patchType, err := updateSource(u.manifest, u.patch, u.patchType)
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(u.name)
+	expectedVersion := strings.TrimSpace(u.resourceVersion)
+
+	// Parse the replacement manifest, which names the template and carries
+	// the resourceVersion it was based on
+	desired := PT(new(T))
+	if patchType == "" {
+		if parseErr := yaml.UnmarshalStrict([]byte(u.manifest), desired); parseErr != nil {
+			return nil, fmt.Errorf("failed to parse %s manifest: %w", noun, parseErr)
+		}
+		if kind := desired.GetObjectKind().GroupVersionKind().Kind; kind != "" && kind != u.kind {
+			return nil, fmt.Errorf("manifest must be a %s, got %q", u.kind, kind)
+		}
+		if name == "" {
+			name = desired.GetName()
+		} else if desired.GetName() != "" && desired.GetName() != name {
+			return nil, fmt.Errorf("manifest name %q does not match name %q", desired.GetName(), name)
+		}
+		if expectedVersion == "" {
+			expectedVersion = desired.GetResourceVersion()
+		} else if desired.GetResourceVersion() != "" && desired.GetResourceVersion() != expectedVersion {
+			return nil, fmt.Errorf("manifest resourceVersion %q does not match resourceVersion %q", desired.GetResourceVersion(), expectedVersion)
+		}
+		if expectedVersion == "" {
+			return nil, fmt.Errorf("resourceVersion is required when replacing the full manifest; "+
+				"get the template with get_%s and pass its resourceVersion", strings.ReplaceAll(noun, " ", "_"))
+		}
+	}
+	if name == "" {
+		return nil, fmt.Errorf("%s name cannot be empty", noun)
+	}
+
+	currentObj, err := api.get(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", noun, err)
+	}
+	current := PT(currentObj)
+
+	if versionErr := checkResourceVersion(u.kind, name, expectedVersion, current.GetResourceVersion()); versionErr != nil {
+		return nil, versionErr
+	}
+
+	if patchType != "" {
+		if patchErr := applyPatch(current, u.patch, patchType, desired); patchErr != nil {
+			return nil, patchErr
+		}
+		if desired.GetName() != name || desired.GetNamespace() != u.namespace {
+			if u.namespace == "" {
+				return nil, fmt.Errorf("patch cannot change the name of a %s", u.kind)
+			}
+			return nil, fmt.Errorf("patch cannot change the name or namespace of a %s", u.kind)
+		}
+	}
+
+	// The update is conditional on the version the change was based on
+	desired.SetName(name)
+	desired.SetNamespace(u.namespace)
+	desired.SetResourceVersion(current.GetResourceVersion())
+
+	diff, err := unifiedDiff(name+".yaml",
+		diffDocument{Metadata: diffMetadata{Labels: current.GetLabels(), Annotations: current.GetAnnotations()}, Spec: current.GetWorkflowSpec()},
+		diffDocument{Metadata: diffMetadata{Labels: desired.GetLabels(), Annotations: desired.GetAnnotations()}, Spec: desired.GetWorkflowSpec()},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &templateUpdateResult{
+		name:                    name,
+		previousResourceVersion: current.GetResourceVersion(),
+		resourceVersion:         current.GetResourceVersion(),
+		diff:                    diff,
+	}
+	if diff == "" {
+		result.text = fmt.Sprintf("%s %q%s is unchanged", u.kind, name, where)
+		return result, nil
+	}
+
+	// Lint before applying so an invalid template never replaces a working one
+	if lintErr := api.lint(ctx, desired); lintErr != nil {
+		return nil, fmt.Errorf("updated %s failed lint, no changes were applied: %w", noun, lintErr)
+	}
+
+	if u.dryRun {
+		result.text = fmt.Sprintf("Dry run: %s %q%s would change as follows:\n\n%s", u.kind, name, where, diff)
+		return result, nil
+	}
+
+	updated, err := api.update(ctx, desired)
+	if err != nil {
+		if isConflict(err) {
+			return nil, fmt.Errorf("%s %q was modified concurrently, no changes were applied; get it again and reapply your changes: %w", u.kind, name, err)
+		}
+		return nil, fmt.Errorf("failed to update %s: %w", noun, err)
+	}
+
+	result.updated = true
+	result.resourceVersion = PT(updated).GetResourceVersion()
+	result.text = fmt.Sprintf("%s %q updated%s (resourceVersion %s -> %s):\n\n%s",
+		u.kind, name, where, result.previousResourceVersion, result.resourceVersion, diff)
+	return result, nil
+}
@@ -0,0 +1,69 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateSource(t *testing.T) {
+	tests := []struct {
+		name      string
+		manifest  string
+		patch     string
+		patchType string
+		want      string
+		wantErr   bool
+	}{
+		{name: "manifest", manifest: "kind: WorkflowTemplate", want: ""},
+		{name: "patch defaults to merge", patch: "{}", want: PatchTypeMerge},
+		{name: "strategic patch", patch: "{}", patchType: "strategic", want: PatchTypeStrategic},
+		{name: "JSON patch", patch: "[]", patchType: "json", want: PatchTypeJSON},
+		{name: "unknown patch type", patch: "{}", patchType: "apply", wantErr: true},
+		{name: "both", manifest: "kind: WorkflowTemplate", patch: "{}", wantErr: true},
+		{name: "neither", manifest: "  ", wantErr: true},
+		{name: "too large", patch: string(make([]byte, maxUpdateBytes+1)), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := updateSource(tt.manifest, tt.patch, tt.patchType)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestApplyPatch_RejectsUnknownFields(t *testing.T) {
+	current := liveWorkflowTemplate()
+	var out = *current
+
+	err := applyPatch(current, `{"spec":{"entrypont":"main"}}`, PatchTypeMerge, &out)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "patched resource is invalid")
+}
+
+func TestCheckResourceVersion(t *testing.T) {
+	require.NoError(t, checkResourceVersion(KindWorkflowTemplate, "t", "", "5"))
+	require.NoError(t, checkResourceVersion(KindWorkflowTemplate, "t", "5", "5"))
+	require.Error(t, checkResourceVersion(KindWorkflowTemplate, "t", "4", "5"))
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	before := diffDocument{Spec: map[string]string{"entrypoint": "a"}}
+	after := diffDocument{Spec: map[string]string{"entrypoint": "b"}}
+
+	diff, err := unifiedDiff("t.yaml", before, after)
+	require.NoError(t, err)
+	assert.Contains(t, diff, "--- a/t.yaml\n+++ b/t.yaml\n")
+	assert.Contains(t, diff, "-  entrypoint: a\n+  entrypoint: b\n")
+
+	diff, err = unifiedDiff("t.yaml", before, before)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+}
@@ -0,0 +1,203 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/utils/ptr"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
+)
+
+// TriggerCronWorkflowInput defines the input parameters for the trigger_cron_workflow tool.
+type TriggerCronWorkflowInput struct {
+	// Namespace is the Kubernetes namespace (uses default if not specified).
+	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace (uses default if not specified)"`
+
+	// Name is the CronWorkflow name.
+	Name string `json:"name" jsonschema:"CronWorkflow name,required"`
+
+	// Parameters are parameter overrides in key=value format.
+	Parameters []string `json:"parameters,omitempty" jsonschema:"Parameter overrides in key=value format"`
+
+	// Labels are additional labels to add to the workflow.
+	Labels map[string]string `json:"labels,omitempty" jsonschema:"Additional labels to add"`
+
+	// Wait waits for the workflow to complete before returning.
+	Wait bool `json:"wait,omitempty" jsonschema:"Wait for the workflow to complete and return its final status"`
+
+	// Timeout is the maximum time to wait (e.g., '5m', '1h'). Default: no timeout.
+	Timeout string `json:"timeout,omitempty" jsonschema:"Maximum time to wait when wait is set (e.g. 5m or 1h). Default: no timeout"`
+}
+
+// TriggerCronWorkflowOutput defines the output for the trigger_cron_workflow tool.
+type TriggerCronWorkflowOutput struct {
+	// Name is the name of the new workflow.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the new workflow.
+	Namespace string `json:"namespace"`
+
+	// UID is the unique identifier of the new workflow.
+	UID string `json:"uid"`
+
+	// CronWorkflow is the CronWorkflow the workflow was submitted from.
+	CronWorkflow string `json:"cronWorkflow"`
+
+	// Phase is the workflow phase, final when the trigger waited for completion.
+	Phase string `json:"phase"`
+
+	// Message provides additional status information.
+	Message string `json:"message,omitempty"`
+
+	// Duration is the workflow duration when the trigger waited for completion.
+	Duration string `json:"duration,omitempty"`
+
+	// TimedOut indicates if waiting for completion timed out.
+	TimedOut bool `json:"timedOut,omitempty"`
+}
+
+// TriggerCronWorkflowTool returns the MCP tool definition for trigger_cron_workflow.
+func TriggerCronWorkflowTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "trigger_cron_workflow",
+		Description: "Run a CronWorkflow now, outside its schedule, like argo submit --from cronwf/NAME. " +
+			"Supports parameter overrides and labels, and can wait for the workflow to complete.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr.To(false),
+		},
+	}
+}
+
+// TriggerCronWorkflowHandler returns a handler function for the trigger_cron_workflow tool.
+func TriggerCronWorkflowHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, TriggerCronWorkflowInput) (*mcp.CallToolResult, *TriggerCronWorkflowOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input TriggerCronWorkflowInput) (*mcp.CallToolResult, *TriggerCronWorkflowOutput, error) {
+		name, err := ValidateName(input.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if err = validateParameters(input.Parameters); err != nil {
+			return nil, nil, err
+		}
+
+		labels, err := formatSubmitLabels(input.Labels)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var timeout time.Duration
+		if input.Timeout != "" {
+			if !input.Wait {
+				return nil, nil, toolerror.Invalid("timeout", errors.New("timeout requires wait"))
+			}
+			timeout, err = time.ParseDuration(input.Timeout)
+			if err != nil {
+				return nil, nil, toolerror.Invalid("timeout", fmt.Errorf("invalid timeout format: %w", err))
+			}
+			if timeout <= 0 {
+				return nil, nil, toolerror.Invalid("timeout", errors.New("invalid timeout: must be a positive duration"))
+			}
+		}
+
+		namespace := ResolveNamespace(input.Namespace, client)
+		wfService := client.WorkflowService()
+
+		// The server converts the CronWorkflow and applies the overrides, as the CLI does
+		wf, err := wfService.SubmitWorkflow(ctx, &workflow.WorkflowSubmitRequest{
+			Namespace:    namespace,
+			ResourceKind: "cronwf",
+			ResourceName: name,
+			SubmitOptions: &wfv1.SubmitOpts{
+				Parameters: input.Parameters,
+				Labels:     labels,
+			},
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to trigger cron workflow: %w", err)
+		}
+
+		output := &TriggerCronWorkflowOutput{
+			Name:         wf.Name,
+			Namespace:    wf.Namespace,
+			UID:          string(wf.UID),
+			CronWorkflow: name,
+			Phase:        string(wf.Status.Phase),
+		}
+		if output.Namespace == "" {
+			output.Namespace = namespace
+		}
+
+		if !input.Wait {
+			return TextResult(fmt.Sprintf("Triggered CronWorkflow %q: workflow %q submitted in namespace %q (UID: %s)",
+				name, output.Name, output.Namespace, output.UID)), output, nil
+		}
+
+		var waitCtx context.Context
+		var cancel context.CancelFunc
+		if timeout > 0 {
+			waitCtx, cancel = context.WithTimeout(ctx, timeout)
+		} else {
+			waitCtx, cancel = context.WithCancel(ctx)
+		}
+		defer cancel()
+
+		last, timedOut, err := waitForWorkflow(waitCtx, wfService, output.Namespace, output.Name)
+		if err != nil {
+			return nil, nil, err
+		}
+		output.TimedOut = timedOut
+		if last != nil {
+			output.Phase = string(last.Status.Phase)
+			output.Message = last.Status.Message
+			if !last.Status.StartedAt.IsZero() {
+				end := last.Status.FinishedAt.Time
+				if end.IsZero() {
+					end = time.Now()
+				}
+				output.Duration = formatDuration(end.Sub(last.Status.StartedAt.Time))
+			}
+		}
+
+		resultText := fmt.Sprintf("Triggered CronWorkflow %q: workflow %q in namespace %q: %s", name, output.Name, output.Namespace, output.Phase)
+		if output.Duration != "" {
+			resultText += fmt.Sprintf(" (duration: %s)", output.Duration)
+		}
+		if output.TimedOut {
+			resultText += " [timed out]"
+		}
+		return TextResult(resultText), output, nil
+	}
+}
+
+// formatSubmitLabels validates labels and encodes them in the comma-separated
+// key=value form of SubmitOpts.
+func formatSubmitLabels(labels map[string]string) (string, error) {
+	keys := make([]string, 0, len(labels))
+	for key, value := range labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return "", fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return "", fmt.Errorf("invalid value for label %q: %s", key, strings.Join(errs, "; "))
+		}
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+labels[key])
+	}
+	return strings.Join(pairs, ","), nil
+}
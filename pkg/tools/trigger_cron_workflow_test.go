@@ -0,0 +1,149 @@
+package tools
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+)
+
+func TestTriggerCronWorkflowTool(t *testing.T) {
+	tool := TriggerCronWorkflowTool()
+
+	assert.Equal(t, "trigger_cron_workflow", tool.Name)
+	assert.Contains(t, tool.Description, "cronwf")
+	require.NotNil(t, tool.Annotations)
+	assert.False(t, *tool.Annotations.DestructiveHint)
+}
+
+func TestTriggerCronWorkflowHandler(t *testing.T) {
+	submitted := &wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: "nightly-x7k2p", Namespace: "argo", UID: "uid-123"},
+		Status:     wfv1.WorkflowStatus{Phase: wfv1.WorkflowPending},
+	}
+	startedAt := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		setupMock func(*mocks.MockWorkflowServiceClient)
+		validate  func(*testing.T, *TriggerCronWorkflowOutput, *mcp.CallToolResult)
+		name      string
+		wantErr   string
+		input     TriggerCronWorkflowInput
+	}{
+		{
+			name: "submits from the cron workflow with overrides",
+			input: TriggerCronWorkflowInput{
+				Namespace:  "argo",
+				Name:       "nightly",
+				Parameters: []string{"date=2025-01-15", "mode=full"},
+				Labels:     map[string]string{"trigger": "manual", "team": "data"},
+			},
+			setupMock: func(m *mocks.MockWorkflowServiceClient) {
+				m.On("SubmitWorkflow", mock.Anything, mock.MatchedBy(func(req *workflow.WorkflowSubmitRequest) bool {
+					return req.Namespace == "argo" &&
+						req.ResourceKind == "cronwf" &&
+						req.ResourceName == "nightly" &&
+						req.SubmitOptions.Labels == "team=data,trigger=manual" &&
+						len(req.SubmitOptions.Parameters) == 2
+				})).Return(submitted, nil)
+			},
+			validate: func(t *testing.T, output *TriggerCronWorkflowOutput, result *mcp.CallToolResult) {
+				assert.Equal(t, "nightly-x7k2p", output.Name)
+				assert.Equal(t, "argo", output.Namespace)
+				assert.Equal(t, "uid-123", output.UID)
+				assert.Equal(t, "nightly", output.CronWorkflow)
+				assert.Equal(t, "Pending", output.Phase)
+
+				text, ok := result.Content[0].(*mcp.TextContent)
+				require.True(t, ok)
+				assert.Contains(t, text.Text, "uid-123")
+			},
+		},
+		{
+			name:  "waits for completion",
+			input: TriggerCronWorkflowInput{Name: "nightly", Wait: true, Timeout: "10m"},
+			setupMock: func(m *mocks.MockWorkflowServiceClient) {
+				m.On("SubmitWorkflow", mock.Anything, mock.Anything).Return(submitted, nil)
+				m.On("WatchWorkflows", mock.Anything, mock.MatchedBy(func(req *workflow.WatchWorkflowsRequest) bool {
+					return req.Namespace == "argo" && req.ListOptions.FieldSelector == "metadata.name=nightly-x7k2p"
+				})).Return(mocks.NewMockWatchWorkflowsStream([]*workflow.WorkflowWatchEvent{
+					mocks.NewWatchEvent("MODIFIED", &wfv1.Workflow{
+						ObjectMeta: metav1.ObjectMeta{Name: "nightly-x7k2p"},
+						Status: wfv1.WorkflowStatus{
+							Phase:      wfv1.WorkflowSucceeded,
+							StartedAt:  metav1.Time{Time: startedAt},
+							FinishedAt: metav1.Time{Time: startedAt.Add(90 * time.Second)},
+						},
+					}),
+				}), nil)
+			},
+			validate: func(t *testing.T, output *TriggerCronWorkflowOutput, _ *mcp.CallToolResult) {
+				assert.Equal(t, "Succeeded", output.Phase)
+				assert.Equal(t, "1m30s", output.Duration)
+				assert.False(t, output.TimedOut)
+			},
+		},
+		{
+			name:  "submit failure",
+			input: TriggerCronWorkflowInput{Name: "missing"},
+			setupMock: func(m *mocks.MockWorkflowServiceClient) {
+				m.On("SubmitWorkflow", mock.Anything, mock.Anything).Return(nil, errors.New("cronworkflow not found"))
+			},
+			wantErr: "failed to trigger cron workflow",
+		},
+		{
+			name:    "invalid parameter",
+			input:   TriggerCronWorkflowInput{Name: "nightly", Parameters: []string{"date"}},
+			wantErr: "expected key=value",
+		},
+		{
+			name:    "invalid label",
+			input:   TriggerCronWorkflowInput{Name: "nightly", Labels: map[string]string{"team": "a,b"}},
+			wantErr: "invalid value for label",
+		},
+		{
+			name:    "timeout without wait",
+			input:   TriggerCronWorkflowInput{Name: "nightly", Timeout: "5m"},
+			wantErr: "timeout requires wait",
+		},
+		{
+			name:    "empty name",
+			input:   TriggerCronWorkflowInput{},
+			wantErr: "name cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := newMockClient(t, "default", true)
+			mockService := newMockWorkflowService(t)
+			mockClient.SetWorkflowService(mockService)
+			if tt.setupMock != nil {
+				tt.setupMock(mockService)
+			}
+			defer mockService.AssertExpectations(t)
+
+			handler := TriggerCronWorkflowHandler(mockClient)
+			result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, tt.input)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, output)
+			tt.validate(t, output, result)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/clusterworkflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/utils/ptr"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// UpdateClusterWorkflowTemplateInput defines the input parameters for the update_cluster_workflow_template tool.
+type UpdateClusterWorkflowTemplateInput struct {
+	// Name is the ClusterWorkflowTemplate name. Required with a patch; defaults to the manifest's name.
+	Name string `json:"name,omitempty" jsonschema:"ClusterWorkflowTemplate name (required with patch, defaults to the manifest's name)"`
+
+	// Manifest is a full WorkflowTemplate YAML manifest that replaces the current one.
+	Manifest string `json:"manifest,omitempty" jsonschema:"Full ClusterWorkflowTemplate YAML manifest replacing the current one. Must carry metadata.resourceVersion unless resourceVersion is set"`
+
+	// Patch is a JSON or YAML patch applied to the current ClusterWorkflowTemplate.
+	Patch string `json:"patch,omitempty" jsonschema:"Patch in JSON or YAML applied to the current ClusterWorkflowTemplate (alternative to manifest)"`
+
+	// PatchType is the patch format: merge, strategic or json.
+	PatchType string `json:"patchType,omitempty" jsonschema:"Patch format: merge (JSON merge patch, default), strategic (strategic merge patch) or json (JSON patch)"`
+
+	// ResourceVersion is the version the change is based on.
+	ResourceVersion string `json:"resourceVersion,omitempty" jsonschema:"resourceVersion the change is based on. The update is rejected if the template has changed since"`
+
+	// DryRun lints and diffs the change without applying it.
+	DryRun bool `json:"dryRun,omitempty" jsonschema:"Lint and diff the change without applying it"`
+}
+
+// UpdateClusterWorkflowTemplateOutput defines the output for the update_cluster_workflow_template tool.
+type UpdateClusterWorkflowTemplateOutput struct {
+	Name                    string `json:"name"`
+	PreviousResourceVersion string `json:"previousResourceVersion"`
+	ResourceVersion         string `json:"resourceVersion"`
+	Diff                    string `json:"diff,omitempty"`
+	Changed                 bool   `json:"changed"`
+	Updated                 bool   `json:"updated"`
+	DryRun                  bool   `json:"dryRun,omitempty"`
+}
+
+// UpdateClusterWorkflowTemplateTool returns the MCP tool definition for update_cluster_workflow_template.
+func UpdateClusterWorkflowTemplateTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "update_cluster_workflow_template",
+		Description: "Update an existing ClusterWorkflowTemplate from a full manifest or a JSON merge, strategic merge or JSON patch. " +
+			"The change is linted before it is applied and a unified diff of the spec is returned. " +
+			"Updates are rejected if the template has changed since the given resourceVersion, so concurrent edits are never overwritten.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr.To(true),
+		},
+	}
+}
+
+// UpdateClusterWorkflowTemplateHandler returns a handler function for the update_cluster_workflow_template tool.
+func UpdateClusterWorkflowTemplateHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, UpdateClusterWorkflowTemplateInput) (*mcp.CallToolResult, *UpdateClusterWorkflowTemplateOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input UpdateClusterWorkflowTemplateInput) (*mcp.CallToolResult, *UpdateClusterWorkflowTemplateOutput, error) {
+		cwftService, err := client.ClusterWorkflowTemplateService()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get cluster workflow template service: %w", err)
+		}
+
+		result, err := updateTemplate(ctx, templateUpdate{
+			kind:            KindClusterWorkflowTemplate,
+			name:            input.Name,
+			manifest:        input.Manifest,
+			patch:           input.Patch,
+			patchType:       input.PatchType,
+			resourceVersion: input.ResourceVersion,
+			dryRun:          input.DryRun,
+		}, templateUpdateAPI[wfv1.ClusterWorkflowTemplate]{
+			get: func(ctx context.Context, name string) (*wfv1.ClusterWorkflowTemplate, error) {
+				return cwftService.GetClusterWorkflowTemplate(ctx, &clusterworkflowtemplate.ClusterWorkflowTemplateGetRequest{Name: name})
+			},
+			lint: func(ctx context.Context, tmpl *wfv1.ClusterWorkflowTemplate) error {
+				_, lintErr := cwftService.LintClusterWorkflowTemplate(ctx, &clusterworkflowtemplate.ClusterWorkflowTemplateLintRequest{Template: tmpl})
+				return lintErr
+			},
+			update: func(ctx context.Context, tmpl *wfv1.ClusterWorkflowTemplate) (*wfv1.ClusterWorkflowTemplate, error) {
+				return cwftService.UpdateClusterWorkflowTemplate(ctx, &clusterworkflowtemplate.ClusterWorkflowTemplateUpdateRequest{Name: tmpl.Name, Template: tmpl})
+			},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		output := &UpdateClusterWorkflowTemplateOutput{
+			Name:                    result.name,
+			PreviousResourceVersion: result.previousResourceVersion,
+			ResourceVersion:         result.resourceVersion,
+			Diff:                    result.diff,
+			Changed:                 result.diff != "",
+			Updated:                 result.updated,
+			DryRun:                  input.DryRun,
+		}
+		return TextResult(result.text), output, nil
+	}
+}
@@ -0,0 +1,143 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/clusterworkflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+)
+
+// liveClusterWorkflowTemplate returns the ClusterWorkflowTemplate the update tests start from.
+func liveClusterWorkflowTemplate() *wfv1.ClusterWorkflowTemplate {
+	wft := liveWorkflowTemplate()
+	return &wfv1.ClusterWorkflowTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "hello-world-cluster-template",
+			ResourceVersion: "200",
+		},
+		Spec: wft.Spec,
+	}
+}
+
+func TestUpdateClusterWorkflowTemplateTool(t *testing.T) {
+	tool := UpdateClusterWorkflowTemplateTool()
+
+	assert.Equal(t, "update_cluster_workflow_template", tool.Name)
+	assert.Contains(t, tool.Description, "ClusterWorkflowTemplate")
+	require.NotNil(t, tool.Annotations)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+}
+
+func TestUpdateClusterWorkflowTemplateHandler(t *testing.T) {
+	manifest := loadTestWorkflowTemplateYAML(t, "simple_cluster_workflow_template.yaml")
+
+	tests := []struct {
+		setupMock func(*mocks.MockClusterWorkflowTemplateServiceClient)
+		validate  func(*testing.T, *UpdateClusterWorkflowTemplateOutput)
+		name      string
+		wantErr   string
+		input     UpdateClusterWorkflowTemplateInput
+	}{
+		{
+			name: "merge patch updates and returns diff",
+			input: UpdateClusterWorkflowTemplateInput{
+				Name:            "hello-world-cluster-template",
+				Patch:           "spec:\n  entrypoint: main\n",
+				ResourceVersion: "200",
+			},
+			setupMock: func(m *mocks.MockClusterWorkflowTemplateServiceClient) {
+				m.On("GetClusterWorkflowTemplate", mock.Anything, mock.Anything).Return(liveClusterWorkflowTemplate(), nil)
+				m.On("LintClusterWorkflowTemplate", mock.Anything, mock.Anything).Return(&wfv1.ClusterWorkflowTemplate{}, nil)
+				m.On("UpdateClusterWorkflowTemplate", mock.Anything, mock.MatchedBy(func(req *clusterworkflowtemplate.ClusterWorkflowTemplateUpdateRequest) bool {
+					return req.Name == "hello-world-cluster-template" && req.Template.ResourceVersion == "200"
+				})).Return(&wfv1.ClusterWorkflowTemplate{ObjectMeta: metav1.ObjectMeta{ResourceVersion: "201"}}, nil)
+			},
+			validate: func(t *testing.T, output *UpdateClusterWorkflowTemplateOutput) {
+				assert.True(t, output.Updated)
+				assert.Equal(t, "201", output.ResourceVersion)
+				assert.Contains(t, output.Diff, "+  entrypoint: main")
+			},
+		},
+		{
+			name: "full manifest with resourceVersion dry run",
+			input: UpdateClusterWorkflowTemplateInput{
+				Manifest:        manifest,
+				ResourceVersion: "200",
+				DryRun:          true,
+			},
+			setupMock: func(m *mocks.MockClusterWorkflowTemplateServiceClient) {
+				m.On("GetClusterWorkflowTemplate", mock.Anything, mock.MatchedBy(func(req *clusterworkflowtemplate.ClusterWorkflowTemplateGetRequest) bool {
+					return req.Name == "hello-world-cluster-template"
+				})).Return(liveClusterWorkflowTemplate(), nil)
+				m.On("LintClusterWorkflowTemplate", mock.Anything, mock.Anything).Return(&wfv1.ClusterWorkflowTemplate{}, nil)
+			},
+			validate: func(t *testing.T, output *UpdateClusterWorkflowTemplateOutput) {
+				assert.True(t, output.DryRun)
+				assert.False(t, output.Updated)
+			},
+		},
+		{
+			name: "stale resourceVersion is rejected",
+			input: UpdateClusterWorkflowTemplateInput{
+				Name:            "hello-world-cluster-template",
+				Patch:           `{"spec":{"entrypoint":"main"}}`,
+				ResourceVersion: "199",
+			},
+			setupMock: func(m *mocks.MockClusterWorkflowTemplateServiceClient) {
+				m.On("GetClusterWorkflowTemplate", mock.Anything, mock.Anything).Return(liveClusterWorkflowTemplate(), nil)
+			},
+			wantErr: "has been modified since it was read",
+		},
+		{
+			name: "concurrent update is reported as conflict",
+			input: UpdateClusterWorkflowTemplateInput{
+				Name:  "hello-world-cluster-template",
+				Patch: `{"spec":{"entrypoint":"main"}}`,
+			},
+			setupMock: func(m *mocks.MockClusterWorkflowTemplateServiceClient) {
+				m.On("GetClusterWorkflowTemplate", mock.Anything, mock.Anything).Return(liveClusterWorkflowTemplate(), nil)
+				m.On("LintClusterWorkflowTemplate", mock.Anything, mock.Anything).Return(&wfv1.ClusterWorkflowTemplate{}, nil)
+				m.On("UpdateClusterWorkflowTemplate", mock.Anything, mock.Anything).Return(nil, status.Error(codes.AlreadyExists, "the object has been modified"))
+			},
+			wantErr: "modified concurrently",
+		},
+		{
+			name:      "manifest without resourceVersion is rejected",
+			input:     UpdateClusterWorkflowTemplateInput{Manifest: manifest},
+			setupMock: func(_ *mocks.MockClusterWorkflowTemplateServiceClient) {},
+			wantErr:   "resourceVersion is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := newMockClient(t, "default", true)
+			mockService := newMockClusterWorkflowTemplateService(t)
+			mockClient.SetClusterWorkflowTemplateService(mockService)
+			tt.setupMock(mockService)
+			defer mockService.AssertExpectations(t)
+
+			handler := UpdateClusterWorkflowTemplateHandler(mockClient)
+			_, output, err := handler(t.Context(), &mcp.CallToolRequest{}, tt.input)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, output)
+			tt.validate(t, output)
+		})
+	}
+}
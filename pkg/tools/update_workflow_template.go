@@ -0,0 +1,112 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/utils/ptr"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+// UpdateWorkflowTemplateInput defines the input parameters for the update_workflow_template tool.
+type UpdateWorkflowTemplateInput struct {
+	// Namespace is the Kubernetes namespace (uses default if not specified).
+	Namespace string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace (uses default if not specified)"`
+
+	// Name is the WorkflowTemplate name. Required with a patch; defaults to the manifest's name.
+	Name string `json:"name,omitempty" jsonschema:"WorkflowTemplate name (required with patch, defaults to the manifest's name)"`
+
+	// Manifest is a full WorkflowTemplate YAML manifest that replaces the current one.
+	Manifest string `json:"manifest,omitempty" jsonschema:"Full WorkflowTemplate YAML manifest replacing the current one. Must carry metadata.resourceVersion unless resourceVersion is set"`
+
+	// Patch is a JSON or YAML patch applied to the current WorkflowTemplate.
+	Patch string `json:"patch,omitempty" jsonschema:"Patch in JSON or YAML applied to the current WorkflowTemplate (alternative to manifest)"`
+
+	// PatchType is the patch format: merge, strategic or json.
+	PatchType string `json:"patchType,omitempty" jsonschema:"Patch format: merge (JSON merge patch, default), strategic (strategic merge patch) or json (JSON patch)"`
+
+	// ResourceVersion is the version the change is based on.
+	ResourceVersion string `json:"resourceVersion,omitempty" jsonschema:"resourceVersion the change is based on. The update is rejected if the template has changed since"`
+
+	// DryRun lints and diffs the change without applying it.
+	DryRun bool `json:"dryRun,omitempty" jsonschema:"Lint and diff the change without applying it"`
+}
+
+// UpdateWorkflowTemplateOutput defines the output for the update_workflow_template tool.
+type UpdateWorkflowTemplateOutput struct {
+	Name                    string `json:"name"`
+	Namespace               string `json:"namespace"`
+	PreviousResourceVersion string `json:"previousResourceVersion"`
+	ResourceVersion         string `json:"resourceVersion"`
+	Diff                    string `json:"diff,omitempty"`
+	Changed                 bool   `json:"changed"`
+	Updated                 bool   `json:"updated"`
+	DryRun                  bool   `json:"dryRun,omitempty"`
+}
+
+// UpdateWorkflowTemplateTool returns the MCP tool definition for update_workflow_template.
+func UpdateWorkflowTemplateTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "update_workflow_template",
+		Description: "Update an existing WorkflowTemplate from a full manifest or a JSON merge, strategic merge or JSON patch. " +
+			"The change is linted before it is applied and a unified diff of the spec is returned. " +
+			"Updates are rejected if the template has changed since the given resourceVersion, so concurrent edits are never overwritten.",
+		Annotations: &mcp.ToolAnnotations{
+			DestructiveHint: ptr.To(true),
+		},
+	}
+}
+
+// UpdateWorkflowTemplateHandler returns a handler function for the update_workflow_template tool.
+func UpdateWorkflowTemplateHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, UpdateWorkflowTemplateInput) (*mcp.CallToolResult, *UpdateWorkflowTemplateOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input UpdateWorkflowTemplateInput) (*mcp.CallToolResult, *UpdateWorkflowTemplateOutput, error) {
+		namespace := ResolveNamespace(input.Namespace, client)
+
+		wftService, err := client.WorkflowTemplateService()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get workflow template service: %w", err)
+		}
+
+		result, err := updateTemplate(ctx, templateUpdate{
+			kind:            KindWorkflowTemplate,
+			namespace:       namespace,
+			name:            input.Name,
+			manifest:        input.Manifest,
+			patch:           input.Patch,
+			patchType:       input.PatchType,
+			resourceVersion: input.ResourceVersion,
+			dryRun:          input.DryRun,
+		}, templateUpdateAPI[wfv1.WorkflowTemplate]{
+			get: func(ctx context.Context, name string) (*wfv1.WorkflowTemplate, error) {
+				return wftService.GetWorkflowTemplate(ctx, &workflowtemplate.WorkflowTemplateGetRequest{Namespace: namespace, Name: name})
+			},
+			lint: func(ctx context.Context, tmpl *wfv1.WorkflowTemplate) error {
+				_, lintErr := wftService.LintWorkflowTemplate(ctx, &workflowtemplate.WorkflowTemplateLintRequest{Namespace: namespace, Template: tmpl})
+				return lintErr
+			},
+			update: func(ctx context.Context, tmpl *wfv1.WorkflowTemplate) (*wfv1.WorkflowTemplate, error) {
+				return wftService.UpdateWorkflowTemplate(ctx, &workflowtemplate.WorkflowTemplateUpdateRequest{Namespace: namespace, Name: tmpl.Name, Template: tmpl})
+			},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		output := &UpdateWorkflowTemplateOutput{
+			Name:                    result.name,
+			Namespace:               namespace,
+			PreviousResourceVersion: result.previousResourceVersion,
+			ResourceVersion:         result.resourceVersion,
+			Diff:                    result.diff,
+			Changed:                 result.diff != "",
+			Updated:                 result.updated,
+			DryRun:                  input.DryRun,
+		}
+		return TextResult(result.text), output, nil
+	}
+}
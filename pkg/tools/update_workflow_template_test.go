@@ -0,0 +1,253 @@
+package tools
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflowtemplate"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+)
+
+// liveWorkflowTemplate returns the WorkflowTemplate the update tests start from.
+func liveWorkflowTemplate() *wfv1.WorkflowTemplate {
+	return &wfv1.WorkflowTemplate{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "hello-world-template",
+			Namespace:       "default",
+			ResourceVersion: "100",
+			Labels:          map[string]string{"team": "data"},
+		},
+		Spec: wfv1.WorkflowSpec{
+			Entrypoint: "whalesay",
+			Templates: []wfv1.Template{{
+				Name: "whalesay",
+				Container: &corev1.Container{
+					Image:   "docker/whalesay:latest",
+					Command: []string{"cowsay"},
+					Args:    []string{"hello world"},
+				},
+			}},
+		},
+	}
+}
+
+func TestUpdateWorkflowTemplateTool(t *testing.T) {
+	tool := UpdateWorkflowTemplateTool()
+
+	assert.Equal(t, "update_workflow_template", tool.Name)
+	assert.Contains(t, tool.Description, "resourceVersion")
+	require.NotNil(t, tool.Annotations)
+	assert.True(t, *tool.Annotations.DestructiveHint)
+}
+
+func TestUpdateWorkflowTemplateHandler(t *testing.T) {
+	manifest := loadTestWorkflowTemplateYAML(t, "simple_workflow_template.yaml")
+
+	tests := []struct {
+		setupMock func(*mocks.MockWorkflowTemplateServiceClient)
+		validate  func(*testing.T, *UpdateWorkflowTemplateOutput, *mcp.CallToolResult)
+		name      string
+		wantErr   string
+		input     UpdateWorkflowTemplateInput
+	}{
+		{
+			name: "merge patch updates and returns diff",
+			input: UpdateWorkflowTemplateInput{
+				Namespace: "default",
+				Name:      "hello-world-template",
+				Patch:     `{"spec":{"entrypoint":"main","templates":[{"name":"main","container":{"image":"alpine:3.20"}}]}}`,
+			},
+			setupMock: func(m *mocks.MockWorkflowTemplateServiceClient) {
+				m.On("GetWorkflowTemplate", mock.Anything, mock.Anything).Return(liveWorkflowTemplate(), nil)
+				m.On("LintWorkflowTemplate", mock.Anything, mock.Anything).Return(&wfv1.WorkflowTemplate{}, nil)
+				m.On("UpdateWorkflowTemplate", mock.Anything, mock.MatchedBy(func(req *workflowtemplate.WorkflowTemplateUpdateRequest) bool {
+					return req.Template.ResourceVersion == "100" &&
+						req.Template.Spec.Entrypoint == "main" &&
+						req.Template.Labels["team"] == "data"
+				})).Return(&wfv1.WorkflowTemplate{ObjectMeta: metav1.ObjectMeta{Name: "hello-world-template", ResourceVersion: "101"}}, nil)
+			},
+			validate: func(t *testing.T, output *UpdateWorkflowTemplateOutput, result *mcp.CallToolResult) {
+				assert.True(t, output.Updated)
+				assert.True(t, output.Changed)
+				assert.Equal(t, "100", output.PreviousResourceVersion)
+				assert.Equal(t, "101", output.ResourceVersion)
+				assert.Contains(t, output.Diff, "--- a/hello-world-template.yaml")
+				assert.Contains(t, output.Diff, "-  entrypoint: whalesay")
+				assert.Contains(t, output.Diff, "+  entrypoint: main")
+				text, ok := result.Content[0].(*mcp.TextContent)
+				require.True(t, ok)
+				assert.Contains(t, text.Text, "100 -> 101")
+			},
+		},
+		{
+			name: "strategic merge patch keeps other templates",
+			input: UpdateWorkflowTemplateInput{
+				Name:      "hello-world-template",
+				Patch:     "metadata:\n  labels:\n    owner: platform\n",
+				PatchType: PatchTypeStrategic,
+				DryRun:    true,
+			},
+			setupMock: func(m *mocks.MockWorkflowTemplateServiceClient) {
+				m.On("GetWorkflowTemplate", mock.Anything, mock.Anything).Return(liveWorkflowTemplate(), nil)
+				m.On("LintWorkflowTemplate", mock.Anything, mock.Anything).Return(&wfv1.WorkflowTemplate{}, nil)
+			},
+			validate: func(t *testing.T, output *UpdateWorkflowTemplateOutput, _ *mcp.CallToolResult) {
+				assert.True(t, output.DryRun)
+				assert.True(t, output.Changed)
+				assert.False(t, output.Updated)
+				assert.Contains(t, output.Diff, "+    owner: platform")
+				assert.NotContains(t, output.Diff, "-    team: data")
+			},
+		},
+		{
+			name: "JSON patch",
+			input: UpdateWorkflowTemplateInput{
+				Name:      "hello-world-template",
+				Patch:     `[{"op":"replace","path":"/spec/templates/0/container/image","value":"docker/whalesay:v2"}]`,
+				PatchType: PatchTypeJSON,
+				DryRun:    true,
+			},
+			setupMock: func(m *mocks.MockWorkflowTemplateServiceClient) {
+				m.On("GetWorkflowTemplate", mock.Anything, mock.Anything).Return(liveWorkflowTemplate(), nil)
+				m.On("LintWorkflowTemplate", mock.Anything, mock.Anything).Return(&wfv1.WorkflowTemplate{}, nil)
+			},
+			validate: func(t *testing.T, output *UpdateWorkflowTemplateOutput, _ *mcp.CallToolResult) {
+				assert.Contains(t, output.Diff, "+      image: docker/whalesay:v2")
+			},
+		},
+		{
+			name: "full manifest with resourceVersion",
+			input: UpdateWorkflowTemplateInput{
+				Namespace:       "default",
+				Manifest:        manifest,
+				ResourceVersion: "100",
+			},
+			setupMock: func(m *mocks.MockWorkflowTemplateServiceClient) {
+				m.On("GetWorkflowTemplate", mock.Anything, mock.Anything).Return(liveWorkflowTemplate(), nil)
+				m.On("LintWorkflowTemplate", mock.Anything, mock.Anything).Return(&wfv1.WorkflowTemplate{}, nil)
+				m.On("UpdateWorkflowTemplate", mock.Anything, mock.Anything).Return(&wfv1.WorkflowTemplate{ObjectMeta: metav1.ObjectMeta{ResourceVersion: "101"}}, nil)
+			},
+			validate: func(t *testing.T, output *UpdateWorkflowTemplateOutput, _ *mcp.CallToolResult) {
+				// The manifest drops the team label
+				assert.True(t, output.Updated)
+				assert.Contains(t, output.Diff, "-    team: data")
+			},
+		},
+		{
+			name: "unchanged template is not updated",
+			input: UpdateWorkflowTemplateInput{
+				Name:  "hello-world-template",
+				Patch: `{"spec":{"entrypoint":"whalesay"}}`,
+			},
+			setupMock: func(m *mocks.MockWorkflowTemplateServiceClient) {
+				m.On("GetWorkflowTemplate", mock.Anything, mock.Anything).Return(liveWorkflowTemplate(), nil)
+			},
+			validate: func(t *testing.T, output *UpdateWorkflowTemplateOutput, _ *mcp.CallToolResult) {
+				assert.False(t, output.Changed)
+				assert.False(t, output.Updated)
+				assert.Empty(t, output.Diff)
+			},
+		},
+		{
+			name: "stale resourceVersion is rejected",
+			input: UpdateWorkflowTemplateInput{
+				Name:            "hello-world-template",
+				Patch:           `{"spec":{"entrypoint":"main"}}`,
+				ResourceVersion: "99",
+			},
+			setupMock: func(m *mocks.MockWorkflowTemplateServiceClient) {
+				m.On("GetWorkflowTemplate", mock.Anything, mock.Anything).Return(liveWorkflowTemplate(), nil)
+			},
+			wantErr: "has been modified since it was read",
+		},
+		{
+			name: "manifest without resourceVersion is rejected",
+			input: UpdateWorkflowTemplateInput{
+				Manifest: manifest,
+			},
+			setupMock: func(_ *mocks.MockWorkflowTemplateServiceClient) {},
+			wantErr:   "resourceVersion is required",
+		},
+		{
+			name: "lint failure prevents update",
+			input: UpdateWorkflowTemplateInput{
+				Name:  "hello-world-template",
+				Patch: `{"spec":{"entrypoint":"missing"}}`,
+			},
+			setupMock: func(m *mocks.MockWorkflowTemplateServiceClient) {
+				m.On("GetWorkflowTemplate", mock.Anything, mock.Anything).Return(liveWorkflowTemplate(), nil)
+				m.On("LintWorkflowTemplate", mock.Anything, mock.Anything).Return(nil, errors.New("entrypoint template missing not found"))
+			},
+			wantErr: "failed lint, no changes were applied",
+		},
+		{
+			name: "concurrent update is reported as conflict",
+			input: UpdateWorkflowTemplateInput{
+				Name:  "hello-world-template",
+				Patch: `{"spec":{"entrypoint":"main"}}`,
+			},
+			setupMock: func(m *mocks.MockWorkflowTemplateServiceClient) {
+				m.On("GetWorkflowTemplate", mock.Anything, mock.Anything).Return(liveWorkflowTemplate(), nil)
+				m.On("LintWorkflowTemplate", mock.Anything, mock.Anything).Return(&wfv1.WorkflowTemplate{}, nil)
+				m.On("UpdateWorkflowTemplate", mock.Anything, mock.Anything).Return(nil, status.Error(codes.AlreadyExists, "the object has been modified"))
+			},
+			wantErr: "modified concurrently",
+		},
+		{
+			name: "patch cannot rename",
+			input: UpdateWorkflowTemplateInput{
+				Name:  "hello-world-template",
+				Patch: `{"metadata":{"name":"other"}}`,
+			},
+			setupMock: func(m *mocks.MockWorkflowTemplateServiceClient) {
+				m.On("GetWorkflowTemplate", mock.Anything, mock.Anything).Return(liveWorkflowTemplate(), nil)
+			},
+			wantErr: "cannot change the name",
+		},
+		{
+			name:      "manifest and patch are exclusive",
+			input:     UpdateWorkflowTemplateInput{Manifest: manifest, Patch: "{}"},
+			setupMock: func(_ *mocks.MockWorkflowTemplateServiceClient) {},
+			wantErr:   "either manifest or patch",
+		},
+		{
+			name:      "patch requires name",
+			input:     UpdateWorkflowTemplateInput{Patch: "{}"},
+			setupMock: func(_ *mocks.MockWorkflowTemplateServiceClient) {},
+			wantErr:   "name cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockClient := newMockClient(t, "default", true)
+			mockService := newMockWorkflowTemplateService(t)
+			mockClient.SetWorkflowTemplateService(mockService)
+			tt.setupMock(mockService)
+			defer mockService.AssertExpectations(t)
+
+			handler := UpdateWorkflowTemplateHandler(mockClient)
+			result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, tt.input)
+
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, output)
+			tt.validate(t, output, result)
+		})
+	}
+}
@@ -16,6 +16,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
 )
 
 // WaitWorkflowInput defines the input parameters for the wait_workflow tool.
@@ -85,10 +86,10 @@ func WaitWorkflowHandler(client argo.ClientInterface) func(context.Context, *mcp
 		if input.Timeout != "" {
 			timeout, err = time.ParseDuration(input.Timeout)
 			if err != nil {
-				return nil, nil, fmt.Errorf("invalid timeout format: %w", err)
+				return nil, nil, toolerror.Invalid("timeout", fmt.Errorf("invalid timeout format: %w", err))
 			}
 			if timeout <= 0 {
-				return nil, nil, fmt.Errorf("invalid timeout: must be a positive duration")
+				return nil, nil, toolerror.Invalid("timeout", errors.New("invalid timeout: must be a positive duration"))
 			}
 		}
 
@@ -105,53 +106,10 @@ func WaitWorkflowHandler(client argo.ClientInterface) func(context.Context, *mcp
 		}
 		defer cancel()
 
-		// Build the request with field selector to watch specific workflow
-		req := &workflow.WatchWorkflowsRequest{
-			Namespace: namespace,
-			ListOptions: &metav1.ListOptions{
-				FieldSelector: fmt.Sprintf("metadata.name=%s", workflowName),
-			},
-		}
-
-		// Get the workflow service client
-		wfService := client.WorkflowService()
-
-		// Start watching (we use the watch API but only care about the final state)
-		stream, err := wfService.WatchWorkflows(waitCtx, req)
-		if err != nil {
-			return nil, nil, fmt.Errorf("failed to wait for workflow: %w", err)
-		}
-
 		// Wait until completion, tracking only the last workflow state
-		var lastWorkflow *wfv1.Workflow
-		timedOut := false
-
-		for {
-			event, recvErr := stream.Recv()
-			if errors.Is(recvErr, io.EOF) {
-				break
-			}
-			if recvErr != nil {
-				// Check if it was a timeout (handle both context and gRPC status)
-				if errors.Is(recvErr, context.DeadlineExceeded) ||
-					errors.Is(waitCtx.Err(), context.DeadlineExceeded) ||
-					status.Code(recvErr) == codes.DeadlineExceeded {
-					timedOut = true
-					break
-				}
-				return nil, nil, fmt.Errorf("failed to receive workflow event: %w", recvErr)
-			}
-
-			if event.Object == nil {
-				continue
-			}
-
-			lastWorkflow = event.Object
-
-			// Check if workflow has completed
-			if isWorkflowCompleted(event.Object.Status.Phase) {
-				break
-			}
+		lastWorkflow, timedOut, err := waitForWorkflow(waitCtx, client.WorkflowService(), namespace, workflowName)
+		if err != nil {
+			return nil, nil, err
 		}
 
 		// Build the output
@@ -214,3 +172,50 @@ func WaitWorkflowHandler(client argo.ClientInterface) func(context.Context, *mcp
 		return TextResult(resultText), output, nil
 	}
 }
+
+// waitForWorkflow watches a workflow until it completes and returns its last
+// observed state. A deadline on ctx is reported as a timeout rather than an
+// error, together with the state observed so far.
+func waitForWorkflow(ctx context.Context, wfService workflow.WorkflowServiceClient, namespace, name string) (*wfv1.Workflow, bool, error) {
+	// Build the request with field selector to watch specific workflow
+	req := &workflow.WatchWorkflowsRequest{
+		Namespace: namespace,
+		ListOptions: &metav1.ListOptions{
+			FieldSelector: fmt.Sprintf("metadata.name=%s", name),
+		},
+	}
+
+	// Start watching (we use the watch API but only care about the final state)
+	stream, err := wfService.WatchWorkflows(ctx, req)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to wait for workflow: %w", err)
+	}
+
+	var lastWorkflow *wfv1.Workflow
+	for {
+		event, recvErr := stream.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			return lastWorkflow, false, nil
+		}
+		if recvErr != nil {
+			// Check if it was a timeout (handle both context and gRPC status)
+			if errors.Is(recvErr, context.DeadlineExceeded) ||
+				errors.Is(ctx.Err(), context.DeadlineExceeded) ||
+				status.Code(recvErr) == codes.DeadlineExceeded {
+				return lastWorkflow, true, nil
+			}
+			return nil, false, fmt.Errorf("failed to receive workflow event: %w", recvErr)
+		}
+
+		if event.Object == nil {
+			continue
+		}
+
+		lastWorkflow = event.Object
+
+		// Check if workflow has completed
+		if isWorkflowCompleted(event.Object.Status.Phase) {
+			return lastWorkflow, false, nil
+		}
+	}
+}
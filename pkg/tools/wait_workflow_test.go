@@ -17,6 +17,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/mocks"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
 )
 
 func TestWaitWorkflowTool(t *testing.T) {
@@ -120,6 +121,9 @@ func TestWaitWorkflowHandler_TimeoutValidation(t *testing.T) {
 			if tt.wantErr {
 				require.Error(t, err)
 				assert.Contains(t, err.Error(), tt.errContains)
+				classified := toolerror.Classify(err)
+				assert.Equal(t, toolerror.CategoryInvalid, classified.Category)
+				assert.Equal(t, "timeout", classified.Field)
 			}
 		})
 	}
@@ -16,6 +16,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/toolerror"
 )
 
 // WatchWorkflowInput defines the input parameters for the watch_workflow tool.
@@ -103,10 +104,10 @@ func WatchWorkflowHandler(client argo.ClientInterface) func(context.Context, *mc
 		if input.Timeout != "" {
 			timeout, err = time.ParseDuration(input.Timeout)
 			if err != nil {
-				return nil, nil, fmt.Errorf("invalid timeout format: %w", err)
+				return nil, nil, toolerror.Invalid("timeout", fmt.Errorf("invalid timeout format: %w", err))
 			}
 			if timeout <= 0 {
-				return nil, nil, fmt.Errorf("invalid timeout: must be a positive duration")
+				return nil, nil, toolerror.Invalid("timeout", errors.New("invalid timeout: must be a positive duration"))
 			}
 		}
 
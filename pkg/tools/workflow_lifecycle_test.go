@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"testing"
+
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo/fake"
+)
+
+const lifecycleManifest = `apiVersion: argoproj.io/v1alpha1
+kind: Workflow
+metadata:
+  generateName: lifecycle-
+spec:
+  entrypoint: main
+  templates:
+  - name: main
+    container:
+      image: alpine
+`
+
+// TestWorkflowLifecycle drives submit, wait, retry and resubmit against the
+// fake backend, checking that the handlers agree on workflow state across calls.
+func TestWorkflowLifecycle(t *testing.T) {
+	client := fake.NewClient(t.Context(), "argo")
+	client.SetDefaultScript(fake.Step{Phase: wfv1.WorkflowRunning}, fake.Step{Phase: wfv1.WorkflowFailed, Message: "exit code 1"})
+
+	_, submitted, err := SubmitWorkflowHandler(client)(t.Context(), nil, SubmitWorkflowInput{Manifest: lifecycleManifest})
+	require.NoError(t, err)
+	assert.Equal(t, "argo", submitted.Namespace)
+
+	// Wait follows the watch stream while the timeline advances
+	type waitResult struct {
+		output *WaitWorkflowOutput
+		err    error
+	}
+	done := make(chan waitResult)
+	go func() {
+		_, output, waitErr := WaitWorkflowHandler(client)(t.Context(), nil, WaitWorkflowInput{Name: submitted.Name})
+		done <- waitResult{output: output, err: waitErr}
+	}()
+	client.Settle()
+	waited := <-done
+	require.NoError(t, waited.err)
+	assert.Equal(t, "Failed", waited.output.Phase)
+	assert.Equal(t, "exit code 1", waited.output.Message)
+
+	client.SetDefaultScript(fake.DefaultScript()...)
+	_, retried, err := RetryWorkflowHandler(client)(t.Context(), nil, RetryWorkflowInput{Name: submitted.Name})
+	require.NoError(t, err)
+	assert.Equal(t, "Running", retried.Phase)
+	client.Settle()
+	_, waitedAgain, err := WaitWorkflowHandler(client)(t.Context(), nil, WaitWorkflowInput{Name: submitted.Name})
+	require.NoError(t, err)
+	assert.Equal(t, "Succeeded", waitedAgain.Phase)
+
+	_, resubmitted, err := ResubmitWorkflowHandler(client)(t.Context(), nil, ResubmitWorkflowInput{Name: submitted.Name})
+	require.NoError(t, err)
+	assert.NotEqual(t, submitted.Name, resubmitted.Name)
+	client.Settle()
+	_, waitedResubmitted, err := WaitWorkflowHandler(client)(t.Context(), nil, WaitWorkflowInput{Name: resubmitted.Name})
+	require.NoError(t, err)
+	assert.Equal(t, "Succeeded", waitedResubmitted.Phase)
+}
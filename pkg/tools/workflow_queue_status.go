@@ -0,0 +1,367 @@
+// Package tools implements MCP tool handlers for Argo Workflows operations.
+package tools
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/argoproj/argo-workflows/v4/workflow/common"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/pipekit/mcp-for-argo-workflows/pkg/argo"
+)
+
+const (
+	// LimitSourceConfigMap is a limit read from the workflow controller configmap.
+	LimitSourceConfigMap = "configmap"
+
+	// LimitSourceNamespaceLabel is a limit read from the parallelism-limit label of a namespace.
+	LimitSourceNamespaceLabel = "namespace-label"
+
+	// throttledMessage is the message the controller sets on workflows held back by parallelism.
+	throttledMessage = "too many workflows are already running"
+)
+
+// WorkflowQueueStatusInput defines the input parameters for the workflow_queue_status tool.
+type WorkflowQueueStatusInput struct {
+	// Namespace is the Kubernetes namespace; empty means all namespaces.
+	Namespace *string `json:"namespace,omitempty" jsonschema:"Kubernetes namespace (uses default if not specified. use empty string for all namespaces)"`
+}
+
+// ParallelismLimits are the controller-wide parallelism settings.
+type ParallelismLimits struct {
+	// Controller caps running workflows across all namespaces; 0 means unlimited.
+	Controller *int `json:"controller,omitempty"`
+
+	// NamespaceDefault caps running workflows per namespace unless a namespace overrides it; 0 means unlimited.
+	NamespaceDefault *int `json:"namespaceDefault,omitempty"`
+
+	// ControllerRunning is the number of running workflows across all namespaces.
+	ControllerRunning *int `json:"controllerRunning,omitempty"`
+
+	// Source is where the limits were read from, if they could be read.
+	Source string `json:"source,omitempty"`
+}
+
+// NamespaceQueue is the running and pending workflow count of a namespace.
+type NamespaceQueue struct {
+	// Limit is the effective parallelism limit of the namespace, when known; 0 means unlimited.
+	Limit *int `json:"limit,omitempty"`
+
+	// Namespace is the namespace name.
+	Namespace string `json:"namespace"`
+
+	// LimitSource is where Limit comes from.
+	LimitSource string `json:"limitSource,omitempty"`
+
+	// Running is the number of running workflows.
+	Running int `json:"running"`
+
+	// Pending is the number of pending workflows.
+	Pending int `json:"pending"`
+}
+
+// QueuedWorkflow is a pending workflow and why it is pending.
+type QueuedWorkflow struct {
+	// Priority is the workflow priority; higher priorities are started first.
+	Priority *int32 `json:"priority,omitempty"`
+
+	// Namespace is the namespace of the workflow.
+	Namespace string `json:"namespace"`
+
+	// Name is the workflow name.
+	Name string `json:"name"`
+
+	// CreatedAt is when the workflow was created, in RFC3339 format.
+	CreatedAt string `json:"createdAt,omitempty"`
+
+	// Reason explains why the workflow is pending.
+	Reason string `json:"reason"`
+
+	// Message is the workflow status message, if any.
+	Message string `json:"message,omitempty"`
+
+	// WaitingOn lists the semaphores and mutexes the workflow is queued for.
+	WaitingOn []SyncWait `json:"waitingOn,omitempty"`
+
+	// WaitingSeconds is how long the workflow has existed.
+	WaitingSeconds int64 `json:"waitingSeconds"`
+
+	// Position is the position in the queue, starting at 1.
+	Position int `json:"position"`
+}
+
+// WorkflowQueueStatusOutput defines the output for the workflow_queue_status tool.
+type WorkflowQueueStatusOutput struct {
+	// Limits are the controller-wide parallelism settings.
+	Limits ParallelismLimits `json:"limits"`
+
+	// Namespaces lists the running and pending counts per namespace.
+	Namespaces []NamespaceQueue `json:"namespaces"`
+
+	// Pending lists the pending workflows, ordered by priority then creation time.
+	Pending []QueuedWorkflow `json:"pending"`
+
+	// Warnings lists details that could not be determined.
+	Warnings []string `json:"warnings,omitempty"`
+
+	// Running is the number of running workflows in scope.
+	Running int `json:"running"`
+}
+
+// WorkflowQueueStatusTool returns the MCP tool definition for workflow_queue_status.
+func WorkflowQueueStatusTool() *mcp.Tool {
+	return &mcp.Tool{
+		Name: "workflow_queue_status",
+		Description: "Explain why workflows are queued: controller and namespace parallelism limits, running workflows per namespace, " +
+			"and pending workflows in priority and creation order with the reason each one is pending",
+		Annotations: &mcp.ToolAnnotations{
+			ReadOnlyHint: true,
+		},
+	}
+}
+
+// WorkflowQueueStatusHandler returns a handler function for the workflow_queue_status tool.
+func WorkflowQueueStatusHandler(client argo.ClientInterface) func(context.Context, *mcp.CallToolRequest, WorkflowQueueStatusInput) (*mcp.CallToolResult, *WorkflowQueueStatusOutput, error) {
+	return func(ctx context.Context, _ *mcp.CallToolRequest, input WorkflowQueueStatusInput) (*mcp.CallToolResult, *WorkflowQueueStatusOutput, error) {
+		namespace := client.DefaultNamespace()
+		if input.Namespace != nil {
+			namespace = strings.TrimSpace(*input.Namespace)
+		}
+
+		wfService := client.WorkflowService()
+		listResp, err := wfService.ListWorkflows(ctx, &workflow.WorkflowListRequest{
+			Namespace:   namespace,
+			ListOptions: &metav1.ListOptions{LabelSelector: common.LabelKeyCompleted + "!=true"},
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to list workflows: %w", err)
+		}
+
+		output := &WorkflowQueueStatusOutput{Namespaces: []NamespaceQueue{}, Pending: []QueuedWorkflow{}}
+		output.Limits, output.Warnings = parallelismLimits(ctx, client)
+
+		now := time.Now()
+		queues := map[string]*NamespaceQueue{}
+		queueFor := func(ns string) *NamespaceQueue {
+			queue, ok := queues[ns]
+			if !ok {
+				queue = &NamespaceQueue{Namespace: ns}
+				queues[ns] = queue
+			}
+			return queue
+		}
+		var pending []*wfv1.Workflow
+		for i := range listResp.Items {
+			wf := &listResp.Items[i]
+			switch wf.Status.Phase {
+			case wfv1.WorkflowRunning:
+				queueFor(wf.Namespace).Running++
+				output.Running++
+			case wfv1.WorkflowUnknown, wfv1.WorkflowPending:
+				queueFor(wf.Namespace).Pending++
+				pending = append(pending, wf)
+			}
+		}
+
+		if namespace == "" {
+			output.Limits.ControllerRunning = ptr.To(output.Running)
+		} else if running, countErr := countRunningWorkflows(ctx, wfService); countErr != nil {
+			output.Warnings = append(output.Warnings, fmt.Sprintf("failed to count running workflows across namespaces: %v", countErr))
+		} else {
+			output.Limits.ControllerRunning = ptr.To(running)
+		}
+
+		if namespace != "" {
+			queueFor(namespace)
+		}
+		for _, ns := range slices.Sorted(maps.Keys(queues)) {
+			queue := queues[ns]
+			output.Warnings = append(output.Warnings, applyNamespaceLimit(ctx, client, output.Limits, queue)...)
+			output.Namespaces = append(output.Namespaces, *queue)
+		}
+
+		slices.SortStableFunc(pending, func(a, b *wfv1.Workflow) int {
+			if c := cmp.Compare(ptr.Deref(b.Spec.Priority, 0), ptr.Deref(a.Spec.Priority, 0)); c != 0 {
+				return c
+			}
+			if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+				return c
+			}
+			return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+		})
+		for i, wf := range pending {
+			queued := QueuedWorkflow{
+				Priority:  wf.Spec.Priority,
+				Namespace: wf.Namespace,
+				Name:      wf.Name,
+				Message:   wf.Status.Message,
+				WaitingOn: syncWaits(wf, now),
+				Position:  i + 1,
+			}
+			if !wf.CreationTimestamp.IsZero() {
+				queued.CreatedAt = wf.CreationTimestamp.Format(time.RFC3339)
+				queued.WaitingSeconds = int64(now.Sub(wf.CreationTimestamp.Time).Seconds())
+			}
+			queued.Reason = pendingReason(wf, &queued, output.Limits, queues[wf.Namespace])
+			output.Pending = append(output.Pending, queued)
+		}
+
+		return TextResult(formatWorkflowQueueStatus(output, namespace)), output, nil
+	}
+}
+
+// parallelismLimits reads the controller parallelism settings. Only the
+// workflow controller configmap has them, so they are unknown in Argo Server
+// mode.
+func parallelismLimits(ctx context.Context, client argo.ClientInterface) (ParallelismLimits, []string) {
+	if client.IsArgoServerMode() {
+		return ParallelismLimits{}, []string{"the Argo Server API does not expose parallelism limits; " +
+			"they are only available with direct Kubernetes API access to the workflow controller configmap"}
+	}
+
+	cfg, err := argo.GetControllerConfig(ctx, client)
+	if err != nil {
+		return ParallelismLimits{}, []string{fmt.Sprintf("failed to read parallelism limits: %v", err)}
+	}
+	return ParallelismLimits{
+		Controller:       ptr.To(cfg.Parallelism),
+		NamespaceDefault: ptr.To(cfg.NamespaceParallelism),
+		Source:           LimitSourceConfigMap,
+	}, nil
+}
+
+// countRunningWorkflows counts running workflows across all namespaces, which
+// is what the controller-wide limit applies to.
+func countRunningWorkflows(ctx context.Context, wfService workflow.WorkflowServiceClient) (int, error) {
+	list, err := wfService.ListWorkflows(ctx, &workflow.WorkflowListRequest{
+		ListOptions: &metav1.ListOptions{LabelSelector: common.LabelKeyPhase + "=" + string(wfv1.WorkflowRunning)},
+		Fields:      "items.metadata.name",
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(list.Items), nil
+}
+
+// applyNamespaceLimit sets the effective limit of a namespace: its
+// parallelism-limit label when present, else the configmap default.
+func applyNamespaceLimit(ctx context.Context, client argo.ClientInterface, limits ParallelismLimits, queue *NamespaceQueue) []string {
+	if limits.NamespaceDefault != nil {
+		queue.Limit = limits.NamespaceDefault
+		queue.LimitSource = LimitSourceConfigMap
+	}
+	if client.IsArgoServerMode() {
+		return nil
+	}
+
+	kubeClient, err := client.KubernetesClient()
+	if err != nil {
+		return []string{fmt.Sprintf("failed to read parallelism limit of namespace %s: %v", queue.Namespace, err)}
+	}
+	ns, err := kubeClient.CoreV1().Namespaces().Get(ctx, queue.Namespace, metav1.GetOptions{})
+	if err != nil {
+		return []string{fmt.Sprintf("failed to read parallelism limit of namespace %s: %v", queue.Namespace, err)}
+	}
+	label, ok := ns.Labels[common.LabelParallelismLimit]
+	if !ok {
+		return nil
+	}
+	limit, err := strconv.Atoi(label)
+	if err != nil {
+		return []string{fmt.Sprintf("namespace %s has an invalid %s label %q", queue.Namespace, common.LabelParallelismLimit, label)}
+	}
+	queue.Limit = ptr.To(limit)
+	queue.LimitSource = LimitSourceNamespaceLabel
+	return nil
+}
+
+// pendingReason explains why a workflow has not started.
+func pendingReason(wf *wfv1.Workflow, queued *QueuedWorkflow, limits ParallelismLimits, queue *NamespaceQueue) string {
+	if len(queued.WaitingOn) > 0 {
+		wait := queued.WaitingOn[0]
+		return fmt.Sprintf("waiting for %s %s", wait.Type, wait.Lock)
+	}
+
+	if strings.Contains(wf.Status.Message, throttledMessage) {
+		controller, running := ptr.Deref(limits.Controller, 0), limits.ControllerRunning
+		if controller > 0 && running != nil && *running >= controller {
+			return fmt.Sprintf("controller parallelism limit reached (%d/%d running)", *running, controller)
+		}
+		if queue != nil && ptr.Deref(queue.Limit, 0) > 0 && queue.Running >= *queue.Limit {
+			return fmt.Sprintf("namespace parallelism limit reached (%d/%d running)", queue.Running, *queue.Limit)
+		}
+		return "held back by a parallelism limit"
+	}
+
+	if wf.Status.Phase == wfv1.WorkflowUnknown {
+		return "not yet picked up by the workflow controller"
+	}
+	if wf.Status.Message != "" {
+		return wf.Status.Message
+	}
+	return "pending"
+}
+
+// formatWorkflowQueueStatus renders the queue status as text.
+func formatWorkflowQueueStatus(output *WorkflowQueueStatusOutput, namespace string) string {
+	var text strings.Builder
+	scope := fmt.Sprintf("in namespace %q", namespace)
+	if namespace == "" {
+		scope = "across all namespaces"
+	}
+	fmt.Fprintf(&text, "%d running and %d pending workflow(s) %s", output.Running, len(output.Pending), scope)
+
+	if limits := output.Limits; limits.Source != "" {
+		fmt.Fprintf(&text, "\nController parallelism: %s", formatLimit(limits.Controller))
+		if limits.ControllerRunning != nil {
+			fmt.Fprintf(&text, " (%d running)", *limits.ControllerRunning)
+		}
+		fmt.Fprintf(&text, ", namespace default: %s", formatLimit(limits.NamespaceDefault))
+	}
+
+	for _, queue := range output.Namespaces {
+		fmt.Fprintf(&text, "\n- %s: %d running, %d pending", queue.Namespace, queue.Running, queue.Pending)
+		if queue.Limit != nil {
+			fmt.Fprintf(&text, ", limit %s (%s)", formatLimit(queue.Limit), queue.LimitSource)
+		}
+	}
+
+	if len(output.Pending) > 0 {
+		text.WriteString("\nPending workflows:")
+		for _, queued := range output.Pending {
+			fmt.Fprintf(&text, "\n  #%d %s/%s", queued.Position, queued.Namespace, queued.Name)
+			if queued.Priority != nil {
+				fmt.Fprintf(&text, " (priority %d)", *queued.Priority)
+			}
+			fmt.Fprintf(&text, " waiting %s: %s", (time.Duration(queued.WaitingSeconds) * time.Second).String(), queued.Reason)
+		}
+	}
+
+	for _, warning := range output.Warnings {
+		fmt.Fprintf(&text, "\nWarning: %s", warning)
+	}
+	return text.String()
+}
+
+// formatLimit renders a parallelism limit, where 0 means unlimited.
+func formatLimit(limit *int) string {
+	switch {
+	case limit == nil:
+		return "unknown"
+	case *limit == 0:
+		return "unlimited"
+	default:
+		return strconv.Itoa(*limit)
+	}
+}
@@ -0,0 +1,189 @@
+package tools
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/argoproj/argo-workflows/v4/pkg/apiclient/workflow"
+	wfv1 "github.com/argoproj/argo-workflows/v4/pkg/apis/workflow/v1alpha1"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/utils/ptr"
+)
+
+// queuedWorkflow is a workflow in the given phase created age ago.
+func queuedWorkflow(namespace, name string, phase wfv1.WorkflowPhase, age time.Duration, priority *int32, message string) wfv1.Workflow {
+	return wfv1.Workflow{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, CreationTimestamp: metav1.NewTime(time.Now().Add(-age))},
+		Spec:       wfv1.WorkflowSpec{Priority: priority},
+		Status:     wfv1.WorkflowStatus{Phase: phase, Message: message},
+	}
+}
+
+// controllerConfigMap is a workflow controller configmap with the given parallelism settings.
+func controllerConfigMap(parallelism, namespaceParallelism string) *corev1.ConfigMap {
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "workflow-controller-configmap", Namespace: "argo"},
+		Data:       map[string]string{"parallelism": parallelism, "namespaceParallelism": namespaceParallelism},
+	}
+}
+
+func TestWorkflowQueueStatusTool(t *testing.T) {
+	tool := WorkflowQueueStatusTool()
+
+	assert.Equal(t, "workflow_queue_status", tool.Name)
+	assert.NotEmpty(t, tool.Description)
+	require.NotNil(t, tool.Annotations)
+	assert.True(t, tool.Annotations.ReadOnlyHint)
+}
+
+func TestWorkflowQueueStatusHandler(t *testing.T) {
+	const throttled = "Workflow processing has been postponed because too many workflows are already running"
+
+	t.Run("direct mode reads limits and orders the queue", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", false)
+		wfService := newMockWorkflowService(t)
+		mockClient.SetWorkflowService(wfService)
+		mockClient.SetKubernetesClient(fake.NewClientset(
+			controllerConfigMap("3", "2"),
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "argo"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team", Labels: map[string]string{
+				"workflows.argoproj.io/parallelism-limit": "1",
+			}}},
+		))
+
+		locked := queuedWorkflow("argo", "locked", wfv1.WorkflowPending, time.Hour, nil, "Waiting for argo/Mutex/release lock")
+		locked.Status.Synchronization = &wfv1.SynchronizationStatus{
+			Mutex: &wfv1.MutexStatus{Waiting: []wfv1.MutexHolding{{Mutex: "argo/Mutex/release", Holder: "argo/other"}}},
+		}
+		wfService.On("ListWorkflows", mock.Anything, &workflow.WorkflowListRequest{
+			ListOptions: &metav1.ListOptions{LabelSelector: "workflows.argoproj.io/completed!=true"},
+		}).Return(&wfv1.WorkflowList{Items: wfv1.Workflows{
+			queuedWorkflow("argo", "run-1", wfv1.WorkflowRunning, time.Hour, nil, ""),
+			queuedWorkflow("argo", "run-2", wfv1.WorkflowRunning, time.Hour, nil, ""),
+			queuedWorkflow("team", "run-3", wfv1.WorkflowRunning, time.Hour, nil, ""),
+			queuedWorkflow("argo", "old", wfv1.WorkflowPending, 2*time.Hour, nil, throttled),
+			queuedWorkflow("team", "urgent", wfv1.WorkflowPending, time.Minute, ptr.To[int32](5), throttled),
+			queuedWorkflow("argo", "new", wfv1.WorkflowUnknown, time.Second, nil, ""),
+			locked,
+		}}, nil)
+		defer wfService.AssertExpectations(t)
+
+		handler := WorkflowQueueStatusHandler(mockClient)
+		result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, WorkflowQueueStatusInput{Namespace: ptr.To("")})
+		require.NoError(t, err)
+
+		assert.Empty(t, output.Warnings)
+		assert.Equal(t, ptr.To(3), output.Limits.Controller)
+		assert.Equal(t, ptr.To(2), output.Limits.NamespaceDefault)
+		assert.Equal(t, ptr.To(3), output.Limits.ControllerRunning)
+		assert.Equal(t, LimitSourceConfigMap, output.Limits.Source)
+		assert.Equal(t, 3, output.Running)
+
+		require.Len(t, output.Namespaces, 2)
+		assert.Equal(t, NamespaceQueue{Namespace: "argo", Running: 2, Pending: 3, Limit: ptr.To(2), LimitSource: LimitSourceConfigMap}, output.Namespaces[0])
+		assert.Equal(t, NamespaceQueue{Namespace: "team", Running: 1, Pending: 1, Limit: ptr.To(1), LimitSource: LimitSourceNamespaceLabel}, output.Namespaces[1])
+
+		require.Len(t, output.Pending, 4)
+		assert.Equal(t, "urgent", output.Pending[0].Name)
+		assert.Equal(t, "controller parallelism limit reached (3/3 running)", output.Pending[0].Reason)
+		assert.Equal(t, "old", output.Pending[1].Name)
+		assert.Equal(t, 2, output.Pending[1].Position)
+		assert.Equal(t, "locked", output.Pending[2].Name)
+		assert.Equal(t, "waiting for mutex argo/Mutex/release", output.Pending[2].Reason)
+		require.Len(t, output.Pending[2].WaitingOn, 1)
+		assert.Equal(t, "new", output.Pending[3].Name)
+		assert.Equal(t, "not yet picked up by the workflow controller", output.Pending[3].Reason)
+
+		text := result.Content[0].(*mcp.TextContent).Text
+		assert.Contains(t, text, "3 running and 4 pending workflow(s) across all namespaces")
+		assert.Contains(t, text, "Controller parallelism: 3 (3 running), namespace default: 2")
+		assert.Contains(t, text, "- team: 1 running, 1 pending, limit 1 (namespace-label)")
+		assert.Contains(t, text, "#1 team/urgent (priority 5)")
+	})
+
+	t.Run("namespace limit explains throttling below the controller limit", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", false)
+		wfService := newMockWorkflowService(t)
+		mockClient.SetWorkflowService(wfService)
+		mockClient.SetKubernetesClient(fake.NewClientset(
+			controllerConfigMap("0", "1"),
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "argo"}},
+		))
+
+		wfService.On("ListWorkflows", mock.Anything, &workflow.WorkflowListRequest{
+			Namespace:   "argo",
+			ListOptions: &metav1.ListOptions{LabelSelector: "workflows.argoproj.io/completed!=true"},
+		}).Return(&wfv1.WorkflowList{Items: wfv1.Workflows{
+			queuedWorkflow("argo", "run", wfv1.WorkflowRunning, time.Hour, nil, ""),
+			queuedWorkflow("argo", "held", wfv1.WorkflowPending, time.Minute, nil, throttled),
+		}}, nil)
+		wfService.On("ListWorkflows", mock.Anything, &workflow.WorkflowListRequest{
+			ListOptions: &metav1.ListOptions{LabelSelector: "workflows.argoproj.io/phase=Running"},
+			Fields:      "items.metadata.name",
+		}).Return(&wfv1.WorkflowList{Items: wfv1.Workflows{{}, {}, {}}}, nil)
+		defer wfService.AssertExpectations(t)
+
+		handler := WorkflowQueueStatusHandler(mockClient)
+		result, output, err := handler(t.Context(), &mcp.CallToolRequest{}, WorkflowQueueStatusInput{})
+		require.NoError(t, err)
+
+		assert.Equal(t, ptr.To(3), output.Limits.ControllerRunning)
+		require.Len(t, output.Pending, 1)
+		assert.Equal(t, "namespace parallelism limit reached (1/1 running)", output.Pending[0].Reason)
+		assert.Contains(t, result.Content[0].(*mcp.TextContent).Text, "Controller parallelism: unlimited")
+	})
+
+	t.Run("argo server mode reports limits as unavailable", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", true)
+		wfService := newMockWorkflowService(t)
+		mockClient.SetWorkflowService(wfService)
+
+		wfService.On("ListWorkflows", mock.Anything, mock.MatchedBy(func(req *workflow.WorkflowListRequest) bool {
+			return req.Namespace == "argo"
+		})).Return(&wfv1.WorkflowList{Items: wfv1.Workflows{
+			queuedWorkflow("argo", "held", wfv1.WorkflowPending, time.Minute, nil, throttled),
+		}}, nil)
+		wfService.On("ListWorkflows", mock.Anything, mock.MatchedBy(func(req *workflow.WorkflowListRequest) bool {
+			return req.Namespace == ""
+		})).Return(nil, errors.New("forbidden"))
+
+		handler := WorkflowQueueStatusHandler(mockClient)
+		_, output, err := handler(t.Context(), &mcp.CallToolRequest{}, WorkflowQueueStatusInput{})
+		require.NoError(t, err)
+
+		assert.Empty(t, output.Limits.Source)
+		assert.Nil(t, output.Limits.Controller)
+		require.Len(t, output.Warnings, 2)
+		assert.Contains(t, output.Warnings[0], "does not expose parallelism limits")
+		assert.Contains(t, output.Warnings[1], "forbidden")
+		require.Len(t, output.Namespaces, 1)
+		assert.Nil(t, output.Namespaces[0].Limit)
+		assert.Equal(t, "held back by a parallelism limit", output.Pending[0].Reason)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		mockClient := newMockClient(t, "argo", true)
+		wfService := newMockWorkflowService(t)
+		mockClient.SetWorkflowService(wfService)
+
+		wfService.On("ListWorkflows", mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+		handler := WorkflowQueueStatusHandler(mockClient)
+		_, _, err := handler(t.Context(), &mcp.CallToolRequest{}, WorkflowQueueStatusInput{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list workflows")
+	})
+}
+
+func TestFormatLimit(t *testing.T) {
+	assert.Equal(t, "unknown", formatLimit(nil))
+	assert.Equal(t, "unlimited", formatLimit(ptr.To(0)))
+	assert.Equal(t, "5", formatLimit(ptr.To(5)))
+}
@@ -422,7 +422,8 @@ func TestRenderManifestGraph(t *testing.T) {
 		t.Skip("Skipping E2E test in short mode")
 	}
 
-	renderHandler := tools.RenderManifestGraphHandler()
+	cluster := SetupE2ECluster(context.Background(), t)
+	renderHandler := tools.RenderManifestGraphHandler(cluster.ArgoClient)
 
 	// Test with DAG workflow
 	t.Run("dag workflow", func(t *testing.T) {
@@ -538,7 +539,8 @@ func TestRenderManifestGraph_InvalidManifest(t *testing.T) {
 		t.Skip("Skipping E2E test in short mode")
 	}
 
-	renderHandler := tools.RenderManifestGraphHandler()
+	cluster := SetupE2ECluster(context.Background(), t)
+	renderHandler := tools.RenderManifestGraphHandler(cluster.ArgoClient)
 
 	t.Run("empty manifest", func(t *testing.T) {
 		input := tools.RenderManifestGraphInput{
@@ -583,7 +585,8 @@ func TestRenderManifestGraph_ClusterWorkflowTemplate(t *testing.T) {
 		t.Skip("Skipping E2E test in short mode")
 	}
 
-	renderHandler := tools.RenderManifestGraphHandler()
+	cluster := SetupE2ECluster(context.Background(), t)
+	renderHandler := tools.RenderManifestGraphHandler(cluster.ArgoClient)
 
 	manifest := LoadTestDataFile(t, "cluster-workflow-template.yaml")
 